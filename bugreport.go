@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/inputlog"
+)
+
+// runBugReport bundles the latest input recording, the log and its backups, and
+// the config file into a tarball to attach to a bug report, as in
+// `go-solar-system bugreport -o report.tar.gz`. It returns the process exit code:
+// 1 if the tarball could not be written, 2 for bad usage.
+func runBugReport(args []string, logPath, configPath string, out io.Writer) int {
+	now := time.Now()
+	flags := flag.NewFlagSet("bugreport", flag.ContinueOnError)
+	flags.SetOutput(out)
+	output := flags.String("o", "bugreport-"+now.Format("20060102-150405")+".tar.gz", "tarball to write")
+	latest, _ := inputlog.Latest(inputlog.DefaultDir())
+	input := flags.String("input", latest, "input recording to include")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		fmt.Fprintln(out, "usage: go-solar-system bugreport [-o file] [-input recording]")
+		return 2
+	}
+
+	files := []string{}
+	if *input != "" {
+		files = append(files, *input)
+	}
+	files = append(files, logPath)
+	for i := 1; i <= constants.LogMaxBackups; i++ {
+		files = append(files, fmt.Sprintf("%s.%d", logPath, i))
+	}
+	files = append(files, configPath)
+
+	file, err := os.Create(*output)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	included, err := inputlog.WriteBugReport(file, inputlog.CurrentEnvironment(0, 0, now), files)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+
+	fmt.Fprintf(out, "Wrote %s with:\n  %s\n", *output, inputlog.EnvironmentFileName)
+	for _, path := range included {
+		fmt.Fprintf(out, "  %s\n", path)
+	}
+	if *input == "" {
+		fmt.Fprintln(out, "No input recording found - run with --record-input and reproduce the bug first")
+	}
+	fmt.Fprintln(out, "Check it holds nothing you would rather not share before attaching it")
+	return 0
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// builtinSystemFiles are example systems compiled into the binary, so an install
+// without a systems/ folder still has some to explore
+//
+//go:embed systems/alpha-centauri.json systems/kepler-90.json systems/proxima-centauri.json systems/trappist-1.json
+var builtinSystemFiles embed.FS
+
+// builtinSystems returns the embedded example systems, rooted at their folder
+func builtinSystems() fs.FS {
+	sub, err := fs.Sub(builtinSystemFiles, "systems")
+	if err != nil {
+		panic(err) // the folder is fixed at build time
+	}
+	return sub
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/furan917/go-solar-system/internal/systems"
+)
+
+// runConvert rewrites a system file in the format its output name asks for, as in
+// `go-solar-system convert big.json big.ssb`. It returns the process exit code: 1
+// if the conversion failed, 2 for bad usage.
+func runConvert(args []string, out io.Writer) int {
+	if len(args) != 2 {
+		fmt.Fprintln(out, "usage: go-solar-system convert <input> <output>")
+		return 2
+	}
+
+	manager := systems.NewSystemManager("")
+	if err := manager.ConvertSystemFile(args[0], args[1]); err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	fmt.Fprintf(out, "%s: written from %s\n", args[1], args[0])
+	return 0
+}
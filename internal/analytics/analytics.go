@@ -0,0 +1,161 @@
+// Package analytics records, when the user opts in, which systems and bodies are
+// looked at and for how long. Everything stays in a local file, one JSON event
+// per line, which Summarize turns into a report.
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+)
+
+// MinViewDuration is the shortest look at a body that counts as a view; anything
+// shorter is a body passed on the way to another
+const MinViewDuration = 2 * time.Second
+
+// IdleTimeout ends a visit when nobody has pressed a key or used the mouse for
+// this long, so an unattended screen does not count as being looked at. The time
+// since the last input is left out.
+const IdleTimeout = 2 * time.Minute
+
+// Kinds of event
+const (
+	KindVisit  = "visit"  // someone using the app, from their first input to their last
+	KindSystem = "system" // a system on screen
+	KindBody   = "body"   // a body selected
+)
+
+// Event is one line of the analytics file: a span of time spent on something
+type Event struct {
+	Kind    string    `json:"kind"`
+	System  string    `json:"system,omitempty"`
+	Body    string    `json:"body,omitempty"`
+	Start   time.Time `json:"start"`
+	Seconds float64   `json:"seconds"`
+}
+
+// span is something being looked at since start
+type span struct {
+	name  string
+	start time.Time
+}
+
+// Recorder appends events to the analytics file as what is on screen changes. It
+// is safe for concurrent use.
+type Recorder struct {
+	mu         sync.Mutex
+	file       *os.File
+	visit      time.Time // when the current visit began; zero while idle
+	lastActive time.Time
+	system     span
+	body       span
+	closed     bool
+}
+
+// PathFor returns where analytics are kept for the config file at configPath:
+// in the same directory
+func PathFor(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), constants.AnalyticsFileName)
+}
+
+// Open starts recording to the file at path, creating it and its directory if
+// needed. The first visit begins at now.
+func Open(path string, now time.Time) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create analytics directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open analytics file %s: %w", path, err)
+	}
+	return &Recorder{file: file, visit: now, lastActive: now}, nil
+}
+
+// Activity notes input from the user at now, beginning a visit if the app was idle
+func (r *Recorder) Activity(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.visit.IsZero() {
+		r.visit = now
+	}
+	r.lastActive = now
+}
+
+// Observe notes the system on screen and the body selected in it at now, ending
+// the spans of whatever was there before. Nothing is recorded while idle.
+func (r *Recorder) Observe(now time.Time, system, body string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || r.visit.IsZero() {
+		return
+	}
+	if now.Sub(r.lastActive) > IdleTimeout {
+		r.endVisit(r.lastActive)
+		return
+	}
+
+	if system != r.system.name {
+		r.endBody(now)
+		r.endSystem(now)
+		r.system = span{name: system, start: now}
+	}
+	if body != r.body.name {
+		r.endBody(now)
+		r.body = span{name: body, start: now}
+	}
+}
+
+// Close ends the visit under way, if any, and the file
+func (r *Recorder) Close(now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	if !r.visit.IsZero() {
+		if now.Sub(r.lastActive) > IdleTimeout {
+			now = r.lastActive
+		}
+		r.endVisit(now)
+	}
+	return r.file.Close()
+}
+
+// endVisit records the visit and the spans still open as ending at end
+func (r *Recorder) endVisit(end time.Time) {
+	r.endBody(end)
+	r.endSystem(end)
+	r.write(Event{Kind: KindVisit, Start: r.visit, Seconds: end.Sub(r.visit).Seconds()})
+	r.visit = time.Time{}
+}
+
+func (r *Recorder) endSystem(end time.Time) {
+	if r.system.name != "" && end.After(r.system.start) {
+		r.write(Event{Kind: KindSystem, System: r.system.name, Start: r.system.start, Seconds: end.Sub(r.system.start).Seconds()})
+	}
+	r.system = span{}
+}
+
+func (r *Recorder) endBody(end time.Time) {
+	if r.body.name != "" && end.Sub(r.body.start) >= MinViewDuration {
+		r.write(Event{Kind: KindBody, System: r.system.name, Body: r.body.name, Start: r.body.start, Seconds: end.Sub(r.body.start).Seconds()})
+	}
+	r.body = span{}
+}
+
+// write appends an event. Analytics are best effort: an event that cannot be
+// written is dropped rather than disturbing the app.
+func (r *Recorder) write(event Event) {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	_, _ = r.file.Write(append(line, '\n'))
+}
@@ -0,0 +1,161 @@
+package analytics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func at(seconds int) time.Time {
+	return start.Add(time.Duration(seconds) * time.Second)
+}
+
+func TestRecorderSummarize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analytics", "events.jsonl")
+	recorder, err := Open(path, at(0))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	recorder.Observe(at(0), "Solar System", "Sun")
+	recorder.Activity(at(30))
+	recorder.Observe(at(30), "Solar System", "Mercury") // passed on the way to Mars
+	recorder.Activity(at(31))
+	recorder.Observe(at(31), "Solar System", "Mars")
+	recorder.Activity(at(91))
+	recorder.Observe(at(91), "TRAPPIST-1", "TRAPPIST-1")
+	recorder.Activity(at(121))
+	recorder.Observe(at(121), "Solar System", "Mars")
+	recorder.Activity(at(151))
+	if err := recorder.Close(at(151)); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	recorder.Observe(at(200), "Solar System", "Venus") // after closing, ignored
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	summary, err := Summarize(file)
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+
+	if summary.Visits != 1 || summary.Time != 151*time.Second {
+		t.Errorf("visits = %d for %s, want 1 for 2m31s", summary.Visits, summary.Time)
+	}
+	wantSystems := []Tally{
+		{System: "Solar System", Views: 2, Time: 121 * time.Second},
+		{System: "TRAPPIST-1", Views: 1, Time: 30 * time.Second},
+	}
+	if len(summary.Systems) != len(wantSystems) {
+		t.Fatalf("systems = %+v, want %+v", summary.Systems, wantSystems)
+	}
+	for i, want := range wantSystems {
+		if summary.Systems[i] != want {
+			t.Errorf("systems[%d] = %+v, want %+v", i, summary.Systems[i], want)
+		}
+	}
+
+	wantBodies := []Tally{
+		{System: "Solar System", Body: "Mars", Views: 2, Time: 90 * time.Second},
+		{System: "Solar System", Body: "Sun", Views: 1, Time: 30 * time.Second},
+		{System: "TRAPPIST-1", Body: "TRAPPIST-1", Views: 1, Time: 30 * time.Second},
+	}
+	if len(summary.Bodies) != len(wantBodies) {
+		t.Fatalf("bodies = %+v, want %+v", summary.Bodies, wantBodies)
+	}
+	for i, want := range wantBodies {
+		if summary.Bodies[i] != want {
+			t.Errorf("bodies[%d] = %+v, want %+v", i, summary.Bodies[i], want)
+		}
+	}
+}
+
+func TestRecorderIdle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	recorder, err := Open(path, at(0))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	// Someone looks at Saturn for a minute and walks away
+	recorder.Observe(at(0), "Solar System", "Saturn")
+	recorder.Activity(at(60))
+	recorder.Observe(at(60), "Solar System", "Saturn")
+	recorder.Observe(at(600), "Solar System", "Saturn")
+	// The next visitor arrives an hour later and stays ten seconds
+	recorder.Activity(at(3600))
+	recorder.Observe(at(3600), "Solar System", "Saturn")
+	recorder.Activity(at(3610))
+	if err := recorder.Close(at(3610)); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	summary, err := Summarize(file)
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	if summary.Visits != 2 || summary.Time != 70*time.Second {
+		t.Errorf("visits = %d for %s, want 2 for 1m10s", summary.Visits, summary.Time)
+	}
+	want := Tally{System: "Solar System", Body: "Saturn", Views: 2, Time: 70 * time.Second}
+	if len(summary.Bodies) != 1 || summary.Bodies[0] != want {
+		t.Errorf("bodies = %+v, want [%+v]", summary.Bodies, want)
+	}
+}
+
+func TestSummarizeSkipsBrokenLines(t *testing.T) {
+	input := `{"kind":"visit","start":"2026-05-01T10:00:00Z","seconds":60}
+{"kind":"body","system":"Solar Sys` + "\n\n"
+
+	summary, err := Summarize(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	if summary.Visits != 1 || summary.Skipped != 1 {
+		t.Errorf("Summarize() = %d visits, %d skipped; want 1 and 1", summary.Visits, summary.Skipped)
+	}
+}
+
+func TestSummaryWrite(t *testing.T) {
+	summary := Summary{
+		Visits:  2,
+		Time:    10 * time.Minute,
+		Systems: []Tally{{System: "Solar System", Views: 2, Time: 10 * time.Minute}},
+		Bodies: []Tally{
+			{System: "Solar System", Body: "Saturn", Views: 3, Time: 3 * time.Minute},
+			{System: "Solar System", Body: "Mars", Views: 1, Time: time.Minute},
+		},
+	}
+
+	var out strings.Builder
+	summary.Write(&out, 1)
+	report := out.String()
+	for _, want := range []string{"2 visits, 10m00s in total", "Average visit 5m00s", "Saturn (Solar System)", "3 views, 1m00s each", "...and 1 more"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "Mars") {
+		t.Errorf("report lists more than the top body:\n%s", report)
+	}
+}
+
+func TestSummaryWriteEmpty(t *testing.T) {
+	var out strings.Builder
+	Summary{}.Write(&out, 10)
+	if got := out.String(); got != "No analytics recorded yet\n" {
+		t.Errorf("Write() = %q", got)
+	}
+}
@@ -0,0 +1,172 @@
+package analytics
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Tally is how often and how long one system or body was looked at
+type Tally struct {
+	System string
+	Body   string // empty for a system
+	Views  int
+	Time   time.Duration
+}
+
+// Summary totals an analytics file
+type Summary struct {
+	Visits  int
+	Time    time.Duration // spent on visits
+	First   time.Time
+	Last    time.Time
+	Systems []Tally // most time first
+	Bodies  []Tally // most time first
+	Skipped int     // lines that were not events
+}
+
+// Summarize reads analytics events, one per line, and totals them. Lines that are
+// not events, such as one cut short by a crash, are counted and skipped.
+func Summarize(r io.Reader) (Summary, error) {
+	var summary Summary
+	systems := map[string]*Tally{}
+	bodies := map[[2]string]*Tally{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			summary.Skipped++
+			continue
+		}
+		spent := time.Duration(event.Seconds * float64(time.Second))
+
+		switch event.Kind {
+		case KindVisit:
+			summary.Visits++
+			summary.Time += spent
+			if summary.First.IsZero() || event.Start.Before(summary.First) {
+				summary.First = event.Start
+			}
+			if end := event.Start.Add(spent); end.After(summary.Last) {
+				summary.Last = end
+			}
+		case KindSystem:
+			tally := systems[event.System]
+			if tally == nil {
+				tally = &Tally{System: event.System}
+				systems[event.System] = tally
+			}
+			tally.Views++
+			tally.Time += spent
+		case KindBody:
+			key := [2]string{event.System, event.Body}
+			tally := bodies[key]
+			if tally == nil {
+				tally = &Tally{System: event.System, Body: event.Body}
+				bodies[key] = tally
+			}
+			tally.Views++
+			tally.Time += spent
+		default:
+			summary.Skipped++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return summary, fmt.Errorf("failed to read analytics: %w", err)
+	}
+
+	for _, tally := range systems {
+		summary.Systems = append(summary.Systems, *tally)
+	}
+	for _, tally := range bodies {
+		summary.Bodies = append(summary.Bodies, *tally)
+	}
+	sortTallies(summary.Systems)
+	sortTallies(summary.Bodies)
+	return summary, nil
+}
+
+// sortTallies orders tallies by time spent, then views, then name
+func sortTallies(tallies []Tally) {
+	sort.Slice(tallies, func(i, j int) bool {
+		a, b := tallies[i], tallies[j]
+		if a.Time != b.Time {
+			return a.Time > b.Time
+		}
+		if a.Views != b.Views {
+			return a.Views > b.Views
+		}
+		return a.System+a.Body < b.System+b.Body
+	})
+}
+
+// Write prints the summary as a report, listing at most top systems and bodies
+func (s Summary) Write(w io.Writer, top int) {
+	if s.Visits == 0 && len(s.Systems) == 0 {
+		fmt.Fprintln(w, "No analytics recorded yet")
+		return
+	}
+
+	fmt.Fprintf(w, "%s, %s in total", count(s.Visits, "visit"), formatDuration(s.Time))
+	if !s.First.IsZero() {
+		fmt.Fprintf(w, ", %s to %s", s.First.Local().Format("2006-01-02"), s.Last.Local().Format("2006-01-02"))
+	}
+	fmt.Fprintln(w)
+	if s.Visits > 0 {
+		fmt.Fprintf(w, "Average visit %s\n", formatDuration(s.Time/time.Duration(s.Visits)))
+	}
+
+	writeTallies(w, "Systems", s.Systems, top)
+	writeTallies(w, "Bodies", s.Bodies, top)
+	if s.Skipped > 0 {
+		fmt.Fprintf(w, "\n%d unreadable lines skipped\n", s.Skipped)
+	}
+}
+
+func writeTallies(w io.Writer, title string, tallies []Tally, top int) {
+	fmt.Fprintf(w, "\n%s by time on screen:\n", title)
+	if len(tallies) == 0 {
+		fmt.Fprintln(w, "  none")
+		return
+	}
+	for i, tally := range tallies {
+		if top > 0 && i == top {
+			fmt.Fprintf(w, "  ...and %d more\n", len(tallies)-top)
+			break
+		}
+		name := tally.System
+		if tally.Body != "" {
+			name = fmt.Sprintf("%s (%s)", tally.Body, tally.System)
+		}
+		fmt.Fprintf(w, "  %-32s %9s  %10s, %s each\n", name, formatDuration(tally.Time), count(tally.Views, "view"), formatDuration(tally.Time/time.Duration(tally.Views)))
+	}
+}
+
+// formatDuration writes a duration to the second, e.g. 1h02m05s or 45s
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	hours, minutes, seconds := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+	switch {
+	case hours > 0:
+		return fmt.Sprintf("%dh%02dm%02ds", hours, minutes, seconds)
+	case minutes > 0:
+		return fmt.Sprintf("%dm%02ds", minutes, seconds)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
+
+// count writes n with noun, made plural unless n is 1
+func count(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
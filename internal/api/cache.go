@@ -0,0 +1,132 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// responseCache keeps successful responses for a fixed time so repeated lookups
+// (opening the same moon twice, switching back to the Solar System) stay offline
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value   *response
+	expires time.Time
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (c *responseCache) get(key string) (*response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *responseCache) put(key string, value *response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry{value: value, expires: time.Now().Add(c.ttl)}
+}
+
+// len returns how many responses are cached and not yet expired
+func (c *responseCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	count := 0
+	for _, entry := range c.entries {
+		if !now.After(entry.expires) {
+			count++
+		}
+	}
+	return count
+}
+
+// Stats summarises the client's traffic since it was created
+type Stats struct {
+	Requests    int           // calls made through the client
+	CacheHits   int           // calls answered from the cache or a shared in-flight request
+	NotModified int           // network requests answered 304, reusing the copy on disk
+	LastLatency time.Duration // round trip of the most recent request that reached the network
+
+	LastSuccess time.Time // when the API last answered a request
+	LastFailure time.Time // when a request to the API last failed
+	LastError   string    // why it failed
+
+	CachedResponses int // responses held in memory for reuse
+}
+
+// Reachable reports whether the API answered the most recent request sent to it
+func (s Stats) Reachable() bool {
+	return !s.LastSuccess.IsZero() && s.LastSuccess.After(s.LastFailure)
+}
+
+// HitRate returns the fraction of requests answered without a network round trip
+func (s Stats) HitRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.CacheHits) / float64(s.Requests)
+}
+
+type statsRecorder struct {
+	mu    sync.Mutex
+	stats Stats
+}
+
+func (r *statsRecorder) record(hit bool, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stats.Requests++
+	if hit {
+		r.stats.CacheHits++
+	} else {
+		r.stats.LastLatency = latency
+	}
+}
+
+func (r *statsRecorder) recordNotModified() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats.NotModified++
+}
+
+// recordOutcome notes whether a request that went to the API was answered. A
+// server error counts as a failure; other statuses mean the API is up.
+func (r *statsRecorder) recordOutcome(err error, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil {
+		r.stats.LastFailure = at
+		r.stats.LastError = err.Error()
+	} else {
+		r.stats.LastSuccess = at
+	}
+}
+
+func (r *statsRecorder) snapshot() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
@@ -1,11 +1,16 @@
 package api
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"log"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/furan917/go-solar-system/internal/constants"
 	"github.com/furan917/go-solar-system/internal/models"
@@ -16,92 +21,326 @@ const (
 	MaxBodiesCount  = 10000
 )
 
+// Client gets bodies from the API, or any other Transport, keeping them in the
+// body store. Fetching is the transport's part; the Client decodes and validates
+// what comes back and cites the API as its source.
 type Client struct {
-	httpClient *http.Client
-	baseURL    string
+	transport Transport
+	http      *httpTransport // the transport NewClient builds; nil for others
+	baseURL   string
+	drift     *driftDetector
+
+	// Settings applied by options before the HTTP transport is built
+	roundTripper      http.RoundTripper
+	userAgent         string
+	requestsPerSecond float64
+	burst             int
+	cacheTTL          time.Duration
+	diskCacheDir      string
+	logger            *log.Logger
+
+	// store keeps bodies between runs and answers when the API cannot; nil when off
+	store   BodyStore
+	offline bool
 }
 
-func NewClient() *Client {
-	return &Client{
-		httpClient: &http.Client{
+// BodyStore keeps bodies between runs so they can be served without the API.
+// bodystore.Store is the one the app uses.
+type BodyStore interface {
+	SaveBodies(system string, bodies []models.CelestialBody, fetched time.Time) error
+	Bodies(system string) ([]models.CelestialBody, error)
+	Body(system, id string) (models.CelestialBody, bool, error)
+}
+
+// storeSystem is the name the Solar System's bodies are stored under, the one
+// the system list uses for it
+const storeSystem = "solar-system"
+
+// ErrOffline is returned for requests the store cannot answer while offline
+var ErrOffline = errors.New("offline, and not in the body store")
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithTransport sets the underlying transport that requests are sent through.
+// Rate limiting and the User-Agent header are still applied on top of it.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.roundTripper = transport
+	}
+}
+
+// WithBaseURL sets the API's address, which bodies are also cited under
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRateLimit caps the overall request rate, allowing bursts of up to burst
+// requests. A rate of zero or less disables limiting.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) {
+		c.requestsPerSecond = requestsPerSecond
+		c.burst = burst
+	}
+}
+
+// WithCacheTTL sets how long successful responses are reused. Zero disables caching.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cacheTTL = ttl
+	}
+}
+
+// WithDiskCache keeps responses in dir between runs and revalidates them with
+// If-None-Match / If-Modified-Since. An empty dir, the default, disables it.
+func WithDiskCache(dir string) Option {
+	return func(c *Client) {
+		c.diskCacheDir = dir
+	}
+}
+
+// DefaultDiskCacheDir returns the disk cache location inside the user's cache directory
+func DefaultDiskCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "go-solar-system", constants.HTTPCacheDirName)
+}
+
+// WithStore keeps every body fetched in store, and answers from it when the API
+// cannot be reached
+func WithStore(store BodyStore) Option {
+	return func(c *Client) {
+		c.store = store
+	}
+}
+
+// WithOffline never contacts the API: everything comes from the store set with
+// WithStore, and anything it does not have fails with ErrOffline
+func WithOffline() Option {
+	return func(c *Client) {
+		c.offline = true
+	}
+}
+
+// WithLogger sets where request failures are logged; by default they are dropped
+func WithLogger(logger *log.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// NewClient returns a client of the API over HTTP, rate limited and cached as
+// the options say
+func NewClient(opts ...Option) *Client {
+	c := newClient(opts)
+
+	var limiter *tokenBucket
+	if c.requestsPerSecond > 0 {
+		limiter = newTokenBucket(c.requestsPerSecond, c.burst)
+	}
+	c.http = &httpTransport{
+		client: &http.Client{
 			Timeout: constants.DefaultTimeout,
+			Transport: &userAgentTransport{
+				base:      c.roundTripper,
+				userAgent: c.userAgent,
+			},
 		},
-		baseURL: constants.SolarSystemAPIBase,
+		limiter: limiter,
+		baseURL: c.baseURL,
+		offline: c.offline,
+		logf:    c.logf,
+	}
+	if c.cacheTTL > 0 {
+		c.http.cache = newResponseCache(c.cacheTTL)
 	}
+	if c.diskCacheDir != "" {
+		c.http.disk = newDiskCache(c.diskCacheDir)
+	}
+	c.transport = c.http
+	return c
 }
 
-func (c *Client) GetAllBodies() ([]models.CelestialBody, error) {
-	targetUrl := fmt.Sprintf("%s/bodies", c.baseURL)
+// NewClientWithTransport returns a client that fetches through transport instead
+// of the API, such as DumpTransport for saved responses or a StaticTransport in
+// tests. The options for HTTP, its rate limit and its caches do not apply.
+func NewClientWithTransport(transport Transport, opts ...Option) *Client {
+	c := newClient(opts)
+	c.transport = transport
+	return c
+}
 
-	resp, err := c.httpClient.Get(targetUrl)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch bodies: %w", err)
+func newClient(opts []Option) *Client {
+	c := &Client{
+		baseURL:           constants.SolarSystemAPIBase,
+		roundTripper:      http.DefaultTransport,
+		userAgent:         constants.DefaultUserAgent,
+		requestsPerSecond: constants.DefaultRequestsPerSecond,
+		burst:             constants.DefaultRequestBurst,
+		cacheTTL:          constants.DefaultCacheTTL,
+		drift:             newDriftDetector(),
 	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			fmt.Printf("Error closing response body: %v\n", err)
-		}
-	}(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	for _, opt := range opts {
+		opt(c)
 	}
 
-	limitedReader := io.LimitReader(resp.Body, MaxResponseSize)
-	body, err := io.ReadAll(limitedReader)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	return c
+}
+
+// Stats returns request, cache and latency counters; all zero for a client with
+// a transport other than the API
+func (c *Client) Stats() Stats {
+	if c.http == nil {
+		return Stats{}
 	}
+	stats := c.http.stats.snapshot()
+	if c.http.cache != nil {
+		stats.CachedResponses = c.http.cache.len()
+	}
+	return stats
+}
 
-	var apiResponse models.APIResponse
-	if err := json.Unmarshal(body, &apiResponse); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+// Settings describes how a Client was configured
+type Settings struct {
+	BaseURL           string
+	UserAgent         string
+	RequestsPerSecond float64 // zero or less when unlimited
+	Burst             int
+	CacheTTL          time.Duration // zero when responses are not kept in memory
+	DiskCacheDir      string        // empty when responses are not kept on disk
+	Store             bool          // bodies are kept between runs
+	Offline           bool
+}
+
+// Settings returns the endpoint, limits and caches the client was built with.
+// Only the store and offline apply to a transport other than the API.
+func (c *Client) Settings() Settings {
+	if c.http == nil {
+		return Settings{BaseURL: c.baseURL, Store: c.store != nil, Offline: c.offline}
 	}
+	return Settings{
+		BaseURL:           c.baseURL,
+		UserAgent:         c.userAgent,
+		RequestsPerSecond: c.requestsPerSecond,
+		Burst:             c.burst,
+		CacheTTL:          c.cacheTTL,
+		DiskCacheDir:      c.diskCacheDir,
+		Store:             c.store != nil,
+		Offline:           c.offline,
+	}
+}
 
-	if err := validateAPIResponse(apiResponse); err != nil {
-		return nil, fmt.Errorf("invalid API response: %w", err)
+// Ping asks the API for its smallest resource, bypassing every cache, and
+// returns how long it took to answer. Other transports are timed fetching it.
+func (c *Client) Ping() (time.Duration, error) {
+	if c.offline {
+		return 0, ErrOffline
 	}
+	if c.http != nil {
+		return c.http.ping()
+	}
+	start := time.Now()
+	_, err := c.transport.Fetch("/knowncount")
+	return time.Since(start), err
+}
 
-	return apiResponse.Bodies, nil
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, v...)
+	}
 }
 
-func (c *Client) GetBody(id string) (*models.CelestialBody, error) {
-	targetUrl := fmt.Sprintf("%s/bodies/%s", c.baseURL, url.QueryEscape(id))
+func (c *Client) GetAllBodies() ([]models.CelestialBody, error) {
+	return c.bodiesVia(c.fetchAllBodies, nil)
+}
 
-	resp, err := c.httpClient.Get(targetUrl)
+func (c *Client) fetchAllBodies() ([]models.CelestialBody, error) {
+	data, err := c.transport.Fetch("/bodies")
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch body %s: %w", id, err)
+		return nil, fmt.Errorf("failed to fetch bodies: %w", err)
 	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			fmt.Printf("Error closing response body for %s: %v\n", id, err)
-		}
-	}(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d for body %s", resp.StatusCode, id)
+	c.checkSchema(data, true)
+	bodies, err := decodeBodies(data)
+	if err != nil {
+		return nil, err
 	}
+	for i := range bodies {
+		c.cite(&bodies[i])
+	}
+	return bodies, nil
+}
 
-	limitedReader := io.LimitReader(resp.Body, MaxResponseSize)
-	body, err := io.ReadAll(limitedReader)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+func (c *Client) GetBody(id string) (*models.CelestialBody, error) {
+	if !c.offline {
+		body, err := c.fetchBody(id)
+		if err == nil {
+			c.save([]models.CelestialBody{*body})
+		}
+		if err == nil || c.store == nil {
+			return body, err
+		}
+		c.logf("Looking for %s in the body store: %v", id, err)
 	}
 
-	var celestialBody models.CelestialBody
-	if err := json.Unmarshal(body, &celestialBody); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	if c.store == nil {
+		return nil, ErrOffline
 	}
+	stored, ok, err := c.store.Body(storeSystem, id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("failed to fetch body %s: %w", id, ErrOffline)
+	}
+	c.cite(&stored)
+	return &stored, nil
+}
 
-	if err := validateCelestialBody(celestialBody); err != nil {
-		return nil, fmt.Errorf("invalid celestial body data for %s: %w", id, err)
+func (c *Client) fetchBody(id string) (*models.CelestialBody, error) {
+	data, err := c.transport.Fetch("/bodies/" + url.QueryEscape(id))
+	if errors.Is(err, ErrNotFound) {
+		return c.pickBody(id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch body %s: %w", id, err)
 	}
 
+	c.checkSchema(data, false)
+	celestialBody, err := decodeBody(data)
+	if err != nil {
+		return nil, fmt.Errorf("body %s: %w", id, err)
+	}
+	c.cite(&celestialBody)
 	return &celestialBody, nil
 }
 
+// pickBody finds a body in the full list, for transports that only have that
+func (c *Client) pickBody(id string) (*models.CelestialBody, error) {
+	bodies, err := c.fetchAllBodies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch body %s: %w", id, err)
+	}
+	for _, body := range bodies {
+		if body.ID == id {
+			return &body, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to fetch body %s: %w", id, ErrNotFound)
+}
+
 func (c *Client) GetPlanets() ([]models.CelestialBody, error) {
 	bodies, err := c.GetAllBodies()
 	if err != nil {
@@ -118,104 +357,175 @@ func (c *Client) GetPlanets() ([]models.CelestialBody, error) {
 	return planets, nil
 }
 
+// GetBodiesWithFilter fetches the bodies passing an API filter such as
+// "bodyType,eq,Moon". Stored bodies can be filtered by bodyType, isPlanet and id.
 func (c *Client) GetBodiesWithFilter(filter string) ([]models.CelestialBody, error) {
-	targetUrl := fmt.Sprintf("%s/bodies?filter[]=%s", c.baseURL, url.QueryEscape(filter))
+	return c.bodiesVia(func() ([]models.CelestialBody, error) {
+		return c.fetchBodiesWithFilter(filter)
+	}, func(body models.CelestialBody) bool {
+		return passesFilter(body, filter)
+	})
+}
 
-	resp, err := c.httpClient.Get(targetUrl)
+func (c *Client) fetchBodiesWithFilter(filter string) ([]models.CelestialBody, error) {
+	data, err := c.transport.Fetch("/bodies?filter[]=" + url.QueryEscape(filter))
+	if errors.Is(err, ErrNotFound) {
+		return c.pickBodies(filter)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch filtered bodies: %w", err)
 	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			fmt.Printf("Error closing response body for filter %s: %v\n", filter, err)
-		}
-	}(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	c.checkSchema(data, true)
+	bodies, err := decodeBodies(data)
+	if err != nil {
+		return nil, fmt.Errorf("filtered bodies: %w", err)
+	}
+	for i := range bodies {
+		c.cite(&bodies[i])
 	}
+	return bodies, nil
+}
 
-	limitedReader := io.LimitReader(resp.Body, MaxResponseSize)
-	body, err := io.ReadAll(limitedReader)
+// pickBodies filters the full list, for transports that only have that
+func (c *Client) pickBodies(filter string) ([]models.CelestialBody, error) {
+	bodies, err := c.fetchAllBodies()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
-
-	var apiResponse models.APIResponse
-	if err := json.Unmarshal(body, &apiResponse); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	var matched []models.CelestialBody
+	for _, body := range bodies {
+		if passesFilter(body, filter) {
+			matched = append(matched, body)
+		}
 	}
-
-	if err := validateAPIResponse(apiResponse); err != nil {
-		return nil, fmt.Errorf("invalid filtered API response: %w", err)
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no bodies pass %s: %w", filter, ErrNotFound)
 	}
-
-	return apiResponse.Bodies, nil
+	return matched, nil
 }
 
-// GetMoonData attempts to fetch detailed moon data from the API
-func (c *Client) GetMoonData(moonID string) (*models.CelestialBody, error) {
-	if moonID == "" {
-		return nil, fmt.Errorf("moon ID is empty")
+// bodiesVia fetches bodies and keeps them in the store, if there is one. When the
+// API cannot be reached, or the client is offline, the stored bodies that pass
+// match, or all of them for a nil match, are returned instead.
+func (c *Client) bodiesVia(fetch func() ([]models.CelestialBody, error), match func(models.CelestialBody) bool) ([]models.CelestialBody, error) {
+	if !c.offline {
+		bodies, err := fetch()
+		if err == nil {
+			c.save(bodies)
+		}
+		if err == nil || c.store == nil {
+			return bodies, err
+		}
+		c.logf("Answering from the body store: %v", err)
 	}
 
-	body, err := c.GetBody(moonID)
+	if c.store == nil {
+		return nil, ErrOffline
+	}
+	stored, err := c.store.Bodies(storeSystem)
 	if err != nil {
 		return nil, err
 	}
-
-	if err := validateCelestialBody(*body); err != nil {
-		return nil, fmt.Errorf("invalid celestial body data for %s: %w", moonID, err)
+	var bodies []models.CelestialBody
+	for _, body := range stored {
+		if match == nil || match(body) {
+			c.cite(&body)
+			bodies = append(bodies, body)
+		}
 	}
-
-	return body, nil
+	if len(bodies) == 0 {
+		return nil, ErrOffline
+	}
+	return bodies, nil
 }
 
-// validateAPIResponse validates the structure and content of API responses
-func validateAPIResponse(response models.APIResponse) error {
-	if len(response.Bodies) == 0 {
-		return fmt.Errorf("API response contains no celestial bodies")
+// StoredPlanets returns the Solar System's planets as the body store last kept
+// them, to show while the API is asked for fresh ones; none without a store
+func (c *Client) StoredPlanets() []models.CelestialBody {
+	if c.store == nil {
+		return nil
 	}
-
-	if len(response.Bodies) > MaxBodiesCount {
-		return fmt.Errorf("API response contains too many celestial bodies: %d (max: %d)", len(response.Bodies), MaxBodiesCount)
+	stored, err := c.store.Bodies(storeSystem)
+	if err != nil {
+		c.logf("Could not read the body store: %v", err)
+		return nil
 	}
-
-	for i, body := range response.Bodies {
-		if err := validateCelestialBody(body); err != nil {
-			return fmt.Errorf("invalid celestial body at index %d: %w", i, err)
+	var planets []models.CelestialBody
+	for _, body := range stored {
+		if body.IsPlanet {
+			c.cite(&body)
+			planets = append(planets, body)
 		}
 	}
+	return planets
+}
 
-	return nil
+func (c *Client) save(bodies []models.CelestialBody) {
+	c.KeepBodies(storeSystem, bodies)
 }
 
-// validateCelestialBody validates individual celestial body data
-func validateCelestialBody(body models.CelestialBody) error {
-	if body.EnglishName == "" {
-		return fmt.Errorf("celestial body missing English name")
+// KeepBodies records bodies of a system in the store, if there is one, for those
+// that come from somewhere other than the API. A store that cannot be written to
+// only costs the next offline run those bodies, so the error is logged.
+func (c *Client) KeepBodies(system string, bodies []models.CelestialBody) {
+	if c.store == nil {
+		return
+	}
+	if err := c.store.SaveBodies(system, bodies, time.Now()); err != nil {
+		c.logf("Could not keep bodies of %s in the body store: %v", system, err)
 	}
+}
 
-	if body.MeanRadius < 0 {
-		return fmt.Errorf("celestial body %s has negative radius: %.2f", body.EnglishName, body.MeanRadius)
+// passesFilter reports whether a body passes an API filter of the form
+// field,eq,value for the fields a stored body can be filtered by
+func passesFilter(body models.CelestialBody, filter string) bool {
+	parts := strings.SplitN(filter, ",", 3)
+	if len(parts) != 3 || parts[1] != "eq" {
+		return false
+	}
+	switch parts[0] {
+	case "bodyType":
+		return strings.EqualFold(body.BodyType, parts[2])
+	case "isPlanet":
+		return strconv.FormatBool(body.IsPlanet) == parts[2]
+	case "id":
+		return body.ID == parts[2]
 	}
+	return false
+}
+
+// cite records that a body came from the API, citing the body's own page, and
+// gathers its orbital angles into orbital elements
+func (c *Client) cite(body *models.CelestialBody) {
+	body.SetSource(models.SourceAPI, fmt.Sprintf("%s/bodies/%s", c.baseURL, url.QueryEscape(body.ID)))
+	body.FillOrbitalElements()
+}
 
-	if body.SemimajorAxis < 0 {
-		return fmt.Errorf("celestial body %s has negative semimajor axis: %.2f", body.EnglishName, body.SemimajorAxis)
+// GetKnownCounts fetches the API's totals of known objects (planets, moons,
+// asteroids, comets...) in our solar system
+func (c *Client) GetKnownCounts() ([]models.KnownCount, error) {
+	data, err := c.transport.Fetch("/knowncount")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch known counts: %w", err)
 	}
+	return decodeKnownCounts(data)
+}
 
-	if body.Density < 0 {
-		return fmt.Errorf("celestial body %s has negative density: %.2f", body.EnglishName, body.Density)
+// GetMoonData attempts to fetch detailed moon data from the API
+func (c *Client) GetMoonData(moonID string) (*models.CelestialBody, error) {
+	if moonID == "" {
+		return nil, fmt.Errorf("moon ID is empty")
 	}
 
-	if body.Gravity < 0 {
-		return fmt.Errorf("celestial body %s has negative gravity: %.2f", body.EnglishName, body.Gravity)
+	body, err := c.GetBody(moonID)
+	if err != nil {
+		return nil, err
 	}
 
-	if body.Eccentricity < 0 || body.Eccentricity > 1 {
-		return fmt.Errorf("celestial body %s has unrealistic eccentricity: %.6f", body.EnglishName, body.Eccentricity)
+	if err := validateCelestialBody(*body); err != nil {
+		return nil, fmt.Errorf("invalid celestial body data for %s: %w", moonID, err)
 	}
 
-	return nil
+	return body, nil
 }
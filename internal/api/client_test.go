@@ -1,10 +1,21 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/furan917/go-solar-system/internal/models"
 )
@@ -50,8 +61,7 @@ func TestClient_GetAllBodies(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient()
-	client.baseURL = server.URL
+	client := NewClient(WithBaseURL(server.URL))
 
 	bodies, err := client.GetAllBodies()
 	if err != nil {
@@ -104,8 +114,7 @@ func TestClient_GetBody(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient()
-	client.baseURL = server.URL
+	client := NewClient(WithBaseURL(server.URL))
 
 	body, err := client.GetBody("terre")
 	if err != nil {
@@ -123,6 +132,10 @@ func TestClient_GetBody(t *testing.T) {
 	if body.Moons[0].EnglishName != "Moon" {
 		t.Errorf("Expected moon to be Moon, got %s", body.Moons[0].EnglishName)
 	}
+
+	if body.Provenance.Source != models.SourceAPI || body.Provenance.Citation != server.URL+"/bodies/terre" {
+		t.Errorf("Provenance = %+v, want the API and the body's URL", body.Provenance)
+	}
 }
 
 func TestClient_GetPlanets(t *testing.T) {
@@ -158,8 +171,7 @@ func TestClient_GetPlanets(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient()
-	client.baseURL = server.URL
+	client := NewClient(WithBaseURL(server.URL))
 
 	planets, err := client.GetPlanets()
 	if err != nil {
@@ -189,8 +201,7 @@ func TestClient_GetBody_NotFound(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient()
-	client.baseURL = server.URL
+	client := NewClient(WithBaseURL(server.URL))
 
 	_, err := client.GetBody("nonexistent")
 	if err == nil {
@@ -223,8 +234,7 @@ func TestClient_GetBodiesWithFilter(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient()
-	client.baseURL = server.URL
+	client := NewClient(WithBaseURL(server.URL))
 
 	bodies, err := client.GetBodiesWithFilter("isPlanet,eq,true")
 	if err != nil {
@@ -239,3 +249,591 @@ func TestClient_GetBodiesWithFilter(t *testing.T) {
 		t.Errorf("Expected body to be Earth, got %s", bodies[0].EnglishName)
 	}
 }
+
+// memoryStore is a BodyStore kept in a map, for tests
+type memoryStore map[string][]models.CelestialBody
+
+func (m memoryStore) SaveBodies(system string, bodies []models.CelestialBody, fetched time.Time) error {
+	m[system] = append(m[system], bodies...)
+	return nil
+}
+
+func (m memoryStore) Bodies(system string) ([]models.CelestialBody, error) {
+	return m[system], nil
+}
+
+func (m memoryStore) Body(system, id string) (models.CelestialBody, bool, error) {
+	for _, body := range m[system] {
+		if body.ID == id {
+			return body, true, nil
+		}
+	}
+	return models.CelestialBody{}, false, nil
+}
+
+func TestClient_StoreAnswersWhenAPIFails(t *testing.T) {
+	up := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(models.APIResponse{Bodies: []models.CelestialBody{
+			{ID: "terre", EnglishName: "Earth", BodyType: "Planet", IsPlanet: true},
+			{ID: "lune", EnglishName: "Moon", BodyType: "Moon"},
+		}})
+	}))
+	defer server.Close()
+
+	store := memoryStore{}
+	client := NewClient(WithStore(store), WithCacheTTL(0), WithBaseURL(server.URL))
+
+	if _, err := client.GetAllBodies(); err != nil {
+		t.Fatalf("GetAllBodies() error = %v", err)
+	}
+	if len(store[storeSystem]) != 2 {
+		t.Fatalf("Expected 2 bodies kept, got %d", len(store[storeSystem]))
+	}
+
+	up = false
+	bodies, err := client.GetAllBodies()
+	if err != nil || len(bodies) != 2 {
+		t.Fatalf("GetAllBodies() with the API down = %d bodies, %v; want 2 from the store", len(bodies), err)
+	}
+	if bodies[0].Provenance.Source != models.SourceAPI {
+		t.Error("Expected stored bodies to be cited")
+	}
+
+	moons, err := client.GetBodiesWithFilter("bodyType,eq,Moon")
+	if err != nil || len(moons) != 1 || moons[0].ID != "lune" {
+		t.Errorf("GetBodiesWithFilter() with the API down = %v, %v; want the Moon", moons, err)
+	}
+
+	body, err := client.GetBody("terre")
+	if err != nil || body.EnglishName != "Earth" {
+		t.Errorf("GetBody() with the API down = %v, %v; want Earth", body, err)
+	}
+}
+
+func TestClient_Offline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Offline client requested %s", r.URL)
+	}))
+	defer server.Close()
+
+	store := memoryStore{storeSystem: {{ID: "terre", EnglishName: "Earth", BodyType: "Planet", IsPlanet: true}}}
+	client := NewClient(WithStore(store), WithOffline(), WithBaseURL(server.URL))
+
+	bodies, err := client.GetAllBodies()
+	if err != nil || len(bodies) != 1 {
+		t.Errorf("GetAllBodies() offline = %d bodies, %v; want Earth", len(bodies), err)
+	}
+	if _, err := client.GetBody("mars"); !errors.Is(err, ErrOffline) {
+		t.Errorf("GetBody() of a body not kept = %v, want ErrOffline", err)
+	}
+	if _, err := client.GetKnownCounts(); !errors.Is(err, ErrOffline) {
+		t.Errorf("GetKnownCounts() offline = %v, want ErrOffline", err)
+	}
+}
+
+func TestClient_GetKnownCounts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/knowncount" {
+			t.Errorf("Expected path /knowncount, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"knowncount": [
+			{"id": "asteroids", "knownCount": 1113527, "updateDate": "07/09/2023", "rel": "x"},
+			{"id": "comets", "knownCount": 3743, "updateDate": "07/09/2023", "rel": "y"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+
+	counts, err := client.GetKnownCounts()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(counts) != 2 {
+		t.Fatalf("Expected 2 counts, got %d", len(counts))
+	}
+	if counts[0].ID != "asteroids" || counts[0].KnownCount != 1113527 {
+		t.Errorf("Unexpected first count: %+v", counts[0])
+	}
+}
+
+func TestClient_GetKnownCounts_Empty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"knowncount": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+
+	if _, err := client.GetKnownCounts(); err == nil {
+		t.Error("Expected an error for an empty response")
+	}
+}
+
+func TestClient_PingTracksReachability(t *testing.T) {
+	var failing atomic.Bool
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"knowncount": [{"id": "comets", "knownCount": 3743}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRateLimit(0, 0), WithBaseURL(server.URL))
+
+	if _, err := client.GetKnownCounts(); err != nil {
+		t.Fatalf("GetKnownCounts() error = %v", err)
+	}
+	if stats := client.Stats(); !stats.Reachable() || stats.CachedResponses != 1 {
+		t.Fatalf("Expected the API reachable with 1 cached response, got %+v", stats)
+	}
+
+	// A ping goes to the API even though the response is cached
+	failing.Store(true)
+	if _, err := client.Ping(); err == nil {
+		t.Fatal("Expected Ping() to fail on a 502")
+	}
+	stats := client.Stats()
+	if stats.Reachable() || !strings.Contains(stats.LastError, "502") {
+		t.Errorf("Expected the failed ping recorded, got %+v", stats)
+	}
+	if got := atomic.LoadInt32(&hits); got != 2 {
+		t.Errorf("Expected 2 requests to reach the server, got %d", got)
+	}
+
+	failing.Store(false)
+	if _, err := client.Ping(); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if !client.Stats().Reachable() {
+		t.Error("Expected the API reachable again after a good ping")
+	}
+
+	settings := client.Settings()
+	if settings.BaseURL != server.URL || settings.Offline || settings.CacheTTL == 0 {
+		t.Errorf("Unexpected settings %+v", settings)
+	}
+	if _, err := NewClient(WithOffline()).Ping(); !errors.Is(err, ErrOffline) {
+		t.Errorf("Ping() offline = %v, want ErrOffline", err)
+	}
+}
+
+func TestClient_CoalescesIdenticalRequests(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.CelestialBody{ID: "lune", EnglishName: "Moon"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRateLimit(0, 0), WithBaseURL(server.URL))
+
+	const callers = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetBody("lune")
+			errs <- err
+		}()
+	}
+
+	// Give every caller time to join the in-flight request before it completes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("GetBody() error = %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("Expected 1 request to reach the server, got %d", got)
+	}
+}
+
+func TestClient_UserAgent(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		_ = json.NewEncoder(w).Encode(models.CelestialBody{ID: "terre", EnglishName: "Earth"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithUserAgent("classroom-explorer/1.0"), WithBaseURL(server.URL))
+
+	if _, err := client.GetBody("terre"); err != nil {
+		t.Fatalf("GetBody() error = %v", err)
+	}
+
+	if userAgent != "classroom-explorer/1.0" {
+		t.Errorf("Expected User-Agent classroom-explorer/1.0, got %q", userAgent)
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(models.CelestialBody{ID: r.URL.Path, EnglishName: "Body"})
+	}))
+	defer server.Close()
+
+	// One request up front, then one every 50ms
+	client := NewClient(WithRateLimit(20, 1), WithBaseURL(server.URL))
+
+	start := time.Now()
+	for _, id := range []string{"a", "b", "c"} {
+		if _, err := client.GetBody(id); err != nil {
+			t.Fatalf("GetBody(%s) error = %v", id, err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected requests to be paced to at least 90ms, took %v", elapsed)
+	}
+}
+
+func TestClient_RateLimitWaitIsNotTimedOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(models.CelestialBody{ID: strings.TrimPrefix(r.URL.Path, "/bodies/"), EnglishName: "Body"})
+	}))
+	defer server.Close()
+
+	// The last of eight requests queues for 350ms, well past the client's timeout
+	client := NewClient(WithRateLimit(20, 1), WithCacheTTL(0), WithBaseURL(server.URL))
+	client.http.client.Timeout = 100 * time.Millisecond
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			if _, err := client.GetBody(id); err != nil {
+				errs <- fmt.Errorf("GetBody(%s): %w", id, err)
+			}
+		}(fmt.Sprintf("body-%d", i))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestClient_CachesResponses(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		_ = json.NewEncoder(w).Encode(models.CelestialBody{ID: "terre", EnglishName: "Earth"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRateLimit(0, 0), WithBaseURL(server.URL))
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetBody("terre"); err != nil {
+			t.Fatalf("GetBody() error = %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("Expected 1 request to reach the server, got %d", got)
+	}
+
+	stats := client.Stats()
+	if stats.Requests != 3 || stats.CacheHits != 2 {
+		t.Errorf("Expected 3 requests with 2 cache hits, got %+v", stats)
+	}
+}
+
+func TestClient_CacheDisabled(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		_ = json.NewEncoder(w).Encode(models.CelestialBody{ID: "terre", EnglishName: "Earth"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRateLimit(0, 0), WithCacheTTL(0), WithBaseURL(server.URL))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetBody("terre"); err != nil {
+			t.Fatalf("GetBody() error = %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&hits); got != 2 {
+		t.Errorf("Expected 2 requests to reach the server, got %d", got)
+	}
+}
+
+func TestClient_RevalidatesDiskCacheWithETag(t *testing.T) {
+	var full, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&full, 1)
+		_ = json.NewEncoder(w).Encode(models.CelestialBody{ID: "terre", EnglishName: "Earth"})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	for run := 0; run < 2; run++ {
+		// A new client per run, as if the program had been started again
+		client := NewClient(WithRateLimit(0, 0), WithDiskCache(dir), WithBaseURL(server.URL))
+
+		body, err := client.GetBody("terre")
+		if err != nil {
+			t.Fatalf("run %d: GetBody() error = %v", run, err)
+		}
+		if body.EnglishName != "Earth" {
+			t.Errorf("run %d: expected Earth, got %s", run, body.EnglishName)
+		}
+		if run == 1 && client.Stats().NotModified != 1 {
+			t.Errorf("run %d: expected one 304, got %+v", run, client.Stats())
+		}
+	}
+
+	if full != 1 || notModified != 1 {
+		t.Errorf("Expected 1 full response and 1 not modified, got %d and %d", full, notModified)
+	}
+}
+
+func TestClient_SendsIfModifiedSince(t *testing.T) {
+	const lastModified = "Wed, 01 Jan 2025 00:00:00 GMT"
+	var conditional int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			atomic.AddInt32(&conditional, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		_ = json.NewEncoder(w).Encode(models.CelestialBody{ID: "terre", EnglishName: "Earth"})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	for run := 0; run < 2; run++ {
+		client := NewClient(WithRateLimit(0, 0), WithDiskCache(dir), WithBaseURL(server.URL))
+		if _, err := client.GetBody("terre"); err != nil {
+			t.Fatalf("run %d: GetBody() error = %v", run, err)
+		}
+	}
+
+	if conditional != 1 {
+		t.Errorf("Expected the second run to send If-Modified-Since, got %d conditional requests", conditional)
+	}
+}
+
+func TestClient_DiskCacheHonoursMaxAge(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		_ = json.NewEncoder(w).Encode(models.CelestialBody{ID: "terre", EnglishName: "Earth"})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	for run := 0; run < 2; run++ {
+		client := NewClient(WithRateLimit(0, 0), WithDiskCache(dir), WithBaseURL(server.URL))
+		if _, err := client.GetBody("terre"); err != nil {
+			t.Fatalf("run %d: GetBody() error = %v", run, err)
+		}
+	}
+
+	if hits != 1 {
+		t.Errorf("Expected a fresh disk copy to avoid the network, got %d requests", hits)
+	}
+}
+
+func TestClient_DiskCacheSkipsNoStore(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("ETag", `"v1"`)
+		_ = json.NewEncoder(w).Encode(models.CelestialBody{ID: "terre", EnglishName: "Earth"})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	for run := 0; run < 2; run++ {
+		client := NewClient(WithRateLimit(0, 0), WithDiskCache(dir), WithBaseURL(server.URL))
+		if _, err := client.GetBody("terre"); err != nil {
+			t.Fatalf("run %d: GetBody() error = %v", run, err)
+		}
+	}
+
+	if hits != 2 {
+		t.Errorf("Expected no-store responses to be fetched every run, got %d requests", hits)
+	}
+}
+
+func TestParseCacheControl(t *testing.T) {
+	tests := []struct {
+		header  string
+		noStore bool
+		maxAge  time.Duration
+	}{
+		{"", false, 0},
+		{"max-age=60", false, time.Minute},
+		{"public, MAX-AGE=120", false, 2 * time.Minute},
+		{"no-cache, max-age=60", false, 0},
+		{"no-store", true, 0},
+		{"max-age=oops", false, 0},
+	}
+
+	for _, tt := range tests {
+		header := http.Header{}
+		header.Set("Cache-Control", tt.header)
+		got := parseCacheControl(header)
+		if got.noStore != tt.noStore || got.maxAge != tt.maxAge {
+			t.Errorf("parseCacheControl(%q) = %+v, want noStore=%v maxAge=%v", tt.header, got, tt.noStore, tt.maxAge)
+		}
+	}
+}
+
+func TestClient_SchemaDrift(t *testing.T) {
+	encoded, err := json.Marshal(models.CelestialBody{ID: "terre", EnglishName: "Earth", IsPlanet: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(encoded, &body); err != nil {
+		t.Fatal(err)
+	}
+	delete(body, "gravity")
+	delete(body, "temperature")
+	body["magneticField"] = 0.5
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/bodies" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"bodies": []interface{}{body}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := NewClient(WithRateLimit(0, 0), WithCacheTTL(0), WithLogger(log.New(&logs, "", 0)), WithBaseURL(server.URL))
+
+	if got := client.SchemaDrift(); !got.Empty() {
+		t.Fatalf("Expected no drift before any request, got %+v", got)
+	}
+	if _, err := client.GetAllBodies(); err != nil {
+		t.Fatalf("GetAllBodies() error = %v", err)
+	}
+	if _, err := client.GetBody("terre"); err != nil {
+		t.Fatalf("GetBody() error = %v", err)
+	}
+
+	got := client.SchemaDrift()
+	if !reflect.DeepEqual(got.Unknown, []string{"magneticField"}) {
+		t.Errorf("Unknown = %v, want [magneticField]", got.Unknown)
+	}
+	// temperature is only ever set by system files, so its absence is not drift
+	if !reflect.DeepEqual(got.Missing, []string{"gravity"}) {
+		t.Errorf("Missing = %v, want [gravity]", got.Missing)
+	}
+	if n := strings.Count(logs.String(), "magneticField"); n != 1 {
+		t.Errorf("Expected the new field to be logged once, got %d times:\n%s", n, logs.String())
+	}
+}
+
+func TestClient_WithStaticTransport(t *testing.T) {
+	client := NewClientWithTransport(StaticTransport{
+		"/bodies": []byte(`{"bodies": [
+			{"id": "terre", "englishName": "Earth", "isPlanet": true, "bodyType": "Planet"},
+			{"id": "lune", "englishName": "Moon", "isPlanet": false, "bodyType": "Moon"}
+		]}`),
+	})
+
+	planets, err := client.GetPlanets()
+	if err != nil {
+		t.Fatalf("Expected the filter picked out of /bodies, got %v", err)
+	}
+	if len(planets) != 1 || planets[0].EnglishName != "Earth" {
+		t.Errorf("Expected only Earth, got %+v", planets)
+	}
+
+	moon, err := client.GetBody("lune")
+	if err != nil {
+		t.Fatalf("Expected the body picked out of /bodies, got %v", err)
+	}
+	if moon.EnglishName != "Moon" {
+		t.Errorf("Expected the Moon, got %q", moon.EnglishName)
+	}
+
+	if _, err := client.GetBody("mars"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing body, got %v", err)
+	}
+	if _, err := client.GetKnownCounts(); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing resource, got %v", err)
+	}
+	if stats := client.Stats(); stats.Requests != 0 {
+		t.Errorf("Expected no HTTP stats without HTTP, got %+v", stats)
+	}
+}
+
+func TestClient_WithDumpTransport(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "bodies"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"bodies.json":      `{"bodies": [{"id": "terre", "englishName": "Earth", "isPlanet": true}]}`,
+		"bodies/mars.json": `{"id": "mars", "englishName": "Mars", "isPlanet": true, "meanRadius": -1}`,
+		"knowncount.json":  `{"knowncount": [{"id": "planet", "knownCount": 8}]}`,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client := NewClientWithTransport(DumpTransport(dir))
+
+	bodies, err := client.GetAllBodies()
+	if err != nil || len(bodies) != 1 {
+		t.Fatalf("Expected Earth from bodies.json, got %+v, %v", bodies, err)
+	}
+	if !strings.HasSuffix(bodies[0].Provenance.Citation, "/bodies/terre") {
+		t.Errorf("Expected the body cited under the API, got %q", bodies[0].Provenance.Citation)
+	}
+
+	if _, err := client.GetBody("mars"); err == nil || !strings.Contains(err.Error(), "negative radius") {
+		t.Errorf("Expected a dumped body validated like an API one, got %v", err)
+	}
+
+	counts, err := client.GetKnownCounts()
+	if err != nil || len(counts) != 1 || counts[0].KnownCount != 8 {
+		t.Errorf("Expected the known counts from knowncount.json, got %+v, %v", counts, err)
+	}
+}
@@ -0,0 +1,50 @@
+package api
+
+import "sync"
+
+// requestGroup coalesces concurrent identical requests so only one reaches the
+// network and every caller shares its result
+type requestGroup struct {
+	mu    sync.Mutex
+	calls map[string]*groupCall
+}
+
+type groupCall struct {
+	wg    sync.WaitGroup
+	value *response
+	err   error
+}
+
+// response is a fully-read HTTP response that can be shared between callers
+type response struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Do runs fn once for all concurrent callers using the same key.
+// shared reports whether the result came from another caller's request.
+func (g *requestGroup) Do(key string, fn func() (*response, error)) (value *response, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*groupCall)
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.value, call.err, true
+	}
+
+	call := &groupCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.value, call.err = fn()
+	call.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return call.value, call.err, false
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// decodeBodies reads a list response, {"bodies": [...]}, and checks every body
+// is sane, whichever transport fetched it
+func decodeBodies(data []byte) ([]models.CelestialBody, error) {
+	var apiResponse models.APIResponse
+	if err := json.Unmarshal(data, &apiResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if err := validateAPIResponse(apiResponse); err != nil {
+		return nil, fmt.Errorf("invalid API response: %w", err)
+	}
+	return apiResponse.Bodies, nil
+}
+
+// decodeBody reads a single body response and checks it is sane
+func decodeBody(data []byte) (models.CelestialBody, error) {
+	var celestialBody models.CelestialBody
+	if err := json.Unmarshal(data, &celestialBody); err != nil {
+		return models.CelestialBody{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if err := validateCelestialBody(celestialBody); err != nil {
+		return models.CelestialBody{}, fmt.Errorf("invalid celestial body data: %w", err)
+	}
+	return celestialBody, nil
+}
+
+// decodeKnownCounts reads the known object counts response
+func decodeKnownCounts(data []byte) ([]models.KnownCount, error) {
+	var countResponse models.KnownCountResponse
+	if err := json.Unmarshal(data, &countResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if len(countResponse.KnownCount) == 0 {
+		return nil, fmt.Errorf("API response contains no known counts")
+	}
+	return countResponse.KnownCount, nil
+}
+
+// validateAPIResponse validates the structure and content of API responses
+func validateAPIResponse(response models.APIResponse) error {
+	if len(response.Bodies) == 0 {
+		return fmt.Errorf("API response contains no celestial bodies")
+	}
+
+	if len(response.Bodies) > MaxBodiesCount {
+		return fmt.Errorf("API response contains too many celestial bodies: %d (max: %d)", len(response.Bodies), MaxBodiesCount)
+	}
+
+	for i, body := range response.Bodies {
+		if err := validateCelestialBody(body); err != nil {
+			return fmt.Errorf("invalid celestial body at index %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// validateCelestialBody validates individual celestial body data
+func validateCelestialBody(body models.CelestialBody) error {
+	if body.EnglishName == "" {
+		return fmt.Errorf("celestial body missing English name")
+	}
+
+	if body.MeanRadius < 0 {
+		return fmt.Errorf("celestial body %s has negative radius: %.2f", body.EnglishName, body.MeanRadius)
+	}
+
+	if body.SemimajorAxis < 0 {
+		return fmt.Errorf("celestial body %s has negative semimajor axis: %.2f", body.EnglishName, body.SemimajorAxis)
+	}
+
+	if body.Density < 0 {
+		return fmt.Errorf("celestial body %s has negative density: %.2f", body.EnglishName, body.Density)
+	}
+
+	if body.Gravity < 0 {
+		return fmt.Errorf("celestial body %s has negative gravity: %.2f", body.EnglishName, body.Gravity)
+	}
+
+	if body.Eccentricity < 0 || body.Eccentricity > 1 {
+		return fmt.Errorf("celestial body %s has unrealistic eccentricity: %.6f", body.EnglishName, body.Eccentricity)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// diskCache keeps responses between runs along with the validators the API sent,
+// so a later run can ask "has this changed?" instead of downloading it again
+type diskCache struct {
+	dir string
+}
+
+// diskEntry is one cached response as written to disk
+type diskEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Expires      time.Time `json:"expires"`
+	Body         []byte    `json:"body"`
+}
+
+func newDiskCache(dir string) *diskCache {
+	return &diskCache{dir: dir}
+}
+
+// path names an entry's file after a hash of its URL
+func (c *diskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// load returns the entry for key, if one was saved. An unreadable entry is treated
+// as missing; it is simply fetched again.
+func (c *diskCache) load(key string) (*diskEntry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != key {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// store writes the entry for key, replacing any older one in a single rename so a
+// concurrent reader never sees half a file
+func (c *diskCache) store(key string, entry *diskEntry) error {
+	entry.URL = key
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// remove drops the entry for key
+func (c *diskCache) remove(key string) {
+	_ = os.Remove(c.path(key))
+}
+
+// fresh reports whether the entry can be used without asking the API
+func (e *diskEntry) fresh(now time.Time) bool {
+	return now.Before(e.Expires)
+}
+
+// setConditionalHeaders asks the API to answer 304 Not Modified if the entry still matches
+func (e *diskEntry) setConditionalHeaders(req *http.Request) {
+	if e.ETag != "" {
+		req.Header.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		req.Header.Set("If-Modified-Since", e.LastModified)
+	}
+}
+
+// cachePolicy is what a response's Cache-Control header allows
+type cachePolicy struct {
+	noStore bool          // the response must not be kept at all
+	maxAge  time.Duration // how long it may be used without revalidating
+}
+
+// parseCacheControl reads the directives that matter to a private client cache.
+// Without max-age, or with no-cache, a kept response is revalidated before each use.
+func parseCacheControl(header http.Header) cachePolicy {
+	var policy cachePolicy
+	noCache := false
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store":
+			policy.noStore = true
+		case "no-cache":
+			noCache = true
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil && seconds > 0 {
+				policy.maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	if noCache {
+		policy.maxAge = 0
+	}
+	return policy
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// httpTransport is the Transport NewClient builds: it fetches from the API over
+// HTTP, answering from the memory and disk caches when it can, sharing identical
+// requests in flight and counting requests, cache hits and outcomes for Stats
+type httpTransport struct {
+	client   *http.Client
+	limiter  *tokenBucket // nil when unlimited
+	baseURL  string
+	requests requestGroup
+	cache    *responseCache
+	disk     *diskCache
+	stats    statsRecorder
+	offline  bool
+	logf     func(format string, v ...interface{})
+}
+
+// Fetch implements Transport
+func (t *httpTransport) Fetch(path string) ([]byte, error) {
+	resp, err := t.get(t.baseURL + path)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{Code: resp.StatusCode}
+	}
+	return resp.Body, nil
+}
+
+// ping asks the API for its smallest resource, bypassing every cache, and
+// returns how long it took to answer
+func (t *httpTransport) ping() (time.Duration, error) {
+	req, err := http.NewRequest(http.MethodGet, t.baseURL+"/knowncount", nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	t.wait()
+	start := time.Now()
+	httpResp, err := t.client.Do(req)
+	latency := time.Since(start)
+	if err == nil {
+		_, _ = io.Copy(io.Discard, io.LimitReader(httpResp.Body, MaxResponseSize))
+		_ = httpResp.Body.Close()
+		if httpResp.StatusCode != http.StatusOK {
+			err = &StatusError{Code: httpResp.StatusCode}
+		}
+	}
+
+	t.stats.recordOutcome(err, time.Now())
+	if err != nil {
+		t.logf("Ping of %s failed: %v", t.baseURL, err)
+	}
+	return latency, err
+}
+
+// get fetches a URL, answering from the cache when possible and sharing the
+// response with any identical request already in flight. A copy kept on disk by an
+// earlier run is used while the API says it is fresh, then revalidated with a
+// conditional request so an unchanged body is not downloaded again.
+func (t *httpTransport) get(targetUrl string) (*response, error) {
+	if t.cache != nil {
+		if resp, ok := t.cache.get(targetUrl); ok {
+			t.stats.record(true, 0)
+			return resp, nil
+		}
+	}
+
+	var stored *diskEntry
+	if t.disk != nil {
+		if entry, ok := t.disk.load(targetUrl); ok {
+			if entry.fresh(time.Now()) {
+				resp := &response{StatusCode: http.StatusOK, Body: entry.Body}
+				if t.cache != nil {
+					t.cache.put(targetUrl, resp)
+				}
+				t.stats.record(true, 0)
+				return resp, nil
+			}
+			stored = entry
+		}
+	}
+
+	if t.offline {
+		return nil, ErrOffline
+	}
+
+	start := time.Now()
+	resp, err, shared := t.requests.Do(targetUrl, func() (*response, error) {
+		return t.fetch(targetUrl, stored)
+	})
+
+	t.stats.record(shared, time.Since(start))
+	outcome := err
+	if err == nil && resp.StatusCode >= http.StatusInternalServerError {
+		outcome = &StatusError{Code: resp.StatusCode}
+	}
+	t.stats.recordOutcome(outcome, time.Now())
+	if err != nil {
+		t.logf("Request to %s failed: %v", targetUrl, err)
+	}
+
+	return resp, err
+}
+
+// wait takes a turn from the rate limiter. It waits before the request is sent,
+// so time spent queued behind other requests does not count against the HTTP
+// client's timeout.
+func (t *httpTransport) wait() {
+	if t.limiter != nil {
+		_ = t.limiter.Wait(context.Background())
+	}
+}
+
+// fetch sends the request, conditional on the stored copy if there is one, and
+// keeps what comes back according to the response's Cache-Control
+func (t *httpTransport) fetch(targetUrl string, stored *diskEntry) (*response, error) {
+	req, err := http.NewRequest(http.MethodGet, targetUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	if stored != nil {
+		stored.setConditionalHeaders(req)
+	}
+
+	t.wait()
+	httpResp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			t.logf("Error closing response body for %s: %v", targetUrl, err)
+		}
+	}(httpResp.Body)
+
+	limitedReader := io.LimitReader(httpResp.Body, MaxResponseSize)
+	body, err := io.ReadAll(limitedReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	policy := parseCacheControl(httpResp.Header)
+	resp := &response{StatusCode: httpResp.StatusCode, Body: body}
+
+	switch {
+	case httpResp.StatusCode == http.StatusNotModified && stored != nil:
+		t.stats.recordNotModified()
+		resp = &response{StatusCode: http.StatusOK, Body: stored.Body}
+		if etag := httpResp.Header.Get("ETag"); etag != "" {
+			stored.ETag = etag
+		}
+		stored.Expires = time.Now().Add(policy.maxAge)
+		t.keepOnDisk(targetUrl, stored, policy)
+	case httpResp.StatusCode == http.StatusOK:
+		t.keepOnDisk(targetUrl, &diskEntry{
+			ETag:         httpResp.Header.Get("ETag"),
+			LastModified: httpResp.Header.Get("Last-Modified"),
+			Expires:      time.Now().Add(policy.maxAge),
+			Body:         body,
+		}, policy)
+	}
+
+	if t.cache != nil && resp.StatusCode == http.StatusOK && !policy.noStore {
+		t.cache.put(targetUrl, resp)
+	}
+	return resp, nil
+}
+
+// keepOnDisk saves an entry for the next run, unless the API asked for it not to be
+// stored or sent nothing to revalidate it with
+func (t *httpTransport) keepOnDisk(targetUrl string, entry *diskEntry, policy cachePolicy) {
+	if t.disk == nil {
+		return
+	}
+	if policy.noStore || (entry.ETag == "" && entry.LastModified == "" && policy.maxAge == 0) {
+		t.disk.remove(targetUrl)
+		return
+	}
+	if err := t.disk.store(targetUrl, entry); err != nil {
+		t.logf("Could not cache %s on disk: %v", targetUrl, err)
+	}
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// SchemaDrift lists the body fields the API and models.CelestialBody disagree on:
+// keys the API sent that the model has no field for, which are data we could be
+// showing, and fields the model expects that the API stopped sending
+type SchemaDrift struct {
+	Unknown []string
+	Missing []string
+}
+
+// Empty reports whether the API and the model agree
+func (d SchemaDrift) Empty() bool {
+	return len(d.Unknown) == 0 && len(d.Missing) == 0
+}
+
+// systemFileFields are body fields only system files fill in; the API never sends
+// them, so their absence is not drift
+var systemFileFields = map[string]bool{
+	"aliases":         true,
+	"temperature":     true,
+	"stellarClass":    true,
+	"age":             true,
+	"luminosity":      true,
+	"metallicity":     true,
+	"spectralType":    true,
+	"orbitalElements": true,
+	"displayColor":    true,
+	"symbol":          true,
+}
+
+// bodyFields returns the JSON keys of models.CelestialBody
+func bodyFields() map[string]bool {
+	fields := make(map[string]bool)
+	bodyType := reflect.TypeOf(models.CelestialBody{})
+	for i := 0; i < bodyType.NumField(); i++ {
+		name, _, _ := strings.Cut(bodyType.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
+
+// driftDetector compares the bodies the API sends with the model, reporting each
+// difference once per run
+type driftDetector struct {
+	mu       sync.Mutex
+	known    map[string]bool
+	unknown  map[string]bool
+	missing  map[string]bool
+	reported SchemaDrift
+}
+
+func newDriftDetector() *driftDetector {
+	return &driftDetector{
+		known:   bodyFields(),
+		unknown: make(map[string]bool),
+		missing: make(map[string]bool),
+	}
+}
+
+// inspect compares the raw bodies of one response with the model and returns the
+// differences not seen before. A field counts as missing only when none of the
+// bodies has it, as the API leaves some out for bodies it knows little about.
+func (d *driftDetector) inspect(bodies []map[string]json.RawMessage) SchemaDrift {
+	if len(bodies) == 0 {
+		return SchemaDrift{}
+	}
+
+	seen := make(map[string]bool)
+	for _, body := range bodies {
+		for key := range body {
+			seen[key] = true
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var drift SchemaDrift
+	for key := range seen {
+		if !d.known[key] && !d.unknown[key] {
+			d.unknown[key] = true
+			drift.Unknown = append(drift.Unknown, key)
+		}
+	}
+	for key := range d.known {
+		if !seen[key] && !systemFileFields[key] && !d.missing[key] {
+			d.missing[key] = true
+			drift.Missing = append(drift.Missing, key)
+		}
+	}
+	sort.Strings(drift.Unknown)
+	sort.Strings(drift.Missing)
+
+	d.reported.Unknown = appendSorted(d.reported.Unknown, drift.Unknown)
+	d.reported.Missing = appendSorted(d.reported.Missing, drift.Missing)
+	return drift
+}
+
+// snapshot returns every difference reported so far
+func (d *driftDetector) snapshot() SchemaDrift {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return SchemaDrift{
+		Unknown: append([]string(nil), d.reported.Unknown...),
+		Missing: append([]string(nil), d.reported.Missing...),
+	}
+}
+
+// appendSorted returns a new sorted slice of list and more, so that snapshots
+// already handed out are never changed
+func appendSorted(list, more []string) []string {
+	if len(more) == 0 {
+		return list
+	}
+	merged := append(append([]string(nil), list...), more...)
+	sort.Strings(merged)
+	return merged
+}
+
+// checkSchema looks for drift in a response of bodies (the list response when
+// list is set, otherwise a single body) and logs what is new. A body the detector
+// cannot read is left to the decoding that follows to report.
+func (c *Client) checkSchema(data []byte, list bool) {
+	var bodies []map[string]json.RawMessage
+	if list {
+		var raw struct {
+			Bodies []map[string]json.RawMessage `json:"bodies"`
+		}
+		if json.Unmarshal(data, &raw) != nil {
+			return
+		}
+		bodies = raw.Bodies
+	} else {
+		var raw map[string]json.RawMessage
+		if json.Unmarshal(data, &raw) != nil {
+			return
+		}
+		bodies = append(bodies, raw)
+	}
+
+	drift := c.drift.inspect(bodies)
+	if len(drift.Unknown) > 0 {
+		c.logf("API sends body fields the model does not read: %s", strings.Join(drift.Unknown, ", "))
+	}
+	if len(drift.Missing) > 0 {
+		c.logf("API no longer sends body fields the model expects: %s", strings.Join(drift.Missing, ", "))
+	}
+}
+
+// SchemaDrift returns the body fields seen so far this run that the API sends but
+// the model does not read, and those the model reads but the API no longer sends
+func (c *Client) SchemaDrift() SchemaDrift {
+	return c.drift.snapshot()
+}
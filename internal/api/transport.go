@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Transport fetches API resources by path, such as "/bodies", "/bodies/terre" or
+// "/knowncount", as the JSON the API answers with. The Client decodes, validates
+// and cites what comes back, so any source of that JSON can stand in for the API.
+type Transport interface {
+	Fetch(path string) ([]byte, error)
+}
+
+// ErrNotFound is returned by transports that do not have a resource. For a
+// filtered list or a single body the Client then picks them out of "/bodies".
+var ErrNotFound = errors.New("not found")
+
+// StatusError is an API answer other than 200 OK
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API returned status %d", e.Code)
+}
+
+// StaticTransport answers from JSON held in memory, keyed by path: for tests and
+// fixtures
+type StaticTransport map[string][]byte
+
+// Fetch implements Transport
+func (t StaticTransport) Fetch(path string) ([]byte, error) {
+	data, ok := t[path]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", path, ErrNotFound)
+	}
+	return data, nil
+}
+
+// DumpTransport answers from JSON files saved from the API under dir, a path to a
+// file with .json added: dir/bodies.json, dir/bodies/terre.json,
+// dir/knowncount.json. Filtered lists come from bodies.json.
+func DumpTransport(dir string) Transport {
+	return dumpTransport(dir)
+}
+
+type dumpTransport string
+
+// Fetch implements Transport
+func (t dumpTransport) Fetch(path string) ([]byte, error) {
+	name, err := url.PathUnescape(strings.TrimPrefix(path, "/"))
+	if err != nil || strings.Contains(name, "?") || strings.Contains(name, "..") {
+		return nil, fmt.Errorf("%s: %w", path, ErrNotFound)
+	}
+	data, err := os.ReadFile(filepath.Join(string(t), filepath.FromSlash(name)+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", path, ErrNotFound)
+	}
+	return data, err
+}
+
+// userAgentTransport wraps another RoundTripper, stamping every request with the
+// client's User-Agent
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.userAgent != "" {
+		// RoundTrippers must not modify the caller's request
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// tokenBucket allows bursts of up to burst requests, refilling at rate tokens per second
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	lastFill time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:     rate,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		lastFill: time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or the context is done
+func (tb *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		delay := tb.reserve()
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available, otherwise returns how long until one is
+func (tb *tokenBucket) reserve() time.Duration {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	now := time.Now()
+	tb.tokens += now.Sub(tb.lastFill).Seconds() * tb.rate
+	if tb.tokens > tb.burst {
+		tb.tokens = tb.burst
+	}
+	tb.lastFill = now
+
+	if tb.tokens >= 1 {
+		tb.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - tb.tokens) / tb.rate * float64(time.Second))
+}
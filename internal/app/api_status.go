@@ -0,0 +1,196 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/api"
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/gdamore/tcell/v2"
+)
+
+// apiStatusLine is one row of the API status modal; headings have no value and
+// warnings stand out
+type apiStatusLine struct {
+	heading bool
+	label   string
+	value   string
+	warning bool
+}
+
+// apiCheckEvent carries the result of a connectivity check into the event loop
+type apiCheckEvent struct {
+	tcell.EventTime
+	latency time.Duration
+	err     error
+}
+
+// checkAPI asks the API for its smallest resource off the event goroutine,
+// bypassing the caches, so a stale cached answer cannot hide a network problem
+func (ed *EventDispatcher) checkAPI() {
+	client := ed.uiRenderer.client
+	if client == nil || ed.state.APIChecking {
+		return
+	}
+	ed.state.APIChecking = true
+
+	screen := ed.uiRenderer.screen
+	ed.uiRenderer.background.Go("API check", func(ctx context.Context) error {
+		latency, err := client.Ping()
+		event := &apiCheckEvent{latency: latency, err: err}
+		event.SetEventNow()
+		ctx, cancel := context.WithTimeout(ctx, constants.DefaultTimeout)
+		defer cancel()
+		postEvent(ctx, screen, event)
+		return nil
+	})
+}
+
+// applyAPICheck records the result of a connectivity check
+func (ed *EventDispatcher) applyAPICheck(ev *apiCheckEvent) {
+	ed.state.APIChecking = false
+	ed.state.APICheckedAt = ev.When()
+	ed.state.APICheckLatency = ev.latency
+	ed.state.APICheckError = ""
+	if ev.err != nil {
+		ed.state.APICheckError = ev.err.Error()
+		ed.state.SetStatusMessage("API unreachable: "+ev.err.Error(), statusMessageDuration)
+		return
+	}
+	ed.state.SetStatusMessage(fmt.Sprintf("API answered in %s", ev.latency.Round(time.Millisecond)), statusMessageDuration)
+}
+
+// handleAPIStatusKeys handles keyboard input while the API status is open
+func (ed *EventDispatcher) handleAPIStatusKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyEnter:
+		ed.state.PopModal()
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'r', 'R':
+			ed.checkAPI()
+		case 'q', 'Q', 'b', 'B':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// apiStatusLines describes the API connection: whether it answers, the last
+// check run from the modal, what the caches hold and where requests go
+func apiStatusLines(state *AppState, client *api.Client) []apiStatusLine {
+	if client == nil {
+		return []apiStatusLine{{label: "No API client in this session", warning: true}}
+	}
+	stats := client.Stats()
+	settings := client.Settings()
+
+	lines := []apiStatusLine{{heading: true, label: "Connection"}}
+	switch {
+	case settings.Offline:
+		lines = append(lines, apiStatusLine{label: "Status", value: "Offline: only the body store is used"})
+	case stats.Reachable():
+		lines = append(lines, apiStatusLine{label: "Status", value: "Reachable"})
+	case !stats.LastFailure.IsZero():
+		lines = append(lines, apiStatusLine{label: "Status", value: "Unreachable", warning: true})
+	default:
+		lines = append(lines, apiStatusLine{label: "Status", value: "Not contacted yet"})
+	}
+	lines = append(lines, apiStatusLine{label: "Last answer", value: formatWhen(stats.LastSuccess)})
+	if !stats.LastFailure.IsZero() {
+		lines = append(lines, apiStatusLine{label: "Last failure", value: formatWhen(stats.LastFailure) + ": " + stats.LastError, warning: true})
+	}
+	switch {
+	case state.APIChecking:
+		lines = append(lines, apiStatusLine{label: "Check", value: "asking the API..."})
+	case state.APICheckError != "":
+		lines = append(lines, apiStatusLine{label: "Check", value: "failed " + formatWhen(state.APICheckedAt), warning: true})
+	case !state.APICheckedAt.IsZero():
+		lines = append(lines, apiStatusLine{label: "Check", value: fmt.Sprintf("answered in %s, %s", state.APICheckLatency.Round(time.Millisecond), formatWhen(state.APICheckedAt))})
+	}
+	if stats.LastLatency > 0 {
+		lines = append(lines, apiStatusLine{label: "Last round trip", value: stats.LastLatency.Round(time.Millisecond).String()})
+	}
+
+	lines = append(lines, apiStatusLine{}, apiStatusLine{heading: true, label: "Caches"})
+	memory := "off"
+	if settings.CacheTTL > 0 {
+		memory = fmt.Sprintf("%d responses, each kept %s", stats.CachedResponses, settings.CacheTTL)
+	}
+	disk := "off"
+	if settings.DiskCacheDir != "" {
+		disk = settings.DiskCacheDir
+	}
+	store := "off"
+	if settings.Store {
+		store = "on: bodies are kept for when the API cannot answer"
+	}
+	lines = append(lines,
+		apiStatusLine{label: "In memory", value: memory},
+		apiStatusLine{label: "On disk", value: disk},
+		apiStatusLine{label: "Body store", value: store},
+		apiStatusLine{label: "Requests", value: fmt.Sprintf("%d, %d from the cache (%.0f%%), %d not modified", stats.Requests, stats.CacheHits, stats.HitRate()*100, stats.NotModified)},
+	)
+
+	rate := "unlimited"
+	if settings.RequestsPerSecond > 0 {
+		rate = fmt.Sprintf("%g a second, bursts of %d", settings.RequestsPerSecond, settings.Burst)
+	}
+	lines = append(lines,
+		apiStatusLine{},
+		apiStatusLine{heading: true, label: "Endpoints"},
+		apiStatusLine{label: "API", value: settings.BaseURL},
+		apiStatusLine{label: "User agent", value: settings.UserAgent},
+		apiStatusLine{label: "Rate limit", value: rate},
+	)
+	return lines
+}
+
+// formatWhen writes a time of day with how long ago it was, or "never"
+func formatWhen(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return fmt.Sprintf("at %s (%s ago)", t.Format("15:04:05"), time.Since(t).Round(time.Second))
+}
+
+// drawAPIStatusModal renders the API connection, caches and endpoints
+func (ur *UIRenderer) drawAPIStatusModal(width, height int) {
+	lines := apiStatusLines(ur.state, ur.client)
+	modalX, modalY, _, modalHeight := ur.setupModal(width, height, fitModalHeight(len(lines), height))
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, " 📡 API Status ")
+
+	headingStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue).Bold(true)
+	labelStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	valueStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	warningStyle := tcell.StyleDefault.Foreground(tcell.ColorOrange).Background(tcell.ColorDarkBlue)
+
+	lastRow := modalY + modalHeight - 3
+	for i, line := range lines {
+		y := modalY + 3 + i
+		if y > lastRow {
+			break
+		}
+		if line.heading {
+			ur.drawText(modalX+2, y, headingStyle, line.label)
+			continue
+		}
+		style := valueStyle
+		if line.warning {
+			style = warningStyle
+		}
+		if line.value == "" {
+			ur.drawText(modalX+4, y, style, line.label)
+			continue
+		}
+		ur.drawText(modalX+4, y, labelStyle, line.label)
+		ur.drawText(modalX+4+statsLabelColumn, y, style, truncateText(line.value, ur.contentWidth()-statsLabelColumn-2))
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "Press 'r' to check the API now; Enter, Escape, or 'b' to close")
+}
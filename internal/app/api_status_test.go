@@ -0,0 +1,60 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/api"
+	"github.com/furan917/go-solar-system/internal/config"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestAPIStatusRetry(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+	dispatcher.uiRenderer.client = api.NewClient(api.WithOffline())
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'n', tcell.ModNone))
+	dispatcher.uiRenderer.DrawScreen()
+	if state.TopModal() != ModalAPIStatus {
+		t.Fatalf("Expected n to open the API status, got modal %v", state.TopModal())
+	}
+	if text := screenText(screen); !strings.Contains(text, "Offline") || !strings.Contains(text, "Endpoints") {
+		t.Fatalf("Expected the connection and endpoints shown, got:\n%s", text)
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone))
+	if !state.APIChecking {
+		t.Fatal("Expected r to start a check")
+	}
+	for {
+		if ev, ok := screen.PollEvent().(*apiCheckEvent); ok {
+			dispatcher.HandleEvent(ev)
+			break
+		}
+	}
+	if state.APIChecking || state.APICheckError == "" {
+		t.Errorf("Expected the offline check to fail, got checking %v, error %q", state.APIChecking, state.APICheckError)
+	}
+	if !strings.Contains(state.GetStatusMessage(), "API unreachable") {
+		t.Errorf("Expected the failure on the status line, got %q", state.GetStatusMessage())
+	}
+}
+
+func TestConfigSetsUserAgent(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	ss, err := NewSolarSystem(Options{
+		Screen:     tcell.NewSimulationScreen("UTF-8"),
+		Config:     config.Config{UserAgent: "planetarium-kiosk/1.0 (admin@example.org)"},
+		SystemsDir: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("NewSolarSystem() error = %v", err)
+	}
+	t.Cleanup(ss.screen.Fini)
+
+	if got := ss.renderer.client.Settings().UserAgent; got != "planetarium-kiosk/1.0 (admin@example.org)" {
+		t.Errorf("UserAgent = %q, want the one from the config", got)
+	}
+}
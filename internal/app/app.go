@@ -2,15 +2,26 @@ package app
 
 import (
 	"context"
-	"log"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"runtime"
 	"time"
 
+	"github.com/furan917/go-solar-system/internal/analytics"
 	"github.com/furan917/go-solar-system/internal/api"
+	"github.com/furan917/go-solar-system/internal/bodystore"
+	"github.com/furan917/go-solar-system/internal/config"
 	"github.com/furan917/go-solar-system/internal/constants"
-	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/display"
+	"github.com/furan917/go-solar-system/internal/events"
+	"github.com/furan917/go-solar-system/internal/inputlog"
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/logging"
 	"github.com/furan917/go-solar-system/internal/systems"
+	"github.com/furan917/go-solar-system/internal/updates"
 	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/furan917/go-solar-system/internal/watch"
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -19,7 +30,7 @@ type SolarSystem struct {
 	screen       tcell.Screen
 	state        *AppState
 	errorHandler *ErrorHandler
-	logger       *log.Logger
+	logger       *logging.Logger
 
 	// Business logic components
 	planetService *PlanetService
@@ -29,36 +40,239 @@ type SolarSystem struct {
 	renderer        *UIRenderer
 	eventDispatcher *EventDispatcher
 	mouseHandler    *MouseEventHandler
+
+	// Live sync session to mirror, if any
+	syncFollow string
+
+	// Automation commands, read from this file, FIFO or "-" for stdin
+	control string
+
+	// Controller inputs, read from this joystick device or bridge FIFO
+	gamepad string
+
+	// Background checks of watched bodies
+	watcher *watchPoller
+
+	// How often the Solar System is fetched again; 0 for never
+	refreshEvery time.Duration
+
+	// Goroutines and the failures they report, stopped when the main loop ends
+	background *background
+
+	// Opt-in record of what is looked at; nil when off
+	analytics *analytics.Recorder
+	store     *bodystore.Store // nil unless bodies are kept
+
+	// Record of input for bug reports; nil when off
+	inputs *inputlog.Recorder
 }
 
-func NewSolarSystem() (*SolarSystem, error) {
-	logger := log.New(os.Stderr, "[SolarSystem] ", log.LstdFlags|log.Lshortfile)
+// Options configures a SolarSystem
+type Options struct {
+	// Logger receives application logs; nil discards them. Never point it at
+	// stdout or stderr, which the terminal UI owns.
+	Logger *logging.Logger
+
+	// Debug shows the debug overlay from the start
+	Debug bool
+
+	// Config holds the user's preferences
+	Config config.Config
+
+	// ConfigPath is where Config was loaded from and calibrated settings are saved;
+	// empty disables saving
+	ConfigPath string
+
+	// ASCII forces plain ASCII symbols instead of detecting what the terminal can show
+	ASCII bool
+
+	// Deterministic runs the animation on a clock that starts at J2000 and moves
+	// a fixed step per frame, so screenshots and recordings can be reproduced
+	Deterministic bool
+
+	// Palette, if set, names the map colors for this run over Config.Palette
+	Palette string
+
+	// FPS, if set, is the frame rate for this run over Config.FPS
+	FPS int
+
+	// IdleSeconds, if set, is how long without input before the frame rate drops
+	// for this run, over Config.IdleSeconds
+	IdleSeconds int
+
+	// SyncListen, if set, is the address to broadcast the session on for live sync
+	SyncListen string
+
+	// SyncOrigins are browser origins, such as http://localhost:3000, allowed to
+	// follow the broadcast besides pages on SyncListen's own host
+	SyncOrigins []string
+
+	// SyncFollow, if set, is the ws:// URL of a session to mirror
+	SyncFollow string
+
+	// Control, if set, is a file or FIFO to read automation commands from, one per
+	// line; "-" reads them from standard input
+	Control string
+
+	// Gamepad, if set, is a joystick device such as /dev/input/js0, or a FIFO a
+	// bridge writes input names to, to navigate with a controller
+	Gamepad string
+
+	// Offline never contacts the API, showing only what the body store holds
+	Offline bool
+
+	// BuiltinSystems holds system files shipped with the app, offered beside those
+	// in the systems folder; nil offers only the folder's
+	BuiltinSystems fs.FS
+
+	// RecordInput writes every key, mouse and resize event, with the terminal's
+	// size, TERM and locale, to a recording for bug reports
+	RecordInput bool
+
+	// Screen, if set, is drawn on instead of the terminal, such as a
+	// tcell.SimulationScreen in tests. NewSolarSystem initializes it.
+	Screen tcell.Screen
+
+	// Client, if set, answers for the API instead of a client of the real one. The
+	// body store and Offline are then up to whoever made it.
+	Client *api.Client
+
+	// SystemsDir is the folder system files are read from; empty is "systems"
+	SystemsDir string
+}
+
+func NewSolarSystem(opts Options) (*SolarSystem, error) {
+	logger := opts.Logger
+	if logger == nil {
+		logger = logging.Discard()
+	}
 
 	// Initialize core dependencies
-	client := api.NewClient()
-	systemManager := systems.NewSystemManager("systems")
+	clientOptions := []api.Option{api.WithLogger(logger.Logger), api.WithDiskCache(api.DefaultDiskCacheDir())}
+	if opts.Config.UserAgent != "" {
+		clientOptions = append(clientOptions, api.WithUserAgent(opts.Config.UserAgent))
+	}
+	var store *bodystore.Store
+	if opts.Client == nil && (opts.Config.Store || opts.Offline) {
+		var err error
+		store, err = bodystore.Open(bodystore.DefaultPath())
+		switch {
+		case err != nil && opts.Offline:
+			return nil, NewSystemError("failed to open the body store", err)
+		case err != nil:
+			logger.Printf("Not keeping bodies: %v", err)
+		default:
+			clientOptions = append(clientOptions, api.WithStore(store))
+		}
+	}
+	if opts.Offline {
+		clientOptions = append(clientOptions, api.WithOffline())
+	}
+	client := opts.Client
+	if client == nil {
+		client = api.NewClient(clientOptions...)
+	}
+	systemsDir := opts.SystemsDir
+	if systemsDir == "" {
+		systemsDir = "systems"
+	}
+	systemManager := systems.NewSystemManager(systemsDir)
+	if opts.BuiltinSystems != nil {
+		if err := systemManager.AddEmbeddedSystems(opts.BuiltinSystems); err != nil {
+			return nil, NewSystemError("failed to read built-in systems", err)
+		}
+	}
 	if err := systemManager.ScanSystems(); err != nil {
 		return nil, NewSystemError("failed to scan systems", err)
 	}
 
-	screen, err := tcell.NewScreen()
-	if err != nil {
-		return nil, NewUIError("failed to create screen", err)
+	screen := opts.Screen
+	if screen == nil {
+		var err error
+		screen, err = tcell.NewScreen()
+		if err != nil {
+			return nil, NewUIError("failed to create screen", err)
+		}
 	}
 
 	if err := screen.Init(); err != nil {
 		return nil, NewUIError("failed to initialize screen", err)
 	}
 
+	keys := keymap.Default()
+	for _, err := range keys.Remap(opts.Config.Keys) {
+		logger.Printf("Ignoring key binding from config: %v", err)
+	}
+
 	// Initialize state and core components
 	state := NewAppState()
+	if opts.Debug {
+		state.ToggleDebugOverlay()
+	}
 	errorHandler := NewErrorHandler(logger, state)
 	planetService := NewPlanetService(client, systemManager)
+	statsService := NewStatsService(client, systemManager)
 
 	// Initialize rendering components
 	width, height := screen.Size()
 	renderer := visualization.NewRendererWithDefaults(width, height)
-	uiRenderer := NewUIRenderer(screen, renderer, systemManager, state)
+	renderMode, err := visualization.ParseRenderMode(opts.Config.RenderMode)
+	if err != nil {
+		logger.Printf("Ignoring render mode from config: %v", err)
+	}
+	symbols := visualization.ASCIISymbols
+	if !opts.ASCII {
+		symbols = visualization.DetectSymbolSet(visualization.TerminalEnv{
+			GOOS:       runtime.GOOS,
+			Getenv:     os.Getenv,
+			CanDisplay: func(r rune) bool { return screen.CanDisplay(r, false) },
+		})
+	}
+	symbols, errs := symbols.WithClassSymbols(opts.Config.Symbols)
+	for _, err := range errs {
+		logger.Printf("Ignoring symbol from config: %v", err)
+	}
+	renderer.SetSymbols(symbols)
+	if symbols.ASCII && renderMode != visualization.RenderModeCells {
+		logger.Printf("Render mode %s needs Unicode, drawing cells instead", renderMode)
+		renderMode = visualization.RenderModeCells
+	}
+	renderer.SetRenderMode(renderMode)
+	paletteName := opts.Config.Palette
+	if opts.Palette != "" {
+		paletteName = opts.Palette
+	}
+	palette, err := visualization.ParsePalette(paletteName)
+	if err != nil {
+		logger.Printf("Ignoring palette: %v", err)
+	}
+	renderer.SetPalette(palette)
+	aspectRatio, measuredAspect := resolveAspectRatio(opts.Config, logger)
+	renderer.SetAspectRatio(aspectRatio)
+	uiRenderer := NewUIRenderer(screen, renderer, systemManager, state, client, keys)
+	uiRenderer.background = newBackground(context.Background(), logger)
+	if opts.Deterministic {
+		uiRenderer.SetClock(newDeterministicClock())
+	}
+	fps, idleSeconds := opts.Config.FPS, opts.Config.IdleSeconds
+	if opts.FPS > 0 {
+		fps = opts.FPS
+	}
+	if opts.IdleSeconds > 0 {
+		idleSeconds = opts.IdleSeconds
+	}
+	uiRenderer.SetFrameRate(fps)
+	uiRenderer.SetIdleAfter(idleAfter(idleSeconds))
+	uiRenderer.images = newBodyImagesFor(opts.Config, aspectRatio, logger)
+	detailFields, errs := display.ParseFieldLayout(opts.Config.DetailFields)
+	for _, err := range errs {
+		logger.Printf("Ignoring detail field from config: %v", err)
+	}
+	units, err := display.ParseUnits(opts.Config.Units)
+	if err != nil {
+		logger.Printf("Ignoring units from config: %v", err)
+	}
+	uiRenderer.detailFields = detailFields.WithUnits(units)
 
 	// Initialize business logic components
 	systemManagerComponent := NewSystemManager(state, planetService, uiRenderer, errorHandler, logger)
@@ -66,10 +280,95 @@ func NewSolarSystem() (*SolarSystem, error) {
 	// Initialize event handling components
 	showMoonList := func() { state.ShowMoonList() }
 	showMoonDetails := func() { /* handled by mouse handler internally */ }
-	mouseHandler := NewMouseEventHandler(state, uiRenderer, showMoonList, showMoonDetails, planetService, systemManagerComponent)
-	eventDispatcher := NewEventDispatcher(state, mouseHandler, systemManagerComponent, planetService, uiRenderer)
+	var eventDispatcher *EventDispatcher
+	openElementEditor := func() { eventDispatcher.openElementEditor() }
+	mouseHandler := NewMouseEventHandler(state, uiRenderer, showMoonList, showMoonDetails, openElementEditor, planetService, systemManagerComponent)
+	eventDispatcher = NewEventDispatcher(state, mouseHandler, systemManagerComponent, planetService, statsService, uiRenderer, keys)
+	eventDispatcher.settings = &settings{path: opts.ConfigPath, config: opts.Config}
+	eventDispatcher.measuredAspect = measuredAspect
+
+	// Check for new and updated system files, if an update index is configured
+	if opts.Config.UpdateIndex != "" && opts.Config.UpdateKey != "" && !opts.Offline {
+		eventDispatcher.updater, err = updates.NewChecker(opts.Config.UpdateIndex, opts.Config.UpdateKey, nil)
+		if err != nil {
+			logger.Printf("Not checking for system updates: %v", err)
+		}
+	}
+
+	// Check watched bodies for changes in the API, remembering the last fetch of
+	// each next to the config file
+	watchPath := ""
+	if opts.ConfigPath != "" {
+		watchPath = filepath.Join(filepath.Dir(opts.ConfigPath), constants.WatchFileName)
+	}
+	watchStore, err := watch.Load(watchPath)
+	if err != nil {
+		logger.Printf("Starting with no watch snapshots: %v", err)
+	}
+	state.SetWatchlist(opts.Config.Watchlist)
+	watchPoller := newWatchPoller(client, watchStore, state, logger)
+	eventDispatcher.watcher = watchPoller
+
+	// Raise alerts as the simulated timeline passes orbital events
+	watcher := newEventWatcher(state, events.NewEngine(renderer.GetEphemeris()), renderer.GetClock())
+	uiRenderer.AddFrameHook(watcher.onFrame)
+
+	// Name the terminal window after what is on screen
+	uiRenderer.AddFrameHook((&titleUpdater{}).onFrame)
+
+	// Look up the names of moons the API lists without one while they are on screen
+	hydrator := newMoonHydrator(client, renderer.GetMoonHandler(), uiRenderer.background, logger)
+	uiRenderer.AddFrameHook(hydrator.onFrame)
+
+	// Record which systems and bodies are looked at, if the user opted in
+	var recorder *analytics.Recorder
+	if opts.Config.Analytics && opts.ConfigPath != "" {
+		recorder, err = analytics.Open(analytics.PathFor(opts.ConfigPath), time.Now())
+		if err != nil {
+			logger.Printf("Not recording analytics: %v", err)
+		} else {
+			uiRenderer.AddFrameHook(func(frame Frame) bool {
+				recorder.Observe(time.Now(), frame.System, frame.Selected.EnglishName)
+				return true
+			})
+		}
+	}
+
+	// Record input for a bug report, if asked to
+	var inputs *inputlog.Recorder
+	if opts.RecordInput {
+		inputs, err = inputlog.Create(inputlog.DefaultDir(), inputlog.CurrentEnvironment(width, height, time.Now()))
+		if err != nil {
+			logger.Printf("Not recording input: %v", err)
+		} else {
+			logger.Printf("Recording input to %s", inputs.Path())
+		}
+	}
+
+	if opts.SyncListen != "" {
+		err = startSyncServer(opts.SyncListen, opts.SyncOrigins, uiRenderer, logger)
+		if err != nil {
+			screen.Fini()
+			return nil, NewUIError("failed to start live sync", err)
+		}
+	}
+
+	// Fetch the Solar System again now and then, if asked to
+	refreshEvery := refreshInterval(opts.Config)
+	if opts.Offline {
+		refreshEvery = 0
+	}
 
 	return &SolarSystem{
+		background:      uiRenderer.background,
+		syncFollow:      opts.SyncFollow,
+		control:         opts.Control,
+		gamepad:         opts.Gamepad,
+		watcher:         watchPoller,
+		refreshEvery:    refreshEvery,
+		analytics:       recorder,
+		inputs:          inputs,
+		store:           store,
 		screen:          screen,
 		state:           state,
 		errorHandler:    errorHandler,
@@ -85,16 +384,26 @@ func NewSolarSystem() (*SolarSystem, error) {
 func (ss *SolarSystem) Run() error {
 	defer func() {
 		ss.screen.Fini()
+		if ss.analytics != nil {
+			if err := ss.analytics.Close(time.Now()); err != nil {
+				ss.logger.Printf("Failed to finish analytics: %v", err)
+			}
+		}
+		if ss.inputs != nil {
+			if err := ss.inputs.Close(); err != nil {
+				ss.logger.Printf("Failed to finish the input recording: %v", err)
+			}
+		}
+		if ss.store != nil {
+			if err := ss.store.Close(); err != nil {
+				ss.logger.Printf("Failed to close the body store: %v", err)
+			}
+		}
 		if err := RecoverFromPanic(); err != nil {
 			ss.errorHandler.HandleError(err)
 		}
 	}()
 
-	// Initialize system
-	if err := ss.initializeSystem(); err != nil {
-		return err
-	}
-
 	// Configure screen
 	ss.screen.SetStyle(tcell.StyleDefault.Background(tcell.ColorBlack).Foreground(tcell.ColorWhite))
 	ss.screen.Clear()
@@ -104,33 +413,6 @@ func (ss *SolarSystem) Run() error {
 	return ss.runMainLoop()
 }
 
-func (ss *SolarSystem) initializeSystem() error {
-	if err := ss.systemManager.LoadCurrentSystem(); err != nil {
-		ss.errorHandler.HandleError(NewSystemError("failed to load initial system", err))
-		return err
-	}
-
-	if err := ss.state.ValidateState(); err != nil {
-		ss.errorHandler.HandleError(NewStateError("invalid state after loading", err))
-	}
-
-	if err := ss.systemManager.SortPlanetsByDistance(); err != nil {
-		ss.errorHandler.HandleError(NewStateError("failed to sort planets", err))
-	}
-
-	// Process planets
-	planets := ss.systemManager.NormalizePlanetNames(ss.state.GetPlanets())
-	ss.state.SetPlanets(planets)
-
-	// Add central star if needed
-	centralStar := ss.systemManager.FindOrCreateCentralStar(ss.state.GetPlanets())
-	if !ss.systemManager.ContainsCentralStar(ss.state.GetPlanets()) {
-		ss.state.SetPlanets(append([]models.CelestialBody{centralStar}, ss.state.GetPlanets()...))
-	}
-
-	return nil
-}
-
 func (ss *SolarSystem) runMainLoop() error {
 	defer func() {
 		if err := RecoverFromPanic(); err != nil {
@@ -138,49 +420,140 @@ func (ss *SolarSystem) runMainLoop() error {
 		}
 	}()
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	defer ss.background.Stop()
 
-	// Start display update goroutine
-	go ss.updateDisplay(ctx)
+	// Draw the loading screen straight away and load the first system behind it
+	ss.state.Loading = "Starting up"
+	ss.state.Publish()
+	ss.background.Go("error forwarding", ss.forwardBackgroundErrors)
+	ss.background.Go("display", ss.updateDisplay)
+	system := ss.renderer.GetSystemManager().GetCurrentSystem()
+	ss.background.Go("startup", func(ctx context.Context) error {
+		ss.loadStartupSystem(ctx, system)
+		return nil
+	})
+	ss.background.Go("watchlist", ss.watcher.run)
 
 	// Main event loop
 	for ss.state.IsRunning() {
 		ev := ss.screen.PollEvent()
-		if err := ss.handleEventSafely(ev); err != nil {
-			response := ss.errorHandler.HandleError(err)
-			if response.ResetState {
-				ss.state.ResetModals()
+		if startup, ok := ev.(*startupEvent); ok {
+			if err := ss.applyStartup(startup); err != nil {
+				return err
+			}
+			ss.state.Publish()
+			ss.renderer.Wake()
+
+			// Mirrored sessions and automation drive a loaded system
+			if startup.done && ss.syncFollow != "" {
+				ss.background.Go("live sync", func(ctx context.Context) error { return ss.followSync(ctx, ss.syncFollow) })
+			}
+			if startup.done && ss.control != "" {
+				ss.background.Go("control", func(ctx context.Context) error { return ss.readControl(ctx, ss.control) })
+			}
+			if startup.done && ss.gamepad != "" {
+				ss.background.Go("gamepad", func(ctx context.Context) error { return ss.readGamepad(ctx, ss.gamepad) })
+			}
+			if startup.done && ss.refreshEvery > 0 {
+				ss.background.Go("refresh", func(ctx context.Context) error { return ss.refreshSolarSystem(ctx, ss.refreshEvery) })
+			}
+			if startup.done {
+				ss.eventDispatcher.checkForSystemUpdates()
+			}
+			continue
+		}
+		if refresh, ok := ev.(*refreshEvent); ok {
+			ss.systemManager.applyRefresh(refresh.bodies)
+			ss.state.Publish()
+			ss.renderer.Wake()
+			continue
+		}
+		if failure, ok := ev.(*backgroundErrorEvent); ok {
+			if !ss.applyBackgroundError(failure) {
+				break
+			}
+			continue
+		}
+		switch ev.(type) {
+		case *tcell.EventKey, *tcell.EventMouse, *gamepadEvent, *tcell.EventResize:
+			ss.renderer.NoteInput(time.Now())
+		}
+		if ss.analytics != nil {
+			switch ev.(type) {
+			case *tcell.EventKey, *tcell.EventMouse, *gamepadEvent:
+				ss.analytics.Activity(time.Now())
 			}
-			if !response.ShouldContinue {
+		}
+		if ss.inputs != nil {
+			ss.inputs.Record(ev)
+		}
+		if err := ss.handleEventSafely(ev); err != nil {
+			if !ss.handleError(err) {
 				break
 			}
 		}
+		ss.renderer.Wake()
 	}
 
-	cancel()
-	time.Sleep(50 * time.Millisecond)
 	return nil
 }
 
-func (ss *SolarSystem) updateDisplay(ctx context.Context) {
-	ticker := time.NewTicker(constants.DisplayUpdateRate)
+// updateDisplay draws frames until the app stops, as often as the renderer's
+// frame budget allows, and straight away after an event unless the last frame
+// has only just been drawn. A frame that panics is reported, only the first of
+// a run of them, and the next is drawn as usual, so closing whatever broke it
+// recovers.
+func (ss *SolarSystem) updateDisplay(ctx context.Context) error {
+	interval := ss.renderer.FrameInterval()
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
+	failing := false
+	drawn := time.Now()
 	for {
 		select {
 		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			if ss.state.IsRunning() {
-				ss.renderer.DrawScreen()
-			} else {
-				return
+			return nil
+		case <-ss.renderer.Woken():
+			if !ss.renderer.CanDrawEarly(time.Since(drawn)) {
+				// Too soon after the last frame, but input ends an idle spell
+				if next := ss.renderer.FrameInterval(); next < interval {
+					interval = next
+					ticker.Reset(interval)
+				}
+				continue
 			}
+			// Start the interval over, so the next tick doesn't follow right on
+			ticker.Reset(interval)
+		case <-ticker.C:
+		}
+		if !ss.state.IsRunning() {
+			return nil
+		}
+		err := ss.drawFrame()
+		drawn = time.Now()
+		if err != nil && !failing {
+			ss.background.Report("display", NewUIError("drawing the screen failed", err))
+		}
+		failing = err != nil
+		if next := ss.renderer.FrameInterval(); next != interval {
+			interval = next
+			ticker.Reset(interval)
 		}
 	}
 }
 
+// drawFrame draws one frame, returning the panic it died of, if it did
+func (ss *SolarSystem) drawFrame() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(r)
+		}
+	}()
+	ss.renderer.DrawScreen()
+	return nil
+}
+
 func (ss *SolarSystem) handleEventSafely(ev tcell.Event) error {
 	defer func() {
 		if r := recover(); r != nil {
@@ -0,0 +1,147 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/logging"
+	"github.com/gdamore/tcell/v2"
+)
+
+// backgroundStopTimeout is how long stopping waits for background tasks to wind
+// down before the app exits without them
+const backgroundStopTimeout = 250 * time.Millisecond
+
+// backgroundErrorQueue is how many failures can wait for the event loop before
+// more are logged and dropped
+const backgroundErrorQueue = 8
+
+// backgroundError is a failure, or recovered panic, of a background task
+type backgroundError struct {
+	task string
+	err  error
+}
+
+// backgroundErrorEvent carries a background task's failure into the event loop,
+// which hands it to the ErrorHandler
+type backgroundErrorEvent struct {
+	tcell.EventTime
+	backgroundError
+}
+
+// background runs the app's goroutines - the display ticker, startup loading,
+// the pollers and the lookups started from the UI - under one context, and
+// funnels what goes wrong in them to one channel so it reaches the ErrorHandler
+// instead of the void
+type background struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	errs   chan backgroundError
+	wg     sync.WaitGroup
+	logger *logging.Logger
+}
+
+// newBackground returns a runner whose tasks stop when parent is done, or when
+// it is stopped
+func newBackground(parent context.Context, logger *logging.Logger) *background {
+	ctx, cancel := context.WithCancel(parent)
+	return &background{ctx: ctx, cancel: cancel, errs: make(chan backgroundError, backgroundErrorQueue), logger: logger}
+}
+
+// Context returns the context every task runs under, to derive shorter-lived
+// ones from
+func (b *background) Context() context.Context {
+	return b.ctx
+}
+
+// Go runs task on its own goroutine, reporting the error it returns or the panic
+// it dies of. Errors from its context ending are the task stopping, not failing.
+func (b *background) Go(task string, run func(ctx context.Context) error) {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				b.Report(task, NewSystemError(task+" crashed", panicError(r)))
+			}
+		}()
+
+		if err := run(b.ctx); err != nil && !errors.Is(err, context.Canceled) {
+			b.Report(task, err)
+		}
+	}()
+}
+
+// Report hands a task's failure to whatever reads Errors, for tasks that carry on
+// after one. If the queue is full the failure is only logged.
+func (b *background) Report(task string, err error) {
+	select {
+	case b.errs <- backgroundError{task: task, err: err}:
+	default:
+		b.logger.Printf("Background %s failed, and too many failures are waiting to be reported: %v", task, err)
+	}
+}
+
+// Errors returns the channel background failures arrive on
+func (b *background) Errors() <-chan backgroundError {
+	return b.errs
+}
+
+// Stop ends every task's context and waits a little for them to return, so they
+// are not cut off halfway through writing something
+func (b *background) Stop() {
+	b.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(backgroundStopTimeout):
+		b.logger.Printf("Background tasks still running after %s, exiting without them", backgroundStopTimeout)
+	}
+}
+
+// forwardBackgroundErrors posts each background failure to the event loop until
+// ctx is done
+func (ss *SolarSystem) forwardBackgroundErrors(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case failure := <-ss.background.Errors():
+			event := &backgroundErrorEvent{backgroundError: failure}
+			event.SetEventNow()
+			postEvent(ctx, ss.screen, event)
+		}
+	}
+}
+
+// applyBackgroundError hands a background failure to the ErrorHandler and shows
+// what it says about it on the status line
+func (ss *SolarSystem) applyBackgroundError(ev *backgroundErrorEvent) bool {
+	err := ev.err
+	var appErr *AppError
+	if !errors.As(err, &appErr) {
+		err = NewSystemError(ev.task+" failed", err)
+	}
+	return ss.handleError(err)
+}
+
+// handleError hands an error from the event loop to the ErrorHandler and carries
+// out its response, reporting whether the app should keep running
+func (ss *SolarSystem) handleError(err error) bool {
+	response := ss.errorHandler.HandleError(err)
+	if response.ResetState {
+		ss.state.ResetModals()
+	}
+	if response.Message != "" {
+		ss.state.SetStatusMessage(response.Message, statusMessageDuration)
+	}
+	ss.state.Publish()
+	return response.ShouldContinue
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/api"
+	"github.com/furan917/go-solar-system/internal/logging"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/visualization"
+)
+
+// nextFailure waits for the next failure a background runner reports
+func nextFailure(t *testing.T, b *background) backgroundError {
+	t.Helper()
+	select {
+	case failure := <-b.Errors():
+		return failure
+	case <-time.After(time.Second):
+		t.Fatal("no failure reported")
+	}
+	return backgroundError{}
+}
+
+func TestBackgroundReportsFailuresAndPanics(t *testing.T) {
+	b := newBackground(context.Background(), logging.Discard())
+	defer b.Stop()
+
+	b.Go("poller", func(ctx context.Context) error { return errors.New("no route to host") })
+	if failure := nextFailure(t, b); failure.task != "poller" || failure.err.Error() != "no route to host" {
+		t.Errorf("failure = %+v, want the poller's error", failure)
+	}
+
+	b.Go("prefetch", func(ctx context.Context) error { panic("index out of range") })
+	failure := nextFailure(t, b)
+	var appErr *AppError
+	if failure.task != "prefetch" || !errors.As(failure.err, &appErr) || !strings.Contains(failure.err.Error(), "index out of range") {
+		t.Errorf("failure = %+v, want the prefetch panic as a system error", failure)
+	}
+}
+
+func TestBackgroundStopCancelsTasks(t *testing.T) {
+	b := newBackground(context.Background(), logging.Discard())
+
+	stopped := make(chan struct{})
+	b.Go("ticker", func(ctx context.Context) error {
+		<-ctx.Done()
+		close(stopped)
+		return ctx.Err()
+	})
+	b.Stop()
+
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("Stop() returned before the task saw its context end")
+	}
+	select {
+	case failure := <-b.Errors():
+		t.Errorf("stopping reported %+v, want nothing", failure)
+	default:
+	}
+}
+
+func TestBackgroundErrorReachesTheStatusLine(t *testing.T) {
+	_, state, _ := newAppFixture(t, 120, 40)
+	state.OpenModal(ModalHelp)
+	logger := logging.Discard()
+	ss := &SolarSystem{state: state, logger: logger, errorHandler: NewErrorHandler(logger, state)}
+
+	control := &backgroundErrorEvent{backgroundError: backgroundError{task: "control",
+		err: NewFileError("control channel /tmp/fifo unavailable", errors.New("permission denied"))}}
+	if !ss.applyBackgroundError(control) {
+		t.Fatal("a control channel failure stopped the app")
+	}
+	if got := state.GetStatusMessage(); got != "File Error: control channel /tmp/fifo unavailable" {
+		t.Errorf("status = %q", got)
+	}
+
+	display := &backgroundErrorEvent{backgroundError: backgroundError{task: "display",
+		err: NewUIError("drawing the screen failed", errors.New("nil map"))}}
+	ss.applyBackgroundError(display)
+	if state.IsAnyModalShowing() {
+		t.Error("a drawing failure left the modals open")
+	}
+}
+
+func TestMoonHydrationReportsFailedLookups(t *testing.T) {
+	b := newBackground(context.Background(), logging.Discard())
+	defer b.Stop()
+	moons := visualization.NewMoonHandler()
+	hydrator := newMoonHydrator(api.NewClient(api.WithOffline()), moons, b, logging.Discard())
+
+	hydrator.start(models.CelestialBody{EnglishName: "Saturn", Moons: []models.Moon{{ID: "s2004s12"}, {ID: "s2004s13"}, {ID: "s2004s17"}}})
+	failure := nextFailure(t, b)
+	var appErr *AppError
+	if failure.task != "moon names" || !errors.As(failure.err, &appErr) || appErr.Message != "could not name 3 moons of Saturn" {
+		t.Errorf("failure = %+v, want one report for the three moons", failure)
+	}
+	if !errors.Is(failure.err, api.ErrOffline) {
+		t.Errorf("failure = %v, want it to wrap the lookup error", failure.err)
+	}
+	select {
+	case failure := <-b.Errors():
+		t.Errorf("another failure reported: %+v", failure)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
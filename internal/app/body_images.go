@@ -0,0 +1,187 @@
+package app
+
+import (
+	"image"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/furan917/go-solar-system/internal/config"
+	"github.com/furan917/go-solar-system/internal/graphics"
+	"github.com/furan917/go-solar-system/internal/logging"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/termsize"
+	"github.com/gdamore/tcell/v2"
+)
+
+// defaultCellWidth is the pixel width assumed for a cell when the terminal does
+// not say; the height follows from the aspect ratio
+const defaultCellWidth = 10
+
+// bodyImages draws pictures of bodies in the details modal on terminals that can
+// show images. Pictures are fetched in the background; until one arrives, or if
+// there is none, the portrait stands in.
+type bodyImages struct {
+	protocol              graphics.Protocol
+	fetcher               *graphics.Fetcher
+	cellWidth, cellHeight int
+	logger                *logging.Logger
+
+	mu       sync.Mutex
+	pictures map[string]image.Image // nil while fetching or when there is none
+	fetched  map[string]bool
+
+	// want is the picture placed in this frame; shown is the one on the terminal.
+	// Both are only touched while drawing.
+	want, shown *imagePlacement
+	stale       bool
+}
+
+// imagePlacement is a picture of a body drawn over a block of cells
+type imagePlacement struct {
+	key              string
+	x, y, cols, rows int
+}
+
+// newBodyImagesFor sets up pictures as the config asks, returning nil when they
+// are off or the terminal cannot show them
+func newBodyImagesFor(cfg config.Config, aspectRatio float64, logger *logging.Logger) *bodyImages {
+	protocol, err := graphics.Resolve(cfg.Graphics, os.Getenv)
+	if err != nil {
+		logger.Printf("Ignoring graphics setting from config: %v", err)
+	}
+	if protocol == graphics.None || strings.EqualFold(cfg.ImageSource, "off") {
+		return nil
+	}
+
+	cellWidth, cellHeight, err := termsize.CellSize()
+	if err != nil {
+		logger.Debugf("Could not measure terminal cells, guessing for pictures: %v", err)
+		cellWidth, cellHeight = defaultCellWidth, int(defaultCellWidth*aspectRatio)
+	}
+	logger.Printf("Drawing pictures with %s graphics", protocol)
+	fetcher := graphics.NewFetcher(cfg.ImageSource, graphics.DefaultCacheDir(), nil)
+	return newBodyImages(protocol, fetcher, cellWidth, cellHeight, logger)
+}
+
+func newBodyImages(protocol graphics.Protocol, fetcher *graphics.Fetcher, cellWidth, cellHeight int, logger *logging.Logger) *bodyImages {
+	return &bodyImages{
+		protocol:   protocol,
+		fetcher:    fetcher,
+		cellWidth:  cellWidth,
+		cellHeight: cellHeight,
+		logger:     logger,
+		pictures:   make(map[string]image.Image),
+		fetched:    make(map[string]bool),
+	}
+}
+
+// imageKey identifies a body's picture
+func imageKey(body models.CelestialBody) string {
+	if body.ID != "" {
+		return body.ID
+	}
+	return body.EnglishName
+}
+
+// picture returns a body's picture, starting to fetch it the first time it is
+// asked for. ok is false until it has arrived, and for good if there is none.
+func (bi *bodyImages) picture(body models.CelestialBody) (image.Image, bool) {
+	key := imageKey(body)
+
+	bi.mu.Lock()
+	defer bi.mu.Unlock()
+	if bi.fetched[key] {
+		picture := bi.pictures[key]
+		return picture, picture != nil
+	}
+	if _, fetching := bi.pictures[key]; !fetching {
+		bi.pictures[key] = nil
+		go bi.fetch(key, body)
+	}
+	return nil, false
+}
+
+// fetch downloads a picture off the render goroutine
+func (bi *bodyImages) fetch(key string, body models.CelestialBody) {
+	picture, err := bi.fetcher.Fetch(body)
+	if err != nil {
+		bi.logger.Printf("No picture of %s: %v", body.EnglishName, err)
+	}
+
+	bi.mu.Lock()
+	defer bi.mu.Unlock()
+	bi.pictures[key] = picture
+	bi.fetched[key] = true
+}
+
+// place puts a body's picture in the top-right of a box of cells, returning false
+// while there is no picture to put there
+func (bi *bodyImages) place(body models.CelestialBody, x, y, cols, rows int) bool {
+	picture, ok := bi.picture(body)
+	if !ok {
+		return false
+	}
+	fitCols, fitRows := graphics.Fit(picture.Bounds(), cols, rows, bi.cellWidth, bi.cellHeight)
+	if fitCols == 0 {
+		return false
+	}
+	bi.want = &imagePlacement{key: imageKey(body), x: x + cols - fitCols, y: y, cols: fitCols, rows: fitRows}
+	return true
+}
+
+// startFrame forgets the last frame's placement before drawing the next
+func (bi *bodyImages) startFrame() {
+	bi.want = nil
+}
+
+// forget notes that the screen has been redrawn from scratch, which wipes sixel
+// pictures, so the next frame sends its picture again
+func (bi *bodyImages) forget() {
+	bi.stale = true
+}
+
+// flush sends the picture placed in this frame once the frame is on screen,
+// replacing the one there before. Nothing is sent while the same picture stays put.
+func (bi *bodyImages) flush(screen tcell.Screen) {
+	if !bi.stale && samePlacement(bi.want, bi.shown) {
+		return
+	}
+	tty, ok := screen.Tty()
+	if !ok {
+		return
+	}
+
+	if bi.shown != nil {
+		if clear := graphics.Clear(bi.protocol); clear != "" {
+			io.WriteString(tty, clear)
+		} else if !bi.stale {
+			// Repaint every cell, covering the old sixels
+			screen.Sync()
+		}
+	}
+	bi.shown, bi.stale = nil, false
+
+	if bi.want == nil {
+		return
+	}
+	bi.mu.Lock()
+	picture := bi.pictures[bi.want.key]
+	bi.mu.Unlock()
+
+	sequence, err := graphics.Encode(bi.protocol, picture, bi.want.cols, bi.want.rows, bi.cellWidth, bi.cellHeight)
+	bi.shown = bi.want
+	if err != nil {
+		bi.logger.Printf("Cannot draw picture: %v", err)
+		return
+	}
+	io.WriteString(tty, graphics.At(bi.want.x, bi.want.y, sequence))
+}
+
+func samePlacement(a, b *imagePlacement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
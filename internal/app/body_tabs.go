@@ -0,0 +1,221 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/gdamore/tcell/v2"
+)
+
+// BodyTab is a class of bodies the list above the map can show
+type BodyTab int
+
+const (
+	TabPlanets BodyTab = iota
+	TabMoons
+	TabAsteroids
+	TabComets
+	bodyTabCount
+)
+
+// String returns the tab's label
+func (t BodyTab) String() string {
+	switch t {
+	case TabMoons:
+		return "Moons"
+	case TabAsteroids:
+		return "Asteroids"
+	case TabComets:
+		return "Comets"
+	default:
+		return "Planets"
+	}
+}
+
+// Next returns the following tab, wrapping around to the planets
+func (t BodyTab) Next() BodyTab {
+	return (t + 1) % bodyTabCount
+}
+
+// bodyType is the bodyType of the bodies on the tab, as the API and system files
+// write it. The planets tab lists the loaded system instead.
+func (t BodyTab) bodyType() string {
+	switch t {
+	case TabMoons:
+		return "Moon"
+	case TabAsteroids:
+		return "Asteroid"
+	case TabComets:
+		return "Comet"
+	default:
+		return ""
+	}
+}
+
+// LoadBodyClass loads the bodies listed on a tab, by name. The Solar System asks
+// the API with a bodyType filter; system files are searched for that type.
+func (ps *PlanetService) LoadBodyClass(systemName string, tab BodyTab) ([]models.CelestialBody, error) {
+	var bodies []models.CelestialBody
+	if systemName == "solar-system" {
+		fetched, err := ps.client.GetBodiesWithFilter("bodyType,eq," + tab.bodyType())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", strings.ToLower(tab.String()), err)
+		}
+		bodies = fetched
+	} else {
+		systemData, err := ps.systemManager.LoadSystem(systemName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load external system %s: %w", systemName, err)
+		}
+		if tab == TabMoons {
+			// Moons can be described under their planet rather than as bodies
+			// of their own; the system manager gathers both
+			moons, err := ps.systemManager.Moons(systemName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load external system %s: %w", systemName, err)
+			}
+			bodies = append(bodies, moons...)
+		} else {
+			for _, body := range systemData.Bodies {
+				if strings.EqualFold(body.BodyType, tab.bodyType()) {
+					bodies = append(bodies, body)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(bodies, func(i, j int) bool {
+		return strings.ToLower(bodies[i].EnglishName) < strings.ToLower(bodies[j].EnglishName)
+	})
+	return bodies, nil
+}
+
+// openListTab switches the list to a tab, loading its bodies the first time. On
+// failure the list stays where it was and the status line says why.
+func openListTab(state *AppState, planetService *PlanetService, systemName string, tab BodyTab) {
+	if tab != TabPlanets && state.TabBodies[tab] == nil {
+		bodies, err := planetService.LoadBodyClass(systemName, tab)
+		if err != nil {
+			state.SetStatusMessage(fmt.Sprintf("Could not load %s: %v", strings.ToLower(tab.String()), err), statusMessageDuration)
+			return
+		}
+		if bodies == nil {
+			bodies = []models.CelestialBody{}
+		}
+		state.TabBodies[tab] = bodies
+	}
+	state.ShowListTab(tab)
+}
+
+// cycleListTab moves the list on to the next class of bodies
+func (ed *EventDispatcher) cycleListTab() {
+	openListTab(ed.state, ed.planetService, ed.uiRenderer.GetSystemManager().GetCurrentSystem(), ed.state.ListTab.Next())
+}
+
+// handleListTabClick switches to a tab that was clicked on
+func (meh *MouseEventHandler) handleListTabClick(mouseX, mouseY int) bool {
+	index, ok := listItemAt(meh.state.GetTabPositions(), mouseX, mouseY)
+	if !ok {
+		return false
+	}
+	openListTab(meh.state, meh.planetService, meh.renderer.GetSystemManager().GetCurrentSystem(), BodyTab(index))
+	return true
+}
+
+// drawListTabs draws a label for each tab, the one shown highlighted, with how
+// many bodies it lists once they have been loaded
+func (ur *UIRenderer) drawListTabs(area layout.Rect) {
+	ur.state.ClearTabPositions()
+
+	tabStyle := tcell.StyleDefault.Foreground(tcell.ColorGray)
+	currentStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Bold(true).Underline(true)
+
+	x := area.X
+	for tab := TabPlanets; tab < bodyTabCount; tab++ {
+		label := " " + tab.String()
+		bodies := ur.state.TabBodies[tab]
+		if tab == TabPlanets {
+			bodies = ur.state.GetPlanets()
+		}
+		if bodies != nil {
+			label += " (" + ur.tabCount(bodies) + ")"
+		}
+		label += " "
+		if x+ui.TextWidth(label) > area.X+area.Width {
+			break
+		}
+
+		style := tabStyle
+		if tab == ur.state.ListTab {
+			style = currentStyle
+		}
+		ur.drawText(x, area.Y, style, label)
+		ur.state.AddTabPosition(PlanetListPosition{Index: int(tab), X: x, Y: area.Y, Width: ui.TextWidth(label)})
+		x += ui.TextWidth(label) + 1
+	}
+
+	hint := ur.keys.Primary(keymap.ActionTab) + " to switch"
+	if x+ui.TextWidth(hint) <= area.X+area.Width {
+		ur.drawText(x, area.Y, tabStyle, hint)
+	}
+}
+
+// tabCount is how many bodies a tab lists, out of how many it has while filtered
+func (ur *UIRenderer) tabCount(bodies []models.CelestialBody) string {
+	if ur.state.ListFilter == nil {
+		return fmt.Sprintf("%d", len(bodies))
+	}
+	shown := 0
+	for _, body := range bodies {
+		if ur.state.Listed(body) {
+			shown++
+		}
+	}
+	return fmt.Sprintf("%d/%d", shown, len(bodies))
+}
+
+// drawTabBodyList renders the bodies of a tab other than the planets. A tab can
+// list hundreds of bodies, so the rows scroll to keep the selected one in view.
+func (ur *UIRenderer) drawTabBodyList(area layout.Rect) {
+	ur.state.ClearPlanetListPositions()
+
+	tab := ur.state.ListTab
+	bodies := ur.state.ListedBodies()
+	if len(bodies) == 0 || ur.state.ListedCount() == 0 {
+		note := fmt.Sprintf("No %s known in this system", strings.ToLower(tab.String()))
+		if len(bodies) > 0 {
+			note = fmt.Sprintf("No %s match the filter", strings.ToLower(tab.String()))
+		}
+		ur.drawText(area.X, area.Y, tcell.StyleDefault.Foreground(tcell.ColorGray), truncateText(note, area.Width))
+		return
+	}
+
+	// Lay every name out in rows first, then show the page holding the selection
+	cells := make([]listCell, 0, len(bodies))
+	selected := ur.state.TabSelected[tab]
+	x, row, selectedRow := area.X, 0, 0
+	for i, body := range bodies {
+		if !ur.state.Listed(body) {
+			continue
+		}
+		text := truncateText(listEntryText(body.EnglishName, i == selected), area.Width)
+		if x+ui.TextWidth(text) > area.X+area.Width && x > area.X {
+			row++
+			x = area.X
+		}
+		style := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+		if i == selected {
+			style = tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true).Reverse(true)
+			selectedRow = row
+		}
+		cells = append(cells, listCell{index: i, x: x, row: row, text: text, style: style})
+		x += ui.TextWidth(text)
+	}
+
+	ur.drawListPage(area, cells, selectedRow)
+}
@@ -0,0 +1,155 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/config"
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/logging"
+	"github.com/furan917/go-solar-system/internal/termsize"
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+// calibrationStep is how far one arrow press moves the aspect ratio
+const calibrationStep = 0.01
+
+// resolveAspectRatio picks the cell ratio to draw with: a calibrated value from the
+// config, else the terminal's own measurement, else the default
+func resolveAspectRatio(cfg config.Config, logger *logging.Logger) (ratio, measured float64) {
+	measured, err := termsize.CellAspectRatio()
+	if err != nil {
+		logger.Debugf("Could not measure terminal cells: %v", err)
+		measured = 0
+	}
+
+	switch {
+	case cfg.AspectRatio >= termsize.MinAspectRatio && cfg.AspectRatio <= termsize.MaxAspectRatio:
+		return cfg.AspectRatio, measured
+	case cfg.AspectRatio != 0:
+		logger.Printf("Ignoring aspect ratio %.2f from config: must be between %.1f and %.1f", cfg.AspectRatio, termsize.MinAspectRatio, termsize.MaxAspectRatio)
+	}
+	if measured > 0 {
+		return measured, measured
+	}
+	return constants.AspectRatio, 0
+}
+
+// openCalibration shows the calibration screen for the ratio in use
+func (ed *EventDispatcher) openCalibration() {
+	ed.state.ShowCalibration(ed.uiRenderer.GetRenderer().GetAspectRatio(), ed.measuredAspect)
+}
+
+// handleCalibrationKeys handles keyboard input while the calibration screen is open.
+// The map behind the modal follows every adjustment.
+func (ed *EventDispatcher) handleCalibrationKeys(ev *tcell.EventKey) {
+	step := calibrationStep
+	if ev.Modifiers()&tcell.ModShift != 0 {
+		step *= 10
+	}
+
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		cancelCalibration(ed.state, ed.uiRenderer.GetRenderer())
+	case tcell.KeyEnter:
+		ed.saveCalibration()
+	case tcell.KeyLeft, tcell.KeyDown:
+		ed.adjustCalibration(ed.state.CalibrationRatio - step)
+	case tcell.KeyRight, tcell.KeyUp:
+		ed.adjustCalibration(ed.state.CalibrationRatio + step)
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'r', 'R':
+			ratio := ed.measuredAspect
+			if ratio == 0 {
+				ratio = constants.AspectRatio
+			}
+			ed.adjustCalibration(ratio)
+		case 'q', 'Q', 'b', 'B':
+			cancelCalibration(ed.state, ed.uiRenderer.GetRenderer())
+		}
+	default:
+		// do nothing
+	}
+}
+
+// adjustCalibration previews a new ratio, kept within the plausible range
+func (ed *EventDispatcher) adjustCalibration(ratio float64) {
+	ratio = math.Round(ratio*100) / 100
+	ratio = math.Max(termsize.MinAspectRatio, math.Min(termsize.MaxAspectRatio, ratio))
+	ed.state.CalibrationRatio = ratio
+	ed.uiRenderer.GetRenderer().SetAspectRatio(ratio)
+}
+
+// saveCalibration keeps the previewed ratio and writes it to the config file
+func (ed *EventDispatcher) saveCalibration() {
+	ratio := ed.state.CalibrationRatio
+	ed.uiRenderer.GetRenderer().SetAspectRatio(ratio)
+	ed.state.PopModal()
+
+	if err := ed.settings.update(func(cfg *config.Config) { cfg.AspectRatio = ratio }); err != nil {
+		ed.state.SetStatusMessage("Aspect ratio set, but not saved: "+err.Error(), statusMessageDuration)
+		return
+	}
+	if ed.settings.path == "" {
+		ed.state.SetStatusMessage(fmt.Sprintf("Aspect ratio %.2f set for this session", ratio), statusMessageDuration)
+		return
+	}
+	ed.state.SetStatusMessage(fmt.Sprintf("Aspect ratio %.2f saved to %s", ratio, ed.settings.path), statusMessageDuration)
+}
+
+// cancelCalibration closes the calibration screen and puts the old ratio back
+func cancelCalibration(state *AppState, renderer *visualization.Renderer) {
+	renderer.SetAspectRatio(state.CalibrationOriginal)
+	state.PopModal()
+}
+
+// calibrationModalHeight is the height of the calibration screen
+func calibrationModalHeight(screenHeight int) int {
+	return minimum(22, screenHeight-4)
+}
+
+// drawCalibrationModal renders a ring at the previewed ratio, to be adjusted until
+// it looks round
+func (ur *UIRenderer) drawCalibrationModal(width, height int) {
+	modalX, modalY, modalWidth, modalHeight := ur.setupModal(width, height, calibrationModalHeight(height))
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, " ◯ Calibrate Orbit Shape ")
+
+	textStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	noteStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+
+	source := "terminal did not report its cell size"
+	if ur.state.CalibrationMeasured > 0 {
+		source = fmt.Sprintf("terminal reports %.2f", ur.state.CalibrationMeasured)
+	}
+	ur.drawText(modalX+2, modalY+3, textStyle, fmt.Sprintf("Cell height / width: %.2f", ur.state.CalibrationRatio))
+	ur.drawText(modalX+2, modalY+4, noteStyle, truncateText(source+". Adjust until the ring is a circle, not an oval.", ur.contentWidth()))
+
+	// The ring fills the rows between the text and the instructions
+	top, bottom := modalY+6, modalY+modalHeight-4
+	rows := bottom - top + 1
+	if rows >= 3 {
+		grid := visualization.NewGrid(modalWidth-4, rows, ur.renderer.GetRenderMode())
+		radius := float64(rows-1) / 2
+		if maxRadius := float64(modalWidth-6) / 2 / ur.state.CalibrationRatio; radius > maxRadius {
+			radius = maxRadius
+		}
+		drawer := visualization.NewCircleDrawer(ur.state.CalibrationRatio)
+		drawer.DrawCircle(grid, grid.Width()/2, rows/2, radius, ur.renderer.GetSymbols().Orbit)
+
+		ringStyle := tcell.StyleDefault.Foreground(tcell.ColorLightCyan).Background(tcell.ColorDarkBlue)
+		for y, row := range grid.Runes() {
+			for x, r := range row {
+				if r != ' ' {
+					ur.screen.SetContent(modalX+2+x, top+y, r, nil, ringStyle)
+				}
+			}
+		}
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "←/→ adjust (Shift ×10), R reset, Enter save, Esc cancel")
+}
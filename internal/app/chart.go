@@ -0,0 +1,145 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+// chartValueColumn is the width kept right of the bars for the value and share
+const chartValueColumn = 22
+
+// chartBar is one body's row in the chart
+type chartBar struct {
+	name   string
+	value  float64 // kg or km; 0 when the body has none recorded
+	share  float64 // of the total of the bodies charted, from 0 to 1
+	length int     // cells of bar
+}
+
+// buildChartBars charts the bodies' masses, or radii, largest first, with bars
+// up to width cells on a log scale: the smallest value gets one cell and the
+// largest all of them. Bodies with nothing recorded go last, without a bar.
+func buildChartBars(bodies []models.CelestialBody, radii bool, width int) []chartBar {
+	bars := make([]chartBar, 0, len(bodies))
+	total, smallest, largest := 0.0, math.Inf(1), 0.0
+	for _, body := range bodies {
+		value := body.MeanRadius
+		if !radii {
+			value = body.GetMassKg()
+		}
+		if value > 0 {
+			total += value
+			smallest = math.Min(smallest, value)
+			largest = math.Max(largest, value)
+		} else {
+			value = 0
+		}
+		bars = append(bars, chartBar{name: body.EnglishName, value: value})
+	}
+	sort.SliceStable(bars, func(i, j int) bool { return bars[i].value > bars[j].value })
+
+	span := math.Log10(largest) - math.Log10(smallest)
+	for i := range bars {
+		bar := &bars[i]
+		if bar.value == 0 {
+			continue
+		}
+		bar.share = bar.value / total
+		bar.length = width
+		if span > 0 {
+			bar.length = 1 + int(math.Round((math.Log10(bar.value)-math.Log10(smallest))/span*float64(width-1)))
+		}
+	}
+	return bars
+}
+
+// chartValue writes a bar's value, and for masses its share of the total
+func chartValue(bar chartBar, radii bool) string {
+	switch {
+	case bar.value == 0:
+		return "unknown"
+	case radii:
+		return formatCount(int(bar.value+0.5)) + " km"
+	}
+	share := fmt.Sprintf("%.2g%%", bar.share*100)
+	if bar.share >= 0.01 {
+		share = fmt.Sprintf("%.1f%%", bar.share*100)
+	}
+	return fmt.Sprintf("%.2e kg %6s", bar.value, share)
+}
+
+// handleChartKeys handles keyboard input while the chart is open
+func (ed *EventDispatcher) handleChartKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyEnter:
+		ed.state.PopModal()
+	case tcell.KeyTab:
+		ed.state.ChartRadii = !ed.state.ChartRadii
+	case tcell.KeyUp:
+		if ed.state.ChartScroll > 0 {
+			ed.state.ChartScroll--
+		}
+	case tcell.KeyDown:
+		if ed.state.ChartScroll < len(ed.state.GetPlanets())-1 {
+			ed.state.ChartScroll++
+		}
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'r', 'R':
+			ed.state.ChartRadii = !ed.state.ChartRadii
+		case 'q', 'Q', 'b', 'B', 'p', 'P':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// drawChartModal renders a bar for each loaded body, the selected one highlighted
+func (ur *UIRenderer) drawChartModal(width, height int) {
+	dynamicHeight := fitModalHeight(len(ur.state.GetPlanets())+2, height)
+	modalX, modalY, modalWidth, modalHeight := ur.setupModal(width, height, dynamicHeight)
+
+	title, other := " ▇ Masses, log scale ", "radii"
+	if ur.state.ChartRadii {
+		title, other = " ▇ Radii, log scale ", "masses"
+	}
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, title)
+
+	labelStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	valueStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	barStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue)
+	selectedStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	glyph := "█"
+	if ur.renderer.GetSymbols().ASCII {
+		glyph = "#"
+	}
+
+	barWidth := max(modalWidth-4-statsLabelColumn-chartValueColumn, 1)
+	bars := buildChartBars(ur.state.GetPlanets(), ur.state.ChartRadii, barWidth)
+	selected := ur.state.GetSelectedPlanet().EnglishName
+
+	top := modalY + 3
+	visible := modalY + modalHeight - 3 - top
+	scroll := minimum(ur.state.ChartScroll, max(len(bars)-visible, 0))
+	for i := 0; i < visible && scroll+i < len(bars); i++ {
+		bar := bars[scroll+i]
+		label, fill := labelStyle, barStyle
+		if bar.name == selected {
+			label, fill = selectedStyle, selectedStyle
+		}
+		y := top + i
+		ur.drawText(modalX+2, y, label, truncateText(bar.name, statsLabelColumn-1))
+		ur.drawText(modalX+2+statsLabelColumn, y, fill, strings.Repeat(glyph, bar.length))
+		ur.drawText(modalX+2+statsLabelColumn+bar.length+1, y, valueStyle, chartValue(bar, ur.state.ChartRadii))
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "Tab for "+other+" • ↑/↓ scroll • Enter or Esc to close")
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestBuildChartBars(t *testing.T) {
+	bodies := []models.CelestialBody{
+		{EnglishName: "Earth", Mass: models.Mass{MassValue: 5.97, MassExponent: 24}},
+		{EnglishName: "Vulcan"},
+		{EnglishName: "Sun", Mass: models.Mass{MassValue: 1.989, MassExponent: 30}},
+		{EnglishName: "Jupiter", Mass: models.Mass{MassValue: 1.898, MassExponent: 27}},
+	}
+
+	bars := buildChartBars(bodies, false, 40)
+	var names []string
+	for _, bar := range bars {
+		names = append(names, bar.name)
+	}
+	if got := strings.Join(names, ", "); got != "Sun, Jupiter, Earth, Vulcan" {
+		t.Fatalf("bars = %s, want the heaviest first and the unknown last", got)
+	}
+	if bars[0].length != 40 || bars[2].length != 1 || bars[3].length != 0 {
+		t.Errorf("lengths = %d, %d, %d, want the full width, one cell and none", bars[0].length, bars[2].length, bars[3].length)
+	}
+	// Jupiter is 2.8 of the 5.7 orders of magnitude from Earth to the Sun
+	if bars[1].length < 19 || bars[1].length > 21 {
+		t.Errorf("Jupiter's bar = %d, want about half the width", bars[1].length)
+	}
+	if got := chartValue(bars[0], false); got != "1.99e+30 kg  99.9%" {
+		t.Errorf("Sun = %q", got)
+	}
+	if got := chartValue(bars[1], false); got != "1.90e+27 kg 0.095%" {
+		t.Errorf("Jupiter = %q", got)
+	}
+	if got := chartValue(bars[3], false); got != "unknown" {
+		t.Errorf("Vulcan = %q, want unknown", got)
+	}
+}
+
+func TestChartTogglesToRadii(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'p', tcell.ModNone))
+	if state.TopModal() != ModalChart {
+		t.Fatalf("top modal = %v, want the chart", state.TopModal())
+	}
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone))
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+
+	text := screenText(screen)
+	for _, want := range []string{"Radii, log scale", "695,508 km", "69,911 km", "Tab for masses"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("chart does not show %q", want)
+		}
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'p', tcell.ModNone))
+	if state.TopModal() == ModalChart {
+		t.Error("p again left the chart open")
+	}
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/display"
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// copyDetails puts the body whose details are open on the system clipboard, as
+// the text shown or as JSON. The terminal receives it by OSC 52, which most modern
+// terminals accept (tmux needs set-clipboard on); nothing says whether it did.
+func (ed *EventDispatcher) copyDetails(action keymap.Action) {
+	body := ed.state.SelectedPlanet
+	var context []string
+	if ed.state.TopModal() == ModalMoonDetails {
+		body = ed.state.SelectedMoon
+		context = append(context, "Orbits: "+ed.state.SelectedPlanet.EnglishName)
+	} else {
+		for _, line := range []string{ed.uiRenderer.seasonDetail(body), ed.uiRenderer.speedDetail(body), ed.uiRenderer.lightTimeDetail(body)} {
+			if line != "" {
+				context = append(context, line)
+			}
+		}
+	}
+
+	text, format := ed.uiRenderer.bodyClipboardText(ed.state, body, context), "text"
+	if action == keymap.ActionCopyJSON {
+		data, err := json.MarshalIndent(body, "", "  ")
+		if err != nil {
+			ed.state.SetStatusMessage("Could not copy "+body.EnglishName+": "+err.Error(), statusMessageDuration)
+			return
+		}
+		text, format = string(data), "JSON"
+	}
+
+	ed.uiRenderer.screen.SetClipboard([]byte(text))
+	ed.state.SetStatusMessage(fmt.Sprintf("Copied %s's details to the clipboard as %s", body.EnglishName, format), statusMessageDuration)
+}
+
+// bodyClipboardText writes a body's details the way the details window shows
+// them: its name, the fields in the configured layout, the lines worked out for
+// it and where the values came from
+func (ur *UIRenderer) bodyClipboardText(state *AppState, body models.CelestialBody, extra []string) string {
+	var b strings.Builder
+	b.WriteString(body.EnglishName + "\n")
+
+	tagged := display.HasMixedSources(body)
+	for _, line := range ur.detailLines(state, body) {
+		b.WriteString(line.Text)
+		if tagged {
+			b.WriteString(" " + body.SourceOf(line.Field).Tag())
+		}
+		b.WriteString("\n")
+	}
+	for _, line := range extra {
+		b.WriteString(line + "\n")
+	}
+	for _, note := range display.SourceNotes(body) {
+		b.WriteString(note + "\n")
+	}
+	return b.String()
+}
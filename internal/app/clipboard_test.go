@@ -0,0 +1,89 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestCopyDetails(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+	state.SelectListed(3)
+	state.OpenModal(ModalDetails)
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone))
+	text := string(screen.GetClipboardData())
+	if !strings.HasPrefix(text, "Earth\n") || !strings.Contains(text, "Mean Radius: 6371 km") {
+		t.Errorf("Expected Earth's details copied as text, got:\n%s", text)
+	}
+	if !strings.Contains(state.GetStatusMessage(), "Copied Earth") {
+		t.Errorf("Expected the copy reported, got %q", state.GetStatusMessage())
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'Y', tcell.ModNone))
+	var body models.CelestialBody
+	if err := json.Unmarshal(screen.GetClipboardData(), &body); err != nil || body.ID != "terre" {
+		t.Errorf("Expected Earth copied as JSON, got %q (%v)", screen.GetClipboardData(), err)
+	}
+	if state.TopModal() != ModalDetails {
+		t.Errorf("Expected the details to stay open, got modal %v", state.TopModal())
+	}
+}
+
+func TestRawJSONView(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+	state.SelectListed(3)
+	state.OpenModal(ModalDetails)
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone))
+	if state.TopModal() != ModalRawJSON {
+		t.Fatalf("Expected r to show the raw JSON, got modal %v", state.TopModal())
+	}
+	dispatcher.uiRenderer.DrawScreen()
+	if text := screenText(screen); !strings.Contains(text, `"englishName": "Earth"`) || !strings.Contains(text, `"meanRadius": 6371`) {
+		t.Fatalf("Expected Earth's fields drawn as JSON, got:\n%s", text)
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyEnd, 0, tcell.ModNone))
+	if state.RawJSONScroll != len(state.RawJSONLines)-1 {
+		t.Errorf("Expected End to scroll to the last line, got %d of %d", state.RawJSONScroll, len(state.RawJSONLines))
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone))
+	var body models.CelestialBody
+	if err := json.Unmarshal(screen.GetClipboardData(), &body); err != nil || body.ID != "terre" {
+		t.Errorf("Expected the JSON shown copied, got %q (%v)", screen.GetClipboardData(), err)
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone))
+	if state.TopModal() != ModalDetails {
+		t.Errorf("Expected r again to go back to the details, got modal %v", state.TopModal())
+	}
+}
+
+func TestDetailUnitsToggle(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+	state.SelectListed(5)
+	state.OpenModal(ModalDetails)
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone))
+	if text := string(screen.GetClipboardData()); !strings.Contains(text, "Orbital Period: 11.86 years") || !strings.Contains(text, "5.203 AU") {
+		t.Errorf("Expected Jupiter's orbit in years and AU, got:\n%s", text)
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'u', tcell.ModNone))
+	if !state.DetailUnitsFlipped || !strings.Contains(state.GetStatusMessage(), "days, km and kg") {
+		t.Fatalf("Expected u to switch to raw units, got %v %q", state.DetailUnitsFlipped, state.GetStatusMessage())
+	}
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone))
+	if text := string(screen.GetClipboardData()); !strings.Contains(text, "Orbital Period: 4332.59 days") || strings.Contains(text, " AU") {
+		t.Errorf("Expected Jupiter's orbit in days and km, got:\n%s", text)
+	}
+	dispatcher.uiRenderer.DrawScreen()
+	if text := screenText(screen); !strings.Contains(text, "4332.59 days") {
+		t.Errorf("Expected the details drawn in raw units, got:\n%s", text)
+	}
+}
@@ -0,0 +1,241 @@
+package app
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/furan917/go-solar-system/internal/fuzzy"
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+// paletteCommand is one entry in the command palette
+type paletteCommand struct {
+	Title string // what the query is matched against
+	Hint  string // the key that does the same, or what kind of entry it is
+	Run   func(ed *EventDispatcher)
+}
+
+// paletteSkipped are main view actions not worth listing: moving through the
+// list only makes sense with the list in front of you, and bodies have their own
+// entries
+var paletteSkipped = map[keymap.Action]bool{
+	keymap.ActionPrevious:     true,
+	keymap.ActionNext:         true,
+	keymap.ActionPagePrevious: true,
+	keymap.ActionPageNext:     true,
+	keymap.ActionSelect:       true,
+	keymap.ActionSelectNumber: true,
+	keymap.ActionPalette:      true,
+}
+
+// paletteCommands lists everything the palette can do: each main view and global
+// action, then a jump to each body, a switch to each other system and each
+// color theme
+func (ed *EventDispatcher) paletteCommands() []paletteCommand {
+	var commands []paletteCommand
+	for _, context := range []keymap.Context{keymap.ContextMain, keymap.ContextGlobal} {
+		for _, binding := range ed.keys.Bindings(context) {
+			if paletteSkipped[binding.Action] {
+				continue
+			}
+			action, title := binding.Action, binding.Description
+			run := func(ed *EventDispatcher) { ed.runMainAction(action) }
+			switch action {
+			case keymap.ActionScreenshot:
+				title = "Export image: " + title
+				run = (*EventDispatcher).captureScreenshot
+			case keymap.ActionDebug:
+				run = func(ed *EventDispatcher) { ed.state.ToggleDebugOverlay() }
+			}
+			commands = append(commands, paletteCommand{Title: title, Hint: binding.Label(), Run: run})
+		}
+	}
+
+	beltsTitle := "Draw the debris belts faintly"
+	switch ed.uiRenderer.GetRenderer().BeltVisibility() {
+	case visualization.BeltsFaint:
+		beltsTitle = "Hide the debris belts"
+	case visualization.BeltsHidden:
+		beltsTitle = "Show the debris belts"
+	}
+	commands = append(commands, paletteCommand{Title: beltsTitle, Hint: "map", Run: func(ed *EventDispatcher) { ed.uiRenderer.toggleBelts() }})
+
+	for _, planet := range ed.state.GetPlanets() {
+		name := planet.EnglishName
+		commands = append(commands, paletteCommand{
+			Title: "Go to " + name,
+			Hint:  "body",
+			Run: func(ed *EventDispatcher) {
+				if err := ed.selectByName(name); err != nil {
+					ed.state.SetStatusMessage(err.Error(), statusMessageDuration)
+				}
+			},
+		})
+	}
+
+	manager := ed.uiRenderer.GetSystemManager()
+	for _, system := range manager.GetAvailableSystems() {
+		if system == manager.GetCurrentSystem() {
+			continue
+		}
+		commands = append(commands, paletteCommand{
+			Title: "Switch to " + manager.GetSystemDisplayName(system),
+			Hint:  "system",
+			Run: func(ed *EventDispatcher) {
+				if err := ed.switchSystemByName(system); err != nil {
+					ed.state.SetStatusMessage(err.Error(), statusMessageDuration)
+				}
+			},
+		})
+	}
+
+	for _, name := range visualization.PaletteNames() {
+		palette, _ := visualization.ParsePalette(name)
+		commands = append(commands, paletteCommand{
+			Title: "Theme: " + name,
+			Hint:  "colors",
+			Run:   func(ed *EventDispatcher) { ed.uiRenderer.setPalette(palette) },
+		})
+	}
+	return commands
+}
+
+// openCommandPalette opens the palette with every command listed
+func (ed *EventDispatcher) openCommandPalette() {
+	ed.state.ShowCommandPalette(ed.paletteCommands())
+}
+
+// paletteMatches returns the commands matching the query, best first
+func (s *AppState) paletteMatches() []paletteCommand {
+	titles := make([]string, len(s.PaletteCommands))
+	for i, command := range s.PaletteCommands {
+		titles[i] = command.Title
+	}
+
+	var matches []paletteCommand
+	for _, index := range fuzzy.Rank(s.PaletteQuery, titles) {
+		matches = append(matches, s.PaletteCommands[index])
+	}
+	return matches
+}
+
+// handleCommandPaletteKeys handles keyboard input in the palette. Every printable
+// key goes into the query, so only Esc closes it.
+func (ed *EventDispatcher) handleCommandPaletteKeys(ev *tcell.EventKey) {
+	matches := ed.state.paletteMatches()
+
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		ed.state.PopModal()
+	case tcell.KeyUp, tcell.KeyCtrlP:
+		if ed.state.PaletteSelected > 0 {
+			ed.state.PaletteSelected--
+		}
+	case tcell.KeyDown, tcell.KeyCtrlN:
+		if ed.state.PaletteSelected < len(matches)-1 {
+			ed.state.PaletteSelected++
+		}
+	case tcell.KeyEnter:
+		if ed.state.PaletteSelected < len(matches) {
+			ed.state.PopModal()
+			matches[ed.state.PaletteSelected].Run(ed)
+		}
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if query := []rune(ed.state.PaletteQuery); len(query) > 0 {
+			ed.state.SetPaletteQuery(string(query[:len(query)-1]))
+		}
+	case tcell.KeyCtrlU:
+		ed.state.SetPaletteQuery("")
+	case tcell.KeyRune:
+		if r := ev.Rune(); unicode.IsPrint(r) && (r != ' ' || ed.state.PaletteQuery != "") {
+			ed.state.SetPaletteQuery(ed.state.PaletteQuery + string(r))
+		}
+	default:
+		// do nothing
+	}
+}
+
+// commandPaletteRows is how many commands the palette shows at once
+func commandPaletteRows(screenHeight int) int {
+	return max(1, commandPaletteHeight(screenHeight)-7)
+}
+
+// commandPaletteHeight is the height of the palette
+func commandPaletteHeight(screenHeight int) int {
+	return minimum(22, screenHeight-4)
+}
+
+// drawCommandPaletteModal draws the query and the commands matching it, with the
+// key that does the same thing where there is one
+func (ur *UIRenderer) drawCommandPaletteModal(width, height int) {
+	modalX, modalY, modalWidth, modalHeight := ur.setupModal(width, height, commandPaletteHeight(height))
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	queryStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue).Bold(true)
+	textStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	hintStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	selectedStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true).Reverse(true)
+
+	ur.drawText(modalX+2, modalY+1, titleStyle, " Command Palette ")
+	ur.drawText(modalX+2, modalY+3, queryStyle, truncateText("> "+ur.state.PaletteQuery+"_", ur.contentWidth()))
+
+	matches := ur.state.paletteMatches()
+	if len(matches) == 0 {
+		ur.drawText(modalX+2, modalY+5, hintStyle, "No command matches")
+	}
+
+	rows := commandPaletteRows(height)
+	first := max(0, ur.state.PaletteSelected-rows+1)
+	for row := 0; row < rows && first+row < len(matches); row++ {
+		index := first + row
+		command := matches[index]
+		style, hint := textStyle, hintStyle
+		if index == ur.state.PaletteSelected {
+			style, hint = selectedStyle, selectedStyle
+			for x := modalX + 1; x < modalX+modalWidth-1; x++ {
+				ur.screen.SetContent(x, modalY+5+row, ' ', nil, selectedStyle)
+			}
+		}
+
+		hintWidth := ui.TextWidth(command.Hint)
+		ur.drawText(modalX+2, modalY+5+row, style, truncateText(command.Title, ur.contentWidth()-hintWidth-2))
+		ur.drawText(modalX+modalWidth-2-hintWidth, modalY+5+row, hint, command.Hint)
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle,
+		truncateText(fmt.Sprintf("%d of %d • ↑/↓ choose • Enter run • Esc back", len(matches), len(ur.state.PaletteCommands)), ur.contentWidth()))
+}
+
+// setPalette recolors the map, between frames
+func (ur *UIRenderer) setPalette(palette visualization.Palette) {
+	ur.drawMu.Lock()
+	defer ur.drawMu.Unlock()
+	ur.renderer.SetPalette(palette)
+	if ur.compareRenderer != nil {
+		ur.compareRenderer.SetPalette(palette)
+	}
+}
+
+// toggleBelts moves the belts on from shown to faint to hidden and round again,
+// between frames
+func (ur *UIRenderer) toggleBelts() {
+	ur.drawMu.Lock()
+	defer ur.drawMu.Unlock()
+	visibility := ur.renderer.BeltVisibility().Next()
+	ur.renderer.SetBeltVisibility(visibility)
+	if ur.compareRenderer != nil {
+		ur.compareRenderer.SetBeltVisibility(visibility)
+	}
+}
+
+// setBelts selects the belts drawn round the main map's star, between frames
+func (ur *UIRenderer) setBelts(belts []models.Belt) {
+	ur.drawMu.Lock()
+	defer ur.drawMu.Unlock()
+	ur.renderer.SetBelts(belts)
+}
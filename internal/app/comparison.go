@@ -0,0 +1,248 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+// comparePane says which half of the comparison view takes navigation keys
+type comparePane int
+
+const (
+	comparePaneCurrent  comparePane = iota // the loaded system, on the left
+	comparePaneCompared                    // the compared system, on the right
+)
+
+// minComparePaneWidth is the narrowest pane worth drawing a system in
+const minComparePaneWidth = 24
+
+// toggleComparison opens the system list to pick a system to compare with, or
+// leaves comparison mode
+func (ed *EventDispatcher) toggleComparison() {
+	if ed.state.Comparing {
+		ed.state.StopComparison()
+		ed.uiRenderer.endComparison()
+		return
+	}
+	ed.showComparisonList()
+}
+
+// showComparisonList opens the system list to choose the compared system
+func (ed *EventDispatcher) showComparisonList() {
+	ed.showSystemList()
+	ed.state.PickingComparison = true
+	for i, system := range ed.uiRenderer.GetSystemManager().GetAvailableSystems() {
+		if system == ed.state.CompareSystem {
+			ed.state.SystemSelectedIndex = i
+			break
+		}
+	}
+}
+
+// switchCompareFocus moves the navigation keys to the other pane
+func (ed *EventDispatcher) switchCompareFocus() {
+	if !ed.state.Comparing {
+		return
+	}
+	if ed.state.CompareFocus == comparePaneCurrent {
+		ed.state.CompareFocus = comparePaneCompared
+	} else {
+		ed.state.CompareFocus = comparePaneCurrent
+	}
+}
+
+// handleComparedPaneAction handles the navigation keys while the compared system
+// has focus. It reports whether the action was for that pane; everything else
+// still works on the loaded system.
+func (ed *EventDispatcher) handleComparedPaneAction(action keymap.Action, ev *tcell.EventKey) bool {
+	planets := ed.state.ComparePlanets
+	switch action {
+	case keymap.ActionPrevious:
+		if ed.state.CompareSelectedIndex > 0 {
+			ed.state.CompareSelectedIndex--
+		}
+	case keymap.ActionNext:
+		if ed.state.CompareSelectedIndex < len(planets)-1 {
+			ed.state.CompareSelectedIndex++
+		}
+	case keymap.ActionSelectNumber:
+		if num := int(ev.Rune() - '0'); num >= 1 && num <= len(planets) {
+			ed.state.CompareSelectedIndex = num - 1
+		}
+	case keymap.ActionSystems:
+		ed.showComparisonList()
+	default:
+		return false
+	}
+	return true
+}
+
+// OpenSelectedSystem acts on the system chosen in the system list: it becomes the
+// compared system if the list was opened for that, otherwise it is switched to
+func (sm *SystemManager) OpenSelectedSystem() {
+	if sm.state.PickingComparison {
+		sm.CompareWithSelectedSystem()
+		return
+	}
+	sm.SwitchToSelectedSystem()
+}
+
+// CompareWithSelectedSystem loads the system chosen in the system list and draws it
+// beside the current one
+func (sm *SystemManager) CompareWithSelectedSystem() {
+	availableSystems := sm.uiRenderer.GetSystemManager().GetAvailableSystems()
+	if sm.state.SystemSelectedIndex >= len(availableSystems) {
+		sm.errorHandler.HandleError(NewValidationError("invalid system index", nil).
+			WithContext("index", sm.state.SystemSelectedIndex).
+			WithContext("available", len(availableSystems)))
+		return
+	}
+
+	selectedSystem := availableSystems[sm.state.SystemSelectedIndex]
+	loaded, err := sm.planetService.LoadSystem(selectedSystem)
+	if err != nil {
+		sm.errorHandler.HandleError(NewSystemError("failed to load system for comparison", err).
+			WithContext("target_system", selectedSystem))
+		return
+	}
+	if len(loaded) == 0 {
+		sm.errorHandler.HandleError(NewValidationError("no celestial bodies to compare", nil).
+			WithContext("target_system", selectedSystem))
+		return
+	}
+
+	// The loader hands back its cached slice; keep the comparison's own copy
+	planets := sm.NormalizePlanetNames(append([]models.CelestialBody(nil), loaded...))
+	if !sm.ContainsCentralStar(planets) {
+		planets = append([]models.CelestialBody{sm.FindOrCreateCentralStar(planets)}, planets...)
+	}
+	sortPlanets(planets, SortByDistance, false)
+
+	sm.uiRenderer.beginComparison()
+	sm.uiRenderer.compareRenderer.SetBelts(sm.uiRenderer.GetSystemManager().Belts(selectedSystem))
+	sm.state.StartComparison(selectedSystem, sm.uiRenderer.GetSystemManager().GetSystemDisplayName(selectedSystem), planets)
+	sm.state.CloseModal(ModalSystemList)
+}
+
+// beginComparison creates the second renderer, animated by the same clock as the
+// first so both panes show the same moment
+func (ur *UIRenderer) beginComparison() {
+	if ur.compareRenderer != nil {
+		return
+	}
+	width, height := ur.screen.Size()
+	ur.compareRenderer = visualization.NewRendererWithDefaults(width, height)
+	ur.compareRenderer.ShareTimeline(ur.renderer)
+	ur.compareRenderer.SetSymbols(ur.renderer.GetSymbols())
+	ur.compareRenderer.SetPalette(ur.renderer.GetPalette())
+	ur.compareRenderer.SetBeltVisibility(ur.renderer.BeltVisibility())
+	ur.compareRenderer.SetResonancesShown(ur.renderer.ResonancesShown())
+	ur.compareRenderer.SetWobbleShown(ur.renderer.WobbleShown())
+	ur.compareRenderer.SetHabitableZoneShown(ur.renderer.HabitableZoneShown())
+}
+
+// endComparison gives the main renderer the whole screen and its own scale back
+func (ur *UIRenderer) endComparison() {
+	width, height := ur.screen.Size()
+	ur.renderer.SetDistanceRange(0, 0)
+	ur.renderer.UpdateDimensions(width, height)
+}
+
+// comparisonRange is the distance range covering both systems, so they share one scale
+func comparisonRange(a, b []models.CelestialBody) (float64, float64) {
+	minA, maxA := visualization.DistanceRange(a)
+	minB, maxB := visualization.DistanceRange(b)
+	return math.Min(minA, minB), math.Max(maxA, maxB)
+}
+
+// drawComparison splits the map between the loaded system and the compared one,
+// both on a common scale so the difference in size shows
+func (ur *UIRenderer) drawComparison(region layout.Rect) {
+	paneWidth := (region.Width - 1) / 2
+	if paneWidth < minComparePaneWidth || region.Height < 8 {
+		ur.endComparison()
+		ur.drawSolarSystem(region.X, region.Y, region.Width, region.Height)
+		ur.drawText(region.X, region.Y, tcell.StyleDefault.Foreground(tcell.ColorGray), "Widen the window to compare systems side by side")
+		return
+	}
+
+	screenWidth, screenHeight := ur.screen.Size()
+	mapY, mapHeight := region.Y+1, region.Height-2
+	rightX := region.X + paneWidth + 1
+	minDistance, maxDistance := comparisonRange(ur.state.GetPlanets(), ur.state.ComparePlanets)
+
+	// The scaler sizes orbits by rows; narrowing its width by the cell ratio keeps
+	// the widest orbit inside a half-width pane
+	aspect := ur.renderer.GetAspectRatio()
+	fitWidth := int(float64(paneWidth) / aspect)
+
+	ur.renderer.UpdateDimensions(fitWidth, mapHeight)
+	ur.renderer.SetDistanceRange(minDistance, maxDistance)
+	ur.drawSolarSystem(region.X, mapY, paneWidth, mapHeight)
+
+	compare := ur.compareRenderer
+	compare.SetAspectRatio(aspect)
+	compare.SetRenderMode(ur.renderer.GetRenderMode())
+	compare.UpdateDimensions(fitWidth, mapHeight)
+	compare.SetDistanceRange(minDistance, maxDistance)
+	grid, _ := compare.RenderSolarSystemDataWithPositions(ur.state.ComparePlanets, paneWidth, mapHeight, screenWidth, screenHeight)
+	ur.drawGrid(compare, grid, rightX, mapY, paneWidth, mapHeight)
+
+	dividerStyle := tcell.StyleDefault.Foreground(tcell.ColorDarkGray)
+	for y := region.Y; y < region.Y+region.Height; y++ {
+		ur.screen.SetContent(region.X+paneWidth, y, '│', nil, dividerStyle)
+	}
+
+	var current, compared models.CelestialBody
+	current = ur.state.SelectedPlanet
+	if index := ur.state.CompareSelectedIndex; index < len(ur.state.ComparePlanets) {
+		compared = ur.state.ComparePlanets[index]
+	}
+	ur.drawPaneLabel(region.X, region.Y, paneWidth, ur.systemManager.GetCurrentSystemDisplayName(), current, ur.state.CompareFocus == comparePaneCurrent)
+	ur.drawPaneLabel(rightX, region.Y, paneWidth, ur.state.CompareSystemName, compared, ur.state.CompareFocus == comparePaneCompared)
+
+	scale := fmt.Sprintf("Common scale %s-%s AU (log) • Tab switches pane • %s to stop comparing",
+		formatAU(minDistance), formatAU(maxDistance), ur.keys.Primary(keymap.ActionCompare))
+	ur.drawText(region.X, region.Y+region.Height-1, tcell.StyleDefault.Foreground(tcell.ColorGray), truncateText(scale, region.Width))
+}
+
+// drawPaneLabel names a comparison pane's system and its selected body; the pane
+// with focus is highlighted
+func (ur *UIRenderer) drawPaneLabel(x, y, width int, system string, selected models.CelestialBody, focused bool) {
+	style := tcell.StyleDefault.Foreground(tcell.ColorGray)
+	marker := "  "
+	if focused {
+		style = tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
+		marker = "▸ "
+	}
+
+	label := marker + system
+	if selected.EnglishName != "" {
+		label += " • " + selected.EnglishName
+		if selected.SemimajorAxis > 0 {
+			label += " (" + formatAU(selected.SemimajorAxis) + " AU)"
+		}
+	}
+	ur.drawText(x, y, style, truncateText(label, width))
+}
+
+// formatAU writes a distance in km as astronomical units, with more decimals for
+// the small distances of compact systems
+func formatAU(km float64) string {
+	au := km / constants.AstronomicalUnit
+	switch {
+	case au >= 10:
+		return fmt.Sprintf("%.1f", au)
+	case au >= 0.1:
+		return fmt.Sprintf("%.2f", au)
+	default:
+		return fmt.Sprintf("%.3f", au)
+	}
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPublishedFrameIsUnchangedByLaterEvents(t *testing.T) {
+	state := NewAppState()
+	state.SetPlanets([]models.CelestialBody{{EnglishName: "Mercury"}, {EnglishName: "Venus"}})
+	state.OpenModal(ModalDetails)
+	state.TabSelected[TabMoons] = 1
+	state.Publish()
+	frame := state.Frame()
+
+	state.SelectedIndex = 1
+	state.Planets[0].EnglishName = "Vulcan"
+	state.PopModal()
+	state.PushModal(ModalHelp)
+	state.TabSelected[TabMoons] = 4
+	state.SetStatusMessage("shared", statusMessageDuration)
+
+	if frame.SelectedIndex != 0 || frame.Planets[0].EnglishName != "Mercury" {
+		t.Errorf("frame selects %d, %q after the live state changed", frame.SelectedIndex, frame.Planets[0].EnglishName)
+	}
+	if frame.TopModal() != ModalDetails {
+		t.Errorf("frame shows modal %v, want the details it was published with", frame.TopModal())
+	}
+	if frame.TabSelected[TabMoons] != 1 {
+		t.Errorf("frame selects moon %d, want 1", frame.TabSelected[TabMoons])
+	}
+	if frame.GetStatusMessage() != "shared" {
+		t.Error("frame does not see the status line, which is shared")
+	}
+
+	state.Publish()
+	if state.Frame().SelectedIndex != 1 {
+		t.Error("publishing again did not pick up the change")
+	}
+}
+
+// TestDrawingWhileHandlingEvents drives the event loop and the display loop at
+// the same time, as the app does. Run with -race to check they share no state.
+func TestDrawingWhileHandlingEvents(t *testing.T) {
+	dispatcher, _, screen := newAppFixture(t, 120, 40)
+	ur := dispatcher.uiRenderer
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				ur.DrawScreen()
+			}
+		}
+	}()
+
+	keys := []*tcell.EventKey{
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRune, 'm', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRune, 'h', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRune, 'k', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRune, '7', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRune, '3', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone),
+	}
+	for round := 0; round < 20; round++ {
+		for _, key := range keys {
+			dispatcher.HandleEvent(key)
+		}
+		click(dispatcher, 10, 4)
+		click(dispatcher, 60, 20)
+		dispatcher.HandleEvent(tcell.NewEventMouse(60, 20, tcell.WheelDown, tcell.ModNone))
+		dispatcher.HandleEvent(tcell.NewEventMouse(61, 21, tcell.ButtonNone, tcell.ModNone))
+		resize(dispatcher, screen, 100+round%2*20, 40)
+	}
+
+	close(done)
+	wg.Wait()
+}
@@ -0,0 +1,281 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/events"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// conjunctionSearchYears is how far ahead of the simulated date the finder looks
+	conjunctionSearchYears = 100
+
+	// conjunctionLimit is the most alignments the finder lists
+	conjunctionLimit = 20
+
+	// conjunctionBodyColumn is the width of the body picker column
+	conjunctionBodyColumn = 24
+)
+
+// conjunctionThresholds are the angular separations, in degrees, the finder can
+// search within
+var conjunctionThresholds = []float64{0.25, 0.5, 1, 2, 5, 10, 20, 30}
+
+// conjunctionDefaultThreshold indexes the 1° threshold the finder starts at
+const conjunctionDefaultThreshold = 2
+
+// Conjunction finder panes
+const (
+	conjunctionFocusBodies = iota
+	conjunctionFocusResults
+)
+
+// openConjunctionFinder starts with Jupiter and Saturn, for their great
+// conjunctions, or else the selected body and the one after it
+func (ed *EventDispatcher) openConjunctionFinder() {
+	candidates := missionBodies(ed.state.GetPlanets())
+	if len(candidates) < 2 {
+		ed.state.SetStatusMessage("Need at least two orbiting bodies to look for conjunctions", statusMessageDuration)
+		return
+	}
+
+	chosen := []string{"Jupiter", "Saturn"}
+	_, hasJupiter := findBodyByName(candidates, "Jupiter")
+	_, hasSaturn := findBodyByName(candidates, "Saturn")
+	if !hasJupiter || !hasSaturn {
+		first := 0
+		for i, body := range candidates {
+			if body.EnglishName == ed.state.SelectedPlanet.EnglishName {
+				first = i
+				break
+			}
+		}
+		second := (first + 1) % len(candidates)
+		chosen = []string{candidates[first].EnglishName, candidates[second].EnglishName}
+	}
+
+	ed.state.ShowConjunctionFinder(chosen)
+	ed.refreshConjunctions()
+}
+
+// refreshConjunctions searches for alignments of the chosen bodies from the
+// current simulated time
+func (ed *EventDispatcher) refreshConjunctions() {
+	from := ed.uiRenderer.GetRenderer().GetClock().Now()
+	to := from.AddDate(conjunctionSearchYears, 0, 0)
+	threshold := conjunctionThresholds[ed.state.ConjunctionThreshold] * math.Pi / 180
+
+	engine := events.NewEngine(ed.uiRenderer.GetRenderer().GetEphemeris())
+	found, searched := engine.FindAlignments(ed.state.GetPlanets(), ed.state.ConjunctionBodies, threshold, from, to, conjunctionLimit)
+	ed.state.SetConjunctions(found, from, searched)
+}
+
+// handleConjunctionKeys handles keyboard input in the conjunction finder
+func (ed *EventDispatcher) handleConjunctionKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		ed.state.PopModal()
+	case tcell.KeyTab, tcell.KeyBacktab:
+		ed.state.ConjunctionFocus = 1 - ed.state.ConjunctionFocus
+	case tcell.KeyUp:
+		ed.moveConjunctionCursor(-1)
+	case tcell.KeyDown:
+		ed.moveConjunctionCursor(1)
+	case tcell.KeyLeft:
+		ed.stepConjunctionThreshold(-1)
+	case tcell.KeyRight:
+		ed.stepConjunctionThreshold(1)
+	case tcell.KeyEnter:
+		if ed.state.ConjunctionFocus == conjunctionFocusResults {
+			ed.jumpToConjunction()
+		} else {
+			ed.toggleConjunctionBody()
+		}
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case ' ':
+			ed.toggleConjunctionBody()
+		case '-':
+			ed.stepConjunctionThreshold(-1)
+		case '+', '=':
+			ed.stepConjunctionThreshold(1)
+		case 'r', 'R':
+			ed.refreshConjunctions()
+		case 'q', 'Q', 'b', 'B':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// moveConjunctionCursor moves the cursor of the focused pane
+func (ed *EventDispatcher) moveConjunctionCursor(direction int) {
+	if ed.state.ConjunctionFocus == conjunctionFocusResults {
+		if count := len(ed.state.ConjunctionResults); count > 0 {
+			ed.state.ConjunctionSelected = max(0, min(ed.state.ConjunctionSelected+direction, count-1))
+		}
+		return
+	}
+	if count := len(missionBodies(ed.state.GetPlanets())); count > 0 {
+		ed.state.ConjunctionCursor = max(0, min(ed.state.ConjunctionCursor+direction, count-1))
+	}
+}
+
+// stepConjunctionThreshold widens or narrows the separation searched within
+func (ed *EventDispatcher) stepConjunctionThreshold(direction int) {
+	index := ed.state.ConjunctionThreshold + direction
+	if index < 0 || index >= len(conjunctionThresholds) {
+		return
+	}
+	ed.state.ConjunctionThreshold = index
+	ed.refreshConjunctions()
+}
+
+// toggleConjunctionBody adds the body under the cursor to the search, or takes it out
+func (ed *EventDispatcher) toggleConjunctionBody() {
+	candidates := missionBodies(ed.state.GetPlanets())
+	if ed.state.ConjunctionCursor >= len(candidates) {
+		return
+	}
+	name := candidates[ed.state.ConjunctionCursor].EnglishName
+
+	// The list is replaced rather than edited, as published frames share it
+	var chosen []string
+	removed := false
+	for _, body := range ed.state.ConjunctionBodies {
+		if body == name {
+			removed = true
+			continue
+		}
+		chosen = append(chosen, body)
+	}
+	if !removed {
+		chosen = append(chosen, name)
+	}
+	ed.state.ConjunctionBodies = chosen
+	ed.refreshConjunctions()
+}
+
+// jumpToConjunction moves the simulation to the selected alignment and shows it
+func (ed *EventDispatcher) jumpToConjunction() {
+	results := ed.state.ConjunctionResults
+	if ed.state.ConjunctionSelected >= len(results) {
+		return
+	}
+	alignment := results[ed.state.ConjunctionSelected]
+
+	clock := ed.uiRenderer.GetRenderer().GetClock()
+	clock.Set(alignment.Time, clock.Speed())
+	ed.state.ResetModals()
+	ed.state.SetStatusMessage(fmt.Sprintf("Simulation moved to %s: %s", alignment.Time.UTC().Format("2006-01-02"), describeAlignment(alignment)), statusMessageDuration)
+}
+
+// describeAlignment names the bodies of an alignment and how close they come
+func describeAlignment(alignment events.Alignment) string {
+	names := alignment.Bodies
+	together := strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	description := fmt.Sprintf("%s %s apart", together, formatDegrees(alignment.Separation*180/math.Pi))
+	if alignment.Observer != "" {
+		description += " seen from " + alignment.Observer
+	}
+	return description
+}
+
+// formatDegrees writes an angle in degrees with more precision the smaller it is
+func formatDegrees(degrees float64) string {
+	if degrees < 0.1 {
+		return fmt.Sprintf("%.3f°", degrees)
+	}
+	return fmt.Sprintf("%.2f°", degrees)
+}
+
+// formatYears writes a number of years, to a tenth when there are only a few
+func formatYears(years float64) string {
+	if years < 10 {
+		return fmt.Sprintf("%.1f", years)
+	}
+	return fmt.Sprintf("%.0f", years)
+}
+
+// drawConjunctionModal draws the bodies to search with on the left and the
+// alignments found on the right
+func (ur *UIRenderer) drawConjunctionModal(width, height int) {
+	modalX, modalY, modalWidth, modalHeight := ur.setupModal(width, height)
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	headingStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue)
+	textStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	dateStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue)
+	noteStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	focusStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorYellow).Bold(true)
+	cursorStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+
+	threshold := conjunctionThresholds[ur.state.ConjunctionThreshold]
+	ur.drawText(modalX+2, modalY+1, titleStyle, " 🪐 Conjunction Finder ")
+	years := ur.state.ConjunctionUntil.Sub(ur.state.ConjunctionFrom).Hours() / 24 / 365.25
+	ur.drawText(modalX+2, modalY+2, textStyle, fmt.Sprintf("Within %g°, in the next %s years", threshold, formatYears(years)))
+
+	startY := modalY + 4
+	visible := max(modalHeight-8, 1)
+	bodyX := modalX + 2
+	resultX := bodyX + conjunctionBodyColumn
+	resultWidth := modalWidth - conjunctionBodyColumn - 4
+
+	ur.drawText(bodyX, startY-1, headingStyle, "Bodies")
+	candidates := missionBodies(ur.state.GetPlanets())
+	first := max(0, ur.state.ConjunctionCursor-visible+1)
+	for i := first; i < len(candidates) && i < first+visible; i++ {
+		name := candidates[i].EnglishName
+		mark := "[ ]"
+		if slices.Contains(ur.state.ConjunctionBodies, name) {
+			mark = "[x]"
+		}
+		style := textStyle
+		if i == ur.state.ConjunctionCursor {
+			style = cursorStyle
+			if ur.state.ConjunctionFocus == conjunctionFocusBodies {
+				style = focusStyle
+			}
+		}
+		ur.drawText(bodyX, startY+i-first, style, truncateText(mark+" "+name, conjunctionBodyColumn-2))
+	}
+
+	heading := "Closest approach"
+	if results := ur.state.ConjunctionResults; len(results) > 0 && results[0].Observer != "" {
+		heading += ", seen from " + results[0].Observer
+	}
+	ur.drawText(resultX, startY-1, headingStyle, truncateText(heading, resultWidth))
+
+	results := ur.state.ConjunctionResults
+	switch {
+	case len(ur.state.ConjunctionBodies) < 2:
+		ur.drawText(resultX, startY, noteStyle, truncateText("Choose two or more bodies", resultWidth))
+	case len(results) == 0:
+		ur.drawText(resultX, startY, noteStyle, truncateText("They never come that close", resultWidth))
+	}
+
+	first = max(0, ur.state.ConjunctionSelected-visible+1)
+	for i := first; i < len(results) && i < first+visible; i++ {
+		alignment := results[i]
+		date := alignment.Time.UTC().Format("2006-01-02")
+		ahead := alignment.Time.Sub(ur.state.ConjunctionFrom).Hours() / 24 / 365.25
+		detail := fmt.Sprintf("%s apart, in %s years", formatDegrees(alignment.Separation*180/math.Pi), formatYears(ahead))
+
+		style, detailStyle := dateStyle, textStyle
+		if i == ur.state.ConjunctionSelected && ur.state.ConjunctionFocus == conjunctionFocusResults {
+			style, detailStyle = focusStyle, focusStyle
+		}
+		ur.drawText(resultX, startY+i-first, style, date)
+		ur.drawText(resultX+len(date), startY+i-first, detailStyle, truncateText("  "+detail, resultWidth-len(date)))
+	}
+
+	ur.drawText(modalX+2, modalY+modalHeight-3, noteStyle, truncateText("Longitudes in the orbital plane, on the simulated clock", ur.contentWidth()))
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "Space pick • ←/→ closeness • Tab dates • Enter go there")
+}
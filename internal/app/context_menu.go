@@ -0,0 +1,173 @@
+package app
+
+import (
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/gdamore/tcell/v2"
+)
+
+// doubleClickInterval is how soon a second click on the same body has to follow
+// the first for the two to open its details
+const doubleClickInterval = 400 * time.Millisecond
+
+// bodyClick is a click that selected a body, remembered to spot a double click
+type bodyClick struct {
+	name string
+	at   time.Time
+}
+
+// openOnDoubleClick shows the details of the body a click just selected when the
+// click before it selected the same body, so that one click only selects
+func (meh *MouseEventHandler) openOnDoubleClick(at time.Time) {
+	name := meh.state.SelectedPlanet.EnglishName
+	if name == meh.lastClick.name && at.Sub(meh.lastClick.at) <= doubleClickInterval {
+		meh.lastClick = bodyClick{}
+		if !meh.state.IsAnyModalShowing() {
+			meh.state.OpenModal(ModalDetails)
+		}
+		return
+	}
+	meh.lastClick = bodyClick{name: name, at: at}
+}
+
+// handleRightClick selects the body right-clicked, on the map or in the list, and
+// opens a menu of what can be done with it
+func (meh *MouseEventHandler) handleRightClick(mouseX, mouseY int) {
+	if meh.state.TopModal() == ModalContextMenu {
+		meh.state.PopModal()
+	}
+	if modal := meh.state.TopModal(); modal != ModalNone {
+		if meh.renderer.IsClickInModalArea(meh.state, mouseX, mouseY) || !modalSpecFor(modal).passThrough {
+			return
+		}
+	}
+
+	if !meh.handlePlanetListClick(mouseX, mouseY) && !meh.handleMapClick(mouseX, mouseY) {
+		return
+	}
+	meh.state.ShowContextMenu(contextMenuItems(meh.state, meh.renderer.keys), mouseX, mouseY)
+}
+
+// contextMenuItems lists what the context menu offers for the selected body, with
+// the key that does the same where there is one
+func contextMenuItems(state *AppState, keys *keymap.Keymap) []paletteCommand {
+	body := state.SelectedPlanet
+	items := []paletteCommand{{
+		Title: "Details",
+		Hint:  keys.Primary(keymap.ActionSelect),
+		Run:   func(ed *EventDispatcher) { ed.state.OpenModal(ModalDetails) },
+	}}
+	if len(body.Moons) > 0 {
+		items = append(items, paletteCommand{
+			Title: "Moons",
+			Run: func(ed *EventDispatcher) {
+				ed.state.OpenModal(ModalDetails)
+				ed.state.ShowMoonList()
+			},
+		})
+	}
+
+	compare := "Compare with a system"
+	if state.Comparing {
+		compare = "Stop comparing"
+	}
+	items = append(items, paletteCommand{Title: compare, Hint: keys.Primary(keymap.ActionCompare), Run: (*EventDispatcher).toggleComparison})
+
+	return append(items, paletteCommand{
+		Title: "Centre on " + body.EnglishName,
+		Hint:  keys.Primary(keymap.ActionFocus),
+		Run: func(ed *EventDispatcher) {
+			if ed.state.Focusing {
+				ed.state.StopFocus()
+			}
+			ed.toggleFocus()
+		},
+	})
+}
+
+// contextMenuArea places the menu just right of the cell clicked, moved left or up
+// where it would run off the screen
+func contextMenuArea(state *AppState, screenWidth, screenHeight int) layout.Rect {
+	width := 0
+	for _, item := range state.ContextMenuItems {
+		width = max(width, ui.TextWidth(item.Title)+ui.TextWidth(item.Hint))
+	}
+	width += 7 // borders, padding and a gap between title and key
+	height := len(state.ContextMenuItems) + 2
+
+	x := min(state.ContextMenuX+1, screenWidth-width)
+	y := min(state.ContextMenuY, screenHeight-height)
+	return layout.Rect{X: max(x, 0), Y: max(y, 0), Width: min(width, screenWidth), Height: min(height, screenHeight)}
+}
+
+// drawContextMenu draws the menu's items, the selected one highlighted
+func (ur *UIRenderer) drawContextMenu(width, height int) {
+	area := contextMenuArea(ur.state, width, height)
+
+	textStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	hintStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	selectedStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true).Reverse(true)
+
+	for y := area.Y; y < area.Y+area.Height; y++ {
+		for x := area.X; x < area.X+area.Width; x++ {
+			ur.screen.SetContent(x, y, ' ', nil, textStyle)
+		}
+	}
+	ur.drawModalBorder(area.X, area.Y, area.Width, area.Height)
+
+	for i, item := range ur.state.ContextMenuItems {
+		y := area.Y + 1 + i
+		if y >= area.Y+area.Height-1 {
+			break
+		}
+		style, hint := textStyle, hintStyle
+		if i == ur.state.ContextMenuSelected {
+			style, hint = selectedStyle, selectedStyle
+			for x := area.X + 1; x < area.X+area.Width-1; x++ {
+				ur.screen.SetContent(x, y, ' ', nil, selectedStyle)
+			}
+		}
+		hintWidth := ui.TextWidth(item.Hint)
+		ur.drawText(area.X+2, y, style, truncateText(item.Title, area.Width-hintWidth-6))
+		ur.drawText(area.X+area.Width-2-hintWidth, y, hint, item.Hint)
+	}
+}
+
+// handleContextMenuKeys moves through the menu and runs the item chosen
+func (ed *EventDispatcher) handleContextMenuKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		ed.state.PopModal()
+	case tcell.KeyUp:
+		if ed.state.ContextMenuSelected > 0 {
+			ed.state.ContextMenuSelected--
+		}
+	case tcell.KeyDown:
+		if ed.state.ContextMenuSelected < len(ed.state.ContextMenuItems)-1 {
+			ed.state.ContextMenuSelected++
+		}
+	case tcell.KeyEnter:
+		ed.runContextMenuItem(ed.state.ContextMenuSelected)
+	}
+}
+
+// handleContextMenuClick runs the item clicked
+func (meh *MouseEventHandler) handleContextMenuClick(_, mouseY int, area layout.Rect) bool {
+	if index := mouseY - area.Y - 1; index >= 0 && index < len(meh.state.ContextMenuItems) && meh.dispatcher != nil {
+		meh.dispatcher.runContextMenuItem(index)
+	}
+	return true
+}
+
+// runContextMenuItem closes the menu and does what its item says
+func (ed *EventDispatcher) runContextMenuItem(index int) {
+	if index < 0 || index >= len(ed.state.ContextMenuItems) {
+		return
+	}
+	item := ed.state.ContextMenuItems[index]
+	ed.state.PopModal()
+	item.Run(ed)
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestClickSelectsAndDoubleClickShowsDetails(t *testing.T) {
+	dispatcher, state, _ := newAppFixture(t, 160, 50)
+	jupiter := state.GetPlanetPositions()["Jupiter"]
+
+	click(dispatcher, jupiter.X, jupiter.Y)
+	if state.SelectedPlanet.EnglishName != "Jupiter" || state.IsAnyModalShowing() {
+		t.Fatalf("Expected one click to only select Jupiter, got %q with modal %v", state.SelectedPlanet.EnglishName, state.TopModal())
+	}
+
+	// A second click long after the first is another single click
+	dispatcher.mouseHandler.lastClick.at = time.Now().Add(-time.Second)
+	click(dispatcher, jupiter.X, jupiter.Y)
+	if state.IsAnyModalShowing() {
+		t.Fatal("Expected clicks a second apart not to count as a double click")
+	}
+
+	doubleClick(dispatcher, jupiter.X, jupiter.Y)
+	if state.TopModal() != ModalDetails {
+		t.Errorf("Expected a double click to show the details, got modal %v", state.TopModal())
+	}
+}
+
+func TestRightClickOpensContextMenu(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 160, 50)
+	row := state.GetPlanetListPositions()[3]
+
+	dispatcher.HandleEvent(tcell.NewEventMouse(row.X+1, row.Y, tcell.Button2, tcell.ModNone))
+	dispatcher.HandleEvent(tcell.NewEventMouse(row.X+1, row.Y, tcell.ButtonNone, tcell.ModNone))
+	if state.TopModal() != ModalContextMenu || state.SelectedPlanet.EnglishName != "Earth" {
+		t.Fatalf("Expected the menu for Earth, got modal %v for %q", state.TopModal(), state.SelectedPlanet.EnglishName)
+	}
+	dispatcher.uiRenderer.DrawScreen()
+	if text := screenText(screen); !strings.Contains(text, "Centre on Earth") {
+		t.Fatalf("Expected the menu drawn, got:\n%s", text)
+	}
+
+	click(dispatcher, 0, 0)
+	if state.IsAnyModalShowing() {
+		t.Fatalf("Expected a click outside to close the menu, got modal %v", state.TopModal())
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventMouse(row.X+1, row.Y, tcell.Button2, tcell.ModNone))
+	dispatcher.HandleEvent(tcell.NewEventMouse(row.X+1, row.Y, tcell.ButtonNone, tcell.ModNone))
+	area := dispatcher.uiRenderer.modalArea(state, ModalContextMenu)
+	click(dispatcher, area.X+2, area.Y+1)
+	if state.TopModal() != ModalDetails {
+		t.Errorf("Expected Details in the menu to show the details, got modal %v", state.TopModal())
+	}
+}
@@ -0,0 +1,208 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/capture"
+	"github.com/furan917/go-solar-system/internal/control"
+	"github.com/gdamore/tcell/v2"
+)
+
+// controlStdin is the --control value that reads commands from standard input
+const controlStdin = "-"
+
+// controlEvent carries a command from the control channel into the event loop
+type controlEvent struct {
+	tcell.EventTime
+	command control.Command
+}
+
+// readControl reads commands from path, or standard input for "-", until ctx is
+// done or the input ends. A FIFO is opened again each time a writer closes it, so
+// several scripts can take turns. It fails only if path cannot be opened.
+func (ss *SolarSystem) readControl(ctx context.Context, path string) error {
+	for ctx.Err() == nil {
+		var input io.ReadCloser = os.Stdin
+		if path != controlStdin {
+			// Opening a FIFO waits for a writer, which is why this is not done up front
+			file, err := os.Open(path)
+			if err != nil {
+				return NewFileError("control channel "+path+" unavailable", err)
+			}
+			input = file
+		}
+
+		stop := context.AfterFunc(ctx, func() { input.Close() })
+		ss.readCommands(ctx, input)
+		stop()
+		input.Close()
+
+		if path == controlStdin || !isFIFO(path) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// readCommands posts each command read from input to the event loop, carrying out
+// waits here so the display keeps running meanwhile
+func (ss *SolarSystem) readCommands(ctx context.Context, input io.Reader) {
+	scanner := bufio.NewScanner(input)
+	for line := 1; scanner.Scan(); line++ {
+		command, ok, err := control.Parse(scanner.Text())
+		if err != nil {
+			ss.logger.Printf("Control line %d: %v", line, err)
+			ss.state.SetStatusMessage(fmt.Sprintf("Control line %d: %v", line, err), statusMessageDuration)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		if command.Name == control.Wait {
+			duration, _ := command.Duration()
+			if !ss.wait(ctx, duration) {
+				return
+			}
+			continue
+		}
+
+		ss.logger.Debugf("Control: %s", command)
+		event := &controlEvent{command: command}
+		event.SetEventNow()
+		if err := ss.screen.PostEvent(event); err != nil {
+			ss.logger.Debugf("Control: dropped %q: %v", command, err)
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		ss.logger.Printf("Control: %v", err)
+	}
+}
+
+// wait pauses the command reader for d, reporting false if ctx ended first. In
+// deterministic mode d is stepped time, counted off in drawn frames, so a script
+// reaches the same frames on every run.
+func (ss *SolarSystem) wait(ctx context.Context, d time.Duration) bool {
+	if !ss.renderer.deterministic() {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(d):
+			return true
+		}
+	}
+
+	target := ss.renderer.clock.Now().Add(d)
+	done := make(chan struct{})
+	ss.renderer.AddFrameHook(func(Frame) bool {
+		if ss.renderer.clock.Now().Before(target) {
+			return true
+		}
+		close(done)
+		return false
+	})
+	select {
+	case <-ctx.Done():
+		return false
+	case <-done:
+		return true
+	}
+}
+
+// isFIFO reports whether path is a named pipe
+func isFIFO(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode()&os.ModeNamedPipe != 0
+}
+
+// applyControlCommand carries out a command from the control channel. Commands
+// that cannot be carried out say why on the status line.
+func (ed *EventDispatcher) applyControlCommand(command control.Command) {
+	var err error
+	switch command.Name {
+	case control.Select:
+		err = ed.selectByName(command.Arg)
+	case control.OpenMoons:
+		err = ed.openMoonsOfSelected()
+	case control.SwitchSystem:
+		err = ed.switchSystemByName(command.Arg)
+	case control.Screenshot:
+		if command.Arg == "" {
+			ed.captureScreenshot()
+		} else {
+			ed.captureScreenshotFile(command.Arg)
+		}
+	case control.Close:
+		ed.state.ResetModals()
+	case control.Quit:
+		ed.state.SetRunning(false)
+	}
+
+	if err != nil {
+		ed.state.SetStatusMessage(fmt.Sprintf("%s: %v", command.Name, err), statusMessageDuration)
+	}
+}
+
+// selectByName selects a body of the loaded system by id or any of its names and
+// shows its details
+func (ed *EventDispatcher) selectByName(name string) error {
+	for i, planet := range ed.state.GetPlanets() {
+		if planet.MatchesName(name) {
+			ed.state.ShowListTab(TabPlanets)
+			ed.state.UpdatePlanetSelection(i, planet)
+			ed.state.OpenModal(ModalDetails)
+			return nil
+		}
+	}
+	return fmt.Errorf("no body named %q in %s", name, ed.uiRenderer.GetSystemManager().GetCurrentSystemDisplayName())
+}
+
+// openMoonsOfSelected lists the selected body's moons over its details
+func (ed *EventDispatcher) openMoonsOfSelected() error {
+	planet := ed.state.SelectedPlanet
+	if len(planet.Moons) == 0 {
+		return fmt.Errorf("%s has no moons", planet.EnglishName)
+	}
+	if ed.state.TopModal() != ModalDetails {
+		ed.state.OpenModal(ModalDetails)
+	}
+	ed.state.ShowMoonList()
+	return nil
+}
+
+// switchSystemByName loads a system by the name it is listed under or its
+// display name
+func (ed *EventDispatcher) switchSystemByName(name string) error {
+	manager := ed.uiRenderer.GetSystemManager()
+	for i, system := range manager.GetAvailableSystems() {
+		if strings.EqualFold(system, name) || strings.EqualFold(manager.GetSystemDisplayName(system), name) {
+			ed.state.SystemSelectedIndex = i
+			ed.state.PickingComparison = false
+			ed.systemManager.SwitchToSelectedSystem()
+			return nil
+		}
+	}
+	return fmt.Errorf("no system named %q", name)
+}
+
+// captureScreenshotFile writes the next drawn frame to a single file, as a PNG,
+// ANSI text or the scene JSON depending on its extension
+func (ed *EventDispatcher) captureScreenshotFile(path string) {
+	ed.uiRenderer.AddFrameHook(func(frame Frame) bool {
+		snapshot := capture.SnapshotScreen(frame.Screen)
+		scene := buildScene(frame, snapshot, ed.uiRenderer.GetRenderer().GetPlanetSymbol, ed.uiRenderer.clock.Now())
+
+		if err := capture.WriteFile(path, snapshot, scene); err != nil {
+			ed.state.SetStatusMessage(fmt.Sprintf("Screenshot failed: %v", err), statusMessageDuration)
+			return false
+		}
+		ed.state.SetStatusMessage(fmt.Sprintf("Screenshot saved to %s", path), statusMessageDuration)
+		return false
+	})
+}
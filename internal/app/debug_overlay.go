@@ -0,0 +1,89 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/gdamore/tcell/v2"
+)
+
+// frameCounter measures the frame rate over a rolling window. It is only touched by
+// the render goroutine.
+type frameCounter struct {
+	windowStart time.Time
+	frames      int
+	fps         float64
+}
+
+// tick records a drawn frame, updating the rate once per window
+func (fc *frameCounter) tick(now time.Time) {
+	if fc.windowStart.IsZero() {
+		fc.windowStart = now
+	}
+
+	fc.frames++
+	if elapsed := now.Sub(fc.windowStart); elapsed >= constants.DebugStatsWindow {
+		fc.fps = float64(fc.frames) / elapsed.Seconds()
+		fc.frames = 0
+		fc.windowStart = now
+	}
+}
+
+// debugOverlayLines returns the rows shown in the debug overlay
+func (ur *UIRenderer) debugOverlayLines() []string {
+	frame := fmt.Sprintf("Frame    %s / %s", ur.budget.cost.Round(time.Millisecond), ur.frameInterval())
+	if ur.idle() {
+		frame += " idle"
+	}
+	lines := []string{
+		fmt.Sprintf("FPS      %.1f", ur.frames.fps),
+		frame,
+	}
+
+	if ur.client != nil {
+		stats := ur.client.Stats()
+		latency := "-"
+		if stats.LastLatency > 0 {
+			latency = stats.LastLatency.Round(time.Millisecond).String()
+		}
+		lines = append(lines,
+			fmt.Sprintf("API      %s", latency),
+			fmt.Sprintf("Cache    %d/%d hits (%.0f%%)", stats.CacheHits, stats.Requests, stats.HitRate()*100),
+			fmt.Sprintf("304s     %d", stats.NotModified),
+		)
+		if drift := ur.client.SchemaDrift(); !drift.Empty() {
+			lines = append(lines, fmt.Sprintf("Schema   %d new, %d gone", len(drift.Unknown), len(drift.Missing)))
+		}
+	}
+
+	lines = append(lines,
+		fmt.Sprintf("Grid     %d×%d", ur.camera.Width, ur.camera.Height),
+		fmt.Sprintf("Bodies   %d", len(ur.state.GetPlanets())),
+		fmt.Sprintf("Sim date %s", ur.renderer.GetClock().Now().UTC().Format("2006-01-02")),
+	)
+	return lines
+}
+
+// drawDebugOverlay renders runtime stats in the top-right corner
+func (ur *UIRenderer) drawDebugOverlay(width int) {
+	lines := ur.debugOverlayLines()
+
+	boxWidth := 0
+	for _, line := range lines {
+		if n := ui.TextWidth(line); n > boxWidth {
+			boxWidth = n
+		}
+	}
+	boxWidth += 2
+
+	x := width - boxWidth - 2
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorGreen).Bold(true)
+	lineStyle := tcell.StyleDefault.Foreground(tcell.ColorGreen).Background(tcell.ColorBlack)
+
+	ur.drawText(x, 1, titleStyle, fmt.Sprintf(" %-*s", boxWidth-1, "DEBUG"))
+	for i, line := range lines {
+		ur.drawText(x, 2+i, lineStyle, fmt.Sprintf(" %-*s", boxWidth-1, line))
+	}
+}
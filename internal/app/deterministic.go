@@ -0,0 +1,40 @@
+package app
+
+import (
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/orbital"
+)
+
+// deterministicEpoch is where the clock starts in deterministic mode: J2000, so
+// every run shows the same sky
+var deterministicEpoch = time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// newDeterministicClock returns the clock deterministic mode runs on. It moves one
+// frame interval per drawn frame, so the animation keeps its usual pace however
+// long frames really take.
+func newDeterministicClock() *orbital.StepClock {
+	return orbital.NewStepClock(deterministicEpoch, constants.DisplayUpdateRate)
+}
+
+// SetClock drives the map and the time-dependent parts of the UI from clock. A
+// StepClock is advanced after each frame, and frames are then paced at a fixed
+// rate rather than slowed down under load.
+func (ur *UIRenderer) SetClock(clock orbital.Clock) {
+	ur.clock = clock
+	ur.renderer.SetClock(clock)
+}
+
+// deterministic reports whether the UI runs on a stepped clock
+func (ur *UIRenderer) deterministic() bool {
+	_, ok := ur.clock.(*orbital.StepClock)
+	return ok
+}
+
+// advanceClock moves a stepped clock on to the next frame
+func (ur *UIRenderer) advanceClock() {
+	if step, ok := ur.clock.(*orbital.StepClock); ok {
+		step.Advance()
+	}
+}
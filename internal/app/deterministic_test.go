@@ -0,0 +1,94 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestDeterministicFramesRepeat(t *testing.T) {
+	record := func() []string {
+		dispatcher, _, screen := newAppFixture(t, 120, 40)
+		ur := dispatcher.uiRenderer
+		ur.SetClock(newDeterministicClock())
+
+		var frames []string
+		for i := 0; i < 5; i++ {
+			ur.DrawScreen()
+			frames = append(frames, screenText(screen))
+		}
+		return frames
+	}
+
+	first, second := record(), record()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("frame %d differs between runs", i)
+		}
+	}
+	if first[0] == first[len(first)-1] {
+		t.Error("the map did not move over five frames")
+	}
+}
+
+func TestDeterministicLaunchRepeats(t *testing.T) {
+	frames := int(launchAnimation/constants.DisplayUpdateRate) + 2
+	record := func() []string {
+		dispatcher, state, screen := newAppFixture(t, 120, 40)
+		ur := dispatcher.uiRenderer
+		ur.SetClock(newDeterministicClock())
+		planets := state.GetPlanets()
+		planets[3].Escape = 11186
+		state.SetPlanets(planets)
+		state.SelectedIndex = 3
+
+		dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone))
+		dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+		if !state.IsShowingLaunch() || state.LaunchFlight == nil {
+			t.Fatal("the launch was not fired")
+		}
+
+		var shown []string
+		for i := 0; i < frames; i++ {
+			state.Publish()
+			ur.DrawScreen()
+			shown = append(shown, screenText(screen))
+		}
+		return shown
+	}
+
+	first, second := record(), record()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("launch frame %d differs between runs", i)
+		}
+	}
+	if !strings.Contains(first[0], "In flight: T+") {
+		t.Errorf("first frame does not show the flight starting:\n%s", first[0])
+	}
+	if first[1] == first[2] {
+		t.Error("the flight did not move from one frame to the next")
+	}
+	if last := first[len(first)-1]; strings.Contains(last, "In flight") {
+		t.Errorf("the flight was still playing after %d frames:\n%s", frames, last)
+	}
+}
+
+func TestDeterministicLoadingSpinner(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 80, 24)
+	ur := dispatcher.uiRenderer
+	ur.SetClock(newDeterministicClock())
+	state.SetPlanets(nil)
+	state.Loading = "Starting up"
+
+	want := loadingSpinner(deterministicEpoch) + " Starting up"
+	for i := 0; i < 3; i++ {
+		state.Publish()
+		ur.DrawScreen()
+		if text := screenText(screen); !strings.Contains(text, want) {
+			t.Fatalf("frame %d does not show %q:\n%s", i, want, text)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/orbital"
+	"github.com/gdamore/tcell/v2"
+)
+
+// diagnosticsLine is one row of the diagnostics modal; warnings stand out
+type diagnosticsLine struct {
+	text    string
+	warning bool
+}
+
+// checkPhysics lints the loaded system after it is loaded, pointing at the
+// diagnostics when some body's period does not fit its orbit
+func (sm *SystemManager) checkPhysics() {
+	report := orbital.CheckKepler(sm.state.GetPlanets(), orbital.KeplerTolerance)
+	sm.state.Diagnostics = report
+	if len(report.Issues) == 0 {
+		return
+	}
+
+	noun := "bodies break"
+	if len(report.Issues) == 1 {
+		noun = "body breaks"
+	}
+	sm.state.SetStatusMessage(fmt.Sprintf("%d %s Kepler's third law • %s for diagnostics",
+		len(report.Issues), noun, sm.uiRenderer.keys.Primary(keymap.ActionDiagnostics)), statusMessageDuration)
+}
+
+// openDiagnostics checks the system again, so that edited orbits count, and shows
+// the result
+func (ed *EventDispatcher) openDiagnostics() {
+	ed.state.ShowDiagnostics(orbital.CheckKepler(ed.state.GetPlanets(), orbital.KeplerTolerance))
+}
+
+// handleDiagnosticsKeys handles keyboard input while the diagnostics are open
+func (ed *EventDispatcher) handleDiagnosticsKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyEnter:
+		ed.state.PopModal()
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'q', 'Q', 'b', 'B':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// diagnosticsLines describes a Kepler check: what was checked, then each body
+// whose period is off and by how much
+func diagnosticsLines(report orbital.KeplerReport) []diagnosticsLine {
+	lines := []diagnosticsLine{
+		{text: "Kepler's third law: a period follows from the semi-major axis and the star's mass"},
+	}
+	if report.AssumedMass {
+		lines = append(lines, diagnosticsLine{text: "No star here has a recorded mass, so the Sun's is assumed", warning: true})
+	}
+	lines = append(lines, diagnosticsLine{})
+
+	tolerance := fmt.Sprintf("%.0f%%", orbital.KeplerTolerance*100)
+	switch {
+	case report.Checked == 0:
+		lines = append(lines, diagnosticsLine{text: "No body has both a semi-major axis and a period to check"})
+	case len(report.Issues) == 0:
+		lines = append(lines, diagnosticsLine{text: fmt.Sprintf("All %d orbits agree within %s", report.Checked, tolerance)})
+	default:
+		lines = append(lines, diagnosticsLine{text: fmt.Sprintf("%d of %d orbits are off by more than %s:", len(report.Issues), report.Checked, tolerance)})
+		for _, issue := range report.Issues {
+			lines = append(lines,
+				diagnosticsLine{text: fmt.Sprintf("⚠ %s: recorded %s, expected %s (%+.0f%%)",
+					issue.Body, formatPeriodDays(issue.Recorded), formatPeriodDays(issue.Expected), issue.Deviation*100), warning: true},
+				diagnosticsLine{text: "    orbiting " + issue.Host})
+		}
+	}
+	return lines
+}
+
+// formatPeriodDays writes a period in days with the precision short ones need
+func formatPeriodDays(days float64) string {
+	if days < 10 {
+		return fmt.Sprintf("%.2f days", days)
+	}
+	return fmt.Sprintf("%s days", formatCount(int(math.Round(days))))
+}
+
+// drawDiagnosticsModal renders the physics checks of the loaded system
+func (ur *UIRenderer) drawDiagnosticsModal(width, height int) {
+	lines := diagnosticsLines(ur.state.Diagnostics)
+	modalX, modalY, _, modalHeight := ur.setupModal(width, height, fitModalHeight(len(lines), height))
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, fmt.Sprintf(" 🔭 %s Diagnostics ", ur.systemManager.GetCurrentSystemDisplayName()))
+
+	textStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	warningStyle := tcell.StyleDefault.Foreground(tcell.ColorOrange).Background(tcell.ColorDarkBlue)
+
+	lastRow := modalY + modalHeight - 3
+	for i, line := range lines {
+		y := modalY + 3 + i
+		if y > lastRow {
+			break
+		}
+		if y == lastRow && i < len(lines)-1 {
+			ur.drawText(modalX+2, y, textStyle, "…")
+			break
+		}
+		style := textStyle
+		if line.warning {
+			style = warningStyle
+		}
+		ur.drawText(modalX+2, y, style, truncateText(line.text, ur.contentWidth()))
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "Press Enter, Escape, or 'b' to close")
+}
@@ -0,0 +1,263 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/api"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+// e2eTimeout is how long the end-to-end tests wait for the app to get somewhere
+const e2eTimeout = 5 * time.Second
+
+// e2eBodies is what the mock API knows: the Sun and four planets
+var e2eBodies = []models.CelestialBody{
+	{ID: "soleil", Name: "Soleil", EnglishName: "Sun", BodyType: "Star", MeanRadius: 695508},
+	{ID: "mercure", Name: "Mercure", EnglishName: "Mercury", BodyType: "Planet", IsPlanet: true, SemimajorAxis: 57909227, SideralOrbit: 87.97, MeanRadius: 2439.4},
+	{ID: "venus", Name: "Vénus", EnglishName: "Venus", BodyType: "Planet", IsPlanet: true, SemimajorAxis: 108209475, SideralOrbit: 224.7, MeanRadius: 6051.8},
+	{ID: "terre", Name: "La Terre", EnglishName: "Earth", BodyType: "Planet", IsPlanet: true, SemimajorAxis: 149598023, SideralOrbit: 365.256, MeanRadius: 6371},
+	{ID: "mars", Name: "Mars", EnglishName: "Mars", BodyType: "Planet", IsPlanet: true, SemimajorAxis: 227939200, SideralOrbit: 686.98, MeanRadius: 3389.5},
+}
+
+// e2eSystem is the one system file the app finds besides the Solar System
+const e2eSystem = `{
+  "systemName": "Test Star",
+  "description": "A star made up for the end-to-end tests",
+  "distance": "12 light-years",
+  "bodies": [
+    {"id": "test-star", "englishName": "Test Star", "bodyType": "Star", "isPlanet": false, "meanRadius": 500000, "stellarClass": "K2V"},
+    {"id": "test-b", "englishName": "Test Star b", "bodyType": "Planet", "isPlanet": true, "semimajorAxis": 90000000, "sideralOrbit": 150, "meanRadius": 7000}
+  ]
+}`
+
+// mockAPI serves e2eBodies the way the Solar System API does and remembers the
+// paths it was asked for
+type mockAPI struct {
+	*httptest.Server
+	mu    sync.Mutex
+	paths []string
+}
+
+func newMockAPI(t *testing.T) *mockAPI {
+	t.Helper()
+	m := &mockAPI{}
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		m.paths = append(m.paths, r.URL.Path)
+		m.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/bodies":
+			json.NewEncoder(w).Encode(map[string]interface{}{"bodies": e2eBodies})
+		case strings.HasPrefix(r.URL.Path, "/bodies/"):
+			id := strings.TrimPrefix(r.URL.Path, "/bodies/")
+			for _, body := range e2eBodies {
+				if body.ID == id {
+					json.NewEncoder(w).Encode(body)
+					return
+				}
+			}
+			http.NotFound(w, r)
+		case r.URL.Path == "/knowncount":
+			json.NewEncoder(w).Encode(map[string]interface{}{"knowncount": []interface{}{}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(m.Close)
+	return m
+}
+
+// asked reports whether the API was asked for path
+func (m *mockAPI) asked(path string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, p := range m.paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// e2eApp is the whole app running on a simulated screen against the mock API,
+// driven by synthetic key events
+type e2eApp struct {
+	t      *testing.T
+	ss     *SolarSystem
+	screen tcell.SimulationScreen
+	api    *mockAPI
+	done   chan error
+}
+
+// startApp runs the app as main does, on a width by height simulated terminal,
+// and waits for the Solar System to load
+func startApp(t *testing.T, width, height int) *e2eApp {
+	t.Helper()
+
+	systemsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(systemsDir, "test-star.json"), []byte(e2eSystem), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := newMockAPI(t)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	ss, err := NewSolarSystem(Options{
+		Screen:        screen,
+		Client:        api.NewClient(api.WithBaseURL(mock.URL), api.WithRateLimit(0, 0)),
+		SystemsDir:    systemsDir,
+		Deterministic: true,
+	})
+	if err != nil {
+		t.Fatalf("NewSolarSystem() error = %v", err)
+	}
+	screen.SetSize(width, height)
+
+	app := &e2eApp{t: t, ss: ss, screen: screen, api: mock, done: make(chan error, 1)}
+	go func() { app.done <- ss.Run() }()
+	t.Cleanup(app.stop)
+
+	app.waitFor("the Solar System to load", func(frame *AppState) bool {
+		return frame.Loading == "" && len(frame.Planets) == len(e2eBodies)
+	})
+	return app
+}
+
+// press sends a special key, as the terminal would
+func (app *e2eApp) press(key tcell.Key) {
+	app.screen.InjectKey(key, 0, tcell.ModNone)
+}
+
+// typeRune sends a printable key
+func (app *e2eApp) typeRune(r rune) {
+	app.screen.InjectKey(tcell.KeyRune, r, tcell.ModNone)
+}
+
+// waitFor waits until the last frame the app published passes ready
+func (app *e2eApp) waitFor(what string, ready func(frame *AppState) bool) {
+	app.t.Helper()
+	deadline := time.Now().Add(e2eTimeout)
+	for time.Now().Before(deadline) {
+		if frame := app.ss.state.Frame(); frame != nil && ready(frame) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	app.t.Fatalf("timed out waiting for %s", what)
+}
+
+// waitForText waits until text is on the simulated screen
+func (app *e2eApp) waitForText(text string) {
+	app.t.Helper()
+	deadline := time.Now().Add(e2eTimeout)
+	for time.Now().Before(deadline) {
+		if strings.Contains(app.text(), text) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	app.t.Fatalf("timed out waiting for %q on screen:\n%s", text, app.text())
+}
+
+// text returns what is on the simulated screen, read between frames as the
+// screen's cells are drawn in place
+func (app *e2eApp) text() string {
+	app.ss.renderer.drawMu.Lock()
+	defer app.ss.renderer.drawMu.Unlock()
+	return screenText(app.screen)
+}
+
+// stop quits the app and waits for Run to return, pressing q until it does as
+// each press first closes a modal left open
+func (app *e2eApp) stop() {
+	app.t.Helper()
+	deadline := time.After(e2eTimeout)
+	for {
+		select {
+		case err := <-app.done:
+			if err != nil {
+				app.t.Errorf("Run() error = %v", err)
+			}
+			app.done <- nil
+			return
+		case <-deadline:
+			app.t.Fatal("the app did not quit")
+		case <-time.After(100 * time.Millisecond):
+			app.typeRune('q')
+		}
+	}
+}
+
+func TestE2EStartsOnTheSolarSystem(t *testing.T) {
+	app := startApp(t, 120, 40)
+
+	app.waitForText("Mercury")
+	app.waitForText("Mars")
+	if !app.api.asked("/bodies") {
+		t.Error("the mock API was never asked for the bodies")
+	}
+
+	app.stop()
+	if app.ss.state.IsRunning() {
+		t.Error("the app still runs after q")
+	}
+}
+
+func TestE2EOpensAndClosesDetails(t *testing.T) {
+	app := startApp(t, 120, 40)
+
+	app.press(tcell.KeyDown)
+	app.press(tcell.KeyDown)
+	app.waitFor("Venus to be selected", func(frame *AppState) bool {
+		return frame.SelectedIndex == 2
+	})
+
+	app.press(tcell.KeyEnter)
+	app.waitFor("the details to open", func(frame *AppState) bool {
+		return frame.TopModal() == ModalDetails
+	})
+	app.waitForText("♀ Venus")
+	app.waitForText("Also Known As: Vénus")
+
+	app.press(tcell.KeyEscape)
+	app.waitFor("the details to close", func(frame *AppState) bool {
+		return frame.TopModal() == ModalNone
+	})
+}
+
+func TestE2ESwitchesSystem(t *testing.T) {
+	app := startApp(t, 120, 40)
+
+	app.typeRune('s')
+	app.waitFor("the system list to open", func(frame *AppState) bool {
+		return frame.TopModal() == ModalSystemList
+	})
+	app.waitForText("Test Star")
+
+	systems := app.ss.renderer.GetSystemManager().GetAvailableSystems()
+	for i, system := range systems {
+		if system == "test-star" {
+			break
+		}
+		if i == len(systems)-1 {
+			t.Fatalf("test-star is not among the systems %v", systems)
+		}
+		app.press(tcell.KeyDown)
+	}
+	app.press(tcell.KeyEnter)
+
+	app.waitFor("the test system to load", func(frame *AppState) bool {
+		return frame.TopModal() == ModalNone && len(frame.Planets) == 2 && frame.Planets[0].EnglishName == "Test Star"
+	})
+	app.waitForText("Test Star b")
+}
@@ -0,0 +1,288 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/gdamore/tcell/v2"
+)
+
+// elementField describes how one orbital element is displayed and nudged in the editor
+type elementField struct {
+	Label   string
+	Display func(el *models.OrbitalElement) string
+	Adjust  func(el *models.OrbitalElement, steps float64)
+}
+
+// getElementFields returns the editable orbital elements in display order
+func getElementFields() []elementField {
+	return []elementField{
+		{
+			Label:   "Semi-major Axis",
+			Display: func(el *models.OrbitalElement) string { return fmt.Sprintf("%.0f km", el.SemimajorAxis) },
+			Adjust: func(el *models.OrbitalElement, steps float64) {
+				el.SemimajorAxis = math.Max(1, el.SemimajorAxis*(1+0.01*steps))
+			},
+		},
+		{
+			Label:   "Eccentricity",
+			Display: func(el *models.OrbitalElement) string { return fmt.Sprintf("%.4f", el.Eccentricity) },
+			Adjust: func(el *models.OrbitalElement, steps float64) {
+				el.Eccentricity = clampFloat(el.Eccentricity+0.005*steps, 0, 0.99)
+			},
+		},
+		{
+			Label:   "Inclination",
+			Display: func(el *models.OrbitalElement) string { return fmt.Sprintf("%.2f°", el.Inclination) },
+			Adjust: func(el *models.OrbitalElement, steps float64) {
+				el.Inclination = clampFloat(el.Inclination+steps, 0, 180)
+			},
+		},
+		{
+			Label:   "Argument of Periapsis",
+			Display: func(el *models.OrbitalElement) string { return fmt.Sprintf("%.2f°", el.ArgumentOfPeriapsis) },
+			Adjust: func(el *models.OrbitalElement, steps float64) {
+				el.ArgumentOfPeriapsis = wrapDegrees(el.ArgumentOfPeriapsis + 5*steps)
+			},
+		},
+		{
+			Label:   "Longitude of Asc. Node",
+			Display: func(el *models.OrbitalElement) string { return fmt.Sprintf("%.2f°", el.LongitudeOfAscendingNode) },
+			Adjust: func(el *models.OrbitalElement, steps float64) {
+				el.LongitudeOfAscendingNode = wrapDegrees(el.LongitudeOfAscendingNode + 5*steps)
+			},
+		},
+		{
+			Label:   "Mean Anomaly",
+			Display: func(el *models.OrbitalElement) string { return fmt.Sprintf("%.2f°", el.MeanAnomaly) },
+			Adjust: func(el *models.OrbitalElement, steps float64) {
+				el.MeanAnomaly = wrapDegrees(el.MeanAnomaly + 5*steps)
+			},
+		},
+		{
+			Label:   "Epoch",
+			Display: func(el *models.OrbitalElement) string { return el.Epoch.UTC().Format("2006-01-02 15:04 MST") },
+			Adjust: func(el *models.OrbitalElement, steps float64) {
+				el.Epoch = el.Epoch.Add(time.Duration(steps * 24 * float64(time.Hour)))
+			},
+		},
+	}
+}
+
+// canEditOrbitalElements reports whether the element editor is available for a body.
+// Bodies that already carry elements can always be tweaked; external-system planets
+// without elements get them seeded from their basic orbit fields.
+func canEditOrbitalElements(body models.CelestialBody, currentSystem string) bool {
+	if body.SemimajorAxis <= 0 && body.OrbitalElements == nil {
+		return false
+	}
+	return body.OrbitalElements != nil || currentSystem != "solar-system"
+}
+
+// seedOrbitalElements builds elements for a body that has none, starting from where
+// it is currently drawn so opening the editor does not make the planet jump
+func seedOrbitalElements(body models.CelestialBody, meanAnomaly float64, now time.Time) *models.OrbitalElement {
+	return &models.OrbitalElement{
+		SemimajorAxis: body.SemimajorAxis,
+		Eccentricity:  clampFloat(body.Eccentricity, 0, 0.99),
+		Inclination:   body.Inclination,
+		MeanAnomaly:   wrapDegrees(meanAnomaly * 180 / math.Pi),
+		Epoch:         now.UTC().Truncate(time.Second),
+	}
+}
+
+// applyElementsToBody mirrors the edited elements onto the body fields used for scaling
+func applyElementsToBody(body *models.CelestialBody, elements *models.OrbitalElement) {
+	body.OrbitalElements = elements
+	body.SemimajorAxis = elements.SemimajorAxis
+	body.Eccentricity = elements.Eccentricity
+	body.Inclination = elements.Inclination
+}
+
+// openElementEditor opens the editor for the selected planet, working on a private
+// copy of its elements so cached system data is untouched until the user saves
+func (ed *EventDispatcher) openElementEditor() {
+	original := ed.state.SelectedPlanet
+	currentSystem := ed.uiRenderer.GetSystemManager().GetCurrentSystem()
+	if !canEditOrbitalElements(original, currentSystem) {
+		return
+	}
+
+	var elements models.OrbitalElement
+	if original.OrbitalElements != nil {
+		elements = *original.OrbitalElements
+		if elements.SemimajorAxis <= 0 {
+			elements.SemimajorAxis = original.SemimajorAxis
+		}
+	} else {
+		meanAnomaly := ed.uiRenderer.GetRenderer().GetCurrentMeanAnomaly(original)
+		elements = *seedOrbitalElements(original, meanAnomaly, ed.uiRenderer.GetRenderer().GetClock().Now())
+	}
+
+	planet := original
+	edited := elements
+	applyElementsToBody(&planet, &edited)
+	ed.state.ReplaceSelectedPlanet(planet)
+	ed.state.ShowElementEditor(original, elements)
+}
+
+// handleElementEditorKeys handles keyboard input while the element editor is open
+func (ed *EventDispatcher) handleElementEditorKeys(ev *tcell.EventKey) {
+	fields := getElementFields()
+
+	steps := 1.0
+	if ev.Modifiers()&tcell.ModShift != 0 {
+		steps = 10
+	}
+
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		ed.state.CloseElementEditor()
+	case tcell.KeyUp:
+		if ed.state.ElementFieldIndex > 0 {
+			ed.state.ElementFieldIndex--
+		}
+	case tcell.KeyDown:
+		if ed.state.ElementFieldIndex < len(fields)-1 {
+			ed.state.ElementFieldIndex++
+		}
+	case tcell.KeyLeft:
+		ed.adjustSelectedElement(-steps)
+	case tcell.KeyRight:
+		ed.adjustSelectedElement(steps)
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case '-', '_':
+			ed.adjustSelectedElement(-1)
+		case '+', '=':
+			ed.adjustSelectedElement(1)
+		case 'r', 'R':
+			ed.resetElementEdits()
+		case 'w', 'W':
+			ed.writeElementEdits()
+		case 'b', 'B':
+			ed.state.CloseElementEditor()
+		case 'q', 'Q':
+			ed.state.SetRunning(false)
+		}
+	default:
+		// do nothing
+	}
+}
+
+// adjustSelectedElement nudges the highlighted element; the map picks the change
+// up on the next frame because the edited body lives in shared state
+func (ed *EventDispatcher) adjustSelectedElement(steps float64) {
+	fields := getElementFields()
+	if ed.state.ElementFieldIndex < 0 || ed.state.ElementFieldIndex >= len(fields) {
+		return
+	}
+
+	planet := ed.state.SelectedPlanet
+	if planet.OrbitalElements == nil {
+		return
+	}
+
+	elements := *planet.OrbitalElements
+	fields[ed.state.ElementFieldIndex].Adjust(&elements, steps)
+	applyElementsToBody(&planet, &elements)
+
+	ed.state.ReplaceSelectedPlanet(planet)
+	ed.state.ElementEditorDirty = true
+	ed.state.ElementEditorStatus = "Modified - press 'w' to write to the system file"
+}
+
+// resetElementEdits restores the elements the editor was opened with
+func (ed *EventDispatcher) resetElementEdits() {
+	if ed.state.ElementEditorBackup == nil {
+		return
+	}
+
+	planet := ed.state.SelectedPlanet
+	elements := *ed.state.ElementEditorBackup
+	applyElementsToBody(&planet, &elements)
+	ed.state.ReplaceSelectedPlanet(planet)
+	ed.state.ElementEditorDirty = false
+	ed.state.ElementEditorStatus = "Edits reverted"
+}
+
+// writeElementEdits saves the edited elements back to the current system file
+func (ed *EventDispatcher) writeElementEdits() {
+	if ed.uiRenderer.GetSystemManager().GetCurrentSystem() == "solar-system" {
+		ed.state.ElementEditorStatus = "Solar System data comes from the API and cannot be saved"
+		return
+	}
+
+	path, err := ed.systemManager.SaveOrbitalElements(ed.state.SelectedPlanet)
+	if err != nil {
+		ed.state.ElementEditorStatus = fmt.Sprintf("Save failed: %v", err)
+		return
+	}
+
+	saved := *ed.state.SelectedPlanet.OrbitalElements
+	ed.state.ElementEditorBackup = &saved
+	ed.state.ElementEditorOriginal = ed.state.SelectedPlanet
+	ed.state.ElementEditorDirty = false
+	ed.state.ElementEditorStatus = fmt.Sprintf("Saved to %s", path)
+}
+
+// calculateElementEditorLines returns the number of content lines in the editor modal
+func (ur *UIRenderer) calculateElementEditorLines() int {
+	return len(getElementFields()) + 2 // fields + spacing + status line
+}
+
+// drawElementEditorModal renders the orbital element editor
+func (ur *UIRenderer) drawElementEditorModal(width, height int) {
+	planet := ur.state.SelectedPlanet
+	dynamicHeight := minimum(ur.calculateElementEditorLines()+6, height-4)
+	modalX, modalY, _, modalHeight := ur.setupModal(width, height, dynamicHeight)
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, fmt.Sprintf(" ✎ Orbital Elements: %s ", planet.EnglishName))
+
+	if planet.OrbitalElements == nil {
+		return
+	}
+
+	currentY := modalY + 3
+	for i, field := range getElementFields() {
+		style := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+		prefix := "  "
+		if i == ur.state.ElementFieldIndex {
+			style = tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true).Reverse(true)
+			prefix = "► "
+		}
+
+		line := fmt.Sprintf("%s%-24s %s", prefix, field.Label+":", field.Display(planet.OrbitalElements))
+		ur.drawText(modalX+2, currentY, style, line)
+		currentY++
+	}
+
+	if ur.state.ElementEditorStatus != "" {
+		statusStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+		ur.drawText(modalX+2, modalY+modalHeight-3, statusStyle, truncateText(ur.state.ElementEditorStatus, ur.contentWidth()))
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "↑/↓ field • ←/→ adjust (Shift ×10) • r reset • w write • Esc back")
+}
+
+func clampFloat(value, low, high float64) float64 {
+	return math.Max(low, math.Min(high, value))
+}
+
+func wrapDegrees(degrees float64) float64 {
+	degrees = math.Mod(degrees, 360)
+	if degrees < 0 {
+		degrees += 360
+	}
+	return degrees
+}
+
+// truncateText shortens text to fit a given number of cells
+func truncateText(text string, maxWidth int) string {
+	return ui.Truncate(text, maxWidth)
+}
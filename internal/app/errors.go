@@ -3,7 +3,8 @@ package app
 import (
 	"errors"
 	"fmt"
-	"log"
+
+	"github.com/furan917/go-solar-system/internal/logging"
 )
 
 // AppError represents application-specific errors with context
@@ -58,12 +59,12 @@ func (e *AppError) WithContext(key string, value interface{}) *AppError {
 
 // ErrorHandler provides centralized error handling for the application
 type ErrorHandler struct {
-	logger *log.Logger
+	logger *logging.Logger
 	state  *AppState
 }
 
 // NewErrorHandler creates a new error handler
-func NewErrorHandler(logger *log.Logger, state *AppState) *ErrorHandler {
+func NewErrorHandler(logger *logging.Logger, state *AppState) *ErrorHandler {
 	return &ErrorHandler{
 		logger: logger,
 		state:  state,
@@ -204,14 +205,19 @@ func NewFileError(message string, cause error) *AppError {
 // RecoverFromPanic handles panics and converts them to errors
 func RecoverFromPanic() error {
 	if r := recover(); r != nil {
-		switch v := r.(type) {
-		case error:
-			return NewAppError(ErrorTypeSystem, "Panic recovered", v)
-		case string:
-			return NewAppError(ErrorTypeSystem, "Panic recovered", fmt.Errorf(v))
-		default:
-			return NewAppError(ErrorTypeSystem, "Panic recovered", fmt.Errorf("%v", v))
-		}
+		return panicError(r)
 	}
 	return nil
 }
+
+// panicError converts a recovered panic value to an error
+func panicError(r interface{}) error {
+	switch v := r.(type) {
+	case error:
+		return NewAppError(ErrorTypeSystem, "Panic recovered", v)
+	case string:
+		return NewAppError(ErrorTypeSystem, "Panic recovered", errors.New(v))
+	default:
+		return NewAppError(ErrorTypeSystem, "Panic recovered", fmt.Errorf("%v", v))
+	}
+}
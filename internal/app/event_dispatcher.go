@@ -3,7 +3,11 @@ package app
 import (
 	"strconv"
 
-	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/display"
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/protocol"
+	"github.com/furan917/go-solar-system/internal/updates"
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -12,60 +16,145 @@ type EventDispatcher struct {
 	mouseHandler  *MouseEventHandler
 	systemManager *SystemManager
 	planetService *PlanetService
+	statsService  *StatsService
 	uiRenderer    *UIRenderer
+	keys          *keymap.Keymap
+
+	// settings is the config file that calibration and the watchlist save to
+	settings *settings
+
+	// measuredAspect is the cell ratio the terminal reported, 0 if it did not say
+	measuredAspect float64
+
+	// watcher re-fetches watched bodies in the background
+	watcher *watchPoller
+
+	// updater checks the update index for system files; nil when none is configured
+	updater *updates.Checker
+
+	// lastSync is the last state applied from a followed live-sync session
+	lastSync protocol.State
 }
 
-func NewEventDispatcher(state *AppState, mouseHandler *MouseEventHandler, systemManager *SystemManager, planetService *PlanetService, uiRenderer *UIRenderer) *EventDispatcher {
-	return &EventDispatcher{
+func NewEventDispatcher(state *AppState, mouseHandler *MouseEventHandler, systemManager *SystemManager, planetService *PlanetService, statsService *StatsService, uiRenderer *UIRenderer, keys *keymap.Keymap) *EventDispatcher {
+	ed := &EventDispatcher{
 		state:         state,
 		mouseHandler:  mouseHandler,
 		systemManager: systemManager,
 		planetService: planetService,
+		statsService:  statsService,
 		uiRenderer:    uiRenderer,
+		keys:          keys,
+	}
+	if mouseHandler != nil {
+		mouseHandler.dispatcher = ed
 	}
+	return ed
 }
 
 func (ed *EventDispatcher) HandleEvent(ev tcell.Event) {
+	// The display goroutine draws what each event leaves behind
+	defer ed.state.Publish()
+
+	switch ev := ev.(type) {
+	case *systemLoadEvent:
+		ed.systemManager.applySystemLoad(ev)
+		return
+	case *apiCheckEvent:
+		ed.applyAPICheck(ev)
+		return
+	case *updateCheckEvent:
+		ed.applyUpdateCheck(ev)
+		return
+	case *updateDownloadEvent:
+		ed.applyUpdateDownload(ev)
+		return
+	}
+
+	// While a system loads only quitting, screenshots, the debug overlay and
+	// resizing do anything
+	if ed.state.Loading != "" {
+		switch ev := ev.(type) {
+		case *tcell.EventKey:
+			ed.handleLoadingKeys(ev)
+		case *tcell.EventResize:
+			ed.handleResizeEvent(ev)
+		}
+		return
+	}
+
 	switch ev := ev.(type) {
 	case *tcell.EventMouse:
+		if ed.mouseHandler.HandleWheel(ev) || ed.mouseHandler.HandleDrag(ev) {
+			return
+		}
+		ed.mouseHandler.HandleHover(ev)
 		ed.mouseHandler.HandleClick(ev)
 	case *tcell.EventKey:
 		ed.handleKeyboardEvent(ev)
 	case *tcell.EventResize:
 		ed.handleResizeEvent(ev)
+	case *syncStateEvent:
+		ed.applySyncState(ev.state)
+	case *controlEvent:
+		ed.applyControlCommand(ev.command)
+	case *gamepadEvent:
+		ed.applyGamepadInput(ev.input)
 	}
 }
 
 func (ed *EventDispatcher) handleKeyboardEvent(ev *tcell.EventKey) {
-	if ed.state.IsShowingMoonDetails() {
-		ed.handleMoonDetailsKeys(ev)
-	} else if ed.state.IsShowingMoons() {
-		ed.handleMoonListKeys(ev)
-	} else if ed.state.IsShowingSystemList() {
-		ed.handleSystemListKeys(ev)
-	} else if ed.state.IsShowingDetails() {
-		ed.handlePlanetDetailsKeys(ev)
+	// Screenshots and the debug overlay work from any screen, including open modals
+	if action, ok := ed.keys.Action(keymap.ContextGlobal, ev); ok {
+		switch action {
+		case keymap.ActionScreenshot:
+			ed.captureScreenshot()
+		case keymap.ActionDebug:
+			ed.state.ToggleDebugOverlay()
+		}
+		return
+	}
+
+	if spec := modalSpecFor(ed.state.TopModal()); spec.keys != nil {
+		spec.keys(ed, ev)
 	} else {
 		ed.handleMainNavigationKeys(ev)
 	}
 }
 
 func (ed *EventDispatcher) handleResizeEvent(ev *tcell.EventResize) {
-	// Update renderer dimensions to handle scaling
 	width, height := ev.Size()
-	ed.uiRenderer.UpdateDimensions(width, height)
+	ed.uiRenderer.Reflow(width, height)
 }
 
 func (ed *EventDispatcher) handleMoonDetailsKeys(ev *tcell.EventKey) {
+	if action, ok := ed.keys.Action(keymap.ContextDetails, ev); ok {
+		switch action {
+		case keymap.ActionCopy, keymap.ActionCopyJSON:
+			ed.copyDetails(action)
+			return
+		case keymap.ActionRawJSON:
+			ed.openRawJSON()
+			return
+		case keymap.ActionUnits:
+			ed.toggleDetailUnits()
+			return
+		}
+	}
+
 	switch ev.Key() {
 	case tcell.KeyEscape, tcell.KeyEnter:
-		ed.state.ShowMoonList()
+		ed.state.PopModal()
+	case tcell.KeyUp:
+		ed.uiRenderer.scrollDetailsBy(ed.state, -1)
+	case tcell.KeyDown:
+		ed.uiRenderer.scrollDetailsBy(ed.state, 1)
 	case tcell.KeyRune:
 		switch ev.Rune() {
 		case 'q', 'Q':
 			ed.state.SetRunning(false)
 		case 'b', 'B':
-			ed.state.ShowMoonList()
+			ed.state.PopModal()
 		}
 	default:
 		// do nothing
@@ -81,76 +170,183 @@ func (ed *EventDispatcher) handleSystemListKeys(ev *tcell.EventKey) {
 }
 
 func (ed *EventDispatcher) handlePlanetDetailsKeys(ev *tcell.EventKey) {
-	switch ev.Key() {
-	case tcell.KeyEscape, tcell.KeyEnter:
-		ed.state.ResetModals()
-	case tcell.KeyRune:
-		switch ev.Rune() {
-		case 'q', 'Q', 'b', 'B':
-			ed.state.ResetModals()
-		case 'm', 'M':
-			if len(ed.state.SelectedPlanet.Moons) > 0 {
-				ed.state.ShowMoonList()
-			}
+	action, ok := ed.keys.Action(keymap.ContextDetails, ev)
+	if !ok {
+		switch ev.Key() {
+		case tcell.KeyUp:
+			ed.uiRenderer.scrollDetailsBy(ed.state, -1)
+		case tcell.KeyDown:
+			ed.uiRenderer.scrollDetailsBy(ed.state, 1)
 		}
-	default:
-		// do nothing
+		return
+	}
+
+	switch action {
+	case keymap.ActionClose:
+		ed.state.PopModal()
+	case keymap.ActionMoons:
+		if len(ed.state.SelectedPlanet.Moons) > 0 {
+			ed.state.ShowMoonList()
+		}
+	case keymap.ActionEditElements:
+		ed.openElementEditor()
+	case keymap.ActionWatch:
+		ed.toggleWatch()
+	case keymap.ActionTransit:
+		ed.openTransit()
+	case keymap.ActionCopy, keymap.ActionCopyJSON:
+		ed.copyDetails(action)
+	case keymap.ActionRawJSON:
+		ed.openRawJSON()
+	case keymap.ActionUnits:
+		ed.toggleDetailUnits()
+	}
+}
+
+// toggleDetailUnits shows detail values as the data gives them, or scaled to
+// readable units, whichever the setting does not
+func (ed *EventDispatcher) toggleDetailUnits() {
+	ed.state.DetailUnitsFlipped = !ed.state.DetailUnitsFlipped
+	units := ed.uiRenderer.detailFields.Units()
+	if ed.state.DetailUnitsFlipped {
+		units = units.Other()
+	}
+	if units == display.UnitsRaw {
+		ed.state.SetStatusMessage("Details in the units the data gives: days, km and kg", statusMessageDuration)
+		return
 	}
+	ed.state.SetStatusMessage("Details in readable units: years, AU and Earth, Jupiter or solar masses", statusMessageDuration)
 }
 
 func (ed *EventDispatcher) handleMainNavigationKeys(ev *tcell.EventKey) {
-	switch ev.Key() {
-	case tcell.KeyEscape, tcell.KeyCtrlC:
+	action, ok := ed.keys.Action(keymap.ContextMain, ev)
+	if !ok {
+		return
+	}
+
+	if ed.state.Comparing && ed.state.CompareFocus == comparePaneCompared && ed.handleComparedPaneAction(action, ev) {
+		return
+	}
+	if action == keymap.ActionSelectNumber {
+		ed.handleDirectPlanetSelection(ev.Rune())
+		return
+	}
+	ed.runMainAction(action)
+}
+
+// runMainAction carries out a main view action, whether its key was pressed or it
+// was picked from the command palette
+func (ed *EventDispatcher) runMainAction(action keymap.Action) {
+	switch action {
+	case keymap.ActionQuit:
 		ed.state.SetRunning(false)
-	case tcell.KeyUp, tcell.KeyLeft:
+	case keymap.ActionPrevious:
 		ed.navigatePlanet(-1)
-	case tcell.KeyDown, tcell.KeyRight:
+	case keymap.ActionNext:
 		ed.navigatePlanet(1)
-	case tcell.KeyEnter:
-		if ed.state.SelectedIndex < len(ed.state.GetPlanets()) {
-			ed.showPlanetDetails(ed.state.GetPlanets()[ed.state.SelectedIndex])
+	case keymap.ActionPagePrevious:
+		ed.pageList(-1)
+	case keymap.ActionPageNext:
+		ed.pageList(1)
+	case keymap.ActionSelect:
+		if ed.state.SelectListed(ed.state.ListedIndex()) {
+			ed.state.OpenModal(ModalDetails)
 		}
-	case tcell.KeyRune:
-		ed.handleMainNavigationRunes(ev.Rune())
-	default:
-		// do nothing
+	case keymap.ActionHelp:
+		ed.state.ShowHelp()
+	case keymap.ActionPalette:
+		ed.openCommandPalette()
+	case keymap.ActionSystems:
+		ed.showSystemList()
+	case keymap.ActionGalaxy:
+		ed.openGalaxyMap()
+	case keymap.ActionQuiz:
+		ed.openQuiz()
+	case keymap.ActionEvents:
+		ed.openEventLog()
+	case keymap.ActionMission:
+		ed.openMissionPlanner()
+	case keymap.ActionConjunctions:
+		ed.openConjunctionFinder()
+	case keymap.ActionStats:
+		ed.openStats()
+	case keymap.ActionCompare:
+		ed.toggleComparison()
+	case keymap.ActionFocus:
+		ed.toggleFocus()
+	case keymap.ActionMapNext:
+		ed.cycleMapFocus(1)
+	case keymap.ActionMapPrevious:
+		ed.cycleMapFocus(-1)
+	case keymap.ActionResonances:
+		ed.toggleResonances()
+	case keymap.ActionWobble:
+		ed.toggleWobble()
+	case keymap.ActionHabitable:
+		ed.toggleHabitableZone()
+	case keymap.ActionSpheres:
+		ed.toggleSpheres()
+	case keymap.ActionEvolution:
+		ed.toggleEvolution()
+	case keymap.ActionView:
+		ed.toggleStripView()
+	case keymap.ActionTab:
+		if ed.state.Comparing {
+			ed.switchCompareFocus()
+		} else {
+			ed.cycleListTab()
+		}
+	case keymap.ActionWatchlist:
+		ed.state.ShowWatchlist()
+	case keymap.ActionWeight:
+		ed.openWeightCalculator()
+	case keymap.ActionChart:
+		ed.state.ShowChart()
+	case keymap.ActionTree:
+		ed.state.ShowTree()
+	case keymap.ActionScaleModel:
+		ed.openScaleModel()
+	case keymap.ActionLaunch:
+		ed.openLaunchGame()
+	case keymap.ActionDiagnostics:
+		ed.openDiagnostics()
+	case keymap.ActionLegend:
+		ed.state.ShowLegend()
+	case keymap.ActionAPIStatus:
+		ed.state.ShowAPIStatus()
+	case keymap.ActionFilter:
+		ed.state.ShowFilter()
+	case keymap.ActionCalibrate:
+		ed.openCalibration()
+	case keymap.ActionSort:
+		ed.state.SortMode = ed.state.SortMode.Next()
+		ed.sortPlanets()
+	case keymap.ActionGroup:
+		ed.state.GroupByType = !ed.state.GroupByType
+		ed.sortPlanets()
 	}
 }
 
-func (ed *EventDispatcher) handleMainNavigationRunes(r rune) {
-	switch r {
-	case 'q', 'Q':
-		ed.state.SetRunning(false)
-	case 'h', 'H':
-		// Help functionality placeholder
-	case 's', 'S':
-		ed.showSystemList()
-	default:
-		ed.handleDirectPlanetSelection(r)
+func (ed *EventDispatcher) sortPlanets() {
+	if err := ed.systemManager.SortPlanets(); err != nil {
+		ed.systemManager.errorHandler.HandleError(NewStateError("failed to sort planets", err))
 	}
 }
 
 func (ed *EventDispatcher) navigatePlanet(direction int) {
-	newIndex := ed.state.SelectedIndex + direction
-	if newIndex >= 0 && newIndex < len(ed.state.GetPlanets()) {
-		ed.state.UpdatePlanetSelection(newIndex, ed.state.GetPlanets()[newIndex])
-	}
+	ed.state.MapFocus = false
+	ed.state.StepListed(direction)
 }
 
 func (ed *EventDispatcher) handleDirectPlanetSelection(r rune) {
-	if num, err := strconv.Atoi(string(r)); err == nil && num >= 1 && num <= len(ed.state.GetPlanets()) {
-		newIndex := num - 1
-		ed.state.UpdatePlanetSelection(newIndex, ed.state.GetPlanets()[newIndex])
-		ed.showPlanetDetails(ed.state.GetPlanets()[newIndex])
+	if num, err := strconv.Atoi(string(r)); err == nil && ed.state.SelectListed(ed.state.ListedMatch(num-1)) {
+		ed.state.OpenModal(ModalDetails)
 	}
 }
 
-func (ed *EventDispatcher) showPlanetDetails(planet models.CelestialBody) {
-	ed.state.ShowPlanetDetails(planet, ed.state.SelectedIndex)
-}
-
 func (ed *EventDispatcher) showSystemList() {
-	ed.state.ShowingSystemList = true
+	ed.state.ShowSystemList()
+	ed.state.PickingComparison = false
 	ed.state.SystemScrollIndex = 0
 	ed.state.SystemSelectedIndex = 0
 
@@ -170,34 +366,32 @@ func (ed *EventDispatcher) handleMoonNavigation(ev *tcell.EventKey) {
 		return
 	}
 
+	page := constants.MaxVisibleItems
 	switch ev.Key() {
 	case tcell.KeyEscape:
-		ed.state.ShowingMoons = false
-		ed.state.ShowingDetails = true
-	case tcell.KeyUp:
-		if ed.state.MoonSelectedIndex > 0 {
-			ed.state.MoonSelectedIndex--
-			if ed.state.MoonSelectedIndex < ed.state.MoonScrollIndex {
-				ed.state.MoonScrollIndex = ed.state.MoonSelectedIndex
-			}
+		if ed.state.MoonSearch != "" {
+			ed.state.MoonSearch = ""
+		} else {
+			ed.state.PopModal()
 		}
+	case tcell.KeyUp:
+		ed.state.SelectMoon(ed.state.MoonSelectedIndex-1, moonCount)
 	case tcell.KeyDown:
-		if ed.state.MoonSelectedIndex < moonCount-1 {
-			ed.state.MoonSelectedIndex++
-			if ed.state.MoonSelectedIndex >= ed.state.MoonScrollIndex+10 {
-				ed.state.MoonScrollIndex = ed.state.MoonSelectedIndex - 9
-			}
-		}
+		ed.state.SelectMoon(ed.state.MoonSelectedIndex+1, moonCount)
+	case tcell.KeyPgUp:
+		ed.state.SelectMoon(ed.state.MoonSelectedIndex-page, moonCount)
+	case tcell.KeyPgDn:
+		ed.state.SelectMoon(ed.state.MoonSelectedIndex+page, moonCount)
+	case tcell.KeyHome:
+		ed.state.SelectMoon(0, moonCount)
+	case tcell.KeyEnd:
+		ed.state.SelectMoon(moonCount-1, moonCount)
 	case tcell.KeyEnter:
 		ed.showMoonDetails()
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		ed.eraseMoonSearch()
 	case tcell.KeyRune:
-		switch ev.Rune() {
-		case 'q', 'Q':
-			ed.state.SetRunning(false)
-		case 'b', 'B':
-			ed.state.ShowingMoons = false
-			ed.state.ShowingDetails = true
-		}
+		ed.typeMoonSearch(ev.Rune())
 	default:
 		// do nothing
 	}
@@ -213,7 +407,7 @@ func (ed *EventDispatcher) handleSystemNavigation(ev *tcell.EventKey) {
 
 	switch ev.Key() {
 	case tcell.KeyEscape:
-		ed.state.ShowingSystemList = false
+		ed.state.PopModal()
 	case tcell.KeyUp:
 		if ed.state.SystemSelectedIndex > 0 {
 			ed.state.SystemSelectedIndex--
@@ -229,13 +423,17 @@ func (ed *EventDispatcher) handleSystemNavigation(ev *tcell.EventKey) {
 			}
 		}
 	case tcell.KeyEnter:
-		ed.systemManager.SwitchToSelectedSystem()
+		ed.systemManager.OpenSelectedSystem()
 	case tcell.KeyRune:
 		switch ev.Rune() {
 		case 'q', 'Q':
 			ed.state.SetRunning(false)
 		case 'b', 'B':
-			ed.state.ShowingSystemList = false
+			ed.state.PopModal()
+		case 'e', 'E':
+			ed.openMetadataEditor()
+		case 'u', 'U':
+			ed.downloadSystemUpdates()
 		}
 	default:
 		// do nothing
@@ -246,39 +444,9 @@ func (ed *EventDispatcher) showMoonDetails() {
 	if ed.state.MoonSelectedIndex < len(ed.state.SelectedPlanet.Moons) {
 		moonData := ed.state.SelectedPlanet.Moons[ed.state.MoonSelectedIndex]
 		moonHandler := ed.uiRenderer.GetRenderer().GetMoonHandler()
-		moonName := moonHandler.GetMoonNameFromAPI(moonData)
-
-		if moonData.ID != "" {
-			if moonDetail, err := ed.planetService.GetClient().GetMoonData(moonData.ID); err == nil {
-				ed.state.SelectedMoon = *moonDetail
-				ed.state.SelectedMoon.BodyType = "Moon"
-				ed.state.SelectedMoon.AroundPlanet = &models.Planet{
-					EnglishName: ed.state.SelectedPlanet.EnglishName,
-				}
-			} else {
-				ed.state.SelectedMoon = models.CelestialBody{
-					ID:          moonData.ID,
-					Name:        moonData.Name,
-					EnglishName: moonName,
-					BodyType:    "Moon",
-					AroundPlanet: &models.Planet{
-						EnglishName: ed.state.SelectedPlanet.EnglishName,
-					},
-				}
-			}
-		} else {
-			ed.state.SelectedMoon = models.CelestialBody{
-				ID:          moonData.ID,
-				Name:        moonData.Name,
-				EnglishName: moonName,
-				BodyType:    "Moon",
-				AroundPlanet: &models.Planet{
-					EnglishName: ed.state.SelectedPlanet.EnglishName,
-				},
-			}
-		}
+		systemName := ed.uiRenderer.GetSystemManager().GetCurrentSystem()
 
-		ed.state.ShowingMoonDetails = true
-		ed.state.ShowingMoons = false
+		ed.state.SelectedMoon = ed.planetService.MoonDetails(systemName, ed.state.SelectedPlanet, moonData, moonHandler)
+		ed.state.ShowMoonDetails(moonHandler.EnrichMoon(ed.state.SelectedMoon))
 	}
 }
@@ -0,0 +1,223 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/events"
+	"github.com/furan917/go-solar-system/internal/orbital"
+	"github.com/gdamore/tcell/v2"
+)
+
+// eventLogRanges are the look-ahead windows the event log cycles through, in days
+var eventLogRanges = []int{30, 90, 365, 1825}
+
+const (
+	// eventWatchLookahead is how far ahead of the simulated clock events are precomputed
+	eventWatchLookahead = 60 * 24 * time.Hour
+
+	// toastDuration is how long an event alert stays on screen
+	toastDuration = 3 * time.Second
+
+	// toastInterval limits alerts to one per interval; extra events are folded in
+	toastInterval = time.Second
+
+	// maxToasts is the number of alerts shown at once
+	maxToasts = 3
+)
+
+// eventWatcher raises alerts as the simulated clock passes orbital events. It runs as
+// a frame hook, so it only ever touches the screen from the render goroutine.
+type eventWatcher struct {
+	state  *AppState
+	engine *events.Engine
+	clock  *orbital.SimulationClock
+
+	system    string
+	bodyCount int
+	checked   time.Time // events up to here have already been raised
+	horizon   time.Time // events up to here have been computed
+	upcoming  []events.Event
+	pending   []events.Event
+	lastToast time.Time
+}
+
+func newEventWatcher(state *AppState, engine *events.Engine, clock *orbital.SimulationClock) *eventWatcher {
+	return &eventWatcher{
+		state:  state,
+		engine: engine,
+		clock:  clock,
+	}
+}
+
+// onFrame moves the watch up to the simulated time of each frame, looking further
+// ahead once the window it has searched runs short, and raises the events the
+// clock has just passed
+func (w *eventWatcher) onFrame(frame Frame) bool {
+	now := w.clock.Now()
+	planets := frame.State.GetPlanets()
+
+	// Start over when the system changes so stale events never fire, and when the
+	// clock jumps, from scrubbing or syncing, rather than raise everything skipped
+	if frame.System != w.system || len(planets) != w.bodyCount || now.Before(w.checked) || now.After(w.horizon) {
+		w.system = frame.System
+		w.bodyCount = len(planets)
+		w.checked = now
+		w.horizon = now
+		w.upcoming = nil
+		w.pending = nil
+	}
+
+	if now.After(w.horizon.Add(-eventWatchLookahead / 2)) {
+		until := now.Add(eventWatchLookahead)
+		w.upcoming = append(w.upcoming, w.engine.Find(planets, w.horizon, until)...)
+		w.horizon = until
+	}
+
+	for len(w.upcoming) > 0 && !w.upcoming[0].Time.After(now) {
+		if w.upcoming[0].Time.After(w.checked) {
+			w.pending = append(w.pending, w.upcoming[0])
+		}
+		w.upcoming = w.upcoming[1:]
+	}
+	w.checked = now
+
+	if len(w.pending) > 0 && time.Since(w.lastToast) >= toastInterval {
+		w.raise(frame.Screen)
+	}
+
+	return true
+}
+
+// raise shows the oldest pending event, folding any others into the same alert
+func (w *eventWatcher) raise(screen tcell.Screen) {
+	toasts, bell := w.state.GetAlertSettings()
+	if !toasts {
+		w.pending = nil
+		return
+	}
+
+	message := "⚡ " + w.pending[0].Description
+	if extra := len(w.pending) - 1; extra > 0 {
+		message += fmt.Sprintf(" (+%d more)", extra)
+	}
+	w.pending = nil
+	w.lastToast = time.Now()
+
+	w.state.PushToast(message, toastDuration)
+	if bell {
+		_ = screen.Beep()
+	}
+}
+
+// openEventLog lists upcoming events starting from the current simulated time
+func (ed *EventDispatcher) openEventLog() {
+	ed.state.ShowEventLog()
+	ed.refreshEventLog()
+}
+
+// refreshEventLog recomputes the event log for the selected look-ahead window
+func (ed *EventDispatcher) refreshEventLog() {
+	days := eventLogRanges[ed.state.EventLogRange]
+	from := ed.uiRenderer.GetRenderer().GetClock().Now()
+	to := from.Add(time.Duration(days) * 24 * time.Hour)
+
+	engine := events.NewEngine(ed.uiRenderer.GetRenderer().GetEphemeris())
+	ed.state.SetEventLog(engine.Find(ed.state.GetPlanets(), from, to), from)
+}
+
+// handleEventLogKeys handles keyboard input while the event log is open
+func (ed *EventDispatcher) handleEventLogKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyEnter:
+		ed.state.PopModal()
+	case tcell.KeyUp:
+		if ed.state.EventLogScroll > 0 {
+			ed.state.EventLogScroll--
+		}
+	case tcell.KeyDown:
+		if ed.state.EventLogScroll < len(ed.state.EventLog)-constants.MaxVisibleItems {
+			ed.state.EventLogScroll++
+		}
+	case tcell.KeyLeft:
+		if ed.state.EventLogRange > 0 {
+			ed.state.EventLogRange--
+			ed.refreshEventLog()
+		}
+	case tcell.KeyRight:
+		if ed.state.EventLogRange < len(eventLogRanges)-1 {
+			ed.state.EventLogRange++
+			ed.refreshEventLog()
+		}
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'q', 'Q', 'b', 'B':
+			ed.state.PopModal()
+		case 'r', 'R':
+			ed.refreshEventLog()
+		case 't', 'T':
+			ed.state.ToggleToasts()
+		case 'a', 'A':
+			ed.state.ToggleBell()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// drawEventLogModal renders the list of upcoming events
+func (ur *UIRenderer) drawEventLogModal(width, height int) {
+	modalX, modalY, modalWidth, modalHeight := ur.setupModal(width, height)
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	days := eventLogRanges[ur.state.EventLogRange]
+	ur.drawText(modalX+2, modalY+1, titleStyle, fmt.Sprintf(" 🔭 Upcoming Events (next %d days) ", days))
+
+	eventLog := ur.state.EventLog
+	detailStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	dateStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue)
+	startY := modalY + 3
+
+	if len(eventLog) == 0 {
+		ur.drawText(modalX+2, startY, detailStyle, "No events in this window")
+	}
+
+	visibleItems := constants.MaxVisibleItems
+	if ur.state.EventLogScroll > 0 {
+		ur.drawText(modalX+modalWidth-2, modalY+2, tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true), "↑")
+	}
+	if ur.state.EventLogScroll+visibleItems < len(eventLog) {
+		ur.drawText(modalX+modalWidth-2, modalY+modalHeight-4, tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true), "↓")
+	}
+
+	for i := 0; i < visibleItems && i+ur.state.EventLogScroll < len(eventLog); i++ {
+		event := eventLog[i+ur.state.EventLogScroll]
+		date := event.Time.UTC().Format("2006-01-02")
+		ur.drawText(modalX+2, startY+i, dateStyle, date)
+		ur.drawText(modalX+2+len(date)+2, startY+i, detailStyle, truncateText(event.Description, ur.contentWidth()-len(date)-2))
+	}
+
+	toasts, bell := ur.state.GetAlertSettings()
+	statusStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	status := fmt.Sprintf("%d events • alerts %s • bell %s", len(eventLog), onOff(toasts), onOff(bell))
+	ur.drawText(modalX+2, modalY+modalHeight-3, statusStyle, status)
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "↑/↓ scroll • ←/→ range • t alerts • a bell • Escape to close")
+}
+
+// drawToasts renders active event alerts above the instruction bar
+func (ur *UIRenderer) drawToasts(height int) {
+	toastStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorYellow).Bold(true)
+	for i, message := range ur.state.GetToasts() {
+		ur.drawText(2, height-4-i, toastStyle, " "+message+" ")
+	}
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
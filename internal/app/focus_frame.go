@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+// toggleFocus centres the map on the selected body with its moons around it, or
+// back on the star when the map is already centred on a body
+func (ed *EventDispatcher) toggleFocus() {
+	if ed.state.Focusing {
+		ed.state.StopFocus()
+		return
+	}
+	if ed.state.Comparing {
+		ed.state.SetStatusMessage("Stop comparing systems to centre the map on a body", statusMessageDuration)
+		return
+	}
+
+	center := ed.state.SelectedPlanet
+	if center.EnglishName == "" || center.BodyType == "Star" || center.SemimajorAxis == 0 {
+		ed.state.SetStatusMessage("Select a planet to centre the map on", statusMessageDuration)
+		return
+	}
+
+	satellites, err := ed.planetService.LoadSatellites(ed.uiRenderer.GetSystemManager().GetCurrentSystem(), center)
+	if err != nil {
+		ed.state.SetStatusMessage(fmt.Sprintf("Could not load the moons of %s: %v", center.EnglishName, err), statusMessageDuration)
+		return
+	}
+	if len(satellites) == 0 {
+		ed.state.SetStatusMessage(fmt.Sprintf("No moons of %s with a known orbit", center.EnglishName), statusMessageDuration)
+		return
+	}
+	ed.state.StartFocus(center, satellites)
+}
+
+// LoadSatellites returns the bodies orbiting center that have an orbit to draw,
+// from the same moons the list's moons tab shows
+func (ps *PlanetService) LoadSatellites(systemName string, center models.CelestialBody) ([]models.CelestialBody, error) {
+	moons, err := ps.LoadBodyClass(systemName, TabMoons)
+	if err != nil {
+		return nil, err
+	}
+
+	var satellites []models.CelestialBody
+	for _, moon := range moons {
+		if moon.SemimajorAxis > 0 && orbits(moon, center) {
+			satellites = append(satellites, moon)
+		}
+	}
+	return satellites, nil
+}
+
+// orbits reports whether body goes around center, going by the body's
+// aroundPlanet or by center's list of moons
+func orbits(body, center models.CelestialBody) bool {
+	if parent := body.AroundPlanet; parent != nil {
+		switch {
+		case center.ID != "" && (parent.ID == center.ID || parent.Planet == center.ID):
+			return true
+		case parent.EnglishName != "" && strings.EqualFold(parent.EnglishName, center.EnglishName):
+			return true
+		}
+	}
+	for _, moon := range center.Moons {
+		if (moon.Rel != "" && moon.Rel == body.Rel) || (moon.ID != "" && moon.ID == body.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// drawFocusFrame draws the map centred on the focused body, its moons' orbits
+// scaled to fill the map
+func (ur *UIRenderer) drawFocusFrame(region layout.Rect) {
+	screenWidth, screenHeight := ur.screen.Size()
+	center := ur.state.FocusBody
+	grid, positions := ur.renderer.RenderFrameWithPositions(center, ur.state.FocusSatellites, region.Width, region.Height, screenWidth, screenHeight)
+	ur.state.UpdatePlanetPositions(region.X, region.Y, positions)
+
+	ur.camera = ur.renderer.GetCamera(region.Width, region.Height)
+	ur.camera.OriginX += region.X
+	ur.camera.OriginY += region.Y
+	ur.drawGrid(ur.renderer, grid, region.X, region.Y, region.Width, region.Height)
+
+	noun := "moons"
+	if len(ur.state.FocusSatellites) == 1 {
+		noun = "moon"
+	}
+	label := fmt.Sprintf("%s-centred • %d %s • %s to centre on the star again",
+		center.EnglishName, len(ur.state.FocusSatellites), noun, ur.keys.Primary(keymap.ActionFocus))
+	ur.drawText(region.X, region.Y, tcell.StyleDefault.Foreground(tcell.ColorGray), truncateText(label, region.Width))
+	ur.drawOverview(region)
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+)
+
+// frameBudget lowers the frame rate while frames are expensive to draw, as on very
+// large terminals, so that at least half of every interval is left for handling
+// input. It raises the rate back towards the target once frames get cheap.
+// Like frameCounter it is only touched while drawing.
+type frameBudget struct {
+	target   time.Duration // fastest interval asked for; 0 for DisplayUpdateRate
+	interval time.Duration
+	cost     time.Duration // smoothed time taken to draw a frame
+}
+
+// observe records how long a frame took to draw and adjusts the interval
+func (fb *frameBudget) observe(cost time.Duration) {
+	fastest := fb.fastest()
+	slowest := max(constants.SlowestUpdateRate, fastest)
+	if fb.interval == 0 {
+		fb.interval = fastest
+	}
+	if fb.cost == 0 {
+		fb.cost = cost
+	} else {
+		fb.cost = (fb.cost*3 + cost) / 4
+	}
+
+	// The gap between the two thresholds keeps the rate from see-sawing
+	switch {
+	case fb.cost*2 > fb.interval && fb.interval < slowest:
+		fb.interval = min(fb.interval*3/2, slowest)
+	case fb.cost*4 < fb.interval && fb.interval > fastest:
+		fb.interval = max(fb.interval*3/4, fastest)
+	}
+}
+
+// current returns the time to leave between frames
+func (fb *frameBudget) current() time.Duration {
+	if fb.interval == 0 {
+		return fb.fastest()
+	}
+	return fb.interval
+}
+
+// fastest returns the shortest time the budget leaves between frames
+func (fb *frameBudget) fastest() time.Duration {
+	if fb.target <= 0 {
+		return constants.DisplayUpdateRate
+	}
+	return fb.target
+}
+
+// allowsEarly reports whether a frame can be drawn ahead of the interval, since
+// after the last one finished, and still leave half the time for input
+func (fb *frameBudget) allowsEarly(since time.Duration) bool {
+	return since >= fb.cost*2
+}
@@ -0,0 +1,294 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/systems"
+	"github.com/gdamore/tcell/v2"
+)
+
+// openGalaxyMap plots every system around the Sun, starting on the loaded one
+func (ed *EventDispatcher) openGalaxyMap() {
+	entries := ed.uiRenderer.GetSystemManager().GalaxyMap()
+	current := ed.uiRenderer.GetSystemManager().GetCurrentSystem()
+
+	selected := 0
+	for i, entry := range entries {
+		if entry.System == current {
+			selected = i
+			break
+		}
+	}
+	ed.state.ShowGalaxyMap(entries, selected)
+}
+
+// handleGalaxyKeys handles keyboard input on the galaxy map: the arrows step
+// through the systems nearest first and Enter goes to the selected one
+func (ed *EventDispatcher) handleGalaxyKeys(ev *tcell.EventKey) {
+	count := len(ed.state.GalaxyEntries)
+	if count == 0 {
+		ed.state.PopModal()
+		return
+	}
+
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		ed.state.PopModal()
+	case tcell.KeyEnter:
+		ed.systemManager.SwitchToGalaxySelection()
+	case tcell.KeyLeft, tcell.KeyUp:
+		ed.state.GalaxySelected = (ed.state.GalaxySelected - 1 + count) % count
+	case tcell.KeyRight, tcell.KeyDown:
+		ed.state.GalaxySelected = (ed.state.GalaxySelected + 1) % count
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case ' ':
+			ed.systemManager.SwitchToGalaxySelection()
+		case 'q', 'Q', 'b', 'B':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// SwitchToGalaxySelection loads the system selected on the galaxy map and
+// returns to the main view
+func (sm *SystemManager) SwitchToGalaxySelection() {
+	if sm.state.GalaxySelected >= len(sm.state.GalaxyEntries) {
+		return
+	}
+	selected := sm.state.GalaxyEntries[sm.state.GalaxySelected].System
+	sm.state.CloseModal(ModalGalaxy)
+
+	if selected == sm.uiRenderer.GetSystemManager().GetCurrentSystem() {
+		return
+	}
+	for i, system := range sm.uiRenderer.GetSystemManager().GetAvailableSystems() {
+		if system == selected {
+			sm.state.SystemSelectedIndex = i
+			sm.state.PickingComparison = false
+			sm.SwitchToSelectedSystem()
+			return
+		}
+	}
+}
+
+// galaxyModalHeight is the height of the galaxy map
+func galaxyModalHeight(screenHeight int) int {
+	return minimum(30, screenHeight-4)
+}
+
+// galaxyMapRect is the part of the galaxy modal the map is drawn in, between the
+// title and the description of the selected system
+func galaxyMapRect(area layout.Rect) layout.Rect {
+	return layout.Rect{X: area.X + 2, Y: area.Y + 3, Width: area.Width - 4, Height: area.Height - 8}
+}
+
+// galaxyScale places systems on the map. Distances go on a log scale, so the
+// nearest stars and ones thousands of light-years away fit on one screen.
+type galaxyScale struct {
+	centerX, centerY int
+	radiusX, radiusY float64 // cells from the Sun to the farthest system
+	maxLog           float64
+}
+
+func newGalaxyScale(rect layout.Rect, entries []systems.GalaxyEntry, aspect float64) galaxyScale {
+	farthest := 0.0
+	for _, entry := range entries {
+		if entry.Located {
+			farthest = math.Max(farthest, entry.LightYears)
+		}
+	}
+
+	// Cells are taller than wide, so the same distance spans more columns than rows
+	radiusY := float64(rect.Height-1) / 2
+	radiusX := radiusY * aspect
+	if maxX := float64(rect.Width-1) / 2; radiusX > maxX {
+		radiusX = maxX
+		radiusY = radiusX / aspect
+	}
+	return galaxyScale{
+		centerX: rect.X + rect.Width/2,
+		centerY: rect.Y + rect.Height/2,
+		radiusX: radiusX,
+		radiusY: radiusY,
+		maxLog:  math.Log10(1 + farthest),
+	}
+}
+
+// fraction is how far out from the Sun a distance is drawn, from 0 to 1
+func (g galaxyScale) fraction(lightYears float64) float64 {
+	if g.maxLog <= 0 {
+		return 0
+	}
+	return math.Log10(1+lightYears) / g.maxLog
+}
+
+// at returns the cell a point at a distance and direction from the Sun is drawn in
+func (g galaxyScale) at(lightYears, dirX, dirY float64) (int, int) {
+	r := g.fraction(lightYears)
+	return g.centerX + int(math.Round(dirX*r*g.radiusX)), g.centerY - int(math.Round(dirY*r*g.radiusY))
+}
+
+// position returns where a system is drawn
+func (g galaxyScale) position(entry systems.GalaxyEntry) (int, int) {
+	if entry.LightYears <= 0 {
+		return g.centerX, g.centerY
+	}
+	return g.at(entry.LightYears, entry.X/entry.LightYears, entry.Y/entry.LightYears)
+}
+
+// galaxyScaleFor returns the scale the galaxy map is drawn at on the current screen
+func (ur *UIRenderer) galaxyScaleFor(area layout.Rect, entries []systems.GalaxyEntry) galaxyScale {
+	return newGalaxyScale(galaxyMapRect(area), entries, ur.renderer.GetAspectRatio())
+}
+
+// handleGalaxyModalClick selects the system clicked on, or goes to it if it was
+// already selected
+func (meh *MouseEventHandler) handleGalaxyModalClick(mouseX, mouseY int, area layout.Rect) bool {
+	scale := meh.renderer.galaxyScaleFor(area, meh.state.GalaxyEntries)
+	for i, entry := range meh.state.GalaxyEntries {
+		if !entry.Located {
+			continue
+		}
+		x, y := scale.position(entry)
+		if mouseY != y || mouseX < x-1 || mouseX > x+1 {
+			continue
+		}
+		if i == meh.state.GalaxySelected {
+			meh.systemManager.SwitchToGalaxySelection()
+		} else {
+			meh.state.GalaxySelected = i
+		}
+		return true
+	}
+	return false
+}
+
+// drawGalaxyModal renders the systems around the Sun with distance rings, and
+// describes the selected one underneath
+func (ur *UIRenderer) drawGalaxyModal(width, height int) {
+	modalX, modalY, modalWidth, modalHeight := ur.setupModal(width, height, galaxyModalHeight(height))
+	area := layout.Rect{X: modalX, Y: modalY, Width: modalWidth, Height: modalHeight}
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, " 🌌 Galaxy map ")
+
+	textStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	noteStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+
+	rect := galaxyMapRect(area)
+	if rect.Height >= 5 && len(ur.state.GalaxyEntries) > 0 {
+		ur.drawGalaxyMap(rect, ur.galaxyScaleFor(area, ur.state.GalaxyEntries))
+	}
+
+	var description, note string
+	if ur.state.GalaxySelected < len(ur.state.GalaxyEntries) {
+		description, note = galaxyDescription(ur.state.GalaxyEntries[ur.state.GalaxySelected])
+	}
+	ur.drawText(modalX+2, modalY+modalHeight-4, textStyle, truncateText(description, ur.contentWidth()))
+	ur.drawText(modalX+2, modalY+modalHeight-3, noteStyle, truncateText(note, ur.contentWidth()))
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "←/→ system • Enter go there • Esc close")
+}
+
+// drawGalaxyMap draws the distance rings, then each system's label and marker
+func (ur *UIRenderer) drawGalaxyMap(rect layout.Rect, scale galaxyScale) {
+	ringStyle := tcell.StyleDefault.Foreground(tcell.ColorDarkGray).Background(tcell.ColorDarkBlue)
+	inside := func(x, y int) bool {
+		return x >= rect.X && x < rect.X+rect.Width && y >= rect.Y && y < rect.Y+rect.Height
+	}
+
+	symbols := ur.renderer.GetSymbols()
+	for ring := 10.0; scale.maxLog > 0 && scale.fraction(ring) < 1; ring *= 10 {
+		for step := 0; step < 180; step++ {
+			angle := float64(step) * 2 * math.Pi / 180
+			if x, y := scale.at(ring, math.Cos(angle), math.Sin(angle)); inside(x, y) {
+				ur.screen.SetContent(x, y, symbols.Orbit, nil, ringStyle)
+			}
+		}
+		label := fmt.Sprintf("%s ly", formatCount(int(ring)))
+		if x, y := scale.at(ring, 0, 1); inside(x, y-1) {
+			ur.drawText(x-len(label)/2, y-1, ringStyle, label)
+		}
+	}
+
+	marker := '✦'
+	if symbols.ASCII {
+		marker = '+'
+	}
+	current := ur.GetSystemManager().GetCurrentSystem()
+	labelStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	currentStyle := tcell.StyleDefault.Foreground(tcell.ColorGreen).Background(tcell.ColorDarkBlue).Bold(true)
+	selectedStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorYellow).Bold(true)
+
+	// Labels first, so that no label covers another system's marker
+	for pass := 0; pass < 2; pass++ {
+		for i, entry := range ur.state.GalaxyEntries {
+			if !entry.Located {
+				continue
+			}
+			x, y := scale.position(entry)
+			if !inside(x, y) {
+				continue
+			}
+
+			style := labelStyle
+			if entry.System == current {
+				style = currentStyle
+			}
+			if i == ur.state.GalaxySelected {
+				style = selectedStyle
+			}
+
+			if pass == 0 {
+				// The current system is marked in words too, not only in green
+				label := entry.Name
+				if entry.System == current {
+					label += " (here)"
+				}
+				room := rect.X + rect.Width - (x + 2)
+				if room > 0 {
+					ur.drawText(x+2, y, style, truncateText(label, room))
+				}
+				continue
+			}
+
+			symbol := marker
+			if entry.LightYears <= 0 {
+				symbol = symbols.Sun
+			}
+			ur.screen.SetContent(x, y, symbol, nil, style)
+		}
+	}
+}
+
+// galaxyDescription describes a system on the galaxy map, with a note on how
+// well its position is known
+func galaxyDescription(entry systems.GalaxyEntry) (string, string) {
+	description := entry.Name
+	if entry.Galaxy != "" {
+		description += ", " + entry.Galaxy
+	}
+	if entry.LightYears > 0 {
+		description += " • " + entry.Distance + ", " + lightYearsDetail(entry.LightYears)
+	}
+	if entry.DiscoveryYear != "" {
+		description += " • discovered " + entry.DiscoveryYear
+	}
+
+	switch {
+	case entry.LightYears <= 0 && entry.Located:
+		return description, "Home. Rings mark 10, 100, 1,000... light-years on a log scale"
+	case !entry.Located:
+		return description, fmt.Sprintf("Distance %q cannot be read, so it is not on the map", entry.Distance)
+	case entry.Estimated:
+		return description, "Its file gives no rightAscension, so the direction is a guess"
+	default:
+		return description, "Direction from its right ascension, seen from above the north pole"
+	}
+}
@@ -0,0 +1,146 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/gamepad"
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/gdamore/tcell/v2"
+)
+
+// gamepadRetry is how long to wait before opening an unplugged controller again
+const gamepadRetry = time.Second
+
+// gamepadEvent carries an input from a controller into the event loop
+type gamepadEvent struct {
+	tcell.EventTime
+	input gamepad.Input
+}
+
+// readGamepad reads inputs from path until ctx is done: a joystick device, which
+// is opened again after it is unplugged, or a FIFO a bridge writes input names
+// to, opened again each time a writer closes it. It fails if path is something
+// else that cannot be opened.
+func (ss *SolarSystem) readGamepad(ctx context.Context, path string) error {
+	for ctx.Err() == nil {
+		file, err := os.Open(path)
+		if err != nil {
+			if isCharDevice(path) || errors.Is(err, os.ErrNotExist) {
+				ss.logger.Debugf("Gamepad: %v", err)
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(gamepadRetry):
+					continue
+				}
+			}
+			return NewFileError("gamepad "+path+" unavailable", err)
+		}
+
+		stop := context.AfterFunc(ctx, func() { file.Close() })
+		device := isCharDevice(path)
+		if device {
+			ss.logger.Printf("Gamepad: reading %s", path)
+			err = ss.readJoystick(file)
+		} else {
+			err = ss.readBridge(file)
+		}
+		stop()
+		file.Close()
+		if err != nil && ctx.Err() == nil {
+			ss.logger.Printf("Gamepad: %v", err)
+		}
+
+		if !device && !isFIFO(path) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// readJoystick posts the inputs from a Linux joystick device
+func (ss *SolarSystem) readJoystick(device io.Reader) error {
+	translator := gamepad.NewTranslator(gamepad.DefaultMapping())
+	var buf [gamepad.EventSize]byte
+	for {
+		if _, err := io.ReadFull(device, buf[:]); err != nil {
+			return err
+		}
+		if input, ok := translator.Translate(gamepad.Decode(buf)); ok {
+			ss.postGamepadInput(input)
+		}
+	}
+}
+
+// readBridge posts the inputs a bridge writes, one name per line
+func (ss *SolarSystem) readBridge(bridge io.Reader) error {
+	scanner := bufio.NewScanner(bridge)
+	for line := 1; scanner.Scan(); line++ {
+		input, ok, err := gamepad.ParseInput(scanner.Text())
+		if err != nil {
+			ss.logger.Printf("Gamepad line %d: %v", line, err)
+			continue
+		}
+		if ok {
+			ss.postGamepadInput(input)
+		}
+	}
+	return scanner.Err()
+}
+
+func (ss *SolarSystem) postGamepadInput(input gamepad.Input) {
+	event := &gamepadEvent{input: input}
+	event.SetEventNow()
+	if err := ss.screen.PostEvent(event); err != nil {
+		ss.logger.Debugf("Gamepad: dropped %s: %v", input, err)
+	}
+}
+
+// isCharDevice reports whether path is a character device, as joysticks are
+func isCharDevice(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// applyGamepadInput carries out a controller input as the key it stands for, so
+// every window handles it as it would the keyboard. Back only closes windows:
+// on the map, where Escape quits, it does nothing, so visitors cannot end the
+// session.
+func (ed *EventDispatcher) applyGamepadInput(input gamepad.Input) {
+	key := tcell.KeyRune
+	switch input {
+	case gamepad.Up:
+		key = tcell.KeyUp
+	case gamepad.Down:
+		key = tcell.KeyDown
+	case gamepad.Left:
+		key = tcell.KeyLeft
+	case gamepad.Right:
+		key = tcell.KeyRight
+	case gamepad.Confirm:
+		key = tcell.KeyEnter
+	case gamepad.Back:
+		if ed.state.TopModal() != ModalNone {
+			key = tcell.KeyEscape
+		}
+	case gamepad.Menu:
+		ed.runGamepadAction(keymap.ActionHelp)
+	case gamepad.Systems:
+		ed.runGamepadAction(keymap.ActionSystems)
+	}
+	if key != tcell.KeyRune {
+		ed.handleKeyboardEvent(tcell.NewEventKey(key, 0, tcell.ModNone))
+	}
+}
+
+// runGamepadAction opens a main view window from the map, first closing whatever
+// is open
+func (ed *EventDispatcher) runGamepadAction(action keymap.Action) {
+	ed.state.ResetModals()
+	ed.runMainAction(action)
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/gamepad"
+)
+
+func TestGamepadInputs(t *testing.T) {
+	dispatcher, state, _ := newAppFixture(t, 120, 40)
+	start := state.SelectedIndex
+
+	dispatcher.HandleEvent(&gamepadEvent{input: gamepad.Down})
+	if state.SelectedIndex != start+1 {
+		t.Fatalf("Expected down to select the next body, got index %d from %d", state.SelectedIndex, start)
+	}
+	dispatcher.HandleEvent(&gamepadEvent{input: gamepad.Confirm})
+	if state.TopModal() != ModalDetails {
+		t.Fatalf("Expected confirm to show the details, got modal %v", state.TopModal())
+	}
+	dispatcher.HandleEvent(&gamepadEvent{input: gamepad.Back})
+	if state.TopModal() != ModalNone {
+		t.Fatalf("Expected back to close the details, got modal %v", state.TopModal())
+	}
+
+	dispatcher.HandleEvent(&gamepadEvent{input: gamepad.Back})
+	if !state.IsRunning() {
+		t.Fatal("Expected back on the map not to quit")
+	}
+
+	dispatcher.HandleEvent(&gamepadEvent{input: gamepad.Menu})
+	if state.TopModal() != ModalHelp {
+		t.Errorf("Expected menu to show the help, got modal %v", state.TopModal())
+	}
+}
@@ -0,0 +1,27 @@
+package app
+
+import "fmt"
+
+// toggleHabitableZone draws the band round the stars where a planet could keep
+// liquid water, or takes it off the map
+func (ed *EventDispatcher) toggleHabitableZone() {
+	if !ed.uiRenderer.toggleHabitableZone() {
+		ed.state.SetStatusMessage("Habitable zone hidden", statusMessageDuration)
+		return
+	}
+	symbol := ed.uiRenderer.renderer.GetSymbols().Habitable
+	ed.state.SetStatusMessage(fmt.Sprintf("Habitable zone shown: %c marks where a planet could keep liquid water, from the stars' luminosity", symbol), statusMessageDuration)
+}
+
+// toggleHabitableZone starts or stops drawing the habitable zone, between
+// frames, and reports whether it is now drawn
+func (ur *UIRenderer) toggleHabitableZone() bool {
+	ur.drawMu.Lock()
+	defer ur.drawMu.Unlock()
+	shown := !ur.renderer.HabitableZoneShown()
+	ur.renderer.SetHabitableZoneShown(shown)
+	if ur.compareRenderer != nil {
+		ur.compareRenderer.SetHabitableZoneShown(shown)
+	}
+	return shown
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestHabitableZoneToggle(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 't', tcell.ModNone))
+	if !dispatcher.uiRenderer.renderer.HabitableZoneShown() || !strings.Contains(state.GetStatusMessage(), "Habitable zone shown") {
+		t.Fatalf("Expected t to show the habitable zone, got %q", state.GetStatusMessage())
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'l', tcell.ModNone))
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+	if marks, ok := dispatcher.uiRenderer.renderer.HabitableZoneMarks(); !ok || marks.Luminosity != 1 {
+		t.Errorf("Expected the Sun's habitable zone drawn, got %+v (%v)", marks, ok)
+	}
+	if text := screenText(screen); !strings.Contains(text, "≈ habitable zone") {
+		t.Errorf("Expected the legend to explain the habitable zone, got:\n%s", text)
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 't', tcell.ModNone))
+	if dispatcher.uiRenderer.renderer.HabitableZoneShown() || state.GetStatusMessage() != "Habitable zone hidden" {
+		t.Errorf("Expected t again to hide the habitable zone, got %q", state.GetStatusMessage())
+	}
+}
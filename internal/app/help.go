@@ -0,0 +1,249 @@
+package app
+
+import (
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/gdamore/tcell/v2"
+)
+
+// helpLineKind picks the style a help line is drawn in
+type helpLineKind int
+
+const (
+	helpHeading helpLineKind = iota
+	helpEntry
+	helpNote
+)
+
+type helpLine struct {
+	kind helpLineKind
+	keys string
+	text string
+}
+
+// helpKeyColumn is the width of the key column in the help screen
+const helpKeyColumn = 16
+
+// fixedHelpSections documents mouse use and the keys inside modes, which are not remappable
+var fixedHelpSections = []struct {
+	title   string
+	entries [][2]string
+}{
+	{"Mouse", [][2]string{
+		{"Click body", "Select it on the map or in the list"},
+		{"Double-click", "Show the details of the body clicked"},
+		{"Right-click", "Menu for the body: details, moons, compare systems, centre on it"},
+		{"Hover body", "Rest the pointer on a body or list row for its name and key figures"},
+		{"Click tab", "Show planets, moons, asteroids or comets in the list"},
+		{"Click bar", "The bottom bar's 'for systems', 'for help' and 'to quit' work"},
+		{"Click hint", "Clicking a modal's instruction line closes it"},
+		{"Wheel", "Scroll the window under the pointer; over the list, move the selection"},
+		{"Drag timeline", "Scrub simulated time up to 20 years either way; planets glide there"},
+	}},
+	{"Moon and system lists", [][2]string{
+		{"↑/↓", "Move the selection"},
+		{"PgUp/PgDn", "Move a page in the moon list"},
+		{"Home/End", "First or last moon"},
+		{"Type a name", "Jump to the first moon matching it (Backspace to edit)"},
+		{"Enter", "Open the moon / switch to the system"},
+		{"Esc/B", "Go back; in the moon list Esc clears a search first and B types"},
+		{"E", "Edit the highlighted system's description, distance, year and galaxy"},
+	}},
+	{"System details editor", [][2]string{
+		{"Type", "Edit the highlighted field (Ctrl+U clears it)"},
+		{"↑/↓ or Tab", "Move between fields"},
+		{"Enter", "Write the details to the system file; bodies are left alone"},
+	}},
+	{"Galaxy map", [][2]string{
+		{"←/→ or ↑/↓", "Step through the systems, nearest first"},
+		{"Enter or click", "Go to the selected system"},
+	}},
+	{"Quiz", [][2]string{
+		{"↑/↓ Enter", "Choose and answer"},
+		{"A-D or 1-4", "Answer directly"},
+		{"N/Enter", "Next question"},
+	}},
+	{"Events log", [][2]string{
+		{"↑/↓", "Scroll"},
+		{"←/→", "Change the look-ahead window"},
+		{"T / A", "Toggle alert toasts / terminal bell"},
+		{"R", "Recompute from the current simulated time"},
+	}},
+	{"Mission planner", [][2]string{
+		{"↑/↓", "Change the origin or destination"},
+		{"Tab or ←/→", "Switch between origin and destination"},
+		{"X / R", "Swap the two / recompute from the current simulated time"},
+		{"P", "Preview the transfer on the map from the launch window; again to hide it"},
+	}},
+	{"Conjunction finder", [][2]string{
+		{"↑/↓", "Move in the bodies or the results"},
+		{"Space", "Add the body to the search or take it out"},
+		{"←/→", "Narrow or widen the separation searched within"},
+		{"Tab", "Switch between the bodies and the results"},
+		{"Enter", "Move the simulation to the selected date"},
+	}},
+	{"Weight calculator", [][2]string{
+		{"0-9 . ⌫", "Type the mass in kg"},
+		{"↑/↓", "Scroll the bodies"},
+	}},
+	{"Mass chart", [][2]string{
+		{"Tab or R", "Switch between masses and radii"},
+		{"↑/↓", "Scroll the bodies"},
+	}},
+	{"Hierarchy", [][2]string{
+		{"↑/↓", "Move between bodies"},
+		{"→/←", "Open a body to its moons, or close it"},
+		{"Space", "Open or close"},
+		{"Enter", "Show the body's details"},
+	}},
+	{"Launch game", [][2]string{
+		{"←/→", "Change the launch speed (Shift for ×10)"},
+		{"↑/↓", "Launch from another body"},
+		{"Enter or Space", "Fire"},
+	}},
+	{"Watchlist", [][2]string{
+		{"↑/↓", "Scroll"},
+		{"R / X", "Check the watched bodies now / clear the reviewed changes"},
+	}},
+	{"Calibration", [][2]string{
+		{"←/→", "Adjust the ratio (Shift for ×10)"},
+		{"R", "Reset to the measured or default ratio"},
+		{"Enter / Esc", "Save to the config file / cancel"},
+	}},
+	{"Orbit editor", [][2]string{
+		{"↑/↓", "Choose a field"},
+		{"←/→", "Adjust (Shift for ×10)"},
+		{"R / W", "Reset the field / write to the system file"},
+	}},
+}
+
+// buildHelpLines generates the help text from the keymap, followed by the fixed sections
+func buildHelpLines(km *keymap.Keymap) []helpLine {
+	var lines []helpLine
+	entry := func(keys, description string) {
+		lines = append(lines, helpLine{kind: helpEntry, keys: keys, text: description})
+	}
+
+	for _, context := range km.Contexts() {
+		lines = append(lines, helpLine{kind: helpHeading, text: string(context)})
+		for _, binding := range km.Bindings(context) {
+			keys := binding.Label()
+			if binding.Action == keymap.ActionSelectNumber {
+				keys = "1-9"
+			}
+			entry(keys, binding.Description)
+		}
+		lines = append(lines, helpLine{kind: helpNote})
+	}
+
+	for _, section := range fixedHelpSections {
+		lines = append(lines, helpLine{kind: helpHeading, text: section.title})
+		for _, e := range section.entries {
+			entry(e[0], e[1])
+		}
+		lines = append(lines, helpLine{kind: helpNote})
+	}
+
+	lines = append(lines,
+		helpLine{kind: helpNote, text: "Remap keys in config.json under \"keys\", using the"},
+		helpLine{kind: helpNote, text: "action names from the README, e.g. {\"keys\": {\"quiz\": \"x\"}}"},
+	)
+	return lines
+}
+
+// helpModalHeight returns the height of the help modal for a screen height
+func helpModalHeight(screenHeight int) int {
+	return screenHeight - 4
+}
+
+// helpVisibleLines returns how many help lines fit in the modal
+func helpVisibleLines(screenWidth, screenHeight int) int {
+	return layout.Compute(screenWidth, screenHeight).Modal(helpModalHeight(screenHeight)).Height - 6
+}
+
+// helpScrollLimit is how far the help can scroll on the current screen
+func (ur *UIRenderer) helpScrollLimit() int {
+	screenWidth, screenHeight := ur.screen.Size()
+	return max(len(buildHelpLines(ur.keys))-helpVisibleLines(screenWidth, screenHeight), 0)
+}
+
+// handleHelpKeys handles keyboard input while the help modal is open
+func (ed *EventDispatcher) handleHelpKeys(ev *tcell.EventKey) {
+	screenWidth, screenHeight := ed.uiRenderer.screen.Size()
+	visible := helpVisibleLines(screenWidth, screenHeight)
+	maxScroll := ed.uiRenderer.helpScrollLimit()
+
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyEnter:
+		ed.state.PopModal()
+	case tcell.KeyUp:
+		ed.state.HelpScroll--
+	case tcell.KeyDown:
+		ed.state.HelpScroll++
+	case tcell.KeyPgUp:
+		ed.state.HelpScroll -= visible
+	case tcell.KeyPgDn:
+		ed.state.HelpScroll += visible
+	case tcell.KeyHome:
+		ed.state.HelpScroll = 0
+	case tcell.KeyEnd:
+		ed.state.HelpScroll = maxScroll
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'q', 'Q', 'b', 'B', 'h', 'H', '?':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+
+	if ed.state.HelpScroll > maxScroll {
+		ed.state.HelpScroll = maxScroll
+	}
+	if ed.state.HelpScroll < 0 {
+		ed.state.HelpScroll = 0
+	}
+}
+
+// drawHelpModal renders the scrollable list of keybindings
+func (ur *UIRenderer) drawHelpModal(width, height int) {
+	modalX, modalY, modalWidth, modalHeight := ur.setupModal(width, height, helpModalHeight(height))
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, " ❓ Help ")
+
+	headingStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue).Bold(true)
+	keyStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	entryStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	noteStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	arrowStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+
+	lines := buildHelpLines(ur.keys)
+	visible := helpVisibleLines(width, height)
+	scroll := ur.state.HelpScroll
+
+	if scroll > 0 {
+		ur.drawText(modalX+modalWidth-2, modalY+2, arrowStyle, "↑")
+	}
+	if scroll+visible < len(lines) {
+		ur.drawText(modalX+modalWidth-2, modalY+modalHeight-4, arrowStyle, "↓")
+	}
+
+	for i := 0; i < visible && i+scroll < len(lines); i++ {
+		line := lines[i+scroll]
+		y := modalY + 3 + i
+
+		switch line.kind {
+		case helpHeading:
+			ur.drawText(modalX+2, y, headingStyle, line.text)
+		case helpNote:
+			ur.drawText(modalX+2, y, noteStyle, truncateText(line.text, ur.contentWidth()))
+		default:
+			ur.drawText(modalX+4, y, keyStyle, line.keys)
+			ur.drawText(modalX+4+helpKeyColumn, y, entryStyle, truncateText(line.text, ur.contentWidth()-helpKeyColumn-2))
+		}
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "↑/↓ PgUp/PgDn scroll • Escape to close")
+}
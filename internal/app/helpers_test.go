@@ -0,0 +1,80 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/systems"
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+// newAppFixture draws a small Solar System on a simulated screen - the Sun, then
+// Mercury out to Jupiter, with nothing selected - for tests that drive the app
+// through its event dispatcher
+func newAppFixture(t *testing.T, width, height int) (*EventDispatcher, *AppState, tcell.SimulationScreen) {
+	t.Helper()
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("screen.Init() error = %v", err)
+	}
+	t.Cleanup(screen.Fini)
+	screen.SetSize(width, height)
+
+	state := NewAppState()
+	state.SetPlanets([]models.CelestialBody{
+		{ID: "soleil", EnglishName: "Sun", BodyType: "Star", MeanRadius: 695508},
+		{ID: "mercure", EnglishName: "Mercury", BodyType: "Planet", IsPlanet: true, SemimajorAxis: 57909227, SideralOrbit: 87.97, MeanRadius: 2439},
+		{ID: "venus", EnglishName: "Venus", BodyType: "Planet", IsPlanet: true, SemimajorAxis: 108209475, SideralOrbit: 224.7, MeanRadius: 6051},
+		{ID: "terre", EnglishName: "Earth", BodyType: "Planet", IsPlanet: true, SemimajorAxis: 149598262, SideralOrbit: 365.256, MeanRadius: 6371},
+		{ID: "mars", EnglishName: "Mars", BodyType: "Planet", IsPlanet: true, SemimajorAxis: 227943824, SideralOrbit: 686.98, MeanRadius: 3389},
+		{ID: "jupiter", EnglishName: "Jupiter", BodyType: "Planet", IsPlanet: true, SemimajorAxis: 778340821, SideralOrbit: 4332.59, MeanRadius: 69911},
+	})
+
+	renderer := visualization.NewRendererWithDefaults(width, height)
+	uiRenderer := NewUIRenderer(screen, renderer, systems.NewSystemManager(""), state, nil, keymap.Default())
+	mouseHandler := NewMouseEventHandler(state, uiRenderer, func() {}, func() {}, func() {}, nil, nil)
+	dispatcher := NewEventDispatcher(state, mouseHandler, nil, nil, nil, uiRenderer, keymap.Default())
+
+	state.Publish()
+	uiRenderer.DrawScreen()
+	return dispatcher, state, screen
+}
+
+// resize changes the simulated terminal's size and sends the event the app would get
+func resize(dispatcher *EventDispatcher, screen tcell.SimulationScreen, width, height int) {
+	screen.SetSize(width, height)
+	dispatcher.HandleEvent(tcell.NewEventResize(width, height))
+}
+
+// click sends a left click at a screen cell, pressing and letting go
+func click(dispatcher *EventDispatcher, x, y int) {
+	dispatcher.HandleEvent(tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone))
+	dispatcher.HandleEvent(tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone))
+}
+
+// doubleClick sends two left clicks in quick succession at a screen cell
+func doubleClick(dispatcher *EventDispatcher, x, y int) {
+	click(dispatcher, x, y)
+	click(dispatcher, x, y)
+}
+
+// screenText returns the characters on a simulated screen, row by row
+func screenText(screen tcell.SimulationScreen) string {
+	cells, width, _ := screen.GetContents()
+	var b strings.Builder
+	for i, cell := range cells {
+		if i > 0 && i%width == 0 {
+			b.WriteByte('\n')
+		}
+		if len(cell.Runes) == 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteRune(cell.Runes[0])
+		}
+	}
+	return b.String()
+}
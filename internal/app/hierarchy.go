@@ -0,0 +1,305 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+// treeNode is a body in the hierarchy with the bodies that orbit it
+type treeNode struct {
+	body models.CelestialBody
+	// moon is how the parent lists the body when nothing more of it is loaded;
+	// its details are looked up from it
+	moon     *models.Moon
+	children []*treeNode
+}
+
+// treeRow is a node as shown, at its depth below the roots
+type treeRow struct {
+	node   *treeNode
+	parent *treeNode
+	depth  int
+}
+
+// buildHierarchy arranges the loaded bodies into star, planets and moons. Moons
+// come from each planet's list of them and from any loaded body whose
+// aroundPlanet names the planet; moonName names those the list gives no English
+// name. A lone star is the root; with several, or none, the stars and the bodies
+// orbiting no other body are all roots.
+func buildHierarchy(planets, moons []models.CelestialBody, moonName func(models.Moon) string) []*treeNode {
+	var stars, top []*treeNode
+	nodes := make(map[string]*treeNode, len(planets))
+	for _, body := range planets {
+		nodes[body.EnglishName] = &treeNode{body: body}
+	}
+
+	// parentOf finds the loaded body another one orbits
+	parentOf := func(body models.CelestialBody) *treeNode {
+		around := body.AroundPlanet
+		if around == nil {
+			return nil
+		}
+		for _, planet := range planets {
+			if (around.Planet != "" && planet.ID == around.Planet) || (around.EnglishName != "" && planet.EnglishName == around.EnglishName) {
+				return nodes[planet.EnglishName]
+			}
+		}
+		return nil
+	}
+	// hasChild reports whether a node already holds a body by that id or name
+	hasChild := func(node *treeNode, id, name string) bool {
+		for _, child := range node.children {
+			if (id != "" && child.body.ID == id) || (name != "" && strings.EqualFold(child.body.EnglishName, name)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, body := range planets {
+		node := nodes[body.EnglishName]
+		switch parent := parentOf(body); {
+		case strings.EqualFold(body.BodyType, "Star"):
+			stars = append(stars, node)
+		case parent != nil && parent != node:
+			parent.children = append(parent.children, node)
+		default:
+			top = append(top, node)
+		}
+	}
+
+	for _, body := range planets {
+		node := nodes[body.EnglishName]
+		for i := range body.Moons {
+			moon := body.Moons[i]
+			switch {
+			case moon.Body != nil:
+				if !hasChild(node, moon.Body.ID, moon.Body.EnglishName) {
+					node.children = append(node.children, &treeNode{body: *moon.Body})
+				}
+			case !hasChild(node, moon.ID, moon.EnglishName):
+				name := moon.EnglishName
+				if name == "" {
+					name = moonName(moon)
+				}
+				node.children = append(node.children, &treeNode{
+					body: models.CelestialBody{ID: moon.ID, Name: moon.Name, EnglishName: name, BodyType: "Moon"},
+					moon: &moon,
+				})
+			}
+		}
+	}
+
+	for _, moon := range moons {
+		if parent := parentOf(moon); parent != nil {
+			if i := childIndex(parent, moon); i >= 0 {
+				parent.children[i] = &treeNode{body: moon}
+			} else {
+				parent.children = append(parent.children, &treeNode{body: moon})
+			}
+		}
+	}
+
+	if len(stars) == 1 {
+		stars[0].children = append(top, stars[0].children...)
+		return stars
+	}
+	return append(stars, top...)
+}
+
+// childIndex finds where a loaded moon already hangs under a parent as only a
+// name in its list, so the full body can take its place; -1 if it is not there
+func childIndex(parent *treeNode, moon models.CelestialBody) int {
+	for i, child := range parent.children {
+		if (moon.ID != "" && child.body.ID == moon.ID) || strings.EqualFold(child.body.EnglishName, moon.EnglishName) {
+			return i
+		}
+	}
+	return -1
+}
+
+// treeExpanded reports whether a node shows its children. Only the roots do
+// until the user says otherwise.
+func treeExpanded(state *AppState, row treeRow) bool {
+	if expanded, ok := state.TreeExpanded[row.node.body.EnglishName]; ok {
+		return expanded
+	}
+	return row.depth == 0
+}
+
+// treeRows flattens the hierarchy into the rows shown, skipping the children of
+// collapsed nodes
+func treeRows(state *AppState, roots []*treeNode) []treeRow {
+	var rows []treeRow
+	var walk func(nodes []*treeNode, parent *treeNode, depth int)
+	walk = func(nodes []*treeNode, parent *treeNode, depth int) {
+		for _, node := range nodes {
+			row := treeRow{node: node, parent: parent, depth: depth}
+			rows = append(rows, row)
+			if len(node.children) > 0 && treeExpanded(state, row) {
+				walk(node.children, node, depth+1)
+			}
+		}
+	}
+	walk(roots, nil, 0)
+	return rows
+}
+
+// hierarchyRows is the tree of the loaded system as the modal shows it
+func (ur *UIRenderer) hierarchyRows(state *AppState) []treeRow {
+	moonHandler := ur.renderer.GetMoonHandler()
+	return treeRows(state, buildHierarchy(state.GetPlanets(), state.TabBodies[TabMoons], moonHandler.GetMoonNameFromAPI))
+}
+
+// treeLabel writes a node's name with how many bodies orbit it
+func treeLabel(node *treeNode) string {
+	n := len(node.children)
+	switch {
+	case n == 0:
+		return node.body.EnglishName
+	case strings.EqualFold(node.body.BodyType, "Star"):
+		return fmt.Sprintf("%s (%s)", node.body.EnglishName, bodyCount(n))
+	case n == 1:
+		return node.body.EnglishName + " (1 moon)"
+	}
+	return fmt.Sprintf("%s (%d moons)", node.body.EnglishName, n)
+}
+
+// handleTreeKeys handles keyboard input while the hierarchy is open
+func (ed *EventDispatcher) handleTreeKeys(ev *tcell.EventKey) {
+	rows := ed.uiRenderer.hierarchyRows(ed.state)
+	if len(rows) == 0 {
+		ed.state.PopModal()
+		return
+	}
+	selected := minimum(ed.state.TreeSelected, len(rows)-1)
+	row := rows[selected]
+
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		ed.state.PopModal()
+	case tcell.KeyUp:
+		ed.state.TreeSelected = max(selected-1, 0)
+	case tcell.KeyDown:
+		ed.state.TreeSelected = minimum(selected+1, len(rows)-1)
+	case tcell.KeyRight:
+		if len(row.node.children) > 0 && !treeExpanded(ed.state, row) {
+			ed.setTreeExpanded(row, true)
+		} else if len(row.node.children) > 0 {
+			ed.state.TreeSelected = selected + 1
+		}
+	case tcell.KeyLeft:
+		if len(row.node.children) > 0 && treeExpanded(ed.state, row) {
+			ed.setTreeExpanded(row, false)
+		} else if row.parent != nil {
+			for i, other := range rows {
+				if other.node == row.parent {
+					ed.state.TreeSelected = i
+				}
+			}
+		}
+	case tcell.KeyEnter:
+		ed.openTreeNode(row)
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case ' ':
+			if len(row.node.children) > 0 {
+				ed.setTreeExpanded(row, !treeExpanded(ed.state, row))
+			}
+		case 'q', 'Q', 'b', 'B', 'T':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// setTreeExpanded shows or hides a node's children
+func (ed *EventDispatcher) setTreeExpanded(row treeRow, expanded bool) {
+	if ed.state.TreeExpanded == nil {
+		ed.state.TreeExpanded = make(map[string]bool)
+	}
+	ed.state.TreeExpanded[row.node.body.EnglishName] = expanded
+}
+
+// openTreeNode shows a node's details over the tree, selecting it in the body
+// list when it is there, or selecting its planet and showing it as a moon when
+// it is not
+func (ed *EventDispatcher) openTreeNode(row treeRow) {
+	if selectLoaded(ed.state, row.node.body.EnglishName) {
+		ed.state.PushModal(ModalDetails)
+		return
+	}
+
+	planet := ed.state.SelectedPlanet
+	if row.parent != nil {
+		selectLoaded(ed.state, row.parent.body.EnglishName)
+		planet = row.parent.body
+	}
+	moonHandler := ed.uiRenderer.GetRenderer().GetMoonHandler()
+	moon := row.node.body
+	if row.node.moon != nil {
+		systemName := ed.uiRenderer.GetSystemManager().GetCurrentSystem()
+		moon = ed.planetService.MoonDetails(systemName, planet, *row.node.moon, moonHandler)
+	}
+	ed.state.ShowMoonDetails(moonHandler.EnrichMoon(moon))
+}
+
+// selectLoaded selects a body of the loaded system in the planet list by name,
+// reporting whether it is there
+func selectLoaded(state *AppState, name string) bool {
+	for i, planet := range state.GetPlanets() {
+		if planet.EnglishName == name {
+			state.ListTab = TabPlanets
+			state.UpdatePlanetSelection(i, planet)
+			return true
+		}
+	}
+	return false
+}
+
+// drawTreeModal renders the hierarchy, one body to a row, indented under the
+// body it orbits
+func (ur *UIRenderer) drawTreeModal(width, height int) {
+	rows := ur.hierarchyRows(ur.state)
+	dynamicHeight := fitModalHeight(len(rows)+2, height)
+	modalX, modalY, modalWidth, modalHeight := ur.setupModal(width, height, dynamicHeight)
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, " ☉ Hierarchy ")
+
+	rowStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	selectedStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	collapsed, expanded, leaf := "▸ ", "▾ ", "  "
+	if ur.renderer.GetSymbols().ASCII {
+		collapsed, expanded = "+ ", "- "
+	}
+
+	top := modalY + 3
+	visible := modalY + modalHeight - 3 - top
+	// The rows scroll with the selection, keeping it on the last one visible
+	selected := minimum(ur.state.TreeSelected, max(len(rows)-1, 0))
+	scroll := max(selected-visible+1, 0)
+	for i := 0; i < visible && scroll+i < len(rows); i++ {
+		row := rows[scroll+i]
+		marker := leaf
+		if len(row.node.children) > 0 {
+			marker = collapsed
+			if treeExpanded(ur.state, row) {
+				marker = expanded
+			}
+		}
+		style := rowStyle
+		if scroll+i == selected {
+			style = selectedStyle
+		}
+		indent := strings.Repeat("  ", row.depth)
+		ur.drawText(modalX+2, top+i, style, truncateText(indent+marker+treeLabel(row.node), modalWidth-4))
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "↑/↓ move • →/← or Space open and close • Enter details • Esc close")
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+// treeText writes out the rows of a hierarchy, indented by depth
+func treeText(rows []treeRow) string {
+	var lines []string
+	for _, row := range rows {
+		lines = append(lines, strings.Repeat("  ", row.depth)+treeLabel(row.node))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// allExpanded opens every node of a hierarchy
+func allExpanded(roots []*treeNode) *AppState {
+	state := NewAppState()
+	state.TreeExpanded = make(map[string]bool)
+	var walk func(nodes []*treeNode)
+	walk = func(nodes []*treeNode) {
+		for _, node := range nodes {
+			state.TreeExpanded[node.body.EnglishName] = true
+			walk(node.children)
+		}
+	}
+	walk(roots)
+	return state
+}
+
+func TestBuildHierarchy(t *testing.T) {
+	planets := []models.CelestialBody{
+		{ID: "soleil", EnglishName: "Sun", BodyType: "Star"},
+		{ID: "terre", EnglishName: "Earth", BodyType: "Planet", Moons: []models.Moon{{ID: "lune", Name: "La Lune"}}},
+		{ID: "mars", EnglishName: "Mars", BodyType: "Planet", Moons: []models.Moon{{ID: "phobos", Name: "Phobos"}, {ID: "deimos", Name: "Deimos"}}},
+		{ID: "charon", EnglishName: "Charon", BodyType: "Moon", AroundPlanet: &models.Planet{Planet: "pluton"}},
+		{ID: "pluton", EnglishName: "Pluto", BodyType: "Dwarf Planet"},
+	}
+	moons := []models.CelestialBody{
+		{ID: "lune", EnglishName: "Moon", BodyType: "Moon", AroundPlanet: &models.Planet{Planet: "terre"}},
+	}
+	moonName := func(moon models.Moon) string { return moon.Name }
+
+	roots := buildHierarchy(planets, moons, moonName)
+	want := strings.Join([]string{
+		"Sun (3 bodies)",
+		"  Earth (1 moon)",
+		"    Moon",
+		"  Mars (2 moons)",
+		"    Phobos",
+		"    Deimos",
+		"  Pluto (1 moon)",
+		"    Charon",
+	}, "\n")
+	if got := treeText(treeRows(allExpanded(roots), roots)); got != want {
+		t.Errorf("hierarchy =\n%s\nwant\n%s", got, want)
+	}
+	if moon := roots[0].children[0].children[0]; moon.moon != nil {
+		t.Error("the Moons tab's Moon should replace the name Earth lists it by")
+	}
+}
+
+func TestBuildHierarchyWithSeveralStars(t *testing.T) {
+	planets := []models.CelestialBody{
+		{EnglishName: "Alpha Centauri A", BodyType: "Star"},
+		{EnglishName: "Alpha Centauri B", BodyType: "Star"},
+		{EnglishName: "Proxima b", BodyType: "Planet"},
+	}
+
+	roots := buildHierarchy(planets, nil, nil)
+	if got, want := treeText(treeRows(NewAppState(), roots)), "Alpha Centauri A\nAlpha Centauri B\nProxima b"; got != want {
+		t.Errorf("hierarchy =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTreeKeys(t *testing.T) {
+	dispatcher, state, _ := newAppFixture(t, 120, 40)
+	planets := state.GetPlanets()
+	planets[3].Moons = []models.Moon{{EnglishName: "Moon", Body: &models.CelestialBody{ID: "lune", EnglishName: "Moon", BodyType: "Moon", MeanRadius: 1737}}}
+	press := func(key tcell.Key, r rune) {
+		dispatcher.HandleEvent(tcell.NewEventKey(key, r, tcell.ModNone))
+	}
+
+	press(tcell.KeyRune, 'T')
+	if state.TopModal() != ModalTree {
+		t.Fatalf("TopModal() = %v, want the hierarchy", state.TopModal())
+	}
+	rows := dispatcher.uiRenderer.hierarchyRows(state)
+	if len(rows) != len(planets) {
+		t.Fatalf("%d rows, want the Sun and its %d planets with Earth closed", len(rows), len(planets)-1)
+	}
+
+	// Down to Earth, open it and go on to its Moon
+	for range 3 {
+		press(tcell.KeyDown, 0)
+	}
+	press(tcell.KeyRight, 0)
+	press(tcell.KeyRight, 0)
+	rows = dispatcher.uiRenderer.hierarchyRows(state)
+	if got := rows[state.TreeSelected].node.body.EnglishName; got != "Moon" {
+		t.Fatalf("selected %s, want Earth's Moon", got)
+	}
+
+	press(tcell.KeyEnter, 0)
+	if state.TopModal() != ModalMoonDetails || state.SelectedMoon.EnglishName != "Moon" || state.SelectedPlanet.EnglishName != "Earth" {
+		t.Fatalf("TopModal() = %v with %s of %s, want the Moon's details", state.TopModal(), state.SelectedMoon.EnglishName, state.SelectedPlanet.EnglishName)
+	}
+	press(tcell.KeyEscape, 0)
+	if state.TopModal() != ModalTree {
+		t.Fatalf("TopModal() = %v, want back at the hierarchy", state.TopModal())
+	}
+
+	// Left goes up to Earth, and again closes it
+	press(tcell.KeyLeft, 0)
+	press(tcell.KeyLeft, 0)
+	rows = dispatcher.uiRenderer.hierarchyRows(state)
+	if got := rows[state.TreeSelected].node.body.EnglishName; got != "Earth" || len(rows) != len(planets) {
+		t.Errorf("selected %s in %d rows, want Earth closed again", got, len(rows))
+	}
+
+	press(tcell.KeyEnter, 0)
+	if state.TopModal() != ModalDetails || state.SelectedIndex != 3 {
+		t.Errorf("TopModal() = %v at %d, want Earth's details", state.TopModal(), state.SelectedIndex)
+	}
+}
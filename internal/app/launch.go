@@ -0,0 +1,288 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// launchAnimation is how long a flight takes to play out on screen, whatever
+	// its real duration
+	launchAnimation = 3 * time.Second
+
+	// launchSpeedSteps is how many arrow presses it takes to go from standing
+	// still to escape speed
+	launchSpeedSteps = 50
+
+	// launchStartFraction is the share of escape speed the game opens with: not
+	// enough, so the first launch falls back
+	launchStartFraction = 0.5
+)
+
+// openLaunchGame starts the launch game from the selected body, or the first one
+// whose gravity is known
+func (ed *EventDispatcher) openLaunchGame() {
+	planets := ed.state.GetPlanets()
+	index := ed.state.SelectedIndex
+	if index >= len(planets) || !canLaunchFrom(planets[index]) {
+		index = nextLaunchBody(ed.state, -1, 1)
+	}
+	if index < 0 {
+		ed.state.SetStatusMessage("No body here has a known gravity to launch from", statusMessageDuration)
+		return
+	}
+
+	_, escape, _ := orbital.LaunchSpeeds(planets[index])
+	ed.state.ShowLaunch(index, roundLaunchSpeed(escape*launchStartFraction, escape))
+}
+
+// handleLaunchKeys handles keyboard input in the launch game
+func (ed *EventDispatcher) handleLaunchKeys(ev *tcell.EventKey) {
+	planets := ed.state.GetPlanets()
+	if ed.state.LaunchIndex >= len(planets) {
+		ed.state.PopModal()
+		return
+	}
+	_, escape, _ := orbital.LaunchSpeeds(planets[ed.state.LaunchIndex])
+
+	step := escape / launchSpeedSteps
+	if ev.Modifiers()&tcell.ModShift != 0 {
+		step *= 10
+	}
+
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		ed.state.PopModal()
+	case tcell.KeyEnter:
+		ed.fireLaunch()
+	case tcell.KeyLeft:
+		ed.setLaunchSpeed(math.Max(ed.state.LaunchSpeed-step, 0), escape)
+	case tcell.KeyRight:
+		ed.setLaunchSpeed(math.Min(ed.state.LaunchSpeed+step, escape*2), escape)
+	case tcell.KeyUp, tcell.KeyDown:
+		direction := 1
+		if ev.Key() == tcell.KeyUp {
+			direction = -1
+		}
+		if index := nextLaunchBody(ed.state, ed.state.LaunchIndex, direction); index >= 0 {
+			_, nextEscape, _ := orbital.LaunchSpeeds(planets[index])
+			// Keep the same share of escape speed, so the outcome carries over
+			ed.state.LaunchIndex = index
+			ed.setLaunchSpeed(ed.state.LaunchSpeed/escape*nextEscape, nextEscape)
+		}
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case ' ':
+			ed.fireLaunch()
+		case 'q', 'Q', 'b', 'B':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// setLaunchSpeed sets a new speed and clears the last flight, which no longer matches
+func (ed *EventDispatcher) setLaunchSpeed(speed, escape float64) {
+	ed.state.LaunchSpeed = roundLaunchSpeed(speed, escape)
+	ed.state.LaunchFlight = nil
+}
+
+// fireLaunch simulates the flight at the chosen speed and starts playing it
+func (ed *EventDispatcher) fireLaunch() {
+	body := ed.state.GetPlanets()[ed.state.LaunchIndex]
+	circular, _, _ := orbital.LaunchSpeeds(body)
+	flight := orbital.SimulateLaunch(ed.state.LaunchSpeed, circular, body.MeanRadius)
+	ed.state.LaunchFlight = &flight
+	ed.state.LaunchFired = ed.uiRenderer.clock.Now()
+}
+
+// nextLaunchBody finds the next body after from, going in direction, that can be
+// launched from. It returns -1 if there is none.
+func nextLaunchBody(state *AppState, from, direction int) int {
+	planets := state.GetPlanets()
+	for i := 1; i <= len(planets); i++ {
+		index := ((from+direction*i)%len(planets) + len(planets)) % len(planets)
+		if canLaunchFrom(planets[index]) {
+			return index
+		}
+	}
+	return -1
+}
+
+// canLaunchFrom reports whether a body's size and gravity are known, which the
+// flight needs
+func canLaunchFrom(body models.CelestialBody) bool {
+	_, _, ok := orbital.LaunchSpeeds(body)
+	return ok && body.MeanRadius > 0
+}
+
+// roundLaunchSpeed rounds a speed to the precision it is shown at, which depends
+// on how small the body's escape speed is
+func roundLaunchSpeed(speed, escape float64) float64 {
+	scale := math.Pow(10, float64(launchSpeedDecimals(escape)))
+	return math.Round(speed*scale) / scale
+}
+
+// launchSpeedDecimals is how many decimals a speed needs for one arrow step to show
+func launchSpeedDecimals(escape float64) int {
+	step := escape / launchSpeedSteps
+	if step <= 0 {
+		return 2
+	}
+	return max(2, int(math.Ceil(-math.Log10(step))))
+}
+
+// launchModalHeight is the height of the launch game
+func launchModalHeight(screenHeight int) int {
+	return minimum(26, screenHeight-4)
+}
+
+// launchResult describes how the flight ended
+func launchResult(flight orbital.LaunchFlight, name string, speed, circular, escape float64, decimals int) string {
+	switch flight.Outcome {
+	case orbital.LaunchEscapes:
+		return fmt.Sprintf("Escaped! %.*f km/s beats %s's escape velocity of %.*f km/s", decimals, speed, name, decimals, escape)
+	case orbital.LaunchOrbits:
+		return fmt.Sprintf("In orbit: one lap takes %s. Faster than %.*f km/s but short of %.*f km/s to escape",
+			formatFlightTime(flight.Period), decimals, circular, decimals, escape)
+	default:
+		return fmt.Sprintf("Fell back after %s: it needs %.*f km/s to stay up", formatFlightTime(flight.Duration), decimals, circular)
+	}
+}
+
+// formatFlightTime writes a flight time in seconds as minutes, hours or days
+func formatFlightTime(seconds float64) string {
+	switch {
+	case seconds < 120:
+		return fmt.Sprintf("%.0f s", seconds)
+	case seconds < 2*3600:
+		return fmt.Sprintf("%.0f min", seconds/60)
+	case seconds < 2*86400:
+		return fmt.Sprintf("%.1f h", seconds/3600)
+	default:
+		return fmt.Sprintf("%.1f days", seconds/86400)
+	}
+}
+
+// drawLaunchModal renders the body, the chosen speed and the flight so far
+func (ur *UIRenderer) drawLaunchModal(width, height int) {
+	modalX, modalY, modalWidth, modalHeight := ur.setupModal(width, height, launchModalHeight(height))
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, " 🚀 Launch! ")
+
+	textStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	noteStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	speedStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorWhite)
+
+	planets := ur.state.GetPlanets()
+	if ur.state.LaunchIndex >= len(planets) {
+		return
+	}
+	body := planets[ur.state.LaunchIndex]
+	circular, escape, _ := orbital.LaunchSpeeds(body)
+	decimals := launchSpeedDecimals(escape)
+
+	ur.drawText(modalX+2, modalY+3, textStyle, "From:  "+body.EnglishName)
+	ur.drawText(modalX+2, modalY+4, textStyle, "Speed:")
+	speed := fmt.Sprintf(" %.*f km/s ", decimals, ur.state.LaunchSpeed)
+	ur.drawText(modalX+9, modalY+4, speedStyle, speed)
+	ur.drawText(modalX+10+ui.TextWidth(speed), modalY+4, noteStyle,
+		truncateText(fmt.Sprintf("orbit %.*f • escape %.*f km/s", decimals, circular, decimals, escape), modalWidth-12-ui.TextWidth(speed)))
+
+	// The flight fills the rows between the readout and the result
+	top, bottom := modalY+6, modalY+modalHeight-5
+	rows := bottom - top + 1
+	if rows >= 5 {
+		ur.drawLaunchFlight(modalX+2, top, modalWidth-4, rows)
+	}
+
+	flight := ur.state.LaunchFlight
+	elapsed := ur.clock.Now().Sub(ur.state.LaunchFired)
+	var result string
+	switch {
+	case flight == nil:
+		result = "Set a speed and press Enter to fire sideways from the surface"
+	case elapsed < launchAnimation:
+		progress := float64(elapsed) / float64(launchAnimation)
+		result = "In flight: T+" + formatFlightTime(progress*flight.Duration)
+	default:
+		result = launchResult(*flight, body.EnglishName, ur.state.LaunchSpeed, circular, escape, decimals)
+	}
+	ur.drawText(modalX+2, modalY+modalHeight-4, textStyle, truncateText(result, ur.contentWidth()))
+	ur.drawText(modalX+2, modalY+modalHeight-3, noteStyle, truncateText("No air, no spin; launched level with the ground", ur.contentWidth()))
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "←/→ speed (Shift ×10) • ↑/↓ body • Enter launch • Esc close")
+}
+
+// drawLaunchFlight draws the body as a disc with the flight path played up to now.
+// Distances are in body radii, scaled so the whole path fits.
+func (ur *UIRenderer) drawLaunchFlight(x, y, width, rows int) {
+	flight := ur.state.LaunchFlight
+
+	extent := 1.5
+	if flight != nil {
+		for _, point := range flight.Path {
+			extent = math.Max(extent, math.Max(math.Abs(point.X), math.Abs(point.Y))*1.05)
+		}
+	}
+
+	// Cells are taller than wide, so a radius spans more columns than rows
+	aspect := ur.renderer.GetAspectRatio()
+	scaleY := float64(rows-1) / 2 / extent
+	scaleX := scaleY * aspect
+	if maxX := float64(width-1) / 2 / extent; scaleX > maxX {
+		scaleX = maxX
+		scaleY = scaleX / aspect
+	}
+	cx, cy := x+width/2, y+rows/2
+	toScreen := func(px, py float64) (int, int) {
+		return cx + int(math.Round(px*scaleX)), cy - int(math.Round(py*scaleY))
+	}
+
+	groundStyle := tcell.StyleDefault.Foreground(tcell.ColorDarkGreen).Background(tcell.ColorDarkBlue)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < width; col++ {
+			dx := float64(x+col-cx) / scaleX
+			dy := float64(y+row-cy) / scaleY
+			if dx*dx+dy*dy <= 1 {
+				ur.screen.SetContent(x+col, y+row, '░', nil, groundStyle)
+			}
+		}
+	}
+
+	if flight == nil || len(flight.Path) == 0 {
+		return
+	}
+
+	shown := len(flight.Path)
+	if elapsed := ur.clock.Now().Sub(ur.state.LaunchFired); elapsed < launchAnimation {
+		shown = int(float64(len(flight.Path)) * float64(elapsed) / float64(launchAnimation))
+	}
+
+	trailStyle := tcell.StyleDefault.Foreground(tcell.ColorLightCyan).Background(tcell.ColorDarkBlue)
+	headStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	inside := func(sx, sy int) bool {
+		return sx >= x && sx < x+width && sy >= y && sy < y+rows
+	}
+	for i := 0; i < shown; i++ {
+		sx, sy := toScreen(flight.Path[i].X, flight.Path[i].Y)
+		if inside(sx, sy) {
+			ur.screen.SetContent(sx, sy, '.', nil, trailStyle)
+		}
+	}
+	if shown > 0 {
+		head := flight.Path[shown-1]
+		if sx, sy := toScreen(head.X, head.Y); inside(sx, sy) {
+			ur.screen.SetContent(sx, sy, '*', nil, headStyle)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+// mapInks is what the map grids of a frame were drawn with, by the renderer that
+// drew them, so the legend explains only what is on screen. It is only filled in
+// while the legend is open.
+type mapInks struct {
+	renderers []*visualization.Renderer
+	inks      map[*visualization.Renderer]map[rune]bool
+}
+
+// reset forgets the last frame's inks
+func (m *mapInks) reset() {
+	m.renderers = m.renderers[:0]
+	clear(m.inks)
+}
+
+// add records a cell drawn by renderer with ink
+func (m *mapInks) add(renderer *visualization.Renderer, ink rune) {
+	if m.inks == nil {
+		m.inks = map[*visualization.Renderer]map[rune]bool{}
+	}
+	inks, ok := m.inks[renderer]
+	if !ok {
+		inks = map[rune]bool{}
+		m.inks[renderer] = inks
+		m.renderers = append(m.renderers, renderer)
+	}
+	inks[ink] = true
+}
+
+// legendLine is one row of the legend: a symbol as the map draws it and what it is
+type legendLine struct {
+	symbol rune
+	style  tcell.Style
+	label  string
+}
+
+// legendLines explains every symbol drawn on the map this frame, in the colors
+// it was drawn in, then the marks drawn over the map
+func (ur *UIRenderer) legendLines() []legendLine {
+	var lines []legendLine
+	seen := map[rune]bool{}
+	add := func(symbol rune, style tcell.Style, label string) {
+		if !seen[symbol] {
+			seen[symbol] = true
+			lines = append(lines, legendLine{symbol: symbol, style: style, label: label})
+		}
+	}
+
+	for _, renderer := range ur.inks.renderers {
+		for _, entry := range renderer.Legend(ur.inks.inks[renderer]) {
+			add(entry.Symbol, ur.inkStyle(renderer, entry.Symbol), entry.Label)
+		}
+	}
+
+	for _, renderer := range ur.inks.renderers {
+		if _, ok := renderer.WobbleMarks(); ok {
+			add(barycenterMark, barycenterStyle, "barycenter the stars and planets circle")
+		}
+		if marks, ok := renderer.TransferMarks(); ok {
+			style := transferMarkStyle(renderer)
+			add('D', style, "transfer departure")
+			add('A', style, "transfer arrival")
+			if marks.InFlight {
+				add(transferCraft(renderer), style, "spacecraft on the transfer")
+			}
+		}
+	}
+	return lines
+}
+
+// drawLegendModal explains the symbols on the map
+func (ur *UIRenderer) drawLegendModal(width, height int) {
+	lines := ur.legendLines()
+	modalX, modalY, _, modalHeight := ur.setupModal(width, height)
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, " 🔎 Map Legend ")
+
+	textStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	if len(lines) == 0 {
+		ur.drawText(modalX+2, modalY+3, textStyle, "Nothing on the map to explain")
+	}
+
+	lastRow := modalY + modalHeight - 3
+	for i, line := range lines {
+		y := modalY + 3 + i
+		if y > lastRow {
+			break
+		}
+		if y == lastRow && i < len(lines)-1 {
+			ur.drawText(modalX+2, y, textStyle, "…")
+			break
+		}
+		style := line.style
+		if _, background, _ := style.Decompose(); background == tcell.ColorDefault {
+			style = style.Background(tcell.ColorDarkBlue)
+		}
+		ur.screen.SetContent(modalX+2, y, line.symbol, nil, style)
+		ur.drawText(modalX+4, y, textStyle, truncateText(line.label, ur.contentWidth()-2))
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "Press Enter, Escape, or 'b' to close")
+}
+
+// handleLegendKeys handles keyboard input while the legend is open
+func (ed *EventDispatcher) handleLegendKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyEnter:
+		ed.state.PopModal()
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'q', 'Q', 'b', 'B', 'l':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestLegendExplainsWhatIsOnTheMap(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'l', tcell.ModNone))
+	if state.TopModal() != ModalLegend {
+		t.Fatalf("top modal = %v, want the legend", state.TopModal())
+	}
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+
+	text := screenText(screen)
+	for _, want := range []string{"Map Legend", "☉ Sun", "♂ Mars", "· orbit"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("legend does not show %q", want)
+		}
+	}
+	if strings.Contains(text, "transfer departure") {
+		t.Error("legend explains a transfer that is not on the map")
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'l', tcell.ModNone))
+	if state.TopModal() == ModalLegend {
+		t.Error("l again left the legend open")
+	}
+}
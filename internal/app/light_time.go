@@ -0,0 +1,39 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/display"
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// lightTimeDetail is the line of a body's details giving how long its star's
+// light takes to reach it where it is at the simulated date, or "" for bodies
+// that do not orbit the star
+func (ur *UIRenderer) lightTimeDetail(body models.CelestialBody) string {
+	if body.SemimajorAxis <= 0 || body.AroundPlanet != nil {
+		return ""
+	}
+	x, y := ur.renderer.GetEphemeris().Position(body, ur.renderer.GetClock().Now())
+	return "Light from Star Now: " + display.LightTime(math.Hypot(x, y))
+}
+
+// lightTimeDetailLines is how many lines the light travel time takes in a body's
+// details
+func (ur *UIRenderer) lightTimeDetailLines(body models.CelestialBody, maxWidth int) int {
+	detail := ur.lightTimeDetail(body)
+	if detail == "" {
+		return 0
+	}
+	return len(ur.wrapText(detail, maxWidth))
+}
+
+// lightYearsDetail says how long a system's light takes to reach the Earth, for
+// its galaxy map description
+func lightYearsDetail(lightYears float64) string {
+	if lightYears < 10 {
+		return fmt.Sprintf("light takes %.2f years to reach Earth", lightYears)
+	}
+	return fmt.Sprintf("light takes %s years to reach Earth", formatCount(int(math.Round(lightYears))))
+}
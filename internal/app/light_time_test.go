@@ -0,0 +1,40 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestLightTimeDetail(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 160, 50)
+	earth := state.GetPlanets()[3]
+
+	if got := dispatcher.uiRenderer.lightTimeDetail(earth); !strings.HasPrefix(got, "Light from Star Now: 8 min") {
+		t.Errorf("Earth's light time = %q, want about 8 minutes", got)
+	}
+	if got := dispatcher.uiRenderer.lightTimeDetail(state.GetPlanets()[0]); got != "" {
+		t.Errorf("the Sun's light time = %q, want none", got)
+	}
+	moon := models.CelestialBody{EnglishName: "Moon", SemimajorAxis: 384400, AroundPlanet: &models.Planet{Planet: "terre"}}
+	if got := dispatcher.uiRenderer.lightTimeDetail(moon); got != "" {
+		t.Errorf("the Moon's light time = %q, want none", got)
+	}
+
+	state.ShowPlanetDetails(earth, 3)
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+	if !strings.Contains(screenText(screen), "Light from Star Now") {
+		t.Error("Earth's details do not show the light travel time")
+	}
+}
+
+func TestLightYearsDetail(t *testing.T) {
+	if got := lightYearsDetail(4.2465); got != "light takes 4.25 years to reach Earth" {
+		t.Errorf("lightYearsDetail(4.2465) = %q", got)
+	}
+	if got := lightYearsDetail(2000); got != "light takes 2,000 years to reach Earth" {
+		t.Errorf("lightYearsDetail(2000) = %q", got)
+	}
+}
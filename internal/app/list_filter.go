@@ -0,0 +1,183 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/filter"
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+// maxFilterInputLength caps what can be typed in the filter bar
+const maxFilterInputLength = 120
+
+// filterModalLines is the content of the filter bar: the input, what it matches
+// and a reminder of the syntax
+const filterModalLines = 4
+
+// Listed reports whether the list shows a body under the filter
+func (s *AppState) Listed(body models.CelestialBody) bool {
+	return s.ListFilter == nil || s.ListFilter.Match(body)
+}
+
+// ListedCount returns how many bodies of the tab being shown pass the filter
+func (s *AppState) ListedCount() int {
+	count := 0
+	for _, body := range s.ListedBodies() {
+		if s.Listed(body) {
+			count++
+		}
+	}
+	return count
+}
+
+// ListedMatch returns the index in the tab's bodies of the nth one the filter
+// shows, or -1 if fewer are shown
+func (s *AppState) ListedMatch(n int) int {
+	for i, body := range s.ListedBodies() {
+		if s.Listed(body) {
+			if n == 0 {
+				return i
+			}
+			n--
+		}
+	}
+	return -1
+}
+
+// StepListed selects the next body the list shows in direction, skipping those
+// the filter hides, reporting false if there is none that way
+func (s *AppState) StepListed(direction int) bool {
+	bodies := s.ListedBodies()
+	for i := s.ListedIndex() + direction; i >= 0 && i < len(bodies); i += direction {
+		if s.Listed(bodies[i]) {
+			return s.SelectListed(i)
+		}
+	}
+	return false
+}
+
+// SetListFilter filters every tab of the list, nil showing every body again. A
+// selection the filter hides moves to the first body it shows.
+func (s *AppState) SetListFilter(f *filter.Filter) {
+	s.ListFilter = f
+	bodies := s.ListedBodies()
+	if index := s.ListedIndex(); index >= 0 && index < len(bodies) && s.Listed(bodies[index]) {
+		return
+	}
+	s.SelectListed(s.ListedMatch(0))
+}
+
+// ShowFilter opens the filter bar with the filter in use to edit
+func (s *AppState) ShowFilter() {
+	s.OpenModal(ModalFilter)
+	s.FilterInput = ""
+	if s.ListFilter != nil {
+		s.FilterInput = s.ListFilter.String()
+	}
+}
+
+// handleFilterKeys handles typing in the filter bar. Enter applies the filter, or
+// with nothing typed clears it; Escape leaves the list as it was.
+func (ed *EventDispatcher) handleFilterKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		ed.state.PopModal()
+	case tcell.KeyEnter:
+		ed.applyFilterInput()
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if input := []rune(ed.state.FilterInput); len(input) > 0 {
+			ed.state.FilterInput = string(input[:len(input)-1])
+		}
+	case tcell.KeyCtrlU:
+		ed.state.FilterInput = ""
+	case tcell.KeyRune:
+		if len(ed.state.FilterInput) < maxFilterInputLength {
+			ed.state.FilterInput += string(ev.Rune())
+		}
+	default:
+		// do nothing
+	}
+}
+
+// applyFilterInput filters the list by what was typed, keeping the bar open if
+// it does not parse, where the error is already shown
+func (ed *EventDispatcher) applyFilterInput() {
+	if strings.TrimSpace(ed.state.FilterInput) == "" {
+		ed.state.SetListFilter(nil)
+		ed.state.PopModal()
+		ed.state.SetStatusMessage("Showing every body", statusMessageDuration)
+		return
+	}
+
+	f, err := filter.Parse(ed.state.FilterInput)
+	if err != nil {
+		return
+	}
+	ed.state.SetListFilter(f)
+	ed.state.PopModal()
+	ed.state.SetStatusMessage(fmt.Sprintf("%d of %d %s match %s", ed.state.ListedCount(), len(ed.state.ListedBodies()),
+		strings.ToLower(ed.state.ListTab.String()), f), statusMessageDuration)
+}
+
+// filterPreview says what the typed expression would match, or why it cannot
+func filterPreview(state *AppState) (string, bool) {
+	if strings.TrimSpace(state.FilterInput) == "" {
+		return "Enter with nothing typed shows every body", true
+	}
+	f, err := filter.Parse(state.FilterInput)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	matched := 0
+	bodies := state.ListedBodies()
+	for _, body := range bodies {
+		if f.Match(body) {
+			matched++
+		}
+	}
+	return fmt.Sprintf("%d of %d %s match", matched, len(bodies), strings.ToLower(state.ListTab.String())), true
+}
+
+// drawFilterModal renders the filter bar: the expression being typed and what it
+// matches on the tab being shown
+func (ur *UIRenderer) drawFilterModal(width, height int) {
+	modalX, modalY, _, modalHeight := ur.setupModal(width, height, fitModalHeight(filterModalLines, height))
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, " 🔍 Filter the List ")
+
+	inputStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorWhite)
+	valueStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	hintStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	errorStyle := tcell.StyleDefault.Foreground(tcell.ColorOrange).Background(tcell.ColorDarkBlue)
+
+	inputWidth := ur.contentWidth() - 2
+	input := ur.state.FilterInput + "_"
+	if runes := []rune(input); len(runes) > inputWidth {
+		input = string(runes[len(runes)-inputWidth:])
+	}
+	ur.drawText(modalX+2, modalY+3, inputStyle, fmt.Sprintf(" %-*s ", inputWidth, input))
+
+	preview, ok := filterPreview(ur.state)
+	style := valueStyle
+	if !ok {
+		style = errorStyle
+	}
+	ur.drawText(modalX+2, modalY+5, style, truncateText(preview, ur.contentWidth()))
+	ur.drawText(modalX+2, modalY+6, hintStyle, truncateText("e.g. mass>1e24 && moons>=2 • bodyType=Moon • name~io || radius<500", ur.contentWidth()))
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "Enter to filter (empty shows all) • Ctrl-U clears • Esc to cancel")
+}
+
+// filterLabel is the note in the header saying which filter is in use
+func (ur *UIRenderer) filterLabel() string {
+	if ur.state.ListFilter == nil {
+		return ""
+	}
+	return fmt.Sprintf("filter: %s (%s)", ur.state.ListFilter, ur.keys.Primary(keymap.ActionFilter))
+}
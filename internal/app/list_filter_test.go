@@ -0,0 +1,66 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func typeText(dispatcher *EventDispatcher, text string) {
+	for _, r := range text {
+		dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+}
+
+func TestListFilter(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+
+	typeText(dispatcher, "/radius>6000 &&")
+	if state.TopModal() != ModalFilter {
+		t.Fatalf("Expected / to open the filter bar, got modal %v", state.TopModal())
+	}
+	// Enter does nothing while the expression does not parse
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if state.TopModal() != ModalFilter || state.ListFilter != nil {
+		t.Fatal("Expected the filter bar kept open on an unfinished expression")
+	}
+	typeText(dispatcher, " isPlanet=true")
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if state.TopModal() != ModalNone || state.ListFilter == nil {
+		t.Fatalf("Expected the filter applied, got modal %v", state.TopModal())
+	}
+
+	// The Sun is hidden, so the selection moves to Venus, and the arrows skip Mars
+	if state.SelectedPlanet.EnglishName != "Venus" {
+		t.Errorf("Expected Venus selected first, got %s", state.SelectedPlanet.EnglishName)
+	}
+	var visited []string
+	for i := 0; i < 3; i++ {
+		dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+		visited = append(visited, state.SelectedPlanet.EnglishName)
+	}
+	if got := strings.Join(visited, ","); got != "Earth,Jupiter,Jupiter" {
+		t.Errorf("Expected the arrows to step through the matches, got %s", got)
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, '2', tcell.ModNone))
+	if state.SelectedPlanet.EnglishName != "Earth" {
+		t.Errorf("Expected 2 to pick the second match, got %s", state.SelectedPlanet.EnglishName)
+	}
+	state.PopModal()
+
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+	if text := screenText(screen); !strings.Contains(text, "Planets (3/6)") || !strings.Contains(text, "filter: radius>6000") {
+		t.Errorf("Expected the filtered count and the filter shown, got:\n%s", text)
+	}
+
+	// An empty filter shows everything again
+	typeText(dispatcher, "/")
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyCtrlU, 0, tcell.ModNone))
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if state.ListFilter != nil || state.ListedCount() != 6 {
+		t.Errorf("Expected the filter cleared, %d bodies listed", state.ListedCount())
+	}
+}
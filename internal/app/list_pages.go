@@ -0,0 +1,92 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/gdamore/tcell/v2"
+)
+
+// listCell is a name or group label laid out in a row of the body list. Labels
+// have no index.
+type listCell struct {
+	index, x, row int
+	text          string
+	style         tcell.Style
+}
+
+// drawListPage draws the page of cells holding selectedRow. A system file can
+// hold hundreds of bodies: when the rows do not all fit, the last row of area
+// counts the bodies shown and says how to page, and only the page's rows are
+// drawn. Pages break at fixed rows rather than scrolling with the selection, so
+// a body stays where it was clicked until the selection leaves its page.
+func (ur *UIRenderer) drawListPage(area layout.Rect, cells []listCell, selectedRow int) {
+	rows, total := 0, 0
+	for _, cell := range cells {
+		rows = max(rows, cell.row+1)
+		if cell.index >= 0 {
+			total++
+		}
+	}
+	perPage := max(area.Height, 1)
+	if rows > area.Height && area.Height > 1 {
+		perPage = area.Height - 1
+	}
+	page := selectedRow / perPage
+
+	var starts []int
+	startPage := -1
+	counted, firstShown, lastShown := 0, 0, 0
+	for _, cell := range cells {
+		if cell.index >= 0 {
+			counted++
+			if p := cell.row / perPage; p != startPage {
+				starts = append(starts, cell.index)
+				startPage = p
+			}
+		}
+		if cell.row/perPage != page {
+			continue
+		}
+		y := area.Y + cell.row - page*perPage
+		ur.drawText(cell.x, y, cell.style, cell.text)
+		if cell.index >= 0 {
+			if firstShown == 0 {
+				firstShown = counted
+			}
+			lastShown = counted
+			ur.state.AddPlanetListPosition(PlanetListPosition{Index: cell.index, X: cell.x, Y: y, Width: ui.TextWidth(cell.text)})
+		}
+	}
+	ur.state.SetListPages(starts)
+
+	if perPage < rows {
+		counts := fmt.Sprintf("%d-%d of %d • %s/%s for more", firstShown, lastShown, total,
+			ur.keys.Primary(keymap.ActionPagePrevious), ur.keys.Primary(keymap.ActionPageNext))
+		ur.drawText(area.X, area.Y+area.Height-1, tcell.StyleDefault.Foreground(tcell.ColorGray), truncateText(counts, area.Width))
+	}
+}
+
+// pageList moves the selection to the first body on the next or previous page
+// of the list as last drawn, or to the last or first body when there is no page
+// that way
+func (ed *EventDispatcher) pageList(direction int) {
+	ed.state.MapFocus = false
+	index := ed.state.ListedIndex()
+	page := 0
+	starts := ed.state.GetListPages()
+	for i, start := range starts {
+		if start <= index {
+			page = i
+		}
+	}
+	if next := page + direction; next >= 0 && next < len(starts) {
+		ed.state.SelectListed(starts[next])
+	} else if direction < 0 {
+		ed.state.SelectListed(ed.state.ListedMatch(0))
+	} else {
+		ed.state.SelectListed(ed.state.ListedMatch(ed.state.ListedCount() - 1))
+	}
+}
@@ -0,0 +1,214 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/livesync"
+	"github.com/furan917/go-solar-system/internal/logging"
+	"github.com/furan917/go-solar-system/internal/orbital"
+	"github.com/furan917/go-solar-system/internal/protocol"
+	"github.com/furan917/go-solar-system/internal/systems"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// syncHeartbeat is how often the state is rebroadcast when nothing changes, so
+	// followers' clocks do not drift
+	syncHeartbeat = time.Second
+
+	// syncRetryDelay is the wait before reconnecting to a lost session
+	syncRetryDelay = 2 * time.Second
+
+	// syncAppName identifies us in the hello message
+	syncAppName = "go-solar-system"
+)
+
+// syncBroadcaster publishes the session to live-sync followers. It runs as a frame
+// hook, so it sees the same selection and time that were just drawn.
+type syncBroadcaster struct {
+	server   *livesync.Server
+	systems  *systems.SystemManager
+	clock    *orbital.SimulationClock
+	logger   *logging.Logger
+	last     protocol.State
+	lastSent time.Time
+}
+
+// onFrame sends followers the system, selection and simulated time of a frame
+// when the view has changed, or a heartbeat when it has sat still for a while
+func (b *syncBroadcaster) onFrame(frame Frame) bool {
+	state := protocol.State{
+		System:        b.systems.GetCurrentSystem(),
+		SystemName:    frame.System,
+		SelectedBody:  frame.Selected.EnglishName,
+		SimulatedTime: b.clock.Now(),
+		Speed:         b.clock.Speed(),
+	}
+	if state.SameView(b.last) && time.Since(b.lastSent) < syncHeartbeat {
+		return true
+	}
+
+	message, err := protocol.Encode(protocol.TypeState, state)
+	if err != nil {
+		b.logger.Printf("Live sync: %v", err)
+		return true
+	}
+	b.server.Broadcast(message)
+	b.last = state
+	b.lastSent = time.Now()
+	return true
+}
+
+// startSyncServer listens for followers on addr and starts broadcasting each frame,
+// until the UI's background tasks stop. Browser pages may follow from addr's own
+// host or the origins given.
+func startSyncServer(addr string, origins []string, ur *UIRenderer, logger *logging.Logger) error {
+	greeting, err := protocol.Encode(protocol.TypeHello, protocol.Hello{App: syncAppName})
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for live sync on %s: %w", addr, err)
+	}
+
+	server := livesync.NewServer(greeting)
+	server.AllowOrigins(origins...)
+	mux := http.NewServeMux()
+	mux.Handle(livesync.Path, server)
+	httpServer := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	httpServer.RegisterOnShutdown(server.Close)
+
+	ur.background.Go("live sync server", func(ctx context.Context) error {
+		stop := context.AfterFunc(ctx, func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			httpServer.Shutdown(shutdownCtx)
+		})
+		defer stop()
+		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return NewNetworkError("live sync server stopped", err)
+		}
+		return nil
+	})
+
+	broadcaster := &syncBroadcaster{
+		server:  server,
+		systems: ur.GetSystemManager(),
+		clock:   ur.GetRenderer().GetClock(),
+		logger:  logger,
+	}
+	ur.AddFrameHook(broadcaster.onFrame)
+
+	logger.Printf("Live sync listening on ws://%s%s", listener.Addr(), livesync.Path)
+	return nil
+}
+
+// syncStateEvent carries a state from the followed session into the event loop
+type syncStateEvent struct {
+	tcell.EventTime
+	state protocol.State
+}
+
+// followSync mirrors the session at url until ctx is done, reconnecting when the
+// connection drops
+func (ss *SolarSystem) followSync(ctx context.Context, url string) error {
+	for ctx.Err() == nil {
+		conn, err := livesync.Dial(url)
+		if err != nil {
+			ss.logger.Debugf("Live sync: %v", err)
+			ss.state.SetStatusMessage("Waiting for live sync at "+url, syncRetryDelay)
+		} else {
+			ss.state.SetStatusMessage("Following "+url, statusMessageDuration)
+			ss.readSync(ctx, conn)
+			ss.state.SetStatusMessage("Lost live sync, reconnecting", statusMessageDuration)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(syncRetryDelay):
+		}
+	}
+	return nil
+}
+
+// readSync posts each state received on conn to the event loop
+func (ss *SolarSystem) readSync(ctx context.Context, conn *livesync.Conn) {
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	defer conn.Close()
+
+	for {
+		data, err := conn.ReadMessage()
+		if err != nil {
+			ss.logger.Debugf("Live sync connection closed: %v", err)
+			return
+		}
+
+		envelope, err := protocol.Decode(data)
+		if err != nil {
+			ss.logger.Printf("Live sync: %v", err)
+			if errors.Is(err, protocol.ErrUnsupportedVersion) {
+				ss.state.SetStatusMessage("Live sync peer speaks a different protocol version", statusMessageDuration)
+			}
+			continue
+		}
+		if envelope.Type != protocol.TypeState {
+			continue
+		}
+
+		state, err := envelope.State()
+		if err != nil {
+			ss.logger.Printf("Live sync: %v", err)
+			continue
+		}
+
+		event := &syncStateEvent{state: state}
+		event.SetEventNow()
+		if err := ss.screen.PostEvent(event); err != nil {
+			ss.logger.Debugf("Live sync: dropped state: %v", err)
+		}
+	}
+}
+
+// applySyncState mirrors the followed session: its clock always, and its system and
+// selection whenever they change there
+func (ed *EventDispatcher) applySyncState(state protocol.State) {
+	ed.uiRenderer.GetRenderer().GetClock().Set(state.SimulatedTime, state.Speed)
+
+	if state.SameView(ed.lastSync) {
+		return
+	}
+	ed.lastSync = state
+
+	if state.System != "" && state.System != ed.uiRenderer.GetSystemManager().GetCurrentSystem() {
+		index := -1
+		for i, name := range ed.uiRenderer.GetSystemManager().GetAvailableSystems() {
+			if name == state.System {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			ed.state.SetStatusMessage(fmt.Sprintf("Followed system %s is not installed here", state.SystemName), statusMessageDuration)
+			return
+		}
+		ed.state.SystemSelectedIndex = index
+		ed.systemManager.SwitchToSelectedSystem()
+	}
+
+	for i, planet := range ed.state.GetPlanets() {
+		if planet.EnglishName == state.SelectedBody {
+			ed.state.SelectedIndex = i
+			ed.state.SelectedPlanet = planet
+			break
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"math"
+	"sort"
+
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+// mapFocusStyle is the ring round the body focused on the map
+var mapFocusStyle = tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
+
+// mapOrder lists the bodies drawn on the map in the order the keyboard visits
+// them: whatever sits at the centre, then the rest clockwise from straight up
+// round it, nearer ones first where two line up
+func mapOrder(positions map[string]visualization.PlanetPosition) []visualization.PlanetPosition {
+	order := make([]visualization.PlanetPosition, 0, len(positions))
+	centreX, centreY, centres := 0, 0, 0
+	for _, pos := range positions {
+		order = append(order, pos)
+		if pos.World == (visualization.WorldPoint{}) {
+			centreX, centreY, centres = centreX+pos.X, centreY+pos.Y, centres+1
+		}
+	}
+	if centres > 0 {
+		centreX, centreY = centreX/centres, centreY/centres
+	}
+
+	// Angle clockwise from straight up on screen, where y grows downwards
+	angle := func(pos visualization.PlanetPosition) float64 {
+		a := math.Atan2(float64(pos.X-centreX), float64(centreY-pos.Y))
+		if a < 0 {
+			a += 2 * math.Pi
+		}
+		return a
+	}
+	distance := func(pos visualization.PlanetPosition) float64 {
+		return math.Hypot(float64(pos.X-centreX), float64(pos.Y-centreY))
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		aCentre, bCentre := a.World == (visualization.WorldPoint{}), b.World == (visualization.WorldPoint{})
+		switch {
+		case aCentre != bCentre:
+			return aCentre
+		case aCentre:
+			return a.Planet.EnglishName < b.Planet.EnglishName
+		case angle(a) != angle(b):
+			return angle(a) < angle(b)
+		case distance(a) != distance(b):
+			return distance(a) < distance(b)
+		}
+		return a.Planet.EnglishName < b.Planet.EnglishName
+	})
+	return order
+}
+
+// cycleMapFocus moves the selection to the next body drawn on the map, or the
+// previous one, ringing it there. Bodies the list has scrolled past or left
+// out can be reached this way too.
+func (ed *EventDispatcher) cycleMapFocus(direction int) {
+	order := mapOrder(ed.state.GetPlanetPositions())
+	if len(order) == 0 {
+		return
+	}
+
+	current := -1
+	if direction < 0 {
+		current = len(order)
+	}
+	for i, pos := range order {
+		if pos.Planet.EnglishName == ed.state.SelectedPlanet.EnglishName {
+			current = i
+			break
+		}
+	}
+	next := order[((current+direction)%len(order)+len(order))%len(order)]
+
+	ed.state.SelectOnMap(next.Planet)
+	ed.state.MapFocus = true
+	ed.state.SetStatusMessage(mapFocusStatus(next.Planet), statusMessageDuration)
+}
+
+// mapFocusStatus names the body focused on the map, for the status line
+func mapFocusStatus(body models.CelestialBody) string {
+	return "Map focus: " + body.EnglishName
+}
+
+// drawMapFocusRing marks the corners round the selected body where it was drawn
+// on the map, when it was focused there
+func (ur *UIRenderer) drawMapFocusRing(region layout.Rect) {
+	if !ur.state.MapFocus {
+		return
+	}
+	pos, ok := ur.state.GetPlanetPositions()[ur.state.SelectedPlanet.EnglishName]
+	if !ok {
+		return
+	}
+
+	corners := [4]rune{'╭', '╮', '╰', '╯'}
+	if ur.renderer.GetSymbols().ASCII {
+		corners = [4]rune{'+', '+', '+', '+'}
+	}
+	// Cells are about twice as tall as wide, so the ring is half as many rows out
+	dx, dy := pos.Radius+1, pos.Radius/2+1
+	for i, corner := range []struct{ x, y int }{
+		{pos.X - dx, pos.Y - dy}, {pos.X + dx, pos.Y - dy},
+		{pos.X - dx, pos.Y + dy}, {pos.X + dx, pos.Y + dy},
+	} {
+		if corner.x >= region.X && corner.x < region.X+region.Width && corner.y >= region.Y && corner.y < region.Y+region.Height {
+			ur.screen.SetContent(corner.x, corner.y, corners[i], nil, mapFocusStyle)
+		}
+	}
+}
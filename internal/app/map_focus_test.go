@@ -0,0 +1,73 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestMapOrderGoesClockwiseFromTheCentre(t *testing.T) {
+	// Bodies off the centre have a world position; the star is at the origin
+	at := func(name string, x, y int) visualization.PlanetPosition {
+		pos := visualization.PlanetPosition{X: x, Y: y, World: visualization.WorldPoint{X: 1}}
+		pos.Planet.EnglishName = name
+		return pos
+	}
+	sun := visualization.PlanetPosition{X: 10, Y: 10}
+	sun.Planet.EnglishName = "Sun"
+	positions := map[string]visualization.PlanetPosition{
+		"Sun":   sun,
+		"West":  at("West", 2, 10),
+		"North": at("North", 10, 4),
+		"East":  at("East", 18, 10),
+		"Far":   at("Far", 30, 10),
+		"South": at("South", 10, 16),
+	}
+
+	var names []string
+	for _, pos := range mapOrder(positions) {
+		names = append(names, pos.Planet.EnglishName)
+	}
+	if got, want := strings.Join(names, " "), "Sun North East Far South West"; got != want {
+		t.Errorf("mapOrder() = %s, want %s", got, want)
+	}
+}
+
+func TestMapFocusCyclesThroughTheMap(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+	state.UpdatePlanetSelection(0, state.GetPlanets()[0])
+	order := mapOrder(state.GetPlanetPositions())
+	if len(order) != len(state.GetPlanets()) {
+		t.Fatalf("%d bodies on the map, want all %d", len(order), len(state.GetPlanets()))
+	}
+
+	// The Sun is selected, so ] goes on to the first body round it
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, ']', tcell.ModNone))
+	if state.SelectedPlanet.EnglishName != order[1].Planet.EnglishName || !state.MapFocus {
+		t.Fatalf("selected %s (focus %v), want %s focused", state.SelectedPlanet.EnglishName, state.MapFocus, order[1].Planet.EnglishName)
+	}
+	if state.GetPlanets()[state.SelectedIndex].EnglishName != state.SelectedPlanet.EnglishName {
+		t.Error("the list's selection does not follow the map's")
+	}
+
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+	pos := state.GetPlanetPositions()[state.SelectedPlanet.EnglishName]
+	if glyph, _, _, _ := screen.GetContent(pos.X-pos.Radius-1, pos.Y-pos.Radius/2-1); glyph != '╭' {
+		t.Errorf("cell above left of %s = %q, want the focus ring", state.SelectedPlanet.EnglishName, glyph)
+	}
+
+	// [ twice goes back past the Sun to the last body round it
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, '[', tcell.ModNone))
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, '[', tcell.ModNone))
+	if last := order[len(order)-1].Planet.EnglishName; state.SelectedPlanet.EnglishName != last {
+		t.Errorf("selected %s, want %s", state.SelectedPlanet.EnglishName, last)
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone))
+	if state.MapFocus {
+		t.Error("the ring stays after moving in the list")
+	}
+}
@@ -0,0 +1,175 @@
+package app
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/furan917/go-solar-system/internal/systems"
+	"github.com/furan917/go-solar-system/internal/systems/formats"
+	"github.com/gdamore/tcell/v2"
+)
+
+// metadataField is one editable line of a system's metadata
+type metadataField struct {
+	Label string
+	Value func(metadata *systems.SystemMetadata) *string
+}
+
+// getMetadataFields returns the editable metadata in display order
+func getMetadataFields() []metadataField {
+	return []metadataField{
+		{Label: "Description", Value: func(m *systems.SystemMetadata) *string { return &m.Description }},
+		{Label: "Distance", Value: func(m *systems.SystemMetadata) *string { return &m.Distance }},
+		{Label: "Discovery Year", Value: func(m *systems.SystemMetadata) *string { return &m.DiscoveryYear }},
+		{Label: "Galaxy", Value: func(m *systems.SystemMetadata) *string { return &m.Galaxy }},
+	}
+}
+
+// metadataEditorLines returns the number of content lines in the editor modal
+func metadataEditorLines() int {
+	return len(getMetadataFields()) + 2 // fields + spacing + status line
+}
+
+// openMetadataEditor opens the metadata editor for the system highlighted in the
+// system list. The Solar System comes from the API and has no file to write to.
+func (ed *EventDispatcher) openMetadataEditor() {
+	manager := ed.uiRenderer.GetSystemManager()
+	available := manager.GetAvailableSystems()
+	if ed.state.SystemSelectedIndex >= len(available) {
+		return
+	}
+
+	systemName := available[ed.state.SystemSelectedIndex]
+	if systemName == "solar-system" {
+		ed.state.SetStatusMessage("Solar System data comes from the API and cannot be edited", statusMessageDuration)
+		return
+	}
+	metadata, err := manager.LoadSystemMetadata(systemName)
+	if err != nil {
+		ed.state.SetStatusMessage(fmt.Sprintf("Cannot edit %s: %v", systemName, err), statusMessageDuration)
+		return
+	}
+
+	ed.state.ShowMetadataEditor(systemName, systems.SystemMetadata{
+		SystemName:     metadata.SystemName,
+		Description:    metadata.Description,
+		DiscoveryYear:  metadata.DiscoveryYear,
+		Distance:       metadata.Distance,
+		Galaxy:         metadata.Galaxy,
+		RightAscension: metadata.RightAscension,
+	})
+}
+
+// handleMetadataEditorKeys handles keyboard input while the metadata editor is
+// open. Letters are typed into the highlighted field, so only keys that cannot be
+// typed move around, save or close.
+func (ed *EventDispatcher) handleMetadataEditorKeys(ev *tcell.EventKey) {
+	fields := getMetadataFields()
+
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		ed.state.PopModal()
+	case tcell.KeyUp, tcell.KeyBacktab:
+		if ed.state.MetadataFieldIndex > 0 {
+			ed.state.MetadataFieldIndex--
+		}
+	case tcell.KeyDown, tcell.KeyTab:
+		if ed.state.MetadataFieldIndex < len(fields)-1 {
+			ed.state.MetadataFieldIndex++
+		}
+	case tcell.KeyEnter:
+		ed.writeMetadataEdits()
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		ed.editMetadataField(func(value string) string {
+			runes := []rune(value)
+			if len(runes) == 0 {
+				return value
+			}
+			return string(runes[:len(runes)-1])
+		})
+	case tcell.KeyCtrlU:
+		ed.editMetadataField(func(string) string { return "" })
+	case tcell.KeyRune:
+		if r := ev.Rune(); unicode.IsPrint(r) {
+			ed.editMetadataField(func(value string) string { return value + string(r) })
+		}
+	default:
+		// do nothing
+	}
+}
+
+// editMetadataField changes the highlighted field's text
+func (ed *EventDispatcher) editMetadataField(edit func(value string) string) {
+	fields := getMetadataFields()
+	if ed.state.MetadataFieldIndex < 0 || ed.state.MetadataFieldIndex >= len(fields) {
+		return
+	}
+
+	value := fields[ed.state.MetadataFieldIndex].Value(&ed.state.MetadataEdit)
+	if edited := edit(*value); edited != *value {
+		*value = edited
+		ed.state.MetadataDirty = true
+		ed.state.MetadataStatus = "Modified - press Enter to write to the system file"
+	}
+}
+
+// writeMetadataEdits saves the edited metadata to the system's file, leaving its
+// bodies as they are
+func (ed *EventDispatcher) writeMetadataEdits() {
+	if !ed.state.MetadataDirty {
+		ed.state.MetadataStatus = "Nothing to save"
+		return
+	}
+
+	path, err := ed.systemManager.WriteSystemMetadata(ed.state.MetadataSystem, ed.state.MetadataEdit)
+	if err != nil {
+		ed.state.MetadataStatus = fmt.Sprintf("Save failed: %v", err)
+		return
+	}
+
+	ed.state.MetadataDirty = false
+	ed.state.MetadataStatus = fmt.Sprintf("Saved to %s", path)
+	if _, known := formats.ParseDistance(ed.state.MetadataEdit.Distance); !known {
+		ed.state.MetadataStatus += "; the galaxy map cannot place that distance"
+	}
+}
+
+// drawMetadataEditorModal renders the system metadata editor
+func (ur *UIRenderer) drawMetadataEditorModal(width, height int) {
+	modalX, modalY, _, modalHeight := ur.setupModal(width, height, fitModalHeight(metadataEditorLines(), height))
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, fmt.Sprintf(" ✎ System Details: %s ", ur.state.MetadataEdit.SystemName))
+
+	const labelWidth = len("Discovery Year: ") + 2
+	valueWidth := ur.contentWidth() - labelWidth - 1
+	currentY := modalY + 3
+	for i, field := range getMetadataFields() {
+		style := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+		prefix := "  "
+		value := *field.Value(&ur.state.MetadataEdit)
+		if i == ur.state.MetadataFieldIndex {
+			style = tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true).Reverse(true)
+			prefix = "► "
+			// Keep the end being typed at in view
+			if runes := []rune(value + "_"); len(runes) > valueWidth && valueWidth > 3 {
+				value = "..." + string(runes[len(runes)-valueWidth+3:])
+			} else {
+				value += "_"
+			}
+		} else {
+			value = truncateText(value, valueWidth)
+		}
+
+		ur.drawText(modalX+2, currentY, style, fmt.Sprintf("%s%-*s %s", prefix, labelWidth-2, field.Label+":", value))
+		currentY++
+	}
+
+	if ur.state.MetadataStatus != "" {
+		statusStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+		ur.drawText(modalX+2, modalY+modalHeight-3, statusStyle, truncateText(ur.state.MetadataStatus, ur.contentWidth()))
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "↑/↓ field • Ctrl+U clear • Enter write • Esc back")
+}
@@ -0,0 +1,332 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+// missionHomeBody is the default origin when the loaded system has it
+const missionHomeBody = "Earth"
+
+// missionLabelColumn is the width of the label column in the mission planner
+const missionLabelColumn = 22
+
+// missionPlan is a Hohmann transfer worked out for the mission planner
+type missionPlan struct {
+	transfer      orbital.Transfer
+	departureBurn float64 // km/s from a low parking orbit, 0 if the origin's size is unknown
+	captureBurn   float64 // km/s into a low orbit, 0 if the destination's size is unknown
+	phaseNow      float64
+	window        time.Time
+	hasWindow     bool
+	computedAt    time.Time
+	err           error
+}
+
+// missionBodies returns the bodies a mission can fly between: those orbiting the star
+func missionBodies(bodies []models.CelestialBody) []models.CelestialBody {
+	var candidates []models.CelestialBody
+	for _, body := range bodies {
+		if body.BodyType == "Star" || body.SemimajorAxis <= 0 || body.SideralOrbit <= 0 {
+			continue
+		}
+		candidates = append(candidates, body)
+	}
+	return candidates
+}
+
+// centralMass returns the mass of the heaviest star, assuming a Sun if none is known
+func centralMass(bodies []models.CelestialBody) float64 {
+	mass := 0.0
+	for _, body := range bodies {
+		if body.BodyType == "Star" {
+			mass = math.Max(mass, body.GetMassKg())
+		}
+	}
+	if mass == 0 {
+		return orbital.SolarMass
+	}
+	return mass
+}
+
+// openMissionPlanner starts from Earth (or the first body) towards the selected body
+func (ed *EventDispatcher) openMissionPlanner() {
+	candidates := missionBodies(ed.state.GetPlanets())
+	if len(candidates) < 2 {
+		ed.state.SetStatusMessage("Need at least two orbiting bodies to plan a mission", statusMessageDuration)
+		return
+	}
+
+	origin := candidates[0].EnglishName
+	if _, ok := findBodyByName(candidates, missionHomeBody); ok {
+		origin = missionHomeBody
+	}
+
+	destination := ed.state.SelectedPlanet.EnglishName
+	if _, ok := findBodyByName(candidates, destination); !ok || destination == origin {
+		destination = candidates[0].EnglishName
+		if destination == origin {
+			destination = candidates[1].EnglishName
+		}
+	}
+
+	ed.state.ShowMissionPlanner(origin, destination)
+	ed.refreshMissionPlan()
+}
+
+// refreshMissionPlan recomputes the transfer from the current simulated time
+func (ed *EventDispatcher) refreshMissionPlan() {
+	bodies := ed.state.GetPlanets()
+	candidates := missionBodies(bodies)
+	origin, okOrigin := findBodyByName(candidates, ed.state.MissionOrigin)
+	destination, okDestination := findBodyByName(candidates, ed.state.MissionDestination)
+
+	now := ed.uiRenderer.GetRenderer().GetClock().Now()
+	plan := missionPlan{computedAt: now}
+	if !okOrigin || !okDestination {
+		plan.err = fmt.Errorf("bodies are no longer in this system")
+		ed.state.MissionPlan = plan
+		return
+	}
+
+	transfer, err := orbital.HohmannTransfer(orbital.GravitationalParameter(centralMass(bodies)), origin.SemimajorAxis, destination.SemimajorAxis)
+	if err != nil {
+		plan.err = err
+		ed.state.MissionPlan = plan
+		return
+	}
+
+	ephemeris := ed.uiRenderer.GetRenderer().GetEphemeris()
+	plan.transfer = transfer
+	plan.phaseNow = ephemeris.PhaseAngle(origin, destination, now)
+	plan.window, plan.hasWindow = ephemeris.NextLaunchWindow(origin, destination, transfer, now)
+	if origin.MeanRadius > 0 && origin.GetMassKg() > 0 {
+		plan.departureBurn = orbital.EscapeBurn(origin.GetMassKg(), origin.MeanRadius, transfer.DepartureDeltaV)
+	}
+	if destination.MeanRadius > 0 && destination.GetMassKg() > 0 {
+		plan.captureBurn = orbital.EscapeBurn(destination.GetMassKg(), destination.MeanRadius, transfer.ArrivalDeltaV)
+	}
+	ed.state.MissionPlan = plan
+}
+
+// handleMissionPlannerKeys handles keyboard input while the mission planner is open
+func (ed *EventDispatcher) handleMissionPlannerKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyEnter:
+		ed.state.PopModal()
+	case tcell.KeyTab, tcell.KeyBacktab, tcell.KeyLeft, tcell.KeyRight:
+		ed.state.MissionField = 1 - ed.state.MissionField
+	case tcell.KeyUp:
+		ed.cycleMissionBody(-1)
+	case tcell.KeyDown:
+		ed.cycleMissionBody(1)
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'q', 'Q', 'b', 'B':
+			ed.state.PopModal()
+		case 'x', 'X':
+			ed.state.MissionOrigin, ed.state.MissionDestination = ed.state.MissionDestination, ed.state.MissionOrigin
+			ed.refreshMissionPlan()
+		case 'r', 'R':
+			ed.refreshMissionPlan()
+		case 'p', 'P':
+			ed.previewTransfer()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// cycleMissionBody moves the focused field to the next body, skipping the other end
+func (ed *EventDispatcher) cycleMissionBody(direction int) {
+	candidates := missionBodies(ed.state.GetPlanets())
+	if len(candidates) < 2 {
+		return
+	}
+
+	current, other := &ed.state.MissionDestination, ed.state.MissionOrigin
+	if ed.state.MissionField == 0 {
+		current, other = &ed.state.MissionOrigin, ed.state.MissionDestination
+	}
+
+	index := 0
+	for i, body := range candidates {
+		if body.EnglishName == *current {
+			index = i
+			break
+		}
+	}
+
+	for {
+		index = (index + direction + len(candidates)) % len(candidates)
+		if candidates[index].EnglishName != other {
+			break
+		}
+	}
+	*current = candidates[index].EnglishName
+	ed.refreshMissionPlan()
+}
+
+func findBodyByName(bodies []models.CelestialBody, name string) (models.CelestialBody, bool) {
+	for _, body := range bodies {
+		if body.EnglishName == name {
+			return body, true
+		}
+	}
+	return models.CelestialBody{}, false
+}
+
+// drawMissionPlannerModal renders the transfer between the chosen bodies
+func (ur *UIRenderer) drawMissionPlannerModal(width, height int) {
+	modalX, modalY, _, modalHeight := ur.setupModal(width, height)
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, " 🚀 Mission Planner ")
+
+	labelStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue)
+	valueStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	focusStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorYellow).Bold(true)
+	headingStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	noteStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+
+	y := modalY + 3
+	row := func(label, value string) {
+		ur.drawText(modalX+4, y, labelStyle, label)
+		ur.drawText(modalX+4+missionLabelColumn, y, valueStyle, value)
+		y++
+	}
+
+	for field, name := range []string{ur.state.MissionOrigin, ur.state.MissionDestination} {
+		label := "From"
+		if field == 1 {
+			label = "To"
+		}
+		style := valueStyle
+		if field == ur.state.MissionField {
+			style = focusStyle
+		}
+		ur.drawText(modalX+2, y, labelStyle, label)
+		ur.drawText(modalX+8, y, style, " "+name+" ")
+		y++
+	}
+	y++
+
+	plan := ur.state.MissionPlan
+	if plan.err != nil {
+		ur.drawText(modalX+2, y, valueStyle, "No transfer: "+plan.err.Error())
+	} else {
+		transfer := plan.transfer
+		ur.drawText(modalX+2, y, headingStyle, "Hohmann transfer")
+		y++
+		row("Departure burn", withLowOrbit(transfer.DepartureDeltaV, plan.departureBurn, "from"))
+		row("Arrival burn", withLowOrbit(transfer.ArrivalDeltaV, plan.captureBurn, "into"))
+		row("Total", fmt.Sprintf("%.2f km/s", transfer.TotalDeltaV()))
+		days := transfer.TravelTime.Hours() / 24
+		row("Travel time", fmt.Sprintf("%.0f days (%.2f years)", days, days/365.25))
+		y++
+
+		ur.drawText(modalX+2, y, headingStyle, "Launch window")
+		y++
+		row("Phase angle needed", fmt.Sprintf("%.1f°", transfer.PhaseAngle*180/math.Pi))
+		row("Phase angle now", fmt.Sprintf("%.1f°", plan.phaseNow*180/math.Pi))
+		if plan.hasWindow {
+			wait := plan.window.Sub(plan.computedAt).Hours() / 24
+			row("Next window", fmt.Sprintf("%s (in %.0f days)", plan.window.UTC().Format("2006-01-02"), wait))
+		} else {
+			row("Next window", "none - the orbits never line up")
+		}
+	}
+
+	ur.drawText(modalX+2, modalY+modalHeight-3, noteStyle, "Circular, coplanar orbits; dates follow the simulated clock")
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "↑/↓ body • Tab from/to • x swap • r refresh • p preview on map • Escape to close")
+}
+
+// previewTransfer draws the planned transfer on the map and moves the simulation
+// to its departure, the next launch window if there is one, so the path runs with
+// the clock from there. Previewing the transfer already drawn stops drawing it.
+func (ed *EventDispatcher) previewTransfer() {
+	plan := ed.state.MissionPlan
+	if plan.err != nil {
+		ed.state.SetStatusMessage("No transfer to preview: "+plan.err.Error(), statusMessageDuration)
+		return
+	}
+	candidates := missionBodies(ed.state.GetPlanets())
+	origin, okOrigin := findBodyByName(candidates, ed.state.MissionOrigin)
+	destination, okDestination := findBodyByName(candidates, ed.state.MissionDestination)
+	if !okOrigin || !okDestination {
+		return
+	}
+
+	renderer := ed.uiRenderer.GetRenderer()
+	ed.uiRenderer.drawMu.Lock()
+	defer ed.uiRenderer.drawMu.Unlock()
+	ed.state.ResetModals()
+
+	if shown := renderer.Transfer(); shown != nil && shown.Origin.EnglishName == origin.EnglishName && shown.Destination.EnglishName == destination.EnglishName {
+		renderer.SetTransfer(nil)
+		ed.state.SetStatusMessage("Transfer preview hidden", statusMessageDuration)
+		return
+	}
+
+	departure := plan.computedAt
+	if plan.hasWindow {
+		departure = plan.window
+	}
+	path := &visualization.TransferPath{Origin: origin, Destination: destination, Departure: departure, TravelTime: plan.transfer.TravelTime}
+	renderer.SetTransfer(path)
+	clock := renderer.GetClock()
+	clock.Set(departure, clock.Speed())
+	ed.state.SetStatusMessage(fmt.Sprintf("%s to %s: leaves %s, arrives %s", origin.EnglishName, destination.EnglishName,
+		departure.UTC().Format("2006-01-02"), path.Arrival().UTC().Format("2006-01-02")), statusMessageDuration)
+}
+
+// drawTransferMarks marks where the transfer the renderer drew into a grid placed
+// at x, y leaves and arrives, and the craft while it is on its way
+func (ur *UIRenderer) drawTransferMarks(renderer *visualization.Renderer, x, y, width, height int) {
+	marks, ok := renderer.TransferMarks()
+	if !ok {
+		return
+	}
+	style := transferMarkStyle(renderer)
+	mark := func(col, row int, glyph rune) {
+		if col >= 0 && col < width && row >= 0 && row < height {
+			ur.screen.SetContent(x+col, y+row, glyph, nil, style)
+		}
+	}
+	mark(marks.DepartureX, marks.DepartureY, 'D')
+	mark(marks.ArrivalX, marks.ArrivalY, 'A')
+	if marks.InFlight {
+		mark(marks.CraftX, marks.CraftY, transferCraft(renderer))
+	}
+}
+
+// transferMarkStyle is the style of a transfer's marks, in the color of its path
+func transferMarkStyle(renderer *visualization.Renderer) tcell.Style {
+	return tcell.StyleDefault.Foreground(renderer.InkColor(renderer.GetSymbols().Transfer)).Bold(true)
+}
+
+// transferCraft is the glyph of the craft on a transfer
+func transferCraft(renderer *visualization.Renderer) rune {
+	if renderer.GetSymbols().ASCII {
+		return '@'
+	}
+	return '✦'
+}
+
+// withLowOrbit formats a heliocentric burn along with the burn from or into a low
+// orbit when it is known
+func withLowOrbit(heliocentric, lowOrbit float64, direction string) string {
+	value := fmt.Sprintf("%.2f km/s", heliocentric)
+	if lowOrbit > 0 {
+		value += fmt.Sprintf(" (%.2f km/s %s low orbit)", lowOrbit, direction)
+	}
+	return value
+}
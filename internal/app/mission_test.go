@@ -0,0 +1,39 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMissionPlannerPreviewsTransfer(t *testing.T) {
+	dispatcher, state, _ := newAppFixture(t, 160, 50)
+	renderer := dispatcher.uiRenderer.GetRenderer()
+
+	typeText(dispatcher, "d")
+	if state.TopModal() != ModalMissionPlanner {
+		t.Fatalf("Expected the mission planner, got modal %v", state.TopModal())
+	}
+	plan := state.MissionPlan
+	typeText(dispatcher, "p")
+	path := renderer.Transfer()
+	if path == nil || path.Origin.EnglishName != state.MissionOrigin || path.Destination.EnglishName != state.MissionDestination {
+		t.Fatalf("Expected a preview from %s to %s, got %+v", state.MissionOrigin, state.MissionDestination, path)
+	}
+	if state.IsAnyModalShowing() {
+		t.Errorf("Expected the preview to show the map, got modal %v", state.TopModal())
+	}
+	// The clock keeps running from the window, so allow it a moment past
+	if since := renderer.GetClock().Now().Sub(plan.window); !plan.hasWindow || !path.Departure.Equal(plan.window) || since < 0 || since > 24*time.Hour {
+		t.Errorf("Expected the preview and the clock at the launch window %v, got departure %v and clock %v", plan.window, path.Departure, renderer.GetClock().Now())
+	}
+
+	dispatcher.uiRenderer.DrawScreen()
+	if marks, ok := renderer.TransferMarks(); !ok || !marks.InFlight {
+		t.Errorf("Expected the transfer drawn with the craft leaving, got %+v, %v", marks, ok)
+	}
+
+	typeText(dispatcher, "dp")
+	if renderer.Transfer() != nil {
+		t.Error("Expected previewing the same transfer again to hide it")
+	}
+}
@@ -0,0 +1,321 @@
+package app
+
+import (
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/gdamore/tcell/v2"
+)
+
+// modalSpec is everything the UI needs to know about a modal: how it is drawn,
+// how tall it is and how it answers keys and clicks. Adding a modal means adding
+// a Modal constant, a Show method that opens or pushes it, and a case below.
+type modalSpec struct {
+	draw func(ur *UIRenderer, width, height int)
+	keys func(ed *EventDispatcher, ev *tcell.EventKey)
+
+	// height is the modal's height on a screen this tall; nil uses the default size
+	height func(ur *UIRenderer, state *AppState, screenHeight int) int
+
+	// click handles a click inside the modal, returning false for clicks it does
+	// not use so that one on the instruction row goes back
+	click func(meh *MouseEventHandler, x, y int, area layout.Rect) bool
+
+	// back is what clicking the instruction row does; nil closes the modal
+	back func(meh *MouseEventHandler)
+
+	// wheel scrolls the modal one step of the mouse wheel, up for a direction of
+	// -1 and down for 1; nil ignores the wheel
+	wheel func(meh *MouseEventHandler, direction int)
+
+	// passThrough lets clicks outside the modal reach the map and the planet list
+	passThrough bool
+
+	// area is where the modal goes on a screen this size; nil centres it at its
+	// height
+	area func(state *AppState, screenWidth, screenHeight int) layout.Rect
+
+	// dismiss closes the modal on a click outside it, as a menu does
+	dismiss bool
+}
+
+// modalSpecFor returns the spec of a modal; ModalNone has an empty one
+func modalSpecFor(modal Modal) modalSpec {
+	switch modal {
+	case ModalDetails:
+		return modalSpec{
+			draw: (*UIRenderer).drawPlanetDetailsModal,
+			keys: (*EventDispatcher).handlePlanetDetailsKeys,
+			height: func(ur *UIRenderer, state *AppState, screenHeight int) int {
+				return fitModalHeight(ur.calculatePlanetDetailsLines(state.SelectedPlanet), screenHeight)
+			},
+			click:       (*MouseEventHandler).handlePlanetDetailsModalClick,
+			wheel:       (*MouseEventHandler).scrollDetails,
+			passThrough: true,
+		}
+	case ModalMoons:
+		return modalSpec{
+			draw:        (*UIRenderer).drawMoonListModal,
+			keys:        (*EventDispatcher).handleMoonListKeys,
+			click:       (*MouseEventHandler).handleMoonListModalClick,
+			wheel:       (*MouseEventHandler).scrollMoonList,
+			passThrough: true,
+		}
+	case ModalMoonDetails:
+		return modalSpec{
+			draw: (*UIRenderer).drawMoonDetailsModal,
+			keys: (*EventDispatcher).handleMoonDetailsKeys,
+			height: func(ur *UIRenderer, state *AppState, screenHeight int) int {
+				return fitModalHeight(ur.calculateMoonDetailsLines(state.SelectedMoon), screenHeight)
+			},
+			wheel:       (*MouseEventHandler).scrollDetails,
+			passThrough: true,
+		}
+	case ModalSystemList:
+		return modalSpec{
+			draw:        (*UIRenderer).drawSystemListModal,
+			keys:        (*EventDispatcher).handleSystemListKeys,
+			click:       (*MouseEventHandler).handleSystemListModalClick,
+			wheel:       (*MouseEventHandler).scrollSystemList,
+			passThrough: true,
+		}
+	case ModalElementEditor:
+		return modalSpec{
+			draw: (*UIRenderer).drawElementEditorModal,
+			keys: (*EventDispatcher).handleElementEditorKeys,
+			height: func(ur *UIRenderer, _ *AppState, screenHeight int) int {
+				return fitModalHeight(ur.calculateElementEditorLines(), screenHeight)
+			},
+			click: (*MouseEventHandler).handleElementEditorModalClick,
+			back:  func(meh *MouseEventHandler) { meh.state.CloseElementEditor() },
+		}
+	case ModalQuiz:
+		return modalSpec{
+			draw: (*UIRenderer).drawQuizModal,
+			keys: (*EventDispatcher).handleQuizKeys,
+			height: func(ur *UIRenderer, state *AppState, screenHeight int) int {
+				return fitModalHeight(ur.calculateQuizLines(state), screenHeight)
+			},
+			click: (*MouseEventHandler).handleQuizModalClick,
+		}
+	case ModalHelp:
+		return modalSpec{
+			draw:   (*UIRenderer).drawHelpModal,
+			keys:   (*EventDispatcher).handleHelpKeys,
+			height: func(_ *UIRenderer, _ *AppState, screenHeight int) int { return helpModalHeight(screenHeight) },
+			wheel:  (*MouseEventHandler).scrollHelp,
+		}
+	case ModalEventLog:
+		return modalSpec{
+			draw:  (*UIRenderer).drawEventLogModal,
+			keys:  (*EventDispatcher).handleEventLogKeys,
+			wheel: (*MouseEventHandler).scrollEventLog,
+		}
+	case ModalMissionPlanner:
+		return modalSpec{
+			draw: (*UIRenderer).drawMissionPlannerModal,
+			keys: (*EventDispatcher).handleMissionPlannerKeys,
+		}
+	case ModalStats:
+		return modalSpec{
+			draw: (*UIRenderer).drawStatsModal,
+			keys: (*EventDispatcher).handleStatsKeys,
+			height: func(ur *UIRenderer, state *AppState, screenHeight int) int {
+				return fitModalHeight(ur.calculateStatsLines(state), screenHeight)
+			},
+		}
+	case ModalCalibration:
+		return modalSpec{
+			draw:   (*UIRenderer).drawCalibrationModal,
+			keys:   (*EventDispatcher).handleCalibrationKeys,
+			height: func(_ *UIRenderer, _ *AppState, screenHeight int) int { return calibrationModalHeight(screenHeight) },
+			back:   func(meh *MouseEventHandler) { cancelCalibration(meh.state, meh.renderer.GetRenderer()) },
+		}
+	case ModalWatchlist:
+		return modalSpec{
+			draw: (*UIRenderer).drawWatchlistModal,
+			keys: (*EventDispatcher).handleWatchlistKeys,
+			height: func(ur *UIRenderer, state *AppState, screenHeight int) int {
+				return fitModalHeight(ur.calculateWatchlistLines(state), screenHeight)
+			},
+			wheel: (*MouseEventHandler).scrollWatchlist,
+		}
+	case ModalWeight:
+		return modalSpec{
+			draw: (*UIRenderer).drawWeightModal,
+			keys: (*EventDispatcher).handleWeightKeys,
+			height: func(ur *UIRenderer, state *AppState, screenHeight int) int {
+				return fitModalHeight(ur.calculateWeightLines(state), screenHeight)
+			},
+			wheel: (*MouseEventHandler).scrollWeight,
+		}
+	case ModalChart:
+		return modalSpec{
+			draw: (*UIRenderer).drawChartModal,
+			keys: (*EventDispatcher).handleChartKeys,
+			height: func(_ *UIRenderer, state *AppState, screenHeight int) int {
+				return fitModalHeight(len(state.GetPlanets())+2, screenHeight)
+			},
+			wheel: (*MouseEventHandler).scrollChart,
+		}
+	case ModalTree:
+		return modalSpec{
+			draw: (*UIRenderer).drawTreeModal,
+			keys: (*EventDispatcher).handleTreeKeys,
+			height: func(ur *UIRenderer, state *AppState, screenHeight int) int {
+				return fitModalHeight(len(ur.hierarchyRows(state))+2, screenHeight)
+			},
+			wheel: (*MouseEventHandler).scrollTree,
+		}
+	case ModalScaleModel:
+		return modalSpec{
+			draw: (*UIRenderer).drawScaleModal,
+			keys: (*EventDispatcher).handleScaleKeys,
+			height: func(ur *UIRenderer, state *AppState, screenHeight int) int {
+				return fitModalHeight(ur.calculateScaleLines(state), screenHeight)
+			},
+			wheel: (*MouseEventHandler).scrollScale,
+		}
+	case ModalRawJSON:
+		return modalSpec{
+			draw: (*UIRenderer).drawRawJSONModal,
+			keys: (*EventDispatcher).handleRawJSONKeys,
+			height: func(_ *UIRenderer, state *AppState, screenHeight int) int {
+				return fitModalHeight(len(state.RawJSONLines)+2, screenHeight)
+			},
+			wheel:       (*MouseEventHandler).scrollRawJSON,
+			passThrough: true,
+		}
+	case ModalLaunch:
+		return modalSpec{
+			draw:   (*UIRenderer).drawLaunchModal,
+			keys:   (*EventDispatcher).handleLaunchKeys,
+			height: func(_ *UIRenderer, _ *AppState, screenHeight int) int { return launchModalHeight(screenHeight) },
+		}
+	case ModalGalaxy:
+		return modalSpec{
+			draw:   (*UIRenderer).drawGalaxyModal,
+			keys:   (*EventDispatcher).handleGalaxyKeys,
+			height: func(_ *UIRenderer, _ *AppState, screenHeight int) int { return galaxyModalHeight(screenHeight) },
+			click:  (*MouseEventHandler).handleGalaxyModalClick,
+		}
+	case ModalDiagnostics:
+		return modalSpec{
+			draw: (*UIRenderer).drawDiagnosticsModal,
+			keys: (*EventDispatcher).handleDiagnosticsKeys,
+			height: func(_ *UIRenderer, state *AppState, screenHeight int) int {
+				return fitModalHeight(len(diagnosticsLines(state.Diagnostics)), screenHeight)
+			},
+		}
+	case ModalAPIStatus:
+		return modalSpec{
+			draw: (*UIRenderer).drawAPIStatusModal,
+			keys: (*EventDispatcher).handleAPIStatusKeys,
+			height: func(ur *UIRenderer, state *AppState, screenHeight int) int {
+				return fitModalHeight(len(apiStatusLines(state, ur.client)), screenHeight)
+			},
+		}
+	case ModalLegend:
+		return modalSpec{
+			draw: (*UIRenderer).drawLegendModal,
+			keys: (*EventDispatcher).handleLegendKeys,
+		}
+	case ModalFilter:
+		return modalSpec{
+			draw: (*UIRenderer).drawFilterModal,
+			keys: (*EventDispatcher).handleFilterKeys,
+			height: func(_ *UIRenderer, _ *AppState, screenHeight int) int {
+				return fitModalHeight(filterModalLines, screenHeight)
+			},
+		}
+	case ModalTransit:
+		return modalSpec{
+			draw:   (*UIRenderer).drawTransitModal,
+			keys:   (*EventDispatcher).handleTransitKeys,
+			height: func(_ *UIRenderer, _ *AppState, screenHeight int) int { return transitModalHeight(screenHeight) },
+		}
+	case ModalMetadataEditor:
+		return modalSpec{
+			draw: (*UIRenderer).drawMetadataEditorModal,
+			keys: (*EventDispatcher).handleMetadataEditorKeys,
+			height: func(_ *UIRenderer, _ *AppState, screenHeight int) int {
+				return fitModalHeight(metadataEditorLines(), screenHeight)
+			},
+		}
+	case ModalCommandPalette:
+		return modalSpec{
+			draw:   (*UIRenderer).drawCommandPaletteModal,
+			keys:   (*EventDispatcher).handleCommandPaletteKeys,
+			height: func(_ *UIRenderer, _ *AppState, screenHeight int) int { return commandPaletteHeight(screenHeight) },
+		}
+	case ModalContextMenu:
+		return modalSpec{
+			draw:    (*UIRenderer).drawContextMenu,
+			keys:    (*EventDispatcher).handleContextMenuKeys,
+			area:    contextMenuArea,
+			click:   (*MouseEventHandler).handleContextMenuClick,
+			dismiss: true,
+		}
+	case ModalConjunctions:
+		return modalSpec{
+			draw: (*UIRenderer).drawConjunctionModal,
+			keys: (*EventDispatcher).handleConjunctionKeys,
+		}
+	default:
+		return modalSpec{}
+	}
+}
+
+// fitModalHeight is the height of a modal with this many lines of content, leaving
+// room for its borders, title and instructions
+func fitModalHeight(lines, screenHeight int) int {
+	return minimum(lines+6, screenHeight-4)
+}
+
+// modalArea returns where a modal is drawn on the current screen
+func (ur *UIRenderer) modalArea(state *AppState, modal Modal) layout.Rect {
+	screenWidth, screenHeight := ur.screen.Size()
+	spec := modalSpecFor(modal)
+	if spec.area != nil {
+		return spec.area(state, screenWidth, screenHeight)
+	}
+	height := 0
+	if spec.height != nil {
+		height = spec.height(ur, state, screenHeight)
+	}
+	return layout.Compute(screenWidth, screenHeight).Modal(height)
+}
+
+// IsClickInModalArea reports whether a screen cell is inside the modal being shown
+func (ur *UIRenderer) IsClickInModalArea(state *AppState, mouseX, mouseY int) bool {
+	return state.IsAnyModalShowing() && ur.modalArea(state, state.TopModal()).Contains(mouseX, mouseY)
+}
+
+// handleModalClick gives a click to the modal being shown. It returns false when
+// the click is outside a modal that lets such clicks through to the map.
+func (meh *MouseEventHandler) handleModalClick(mouseX, mouseY int) bool {
+	modal := meh.state.TopModal()
+	if modal == ModalNone {
+		return false
+	}
+
+	spec := modalSpecFor(modal)
+	area := meh.renderer.modalArea(meh.state, modal)
+	if !area.Contains(mouseX, mouseY) {
+		if spec.dismiss {
+			meh.state.PopModal()
+			return true
+		}
+		return !spec.passThrough
+	}
+
+	if spec.click != nil && spec.click(meh, mouseX, mouseY, area) {
+		return true
+	}
+	if mouseY == area.Y+area.Height-2 {
+		if spec.back != nil {
+			spec.back(meh)
+		} else {
+			meh.state.PopModal()
+		}
+	}
+	return true
+}
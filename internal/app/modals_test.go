@@ -0,0 +1,118 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+// moonFixture opens Earth's details, its moons and then the Moon's details
+func moonFixture(t *testing.T) (*EventDispatcher, *AppState) {
+	t.Helper()
+	dispatcher, state, _ := newAppFixture(t, 120, 40)
+	earth := state.GetPlanets()[3]
+	moon := models.CelestialBody{ID: "lune", EnglishName: "Moon", BodyType: "Moon", MeanRadius: 1737}
+	earth.Moons = []models.Moon{{EnglishName: "Moon", Body: &moon}}
+	state.ShowPlanetDetails(earth, 3)
+	state.ShowMoonList()
+	state.ShowMoonDetails(moon)
+	state.Publish()
+	return dispatcher, state
+}
+
+func TestModalStackGoesBackOneAtATime(t *testing.T) {
+	want := []Modal{ModalMoons, ModalDetails, ModalNone}
+
+	t.Run("escape", func(t *testing.T) {
+		dispatcher, state := moonFixture(t)
+		for _, modal := range want {
+			dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+			if state.TopModal() != modal {
+				t.Fatalf("TopModal() = %v after Escape, want %v", state.TopModal(), modal)
+			}
+		}
+	})
+
+	t.Run("instruction row", func(t *testing.T) {
+		dispatcher, state := moonFixture(t)
+		for _, modal := range want {
+			area := dispatcher.uiRenderer.modalArea(state, state.TopModal())
+			click(dispatcher, area.X+2, area.Y+area.Height-2)
+			if state.TopModal() != modal {
+				t.Fatalf("TopModal() = %v after clicking the instruction row, want %v", state.TopModal(), modal)
+			}
+		}
+	})
+}
+
+func TestOpenAndPushModal(t *testing.T) {
+	state := NewAppState()
+	state.PushModal(ModalDetails)
+	state.PushModal(ModalMoons)
+	state.PushModal(ModalMoons)
+	state.PopModal()
+	if state.TopModal() != ModalDetails {
+		t.Errorf("TopModal() = %v, want the details under the one moon list pushed", state.TopModal())
+	}
+
+	state.PushModal(ModalMoons)
+	state.OpenModal(ModalHelp)
+	if state.TopModal() != ModalHelp {
+		t.Fatalf("TopModal() = %v, want help", state.TopModal())
+	}
+	state.PopModal()
+	if state.IsAnyModalShowing() {
+		t.Errorf("TopModal() = %v after closing help, want the stack cleared by OpenModal", state.TopModal())
+	}
+
+	state.PushModal(ModalDetails)
+	state.CloseModal(ModalMoons)
+	if state.TopModal() != ModalDetails {
+		t.Errorf("CloseModal(moons) closed %v, which was not showing", ModalDetails)
+	}
+	state.PopModal()
+	state.PopModal()
+	if state.TopModal() != ModalNone {
+		t.Errorf("TopModal() = %v, want none after popping an empty stack", state.TopModal())
+	}
+}
+
+func TestClickOutsideModal(t *testing.T) {
+	tests := []struct {
+		modal   Modal
+		reaches bool
+	}{
+		{ModalDetails, true},
+		{ModalSystemList, true},
+		{ModalElementEditor, false},
+		{ModalQuiz, false},
+	}
+	for _, tt := range tests {
+		dispatcher, state, _ := newAppFixture(t, 160, 50)
+		dispatcher.uiRenderer.DrawScreen()
+		state.UpdatePlanetSelection(3, state.GetPlanets()[3])
+		state.PushModal(tt.modal)
+
+		// A body drawn clear of the modal
+		area := dispatcher.uiRenderer.modalArea(state, tt.modal)
+		var target models.CelestialBody
+		var x, y int
+		for name, pos := range state.GetPlanetPositions() {
+			if name != "Earth" && !area.Contains(pos.X, pos.Y) {
+				target, x, y = pos.Planet, pos.X, pos.Y
+				break
+			}
+		}
+		if target.EnglishName == "" {
+			t.Fatalf("%v covers every body", tt.modal)
+		}
+
+		click(dispatcher, x, y)
+		reached := state.SelectedPlanet.EnglishName == target.EnglishName
+		if reached != tt.reaches || state.TopModal() != tt.modal {
+			t.Errorf("click on %s outside %v: selected %s with %v on top, want it reaching the map %v",
+				target.EnglishName, tt.modal, state.SelectedPlanet.EnglishName, state.TopModal(), tt.reaches)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/furan917/go-solar-system/internal/api"
+	"github.com/furan917/go-solar-system/internal/logging"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/visualization"
+)
+
+// moonHydrationWorkers bounds how many moon lookups run at once
+const moonHydrationWorkers = 4
+
+// moonHydrator fetches real names for the selected planet's moons in the background
+// while its details or moon list are open, so the list shows Io and Europa rather
+// than ids. It runs as a frame hook; the fetches run as background tasks.
+type moonHydrator struct {
+	client     *api.Client
+	moons      *visualization.MoonHandler
+	background *background
+	logger     *logging.Logger
+
+	planet string // planet being hydrated, empty when idle
+	cancel context.CancelFunc
+}
+
+func newMoonHydrator(client *api.Client, moons *visualization.MoonHandler, tasks *background, logger *logging.Logger) *moonHydrator {
+	return &moonHydrator{
+		client:     client,
+		moons:      moons,
+		background: tasks,
+		logger:     logger,
+	}
+}
+
+// onFrame starts naming the moons of the body whose details or moons are open,
+// and stops once they are closed or another body is chosen
+func (h *moonHydrator) onFrame(frame Frame) bool {
+	open := frame.State.IsShowingDetails() || frame.State.IsShowingMoons() || frame.State.IsShowingMoonDetails()
+	switch {
+	case !open:
+		h.stop()
+	case frame.Selected.EnglishName != h.planet:
+		h.stop()
+		h.start(frame.Selected)
+	}
+	return true
+}
+
+// start looks up every moon of the planet that was listed without a name
+func (h *moonHydrator) start(planet models.CelestialBody) {
+	h.planet = planet.EnglishName
+
+	var ids []string
+	for _, moon := range planet.Moons {
+		if moon.EnglishName != "" {
+			continue
+		}
+		if _, ok := h.moons.ResolvedName(moon); ok {
+			continue
+		}
+		if id := h.moons.MoonID(moon); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(h.background.Context())
+	h.cancel = cancel
+	h.logger.Debugf("Fetching names for %d moons of %s", len(ids), planet.EnglishName)
+
+	jobs := make(chan string)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failed   int
+		firstErr error
+	)
+	for i := 0; i < min(moonHydrationWorkers, len(ids)); i++ {
+		wg.Add(1)
+		h.background.Go("moon names", func(context.Context) error {
+			defer wg.Done()
+			for id := range jobs {
+				if err := h.fetch(ctx, id); err != nil {
+					mu.Lock()
+					failed++
+					firstErr = cmp.Or(firstErr, err)
+					mu.Unlock()
+				}
+			}
+			return nil
+		})
+	}
+
+	h.background.Go("moon names", func(context.Context) error {
+		defer close(jobs)
+		for _, id := range ids {
+			select {
+			case jobs <- id:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		return nil
+	})
+
+	// One report for the lot, so an outage does not queue a failure per moon
+	name := planet.EnglishName
+	h.background.Go("moon names", func(context.Context) error {
+		wg.Wait()
+		defer cancel()
+		if failed == 0 || ctx.Err() != nil {
+			return nil
+		}
+		return NewNetworkError(fmt.Sprintf("could not name %d moons of %s", failed, name), firstErr)
+	})
+}
+
+// fetch resolves one moon. A lookup already under way when the modal closes still
+// finishes, since the client cannot abandon a request, but its name is kept.
+func (h *moonHydrator) fetch(ctx context.Context, id string) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	moon, err := h.client.GetMoonData(id)
+	if err != nil {
+		h.logger.Debugf("Could not fetch moon %s: %v", id, err)
+		return err
+	}
+	if moon.EnglishName != "" {
+		h.moons.SetResolvedName(id, moon.EnglishName)
+	}
+	return nil
+}
+
+// stop cancels any lookups that have not started yet
+func (h *moonHydrator) stop() {
+	if h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+	h.planet = ""
+}
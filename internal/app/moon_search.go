@@ -0,0 +1,75 @@
+package app
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// typeMoonSearch adds a typed character to the moon search and jumps to the
+// first moon it finds
+func (ed *EventDispatcher) typeMoonSearch(r rune) {
+	if !unicode.IsPrint(r) || (r == ' ' && ed.state.MoonSearch == "") {
+		return
+	}
+	ed.state.MoonSearch += string(r)
+	ed.jumpToMoonSearch()
+}
+
+// eraseMoonSearch removes the last character of the moon search
+func (ed *EventDispatcher) eraseMoonSearch() {
+	search := []rune(ed.state.MoonSearch)
+	if len(search) == 0 {
+		return
+	}
+	ed.state.MoonSearch = string(search[:len(search)-1])
+	if ed.state.MoonSearch != "" {
+		ed.jumpToMoonSearch()
+	}
+}
+
+// jumpToMoonSearch selects the first moon matching the search; with no match the
+// selection stays where it is
+func (ed *EventDispatcher) jumpToMoonSearch() {
+	names := ed.uiRenderer.moonSearchNames(ed.state.SelectedPlanet)
+	if index, ok := findMoon(names, ed.state.MoonSearch); ok {
+		ed.state.SelectMoon(index, len(ed.state.SelectedPlanet.Moons))
+	}
+}
+
+// moonSearchNames returns, for each moon in the list, the name it is listed by
+// followed by its names in the API, so a search finds it by any of them
+func (ur *UIRenderer) moonSearchNames(planet models.CelestialBody) [][]string {
+	listed := ur.renderer.GetMoonHandler().GetMoonNames(planet)
+	names := make([][]string, len(listed))
+	for i, name := range listed {
+		names[i] = []string{name}
+		if i < len(planet.Moons) {
+			names[i] = append(names[i], planet.Moons[i].Name, planet.Moons[i].EnglishName)
+		}
+	}
+	return names
+}
+
+// findMoon returns the first moon with a name starting with the search, ignoring
+// case, or failing that the first with a name containing it
+func findMoon(names [][]string, search string) (int, bool) {
+	search = strings.ToLower(search)
+	if search == "" {
+		return 0, false
+	}
+	for _, matches := range []func(name string) bool{
+		func(name string) bool { return strings.HasPrefix(strings.ToLower(name), search) },
+		func(name string) bool { return strings.Contains(strings.ToLower(name), search) },
+	} {
+		for i, moonNames := range names {
+			for _, name := range moonNames {
+				if name != "" && matches(name) {
+					return i, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
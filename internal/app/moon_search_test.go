@@ -0,0 +1,127 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFindMoon(t *testing.T) {
+	names := [][]string{
+		{"Io", "Io"},
+		{"Ganymede", "Ganymède"},
+		{"Europa", "Europe"},
+		{"Callisto", "Callisto"},
+		{"Amalthea", "Amalthée"},
+	}
+	tests := []struct {
+		search string
+		want   int
+		found  bool
+	}{
+		{"eu", 2, true},
+		{"EU", 2, true},
+		{"a", 4, true},      // Amalthea starts with it, before Ganymede containing it
+		{"lis", 3, true},    // only inside a name
+		{"europe", 2, true}, // the API's name
+		{"mède", 1, true},
+		{"titan", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		if got, found := findMoon(names, tt.search); got != tt.want || found != tt.found {
+			t.Errorf("findMoon(%q) = %d, %v; want %d, %v", tt.search, got, found, tt.want, tt.found)
+		}
+	}
+}
+
+// jupiterFixture opens the moon list of a Jupiter with the Galilean moons and
+// enough others to page through
+func jupiterFixture(t *testing.T) (*EventDispatcher, *AppState) {
+	t.Helper()
+	dispatcher, state, _ := newAppFixture(t, 120, 40)
+	jupiter := state.GetPlanets()[5]
+	jupiter.Moons = []models.Moon{
+		{EnglishName: "Io"}, {EnglishName: "Europa", Name: "Europe"}, {EnglishName: "Ganymede"}, {EnglishName: "Callisto"},
+	}
+	for i := len(jupiter.Moons); i < 25; i++ {
+		jupiter.Moons = append(jupiter.Moons, models.Moon{EnglishName: fmt.Sprintf("S/2003 J %d", i)})
+	}
+	state.ShowPlanetDetails(jupiter, 5)
+	state.ShowMoonList()
+	return dispatcher, state
+}
+
+func TestMoonListTypeAhead(t *testing.T) {
+	dispatcher, state := jupiterFixture(t)
+	typeRune := func(r rune) {
+		dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+	backspace := func() {
+		dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone))
+	}
+
+	typeRune(' ')
+	if state.MoonSearch != "" {
+		t.Errorf("MoonSearch = %q, want a leading space ignored", state.MoonSearch)
+	}
+	typeRune('e')
+	typeRune('u')
+	if state.MoonSearch != "eu" || state.MoonSelectedIndex != 1 {
+		t.Fatalf("search %q selected %d, want Europa at 1", state.MoonSearch, state.MoonSelectedIndex)
+	}
+
+	// Erasing a one-character search empties it and keeps the selection
+	backspace()
+	if state.MoonSearch != "e" || state.MoonSelectedIndex != 1 {
+		t.Errorf("search %q selected %d, want e on Europa", state.MoonSearch, state.MoonSelectedIndex)
+	}
+	backspace()
+	if state.MoonSearch != "" || state.MoonSelectedIndex != 1 {
+		t.Errorf("search %q selected %d, want it emptied with Europa still selected", state.MoonSearch, state.MoonSelectedIndex)
+	}
+	backspace()
+	if state.MoonSearch != "" || state.TopModal() != ModalMoons {
+		t.Errorf("backspace on an empty search left %q with %v on top", state.MoonSearch, state.TopModal())
+	}
+
+	typeRune('c')
+	if state.MoonSelectedIndex != 3 {
+		t.Errorf("search c selected %d, want Callisto at 3", state.MoonSelectedIndex)
+	}
+	typeRune('x')
+	if state.MoonSearch != "cx" || state.MoonSelectedIndex != 3 {
+		t.Errorf("search %q selected %d, want Callisto kept with no match", state.MoonSearch, state.MoonSelectedIndex)
+	}
+
+	// Escape clears the search before it closes the list
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+	if state.MoonSearch != "" || state.TopModal() != ModalMoons {
+		t.Errorf("Escape left %q with %v on top, want the search cleared and the list open", state.MoonSearch, state.TopModal())
+	}
+}
+
+func TestMoonListPageKeys(t *testing.T) {
+	dispatcher, state := jupiterFixture(t)
+	tests := []struct {
+		key           tcell.Key
+		selected, top int
+	}{
+		{tcell.KeyPgDn, 10, 1},
+		{tcell.KeyPgDn, 20, 11},
+		{tcell.KeyPgDn, 24, 15},
+		{tcell.KeyPgUp, 14, 14},
+		{tcell.KeyHome, 0, 0},
+		{tcell.KeyPgUp, 0, 0},
+		{tcell.KeyEnd, 24, 15},
+	}
+	for _, tt := range tests {
+		dispatcher.HandleEvent(tcell.NewEventKey(tt.key, 0, tcell.ModNone))
+		if state.MoonSelectedIndex != tt.selected || state.MoonScrollIndex != tt.top {
+			t.Errorf("%s selected %d scrolled to %d, want %d and %d",
+				tcell.KeyNames[tt.key], state.MoonSelectedIndex, state.MoonScrollIndex, tt.selected, tt.top)
+		}
+	}
+}
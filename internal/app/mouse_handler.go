@@ -1,290 +1,238 @@
 package app
 
 import (
-    "math"
-    "strings"
-
-    "github.com/furan917/go-solar-system/internal/models"
-    "github.com/gdamore/tcell/v2"
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/gdamore/tcell/v2"
 )
 
 type MouseEventHandler struct {
-    state           *AppState
-    renderer        *UIRenderer
-    showMoonList    func()
-    showMoonDetails func()
-    planetService   *PlanetService
-    systemManager   *SystemManager
+	state             *AppState
+	renderer          *UIRenderer
+	showMoonList      func()
+	showMoonDetails   func()
+	openElementEditor func()
+	planetService     *PlanetService
+	systemManager     *SystemManager
+
+	// buttonHeld is whether the left button was down at the last mouse event, so
+	// a press can be told apart from a drag
+	buttonHeld bool
+
+	// buttonsDown are the buttons down at the last click, so a button held while
+	// the mouse moves is still one press
+	buttonsDown tcell.ButtonMask
+
+	// lastClick is the last click that selected a body, for double clicks
+	lastClick bodyClick
+
+	// dispatcher runs what is picked from the context menu
+	dispatcher *EventDispatcher
 }
 
-func NewMouseEventHandler(state *AppState, renderer *UIRenderer, showMoonList, showMoonDetails func(), planetService *PlanetService, systemManager *SystemManager) *MouseEventHandler {
-    return &MouseEventHandler{
-        state:           state,
-        renderer:        renderer,
-        showMoonList:    showMoonList,
-        showMoonDetails: showMoonDetails,
-        planetService:   planetService,
-        systemManager:   systemManager,
-    }
+func NewMouseEventHandler(state *AppState, renderer *UIRenderer, showMoonList, showMoonDetails, openElementEditor func(), planetService *PlanetService, systemManager *SystemManager) *MouseEventHandler {
+	return &MouseEventHandler{
+		state:             state,
+		renderer:          renderer,
+		showMoonList:      showMoonList,
+		showMoonDetails:   showMoonDetails,
+		openElementEditor: openElementEditor,
+		planetService:     planetService,
+		systemManager:     systemManager,
+	}
 }
 
+// HandleClick answers a button going down: a left click selects what is under
+// the pointer (a second one on the same body shows its details) and a right
+// click opens the context menu of the body under it
 func (meh *MouseEventHandler) HandleClick(ev *tcell.EventMouse) {
-    if ev.Buttons() != tcell.Button1 {
-        return
-    }
-
-    mouseX, mouseY := ev.Position()
-
-    if meh.handleInstructionBarClick(mouseX, mouseY) {
-        return
-    }
-
-    if meh.handlePlanetListClick(mouseX, mouseY) {
-        return
-    }
-
-    switch {
-    case meh.state.ShowingMoonDetails:
-        if meh.handleMoonDetailsModalClick(mouseX, mouseY) {
-            return
-        }
-    case meh.state.ShowingMoons:
-        if meh.handleMoonListModalClick(mouseX, mouseY) {
-            return
-        }
-    case meh.state.ShowingSystemList:
-        if meh.handleSystemListModalClick(mouseX, mouseY) {
-            return
-        }
-    case meh.state.ShowingDetails:
-        if meh.handlePlanetDetailsModalClick(mouseX, mouseY) {
-            return
-        }
-    default:
-        //
-    }
-
-    if meh.renderer.IsClickInModalArea(mouseX, mouseY) {
-        return
-    }
-
-    for name, pos := range meh.state.GetPlanetPositions() {
-        dx := float64(mouseX - pos.X)
-        dy := float64(mouseY - pos.Y)
-        distance := math.Sqrt(dx*dx + dy*dy)
-
-        clickRadius := float64(pos.Radius + 2)
-        if distance <= clickRadius {
-            meh.state.SelectedPlanet = pos.Planet
-
-            for i, planet := range meh.state.GetPlanets() {
-                if planet.EnglishName == name {
-                    meh.state.SelectedIndex = i
-                    break
-                }
-            }
-
-            if !meh.state.ShowingDetails && !meh.state.ShowingMoons && !meh.state.ShowingMoonDetails && !meh.state.ShowingSystemList {
-                meh.state.ShowingDetails = true
-            } else if meh.state.ShowingDetails {
-            }
-            return
-        }
-    }
-}
-
-func (meh *MouseEventHandler) handleInstructionBarClick(mouseX, mouseY int) bool {
-    _, screenHeight := meh.renderer.screen.Size()
-    instructionY := screenHeight - 2
-
-    if mouseY != instructionY {
-        return false
-    }
-
-    instructions := "Arrow keys to navigate • Enter/Click to select • S for systems • Q to quit • 1-9 for direct selection"
-
-    sPos := strings.Index(instructions, "S for systems")
-    if sPos >= 0 && mouseX >= 2+sPos && mouseX <= 2+sPos+13 {
-        meh.state.ShowingSystemList = true
-        meh.state.ShowingDetails = false
-        meh.state.ShowingMoons = false
-        meh.state.ShowingMoonDetails = false
-        return true
-    }
-
-    qPos := strings.Index(instructions, "Q to quit")
-    if qPos >= 0 && mouseX >= 2+qPos && mouseX <= 2+qPos+8 {
-        meh.state.SetRunning(false)
-        return true
-    }
-
-    return false
+	buttons := ev.Buttons() & (tcell.Button1 | tcell.Button2)
+	pressed := buttons != 0 && buttons != meh.buttonsDown
+	meh.buttonsDown = buttons
+	if !pressed {
+		return
+	}
+
+	mouseX, mouseY := ev.Position()
+	if buttons == tcell.Button2 {
+		meh.handleRightClick(mouseX, mouseY)
+		return
+	}
+	if buttons != tcell.Button1 {
+		return
+	}
+
+	if meh.handleInstructionBarClick(mouseX, mouseY) {
+		return
+	}
+
+	if meh.handleModalClick(mouseX, mouseY) {
+		return
+	}
+
+	if meh.handleListTabClick(mouseX, mouseY) {
+		return
+	}
+
+	if meh.handlePlanetListClick(mouseX, mouseY) {
+		meh.openOnDoubleClick(ev.When())
+		return
+	}
+
+	if meh.handleOverviewClick(mouseX, mouseY) {
+		return
+	}
+
+	if meh.handleMapClick(mouseX, mouseY) {
+		meh.openOnDoubleClick(ev.When())
+		return
+	}
+	meh.lastClick = bodyClick{}
 }
 
-func (meh *MouseEventHandler) handleMoonDetailsModalClick(mouseX, mouseY int) bool {
-    screenWidth, screenHeight := meh.renderer.screen.Size()
-    contentLines := meh.renderer.calculateMoonDetailsLines(meh.state.SelectedMoon)
-    dynamicHeight := minimum(contentLines+6, screenHeight-4)
-    modalX, modalY, modalWidth, modalHeight := meh.renderer.GetModalDimensions(screenWidth, screenHeight, dynamicHeight)
+// handleMapClick selects the body drawn under the pointer
+func (meh *MouseEventHandler) handleMapClick(mouseX, mouseY int) bool {
+	clicked, ok := planetAt(meh.state.GetPlanetPositions(), mouseX, mouseY)
+	if !ok {
+		return false
+	}
 
-    if mouseX < modalX || mouseX >= modalX+modalWidth || mouseY < modalY || mouseY >= modalY+modalHeight {
-        return false
-    }
-
-    instructionY := modalY + modalHeight - 2
-    if mouseY == instructionY {
-        meh.state.ShowingMoonDetails = false
-        meh.state.ShowingMoons = true
-        return true
-    }
-
-    return true
+	meh.state.SelectOnMap(clicked)
+	return true
 }
 
-func (meh *MouseEventHandler) handleMoonListModalClick(mouseX, mouseY int) bool {
-    screenWidth, screenHeight := meh.renderer.screen.Size()
-    modalX, modalY, modalWidth, modalHeight := meh.renderer.GetModalDimensions(screenWidth, screenHeight)
-
-    if mouseX < modalX || mouseX >= modalX+modalWidth || mouseY < modalY || mouseY >= modalY+modalHeight {
-        return false
-    }
-
-    moonListStartY := modalY + 3
-    maxVisibleMoons := 10
-
-    if mouseY >= moonListStartY && mouseY < moonListStartY+maxVisibleMoons {
-        moonIndex := meh.state.MoonScrollIndex + (mouseY - moonListStartY)
-        if moonIndex < len(meh.state.SelectedPlanet.Moons) {
-            meh.state.MoonSelectedIndex = moonIndex
-            meh.showMoonDetailsInternal()
-            return true
-        }
-    }
-
-    instructionY := modalY + modalHeight - 2
-    if mouseY == instructionY {
-        meh.state.ShowingMoons = false
-        meh.state.ShowingDetails = true
-        return true
-    }
-
-    return true
+func (meh *MouseEventHandler) handleInstructionBarClick(mouseX, mouseY int) bool {
+	_, screenHeight := meh.renderer.screen.Size()
+	instructionY := screenHeight - 2
+
+	if mouseY != instructionY {
+		return false
+	}
+
+	instructions := meh.renderer.MainInstructions()
+	systems, help, quit := meh.renderer.mainInstructionParts()
+
+	sPos := textColumn(instructions, systems)
+	if sPos >= 0 && mouseX >= 2+sPos && mouseX < 2+sPos+ui.TextWidth(systems) {
+		meh.state.ShowSystemList()
+		meh.state.PickingComparison = false
+		return true
+	}
+
+	hPos := textColumn(instructions, help)
+	if hPos >= 0 && mouseX >= 2+hPos && mouseX < 2+hPos+ui.TextWidth(help) {
+		meh.state.ShowHelp()
+		return true
+	}
+
+	qPos := textColumn(instructions, quit)
+	if qPos >= 0 && mouseX >= 2+qPos && mouseX < 2+qPos+ui.TextWidth(quit) {
+		meh.state.SetRunning(false)
+		return true
+	}
+
+	return false
 }
 
-func (meh *MouseEventHandler) handleSystemListModalClick(mouseX, mouseY int) bool {
-    screenWidth, screenHeight := meh.renderer.screen.Size()
-    modalX, modalY, modalWidth, modalHeight := meh.renderer.GetModalDimensions(screenWidth, screenHeight)
+func (meh *MouseEventHandler) handleMoonListModalClick(mouseX, mouseY int, area layout.Rect) bool {
+	moonListStartY := area.Y + 3
+	maxVisibleMoons := 10
 
-    if mouseX < modalX || mouseX >= modalX+modalWidth || mouseY < modalY || mouseY >= modalY+modalHeight {
-        return false
-    }
+	if mouseY >= moonListStartY && mouseY < moonListStartY+maxVisibleMoons {
+		moonIndex := meh.state.MoonScrollIndex + (mouseY - moonListStartY)
+		if moonIndex < len(meh.state.SelectedPlanet.Moons) {
+			meh.state.MoonSelectedIndex = moonIndex
+			meh.showMoonDetailsInternal()
+			return true
+		}
+	}
 
-    systemListStartY := modalY + 3
-    maxVisibleSystems := 12
+	return false
+}
 
-    if mouseY >= systemListStartY && mouseY < systemListStartY+maxVisibleSystems {
-        systemIndex := meh.state.SystemScrollIndex + (mouseY - systemListStartY)
-        availableSystems := meh.renderer.GetSystemManager().GetAvailableSystems()
+func (meh *MouseEventHandler) handleSystemListModalClick(mouseX, mouseY int, area layout.Rect) bool {
+	systemListStartY := area.Y + 3
+	maxVisibleSystems := 12
 
-        if systemIndex < len(availableSystems) {
-            meh.state.SystemSelectedIndex = systemIndex
-            meh.systemManager.SwitchToSelectedSystem()
-            return true
-        }
-    }
+	if mouseY >= systemListStartY && mouseY < systemListStartY+maxVisibleSystems {
+		systemIndex := meh.state.SystemScrollIndex + (mouseY - systemListStartY)
+		availableSystems := meh.renderer.GetSystemManager().GetAvailableSystems()
 
-    instructionY := modalY + modalHeight - 2
-    if mouseY == instructionY {
-        meh.state.ShowingSystemList = false
-        return true
-    }
+		if systemIndex < len(availableSystems) {
+			meh.state.SystemSelectedIndex = systemIndex
+			meh.systemManager.OpenSelectedSystem()
+			return true
+		}
+	}
 
-    return true
+	return false
 }
 
-func (meh *MouseEventHandler) handlePlanetDetailsModalClick(mouseX, mouseY int) bool {
-    screenWidth, screenHeight := meh.renderer.screen.Size()
-    contentLines := meh.renderer.calculatePlanetDetailsLines(meh.state.SelectedPlanet)
-    dynamicHeight := minimum(contentLines+6, screenHeight-4)
-    modalX, modalY, modalWidth, modalHeight := meh.renderer.GetModalDimensions(screenWidth, screenHeight, dynamicHeight)
-
-    if mouseX < modalX || mouseX >= modalX+modalWidth || mouseY < modalY || mouseY >= modalY+modalHeight {
-        return false
-    }
-
-    instructionY := modalY + modalHeight - 2
-    if mouseY == instructionY && len(meh.state.SelectedPlanet.Moons) > 0 {
-        instruction := "Press Enter, Escape, or 'b' to close • 'm' for moons"
-        mPos := strings.Index(instruction, "'m' for moons")
-        if mPos >= 0 && mouseX >= modalX+2+mPos && mouseX <= modalX+2+mPos+12 {
-            meh.showMoonList()
-            return true
-        }
-    }
-
-    if mouseY == instructionY {
-        meh.state.ShowingDetails = false
-        return true
-    }
-
-    return true
+func (meh *MouseEventHandler) handlePlanetDetailsModalClick(mouseX, mouseY int, area layout.Rect) bool {
+	instructionY := area.Y + area.Height - 2
+	if mouseY == instructionY && len(meh.state.SelectedPlanet.Moons) > 0 {
+		instruction := "Press Enter, Escape, or 'b' to close • 'm' for moons"
+		mPos := textColumn(instruction, "'m' for moons")
+		if mPos >= 0 && mouseX >= area.X+2+mPos && mouseX <= area.X+2+mPos+12 {
+			meh.showMoonList()
+			return true
+		}
+	}
+
+	if mouseY == instructionY && canEditOrbitalElements(meh.state.SelectedPlanet, meh.renderer.GetSystemManager().GetCurrentSystem()) {
+		instruction := "Press Enter, Escape, or 'b' to close"
+		if len(meh.state.SelectedPlanet.Moons) > 0 {
+			instruction += " • 'm' for moons"
+		}
+		instruction += " • 'e' orbit"
+		ePos := ui.TextWidth(instruction) - ui.TextWidth("'e' orbit")
+		if mouseX >= area.X+2+ePos && mouseX <= area.X+2+ePos+8 {
+			meh.openElementEditor()
+			return true
+		}
+	}
+
+	return false
 }
 
-func (meh *MouseEventHandler) handlePlanetListClick(mouseX, mouseY int) bool {
-    for _, pos := range meh.state.GetPlanetListPositions() {
-        if mouseX >= pos.X && mouseX < pos.X+pos.Width && mouseY == pos.Y {
-            meh.state.SelectedIndex = pos.Index
-            meh.state.SelectedPlanet = meh.state.GetPlanets()[pos.Index]
+func (meh *MouseEventHandler) handleElementEditorModalClick(mouseX, mouseY int, area layout.Rect) bool {
+	fieldStartY := area.Y + 3
+	if mouseY >= fieldStartY && mouseY < fieldStartY+len(getElementFields()) {
+		meh.state.ElementFieldIndex = mouseY - fieldStartY
+		return true
+	}
 
-            if !meh.state.ShowingDetails && !meh.state.ShowingMoons && !meh.state.ShowingMoonDetails && !meh.state.ShowingSystemList {
-                meh.state.ShowingDetails = true
-            } else if meh.state.ShowingDetails {
-            }
+	return false
+}
 
-            return true
-        }
-    }
+func (meh *MouseEventHandler) handlePlanetListClick(mouseX, mouseY int) bool {
+	index, ok := listItemAt(meh.state.GetPlanetListPositions(), mouseX, mouseY)
+	if !ok {
+		return false
+	}
 
-    return false
+	return meh.state.SelectListed(index)
 }
 
 func (meh *MouseEventHandler) showMoonDetailsInternal() {
-    if meh.state.MoonSelectedIndex < len(meh.state.SelectedPlanet.Moons) {
-        moonData := meh.state.SelectedPlanet.Moons[meh.state.MoonSelectedIndex]
-        moonHandler := meh.renderer.GetRenderer().GetMoonHandler()
-        moonName := moonHandler.GetMoonNameFromAPI(moonData)
-
-        if moonData.ID != "" {
-            if moonDetail, err := meh.planetService.GetClient().GetMoonData(moonData.ID); err == nil {
-                meh.state.SelectedMoon = *moonDetail
-                meh.state.SelectedMoon.BodyType = "Moon"
-                meh.state.SelectedMoon.AroundPlanet = &models.Planet{
-                    EnglishName: meh.state.SelectedPlanet.EnglishName,
-                }
-            } else {
-                meh.state.SelectedMoon = models.CelestialBody{
-                    ID:          moonData.ID,
-                    Name:        moonData.Name,
-                    EnglishName: moonName,
-                    BodyType:    "Moon",
-                    AroundPlanet: &models.Planet{
-                        EnglishName: meh.state.SelectedPlanet.EnglishName,
-                    },
-                }
-            }
-        } else {
-            meh.state.SelectedMoon = models.CelestialBody{
-                ID:          moonData.ID,
-                Name:        moonData.Name,
-                EnglishName: moonName,
-                BodyType:    "Moon",
-                AroundPlanet: &models.Planet{
-                    EnglishName: meh.state.SelectedPlanet.EnglishName,
-                },
-            }
-        }
-
-        meh.state.ShowingMoonDetails = true
-        meh.state.ShowingMoons = false
-    }
+	if meh.state.MoonSelectedIndex < len(meh.state.SelectedPlanet.Moons) {
+		moonData := meh.state.SelectedPlanet.Moons[meh.state.MoonSelectedIndex]
+		moonHandler := meh.renderer.GetRenderer().GetMoonHandler()
+		systemName := meh.renderer.GetSystemManager().GetCurrentSystem()
+
+		meh.state.SelectedMoon = meh.planetService.MoonDetails(systemName, meh.state.SelectedPlanet, moonData, moonHandler)
+		meh.state.ShowMoonDetails(moonHandler.EnrichMoon(meh.state.SelectedMoon))
+	}
+}
+
+func (meh *MouseEventHandler) handleQuizModalClick(mouseX, mouseY int, area layout.Rect) bool {
+	optionStartY := meh.renderer.quizOptionsStartY(meh.state.QuizQuestion, area.Y)
+	optionCount := len(meh.state.QuizQuestion.Options)
+	if !meh.state.QuizAnswered && mouseY >= optionStartY && mouseY < optionStartY+optionCount {
+		meh.state.QuizSelected = mouseY - optionStartY
+		meh.state.AnswerQuiz(meh.state.QuizSelected)
+		return true
+	}
+
+	return false
 }
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+)
+
+// speedDetail is the line of a body's details giving its orbital speed where it
+// is at the simulated date, and the range it swings through over an orbit, or ""
+// if its orbit is not known well enough
+func (ur *UIRenderer) speedDetail(body models.CelestialBody) string {
+	speed, r, ok := ur.renderer.GetEphemeris().SpeedAt(body, ur.renderer.GetClock().Now())
+	if !ok {
+		return ""
+	}
+
+	detail := fmt.Sprintf("Orbital Speed Now: %.2f km/s at %s km", speed, formatCount(int(math.Round(r))))
+	if e := orbital.OrbitEccentricity(body); e > 0 {
+		mu, _ := orbital.OrbitParameter(body)
+		a := body.SemimajorAxis
+		detail += fmt.Sprintf(" (%.2f at periapsis, %.2f at apoapsis)",
+			orbital.OrbitalSpeed(mu, a*(1-e), a), orbital.OrbitalSpeed(mu, a*(1+e), a))
+	}
+	return detail
+}
+
+// speedDetailLines is how many lines the orbital speed takes in a body's details
+func (ur *UIRenderer) speedDetailLines(body models.CelestialBody, maxWidth int) int {
+	detail := ur.speedDetail(body)
+	if detail == "" {
+		return 0
+	}
+	return len(ur.wrapText(detail, maxWidth))
+}
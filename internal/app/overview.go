@@ -0,0 +1,130 @@
+package app
+
+import (
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// Smallest map the overview inset is drawn on, so it never crowds out the
+	// frame it summarises
+	minOverviewMapWidth  = 60
+	minOverviewMapHeight = 20
+
+	// Smallest inset, border included
+	minOverviewWidth  = 28
+	minOverviewHeight = 11
+)
+
+// overviewArea is where the overview inset goes on a map region: its bottom-right
+// corner, a quarter of the width and two fifths of the height. It is empty when the
+// map is too small to spare the room.
+func overviewArea(region layout.Rect) layout.Rect {
+	if region.Width < minOverviewMapWidth || region.Height < minOverviewMapHeight {
+		return layout.Rect{}
+	}
+	width := max(region.Width/4, minOverviewWidth)
+	height := max(region.Height*2/5, minOverviewHeight)
+	return layout.Rect{X: region.X + region.Width - width, Y: region.Y + region.Height - height, Width: width, Height: height}
+}
+
+// drawOverview insets the whole system in a corner of the centred map, drawn by
+// a renderer of its own on the same clock, with a box round the body the map is
+// centred on marking the part the map shows
+func (ur *UIRenderer) drawOverview(region layout.Rect) {
+	area := overviewArea(region)
+	if area.Empty() {
+		ur.state.SetOverview(layout.Rect{}, nil)
+		return
+	}
+	inner := layout.Rect{X: area.X + 1, Y: area.Y + 1, Width: area.Width - 2, Height: area.Height - 2}
+
+	overview := ur.overviewRenderer
+	if overview == nil {
+		width, height := ur.screen.Size()
+		overview = visualization.NewRendererWithDefaults(width, height)
+		overview.ShareTimeline(ur.renderer)
+		overview.SetSymbols(ur.renderer.GetSymbols())
+		overview.SetBeltVisibility(visualization.BeltsHidden)
+		overview.SetMiniature(true)
+		ur.overviewRenderer = overview
+	}
+	aspect := ur.renderer.GetAspectRatio()
+	overview.SetAspectRatio(aspect)
+	overview.SetRenderMode(ur.renderer.GetRenderMode())
+	overview.SetPalette(ur.renderer.GetPalette())
+	overview.UpdateDimensions(int(float64(inner.Width)/aspect), inner.Height)
+	grid, positions := overview.RenderSolarSystemDataWithPositions(ur.state.GetPlanets(), inner.Width, inner.Height, inner.Width, inner.Height)
+
+	background := tcell.StyleDefault.Background(tcell.ColorBlack)
+	for y := area.Y; y < area.Y+area.Height; y++ {
+		for x := area.X; x < area.X+area.Width; x++ {
+			ur.screen.SetContent(x, y, ' ', nil, background)
+		}
+	}
+	ur.drawGrid(overview, grid, inner.X, inner.Y, inner.Width, inner.Height)
+	ur.drawOverviewBorder(area)
+
+	if pos, ok := positions[ur.state.FocusBody.EnglishName]; ok {
+		marker := tcell.StyleDefault.Foreground(tcell.ColorYellow)
+		left, right := inner.X+pos.X-2, inner.X+pos.X+2
+		top, bottom := inner.Y+pos.Y-1, inner.Y+pos.Y+1
+		for _, corner := range []struct {
+			x, y  int
+			glyph rune
+		}{{left, top, '┌'}, {right, top, '┐'}, {left, bottom, '└'}, {right, bottom, '┘'}} {
+			if inner.Contains(corner.x, corner.y) {
+				ur.screen.SetContent(corner.x, corner.y, corner.glyph, nil, marker)
+			}
+		}
+	}
+
+	shifted := make(map[string]visualization.PlanetPosition, len(positions))
+	for name, pos := range positions {
+		pos.X += inner.X
+		pos.Y += inner.Y
+		shifted[name] = pos
+	}
+	ur.state.SetOverview(area, shifted)
+}
+
+// drawOverviewBorder frames the overview inset, titled
+func (ur *UIRenderer) drawOverviewBorder(area layout.Rect) {
+	style := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorBlack)
+	right, bottom := area.X+area.Width-1, area.Y+area.Height-1
+	for x := area.X + 1; x < right; x++ {
+		ur.screen.SetContent(x, area.Y, '─', nil, style)
+		ur.screen.SetContent(x, bottom, '─', nil, style)
+	}
+	for y := area.Y + 1; y < bottom; y++ {
+		ur.screen.SetContent(area.X, y, '│', nil, style)
+		ur.screen.SetContent(right, y, '│', nil, style)
+	}
+	ur.screen.SetContent(area.X, area.Y, '┌', nil, style)
+	ur.screen.SetContent(right, area.Y, '┐', nil, style)
+	ur.screen.SetContent(area.X, bottom, '└', nil, style)
+	ur.screen.SetContent(right, bottom, '┘', nil, style)
+	ur.drawText(area.X+2, area.Y, style, truncateText(" Overview ", area.Width-4))
+}
+
+// handleOverviewClick jumps from the centred map back to the whole system on a
+// click in the overview inset, selecting the body clicked, if any
+func (meh *MouseEventHandler) handleOverviewClick(mouseX, mouseY int) bool {
+	area, positions := meh.state.GetOverview()
+	if !meh.state.Focusing || !area.Contains(mouseX, mouseY) {
+		return false
+	}
+
+	meh.state.StopFocus()
+	if clicked, ok := planetAt(positions, mouseX, mouseY); ok {
+		for i, planet := range meh.state.GetPlanets() {
+			if planet.EnglishName == clicked.EnglishName {
+				meh.state.ListTab = TabPlanets
+				meh.state.UpdatePlanetSelection(i, planet)
+				break
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestOverviewInsetJumpsBackToTheSystem(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 160, 50)
+	earth := state.GetPlanets()[3]
+	state.StartFocus(earth, []models.CelestialBody{
+		{EnglishName: "Moon", BodyType: "Moon", SemimajorAxis: 384400, SideralOrbit: 27.32, MeanRadius: 1737},
+	})
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+
+	area, positions := state.GetOverview()
+	if area.Empty() {
+		t.Fatal("Expected the overview inset on the centred map")
+	}
+	if !strings.Contains(screenText(screen), "Overview") {
+		t.Fatalf("Expected the inset titled, got:\n%s", screenText(screen))
+	}
+	jupiter, ok := positions["Jupiter"]
+	if !ok || !area.Contains(jupiter.X, jupiter.Y) {
+		t.Fatalf("Expected Jupiter drawn in the inset %+v, got %+v", area, jupiter)
+	}
+
+	click(dispatcher, jupiter.X, jupiter.Y)
+	if state.Focusing {
+		t.Fatal("Expected a click in the inset to go back to the whole system")
+	}
+	if state.SelectedPlanet.EnglishName != "Jupiter" {
+		t.Errorf("Expected the clicked body selected, got %q", state.SelectedPlanet.EnglishName)
+	}
+
+	dispatcher.uiRenderer.DrawScreen()
+	if area, _ := state.GetOverview(); !area.Empty() {
+		t.Error("Expected no inset on the whole system")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/furan917/go-solar-system/internal/api"
 	"github.com/furan917/go-solar-system/internal/models"
 	"github.com/furan917/go-solar-system/internal/systems"
+	"github.com/furan917/go-solar-system/internal/visualization"
 )
 
 // PlanetService handles business logic for celestial body operations
@@ -25,13 +26,16 @@ func NewPlanetService(client *api.Client, systemManager *systems.SystemManager)
 
 // LoadCurrentSystem loads celestial bodies for the current system
 func (ps *PlanetService) LoadCurrentSystem() ([]models.CelestialBody, error) {
-	currentSystem := ps.systemManager.GetCurrentSystem()
+	return ps.LoadSystem(ps.systemManager.GetCurrentSystem())
+}
 
-	if currentSystem == "solar-system" {
+// LoadSystem loads celestial bodies for any available system without switching to it
+func (ps *PlanetService) LoadSystem(systemName string) ([]models.CelestialBody, error) {
+	if systemName == "solar-system" {
 		return ps.loadSolarSystem()
 	}
 
-	return ps.loadExternalSystem(currentSystem)
+	return ps.loadExternalSystem(systemName)
 }
 
 // loadSolarSystem loads our solar system from the API
@@ -62,6 +66,8 @@ func (ps *PlanetService) loadExternalSystem(systemName string) ([]models.Celesti
 		return nil, fmt.Errorf("failed to load external system %s: %w", systemName, err)
 	}
 
+	ps.client.KeepBodies(systemName, systemData.Bodies)
+
 	planets := systemData.Bodies
 	sort.Slice(planets, func(i, j int) bool {
 		return planets[i].SemimajorAxis < planets[j].SemimajorAxis
@@ -84,6 +90,36 @@ func (ps *PlanetService) GetMoonData(moonID string) (*models.CelestialBody, erro
 	return ps.client.GetMoonData(moonID)
 }
 
+// MoonDetails returns what is known of one of planet's moons. A system file's
+// moons come from the file alone; the Solar System's are fetched from the API,
+// falling back to the name the planet lists the moon by.
+func (ps *PlanetService) MoonDetails(systemName string, planet models.CelestialBody, moon models.Moon, moonHandler *visualization.MoonHandler) models.CelestialBody {
+	if moon.Body != nil {
+		return *moon.Body
+	}
+
+	if systemName != "solar-system" {
+		if body, ok := ps.systemManager.FindMoon(systemName, moon); ok {
+			return body
+		}
+	} else if moonID := moonHandler.MoonID(moon); moonID != "" {
+		if moonDetail, err := ps.client.GetMoonData(moonID); err == nil {
+			body := *moonDetail
+			body.BodyType = "Moon"
+			body.AroundPlanet = &models.Planet{EnglishName: planet.EnglishName}
+			return body
+		}
+	}
+
+	return models.CelestialBody{
+		ID:           moon.ID,
+		Name:         moon.Name,
+		EnglishName:  moonHandler.GetMoonNameFromAPI(moon),
+		BodyType:     "Moon",
+		AroundPlanet: &models.Planet{EnglishName: planet.EnglishName},
+	}
+}
+
 // ValidatePlanetData performs basic validation on planet data
 func (ps *PlanetService) ValidatePlanetData(planets []models.CelestialBody) error {
 	if len(planets) == 0 {
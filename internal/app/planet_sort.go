@@ -0,0 +1,120 @@
+package app
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// SortMode selects how the planet list is ordered
+type SortMode int
+
+const (
+	SortByDistance SortMode = iota
+	SortByRadius
+	SortByMass
+	SortByMoons
+	SortByName
+	sortModeCount
+)
+
+// planetSorters holds the ordering strategy for each sort mode; each reports whether a comes before b
+var planetSorters = map[SortMode]func(a, b models.CelestialBody) bool{
+	SortByDistance: func(a, b models.CelestialBody) bool {
+		return a.SemimajorAxis < b.SemimajorAxis
+	},
+	SortByRadius: func(a, b models.CelestialBody) bool {
+		return a.MeanRadius > b.MeanRadius
+	},
+	SortByMass: func(a, b models.CelestialBody) bool {
+		return a.GetMassKg() > b.GetMassKg()
+	},
+	SortByMoons: func(a, b models.CelestialBody) bool {
+		return len(a.Moons) > len(b.Moons)
+	},
+	SortByName: func(a, b models.CelestialBody) bool {
+		return strings.ToLower(a.EnglishName) < strings.ToLower(b.EnglishName)
+	},
+}
+
+// String returns the label shown next to the title
+func (m SortMode) String() string {
+	switch m {
+	case SortByRadius:
+		return "radius"
+	case SortByMass:
+		return "mass"
+	case SortByMoons:
+		return "moons"
+	case SortByName:
+		return "name"
+	default:
+		return "distance"
+	}
+}
+
+// Next returns the following sort mode, wrapping around to distance
+func (m SortMode) Next() SortMode {
+	return (m + 1) % sortModeCount
+}
+
+// BodyGroup is the body-type group a body is listed under
+type BodyGroup int
+
+const (
+	GroupStars BodyGroup = iota
+	GroupPlanets
+	GroupDwarfPlanets
+	GroupOther
+)
+
+// String returns the heading shown before the group in the planet list
+func (g BodyGroup) String() string {
+	switch g {
+	case GroupStars:
+		return "Stars"
+	case GroupPlanets:
+		return "Planets"
+	case GroupDwarfPlanets:
+		return "Dwarf planets"
+	default:
+		return "Other"
+	}
+}
+
+// bodyGroup classifies a body by its type
+func bodyGroup(body models.CelestialBody) BodyGroup {
+	switch strings.ToLower(body.BodyType) {
+	case "star":
+		return GroupStars
+	case "dwarf planet":
+		return GroupDwarfPlanets
+	case "planet":
+		return GroupPlanets
+	}
+
+	if body.IsPlanet || body.SemimajorAxis > 0 {
+		return GroupPlanets
+	}
+	return GroupOther
+}
+
+// sortPlanets orders bodies with the mode's strategy, optionally grouping them by
+// body type first. The sort is stable so ties keep their current order.
+func sortPlanets(planets []models.CelestialBody, mode SortMode, grouped bool) {
+	less, ok := planetSorters[mode]
+	if !ok {
+		less = planetSorters[SortByDistance]
+	}
+
+	sort.SliceStable(planets, func(i, j int) bool {
+		if grouped {
+			gi, gj := bodyGroup(planets[i]), bodyGroup(planets[j])
+			if gi != gj {
+				return gi < gj
+			}
+		}
+		return less(planets[i], planets[j])
+	})
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+// sortFixture returns bodies of every group, in an order none of the modes give
+func sortFixture() []models.CelestialBody {
+	moons := func(n int) []models.Moon { return make([]models.Moon, n) }
+	return []models.CelestialBody{
+		{EnglishName: "Sun", BodyType: "Star", MeanRadius: 695508, Mass: models.Mass{MassValue: 1.989, MassExponent: 30}},
+		{EnglishName: "Mercury", BodyType: "Planet", SemimajorAxis: 57909227, MeanRadius: 2439, Mass: models.Mass{MassValue: 3.301, MassExponent: 23}},
+		{EnglishName: "Earth", BodyType: "Planet", SemimajorAxis: 149598023, MeanRadius: 6371, Mass: models.Mass{MassValue: 5.972, MassExponent: 24}, Moons: moons(1)},
+		{EnglishName: "Jupiter", BodyType: "Planet", SemimajorAxis: 778340821, MeanRadius: 69911, Mass: models.Mass{MassValue: 1.898, MassExponent: 27}, Moons: moons(3)},
+		{EnglishName: "Pluto", BodyType: "Dwarf Planet", SemimajorAxis: 5906440628, MeanRadius: 1188, Mass: models.Mass{MassValue: 1.303, MassExponent: 22}, Moons: moons(2)},
+		{EnglishName: "ceres", BodyType: "Dwarf Planet", SemimajorAxis: 413690250, MeanRadius: 470, Mass: models.Mass{MassValue: 9.39, MassExponent: 20}},
+		{EnglishName: "Oumuamua"},
+	}
+}
+
+// names lists the bodies' names in order
+func names(bodies []models.CelestialBody) string {
+	var out []string
+	for _, body := range bodies {
+		out = append(out, body.EnglishName)
+	}
+	return strings.Join(out, ", ")
+}
+
+func TestSortPlanets(t *testing.T) {
+	tests := []struct {
+		mode    SortMode
+		grouped bool
+		want    string
+	}{
+		{SortByDistance, false, "Sun, Oumuamua, Mercury, Earth, ceres, Jupiter, Pluto"},
+		{SortByRadius, false, "Sun, Jupiter, Earth, Mercury, Pluto, ceres, Oumuamua"},
+		{SortByMass, false, "Sun, Jupiter, Earth, Mercury, Pluto, ceres, Oumuamua"},
+		{SortByMoons, false, "Jupiter, Pluto, Earth, Sun, Mercury, ceres, Oumuamua"},
+		{SortByName, false, "ceres, Earth, Jupiter, Mercury, Oumuamua, Pluto, Sun"},
+		{SortByDistance, true, "Sun, Mercury, Earth, Jupiter, ceres, Pluto, Oumuamua"},
+		{SortByName, true, "Sun, Earth, Jupiter, Mercury, ceres, Pluto, Oumuamua"},
+		{SortByMoons, true, "Sun, Jupiter, Earth, Mercury, Pluto, ceres, Oumuamua"},
+		{sortModeCount, false, "Sun, Oumuamua, Mercury, Earth, ceres, Jupiter, Pluto"},
+	}
+	for _, tt := range tests {
+		planets := sortFixture()
+		sortPlanets(planets, tt.mode, tt.grouped)
+		if got := names(planets); got != tt.want {
+			t.Errorf("sortPlanets(%v, grouped %v) = %s, want %s", tt.mode, tt.grouped, got, tt.want)
+		}
+	}
+}
+
+func TestBodyGroup(t *testing.T) {
+	tests := []struct {
+		body models.CelestialBody
+		want BodyGroup
+	}{
+		{models.CelestialBody{BodyType: "Star"}, GroupStars},
+		{models.CelestialBody{BodyType: "planet"}, GroupPlanets},
+		{models.CelestialBody{BodyType: "Dwarf Planet"}, GroupDwarfPlanets},
+		{models.CelestialBody{BodyType: "Asteroid", SemimajorAxis: 4e8}, GroupPlanets},
+		{models.CelestialBody{IsPlanet: true}, GroupPlanets},
+		{models.CelestialBody{BodyType: "Comet"}, GroupOther},
+	}
+	for _, tt := range tests {
+		if got := bodyGroup(tt.body); got != tt.want {
+			t.Errorf("bodyGroup(%+v) = %v, want %v", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestSortModeCycles(t *testing.T) {
+	var labels []string
+	mode := SortByDistance
+	for range sortModeCount + 1 {
+		labels = append(labels, mode.String())
+		mode = mode.Next()
+	}
+	if got, want := strings.Join(labels, " "), "distance radius mass moons name distance"; got != want {
+		t.Errorf("sort modes = %s, want %s", got, want)
+	}
+}
+
+func TestSortKeyKeepsSelection(t *testing.T) {
+	dispatcher, state, _ := newLoadingFixture(t)
+	state.UpdatePlanetSelection(3, state.GetPlanets()[3])
+	press := func(r rune) {
+		dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+
+	press('o')
+	if state.SortMode != SortByRadius {
+		t.Fatalf("SortMode = %v, want radius after o", state.SortMode)
+	}
+	if got, want := names(state.GetPlanets()), "Sun, Jupiter, Earth, Venus, Mars, Mercury"; got != want {
+		t.Errorf("planets = %s, want %s", got, want)
+	}
+	if state.SelectedPlanet.EnglishName != "Earth" || state.SelectedIndex != 2 {
+		t.Errorf("selected %s at %d, want Earth followed to 2", state.SelectedPlanet.EnglishName, state.SelectedIndex)
+	}
+
+	press('O')
+	press('o')
+	press('o')
+	press('o')
+	if got, want := names(state.GetPlanets()), "Sun, Earth, Jupiter, Mars, Mercury, Venus"; !state.GroupByType || got != want {
+		t.Errorf("grouped by name = %s (%v), want %s", got, state.GroupByType, want)
+	}
+	if state.SelectedPlanet.EnglishName != "Earth" || state.SelectedIndex != 1 {
+		t.Errorf("selected %s at %d, want Earth followed to 1", state.SelectedPlanet.EnglishName, state.SelectedIndex)
+	}
+}
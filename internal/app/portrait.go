@@ -0,0 +1,35 @@
+package app
+
+import (
+	"github.com/furan917/go-solar-system/internal/portrait"
+	"github.com/gdamore/tcell/v2"
+)
+
+// minPortraitTextWidth is the narrowest the detail text may get before the
+// portrait is left out
+const minPortraitTextWidth = 30
+
+// portraitTextWidth returns the width left for detail text beside a portrait
+func (ur *UIRenderer) portraitTextWidth() int {
+	return ur.contentWidth() - portrait.Width - 2
+}
+
+// portraitFits reports whether the details modal is wide enough for a portrait
+func (ur *UIRenderer) portraitFits() bool {
+	return ur.portraitTextWidth() >= minPortraitTextWidth
+}
+
+// drawPortrait draws a body portrait with its top-left corner at x, y on the modal
+// background. Transparent cells are left alone.
+func (ur *UIRenderer) drawPortrait(x, y int, p portrait.Portrait) {
+	for row := 0; row < portrait.Height; row++ {
+		for col := 0; col < portrait.Width; col++ {
+			cell := p.At(col, row)
+			if cell.Glyph == 0 {
+				continue
+			}
+			style := tcell.StyleDefault.Foreground(tcell.GetColor(cell.Color)).Background(tcell.ColorDarkBlue)
+			ur.screen.SetContent(x+col, y+row, cell.Glyph, nil, style)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+)
+
+// activity is when the user last gave input, and the signal that something has
+// changed worth drawing before the next frame is due. The event goroutine writes
+// both and the render goroutine reads them.
+type activity struct {
+	lastInput atomic.Int64 // Unix nanoseconds
+	wake      chan struct{}
+}
+
+func newActivity(now time.Time) *activity {
+	a := &activity{wake: make(chan struct{}, 1)}
+	a.lastInput.Store(now.UnixNano())
+	return a
+}
+
+// input records a key, click, controller input or resize at now
+func (a *activity) input(now time.Time) {
+	a.lastInput.Store(now.UnixNano())
+}
+
+// idleFor returns how long it has been since the last input
+func (a *activity) idleFor(now time.Time) time.Duration {
+	return now.Sub(time.Unix(0, a.lastInput.Load()))
+}
+
+// poke asks for a frame now; pokes made before it is drawn are merged
+func (a *activity) poke() {
+	select {
+	case a.wake <- struct{}{}:
+	default:
+	}
+}
+
+// frameRate returns the time between frames for the fps setting, from 1 to
+// MaxFPS a second, or 0 for the default when it is not set
+func frameRate(fps int) time.Duration {
+	if fps <= 0 {
+		return 0
+	}
+	return time.Second / time.Duration(min(fps, constants.MaxFPS))
+}
+
+// idleAfter returns how long without input the app waits before drawing a frame
+// only every IdleUpdateRate, for the idle_seconds setting; 0 never does
+func idleAfter(seconds int) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// SetFrameRate draws frames fps times a second, as long as they are cheap
+// enough; 0 goes back to the default
+func (ur *UIRenderer) SetFrameRate(fps int) {
+	ur.drawMu.Lock()
+	defer ur.drawMu.Unlock()
+	ur.budget.target = frameRate(fps)
+	ur.budget.interval = 0
+}
+
+// SetIdleAfter drops to a frame a second once there has been no input for d,
+// until the next; 0 keeps the full frame rate
+func (ur *UIRenderer) SetIdleAfter(d time.Duration) {
+	ur.drawMu.Lock()
+	defer ur.drawMu.Unlock()
+	ur.idleAfter = d
+}
+
+// NoteInput records user input, ending any idle spell
+func (ur *UIRenderer) NoteInput(now time.Time) {
+	ur.activity.input(now)
+}
+
+// Wake asks for a frame to be drawn now rather than at the next tick, as an
+// event has just changed the state
+func (ur *UIRenderer) Wake() {
+	ur.activity.poke()
+}
+
+// Woken receives after Wake
+func (ur *UIRenderer) Woken() <-chan struct{} {
+	return ur.activity.wake
+}
+
+// CanDrawEarly reports whether a frame woken since after the last one finished
+// can be drawn at once. The deterministic clock moves a step per frame, so it
+// keeps to the ticks.
+func (ur *UIRenderer) CanDrawEarly(since time.Duration) bool {
+	ur.drawMu.Lock()
+	defer ur.drawMu.Unlock()
+	return !ur.deterministic() && ur.budget.allowsEarly(since)
+}
+
+// idle reports whether it has been long enough since the last input to slow
+// down; called under drawMu
+func (ur *UIRenderer) idle() bool {
+	return ur.idleAfter > 0 && ur.activity.idleFor(time.Now()) >= ur.idleAfter
+}
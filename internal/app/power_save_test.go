@@ -0,0 +1,93 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+)
+
+func TestFrameRate(t *testing.T) {
+	tests := []struct {
+		fps  int
+		want time.Duration
+	}{
+		{0, 0},
+		{-5, 0},
+		{1, time.Second},
+		{30, time.Second / 30},
+		{240, time.Second / constants.MaxFPS},
+	}
+	for _, tt := range tests {
+		if got := frameRate(tt.fps); got != tt.want {
+			t.Errorf("frameRate(%d) = %v, want %v", tt.fps, got, tt.want)
+		}
+	}
+}
+
+func TestFrameBudgetKeepsToTarget(t *testing.T) {
+	fb := frameBudget{target: time.Second / 30}
+	for range 10 {
+		fb.observe(time.Millisecond)
+	}
+	if got := fb.current(); got != time.Second/30 {
+		t.Errorf("cheap frames at 30 FPS every %v, want %v", got, time.Second/30)
+	}
+
+	// Slow frames still slow it down, however fast the target
+	for range 20 {
+		fb.observe(200 * time.Millisecond)
+	}
+	if got := fb.current(); got != constants.SlowestUpdateRate {
+		t.Errorf("slow frames every %v, want %v", got, constants.SlowestUpdateRate)
+	}
+	if fb.allowsEarly(100 * time.Millisecond) {
+		t.Error("Expected no early frame while frames take 200ms")
+	}
+}
+
+func TestIdleFrameRate(t *testing.T) {
+	dispatcher, _, _ := newAppFixture(t, 120, 40)
+	ur := dispatcher.uiRenderer
+	ur.SetFrameRate(20)
+	if got := ur.FrameInterval(); got != 50*time.Millisecond {
+		t.Fatalf("FrameInterval() = %v at 20 FPS, want 50ms", got)
+	}
+
+	ur.SetIdleAfter(time.Minute)
+	ur.NoteInput(time.Now().Add(-2 * time.Minute))
+	if got := ur.FrameInterval(); got != constants.IdleUpdateRate {
+		t.Errorf("FrameInterval() = %v after two idle minutes, want %v", got, constants.IdleUpdateRate)
+	}
+
+	ur.NoteInput(time.Now())
+	if got := ur.FrameInterval(); got != 50*time.Millisecond {
+		t.Errorf("FrameInterval() = %v after input, want back to 50ms", got)
+	}
+}
+
+func TestWakeDrawsEarly(t *testing.T) {
+	dispatcher, _, _ := newAppFixture(t, 120, 40)
+	ur := dispatcher.uiRenderer
+
+	ur.Wake()
+	ur.Wake()
+	select {
+	case <-ur.Woken():
+	default:
+		t.Fatal("Expected Wake to ask for a frame")
+	}
+	select {
+	case <-ur.Woken():
+		t.Error("Expected wakes before a frame to be merged")
+	default:
+	}
+	if !ur.CanDrawEarly(time.Second) {
+		t.Error("Expected a woken frame drawn early")
+	}
+
+	ur.SetClock(newDeterministicClock())
+	if ur.CanDrawEarly(time.Second) {
+		t.Error("Expected deterministic frames to keep to the ticks")
+	}
+}
@@ -0,0 +1,149 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/furan917/go-solar-system/internal/quiz"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/gdamore/tcell/v2"
+)
+
+// openQuiz starts (or resumes) a quiz built from the currently loaded bodies
+func (ed *EventDispatcher) openQuiz() {
+	generator := quiz.NewGenerator(ed.state.GetPlanets(), ed.uiRenderer.clock.Now().UnixNano())
+	question, ok := generator.Next()
+	if !ok {
+		ed.state.SetStatusMessage("Not enough data in this system for a quiz", statusMessageDuration)
+		return
+	}
+
+	ed.state.ShowQuiz(generator, question)
+}
+
+// handleQuizKeys handles keyboard input while the quiz modal is open
+func (ed *EventDispatcher) handleQuizKeys(ev *tcell.EventKey) {
+	question := ed.state.QuizQuestion
+
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		ed.state.PopModal()
+	case tcell.KeyUp:
+		if !ed.state.QuizAnswered && ed.state.QuizSelected > 0 {
+			ed.state.QuizSelected--
+		}
+	case tcell.KeyDown:
+		if !ed.state.QuizAnswered && ed.state.QuizSelected < len(question.Options)-1 {
+			ed.state.QuizSelected++
+		}
+	case tcell.KeyEnter:
+		if ed.state.QuizAnswered {
+			ed.nextQuizQuestion()
+		} else {
+			ed.state.AnswerQuiz(ed.state.QuizSelected)
+		}
+	case tcell.KeyRune:
+		r := ev.Rune()
+		switch {
+		case r >= '1' && r <= '9':
+			ed.answerQuizOption(int(r - '1'))
+		case r >= 'a' && r <= 'd':
+			ed.answerQuizOption(int(r - 'a'))
+		case r == 'n' || r == 'N':
+			if ed.state.QuizAnswered {
+				ed.nextQuizQuestion()
+			}
+		case r == 'q' || r == 'Q':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// answerQuizOption answers with the option at index if it exists
+func (ed *EventDispatcher) answerQuizOption(index int) {
+	if ed.state.QuizAnswered || index < 0 || index >= len(ed.state.QuizQuestion.Options) {
+		return
+	}
+	ed.state.QuizSelected = index
+	ed.state.AnswerQuiz(index)
+}
+
+func (ed *EventDispatcher) nextQuizQuestion() {
+	if ed.state.QuizGenerator == nil {
+		return
+	}
+	if question, ok := ed.state.QuizGenerator.Next(); ok {
+		ed.state.SetQuizQuestion(question)
+	}
+}
+
+// quizOptionLabel returns the letter shown before an option
+func quizOptionLabel(index int) string {
+	return string(rune('A' + index))
+}
+
+// calculateQuizLines returns the number of content lines in the quiz modal
+func (ur *UIRenderer) calculateQuizLines(state *AppState) int {
+	promptLines := len(ur.wrapText(state.QuizQuestion.Prompt, ur.contentWidth()))
+	return promptLines + 1 + len(state.QuizQuestion.Options) + 2 // prompt + gap + options + gap + feedback
+}
+
+// quizOptionsStartY returns the screen row of the first option of a question
+func (ur *UIRenderer) quizOptionsStartY(question quiz.Question, modalY int) int {
+	return modalY + 3 + len(ur.wrapText(question.Prompt, ur.contentWidth())) + 1
+}
+
+// drawQuizModal renders the current question, its options and the score
+func (ur *UIRenderer) drawQuizModal(width, height int) {
+	dynamicHeight := minimum(ur.calculateQuizLines(ur.state)+6, height-4)
+	modalX, modalY, modalWidth, modalHeight := ur.setupModal(width, height, dynamicHeight)
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, " 🎓 Quiz ")
+
+	score := ur.state.QuizScore
+	scoreText := fmt.Sprintf("Score %d/%d (%d%%) • Streak %d", score.Correct, score.Answered, score.Percent(), score.Streak)
+	ur.drawText(modalX+modalWidth-ui.TextWidth(scoreText)-2, modalY+1, tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue), scoreText)
+
+	question := ur.state.QuizQuestion
+	promptStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawWrappedTextAt(modalX+2, modalY+3, promptStyle, question.Prompt, ur.contentWidth())
+
+	optionY := ur.quizOptionsStartY(ur.state.QuizQuestion, modalY)
+	for i, option := range question.Options {
+		style := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+		prefix := "  "
+
+		switch {
+		case ur.state.QuizAnswered && question.IsCorrect(i):
+			style = tcell.StyleDefault.Foreground(tcell.ColorGreen).Background(tcell.ColorDarkBlue).Bold(true)
+			prefix = "✓ "
+		case ur.state.QuizAnswered && i == ur.state.QuizSelected:
+			style = tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorDarkBlue).Bold(true)
+			prefix = "✗ "
+		case !ur.state.QuizAnswered && i == ur.state.QuizSelected:
+			style = tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true).Reverse(true)
+			prefix = "► "
+		}
+
+		ur.drawText(modalX+2, optionY+i, style, fmt.Sprintf("%s%s) %s", prefix, quizOptionLabel(i), option))
+	}
+
+	if ur.state.QuizAnswered {
+		feedback := "Correct!"
+		feedbackStyle := tcell.StyleDefault.Foreground(tcell.ColorGreen).Background(tcell.ColorDarkBlue).Bold(true)
+		if !ur.state.QuizLastCorrect {
+			feedback = fmt.Sprintf("Not quite - the answer is %s", question.Options[question.Answer])
+			feedbackStyle = tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorDarkBlue).Bold(true)
+		}
+		ur.drawText(modalX+2, modalY+modalHeight-3, feedbackStyle, truncateText(feedback, ur.contentWidth()))
+	}
+
+	instruction := "↑/↓ choose • Enter or A-D to answer • Escape to close"
+	if ur.state.QuizAnswered {
+		instruction = "Enter or 'n' for next question • Escape to close"
+	}
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, instruction)
+}
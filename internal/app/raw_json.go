@@ -0,0 +1,96 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/gdamore/tcell/v2"
+)
+
+// openRawJSON shows the body whose details are open as JSON: every field the app
+// holds for it under the API's names, zeros included, so what it was given can be
+// checked against what it shows
+func (ed *EventDispatcher) openRawJSON() {
+	body := ed.state.SelectedPlanet
+	if ed.state.TopModal() == ModalMoonDetails {
+		body = ed.state.SelectedMoon
+	}
+
+	data, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		ed.state.SetStatusMessage("Could not show "+body.EnglishName+" as JSON: "+err.Error(), statusMessageDuration)
+		return
+	}
+	ed.state.ShowRawJSON(body, strings.Split(string(data), "\n"))
+}
+
+// handleRawJSONKeys scrolls the JSON and copies it; the raw JSON key or any key
+// that closes the details goes back to them
+func (ed *EventDispatcher) handleRawJSONKeys(ev *tcell.EventKey) {
+	if action, ok := ed.keys.Action(keymap.ContextDetails, ev); ok {
+		switch action {
+		case keymap.ActionClose, keymap.ActionRawJSON:
+			ed.state.PopModal()
+		case keymap.ActionCopy, keymap.ActionCopyJSON:
+			body := ed.state.RawJSONBody
+			ed.uiRenderer.screen.SetClipboard([]byte(strings.Join(ed.state.RawJSONLines, "\n")))
+			ed.state.SetStatusMessage(fmt.Sprintf("Copied %s's raw JSON to the clipboard", body.EnglishName), statusMessageDuration)
+		}
+		return
+	}
+
+	_, height := ed.uiRenderer.screen.Size()
+	page := max(rawJSONRows(ed.state, height)-1, 1)
+	limit := max(len(ed.state.RawJSONLines)-1, 0)
+	switch ev.Key() {
+	case tcell.KeyUp:
+		ed.state.RawJSONScroll = clampScroll(ed.state.RawJSONScroll, -1, limit)
+	case tcell.KeyDown:
+		ed.state.RawJSONScroll = clampScroll(ed.state.RawJSONScroll, 1, limit)
+	case tcell.KeyPgUp:
+		ed.state.RawJSONScroll = clampScroll(ed.state.RawJSONScroll, -page, limit)
+	case tcell.KeyPgDn:
+		ed.state.RawJSONScroll = clampScroll(ed.state.RawJSONScroll, page, limit)
+	case tcell.KeyHome:
+		ed.state.RawJSONScroll = 0
+	case tcell.KeyEnd:
+		ed.state.RawJSONScroll = limit
+	}
+}
+
+// rawJSONRows is how many lines of JSON fit in the view on a screen this tall
+func rawJSONRows(state *AppState, screenHeight int) int {
+	return fitModalHeight(len(state.RawJSONLines)+2, screenHeight) - 8
+}
+
+// drawRawJSONModal renders the body's JSON under a line saying where it came from
+func (ur *UIRenderer) drawRawJSONModal(width, height int) {
+	body := ur.state.RawJSONBody
+	lines := ur.state.RawJSONLines
+	modalX, modalY, _, modalHeight := ur.setupModal(width, height, fitModalHeight(len(lines)+2, height))
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	sourceStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	jsonStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+1, titleStyle, fmt.Sprintf(" { } %s ", body.EnglishName))
+
+	source := "Source: " + body.Provenance.Source.Label()
+	if citation := body.Provenance.Citation; citation != "" {
+		source += " (" + citation + ")"
+	}
+	ur.drawText(modalX+2, modalY+3, sourceStyle, truncateText(source, ur.contentWidth()))
+
+	top := modalY + 5
+	visible := rawJSONRows(ur.state, height)
+	scroll := minimum(ur.state.RawJSONScroll, max(len(lines)-visible, 0))
+	for i := 0; i < visible && scroll+i < len(lines); i++ {
+		ur.drawText(modalX+2, top+i, jsonStyle, truncateText(lines[scroll+i], ur.contentWidth()))
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	instruction := fmt.Sprintf("↑/↓ PgUp/PgDn scroll • '%s' copy • '%s' or Esc back to the details",
+		ur.keys.Primary(keymap.ActionCopy), strings.ToLower(ur.keys.Primary(keymap.ActionRawJSON)))
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, truncateText(instruction, ur.contentWidth()))
+}
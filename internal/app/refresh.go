@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/config"
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/watch"
+	"github.com/gdamore/tcell/v2"
+)
+
+// refreshEvent carries a fresh fetch of the Solar System into the event loop
+type refreshEvent struct {
+	tcell.EventTime
+	bodies []models.CelestialBody
+}
+
+// refreshInterval is how often the Solar System is fetched again, or 0 for never.
+// Intervals shorter than the API cache's are raised to it, as a fetch inside it
+// would only get the cached answer back.
+func refreshInterval(cfg config.Config) time.Duration {
+	if cfg.RefreshMinutes <= 0 {
+		return 0
+	}
+	return max(time.Duration(cfg.RefreshMinutes)*time.Minute, constants.DefaultCacheTTL)
+}
+
+// refreshSolarSystem fetches the Solar System's bodies every interval until ctx is
+// done and posts them to the event loop. A fetch that fails is logged and tried
+// again at the next interval.
+func (ss *SolarSystem) refreshSolarSystem(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		bodies, err := ss.planetService.GetClient().GetPlanets()
+		if err != nil {
+			ss.logger.Printf("Refresh: could not fetch the Solar System: %v", err)
+			continue
+		}
+		event := &refreshEvent{bodies: bodies}
+		event.SetEventNow()
+		postEvent(ctx, ss.screen, event)
+	}
+}
+
+// applyRefresh lays freshly fetched bodies over the Solar System being shown,
+// matching them by id. Changed bodies are replaced where they stand and new ones
+// added; the selection and any open modal stay as they were, showing the fresh
+// values. Bodies the API no longer lists are kept until the system is loaded
+// again. A refresh that arrives once another system is shown is dropped.
+func (sm *SystemManager) applyRefresh(fresh []models.CelestialBody) {
+	if sm.uiRenderer.GetSystemManager().GetCurrentSystem() != "solar-system" || sm.loadingSystem != "" || sm.state.Loading != "" {
+		return
+	}
+
+	planets := slices.Clone(sm.state.GetPlanets())
+	changed, added := 0, 0
+	for _, body := range sm.NormalizePlanetNames(fresh) {
+		i := slices.IndexFunc(planets, func(p models.CelestialBody) bool { return p.ID != "" && p.ID == body.ID })
+		if i < 0 {
+			planets = append(planets, body)
+			added++
+			continue
+		}
+		if len(watch.Diff(planets[i], body)) == 0 {
+			continue
+		}
+		planets[i] = body
+		changed++
+		if sm.state.SelectedPlanet.ID == body.ID {
+			sm.state.SelectedPlanet = body
+		}
+	}
+	if changed == 0 && added == 0 {
+		return
+	}
+
+	sm.state.SetPlanets(planets)
+	if added > 0 {
+		if err := sm.SortPlanets(); err != nil {
+			sm.errorHandler.HandleError(NewStateError("failed to sort planets", err))
+		}
+	}
+	sm.state.SetStatusMessage(refreshSummary(changed, added), statusMessageDuration)
+}
+
+// refreshSummary says what a refresh changed, for the status line
+func refreshSummary(changed, added int) string {
+	switch {
+	case added == 0:
+		return fmt.Sprintf("Solar System refreshed: %s updated", bodyCount(changed))
+	case changed == 0:
+		return fmt.Sprintf("Solar System refreshed: %s added", bodyCount(added))
+	}
+	return fmt.Sprintf("Solar System refreshed: %s updated, %d added", bodyCount(changed), added)
+}
+
+// bodyCount writes n bodies, or 1 body
+func bodyCount(n int) string {
+	if n == 1 {
+		return "1 body"
+	}
+	return fmt.Sprintf("%d bodies", n)
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/config"
+	"github.com/furan917/go-solar-system/internal/logging"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+// newRefreshFixture returns the app fixture with a system manager to apply
+// refreshes, showing the Solar System with Venus selected
+func newRefreshFixture(t *testing.T) (*EventDispatcher, *AppState) {
+	t.Helper()
+	dispatcher, state, _ := newAppFixture(t, 120, 40)
+	logger := logging.Discard()
+	dispatcher.systemManager = NewSystemManager(state, nil, dispatcher.uiRenderer, NewErrorHandler(logger, state), logger)
+	state.UpdatePlanetSelection(2, state.GetPlanets()[2])
+	return dispatcher, state
+}
+
+func TestRefreshReplacesChangedBodies(t *testing.T) {
+	dispatcher, state := newRefreshFixture(t)
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if state.TopModal() != ModalDetails {
+		t.Fatalf("TopModal() = %v, want the details open", state.TopModal())
+	}
+
+	fresh := append([]models.CelestialBody(nil), state.GetPlanets()...)
+	fresh[2].MeanRadius = 6051.8
+	fresh[4].MeanRadius = 3389.5
+	dispatcher.systemManager.applyRefresh(fresh)
+
+	if got := state.GetPlanets()[4].MeanRadius; got != 3389.5 {
+		t.Errorf("Mars's radius = %v, want the refreshed 3389.5", got)
+	}
+	if state.SelectedIndex != 2 || state.SelectedPlanet.MeanRadius != 6051.8 {
+		t.Errorf("selection = %d %+v, want Venus with its refreshed radius", state.SelectedIndex, state.SelectedPlanet)
+	}
+	if state.TopModal() != ModalDetails {
+		t.Errorf("TopModal() = %v, want the details still open", state.TopModal())
+	}
+	if want := "Solar System refreshed: 2 bodies updated"; state.GetStatusMessage() != want {
+		t.Errorf("GetStatusMessage() = %q, want %q", state.GetStatusMessage(), want)
+	}
+}
+
+func TestRefreshAddsNewBodies(t *testing.T) {
+	dispatcher, state := newRefreshFixture(t)
+
+	fresh := append([]models.CelestialBody(nil), state.GetPlanets()...)
+	fresh = append(fresh, models.CelestialBody{ID: "saturne", EnglishName: "Saturn", BodyType: "Planet", IsPlanet: true, SemimajorAxis: 1426666422, SideralOrbit: 10759.22, MeanRadius: 58232})
+	dispatcher.systemManager.applyRefresh(fresh)
+
+	if got := len(state.GetPlanets()); got != 7 {
+		t.Fatalf("len(planets) = %d, want Saturn added to the 6", got)
+	}
+	if state.SelectedPlanet.EnglishName != "Venus" || state.GetPlanets()[state.SelectedIndex].EnglishName != "Venus" {
+		t.Errorf("selection = %d %s, want Venus kept", state.SelectedIndex, state.SelectedPlanet.EnglishName)
+	}
+	if want := "Solar System refreshed: 1 body added"; state.GetStatusMessage() != want {
+		t.Errorf("GetStatusMessage() = %q, want %q", state.GetStatusMessage(), want)
+	}
+}
+
+func TestRefreshLeavesUnchangedSystemAlone(t *testing.T) {
+	dispatcher, state := newRefreshFixture(t)
+
+	dispatcher.systemManager.applyRefresh(state.GetPlanets())
+	if got := state.GetStatusMessage(); got != "" {
+		t.Errorf("GetStatusMessage() = %q, want nothing said of a refresh that changed nothing", got)
+	}
+}
+
+func TestRefreshIsDroppedForOtherSystems(t *testing.T) {
+	dispatcher, state, _ := newLoadingFixture(t)
+	if err := dispatcher.uiRenderer.GetSystemManager().SwitchToSystem("rubble"); err != nil {
+		t.Fatalf("SwitchToSystem() error = %v", err)
+	}
+
+	fresh := append([]models.CelestialBody(nil), state.GetPlanets()...)
+	fresh[4].MeanRadius = 3389.5
+	dispatcher.systemManager.applyRefresh(fresh)
+
+	if got := state.GetPlanets()[4].MeanRadius; got != 3389 {
+		t.Errorf("Mars's radius = %v, want it untouched while another system is shown", got)
+	}
+}
+
+func TestRefreshInterval(t *testing.T) {
+	tests := []struct {
+		minutes int
+		want    time.Duration
+	}{
+		{0, 0},
+		{-5, 0},
+		{1, 10 * time.Minute},
+		{30, 30 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := refreshInterval(config.Config{RefreshMinutes: tt.minutes}); got != tt.want {
+			t.Errorf("refreshInterval(%d minutes) = %v, want %v", tt.minutes, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestResizeReflowsPlanetList(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 160, 50)
+	resize(dispatcher, screen, 60, 30)
+
+	positions := state.GetPlanetListPositions()
+	if len(positions) == 0 {
+		t.Fatal("planet list not redrawn after resize")
+	}
+	for _, pos := range positions {
+		if pos.X+pos.Width > 60 {
+			t.Errorf("list row %d ends at column %d, past the new width 60", pos.Index, pos.X+pos.Width)
+		}
+	}
+
+	last := positions[len(positions)-1]
+	doubleClick(dispatcher, last.X+1, last.Y)
+	if state.SelectedIndex != last.Index || state.TopModal() != ModalDetails {
+		t.Errorf("clicking list row %d selected %d (top modal %v)", last.Index, state.SelectedIndex, state.TopModal())
+	}
+}
+
+func TestResizeMovesMapClickTargets(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 160, 50)
+	before := state.GetPlanetPositions()["Jupiter"]
+
+	resize(dispatcher, screen, 80, 24)
+	after, ok := state.GetPlanetPositions()["Jupiter"]
+	if !ok {
+		t.Fatal("Jupiter's position not redrawn after resize")
+	}
+	if after.X >= 80 || after.Y >= 24 {
+		t.Errorf("Jupiter at (%d, %d), outside the new 80×24 screen", after.X, after.Y)
+	}
+	if after.X == before.X && after.Y == before.Y {
+		t.Errorf("Jupiter still at (%d, %d) after the map shrank", after.X, after.Y)
+	}
+
+	click(dispatcher, after.X, after.Y)
+	if state.SelectedPlanet.EnglishName != "Jupiter" {
+		t.Errorf("clicking Jupiter's new position selected %q", state.SelectedPlanet.EnglishName)
+	}
+}
+
+func TestResizeMovesModalClickTargets(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 160, 50)
+	state.SelectedPlanet = state.GetPlanets()[3]
+	state.OpenModal(ModalDetails)
+
+	resize(dispatcher, screen, 70, 30)
+	area := dispatcher.uiRenderer.modalArea(state, ModalDetails)
+	if area.X+area.Width > 70 {
+		t.Fatalf("details modal spans to column %d on a 70-column screen", area.X+area.Width)
+	}
+
+	click(dispatcher, area.X+area.Width/2, area.Y+area.Height-2)
+	if state.IsAnyModalShowing() {
+		t.Error("clicking the details modal's instruction row after resize did not close it")
+	}
+}
+
+func TestPlanetListScrollsToSelection(t *testing.T) {
+	dispatcher, state, _ := newAppFixture(t, 100, 40)
+
+	bodies := []models.CelestialBody{{ID: "star", EnglishName: "Star", BodyType: "Star"}}
+	for i := 1; i <= 150; i++ {
+		bodies = append(bodies, models.CelestialBody{
+			ID:            fmt.Sprintf("rock-%d", i),
+			EnglishName:   fmt.Sprintf("Rock %d", i),
+			BodyType:      "Asteroid",
+			SemimajorAxis: float64(i) * 1e7,
+		})
+	}
+	state.SetPlanets(bodies)
+	state.SelectedIndex = len(bodies) - 1
+	state.Publish()
+
+	ur := dispatcher.uiRenderer
+	ur.state = state.Frame()
+	ur.drawPlanetList(layout.Rect{X: 2, Y: 3, Width: 60, Height: 3})
+
+	positions := ur.state.GetPlanetListPositions()
+	if len(positions) == 0 || len(positions) >= len(bodies) {
+		t.Fatalf("drew %d of %d bodies, want only the rows that fit", len(positions), len(bodies))
+	}
+	last := positions[len(positions)-1]
+	if last.Index != len(bodies)-1 {
+		t.Errorf("last drawn body is %d, want the selected %d", last.Index, len(bodies)-1)
+	}
+	for _, pos := range positions {
+		if pos.Y < 3 || pos.Y >= 6 {
+			t.Errorf("body %d drawn on row %d, outside the list", pos.Index, pos.Y)
+		}
+	}
+}
+
+func TestPlanetListPages(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 100, 40)
+
+	bodies := []models.CelestialBody{{ID: "star", EnglishName: "Star", BodyType: "Star"}}
+	for i := 1; i <= 150; i++ {
+		bodies = append(bodies, models.CelestialBody{
+			ID:            fmt.Sprintf("rock-%d", i),
+			EnglishName:   fmt.Sprintf("Rock %d", i),
+			BodyType:      "Asteroid",
+			SemimajorAxis: float64(i) * 1e7,
+		})
+	}
+	state.SetPlanets(bodies)
+	state.SelectedIndex = 0
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+
+	list := layout.Compute(100, 40).List
+	first := state.GetPlanetListPositions()
+	if len(first) == 0 || first[0].Index != 0 {
+		t.Fatalf("first page starts at %v, want the star", first)
+	}
+	for _, pos := range first {
+		if pos.Y < list.Y || pos.Y >= list.Y+list.Height-1 {
+			t.Errorf("body %d drawn on row %d, over the counts or outside the list", pos.Index, pos.Y)
+		}
+	}
+	counts := fmt.Sprintf("1-%d of 151", len(first))
+	if text := screenText(screen); !strings.Contains(text, counts) {
+		t.Errorf("expected %q under the list, got:\n%s", counts, text)
+	}
+
+	// Stepping within the page leaves every body where it was
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+	dispatcher.uiRenderer.DrawScreen()
+	if again := state.GetPlanetListPositions(); len(again) != len(first) || again[3] != first[3] {
+		t.Errorf("stepping moved the page's hitboxes: %v, was %v", again, first)
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone))
+	if want := len(first); state.SelectedIndex != want {
+		t.Fatalf("Right selected %d, want %d, the first body of the next page", state.SelectedIndex, want)
+	}
+	dispatcher.uiRenderer.DrawScreen()
+	second := state.GetPlanetListPositions()
+	if second[0].Index != len(first) || second[0].Y != list.Y {
+		t.Errorf("second page starts with %d on row %d", second[0].Index, second[0].Y)
+	}
+	click(dispatcher, second[1].X+1, second[1].Y)
+	if state.SelectedIndex != second[1].Index {
+		t.Errorf("clicking %d on the second page selected %d", second[1].Index, state.SelectedIndex)
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone))
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone))
+	if state.SelectedIndex != 0 {
+		t.Errorf("Left twice selected %d, want the first body", state.SelectedIndex)
+	}
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyPgDn, 0, tcell.ModNone))
+	for i := 0; i < 100; i++ {
+		dispatcher.uiRenderer.DrawScreen()
+		dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyPgDn, 0, tcell.ModNone))
+	}
+	if state.SelectedIndex != len(bodies)-1 {
+		t.Errorf("paging to the end selected %d, want the last body", state.SelectedIndex)
+	}
+}
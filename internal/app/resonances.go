@@ -0,0 +1,61 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/orbital"
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+// toggleResonances draws or hides the links between orbits in resonance, and says
+// which pairs are on the map
+func (ed *EventDispatcher) toggleResonances() {
+	if !ed.uiRenderer.toggleResonances() {
+		ed.state.SetStatusMessage("Resonance links hidden", statusMessageDuration)
+		return
+	}
+
+	bodies := ed.state.GetPlanets()
+	if ed.state.Focusing {
+		bodies = ed.state.FocusSatellites
+	}
+	resonances := orbital.FindResonances(bodies)
+	if len(resonances) == 0 {
+		ed.state.SetStatusMessage("No neighbouring orbits here are near a resonance", statusMessageDuration)
+		return
+	}
+	pairs := make([]string, len(resonances))
+	for i, resonance := range resonances {
+		pairs[i] = fmt.Sprintf("%s-%s %s", resonance.Inner.EnglishName, resonance.Outer.EnglishName, resonance.Label())
+	}
+	ed.state.SetStatusMessage("Resonances (period ratios): "+strings.Join(pairs, ", "), statusMessageDuration)
+}
+
+// toggleResonances starts or stops drawing resonance links, between frames, and
+// reports whether they are now drawn
+func (ur *UIRenderer) toggleResonances() bool {
+	ur.drawMu.Lock()
+	defer ur.drawMu.Unlock()
+	shown := !ur.renderer.ResonancesShown()
+	ur.renderer.SetResonancesShown(shown)
+	if ur.compareRenderer != nil {
+		ur.compareRenderer.SetResonancesShown(shown)
+	}
+	return shown
+}
+
+// drawResonanceLabels writes the period ratio halfway along each resonance link
+// the renderer drew into a grid placed at x, y
+func (ur *UIRenderer) drawResonanceLabels(renderer *visualization.Renderer, x, y, width, height int) {
+	style := tcell.StyleDefault.Foreground(renderer.InkColor(renderer.GetSymbols().Resonance)).Bold(true)
+	for _, link := range renderer.ResonanceLinks() {
+		label := link.Resonance.Label()
+		col := min(max(link.X-len(label)/2, 0), width-len(label))
+		if col < 0 || link.Y < 0 || link.Y >= height {
+			continue
+		}
+		ur.drawText(x+col, y+link.Y, style, label)
+	}
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"math"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/portrait"
+	"github.com/gdamore/tcell/v2"
+)
+
+// maxSpinTurnsPerSecond caps how fast the rotation marker goes round a portrait.
+// Faster than this, a frame would move it most of the way round and the way it
+// turns would be lost, so the spin is slowed to this rate, keeping its direction.
+const maxSpinTurnsPerSecond = 0.25
+
+// rotationLongitude returns how far round a body has turned at the simulated time
+// now, in radians, for a clock running speed simulated seconds per real second.
+// A negative rotation period is a retrograde spin and turns the other way. ok is
+// false for bodies whose rotation period is not known.
+func rotationLongitude(body models.CelestialBody, now time.Time, speed float64) (float64, bool) {
+	if body.SideralRotation == 0 {
+		return 0, false
+	}
+
+	turns := now.Sub(models.J2000).Hours() / body.SideralRotation
+	if spin := math.Abs(speed) / 3600 / math.Abs(body.SideralRotation); spin > maxSpinTurnsPerSecond {
+		turns *= maxSpinTurnsPerSecond / spin
+	}
+	return 2 * math.Pi * math.Mod(turns, 1), true
+}
+
+// drawRotationMarker marks a spot on the equator of a body's portrait, drawn at
+// x, y, that goes round with the body as the simulation runs. The marker is only
+// drawn over the disc, and not while the spot is on the far side.
+func (ur *UIRenderer) drawRotationMarker(x, y int, body models.CelestialBody, p portrait.Portrait) {
+	clock := ur.renderer.GetClock()
+	longitude, ok := rotationLongitude(body, clock.Now(), clock.Speed())
+	if !ok {
+		return
+	}
+	col, row, visible := portrait.EquatorCell(longitude)
+	if !visible || p.At(col, row).Glyph == 0 {
+		return
+	}
+
+	marker := '●'
+	if ur.renderer.GetSymbols().ASCII {
+		marker = 'o'
+	}
+	style := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.screen.SetContent(x+col, y+row, marker, nil, style)
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// turnedBetween is how far round a body turns from one time to another, in
+// radians from -π to π
+func turnedBetween(t *testing.T, body models.CelestialBody, from, to time.Time, speed float64) float64 {
+	t.Helper()
+	start, ok := rotationLongitude(body, from, speed)
+	end, ok2 := rotationLongitude(body, to, speed)
+	if !ok || !ok2 {
+		t.Fatalf("%s has no rotation", body.EnglishName)
+	}
+	return math.Remainder(end-start, 2*math.Pi)
+}
+
+func TestRotationLongitude(t *testing.T) {
+	earth := models.CelestialBody{EnglishName: "Earth", SideralRotation: 24}
+	venus := models.CelestialBody{EnglishName: "Venus", SideralRotation: -5832.5}
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if turned := turnedBetween(t, earth, start, start.Add(6*time.Hour), 1); math.Abs(turned-math.Pi/2) > 1e-6 {
+		t.Errorf("Earth turned %.3f rad in 6 hours, want a quarter turn", turned)
+	}
+	if turned := turnedBetween(t, venus, start, start.Add(24*time.Hour), 1); turned >= 0 {
+		t.Errorf("Venus turned %.5f rad in a day, want it turning backwards", turned)
+	}
+
+	// A day a second would spin Earth once a second; the marker is slowed to
+	// maxSpinTurnsPerSecond, still turning the same way
+	turned := turnedBetween(t, earth, start, start.Add(time.Hour), 86400)
+	want := 2 * math.Pi * maxSpinTurnsPerSecond / 24
+	if math.Abs(turned-want) > 1e-6 {
+		t.Errorf("Earth turned %.4f rad in a simulated hour at a day a second, want %.4f", turned, want)
+	}
+
+	if _, ok := rotationLongitude(models.CelestialBody{EnglishName: "Rock"}, start, 1); ok {
+		t.Error("a body with no rotation period has a rotation")
+	}
+}
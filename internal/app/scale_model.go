@@ -0,0 +1,166 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/scalemodel"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// defaultScaleInput is the star's diameter the scale model opens with, in cm:
+	// a football
+	defaultScaleInput = "22"
+
+	// maxScaleInputLength keeps the typed size to a sensible number of digits
+	maxScaleInputLength = 7
+)
+
+// openScaleModel shows the loaded system shrunk so its star is a football
+func (ed *EventDispatcher) openScaleModel() {
+	ed.state.ShowScaleModel(defaultScaleInput)
+}
+
+// handleScaleKeys handles keyboard input while the scale model is open: digits
+// and a decimal point edit the star's size, ←/→ step through everyday objects
+// to use instead and ↑/↓ scroll the list
+func (ed *EventDispatcher) handleScaleKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyEnter:
+		ed.state.PopModal()
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if input := ed.state.ScaleInput; input != "" {
+			ed.state.ScaleInput = input[:len(input)-1]
+		}
+	case tcell.KeyLeft:
+		ed.state.ScaleInput = stepScaleObject(ed.state.ScaleInput, -1)
+	case tcell.KeyRight:
+		ed.state.ScaleInput = stepScaleObject(ed.state.ScaleInput, 1)
+	case tcell.KeyUp:
+		if ed.state.ScaleScroll > 0 {
+			ed.state.ScaleScroll--
+		}
+	case tcell.KeyDown:
+		if ed.state.ScaleScroll < len(buildScaleLines(ed.state.ScaleInput, ed.state))-1 {
+			ed.state.ScaleScroll++
+		}
+	case tcell.KeyRune:
+		r := ev.Rune()
+		switch {
+		case r >= '0' && r <= '9', r == '.' && !strings.Contains(ed.state.ScaleInput, "."):
+			if len(ed.state.ScaleInput) < maxScaleInputLength {
+				ed.state.ScaleInput += string(r)
+			}
+		case r == 'q', r == 'Q', r == 'b', r == 'B':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// stepScaleObject moves from a typed size in cm to the next larger or smaller
+// everyday object, returning its size in cm
+func stepScaleObject(input string, direction int) string {
+	size, _ := strconv.ParseFloat(input, 64)
+	size /= 100
+	objects := scalemodel.Objects
+	pick := objects[0]
+	if direction > 0 {
+		pick = objects[len(objects)-1]
+		for _, object := range objects {
+			if object.Diameter > size+1e-9 {
+				pick = object
+				break
+			}
+		}
+	} else {
+		for _, object := range objects {
+			if object.Diameter < size-1e-9 {
+				pick = object
+			}
+		}
+	}
+	return strconv.FormatFloat(pick.Diameter*100, 'f', -1, 64)
+}
+
+// scaleObjectLabel names the everyday object the typed size matches, if any
+func scaleObjectLabel(input string) string {
+	size, err := strconv.ParseFloat(input, 64)
+	if err != nil {
+		return ""
+	}
+	if object, ok := scalemodel.ObjectSized(size / 100); ok {
+		return "a " + object.Name
+	}
+	return ""
+}
+
+// buildScaleLines lists each loaded body's diameter and distance from the star
+// in the model
+func buildScaleLines(input string, state *AppState) []statsLine {
+	size, err := strconv.ParseFloat(input, 64)
+	if err != nil || size <= 0 {
+		return []statsLine{{heading: true, label: "Type the star's diameter in cm to build the model"}}
+	}
+	model, err := scalemodel.New(state.GetPlanets(), size/100)
+	if err != nil {
+		return []statsLine{{heading: true, label: "This system has " + err.Error()}}
+	}
+
+	lines := []statsLine{{heading: true, label: fmt.Sprintf("%s %s across", model.Star, scalemodel.FormatLength(size/100))}}
+	for _, body := range model.Bodies {
+		value := scalemodel.FormatLength(body.Diameter) + " across"
+		if body.Distance > 0 {
+			value += " • " + scalemodel.FormatLength(body.Distance) + " from the star"
+		}
+		lines = append(lines, statsLine{label: body.Name, value: value})
+	}
+	return lines
+}
+
+// calculateScaleLines returns the number of content lines in the scale model
+func (ur *UIRenderer) calculateScaleLines(state *AppState) int {
+	return len(buildScaleLines(state.ScaleInput, state)) + 2 // input and spacer
+}
+
+// drawScaleModal renders the star's size input and the model of every body
+func (ur *UIRenderer) drawScaleModal(width, height int) {
+	dynamicHeight := minimum(ur.calculateScaleLines(ur.state)+6, height-4)
+	modalX, modalY, _, modalHeight := ur.setupModal(width, height, dynamicHeight)
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, " 📏 Scale Model ")
+
+	inputStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorWhite)
+	labelStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	valueStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	headingStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue).Bold(true)
+
+	ur.drawText(modalX+2, modalY+3, valueStyle, "Star:")
+	ur.drawText(modalX+8, modalY+3, inputStyle, fmt.Sprintf(" %-*s ", maxScaleInputLength, ur.state.ScaleInput+"_"))
+	unit := "cm"
+	if object := scaleObjectLabel(ur.state.ScaleInput); object != "" {
+		unit += ", " + object
+	}
+	ur.drawText(modalX+8+maxScaleInputLength+3, modalY+3, valueStyle, unit)
+
+	lines := buildScaleLines(ur.state.ScaleInput, ur.state)
+	top := modalY + 5
+	visible := modalY + modalHeight - 3 - top
+	scroll := minimum(ur.state.ScaleScroll, max(len(lines)-visible, 0))
+	for i := 0; i < visible && scroll+i < len(lines); i++ {
+		line := lines[scroll+i]
+		if line.heading {
+			ur.drawText(modalX+2, top+i, headingStyle, line.label)
+			continue
+		}
+		ur.drawText(modalX+2, top+i, labelStyle, truncateText(line.label, statsLabelColumn-1))
+		ur.drawText(modalX+2+statsLabelColumn, top+i, valueStyle, truncateText(line.value, ur.contentWidth()-statsLabelColumn))
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "Type a size • ←/→ everyday objects • ↑/↓ scroll • Enter or Esc to close")
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestScaleModel(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'm', tcell.ModNone))
+	dispatcher.uiRenderer.DrawScreen()
+	if state.TopModal() != ModalScaleModel {
+		t.Fatalf("Expected m to open the scale model, got modal %v", state.TopModal())
+	}
+	text := screenText(screen)
+	if !strings.Contains(text, "a football") || !strings.Contains(text, "Sun 22.0 cm across") {
+		t.Fatalf("Expected the Sun as a football, got:\n%s", text)
+	}
+	if !strings.Contains(text, "Earth") || !strings.Contains(text, "23.7 m from the star") {
+		t.Fatalf("Expected Earth's distance in the model, got:\n%s", text)
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone))
+	if state.ScaleInput != "50" {
+		t.Errorf("Expected → to step up to the beach ball, got %q", state.ScaleInput)
+	}
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone))
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone))
+	if state.ScaleInput != "4.3" {
+		t.Errorf("Expected ← from 5 cm to step down to the golf ball, got %q", state.ScaleInput)
+	}
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/capture"
+)
+
+// screenshotDir is where screenshot bundles are written, relative to the working directory
+const screenshotDir = "screenshots"
+
+// statusMessageDuration is how long status line messages stay visible
+const statusMessageDuration = 5 * time.Second
+
+// captureScreenshot writes the next drawn frame as a screenshot bundle (ANSI, PNG and
+// scene JSON). The capture runs as a frame hook so it sees exactly what is on screen.
+func (ed *EventDispatcher) captureScreenshot() {
+	ed.uiRenderer.AddFrameHook(func(frame Frame) bool {
+		snapshot := capture.SnapshotScreen(frame.Screen)
+		scene := buildScene(frame, snapshot, ed.uiRenderer.GetRenderer().GetPlanetSymbol, ed.uiRenderer.clock.Now())
+
+		dir, err := capture.WriteBundle(screenshotDir, snapshot, scene)
+		if err != nil {
+			ed.state.SetStatusMessage(fmt.Sprintf("Screenshot failed: %v", err), statusMessageDuration)
+			return false
+		}
+
+		ed.state.SetStatusMessage(fmt.Sprintf("Screenshot saved to %s", dir), statusMessageDuration)
+		return false
+	})
+}
+
+// buildScene describes the bodies of a frame in both world and screen coordinates
+func buildScene(frame Frame, snapshot capture.Frame, symbolFor func(string) rune, now time.Time) capture.Scene {
+	scene := capture.Scene{
+		CapturedAt: now,
+		System:     frame.System,
+		Camera: capture.Camera{
+			OriginX:     frame.Camera.OriginX,
+			OriginY:     frame.Camera.OriginY,
+			Width:       frame.Camera.Width,
+			Height:      frame.Camera.Height,
+			AspectRatio: frame.Camera.AspectRatio,
+			Scale:       frame.Camera.Scale,
+		},
+		Selected: frame.Selected.EnglishName,
+	}
+	scene.Terminal.Width = snapshot.Width
+	scene.Terminal.Height = snapshot.Height
+
+	for name, pos := range frame.Positions {
+		scene.Bodies = append(scene.Bodies, capture.Body{
+			Name:          name,
+			ID:            pos.Planet.ID,
+			BodyType:      pos.Planet.BodyType,
+			Symbol:        string(symbolFor(name)),
+			SemimajorAxis: pos.Planet.SemimajorAxis,
+			Eccentricity:  pos.Planet.Eccentricity,
+			World:         capture.Point{X: pos.World.X, Y: pos.World.Y},
+			Screen:        capture.ScreenPoint{X: pos.X, Y: pos.Y, Radius: pos.Radius},
+		})
+	}
+
+	// Order bodies outward from the star so bundles diff cleanly
+	sort.Slice(scene.Bodies, func(i, j int) bool {
+		if scene.Bodies[i].SemimajorAxis != scene.Bodies[j].SemimajorAxis {
+			return scene.Bodies[i].SemimajorAxis < scene.Bodies[j].SemimajorAxis
+		}
+		return scene.Bodies[i].Name < scene.Bodies[j].Name
+	})
+
+	return scene
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/capture"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestScreenshotKeyWritesBundle(t *testing.T) {
+	dispatcher, state, _ := newAppFixture(t, 120, 40)
+
+	// Bundles go under the working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyF12, 0, tcell.ModNone))
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+
+	bundles, err := filepath.Glob(filepath.Join(dir, screenshotDir, "screenshot-*"))
+	if err != nil || len(bundles) != 1 {
+		t.Fatalf("Expected one timestamped bundle, got %v (%v)", bundles, err)
+	}
+	for _, name := range []string{capture.ANSIFileName, capture.PNGFileName, capture.SceneFileName} {
+		info, err := os.Stat(filepath.Join(bundles[0], name))
+		if err != nil || info.Size() == 0 {
+			t.Errorf("Expected %s in the bundle, got %v", name, err)
+		}
+	}
+	scene, err := os.ReadFile(filepath.Join(bundles[0], capture.SceneFileName))
+	if err != nil || !strings.Contains(string(scene), `"Jupiter"`) {
+		t.Errorf("Expected the scene to list the bodies drawn, got %s (%v)", scene, err)
+	}
+	if msg := state.GetStatusMessage(); !strings.HasPrefix(msg, "Screenshot saved to ") {
+		t.Errorf("status = %q, want where the bundle went", msg)
+	}
+}
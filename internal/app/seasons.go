@@ -0,0 +1,48 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+)
+
+// seasonOf returns the northern season a planet is having at the simulated date
+// and its solar longitude. ok is false for planets whose equinox is not known and
+// for ones without a tilt, which have no seasons to speak of.
+func (ur *UIRenderer) seasonOf(body models.CelestialBody) (orbital.Season, float64, bool) {
+	if body.AxialTilt == 0 {
+		return 0, 0, false
+	}
+	ls, ok := ur.renderer.GetEphemeris().SolarLongitude(body, ur.renderer.GetClock().Now())
+	if !ok {
+		return 0, 0, false
+	}
+	return orbital.SeasonAt(ls), ls, true
+}
+
+// seasonDetail is the season line of a planet's details, or "" if it has none
+func (ur *UIRenderer) seasonDetail(body models.CelestialBody) string {
+	season, ls, ok := ur.seasonOf(body)
+	if !ok {
+		return ""
+	}
+
+	latitude := orbital.SubsolarLatitude(body.AxialTilt, ls)
+	hemisphere := "N"
+	if latitude < 0 {
+		hemisphere = "S"
+	}
+	return fmt.Sprintf("Season: northern %s, southern %s (Ls %.0f°, Sun overhead at %.1f°%s)",
+		season, season.Opposite(), ls, math.Abs(latitude), hemisphere)
+}
+
+// seasonDetailLines is how many lines the season takes in a planet's details
+func (ur *UIRenderer) seasonDetailLines(body models.CelestialBody, maxWidth int) int {
+	detail := ur.seasonDetail(body)
+	if detail == "" {
+		return 0
+	}
+	return len(ur.wrapText(detail, maxWidth))
+}
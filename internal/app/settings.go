@@ -0,0 +1,20 @@
+package app
+
+import "github.com/furan917/go-solar-system/internal/config"
+
+// settings is the user's config file, kept so choices made in the app can be
+// written back to it
+type settings struct {
+	path   string // empty when there is nowhere to save
+	config config.Config
+}
+
+// update applies change and saves the result. Without a config file the change
+// lasts for this session only.
+func (s *settings) update(change func(*config.Config)) error {
+	change(&s.config)
+	if s.path == "" {
+		return nil
+	}
+	return config.Save(s.path, s.config)
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/visualization"
+)
+
+// screenLayout is where one frame drew the things clicks and hovers are matched
+// against. A frame fills in its own and hands it to the live state when drawn, so
+// the event goroutine only ever sees a finished one.
+type screenLayout struct {
+	planetPositions map[string]visualization.PlanetPosition
+	planetList      []PlanetListPosition
+	listPages       []int                // the first body on each page of the list
+	tabs            []PlanetListPosition // Index is the tab
+
+	// The overview inset of the centred map, and where its bodies were drawn
+	overview          layout.Rect
+	overviewPositions map[string]visualization.PlanetPosition
+}
+
+// Publish takes a frame of the state for the display goroutine to draw. It runs on
+// the event goroutine once the state is settled, after each event and before the
+// first frame. Slices the event goroutine edits in place are copied; the rest are
+// only ever replaced, so sharing them is safe.
+func (s *AppState) Publish() {
+	frame := &AppState{
+		appView:     s.appView,
+		sharedState: s.sharedState,
+		layout:      &screenLayout{},
+	}
+	frame.Planets = slices.Clone(s.Planets)
+	frame.ComparePlanets = slices.Clone(s.ComparePlanets)
+	frame.modals = slices.Clone(s.modals)
+	frame.TabSelected = maps.Clone(s.TabSelected)
+	frame.TreeExpanded = maps.Clone(s.TreeExpanded)
+	frame.TabBodies = make(map[BodyTab][]models.CelestialBody, len(s.TabBodies))
+	for tab, bodies := range s.TabBodies {
+		frame.TabBodies[tab] = slices.Clone(bodies)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.frame = frame
+}
+
+// Frame returns the last published frame, to be drawn and then thrown away
+func (s *AppState) Frame() *AppState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.frame
+}
+
+// startLayout gives a frame an empty layout to draw into, as it may be drawn more
+// than once and the layout it last drew has been handed over
+func (s *AppState) startLayout() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layout = &screenLayout{}
+}
+
+// setLayout makes layout the one clicks are matched against
+func (s *AppState) setLayout(layout *screenLayout) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layout = layout
+}
+
+// drawnLayout returns the layout the state holds
+func (s *AppState) drawnLayout() *screenLayout {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.layout
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+)
+
+// toggleSpheres draws faint circles for the selected body's Hill sphere and Roche
+// limit, or takes them off the map, and says how big they are
+func (ed *EventDispatcher) toggleSpheres() {
+	if !ed.uiRenderer.toggleSpheres() {
+		ed.state.SetStatusMessage("Hill sphere and Roche limit hidden", statusMessageDuration)
+		return
+	}
+	symbols := ed.uiRenderer.renderer.GetSymbols()
+	ed.state.SetStatusMessage(spheresStatus(ed.state.SelectedPlanet, symbols.Hill, symbols.Roche), statusMessageDuration)
+}
+
+// toggleSpheres starts or stops drawing the spheres, between frames, and reports
+// whether they are now drawn
+func (ur *UIRenderer) toggleSpheres() bool {
+	ur.drawMu.Lock()
+	defer ur.drawMu.Unlock()
+	ur.showSpheres = !ur.showSpheres
+	return ur.showSpheres
+}
+
+// spheresBody is the body the map draws the spheres of: the selected one while
+// they are shown, else none
+func (ur *UIRenderer) spheresBody() string {
+	if !ur.showSpheres {
+		return ""
+	}
+	return ur.state.SelectedPlanet.EnglishName
+}
+
+// spheresStatus says how far a body's Hill sphere and Roche limit reach, with the
+// symbols they are drawn in, or what is missing to work them out
+func spheresStatus(body models.CelestialBody, hillSymbol, rocheSymbol rune) string {
+	var parts []string
+	if hill, ok := orbital.BodyHillRadius(body); ok {
+		parts = append(parts, fmt.Sprintf("Hill sphere %c %s", hillSymbol, formatSphereRadius(hill)))
+	}
+	if roche, ok := orbital.BodyRocheLimit(body); ok {
+		parts = append(parts, fmt.Sprintf("Roche limit %c %s", rocheSymbol, formatSphereRadius(roche)))
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("No Hill sphere or Roche limit for %s: it needs a mass and an orbit, or a radius and a density", body.EnglishName)
+	}
+	return fmt.Sprintf("%s round %s; X centres on it when they are too small to see", strings.Join(parts, ", "), body.EnglishName)
+}
+
+// formatSphereRadius writes a radius in km, in millions once it runs that long
+func formatSphereRadius(km float64) string {
+	if km >= 1e6 {
+		return fmt.Sprintf("%.3g million km", km/1e6)
+	}
+	return formatCount(int(math.Round(km))) + " km"
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSpheresToggle(t *testing.T) {
+	dispatcher, state, _ := newAppFixture(t, 120, 40)
+	earth := state.GetPlanets()[3]
+	earth.Mass = models.Mass{MassValue: 5.97237, MassExponent: 24}
+	state.UpdatePlanetSelection(3, earth)
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'u', tcell.ModNone))
+	if msg := state.GetStatusMessage(); !strings.Contains(msg, "Hill sphere ∴ 1.5 million km") || !strings.Contains(msg, "Roche limit ∵") {
+		t.Fatalf("Expected u to give Earth's spheres, got %q", msg)
+	}
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+	if body := dispatcher.uiRenderer.renderer.SpheresBody(); body != "Earth" {
+		t.Errorf("SpheresBody() = %q, want Earth", body)
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'u', tcell.ModNone))
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+	if body := dispatcher.uiRenderer.renderer.SpheresBody(); body != "" || state.GetStatusMessage() != "Hill sphere and Roche limit hidden" {
+		t.Errorf("Expected u again to hide the spheres, got %q and %q", body, state.GetStatusMessage())
+	}
+}
+
+func TestSpheresStatusWithoutMass(t *testing.T) {
+	if got := spheresStatus(models.CelestialBody{EnglishName: "Vulcan"}, '∴', '∵'); !strings.HasPrefix(got, "No Hill sphere or Roche limit for Vulcan") {
+		t.Errorf("spheresStatus() = %q", got)
+	}
+}
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/gdamore/tcell/v2"
+)
+
+// spinnerFrames are the frames of the loading spinner, one per spinnerStep
+const spinnerFrames = `|/-\`
+
+const spinnerStep = 100 * time.Millisecond
+
+// startupEvent carries the progress of loading the first system into the event loop
+type startupEvent struct {
+	tcell.EventTime
+	status string                 // what startup is waiting for now
+	bodies []models.CelestialBody // bodies to show so far, if any
+	done   bool                   // the first system is loaded, or failed to load
+	err    error
+}
+
+// loadStartupSystem loads the system the app opens on off the event goroutine,
+// posting what it is waiting for and the bodies it has so far. The Solar System
+// shows the bodies the store kept last run while the API is asked for fresh ones.
+func (ss *SolarSystem) loadStartupSystem(ctx context.Context, system string) {
+	post := func(event *startupEvent) {
+		event.SetEventNow()
+		postEvent(ctx, ss.screen, event)
+	}
+
+	if system == "solar-system" {
+		if stored := ss.planetService.GetClient().StoredPlanets(); len(stored) > 0 {
+			post(&startupEvent{status: fmt.Sprintf("Showing %d stored bodies while the API answers", len(stored)), bodies: stored})
+		} else {
+			post(&startupEvent{status: "Asking the API for the Solar System"})
+		}
+	} else {
+		// Nothing else uses the system files until startup is done, so a large one
+		// can be read and kept from here
+		files := ss.renderer.GetSystemManager()
+		name := files.GetSystemDisplayName(system)
+		post(&startupEvent{status: "Reading " + name})
+		if files.IsLarge(system) {
+			data, err := files.ReadSystem(ctx, system, loadProgress(name, files.FileSize(system), func(status string) {
+				post(&startupEvent{status: status})
+			}))
+			if err != nil {
+				post(&startupEvent{done: true, err: err})
+				return
+			}
+			files.KeepSystem(system, data)
+		}
+	}
+
+	bodies, err := ss.systemManager.fetchSystem(system)
+	post(&startupEvent{bodies: bodies, done: true, err: err})
+}
+
+// postEvent posts an event to the event loop from another goroutine. Loading waits
+// on these events, so one is retried while the queue is full, until ctx is done.
+func postEvent(ctx context.Context, screen tcell.Screen, event tcell.Event) {
+	for screen.PostEvent(event) != nil {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// applyStartup shows what startup has loaded so far, returning the error that
+// stops the app when the first system cannot be loaded
+func (ss *SolarSystem) applyStartup(ev *startupEvent) error {
+	if ev.err != nil {
+		ss.errorHandler.HandleError(NewSystemError("failed to load initial system", ev.err))
+		return ev.err
+	}
+
+	if len(ev.bodies) > 0 {
+		ss.systemManager.showBodies(ev.bodies)
+	}
+	if !ev.done {
+		ss.state.Loading = ev.status
+		return nil
+	}
+
+	ss.state.Loading = ""
+	if err := ss.state.ValidateState(); err != nil {
+		ss.errorHandler.HandleError(NewStateError("invalid state after loading", err))
+	}
+	ss.logger.Debugf("Loaded %d bodies for %s", len(ss.state.GetPlanets()), ss.renderer.GetSystemManager().GetCurrentSystem())
+	ss.systemManager.checkPhysics()
+	return nil
+}
+
+// handleLoadingKeys handles keyboard input while a system loads. Quitting stops
+// a large system file being read, or before the first system is in quits.
+func (ed *EventDispatcher) handleLoadingKeys(ev *tcell.EventKey) {
+	if action, ok := ed.keys.Action(keymap.ContextGlobal, ev); ok {
+		switch action {
+		case keymap.ActionScreenshot:
+			ed.captureScreenshot()
+		case keymap.ActionDebug:
+			ed.state.ToggleDebugOverlay()
+		}
+		return
+	}
+	if action, ok := ed.keys.Action(keymap.ContextMain, ev); ok && action == keymap.ActionQuit {
+		if ed.systemManager == nil || !ed.systemManager.stopLoading() {
+			ed.state.SetRunning(false)
+		}
+	}
+}
+
+// loadingSpinner returns the spinner frame to show at now
+func loadingSpinner(now time.Time) string {
+	i := int(now.UnixMilli()/spinnerStep.Milliseconds()) % len(spinnerFrames)
+	return spinnerFrames[i : i+1]
+}
+
+// drawLoadingScreen fills the screen while startup has no bodies to show yet. It
+// reads nothing from the system files, which are still being loaded.
+func (ur *UIRenderer) drawLoadingScreen(width, height int) {
+	title := "Solar System Explorer"
+	status := loadingSpinner(ur.clock.Now()) + " " + ur.state.Loading
+	hint := ur.keys.Primary(keymap.ActionQuit) + " to quit"
+
+	y := height/2 - 1
+	ur.drawText(max((width-ui.TextWidth(title))/2, 0), y, tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true), title)
+	ur.drawText(max((width-ui.TextWidth(status))/2, 0), y+2, tcell.StyleDefault.Foreground(tcell.ColorWhite), status)
+	ur.drawText(max((width-ui.TextWidth(hint))/2, 0), y+4, tcell.StyleDefault.Foreground(tcell.ColorGray), hint)
+}
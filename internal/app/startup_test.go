@@ -0,0 +1,67 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/logging"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestStartupShowsBodiesAsTheyArrive(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+	state.SetPlanets(nil)
+	state.Loading = "Starting up"
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+
+	if text := screenText(screen); !strings.Contains(text, "Starting up") {
+		t.Fatalf("Expected the loading screen before any bodies arrive, got:\n%s", text)
+	}
+
+	// Keys other than quitting wait for the system
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone))
+	if state.SelectedIndex != 0 || !state.IsRunning() {
+		t.Fatalf("Expected keys to be ignored while loading, selected %d", state.SelectedIndex)
+	}
+
+	logger := logging.Discard()
+	errorHandler := NewErrorHandler(logger, state)
+	ss := &SolarSystem{
+		state:         state,
+		logger:        logger,
+		errorHandler:  errorHandler,
+		renderer:      dispatcher.uiRenderer,
+		systemManager: NewSystemManager(state, nil, dispatcher.uiRenderer, errorHandler, logger),
+	}
+
+	stored := []models.CelestialBody{
+		{ID: "terre", EnglishName: "Earth", BodyType: "Planet", IsPlanet: true, SemimajorAxis: 149598262, SideralOrbit: 365.256, MeanRadius: 6371},
+	}
+	if err := ss.applyStartup(&startupEvent{status: "Showing 1 stored bodies while the API answers", bodies: stored}); err != nil {
+		t.Fatalf("applyStartup() error = %v", err)
+	}
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+
+	if len(state.GetPlanets()) != 2 {
+		t.Fatalf("Expected the stored body and a central star, got %d bodies", len(state.GetPlanets()))
+	}
+	if text := screenText(screen); !strings.Contains(text, "Earth") || !strings.Contains(text, "while the API answers") {
+		t.Fatalf("Expected the stored bodies drawn with the loading status below, got:\n%s", text)
+	}
+
+	fresh := append(stored, models.CelestialBody{ID: "mars", EnglishName: "Mars", BodyType: "Planet", IsPlanet: true, SemimajorAxis: 227943824, SideralOrbit: 686.98, MeanRadius: 3389})
+	if err := ss.applyStartup(&startupEvent{bodies: fresh, done: true}); err != nil {
+		t.Fatalf("applyStartup() error = %v", err)
+	}
+	if state.Loading != "" || len(state.GetPlanets()) != 3 {
+		t.Fatalf("Expected startup finished with 3 bodies, got %q and %d", state.Loading, len(state.GetPlanets()))
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone))
+	if state.IsRunning() {
+		t.Error("Expected q to quit once loaded")
+	}
+}
@@ -2,43 +2,283 @@ package app
 
 import (
 	"sync"
+	"time"
 
 	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/events"
+	"github.com/furan917/go-solar-system/internal/filter"
+	"github.com/furan917/go-solar-system/internal/layout"
 	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+	"github.com/furan917/go-solar-system/internal/quiz"
+	"github.com/furan917/go-solar-system/internal/systems"
+	"github.com/furan917/go-solar-system/internal/updates"
 	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/furan917/go-solar-system/internal/watch"
 )
 
-// AppState manages all application state for the solar system application
-// Critical fields use thread-safe access, others maintain backward compatibility
+// AppState manages all application state for the solar system application.
+//
+// The event goroutine owns it and changes it freely. After each event it publishes
+// a frame: a copy the display goroutine draws from and never changes, so the two
+// never touch the same fields. Publish copies the slices and maps that are edited
+// in place; any other slice or map field must only ever be replaced. State both
+// goroutines write, such as the status line, is in sharedState behind its lock,
+// and where things were drawn comes back from each frame in a screenLayout.
 type AppState struct {
-	// Protects critical concurrent access
-	mu sync.RWMutex
+	appView
+	*sharedState
+
+	// layout is where the last frame drew what clicks are matched against; a frame
+	// fills its own and hands it over once drawn. Guarded by sharedState's lock.
+	layout *screenLayout
+}
 
+// appView is the state the event goroutine owns, copied into each frame
+type appView struct {
 	// Core data - centralized to avoid scattered state
-	Planets             []models.CelestialBody
-	PlanetPositions     map[string]visualization.PlanetPosition
-	PlanetListPositions []PlanetListPosition
-	CurrentSystem       string
+	Planets       []models.CelestialBody
+	CurrentSystem string
+
+	// Loading says what startup is waiting for; empty once the first system is in
+	Loading string
+
+	// Mouse hover, for tooltips: where the pointer rests and since when
+	Hovering   bool
+	HoverX     int
+	HoverY     int
+	HoverSince time.Time
 
 	// Navigation state
 	SelectedIndex  int
 	SelectedPlanet models.CelestialBody
 	SelectedMoon   models.CelestialBody
 
-	// UI visibility state
-	ShowingDetails     bool
-	ShowingMoons       bool
-	ShowingMoonDetails bool
-	ShowingSystemList  bool
+	// Planet list ordering
+	SortMode    SortMode
+	GroupByType bool
+
+	// List tabs: the class of bodies the list shows, with each tab's bodies and
+	// selection. The planets tab lists Planets and selects with SelectedIndex.
+	ListTab     BodyTab
+	TabBodies   map[BodyTab][]models.CelestialBody
+	TabSelected map[BodyTab]int
+
+	// List filter: which bodies every tab lists, and the filter bar's input
+	ListFilter  *filter.Filter // nil when the list shows every body
+	FilterInput string
+
+	// Open modals, bottom first; only the top one is shown and takes input
+	modals []Modal
+
+	// Orbital element editor state
+	ElementFieldIndex     int
+	ElementEditorStatus   string
+	ElementEditorDirty    bool
+	ElementEditorBackup   *models.OrbitalElement
+	ElementEditorOriginal models.CelestialBody
+
+	// Command palette state
+	PaletteCommands []paletteCommand
+	PaletteQuery    string
+	PaletteSelected int
+
+	// Context menu state
+	ContextMenuItems    []paletteCommand
+	ContextMenuSelected int
+	ContextMenuX        int // the cell right-clicked, which the menu opens from
+	ContextMenuY        int
+
+	// System metadata editor state
+	MetadataSystem     string // the system being edited, by file name
+	MetadataEdit       systems.SystemMetadata
+	MetadataFieldIndex int
+	MetadataStatus     string
+	MetadataDirty      bool
+
+	// Quiz state
+	QuizGenerator   *quiz.Generator
+	QuizQuestion    quiz.Question
+	QuizSelected    int
+	QuizAnswered    bool
+	QuizLastCorrect bool
+	QuizScore       quiz.Score
+
+	// Help state
+	HelpScroll int
+
+	// DetailsScroll is how far the content of the planet or moon details is
+	// scrolled down
+	DetailsScroll int
+
+	// DetailUnitsFlipped shows detail values in the other units to the setting:
+	// as the data gives them instead of scaled, or the other way round
+	DetailUnitsFlipped bool
+
+	// Event log state
+	EventLog       []events.Event
+	EventLogFrom   time.Time
+	EventLogRange  int
+	EventLogScroll int
+
+	// Mission planner state
+	MissionOrigin      string
+	MissionDestination string
+	MissionField       int // 0 edits the origin, 1 the destination
+	MissionPlan        missionPlan
+
+	// Conjunction finder state
+	ConjunctionBodies    []string // bodies searched with, by name
+	ConjunctionCursor    int      // row of the body picker
+	ConjunctionThreshold int      // index into conjunctionThresholds
+	ConjunctionFocus     int      // pane the arrow keys move in
+	ConjunctionResults   []events.Alignment
+	ConjunctionSelected  int
+	ConjunctionFrom      time.Time // simulated time the results were searched from
+	ConjunctionUntil     time.Time // and up to
+
+	// Statistics state
+	Stats SystemStats
+
+	// Comparison state: a second system drawn beside the current one
+	Comparing            bool
+	PickingComparison    bool // the system list chooses the compared system
+	CompareFocus         comparePane
+	CompareSystem        string
+	CompareSystemName    string
+	ComparePlanets       []models.CelestialBody
+	CompareSelectedIndex int
+
+	// Focus frame: the map centred on a body other than the star, with the
+	// bodies that orbit it
+	Focusing        bool
+	FocusBody       models.CelestialBody
+	FocusSatellites []models.CelestialBody
+
+	// MapFocus rings the selected body on the map, as it was picked there with
+	// the keyboard rather than in the list
+	MapFocus bool
+
+	// Launch game state
+	LaunchIndex  int     // body launched from, in the loaded list
+	LaunchSpeed  float64 // km/s
+	LaunchFlight *orbital.LaunchFlight
+	LaunchFired  time.Time // on the UI clock, so deterministic mode replays the flight
+
+	// Transit light curve state
+	TransitIndex   int       // planet crossing the star, in the loaded list
+	TransitStarted time.Time // when the pass began playing, on the UI clock
+
+	// Stellar evolution: the star being fast-forwarded through its life on the
+	// map, nil when off
+	Evolution *stellarEvolution
+
+	// Galaxy map state
+	GalaxyEntries  []systems.GalaxyEntry
+	GalaxySelected int
+
+	// Physics diagnostics of the loaded system
+	Diagnostics orbital.KeplerReport
+
+	// API status state: the last connectivity check run from the modal
+	APIChecking     bool
+	APICheckedAt    time.Time
+	APICheckLatency time.Duration
+	APICheckError   string
+
+	// System update state: the files the update index offers that are not installed
+	// as listed, and the check or download under way
+	SystemUpdates      []updates.Update
+	UpdatesChecking    bool
+	UpdatesDownloading bool
+	UpdatesError       string
+
+	// Weight calculator state
+	WeightInput  string // mass in kg as typed
+	WeightScroll int
+
+	// Mass chart state
+	ChartRadii  bool // chart the radii rather than the masses
+	ChartScroll int
+
+	// Hierarchy state: the row selected and the bodies opened or closed, by name
+	TreeSelected int
+	TreeExpanded map[string]bool
+
+	// Scale model state
+	ScaleInput  string // star diameter in cm as typed
+	ScaleScroll int
+
+	// Raw JSON view state
+	RawJSONBody   models.CelestialBody
+	RawJSONLines  []string
+	RawJSONScroll int
+
+	// Watchlist modal state
+	WatchlistScroll int
+
+	// Aspect ratio calibration state
+	CalibrationRatio    float64 // ratio being previewed
+	CalibrationOriginal float64 // ratio to restore on cancel
+	CalibrationMeasured float64 // ratio the terminal reported, 0 if unknown
 
 	// Scroll state for lists
 	MoonScrollIndex     int
 	MoonSelectedIndex   int
+	MoonSearch          string // typed in the moon list to find a moon by name
 	SystemScrollIndex   int
 	SystemSelectedIndex int
+}
+
+// sharedState is the state both goroutines use; it is the same for the live
+// state and every frame taken from it, and its fields are only reached through
+// methods that hold the lock
+type sharedState struct {
+	mu sync.RWMutex
 
 	// Application control - CRITICAL: Use thread-safe access only
 	running bool
+
+	// Transient status line, set from both goroutines - use thread-safe access only
+	statusMessage string
+	statusExpiry  time.Time
+
+	// Event alerts, raised from the render goroutine - use thread-safe access only
+	toasts        []toast
+	toastsEnabled bool
+	bellEnabled   bool
+
+	// Debug overlay, toggled from the event goroutine and read while rendering
+	showingDebugOverlay bool
+
+	// Watched bodies and the changes found in them, updated by the background
+	// poller - use thread-safe access only
+	watched      []watchedBody
+	watchReports []watchReport
+	watchChecked time.Time
+
+	// frame is the last one published
+	frame *AppState
+}
+
+// watchedBody is a body on the watchlist; Name is empty until it has been fetched
+type watchedBody struct {
+	ID   string
+	Name string
+}
+
+// watchReport is what changed in one body between two checks
+type watchReport struct {
+	ID      string
+	Name    string
+	At      time.Time
+	Changes []watch.Change
+}
+
+// toast is a short-lived alert message
+type toast struct {
+	message string
+	expiry  time.Time
 }
 
 // PlanetListPosition represents a clickable planet position in the UI
@@ -51,64 +291,387 @@ type PlanetListPosition struct {
 
 // NewAppState creates a new application state with default values
 func NewAppState() *AppState {
-	return &AppState{
-		Planets:             make([]models.CelestialBody, 0),
-		PlanetPositions:     make(map[string]visualization.PlanetPosition),
-		PlanetListPositions: make([]PlanetListPosition, 0),
-		CurrentSystem:       "solar-system",
-		SelectedIndex:       0,
-		MoonScrollIndex:     0,
-		MoonSelectedIndex:   0,
-		SystemScrollIndex:   0,
-		SystemSelectedIndex: 0,
-		running:             true,
-		ShowingDetails:      false,
-		ShowingMoons:        false,
-		ShowingMoonDetails:  false,
-		ShowingSystemList:   false,
+	s := &AppState{
+		appView: appView{
+			Planets:             make([]models.CelestialBody, 0),
+			TabBodies:           make(map[BodyTab][]models.CelestialBody),
+			TabSelected:         make(map[BodyTab]int),
+			CurrentSystem:       "solar-system",
+			SelectedIndex:       0,
+			MoonScrollIndex:     0,
+			MoonSelectedIndex:   0,
+			SystemScrollIndex:   0,
+			SystemSelectedIndex: 0,
+			EventLogRange:       2,
+		},
+		sharedState: &sharedState{
+			running:       true,
+			toastsEnabled: true,
+		},
+		layout: &screenLayout{},
 	}
+	s.Publish()
+	return s
 }
 
+// Modal identifies a modal window
+type Modal int
+
+const (
+	ModalNone Modal = iota
+	ModalDetails
+	ModalMoons
+	ModalMoonDetails
+	ModalSystemList
+	ModalElementEditor
+	ModalQuiz
+	ModalHelp
+	ModalEventLog
+	ModalMissionPlanner
+	ModalStats
+	ModalCalibration
+	ModalWatchlist
+	ModalWeight
+	ModalLaunch
+	ModalGalaxy
+	ModalDiagnostics
+	ModalTransit
+	ModalMetadataEditor
+	ModalCommandPalette
+	ModalConjunctions
+	ModalAPIStatus
+	ModalFilter
+	ModalScaleModel
+	ModalContextMenu
+	ModalRawJSON
+	ModalLegend
+	ModalChart
+	ModalTree
+)
+
 // ResetModals closes all modal windows
 func (s *AppState) ResetModals() {
-	s.ShowingDetails = false
-	s.ShowingMoons = false
-	s.ShowingMoonDetails = false
-	s.ShowingSystemList = false
+	s.modals = s.modals[:0]
+}
+
+// OpenModal closes any open modals and shows modal on its own, so going back
+// returns to the map
+func (s *AppState) OpenModal(modal Modal) {
+	s.ResetModals()
+	s.PushModal(modal)
+}
+
+// PushModal shows modal over the current one, which going back returns to
+func (s *AppState) PushModal(modal Modal) {
+	if s.TopModal() != modal {
+		s.modals = append(s.modals, modal)
+		if modal == ModalDetails || modal == ModalMoonDetails {
+			s.DetailsScroll = 0
+		}
+	}
+}
+
+// PopModal closes the top modal, going back to the one beneath it or the map
+func (s *AppState) PopModal() {
+	if len(s.modals) > 0 {
+		s.modals = s.modals[:len(s.modals)-1]
+	}
+}
+
+// CloseModal closes modal if it is the one showing
+func (s *AppState) CloseModal(modal Modal) {
+	if s.TopModal() == modal {
+		s.PopModal()
+	}
+}
+
+// TopModal returns the modal being shown, or ModalNone
+func (s *AppState) TopModal() Modal {
+	if len(s.modals) == 0 {
+		return ModalNone
+	}
+	return s.modals[len(s.modals)-1]
 }
 
 // IsAnyModalShowing returns true if any modal is currently visible
 func (s *AppState) IsAnyModalShowing() bool {
-	return s.ShowingDetails || s.ShowingMoons || s.ShowingMoonDetails || s.ShowingSystemList
+	return len(s.modals) > 0
 }
 
 // ShowPlanetDetails opens the planet details modal
 func (s *AppState) ShowPlanetDetails(planet models.CelestialBody, index int) {
-	s.ResetModals()
 	s.SelectedPlanet = planet
 	s.SelectedIndex = index
-	s.ShowingDetails = true
+	s.OpenModal(ModalDetails)
 }
 
-// ShowMoonList opens the moon list modal
+// ShowMoonList opens the moon list over the planet details
 func (s *AppState) ShowMoonList() {
-	s.ResetModals()
-	s.ShowingMoons = true
+	s.PushModal(ModalMoons)
 	s.MoonScrollIndex = 0
 	s.MoonSelectedIndex = 0
+	s.MoonSearch = ""
 }
 
-// ShowMoonDetails opens the moon details modal
+// ShowMoonDetails opens a moon's details over the moon list
 func (s *AppState) ShowMoonDetails(moon models.CelestialBody) {
-	s.ResetModals()
 	s.SelectedMoon = moon
-	s.ShowingMoonDetails = true
+	s.PushModal(ModalMoonDetails)
 }
 
 // ShowSystemList opens the system selection modal
 func (s *AppState) ShowSystemList() {
-	s.ResetModals()
-	s.ShowingSystemList = true
+	s.OpenModal(ModalSystemList)
+}
+
+// ShowCommandPalette opens the command palette with an empty query
+func (s *AppState) ShowCommandPalette(commands []paletteCommand) {
+	s.OpenModal(ModalCommandPalette)
+	s.PaletteCommands = commands
+	s.SetPaletteQuery("")
+}
+
+// ShowContextMenu opens a menu of commands at a screen cell, over whatever is
+// showing
+func (s *AppState) ShowContextMenu(items []paletteCommand, x, y int) {
+	s.PushModal(ModalContextMenu)
+	s.ContextMenuItems = items
+	s.ContextMenuSelected = 0
+	s.ContextMenuX, s.ContextMenuY = x, y
+}
+
+// SetPaletteQuery changes what the palette filters by and selects the best match
+func (s *AppState) SetPaletteQuery(query string) {
+	s.PaletteQuery = query
+	s.PaletteSelected = 0
+}
+
+// ShowMetadataEditor opens the metadata editor for a system over the system list
+func (s *AppState) ShowMetadataEditor(systemName string, metadata systems.SystemMetadata) {
+	s.PushModal(ModalMetadataEditor)
+	s.MetadataSystem = systemName
+	s.MetadataEdit = metadata
+	s.MetadataFieldIndex = 0
+	s.MetadataStatus = ""
+	s.MetadataDirty = false
+}
+
+// ShowElementEditor opens the orbital element editor for the selected planet over
+// its details. original is the body as it was before editing; backup holds the
+// starting elements.
+func (s *AppState) ShowElementEditor(original models.CelestialBody, backup models.OrbitalElement) {
+	s.PushModal(ModalElementEditor)
+	s.ElementFieldIndex = 0
+	s.ElementEditorStatus = ""
+	s.ElementEditorDirty = false
+	s.ElementEditorBackup = &backup
+	s.ElementEditorOriginal = original
+}
+
+// ShowQuiz opens the quiz modal with a fresh generator; the score carries over
+// between quizzes for the rest of the session
+func (s *AppState) ShowQuiz(generator *quiz.Generator, question quiz.Question) {
+	s.OpenModal(ModalQuiz)
+	s.QuizGenerator = generator
+	s.SetQuizQuestion(question)
+}
+
+// SetQuizQuestion moves the quiz on to a new question
+func (s *AppState) SetQuizQuestion(question quiz.Question) {
+	s.QuizQuestion = question
+	s.QuizSelected = 0
+	s.QuizAnswered = false
+	s.QuizLastCorrect = false
+}
+
+// AnswerQuiz records an answer to the current question
+func (s *AppState) AnswerQuiz(index int) {
+	if s.QuizAnswered {
+		return
+	}
+	s.QuizAnswered = true
+	s.QuizLastCorrect = s.QuizQuestion.IsCorrect(index)
+	s.QuizScore.Record(s.QuizLastCorrect)
+}
+
+// ShowHelp opens the help modal at the top
+func (s *AppState) ShowHelp() {
+	s.OpenModal(ModalHelp)
+	s.HelpScroll = 0
+}
+
+// ShowEventLog opens the upcoming events modal
+func (s *AppState) ShowEventLog() {
+	s.OpenModal(ModalEventLog)
+	s.EventLogScroll = 0
+}
+
+// SetEventLog replaces the listed events, computed from the given simulated time
+func (s *AppState) SetEventLog(eventLog []events.Event, from time.Time) {
+	s.EventLog = eventLog
+	s.EventLogFrom = from
+	s.EventLogScroll = 0
+}
+
+// ShowConjunctionFinder opens the conjunction finder searching with the named bodies
+func (s *AppState) ShowConjunctionFinder(bodies []string) {
+	s.OpenModal(ModalConjunctions)
+	s.ConjunctionBodies = bodies
+	s.ConjunctionCursor = 0
+	s.ConjunctionThreshold = conjunctionDefaultThreshold
+	s.ConjunctionFocus = conjunctionFocusBodies
+}
+
+// SetConjunctions replaces the alignments found, searched between the given
+// simulated times
+func (s *AppState) SetConjunctions(found []events.Alignment, from, until time.Time) {
+	s.ConjunctionResults = found
+	s.ConjunctionFrom = from
+	s.ConjunctionUntil = until
+	s.ConjunctionSelected = 0
+}
+
+// ShowMissionPlanner opens the mission planner between the named bodies
+func (s *AppState) ShowMissionPlanner(origin, destination string) {
+	s.OpenModal(ModalMissionPlanner)
+	s.MissionOrigin = origin
+	s.MissionDestination = destination
+	s.MissionField = 1
+}
+
+// ShowStats opens the statistics modal with the given figures
+func (s *AppState) ShowStats(stats SystemStats) {
+	s.OpenModal(ModalStats)
+	s.Stats = stats
+}
+
+// ShowCalibration opens the aspect ratio calibration screen at the current ratio
+func (s *AppState) ShowCalibration(current, measured float64) {
+	s.OpenModal(ModalCalibration)
+	s.CalibrationRatio = current
+	s.CalibrationOriginal = current
+	s.CalibrationMeasured = measured
+}
+
+// ShowWeightCalculator opens the weight calculator with a starting mass
+func (s *AppState) ShowWeightCalculator(input string) {
+	s.OpenModal(ModalWeight)
+	s.WeightInput = input
+	s.WeightScroll = 0
+}
+
+// ShowChart opens the bar chart of the bodies' masses
+func (s *AppState) ShowChart() {
+	s.OpenModal(ModalChart)
+	s.ChartRadii = false
+	s.ChartScroll = 0
+}
+
+// ShowTree opens the hierarchy of the loaded bodies at the star
+func (s *AppState) ShowTree() {
+	s.OpenModal(ModalTree)
+	s.TreeSelected = 0
+	s.TreeExpanded = nil
+}
+
+// ShowScaleModel opens the scale model with a starting size for the star
+func (s *AppState) ShowScaleModel(input string) {
+	s.OpenModal(ModalScaleModel)
+	s.ScaleInput = input
+	s.ScaleScroll = 0
+}
+
+// ShowRawJSON shows a body's data as JSON over its details
+func (s *AppState) ShowRawJSON(body models.CelestialBody, lines []string) {
+	s.PushModal(ModalRawJSON)
+	s.RawJSONBody = body
+	s.RawJSONLines = lines
+	s.RawJSONScroll = 0
+}
+
+// ShowLaunch opens the launch game on a body at a starting speed
+func (s *AppState) ShowLaunch(index int, speed float64) {
+	s.OpenModal(ModalLaunch)
+	s.LaunchIndex = index
+	s.LaunchSpeed = speed
+	s.LaunchFlight = nil
+}
+
+// ShowTransit shows the light curve of a planet over the modal beneath, playing
+// the pass from started
+func (s *AppState) ShowTransit(index int, started time.Time) {
+	s.PushModal(ModalTransit)
+	s.TransitIndex = index
+	s.TransitStarted = started
+}
+
+// ShowGalaxyMap opens the galaxy map with a system selected
+func (s *AppState) ShowGalaxyMap(entries []systems.GalaxyEntry, selected int) {
+	s.OpenModal(ModalGalaxy)
+	s.GalaxyEntries = entries
+	s.GalaxySelected = selected
+}
+
+// ShowDiagnostics opens the physics diagnostics with a fresh report
+func (s *AppState) ShowDiagnostics(report orbital.KeplerReport) {
+	s.OpenModal(ModalDiagnostics)
+	s.Diagnostics = report
+}
+
+// ShowLegend opens the legend of the symbols on the map
+func (s *AppState) ShowLegend() {
+	s.OpenModal(ModalLegend)
+}
+
+// ShowAPIStatus opens the API status and connectivity diagnostics
+func (s *AppState) ShowAPIStatus() {
+	s.OpenModal(ModalAPIStatus)
+}
+
+// ShowWatchlist opens the watchlist and its changes
+func (s *AppState) ShowWatchlist() {
+	s.OpenModal(ModalWatchlist)
+	s.WatchlistScroll = 0
+}
+
+// StartComparison draws planets from another system beside the current one
+func (s *AppState) StartComparison(system, name string, planets []models.CelestialBody) {
+	s.Comparing = true
+	s.PickingComparison = false
+	s.CompareSystem = system
+	s.CompareSystemName = name
+	s.ComparePlanets = planets
+	s.CompareSelectedIndex = 0
+}
+
+// StopComparison goes back to a single system with input on it
+func (s *AppState) StopComparison() {
+	s.Comparing = false
+	s.CompareFocus = comparePaneCurrent
+	s.ComparePlanets = nil
+}
+
+// StartFocus centres the map on a body and the satellites around it
+func (s *AppState) StartFocus(center models.CelestialBody, satellites []models.CelestialBody) {
+	s.Focusing = true
+	s.FocusBody = center
+	s.FocusSatellites = satellites
+}
+
+// StopFocus centres the map on the system's star again
+func (s *AppState) StopFocus() {
+	s.Focusing = false
+	s.FocusBody = models.CelestialBody{}
+	s.FocusSatellites = nil
+}
+
+// CloseElementEditor returns to the planet details modal. Unsaved edits stay on
+// screen for the rest of the session; an untouched body is restored as it was.
+func (s *AppState) CloseElementEditor() {
+	if !s.ElementEditorDirty {
+		s.ReplaceSelectedPlanet(s.ElementEditorOriginal)
+	}
+	s.PopModal()
 }
 
 // HandleMoonNavigation updates moon navigation state
@@ -131,6 +694,20 @@ func (s *AppState) HandleMoonNavigation(direction int, moonCount int) {
 	}
 }
 
+// SelectMoon selects a moon in the list, clamped to the list, and scrolls it into view
+func (s *AppState) SelectMoon(index, moonCount int) {
+	if moonCount == 0 {
+		return
+	}
+	s.MoonSelectedIndex = max(0, min(index, moonCount-1))
+	if s.MoonSelectedIndex < s.MoonScrollIndex {
+		s.MoonScrollIndex = s.MoonSelectedIndex
+	}
+	if s.MoonSelectedIndex >= s.MoonScrollIndex+constants.MaxVisibleItems {
+		s.MoonScrollIndex = s.MoonSelectedIndex - constants.MaxVisibleItems + 1
+	}
+}
+
 // HandleSystemNavigation updates system navigation state
 func (s *AppState) HandleSystemNavigation(direction int, systemCount int) {
 	switch direction {
@@ -157,6 +734,79 @@ func (s *AppState) UpdatePlanetSelection(index int, planet models.CelestialBody)
 	s.SelectedPlanet = planet
 }
 
+// SelectOnMap selects a body drawn on the map. The map only has planets, so the
+// list goes back to them too.
+func (s *AppState) SelectOnMap(body models.CelestialBody) {
+	s.ListTab = TabPlanets
+	s.SelectedPlanet = body
+	for i, planet := range s.Planets {
+		if planet.EnglishName == body.EnglishName {
+			s.SelectedIndex = i
+			break
+		}
+	}
+}
+
+// ShowListTab makes the list show a tab whose bodies are loaded. Coming back to
+// the planets selects the planet that was selected there.
+func (s *AppState) ShowListTab(tab BodyTab) {
+	s.ListTab = tab
+	if tab == TabPlanets && s.SelectedIndex < len(s.Planets) {
+		s.SelectedPlanet = s.Planets[s.SelectedIndex]
+	}
+}
+
+// ResetListTabs goes back to the planets tab and forgets the other tabs' bodies,
+// which belong to the system that was loaded
+func (s *AppState) ResetListTabs() {
+	s.ListTab = TabPlanets
+	s.TabBodies = make(map[BodyTab][]models.CelestialBody)
+	s.TabSelected = make(map[BodyTab]int)
+}
+
+// ListedBodies returns the bodies of the tab being shown
+func (s *AppState) ListedBodies() []models.CelestialBody {
+	if s.ListTab == TabPlanets {
+		return s.Planets
+	}
+	return s.TabBodies[s.ListTab]
+}
+
+// SelectListed selects a body in the list being shown, reporting false if there
+// is none at that index. Bodies on other tabs than the planets are selected for
+// their details only; the map keeps its planet.
+func (s *AppState) SelectListed(index int) bool {
+	bodies := s.ListedBodies()
+	if index < 0 || index >= len(bodies) {
+		return false
+	}
+	if s.ListTab == TabPlanets {
+		s.UpdatePlanetSelection(index, bodies[index])
+	} else {
+		s.TabSelected[s.ListTab] = index
+		s.SelectedPlanet = bodies[index]
+	}
+	return true
+}
+
+// ListedIndex is the selected index in the list being shown
+func (s *AppState) ListedIndex() int {
+	if s.ListTab == TabPlanets {
+		return s.SelectedIndex
+	}
+	return s.TabSelected[s.ListTab]
+}
+
+// ReplaceSelectedPlanet stores an edited copy of the selected planet in both the
+// selection and the planet list so the map picks up the change on the next frame
+func (s *AppState) ReplaceSelectedPlanet(planet models.CelestialBody) {
+	s.SelectedPlanet = planet
+	if s.SelectedIndex >= 0 && s.SelectedIndex < len(s.Planets) &&
+		s.Planets[s.SelectedIndex].EnglishName == planet.EnglishName {
+		s.Planets[s.SelectedIndex] = planet
+	}
+}
+
 // Thread-safe accessors for critical concurrent fields
 
 func (s *AppState) IsRunning() bool {
@@ -171,6 +821,168 @@ func (s *AppState) SetRunning(running bool) {
 	s.running = running
 }
 
+// SetStatusMessage shows a message on the status line for the given duration
+func (s *AppState) SetStatusMessage(message string, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statusMessage = message
+	s.statusExpiry = time.Now().Add(duration)
+}
+
+// GetStatusMessage returns the current status message, or "" once it has expired
+func (s *AppState) GetStatusMessage() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if time.Now().After(s.statusExpiry) {
+		return ""
+	}
+	return s.statusMessage
+}
+
+// PushToast shows an alert for the given duration, dropping the oldest beyond the limit
+func (s *AppState) PushToast(message string, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.toasts = append(s.toasts, toast{message: message, expiry: time.Now().Add(duration)})
+	if len(s.toasts) > maxToasts {
+		s.toasts = s.toasts[len(s.toasts)-maxToasts:]
+	}
+}
+
+// GetToasts returns the messages of unexpired alerts, newest first
+func (s *AppState) GetToasts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	active := s.toasts[:0]
+	for _, t := range s.toasts {
+		if now.Before(t.expiry) {
+			active = append(active, t)
+		}
+	}
+	s.toasts = active
+
+	messages := make([]string, len(active))
+	for i, t := range active {
+		messages[len(active)-1-i] = t.message
+	}
+	return messages
+}
+
+// SetWatchlist replaces the watched bodies, e.g. with those saved in the config
+func (s *AppState) SetWatchlist(ids []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watched = nil
+	for _, id := range ids {
+		s.watched = append(s.watched, watchedBody{ID: id})
+	}
+}
+
+// GetWatchlist returns a copy of the watched bodies
+func (s *AppState) GetWatchlist() []watchedBody {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]watchedBody(nil), s.watched...)
+}
+
+// IsWatched reports whether the body with this API id is watched
+func (s *AppState) IsWatched(id string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, body := range s.watched {
+		if body.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleWatched adds a body to the watchlist or removes it, returning whether it
+// is now watched
+func (s *AppState) ToggleWatched(id, name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, body := range s.watched {
+		if body.ID == id {
+			s.watched = append(s.watched[:i], s.watched[i+1:]...)
+			return false
+		}
+	}
+	s.watched = append(s.watched, watchedBody{ID: id, Name: name})
+	return true
+}
+
+// SetWatchedName records the name of a watched body once it has been fetched
+func (s *AppState) SetWatchedName(id, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.watched {
+		if s.watched[i].ID == id {
+			s.watched[i].Name = name
+		}
+	}
+}
+
+// RecordWatchCheck notes a completed check and any changes it found
+func (s *AppState) RecordWatchCheck(reports []watchReport, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watchReports = append(reports, s.watchReports...)
+	s.watchChecked = at
+}
+
+// GetWatchReports returns the changes found so far, newest first, and when the
+// last check finished
+func (s *AppState) GetWatchReports() ([]watchReport, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]watchReport(nil), s.watchReports...), s.watchChecked
+}
+
+// ClearWatchReports forgets the changes once they have been reviewed
+func (s *AppState) ClearWatchReports() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watchReports = nil
+}
+
+// GetAlertSettings reports whether event toasts and the terminal bell are enabled
+func (s *AppState) GetAlertSettings() (toasts, bell bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.toastsEnabled, s.bellEnabled
+}
+
+// ToggleToasts turns event alerts on or off
+func (s *AppState) ToggleToasts() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.toastsEnabled = !s.toastsEnabled
+}
+
+// ToggleBell turns the terminal bell for event alerts on or off
+func (s *AppState) ToggleBell() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bellEnabled = !s.bellEnabled
+}
+
+// ToggleDebugOverlay shows or hides the debug overlay
+func (s *AppState) ToggleDebugOverlay() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.showingDebugOverlay = !s.showingDebugOverlay
+}
+
+// IsShowingDebugOverlay reports whether the debug overlay is visible
+func (s *AppState) IsShowingDebugOverlay() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.showingDebugOverlay
+}
+
 // Convenience getters for interface compliance (not thread-safe - only use from main thread)
 
 func (s *AppState) GetSelectedIndex() int {
@@ -186,19 +998,67 @@ func (s *AppState) GetSelectedMoon() models.CelestialBody {
 }
 
 func (s *AppState) IsShowingDetails() bool {
-	return s.ShowingDetails
+	return s.TopModal() == ModalDetails
 }
 
 func (s *AppState) IsShowingMoons() bool {
-	return s.ShowingMoons
+	return s.TopModal() == ModalMoons
 }
 
 func (s *AppState) IsShowingMoonDetails() bool {
-	return s.ShowingMoonDetails
+	return s.TopModal() == ModalMoonDetails
 }
 
 func (s *AppState) IsShowingSystemList() bool {
-	return s.ShowingSystemList
+	return s.TopModal() == ModalSystemList
+}
+
+func (s *AppState) IsShowingElementEditor() bool {
+	return s.TopModal() == ModalElementEditor
+}
+
+func (s *AppState) IsShowingQuiz() bool {
+	return s.TopModal() == ModalQuiz
+}
+
+func (s *AppState) IsShowingEventLog() bool {
+	return s.TopModal() == ModalEventLog
+}
+
+func (s *AppState) IsShowingMissionPlanner() bool {
+	return s.TopModal() == ModalMissionPlanner
+}
+
+func (s *AppState) IsShowingStats() bool {
+	return s.TopModal() == ModalStats
+}
+
+func (s *AppState) IsShowingCalibration() bool {
+	return s.TopModal() == ModalCalibration
+}
+
+func (s *AppState) IsShowingWatchlist() bool {
+	return s.TopModal() == ModalWatchlist
+}
+
+func (s *AppState) IsShowingWeight() bool {
+	return s.TopModal() == ModalWeight
+}
+
+func (s *AppState) IsShowingLaunch() bool {
+	return s.TopModal() == ModalLaunch
+}
+
+func (s *AppState) IsShowingGalaxy() bool {
+	return s.TopModal() == ModalGalaxy
+}
+
+func (s *AppState) IsShowingDiagnostics() bool {
+	return s.TopModal() == ModalDiagnostics
+}
+
+func (s *AppState) IsShowingHelp() bool {
+	return s.TopModal() == ModalHelp
 }
 
 // Data accessors for centralized state
@@ -211,20 +1071,40 @@ func (s *AppState) SetPlanets(planets []models.CelestialBody) {
 	s.Planets = planets
 }
 
+// GetPlanetPositions returns where bodies were drawn on the map, by name
 func (s *AppState) GetPlanetPositions() map[string]visualization.PlanetPosition {
-	return s.PlanetPositions
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.layout.planetPositions
 }
 
-func (s *AppState) SetPlanetPositions(positions map[string]visualization.PlanetPosition) {
-	s.PlanetPositions = positions
+// GetPlanetListPositions returns where the rows of the body list were drawn
+func (s *AppState) GetPlanetListPositions() []PlanetListPosition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.layout.planetList
 }
 
-func (s *AppState) GetPlanetListPositions() []PlanetListPosition {
-	return s.PlanetListPositions
+// GetListPages returns the index of the first body on each page of the list as
+// last drawn
+func (s *AppState) GetListPages() []int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.layout.listPages
+}
+
+// SetListPages records the first body on each page of the list being drawn
+func (s *AppState) SetListPages(starts []int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layout.listPages = starts
 }
 
-func (s *AppState) SetPlanetListPositions(positions []PlanetListPosition) {
-	s.PlanetListPositions = positions
+// GetTabPositions returns where the list tabs were drawn; Index is the tab
+func (s *AppState) GetTabPositions() []PlanetListPosition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.layout.tabs
 }
 
 func (s *AppState) GetCurrentSystem() string {
@@ -235,24 +1115,82 @@ func (s *AppState) SetCurrentSystem(system string) {
 	s.CurrentSystem = system
 }
 
+// SetHover records the pointer position; the rest timer restarts only when it moves
+func (s *AppState) SetHover(x, y int, now time.Time) {
+	if s.Hovering && s.HoverX == x && s.HoverY == y {
+		return
+	}
+	s.Hovering = true
+	s.HoverX, s.HoverY = x, y
+	s.HoverSince = now
+}
+
+// ClearHover hides any tooltip until the pointer next moves
+func (s *AppState) ClearHover() {
+	s.Hovering = false
+}
+
+// ClearLayoutCaches forgets where bodies, list rows and tabs were drawn, and where the
+// pointer rests, all of which a resize moves
+func (s *AppState) ClearLayoutCaches() {
+	s.setLayout(&screenLayout{})
+	s.ClearHover()
+}
+
 // Data manipulation methods for better encapsulation
 
+// SetOverview records where the overview inset was drawn and its bodies in it
+func (s *AppState) SetOverview(area layout.Rect, positions map[string]visualization.PlanetPosition) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layout.overview = area
+	s.layout.overviewPositions = positions
+}
+
+// GetOverview returns where the overview inset was drawn, empty when it was not,
+// and where its bodies landed in screen coordinates
+func (s *AppState) GetOverview() (layout.Rect, map[string]visualization.PlanetPosition) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.layout.overview, s.layout.overviewPositions
+}
+
 func (s *AppState) ClearPlanetListPositions() {
-	s.PlanetListPositions = s.PlanetListPositions[:0]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layout.planetList = nil
+	s.layout.listPages = nil
 }
 
 func (s *AppState) AddPlanetListPosition(pos PlanetListPosition) {
-	s.PlanetListPositions = append(s.PlanetListPositions, pos)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layout.planetList = append(s.layout.planetList, pos)
+}
+
+func (s *AppState) ClearTabPositions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layout.tabs = nil
+}
+
+func (s *AppState) AddTabPosition(pos PlanetListPosition) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layout.tabs = append(s.layout.tabs, pos)
 }
 
 func (s *AppState) UpdatePlanetPositions(x, y int, positions map[string]visualization.PlanetPosition) {
-	s.PlanetPositions = make(map[string]visualization.PlanetPosition)
+	adjusted := make(map[string]visualization.PlanetPosition, len(positions))
 	for name, pos := range positions {
 		adjustedPos := pos
 		adjustedPos.X += x
 		adjustedPos.Y += y
-		s.PlanetPositions[name] = adjustedPos
+		adjusted[name] = adjustedPos
 	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layout.planetPositions = adjusted
 }
 
 // Data consistency and validation methods
@@ -0,0 +1,144 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// earthMass in kg, used to put masses in relatable terms
+const earthMass = 5.972e24
+
+// statsLabelColumn is the width of the label column in the statistics modal
+const statsLabelColumn = 24
+
+// knownCountLabels names the API's known count ids
+var knownCountLabels = map[string]string{
+	"planets":      "Planets",
+	"dwarfPlanets": "Dwarf planets",
+	"moons":        "Moons",
+	"asteroids":    "Asteroids",
+	"comets":       "Comets",
+}
+
+// statsLine is one row of the statistics modal; headings have no value
+type statsLine struct {
+	heading bool
+	label   string
+	value   string
+}
+
+// openStats computes statistics for the loaded system and shows them
+func (ed *EventDispatcher) openStats() {
+	ed.state.ShowStats(ed.statsService.Build(ed.state.GetPlanets()))
+}
+
+// handleStatsKeys handles keyboard input while the statistics modal is open
+func (ed *EventDispatcher) handleStatsKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyEnter:
+		ed.state.PopModal()
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'q', 'Q', 'b', 'B':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// buildStatsLines lays out the statistics as label/value rows
+func buildStatsLines(systemStats SystemStats) []statsLine {
+	summary := systemStats.Summary
+	lines := []statsLine{
+		{heading: true, label: "Loaded bodies"},
+		{label: "Bodies", value: fmt.Sprintf("%d (%d stars, %d planets, %d dwarf planets, %d other)",
+			summary.Bodies, summary.Stars, summary.Planets, summary.DwarfPlanets, summary.Other)},
+		{label: "Known moons", value: formatCount(summary.Moons)},
+	}
+
+	if summary.TotalMass > 0 {
+		lines = append(lines, statsLine{label: "Total mass", value: fmt.Sprintf("%.3e kg", summary.TotalMass)})
+	}
+	if summary.NonStellarMass > 0 {
+		lines = append(lines, statsLine{label: "Mass outside the stars", value: fmt.Sprintf("%.3e kg (%.1f Earths)", summary.NonStellarMass, summary.NonStellarMass/earthMass)})
+	}
+	if summary.Largest.Name != "" {
+		lines = append(lines, statsLine{label: "Largest", value: fmt.Sprintf("%s (%s km radius)", summary.Largest.Name, formatCount(int(summary.Largest.Value)))})
+		lines = append(lines, statsLine{label: "Smallest", value: fmt.Sprintf("%s (%s km radius)", summary.Smallest.Name, formatCount(int(summary.Smallest.Value)))})
+	}
+	if summary.Heaviest.Name != "" {
+		lines = append(lines, statsLine{label: "Heaviest", value: fmt.Sprintf("%s (%.2f Earths)", summary.Heaviest.Name, summary.Heaviest.Value/earthMass)})
+	}
+	if summary.MeanDensity > 0 {
+		lines = append(lines, statsLine{label: "Mean density", value: fmt.Sprintf("%.2f g/cm³", summary.MeanDensity)})
+	}
+
+	if systemStats.KnownCountsErr != nil {
+		lines = append(lines, statsLine{}, statsLine{heading: true, label: "Known objects: unavailable (API unreachable)"})
+	} else if len(systemStats.KnownCounts) > 0 {
+		lines = append(lines, statsLine{}, statsLine{heading: true, label: "Known objects in the Solar System"})
+		for _, count := range systemStats.KnownCounts {
+			label, ok := knownCountLabels[count.ID]
+			if !ok {
+				label = count.ID
+			}
+			value := formatCount(count.KnownCount)
+			if count.UpdateDate != "" {
+				value += fmt.Sprintf(" (as of %s)", count.UpdateDate)
+			}
+			lines = append(lines, statsLine{label: label, value: value})
+		}
+	}
+
+	return lines
+}
+
+// formatCount writes a count with thousands separators
+func formatCount(n int) string {
+	digits := strconv.Itoa(n)
+	if n < 0 {
+		return "-" + formatCount(-n)
+	}
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return digits
+}
+
+// calculateStatsLines returns the number of content lines in the statistics modal
+func (ur *UIRenderer) calculateStatsLines(state *AppState) int {
+	return len(buildStatsLines(state.Stats))
+}
+
+// drawStatsModal renders the statistics for the loaded system
+func (ur *UIRenderer) drawStatsModal(width, height int) {
+	dynamicHeight := minimum(ur.calculateStatsLines(ur.state)+6, height-4)
+	modalX, modalY, _, modalHeight := ur.setupModal(width, height, dynamicHeight)
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, fmt.Sprintf(" 📊 %s Statistics ", ur.state.Stats.System))
+
+	headingStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue).Bold(true)
+	labelStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	valueStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+
+	lastRow := modalY + modalHeight - 3
+	for i, line := range buildStatsLines(ur.state.Stats) {
+		y := modalY + 3 + i
+		if y > lastRow {
+			break
+		}
+		if line.heading {
+			ur.drawText(modalX+2, y, headingStyle, line.label)
+			continue
+		}
+		ur.drawText(modalX+4, y, labelStyle, line.label)
+		ur.drawText(modalX+4+statsLabelColumn, y, valueStyle, truncateText(line.value, ur.contentWidth()-statsLabelColumn-2))
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "Press Enter, Escape, or 'b' to close")
+}
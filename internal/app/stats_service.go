@@ -0,0 +1,47 @@
+package app
+
+import (
+	"github.com/furan917/go-solar-system/internal/api"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/stats"
+	"github.com/furan917/go-solar-system/internal/systems"
+)
+
+// SystemStats is everything shown in the statistics modal
+type SystemStats struct {
+	System  string
+	Summary stats.Summary
+
+	// KnownCounts are the API's totals of known objects; only our solar system has them
+	KnownCounts    []models.KnownCount
+	KnownCountsErr error
+}
+
+// StatsService builds statistics for the loaded system
+type StatsService struct {
+	client        *api.Client
+	systemManager *systems.SystemManager
+}
+
+// NewStatsService creates a new stats service with necessary dependencies
+func NewStatsService(client *api.Client, systemManager *systems.SystemManager) *StatsService {
+	return &StatsService{
+		client:        client,
+		systemManager: systemManager,
+	}
+}
+
+// Build aggregates the loaded bodies and, for our solar system, fetches the
+// API's known object counts
+func (ss *StatsService) Build(bodies []models.CelestialBody) SystemStats {
+	result := SystemStats{
+		System:  ss.systemManager.GetCurrentSystemDisplayName(),
+		Summary: stats.Summarize(bodies),
+	}
+
+	if ss.systemManager.GetCurrentSystem() == "solar-system" {
+		result.KnownCounts, result.KnownCountsErr = ss.client.GetKnownCounts()
+	}
+
+	return result
+}
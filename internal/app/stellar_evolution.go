@@ -0,0 +1,205 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// evolutionPlayTime is how long a star's life takes to play through
+	evolutionPlayTime = 40 * time.Second
+
+	// evolutionRemnantShown is how long, in billion years, the remnant is shown
+	// cooling after the star dies
+	evolutionRemnantShown = 5.0
+)
+
+// evolutionShares is the share of the play time each part of the star's life
+// takes, whatever its length in years: what is left of the main sequence, the
+// giant and the remnant. The giant is over in a tenth of the time the star spent
+// on the main sequence, too quick to watch at one rate.
+var evolutionShares = [3]float64{0.5, 0.35, 0.15}
+
+// stellarEvolution is a star being fast-forwarded through its life
+type stellarEvolution struct {
+	Star    models.CelestialBody
+	Track   models.EvolutionTrack
+	From    float64   // the star's age when the play started, in billion years
+	Started time.Time // on the UI clock
+}
+
+// newStellarEvolution plays star's life from its age, or from its birth when its
+// age is not known. A star the simple track would have dead already starts near
+// the end of its main sequence instead.
+func newStellarEvolution(star models.CelestialBody, started time.Time) (*stellarEvolution, bool) {
+	mass := star.SolarMasses()
+	if mass <= 0 {
+		return nil, false
+	}
+	track := models.NewEvolutionTrack(mass)
+	from := math.Min(star.Age/1e9, 0.9*track.MainSequence)
+	return &stellarEvolution{Star: star, Track: track, From: from, Started: started}, true
+}
+
+// ageAt returns the star's age, in billion years, elapsed into the play. The
+// parts of its life are played one after another, each over its share of the
+// play time, and it stays a cooling remnant once the play is over.
+func (e *stellarEvolution) ageAt(elapsed time.Duration) float64 {
+	bounds := [4]float64{e.From, e.Track.MainSequence, e.Track.Death(), e.Track.Death() + evolutionRemnantShown}
+	var total float64
+	for i, share := range evolutionShares {
+		if bounds[i+1] > bounds[i] {
+			total += share
+		}
+	}
+
+	played := math.Min(elapsed.Seconds()/evolutionPlayTime.Seconds(), 1) * total
+	for i, share := range evolutionShares {
+		from, to := math.Max(bounds[i], e.From), bounds[i+1]
+		if to <= from {
+			continue
+		}
+		if played <= share {
+			return from + (to-from)*played/share
+		}
+		played -= share
+	}
+	return bounds[3]
+}
+
+// evolvingStar returns the star to fast-forward: the selected body if it is a
+// star, else the system's first
+func evolvingStar(state *AppState) (models.CelestialBody, bool) {
+	if state.SelectedPlanet.BodyType == "Star" {
+		return state.SelectedPlanet, true
+	}
+	for _, body := range state.GetPlanets() {
+		if body.BodyType == "Star" {
+			return body, true
+		}
+	}
+	return models.CelestialBody{}, false
+}
+
+// toggleEvolution starts fast-forwarding the star's life on the map, or stops
+func (ed *EventDispatcher) toggleEvolution() {
+	if ed.state.Evolution != nil {
+		ed.state.Evolution = nil
+		ed.state.SetStatusMessage("Stellar evolution stopped", statusMessageDuration)
+		return
+	}
+
+	star, ok := evolvingStar(ed.state)
+	if !ok {
+		ed.state.SetStatusMessage("There is no star in this system to fast-forward", statusMessageDuration)
+		return
+	}
+	evolution, ok := newStellarEvolution(star, ed.uiRenderer.clock.Now())
+	if !ok {
+		ed.state.SetStatusMessage(fmt.Sprintf("%s's mass and luminosity aren't known, so its life can't be played", star.EnglishName), statusMessageDuration)
+		return
+	}
+	ed.state.Evolution = evolution
+	ed.state.SetStatusMessage(fmt.Sprintf("Fast-forwarding %s's life on rough tracks for its mass; %s to stop",
+		star.EnglishName, ed.keys.Primary(keymap.ActionEvolution)), statusMessageDuration)
+}
+
+// evolutionStage works out how the star being fast-forwarded looks this frame
+// and has the map draw it so, or draws the stars as they are when none is. It
+// reports whether a star is being fast-forwarded.
+func (ur *UIRenderer) evolutionStage() (models.StellarStage, bool) {
+	evolution := ur.state.Evolution
+	if evolution == nil {
+		ur.renderer.SetEvolvedStar(nil)
+		return models.StellarStage{}, false
+	}
+	stage := evolution.Track.At(evolution.ageAt(ur.clock.Now().Sub(evolution.Started)))
+	ur.renderer.SetEvolvedStar(&visualization.EvolvedStar{Luminosity: stage.Luminosity, RadiusKm: stage.Radius * models.SolarRadiusKm})
+	return stage, true
+}
+
+// evolutionWidgetLines returns the rows of the readout of a star at stage: its
+// age, phase and class, size and brightness, and habitable zone
+func evolutionWidgetLines(star models.CelestialBody, stage models.StellarStage) []string {
+	phase := stage.Phase.String()
+	if stage.Class != "" {
+		phase += ", class " + stage.Class
+	}
+	phase = strings.ToUpper(phase[:1]) + phase[1:]
+
+	size := fmt.Sprintf("Radius %s R☉", formatSolarUnits(stage.Radius))
+	if stage.Radius*models.SolarRadiusKm < 1000 {
+		size = fmt.Sprintf("Radius %.0f km", stage.Radius*models.SolarRadiusKm)
+	}
+	zone := "No habitable zone: too little light"
+	if stage.Luminosity > 0 {
+		size += fmt.Sprintf(", %s L☉", formatSolarUnits(stage.Luminosity))
+		inner, outer := models.HabitableZone(stage.Luminosity)
+		zone = fmt.Sprintf("Habitable zone %.3g-%.3g AU", inner, outer)
+	}
+
+	return []string{
+		star.EnglishName + ", fast-forwarded",
+		"Age " + formatBillionYears(stage.Age),
+		phase,
+		size,
+		zone,
+	}
+}
+
+// formatBillionYears writes an age given in billion years in millions below a
+// billion
+func formatBillionYears(age float64) string {
+	if age < 1 {
+		return fmt.Sprintf("%.0f million years", age*1000)
+	}
+	return fmt.Sprintf("%s billion years", formatSolarUnits(age))
+}
+
+// formatSolarUnits writes a multiple of a solar unit to three significant
+// figures, and whole above a hundred
+func formatSolarUnits(value float64) string {
+	if value >= 100 {
+		return formatCount(int(math.Round(value)))
+	}
+	return fmt.Sprintf("%.3g", value)
+}
+
+// drawEvolutionWidget draws the readout of the star being fast-forwarded in the
+// top-left corner of the map
+func (ur *UIRenderer) drawEvolutionWidget(area layout.Rect, stage models.StellarStage) {
+	if area.Width < hereWidgetMinWidth || area.Height < hereWidgetMinHeight {
+		return
+	}
+
+	lines := evolutionWidgetLines(ur.state.Evolution.Star, stage)
+	boxWidth := 0
+	for _, line := range lines {
+		boxWidth = max(boxWidth, ui.TextWidth(line))
+	}
+	boxWidth += 2
+
+	color := tcell.ColorOrange
+	if stage.Phase.Remnant() {
+		color = tcell.ColorSilver
+	}
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(color).Bold(true)
+	lineStyle := tcell.StyleDefault.Foreground(color).Background(tcell.ColorBlack)
+
+	for i, line := range lines {
+		style := lineStyle
+		if i == 0 {
+			style = titleStyle
+		}
+		ur.drawText(area.X, area.Y+i, style, truncateText(fmt.Sprintf(" %-*s", boxWidth-1, line), area.Width))
+	}
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestStellarEvolutionPlay(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+	state.SelectListed(3)
+
+	// Away from a star, the system's star is fast-forwarded
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'f', tcell.ModNone))
+	evolution := state.Evolution
+	if evolution == nil || evolution.Star.EnglishName != "Sun" || !strings.Contains(state.GetStatusMessage(), "Fast-forwarding Sun's life") {
+		t.Fatalf("Expected f to fast-forward the Sun, got %q", state.GetStatusMessage())
+	}
+
+	// A fifth of the way in, the Sun is still on the main sequence; later on a
+	// giant, with its habitable zone far out, then a white dwarf
+	tests := []struct {
+		played float64
+		want   string
+		phase  models.StellarPhase
+	}{
+		{0.2, "Main sequence, class G V", models.PhaseMainSequence},
+		{0.8, "Red giant, class M III", models.PhaseGiant},
+		{1.5, "White dwarf, class D", models.PhaseWhiteDwarf},
+	}
+	for _, tt := range tests {
+		evolution.Started = dispatcher.uiRenderer.clock.Now().Add(-time.Duration(tt.played * float64(evolutionPlayTime)))
+		state.Publish()
+		dispatcher.uiRenderer.DrawScreen()
+		if text := screenText(screen); !strings.Contains(text, "Sun, fast-forwarded") || !strings.Contains(text, tt.want) {
+			t.Errorf("%.0f%% of the way in, expected %q, got:\n%s", 100*tt.played, tt.want, text)
+		}
+		star := dispatcher.uiRenderer.renderer.EvolvedStar()
+		marks, drawn := dispatcher.uiRenderer.renderer.HabitableZoneMarks()
+		if tt.phase.Remnant() {
+			// The white dwarf's zone is too close in to see
+			if star == nil || star.Luminosity > 0.01 {
+				t.Errorf("expected a faint white dwarf drawn, got %+v", star)
+			}
+			continue
+		}
+		if star == nil || !drawn || marks.Luminosity != star.Luminosity {
+			t.Errorf("%.0f%% of the way in, expected the map to draw the %s's habitable zone, got %+v (%v)", 100*tt.played, tt.phase, marks, drawn)
+		}
+		if tt.phase == models.PhaseGiant && marks.InnerAU < 5 {
+			t.Errorf("the giant's habitable zone starts at %.2f AU, want it far out", marks.InnerAU)
+		}
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'f', tcell.ModNone))
+	state.Publish()
+	dispatcher.uiRenderer.DrawScreen()
+	if state.Evolution != nil || dispatcher.uiRenderer.renderer.EvolvedStar() != nil || state.GetStatusMessage() != "Stellar evolution stopped" {
+		t.Errorf("Expected f again to stop, got %q", state.GetStatusMessage())
+	}
+}
+
+func TestStellarEvolutionAges(t *testing.T) {
+	started := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sun := models.CelestialBody{EnglishName: "Sun", BodyType: "Star", Age: 4.6e9}
+	evolution, ok := newStellarEvolution(sun, started)
+	if !ok {
+		t.Fatal("Expected the Sun's life to play")
+	}
+
+	tests := []struct {
+		elapsed time.Duration
+		want    float64
+	}{
+		{0, 4.6},
+		{evolutionPlayTime / 2, 10},
+		{evolutionPlayTime * 85 / 100, 11},
+		{evolutionPlayTime * 2, 11 + evolutionRemnantShown},
+	}
+	for _, tt := range tests {
+		if got := evolution.ageAt(tt.elapsed); got < tt.want-1e-6 || got > tt.want+1e-6 {
+			t.Errorf("ageAt(%v) = %.3f billion years, want %.3f", tt.elapsed, got, tt.want)
+		}
+	}
+
+	// Red dwarfs never swell, so their remnant follows straight on, and the main
+	// sequence and remnant share the whole play
+	dwarf, _ := newStellarEvolution(models.CelestialBody{BodyType: "Star", Mass: models.Mass{MassValue: 2.4, MassExponent: 29}}, started)
+	if got, want := dwarf.ageAt(evolutionPlayTime*50/65), dwarf.Track.MainSequence; got < want-1e-6 || got > want+1e-6 {
+		t.Errorf("red dwarf at 50/65 of the play = %.0f billion years, want the end of its main sequence, %.0f", got, want)
+	}
+
+	if _, ok := newStellarEvolution(models.CelestialBody{EnglishName: "Vega", BodyType: "Star"}, started); ok {
+		t.Error("Expected no play for a star of unknown mass and luminosity")
+	}
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/gdamore/tcell/v2"
+)
+
+// toggleStripView switches the map between orbits and the strip of bodies by distance
+func (ed *EventDispatcher) toggleStripView() {
+	if ed.uiRenderer.toggleStripView() {
+		ed.state.SetStatusMessage("Strip view: bodies by distance from the star, log scale", statusMessageDuration)
+		return
+	}
+	ed.state.SetStatusMessage("Orbit view", statusMessageDuration)
+}
+
+// toggleStripView switches views between frames and reports whether the strip is
+// now drawn
+func (ur *UIRenderer) toggleStripView() bool {
+	ur.drawMu.Lock()
+	defer ur.drawMu.Unlock()
+	ur.stripView = !ur.stripView
+	return ur.stripView
+}
+
+// drawStrip draws the system as a strip of bodies along a distance axis, with
+// their names beside them and the distance scale along the bottom
+func (ur *UIRenderer) drawStrip(region layout.Rect) {
+	screenWidth, screenHeight := ur.screen.Size()
+	grid, positions := ur.renderer.RenderStripWithPositions(ur.state.GetPlanets(), region.Width, region.Height, screenWidth, screenHeight)
+	ur.state.UpdatePlanetPositions(region.X, region.Y, positions)
+
+	ur.camera = ur.renderer.GetStripCamera(region.Width, region.Height)
+	ur.camera.OriginX += region.X
+	ur.camera.OriginY += region.Y
+	ur.drawGrid(ur.renderer, grid, region.X, region.Y, region.Width, region.Height)
+
+	style := tcell.StyleDefault.Foreground(tcell.ColorGray)
+	selectedStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
+	for _, label := range ur.renderer.StripLabels() {
+		if label.Text == ur.state.SelectedPlanet.EnglishName {
+			ur.drawText(region.X+label.X, region.Y+label.Y, selectedStyle, label.Text)
+			continue
+		}
+		ur.drawText(region.X+label.X, region.Y+label.Y, style, label.Text)
+	}
+}
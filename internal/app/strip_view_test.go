@@ -0,0 +1,37 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestStripViewBodiesCanBeClicked(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 200, 24)
+	ur := dispatcher.uiRenderer
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'v', tcell.ModNone))
+	ur.DrawScreen()
+	if text := screenText(screen); !strings.Contains(text, "Jupiter") || !strings.Contains(text, "1 AU") {
+		t.Fatalf("strip view lacks body names or the distance scale:\n%s", text)
+	}
+
+	positions := state.GetPlanetPositions()
+	jupiter, earth := positions["Jupiter"], positions["Earth"]
+	if jupiter.Y != earth.Y || jupiter.X <= earth.X {
+		t.Fatalf("Jupiter at %d,%d and Earth at %d,%d, want Jupiter further along the same row", jupiter.X, jupiter.Y, earth.X, earth.Y)
+	}
+
+	doubleClick(dispatcher, jupiter.X, jupiter.Y)
+	if state.TopModal() != ModalDetails || state.SelectedPlanet.EnglishName != "Jupiter" {
+		t.Errorf("clicking Jupiter on the strip showed %v for %q", state.TopModal(), state.SelectedPlanet.EnglishName)
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRune, 'v', tcell.ModNone))
+	ur.DrawScreen()
+	if text := screenText(screen); strings.Contains(text, "1 AU") {
+		t.Errorf("V again left the strip on screen:\n%s", text)
+	}
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/furan917/go-solar-system/internal/systems"
+	"github.com/furan917/go-solar-system/internal/systems/formats"
+	"github.com/gdamore/tcell/v2"
+)
+
+// systemLoadEvent carries the progress of reading a large system file into the
+// event loop
+type systemLoadEvent struct {
+	tcell.EventTime
+	system string
+	status string              // how far the read has got, while it runs
+	data   *systems.SystemData // the system read, once done
+	done   bool
+	err    error
+}
+
+// loadInBackground reads a large system file off the event goroutine, showing its
+// progress on the status line, and switches to the system once it is in. Keys
+// wait meanwhile, except quit, which stops the read.
+func (sm *SystemManager) loadInBackground(system string) {
+	files := sm.uiRenderer.GetSystemManager()
+	name := files.GetSystemDisplayName(system)
+	size := files.FileSize(system)
+
+	ctx, cancel := context.WithCancel(sm.uiRenderer.background.Context())
+	sm.loadingSystem = system
+	sm.cancelLoad = cancel
+	sm.state.Loading = "Reading " + name
+	sm.state.CloseModal(ModalSystemList)
+
+	post := func(event *systemLoadEvent) {
+		event.system = system
+		event.SetEventNow()
+		postEvent(ctx, sm.uiRenderer.screen, event)
+	}
+	sm.uiRenderer.background.Go("loading "+name, func(context.Context) error {
+		data, err := files.ReadSystem(ctx, system, loadProgress(name, size, func(status string) {
+			post(&systemLoadEvent{status: status})
+		}))
+		post(&systemLoadEvent{data: data, done: true, err: err})
+		return nil
+	})
+}
+
+// applySystemLoad shows the progress of a background read, and switches to the
+// system when it is done. Events from a read that was stopped are dropped.
+func (sm *SystemManager) applySystemLoad(ev *systemLoadEvent) {
+	if ev.system != sm.loadingSystem {
+		return
+	}
+	if !ev.done {
+		sm.state.Loading = ev.status
+		return
+	}
+
+	sm.cancelLoad()
+	sm.loadingSystem = ""
+	sm.cancelLoad = nil
+	sm.state.Loading = ""
+	if ev.err != nil {
+		sm.errorHandler.HandleError(NewFileError("failed to load external system", ev.err).
+			WithContext("system", ev.system))
+		return
+	}
+
+	sm.uiRenderer.GetSystemManager().KeepSystem(ev.system, ev.data)
+	sm.switchTo(ev.system)
+}
+
+// stopLoading stops a background read, reporting whether there was one
+func (sm *SystemManager) stopLoading() bool {
+	if sm.cancelLoad == nil {
+		return false
+	}
+	sm.cancelLoad()
+	sm.state.SetStatusMessage("Stopped loading "+sm.uiRenderer.GetSystemManager().GetSystemDisplayName(sm.loadingSystem), statusMessageDuration)
+	sm.loadingSystem = ""
+	sm.cancelLoad = nil
+	sm.state.Loading = ""
+	return true
+}
+
+// loadProgress returns a progress callback for reading a system file of size
+// bytes that reports a status line each time another percent has been read
+func loadProgress(name string, size int64, report func(string)) func(formats.StreamProgress) {
+	last := -1
+	return func(progress formats.StreamProgress) {
+		if size <= 0 {
+			return
+		}
+		percent := int(progress.BytesRead * 100 / size)
+		if percent == last {
+			return
+		}
+		last = percent
+		report(fmt.Sprintf("Reading %s: %d bodies, %d%%", name, progress.Bodies, percent))
+	}
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/logging"
+	"github.com/furan917/go-solar-system/internal/systems"
+	"github.com/gdamore/tcell/v2"
+)
+
+// newLoadingFixture returns the app fixture with a systems directory holding
+// one small system, rubble, that tests read as if it were large
+func newLoadingFixture(t *testing.T) (*EventDispatcher, *AppState, tcell.SimulationScreen) {
+	t.Helper()
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+
+	dir := t.TempDir()
+	rubble := `{"systemName": "Rubble", "distance": "1 ly", "bodies": [{"id": "star", "englishName": "Rubble Star", "bodyType": "Star"}, {"id": "rock", "englishName": "Rock", "bodyType": "Asteroid", "semimajorAxis": 3e8}]}`
+	if err := os.WriteFile(filepath.Join(dir, "rubble.json"), []byte(rubble), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	files := systems.NewSystemManager(dir)
+	if err := files.ScanSystems(); err != nil {
+		t.Fatalf("ScanSystems() error = %v", err)
+	}
+	dispatcher.uiRenderer.systemManager = files
+
+	logger := logging.Discard()
+	dispatcher.systemManager = NewSystemManager(state, nil, dispatcher.uiRenderer, NewErrorHandler(logger, state), logger)
+	return dispatcher, state, screen
+}
+
+// awaitSystemLoad hands the events of a background read to the dispatcher until
+// its last one
+func awaitSystemLoad(dispatcher *EventDispatcher, screen tcell.SimulationScreen) {
+	for {
+		ev, ok := screen.PollEvent().(*systemLoadEvent)
+		if !ok {
+			continue
+		}
+		dispatcher.HandleEvent(ev)
+		if ev.done {
+			return
+		}
+	}
+}
+
+func TestBackgroundLoadSwitchesWhenDone(t *testing.T) {
+	dispatcher, state, screen := newLoadingFixture(t)
+
+	dispatcher.systemManager.loadInBackground("rubble")
+	if state.Loading == "" {
+		t.Fatal("Expected the status line to show the read")
+	}
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone))
+	if state.SelectedIndex != 0 {
+		t.Error("Expected keys to wait while the system loads")
+	}
+
+	awaitSystemLoad(dispatcher, screen)
+	if state.Loading != "" {
+		t.Errorf("Expected loading finished, status still %q", state.Loading)
+	}
+	if got := dispatcher.uiRenderer.GetSystemManager().GetCurrentSystem(); got != "rubble" {
+		t.Fatalf("current system = %s, want rubble", got)
+	}
+	if planets := state.GetPlanets(); len(planets) != 2 || planets[0].EnglishName != "Rubble Star" {
+		t.Errorf("planets = %+v, want Rubble's", planets)
+	}
+}
+
+func TestBackgroundLoadCanBeStopped(t *testing.T) {
+	dispatcher, state, screen := newLoadingFixture(t)
+	before := len(state.GetPlanets())
+
+	dispatcher.systemManager.loadInBackground("rubble")
+	dispatcher.HandleEvent(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+	if !state.IsRunning() {
+		t.Fatal("Expected Esc to stop the read, not quit")
+	}
+	if state.Loading != "" || state.GetStatusMessage() != "Stopped loading Rubble" {
+		t.Errorf("status = %q / %q, want the read stopped", state.Loading, state.GetStatusMessage())
+	}
+
+	// The file is small enough to be read before the stop is noticed; what it
+	// sends is ignored
+	awaitSystemLoad(dispatcher, screen)
+	if got := dispatcher.uiRenderer.GetSystemManager().GetCurrentSystem(); got != "solar-system" || len(state.GetPlanets()) != before {
+		t.Errorf("Expected the loaded system kept, got %s with %d bodies", got, len(state.GetPlanets()))
+	}
+}
@@ -1,10 +1,11 @@
 package app
 
 import (
+	"context"
 	"fmt"
-	"sort"
 
 	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/systems"
 )
 
 type SystemManager struct {
@@ -13,6 +14,10 @@ type SystemManager struct {
 	uiRenderer    *UIRenderer
 	errorHandler  *ErrorHandler
 	logger        interface{}
+
+	// The system file being read in the background, and how to stop reading it
+	loadingSystem string
+	cancelLoad    context.CancelFunc
 }
 
 func NewSystemManager(state *AppState, planetService *PlanetService, uiRenderer *UIRenderer, errorHandler *ErrorHandler, logger interface{}) *SystemManager {
@@ -34,53 +39,83 @@ func (sm *SystemManager) LoadCurrentSystem() error {
 		}
 	}()
 
-	currentSystem := sm.uiRenderer.GetSystemManager().GetCurrentSystem()
+	planets, err := sm.fetchSystem(sm.uiRenderer.GetSystemManager().GetCurrentSystem())
+	if err != nil {
+		return err
+	}
+	sm.state.SetPlanets(planets)
+	return nil
+}
 
-	if currentSystem == "solar-system" {
+// fetchSystem reads a system's bodies from the API or its file without touching
+// the state, so startup can run it off the event goroutine
+func (sm *SystemManager) fetchSystem(system string) ([]models.CelestialBody, error) {
+	if system == "solar-system" {
 		planets, err := sm.planetService.GetClient().GetPlanets()
 		if err != nil {
-			return NewAPIError("failed to load Solar System from API", err).
-				WithContext("system", currentSystem)
+			return nil, NewAPIError("failed to load Solar System from API", err).
+				WithContext("system", system)
 		}
 
 		if len(planets) == 0 {
-			return NewValidationError("no planets received from API", nil).
-				WithContext("system", currentSystem)
+			return nil, NewValidationError("no planets received from API", nil).
+				WithContext("system", system)
 		}
 
-		sm.state.SetPlanets(planets)
-	} else {
-		systemData, err := sm.uiRenderer.GetSystemManager().GetSystemData()
-		if err != nil {
-			return NewFileError("failed to load external system", err).
-				WithContext("system", currentSystem)
-		}
+		return planets, nil
+	}
 
-		if len(systemData.Bodies) == 0 {
-			return NewValidationError("no celestial bodies in system file", nil).
-				WithContext("system", currentSystem)
-		}
+	systemData, err := sm.uiRenderer.GetSystemManager().LoadSystem(system)
+	if err != nil {
+		return nil, NewFileError("failed to load external system", err).
+			WithContext("system", system)
+	}
 
-		sm.state.SetPlanets(systemData.Bodies)
+	if len(systemData.Bodies) == 0 {
+		return nil, NewValidationError("no celestial bodies in system file", nil).
+			WithContext("system", system)
 	}
 
-	return nil
+	return systemData.Bodies, nil
 }
 
-func (sm *SystemManager) SortPlanetsByDistance() error {
+// showBodies makes planets the loaded bodies: the Sun named as such, a central
+// star added when the system has none, and the list in the current order
+func (sm *SystemManager) showBodies(planets []models.CelestialBody) {
+	sm.uiRenderer.setBelts(sm.uiRenderer.GetSystemManager().Belts(sm.uiRenderer.GetSystemManager().GetCurrentSystem()))
+	sm.state.SetPlanets(sm.NormalizePlanetNames(planets))
+	centralStar := sm.FindOrCreateCentralStar(sm.state.GetPlanets())
+
+	if !sm.ContainsCentralStar(sm.state.GetPlanets()) {
+		sm.state.SetPlanets(append([]models.CelestialBody{centralStar}, sm.state.GetPlanets()...))
+	}
+
+	if err := sm.SortPlanets(); err != nil {
+		sm.errorHandler.HandleError(NewStateError("failed to sort planets", err))
+	}
+}
+
+// SortPlanets orders the planet list by the current sort mode and grouping,
+// keeping the selected body selected
+func (sm *SystemManager) SortPlanets() error {
 	defer func() {
 		if r := recover(); r != nil {
 			if logger, ok := sm.logger.(interface{ Printf(string, ...interface{}) }); ok {
-				logger.Printf("Panic in sortPlanetsByDistance: %v", r)
+				logger.Printf("Panic in sortPlanets: %v", r)
 			}
 		}
 	}()
 
 	planets := sm.state.GetPlanets()
-	sort.Slice(planets, func(i, j int) bool {
-		return planets[i].SemimajorAxis < planets[j].SemimajorAxis
-	})
+	sortPlanets(planets, sm.state.SortMode, sm.state.GroupByType)
 	sm.state.SetPlanets(planets)
+
+	for i, planet := range planets {
+		if planet.EnglishName == sm.state.SelectedPlanet.EnglishName {
+			sm.state.UpdatePlanetSelection(i, planet)
+			break
+		}
+	}
 	return nil
 }
 
@@ -181,6 +216,16 @@ func (sm *SystemManager) SwitchToSelectedSystem() {
 
 	selectedSystem := availableSystems[sm.state.SystemSelectedIndex]
 
+	// A huge file is read in the background; the switch finishes once it is in
+	if sm.uiRenderer.GetSystemManager().IsLarge(selectedSystem) {
+		sm.loadInBackground(selectedSystem)
+		return
+	}
+	sm.switchTo(selectedSystem)
+}
+
+// switchTo makes selectedSystem the loaded system and shows its bodies
+func (sm *SystemManager) switchTo(selectedSystem string) {
 	if err := sm.uiRenderer.GetSystemManager().SwitchToSystem(selectedSystem); err != nil {
 		sm.errorHandler.HandleError(NewSystemError("failed to switch system", err).
 			WithContext("target_system", selectedSystem))
@@ -193,19 +238,43 @@ func (sm *SystemManager) SwitchToSelectedSystem() {
 		return
 	}
 
-	if err := sm.SortPlanetsByDistance(); err != nil {
-		sm.errorHandler.HandleError(NewStateError("failed to sort planets after system switch", err))
+	sm.showBodies(sm.state.GetPlanets())
+
+	sm.state.SelectedIndex = 0
+	sm.state.ResetListTabs()
+	sm.state.StopFocus()
+	sm.state.Evolution = nil
+	sm.state.CloseModal(ModalSystemList)
+	sm.checkPhysics()
+}
+
+// SaveOrbitalElements writes the body's edited orbital elements back to the current system file
+func (sm *SystemManager) SaveOrbitalElements(body models.CelestialBody) (string, error) {
+	currentSystem := sm.uiRenderer.GetSystemManager().GetCurrentSystem()
+
+	path, err := sm.uiRenderer.GetSystemManager().SaveOrbitalElements(currentSystem, body)
+	if err != nil {
+		appErr := NewFileError("failed to save orbital elements", err).
+			WithContext("system", currentSystem).
+			WithContext("body", body.EnglishName)
+		sm.errorHandler.HandleError(appErr)
+		return "", err
 	}
 
-	sm.state.SetPlanets(sm.NormalizePlanetNames(sm.state.GetPlanets()))
-	centralStar := sm.FindOrCreateCentralStar(sm.state.GetPlanets())
+	return path, nil
+}
 
-	if !sm.ContainsCentralStar(sm.state.GetPlanets()) {
-		sm.state.SetPlanets(append([]models.CelestialBody{centralStar}, sm.state.GetPlanets()...))
+// WriteSystemMetadata writes a system's edited metadata back to its file
+func (sm *SystemManager) WriteSystemMetadata(systemName string, metadata systems.SystemMetadata) (string, error) {
+	path, err := sm.uiRenderer.GetSystemManager().WriteSystemMetadata(systemName, metadata)
+	if err != nil {
+		appErr := NewFileError("failed to save system metadata", err).
+			WithContext("system", systemName)
+		sm.errorHandler.HandleError(appErr)
+		return "", err
 	}
 
-	sm.state.SelectedIndex = 0
-	sm.state.ShowingSystemList = false
+	return path, nil
 }
 
 func (sm *SystemManager) isOurSolarSystem(planets []models.CelestialBody) bool {
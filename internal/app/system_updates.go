@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/updates"
+	"github.com/gdamore/tcell/v2"
+)
+
+// updateCheckEvent carries a checked update manifest into the event loop
+type updateCheckEvent struct {
+	tcell.EventTime
+	manifest *updates.Manifest
+	err      error
+}
+
+// updateDownloadEvent carries one downloaded system file into the event loop
+type updateDownloadEvent struct {
+	tcell.EventTime
+	update updates.Update
+	data   []byte
+	err    error
+	last   bool // no more downloads follow
+}
+
+// checkForSystemUpdates fetches the update manifest off the event goroutine
+func (ed *EventDispatcher) checkForSystemUpdates() {
+	if ed.updater == nil || ed.state.UpdatesChecking {
+		return
+	}
+	ed.state.UpdatesChecking = true
+
+	updater, screen := ed.updater, ed.uiRenderer.screen
+	ed.uiRenderer.background.Go("update check", func(ctx context.Context) error {
+		checkCtx, cancel := context.WithTimeout(ctx, constants.DefaultTimeout)
+		manifest, err := updater.Check(checkCtx)
+		cancel()
+		event := &updateCheckEvent{manifest: manifest, err: err}
+		event.SetEventNow()
+		postUpdateEvent(ctx, screen, event)
+		return nil
+	})
+}
+
+// applyUpdateCheck lists the systems the manifest offers that are not installed
+// as listed
+func (ed *EventDispatcher) applyUpdateCheck(ev *updateCheckEvent) {
+	ed.state.UpdatesChecking = false
+	ed.state.UpdatesError = ""
+	if ev.err != nil {
+		ed.state.UpdatesError = ev.err.Error()
+		ed.state.SystemUpdates = nil
+		return
+	}
+	ed.state.SystemUpdates = ev.manifest.Pending(ed.uiRenderer.GetSystemManager().SystemFileHash)
+}
+
+// downloadSystemUpdates fetches every listed update off the event goroutine, one
+// after another
+func (ed *EventDispatcher) downloadSystemUpdates() {
+	if ed.updater == nil || ed.state.UpdatesDownloading {
+		return
+	}
+	pending := ed.state.SystemUpdates
+	if len(pending) == 0 {
+		ed.state.SetStatusMessage("No system updates to download", statusMessageDuration)
+		return
+	}
+	ed.state.UpdatesDownloading = true
+	ed.state.SetStatusMessage(fmt.Sprintf("Downloading %d system file(s)...", len(pending)), statusMessageDuration)
+
+	updater, screen := ed.updater, ed.uiRenderer.screen
+	ed.uiRenderer.background.Go("update download", func(ctx context.Context) error {
+		for i, update := range pending {
+			downloadCtx, cancel := context.WithTimeout(ctx, constants.DefaultTimeout)
+			data, err := updater.Download(downloadCtx, update.Entry)
+			cancel()
+			event := &updateDownloadEvent{update: update, data: data, err: err, last: i == len(pending)-1}
+			event.SetEventNow()
+			postUpdateEvent(ctx, screen, event)
+		}
+		return nil
+	})
+}
+
+// postUpdateEvent hands an update event to the event loop, giving up if the loop
+// does not take it in time or ctx is done
+func postUpdateEvent(ctx context.Context, screen tcell.Screen, event tcell.Event) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DefaultTimeout)
+	defer cancel()
+	postEvent(ctx, screen, event)
+}
+
+// applyUpdateDownload installs a downloaded system file into the systems directory
+func (ed *EventDispatcher) applyUpdateDownload(ev *updateDownloadEvent) {
+	if ev.last {
+		ed.state.UpdatesDownloading = false
+	}
+	err := ev.err
+	if err == nil {
+		_, err = ed.uiRenderer.GetSystemManager().InstallSystemFile(ev.update.File, ev.data)
+	}
+	if err != nil {
+		ed.state.SetStatusMessage(fmt.Sprintf("Could not update %s: %v", ev.update.Name, err), statusMessageDuration)
+		return
+	}
+
+	ed.state.SystemUpdates = slices.DeleteFunc(slices.Clone(ed.state.SystemUpdates), func(update updates.Update) bool {
+		return update.Name == ev.update.Name
+	})
+	ed.state.SetStatusMessage(fmt.Sprintf("Installed %s (%s)", ev.update.Name, ev.update.Status), statusMessageDuration)
+}
+
+// systemUpdatesLine describes the update check for the system selection modal,
+// or returns "" when there is nothing to say
+func systemUpdatesLine(state *AppState) string {
+	switch {
+	case state.UpdatesDownloading:
+		return "Updates: downloading..."
+	case len(state.SystemUpdates) > 0:
+		names := make([]string, len(state.SystemUpdates))
+		for i, update := range state.SystemUpdates {
+			names[i] = fmt.Sprintf("%s (%s)", update.Name, update.Status)
+		}
+		return "Updates: " + strings.Join(names, ", ") + " • 'u' to download"
+	case state.UpdatesChecking:
+		return "Updates: checking..."
+	case state.UpdatesError != "":
+		return "Updates: check failed: " + state.UpdatesError
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/updates"
+)
+
+func TestUpdateCheckListsSystemsNotInstalled(t *testing.T) {
+	dispatcher, state, _ := newAppFixture(t, 120, 40)
+
+	state.UpdatesChecking = true
+	manifest := &updates.Manifest{Systems: []updates.Entry{{Name: "kepler-90", File: "kepler-90.json", SHA256: "abc"}}}
+	dispatcher.HandleEvent(&updateCheckEvent{manifest: manifest})
+
+	if state.UpdatesChecking {
+		t.Error("UpdatesChecking still set after the check came back")
+	}
+	if len(state.SystemUpdates) != 1 || state.SystemUpdates[0].Status != updates.StatusNew {
+		t.Fatalf("SystemUpdates = %+v, want kepler-90 as new", state.SystemUpdates)
+	}
+	if line := systemUpdatesLine(state); !strings.Contains(line, "kepler-90 (new)") || !strings.Contains(line, "'u'") {
+		t.Errorf("systemUpdatesLine() = %q, want kepler-90 offered for download", line)
+	}
+
+	// A failed download keeps the update listed
+	state.UpdatesDownloading = true
+	dispatcher.HandleEvent(&updateDownloadEvent{update: state.SystemUpdates[0], err: errors.New("timed out"), last: true})
+	if state.UpdatesDownloading || len(state.SystemUpdates) != 1 {
+		t.Errorf("after a failed download: downloading %v, updates %+v", state.UpdatesDownloading, state.SystemUpdates)
+	}
+
+	dispatcher.HandleEvent(&updateCheckEvent{err: errors.New("signature does not match")})
+	if line := systemUpdatesLine(state); !strings.Contains(line, "check failed") {
+		t.Errorf("systemUpdatesLine() = %q, want the failure shown", line)
+	}
+}
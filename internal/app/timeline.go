@@ -0,0 +1,202 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// timelineGlideFrames is how many frames the simulation clock takes to reach a
+	// scrubbed time, so the planets visibly travel there
+	timelineGlideFrames = 8
+
+	// timelineMinBar is the narrowest bar worth drawing
+	timelineMinBar = 10
+
+	// timelineLeft and timelineRight are the widths of the labels either side of
+	// the bar: the first year, then the last year and the simulated date
+	timelineLeft  = len("2000 ")
+	timelineRight = len(" 2000  2000-01-01")
+)
+
+// timelineSpan is the simulated time either side of today the scrubber covers
+func timelineSpan() time.Duration {
+	return time.Duration(constants.TimelineYears * 365.25 * float64(24*time.Hour))
+}
+
+// timelineScrub is where the time scrubber has been dragged to. The event goroutine
+// sets it and the render goroutine moves the simulation clock after it.
+type timelineScrub struct {
+	mu       sync.Mutex
+	dragging bool
+	gliding  bool
+	target   time.Time
+	from     time.Time // where the clock was when the glide began
+	frame    int       // frames of the glide drawn so far
+}
+
+// drag points the scrubber at t and holds it there until release
+func (s *timelineScrub) drag(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dragging = true
+	if !t.Equal(s.target) || !s.gliding {
+		s.target, s.gliding, s.frame = t, true, 0
+	}
+}
+
+// release lets go of the scrubber; the clock finishes its glide, then runs on
+func (s *timelineScrub) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dragging = false
+}
+
+// isDragging reports whether the scrubber is held
+func (s *timelineScrub) isDragging() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dragging
+}
+
+// next returns where the simulation clock, now at now, should be for this frame:
+// eased along the glide to the scrubbed time, then held there while dragging. It
+// returns false once the scrubber has let go of the clock.
+func (s *timelineScrub) next(now time.Time) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.gliding {
+		return s.target, s.dragging
+	}
+
+	if s.frame == 0 {
+		s.from = now
+	}
+	s.frame++
+	if s.frame >= timelineGlideFrames {
+		s.gliding = false
+		return s.target, true
+	}
+	progress := float64(s.frame) / timelineGlideFrames
+	eased := 1 - (1-progress)*(1-progress)
+	return s.from.Add(time.Duration(float64(s.target.Sub(s.from)) * eased)), true
+}
+
+// timelineBar returns the first column and width of the scrubber's bar
+func timelineBar(area layout.Rect) (int, int) {
+	return area.X + timelineLeft, area.Width - timelineLeft - timelineRight
+}
+
+// timelineTimeAt returns the simulated time a screen column of the bar stands for,
+// with today in the middle
+func timelineTimeAt(area layout.Rect, today time.Time, column int) time.Time {
+	x, width := timelineBar(area)
+	offset := max(0, min(column-x, width-1))
+	fraction := float64(offset)/float64(width-1)*2 - 1
+	return today.Add(time.Duration(fraction * float64(timelineSpan())))
+}
+
+// timelineColumn returns the offset along the bar of a simulated time, and false
+// when the time lies beyond either end, where it is pinned
+func timelineColumn(area layout.Rect, today, t time.Time) (int, bool) {
+	_, width := timelineBar(area)
+	fraction := (float64(t.Sub(today))/float64(timelineSpan()) + 1) / 2
+	offset := int(fraction*float64(width-1) + 0.5)
+	if offset < 0 || offset >= width {
+		return max(0, min(offset, width-1)), false
+	}
+	return offset, true
+}
+
+// drawTimeline draws the scrubber: a bar from TimelineYears ago to as far ahead,
+// a tick at today and a marker at the simulated date
+func (ur *UIRenderer) drawTimeline(area layout.Rect) {
+	x, width := timelineBar(area)
+	if area.Empty() || width < timelineMinBar {
+		return
+	}
+
+	today := ur.clock.Now()
+	simulated := ur.renderer.GetClock().Now()
+	dragging := ur.scrub.isDragging()
+
+	rule, tick, marker, before, after := '─', '┼', '◆', '◀', '▶'
+	if ur.renderer.GetSymbols().ASCII {
+		rule, tick, marker, before, after = '-', '+', '#', '<', '>'
+	}
+
+	labelStyle := tcell.StyleDefault.Foreground(tcell.ColorGray)
+	markerStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
+	dateStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	if dragging {
+		dateStyle = markerStyle
+	}
+
+	ur.drawText(area.X, area.Y, labelStyle, fmt.Sprintf("%d", today.Add(-timelineSpan()).Year()))
+	for i := 0; i < width; i++ {
+		ur.screen.SetContent(x+i, area.Y, rule, nil, labelStyle)
+	}
+	ur.screen.SetContent(x+width/2, area.Y, tick, nil, labelStyle)
+
+	offset, inRange := timelineColumn(area, today, simulated)
+	switch {
+	case inRange:
+		ur.screen.SetContent(x+offset, area.Y, marker, nil, markerStyle)
+	case simulated.Before(today):
+		ur.screen.SetContent(x, area.Y, before, nil, markerStyle)
+	default:
+		ur.screen.SetContent(x+width-1, area.Y, after, nil, markerStyle)
+	}
+
+	ur.drawText(x+width+1, area.Y, labelStyle, fmt.Sprintf("%d", today.Add(timelineSpan()).Year()))
+	ur.drawText(x+width+7, area.Y, dateStyle, simulated.UTC().Format("2006-01-02"))
+}
+
+// scrubTo points the scrubber at the time under a screen column of the bar
+func (ur *UIRenderer) scrubTo(column int) {
+	area := layout.Compute(ur.screen.Size()).Timeline
+	ur.scrub.drag(timelineTimeAt(area, ur.clock.Now(), column))
+}
+
+// glideTimeline moves the simulation clock along to the scrubbed time, keeping
+// its speed
+func (ur *UIRenderer) glideTimeline() {
+	clock := ur.renderer.GetClock()
+	if t, ok := ur.scrub.next(clock.Now()); ok {
+		clock.Set(t, clock.Speed())
+	}
+}
+
+// HandleDrag scrubs simulated time while the left button is held after pressing
+// it on the timeline. It reports whether it took the event, so a drag never also
+// clicks what is under the pointer.
+func (meh *MouseEventHandler) HandleDrag(ev *tcell.EventMouse) bool {
+	ur := meh.renderer
+	if ev.Buttons() != tcell.Button1 {
+		meh.buttonHeld = false
+		ur.scrub.release()
+		return false
+	}
+
+	pressed := !meh.buttonHeld
+	meh.buttonHeld = true
+	x, y := ev.Position()
+	if !ur.scrub.isDragging() {
+		if !pressed || !layout.Compute(ur.screen.Size()).Timeline.Contains(x, y) {
+			return false
+		}
+		if modal := meh.state.TopModal(); modal != ModalNone {
+			if ur.modalArea(meh.state, modal).Contains(x, y) || !modalSpecFor(modal).passThrough {
+				return false
+			}
+		}
+	}
+
+	ur.scrubTo(x)
+	return true
+}
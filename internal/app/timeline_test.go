@@ -0,0 +1,89 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTimelineColumnsRoundTrip(t *testing.T) {
+	area := layout.Rect{X: 2, Y: 30, Width: 100, Height: 1}
+	today := deterministicEpoch
+	x, width := timelineBar(area)
+
+	if got := timelineTimeAt(area, today, x); !got.Equal(today.Add(-timelineSpan())) {
+		t.Errorf("left end = %v, want %v", got, today.Add(-timelineSpan()))
+	}
+	if got := timelineTimeAt(area, today, x+width+5); !got.Equal(today.Add(timelineSpan())) {
+		t.Errorf("past the right end = %v, want it pinned to %v", got, today.Add(timelineSpan()))
+	}
+	for _, offset := range []int{0, 17, width / 2, width - 1} {
+		if got, ok := timelineColumn(area, today, timelineTimeAt(area, today, x+offset)); !ok || got != offset {
+			t.Errorf("column %d came back as %d, %v", offset, got, ok)
+		}
+	}
+	if _, ok := timelineColumn(area, today, today.Add(2*timelineSpan())); ok {
+		t.Error("a time past the end should be out of range")
+	}
+}
+
+func TestDraggingTimelineScrubsClock(t *testing.T) {
+	dispatcher, _, screen := newAppFixture(t, 120, 40)
+	ur := dispatcher.uiRenderer
+	ur.SetClock(newDeterministicClock())
+	clock := ur.GetRenderer().GetClock()
+
+	area := layout.Compute(120, 40).Timeline
+	x, width := timelineBar(area)
+	dispatcher.HandleEvent(tcell.NewEventMouse(x+width/2, area.Y, tcell.Button1, tcell.ModNone))
+	dispatcher.HandleEvent(tcell.NewEventMouse(x+width-1, area.Y, tcell.Button1, tcell.ModNone))
+	target := ur.clock.Now().Add(timelineSpan())
+
+	ur.DrawScreen()
+	first := clock.Now()
+	if !first.After(deterministicEpoch) || !first.Before(target) {
+		t.Errorf("after one frame the clock is at %v; it should be gliding towards %v", first, target)
+	}
+
+	// Each frame ends by stepping the clock on, so it reads a frame past the scrubber
+	for i := 0; i < timelineGlideFrames; i++ {
+		ur.DrawScreen()
+	}
+	held := clock.Now().Sub(target)
+	if held < 0 || held > 24*time.Hour {
+		t.Fatalf("clock settled at %v, want %v", clock.Now(), target)
+	}
+	ur.DrawScreen()
+	if clock.Now().Sub(target) != held {
+		t.Error("the clock should stay where the scrubber is held")
+	}
+	if row := strings.Split(screenText(screen), "\n")[area.Y]; !strings.Contains(row, target.UTC().Format("2006-01-02")) {
+		t.Errorf("timeline row %q does not show the scrubbed date", row)
+	}
+
+	dispatcher.HandleEvent(tcell.NewEventMouse(x+width-1, area.Y, tcell.ButtonNone, tcell.ModNone))
+	ur.DrawScreen()
+	if clock.Now().Sub(target) <= held {
+		t.Error("time should run on after the scrubber is let go")
+	}
+}
+
+func TestDragStartingOnMapDoesNotScrub(t *testing.T) {
+	dispatcher, _, _ := newAppFixture(t, 120, 40)
+	ur := dispatcher.uiRenderer
+	before := ur.GetRenderer().GetClock().Now()
+
+	area := layout.Compute(120, 40).Timeline
+	dispatcher.HandleEvent(tcell.NewEventMouse(60, 20, tcell.Button1, tcell.ModNone))
+	dispatcher.HandleEvent(tcell.NewEventMouse(60, area.Y, tcell.Button1, tcell.ModNone))
+	if ur.scrub.isDragging() {
+		t.Fatal("a drag from the map onto the timeline should not scrub")
+	}
+	ur.DrawScreen()
+	if got := ur.GetRenderer().GetClock().Now(); got.Sub(before) > 24*time.Hour {
+		t.Errorf("the clock jumped from %v to %v", before, got)
+	}
+}
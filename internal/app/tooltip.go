@@ -0,0 +1,136 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+// tooltipDelay is how long the pointer must rest on a body before its tooltip shows
+const tooltipDelay = 400 * time.Millisecond
+
+// HandleHover tracks where the pointer rests. Pressing a button hides the tooltip
+// until the pointer moves again.
+func (meh *MouseEventHandler) HandleHover(ev *tcell.EventMouse) {
+	if ev.Buttons() != tcell.ButtonNone {
+		meh.state.ClearHover()
+		return
+	}
+	x, y := ev.Position()
+	meh.state.SetHover(x, y, ev.When())
+}
+
+// planetAt returns the body drawn nearest to a screen cell, if the cell is within
+// reach of its glyph
+func planetAt(positions map[string]visualization.PlanetPosition, x, y int) (models.CelestialBody, bool) {
+	var nearest models.CelestialBody
+	best := math.Inf(1)
+	for _, pos := range positions {
+		dx := float64(x - pos.X)
+		dy := float64(y - pos.Y)
+		distance := math.Sqrt(dx*dx + dy*dy)
+		if distance <= float64(pos.Radius+2) && distance < best {
+			nearest, best = pos.Planet, distance
+		}
+	}
+	return nearest, !math.IsInf(best, 1)
+}
+
+// listItemAt returns the index of the planet list row under a screen cell
+func listItemAt(positions []PlanetListPosition, x, y int) (int, bool) {
+	for _, pos := range positions {
+		if x >= pos.X && x < pos.X+pos.Width && y == pos.Y {
+			return pos.Index, true
+		}
+	}
+	return 0, false
+}
+
+// hoveredBody returns the body the pointer has rested on long enough for a tooltip
+func (ur *UIRenderer) hoveredBody(now time.Time) (models.CelestialBody, bool) {
+	state := ur.state
+	if !state.Hovering || now.Sub(state.HoverSince) < tooltipDelay || state.IsAnyModalShowing() {
+		return models.CelestialBody{}, false
+	}
+
+	if index, ok := listItemAt(state.GetPlanetListPositions(), state.HoverX, state.HoverY); ok && index < len(state.ListedBodies()) {
+		return state.ListedBodies()[index], true
+	}
+	return planetAt(state.GetPlanetPositions(), state.HoverX, state.HoverY)
+}
+
+// tooltipLines names a body and gives a couple of its key figures
+func tooltipLines(body models.CelestialBody) []string {
+	title := body.EnglishName
+	if body.BodyType != "" {
+		title += " (" + body.BodyType + ")"
+	}
+	lines := []string{title}
+
+	var orbit, size string
+	if body.SemimajorAxis > 0 {
+		orbit = formatAU(body.SemimajorAxis) + " AU"
+		if body.SideralOrbit > 0 {
+			orbit += fmt.Sprintf(" • %s day orbit", formatCount(int(math.Round(body.SideralOrbit))))
+		}
+	}
+	if body.MeanRadius > 0 {
+		size = fmt.Sprintf("radius %s km", formatCount(int(math.Round(body.MeanRadius))))
+	}
+	if moons := len(body.Moons); moons > 0 {
+		if size != "" {
+			size += " • "
+		}
+		noun := "moons"
+		if moons == 1 {
+			noun = "moon"
+		}
+		size += fmt.Sprintf("%d %s", moons, noun)
+	}
+
+	for _, line := range []string{orbit, size} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// drawTooltip shows the hovered body's tooltip beside the pointer, kept on screen
+func (ur *UIRenderer) drawTooltip(width, height int) {
+	body, ok := ur.hoveredBody(time.Now())
+	if !ok {
+		return
+	}
+
+	lines := tooltipLines(body)
+	boxWidth := 0
+	for _, line := range lines {
+		boxWidth = max(boxWidth, ui.TextWidth(line)+2)
+	}
+	boxWidth = minimum(boxWidth, width)
+
+	// Below and right of the pointer, flipped to the other side near the edges
+	x, y := ur.state.HoverX+2, ur.state.HoverY+1
+	if x+boxWidth > width {
+		x = max(ur.state.HoverX-boxWidth-1, 0)
+	}
+	if y+len(lines) > height {
+		y = max(ur.state.HoverY-len(lines), 0)
+	}
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorLightCyan).Bold(true)
+	lineStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorLightCyan)
+	for i, line := range lines {
+		style := lineStyle
+		if i == 0 {
+			style = titleStyle
+		}
+		ur.drawText(x, y+i, style, fmt.Sprintf(" %-*s", boxWidth-1, truncateText(line, boxWidth-2)))
+	}
+}
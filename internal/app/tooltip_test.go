@@ -0,0 +1,79 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTooltipAfterDwell(t *testing.T) {
+	dispatcher, state, screen := newAppFixture(t, 120, 40)
+	ur := dispatcher.uiRenderer
+	ur.DrawScreen()
+
+	hover := func(x, y int) {
+		dispatcher.HandleEvent(tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone))
+		ur.DrawScreen()
+	}
+	// dwell lets the pointer rest where it is for the tooltip delay
+	dwell := func() {
+		state.HoverSince = state.HoverSince.Add(-tooltipDelay)
+		state.Publish()
+		ur.DrawScreen()
+	}
+
+	// Over a planet on the map
+	mars := state.GetPlanetPositions()["Mars"]
+	hover(mars.X, mars.Y)
+	if strings.Contains(screenText(screen), "Mars (Planet)") {
+		t.Fatal("tooltip shown before the pointer rested")
+	}
+	dwell()
+	if text := screenText(screen); !strings.Contains(text, "Mars (Planet)") || !strings.Contains(text, "radius 3,389 km") {
+		t.Fatalf("Expected Mars's tooltip after the delay, got:\n%s", text)
+	}
+
+	// Over nothing, it goes and stays gone
+	x, y := emptyCell(t, state, 120, 40)
+	hover(x, y)
+	dwell()
+	if strings.Contains(screenText(screen), "Mars (Planet)") {
+		t.Error("tooltip still shown after the pointer left Mars")
+	}
+
+	// Over a row of the planet list
+	var row PlanetListPosition
+	for _, pos := range state.GetPlanetListPositions() {
+		if state.ListedBodies()[pos.Index].EnglishName == "Venus" {
+			row = pos
+		}
+	}
+	hover(row.X+1, row.Y)
+	dwell()
+	if text := screenText(screen); !strings.Contains(text, "Venus (Planet)") {
+		t.Errorf("Expected Venus's tooltip over its list row, got:\n%s", text)
+	}
+
+	// A click hides it
+	dispatcher.HandleEvent(tcell.NewEventMouse(row.X+1, row.Y, tcell.Button1, tcell.ModNone))
+	if state.Hovering {
+		t.Error("Expected a click to hide the tooltip")
+	}
+}
+
+// emptyCell finds a screen cell with no body or list row under it
+func emptyCell(t *testing.T, state *AppState, width, height int) (int, int) {
+	t.Helper()
+	for y := 1; y < height-1; y++ {
+		for x := width - 1; x >= 0; x-- {
+			_, onBody := planetAt(state.GetPlanetPositions(), x, y)
+			_, onRow := listItemAt(state.GetPlanetListPositions(), x, y)
+			if !onBody && !onRow {
+				return x, y
+			}
+		}
+	}
+	t.Fatal("no empty cell on screen")
+	return 0, 0
+}
@@ -0,0 +1,256 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// transitAnimation is how long one pass across the star takes to play, however
+	// long the real transit lasts
+	transitAnimation = 4 * time.Second
+
+	// transitWindow is how much of the light curve is shown, in transit durations,
+	// so there is steady starlight either side of the dip
+	transitWindow = 1.6
+
+	// transitStarRows is the height of the side view of the star
+	transitStarRows = 7
+
+	// transitLabelWidth is the room left of the light curve for its brightness labels
+	transitLabelWidth = len("100.00% ")
+)
+
+// transitStar returns the star planets orbit: the first one listed
+func transitStar(planets []models.CelestialBody) (models.CelestialBody, bool) {
+	for _, body := range planets {
+		if body.BodyType == "Star" {
+			return body, true
+		}
+	}
+	return models.CelestialBody{}, false
+}
+
+// transitFor models the selected system's star being crossed by a planet
+func transitFor(planets []models.CelestialBody, planet models.CelestialBody) (orbital.Transit, bool) {
+	star, ok := transitStar(planets)
+	if !ok || planet.BodyType == "Star" {
+		return orbital.Transit{}, false
+	}
+	return orbital.NewTransit(star, planet)
+}
+
+// canShowTransit reports whether the transit panel can open for a planet: it is in
+// another star system and its size, distance and period are known
+func (ur *UIRenderer) canShowTransit(state *AppState, planet models.CelestialBody) bool {
+	if ur.systemManager.GetCurrentSystem() == "solar-system" {
+		return false
+	}
+	_, ok := transitFor(state.GetPlanets(), planet)
+	return ok
+}
+
+// openTransit opens the light curve of the planet whose details are showing
+func (ed *EventDispatcher) openTransit() {
+	planet := ed.state.SelectedPlanet
+	if !ed.uiRenderer.canShowTransit(ed.state, planet) {
+		message := fmt.Sprintf("%s's size, distance or period isn't known, so its transit can't be drawn", planet.EnglishName)
+		if ed.uiRenderer.GetSystemManager().GetCurrentSystem() == "solar-system" {
+			message = "Transit light curves are for planets of other stars"
+		}
+		ed.state.SetStatusMessage(message, statusMessageDuration)
+		return
+	}
+
+	for i, body := range ed.state.GetPlanets() {
+		if body.EnglishName == planet.EnglishName {
+			ed.state.ShowTransit(i, ed.uiRenderer.clock.Now())
+			return
+		}
+	}
+}
+
+// handleTransitKeys handles keyboard input in the transit panel
+func (ed *EventDispatcher) handleTransitKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		ed.state.PopModal()
+	case tcell.KeyLeft, tcell.KeyUp:
+		ed.stepTransitPlanet(-1)
+	case tcell.KeyRight, tcell.KeyDown:
+		ed.stepTransitPlanet(1)
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'q', 'Q', 'b', 'B':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// stepTransitPlanet moves the panel to the next planet in direction whose transit
+// can be drawn, and plays it from the start
+func (ed *EventDispatcher) stepTransitPlanet(direction int) {
+	planets := ed.state.GetPlanets()
+	for i := 1; i < len(planets); i++ {
+		index := ((ed.state.TransitIndex+direction*i)%len(planets) + len(planets)) % len(planets)
+		if _, ok := transitFor(planets, planets[index]); ok {
+			ed.state.TransitIndex = index
+			ed.state.TransitStarted = ed.uiRenderer.clock.Now()
+			return
+		}
+	}
+}
+
+// transitModalHeight is the height of the transit panel
+func transitModalHeight(screenHeight int) int {
+	return minimum(28, screenHeight-4)
+}
+
+// drawTransitModal draws a planet crossing its star from the side, the light curve
+// it makes, and when the next one is due on the simulated timeline
+func (ur *UIRenderer) drawTransitModal(width, height int) {
+	modalX, modalY, modalWidth, modalHeight := ur.setupModal(width, height, transitModalHeight(height))
+
+	planets := ur.state.GetPlanets()
+	if ur.state.TransitIndex >= len(planets) {
+		return
+	}
+	planet := planets[ur.state.TransitIndex]
+	star, _ := transitStar(planets)
+	transit, ok := transitFor(planets, planet)
+	if !ok {
+		return
+	}
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	textStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	noteStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+1, titleStyle, fmt.Sprintf(" Transit: %s crossing %s ", planet.EnglishName, star.EnglishName))
+
+	period := formatFlightTime(transit.Period.Seconds())
+	summary := fmt.Sprintf("Dims the star by %.3f%% for %s, every %s", transit.Depth()*100, formatFlightTime(transit.Duration().Seconds()), period)
+	if !transit.Transits() {
+		summary = fmt.Sprintf("Its orbit is tilted %.1f° from edge-on, so it passes %.1f star radii from the centre and never dims it",
+			math.Abs(90-planet.Inclination), transit.Impact/transit.StarRadius)
+	}
+	currentY := ur.drawWrappedTextAt(modalX+2, modalY+3, textStyle, summary, ur.contentWidth())
+
+	until := ur.renderer.GetEphemeris().UntilTransit(planet, ur.renderer.GetClock().Now())
+	currentY = ur.drawWrappedTextAt(modalX+2, currentY, noteStyle,
+		fmt.Sprintf("Next pass in front, seen from below the map: in %s of simulated time", formatFlightTime(until.Seconds())), ur.contentWidth())
+
+	// The pass loops; the window is as long as a transit would be edge-on, so a
+	// planet that misses is still seen going by
+	span := transit.Duration()
+	if span == 0 {
+		edgeOn := transit
+		edgeOn.Impact = 0
+		span = edgeOn.Duration()
+	}
+	span = time.Duration(float64(span) * transitWindow)
+	elapsed := ur.clock.Now().Sub(ur.state.TransitStarted) % transitAnimation
+	now := time.Duration(float64(span) * (float64(elapsed)/float64(transitAnimation) - 0.5))
+
+	contentWidth := modalWidth - 4
+	top := currentY + 1
+	ur.drawTransitSideView(transit, modalX+2, top, contentWidth, span, now)
+
+	curveTop := top + transitStarRows + 1
+	curveRows := modalY + modalHeight - 5 - curveTop
+	if curveRows >= 3 {
+		ur.drawLightCurve(transit, modalX+2, curveTop, contentWidth, curveRows, span, now)
+		axis := fmt.Sprintf("-%s", formatFlightTime((span / 2).Seconds()))
+		ur.drawText(modalX+2+transitLabelWidth, curveTop+curveRows, noteStyle, axis)
+		after := fmt.Sprintf("+%s", formatFlightTime((span / 2).Seconds()))
+		ur.drawText(modalX+2+contentWidth-len(after), curveTop+curveRows, noteStyle, after)
+	}
+
+	ur.drawText(modalX+2, modalY+modalHeight-3, noteStyle,
+		truncateText("An evenly lit star and a circular orbit; real stars are dimmer at the edge", ur.contentWidth()))
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "←/→ other planet • Esc back")
+}
+
+// drawTransitSideView draws the star as seen from afar with the planet at its
+// place in the pass, in star radii scaled so the whole pass fits
+func (ur *UIRenderer) drawTransitSideView(transit orbital.Transit, x, y, width int, span, now time.Duration) {
+	extent := math.Max(math.Abs(transit.Offset(span/2)), 1.2)
+	aspect := ur.renderer.GetAspectRatio()
+	scaleY := float64(transitStarRows-1) / 2
+	scaleX := scaleY * aspect
+	if maxX := float64(width-1) / 2 / extent; scaleX > maxX {
+		scaleX = maxX
+		scaleY = scaleX / aspect
+	}
+	cx, cy := x+width/2, y+transitStarRows/2
+
+	starStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	for row := 0; row < transitStarRows; row++ {
+		for col := 0; col < width; col++ {
+			dx := float64(x+col-cx) / scaleX
+			dy := float64(y+row-cy) / scaleY
+			if dx*dx+dy*dy <= 1 {
+				ur.screen.SetContent(x+col, y+row, '░', nil, starStyle)
+			}
+		}
+	}
+
+	planetStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorDarkBlue).Bold(true)
+	glyph := '●'
+	if ur.renderer.GetSymbols().ASCII {
+		glyph = 'o'
+	}
+	px := cx + int(math.Round(transit.Offset(now)*scaleX))
+	// A planet that misses the star goes by along the top or bottom edge
+	py := min(cy+int(math.Round(transit.Impact/transit.StarRadius*scaleY)), y+transitStarRows-1)
+	if px >= x && px < x+width {
+		ur.screen.SetContent(px, py, glyph, nil, planetStyle)
+	}
+}
+
+// drawLightCurve plots the star's brightness across the window up to now, with
+// labels for full brightness and the bottom of the dip
+func (ur *UIRenderer) drawLightCurve(transit orbital.Transit, x, y, width, rows int, span, now time.Duration) {
+	noteStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	curveStyle := tcell.StyleDefault.Foreground(tcell.ColorLightCyan).Background(tcell.ColorDarkBlue)
+
+	depth := transit.Depth()
+	scale := depth * 1.2
+	if scale == 0 {
+		scale = 1
+	}
+	ur.drawText(x, y, noteStyle, "100.00%")
+	if depth > 0 {
+		ur.drawText(x, y+int(float64(rows-1)*depth/scale), noteStyle, fmt.Sprintf("%6.2f%%", (1-depth)*100))
+	}
+
+	plotWidth := width - transitLabelWidth
+	grid := visualization.NewGrid(plotWidth, rows, ur.renderer.GetRenderMode())
+	subX, _ := grid.SubCells()
+	ink := ur.renderer.GetSymbols().Orbit
+	for i := 0; i < plotWidth*subX; i++ {
+		column := (float64(i) + 0.5) / float64(subX)
+		at := time.Duration(float64(span) * (column/float64(plotWidth) - 0.5))
+		if at > now {
+			break
+		}
+		dip := (1 - transit.Flux(at)) / scale
+		grid.Plot(column, 0.5+dip*float64(rows-1), ink)
+	}
+
+	for row, runes := range grid.Runes() {
+		for col, r := range runes {
+			if r != ' ' {
+				ur.screen.SetContent(x+transitLabelWidth+col, y+row, r, nil, curveStyle)
+			}
+		}
+	}
+}
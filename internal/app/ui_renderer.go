@@ -1,13 +1,23 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/furan917/go-solar-system/internal/api"
 	"github.com/furan917/go-solar-system/internal/constants"
 	"github.com/furan917/go-solar-system/internal/display"
+	"github.com/furan917/go-solar-system/internal/keymap"
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/logging"
 	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+	"github.com/furan917/go-solar-system/internal/portrait"
 	"github.com/furan917/go-solar-system/internal/systems"
+	"github.com/furan917/go-solar-system/internal/ui"
 	"github.com/furan917/go-solar-system/internal/visualization"
 	"github.com/gdamore/tcell/v2"
 )
@@ -17,103 +27,402 @@ type UIRenderer struct {
 	screen        tcell.Screen
 	renderer      *visualization.Renderer
 	systemManager *systems.SystemManager
-	state         *AppState
+	client        *api.Client
+	keys          *keymap.Keymap
+
+	// live is the state the event goroutine changes, and state the frame of it
+	// being drawn. Only the render goroutine reads state, under drawMu; code run
+	// from the event goroutine is given the live state instead.
+	live  *AppState
+	state *AppState
+
+	// compareRenderer draws the second system in comparison mode; nil until used
+	compareRenderer *visualization.Renderer
+
+	// overviewRenderer draws the whole system inset in the centred map; nil until used
+	overviewRenderer *visualization.Renderer
+
+	// Frame rate shown in the debug overlay
+	frames frameCounter
+
+	// budget slows the frame rate down while frames are slow to draw
+	budget frameBudget
+
+	// activity drops the frame rate to IdleUpdateRate after idleAfter without
+	// input, 0 for never, and wakes the display for events; idleAfter is set
+	// under drawMu
+	activity  *activity
+	idleAfter time.Duration
+
+	// Camera used for the most recent orbital view, in screen coordinates
+	camera visualization.Camera
+
+	// stripView lays the system out on a distance axis instead of its orbits; set
+	// under drawMu
+	stripView bool
+
+	// showSpheres draws the selected body's Hill sphere and Roche limit; set under
+	// drawMu
+	showSpheres bool
+
+	// drawMu keeps a reflow on the event goroutine from drawing over a frame the
+	// render goroutine is in the middle of
+	drawMu sync.Mutex
+
+	// Hooks run by the render goroutine after each frame is shown
+	hooksMu    sync.Mutex
+	frameHooks []FrameHook
+
+	// clock is the time the map and the UI are drawn at; a StepClock in
+	// deterministic mode
+	clock orbital.Clock
+
+	// clip, while set, scrolls what drawText draws; only the render goroutine
+	// sets it, under drawMu
+	clip *textClip
+
+	// images draws pictures of bodies on terminals that can; nil when off
+	images *bodyImages
+
+	// scrub is where the time scrubber under the map has been dragged to
+	scrub timelineScrub
+
+	// detailFields picks which fields detail modals show, in what order
+	detailFields display.FieldLayout
+
+	// inks is what the map was drawn with this frame, for the legend
+	inks mapInks
+
+	// background runs the goroutines started on the UI's behalf, such as loads,
+	// lookups and checks, and collects what goes wrong in them
+	background *background
 }
 
+// Frame describes what was drawn in a single DrawScreen pass
+type Frame struct {
+	Screen    tcell.Screen
+	Positions map[string]visualization.PlanetPosition
+	Camera    visualization.Camera
+	System    string
+	Selected  models.CelestialBody
+
+	// State is the frame of the app state that was drawn; hooks read it rather
+	// than the live state, which the event goroutine is changing
+	State *AppState
+}
+
+// FrameHook is called after a frame has been drawn; returning false unregisters it
+type FrameHook func(frame Frame) bool
+
 // NewUIRenderer creates a new UI renderer with necessary dependencies
 func NewUIRenderer(
 	screen tcell.Screen,
 	renderer *visualization.Renderer,
 	systemManager *systems.SystemManager,
 	state *AppState,
+	client *api.Client,
+	keys *keymap.Keymap,
 ) *UIRenderer {
 	return &UIRenderer{
 		screen:        screen,
 		renderer:      renderer,
 		systemManager: systemManager,
-		state:         state,
+		live:          state,
+		state:         state.Frame(),
+		client:        client,
+		keys:          keys,
+		clock:         orbital.SystemClock{},
+		activity:      newActivity(time.Now()),
+		background:    newBackground(context.Background(), logging.Discard()),
 	}
 }
 
 // DrawScreen renders the complete UI
 func (ur *UIRenderer) DrawScreen() {
+	ur.drawMu.Lock()
+	defer ur.drawMu.Unlock()
+
+	ur.state = ur.live.Frame()
+	ur.state.startLayout()
+	ur.inks.reset()
+
+	started := time.Now()
 	ur.screen.Clear()
+	if ur.state.Loading != "" && len(ur.state.GetPlanets()) == 0 {
+		width, height := ur.screen.Size()
+		ur.drawLoadingScreen(width, height)
+		ur.screen.Show()
+		return
+	}
+	if ur.images != nil {
+		ur.images.startFrame()
+	}
 
 	width, height := ur.screen.Size()
+	regions := layout.Compute(width, height)
 
-	ur.drawText(2, 1, tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true), "🌌 Solar System Explorer")
+	header := regions.Header
+	title := "🌌 Solar System Explorer"
+	ur.drawText(header.X, header.Y, tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true), title)
+	x := header.X + ui.TextWidth(title) + 3
+	if sortLabel := ur.sortLabel(); ui.TextWidth(title)+3+ui.TextWidth(sortLabel) <= header.Width {
+		ur.drawText(x, header.Y, tcell.StyleDefault.Foreground(tcell.ColorGray), sortLabel)
+		x += ui.TextWidth(sortLabel) + 3
+	}
+	if filterLabel := ur.filterLabel(); filterLabel != "" && x < header.X+header.Width {
+		ur.drawText(x, header.Y, tcell.StyleDefault.Foreground(tcell.ColorYellow), truncateText(filterLabel, header.X+header.Width-x))
+	}
+
+	if regions.List.Empty() {
+		ur.state.ClearPlanetListPositions()
+		ur.state.ClearTabPositions()
+	} else {
+		ur.drawListTabs(regions.Tabs)
+		if ur.state.ListTab == TabPlanets {
+			ur.drawPlanetList(regions.List)
+		} else {
+			ur.drawTabBodyList(regions.List)
+		}
+	}
 
-	modalWidth := constants.ModalWidth
-	availableWidth := width - modalWidth - (constants.ModalMargin * 3)
-	ur.drawPlanetList(2, 3, availableWidth)
+	ur.glideTimeline()
+	ur.renderer.SetSpheresBody(ur.spheresBody())
+	stage, evolving := ur.evolutionStage()
+	if ur.state.Comparing {
+		ur.drawComparison(regions.Map)
+	} else if ur.state.Focusing {
+		ur.drawFocusFrame(regions.Map)
+	} else if ur.stripView {
+		ur.drawStrip(regions.Map)
+	} else {
+		ur.drawSolarSystem(regions.Map.X, regions.Map.Y, regions.Map.Width, regions.Map.Height)
+		ur.drawEarthMarker(ur.clock.Now())
+		ur.drawHereWidget(regions.Map)
+		ur.drawWobbleWidget(regions.Map)
+		if evolving {
+			ur.drawEvolutionWidget(regions.Map, stage)
+		}
+	}
+	ur.drawMapFocusRing(regions.Map)
+	ur.drawTimeline(regions.Timeline)
 
-	ur.drawSolarSystem(2, 6, width-4, height-8)
+	ur.drawInstructionBar(regions.Status)
 
-	instructions := "Arrow keys to navigate • Enter/Click to select • S for systems • Q to quit • 1-9 for direct selection"
-	systemDisplayName := ur.systemManager.GetCurrentSystemDisplayName()
+	// Only the top modal is drawn; the ones beneath it wait for it to close
+	if spec := modalSpecFor(ur.state.TopModal()); spec.draw != nil {
+		spec.draw(ur, width, height)
+	}
 
-	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue)
-	systemStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	ur.drawToasts(height)
+	ur.drawTooltip(width, height)
 
-	ur.drawText(2, height-2, instructionStyle, instructions)
-	ur.drawText(2+len(instructions)+3, height-2, systemStyle, fmt.Sprintf("• Current System: %s", systemDisplayName))
+	ur.frames.tick(time.Now())
+	if ur.state.IsShowingDebugOverlay() {
+		ur.drawDebugOverlay(width)
+	}
 
-	// Draw modals based on current state
-	if ur.state.IsShowingMoonDetails() {
-		ur.drawMoonDetailsModal(width, height)
-	} else if ur.state.IsShowingMoons() {
-		ur.drawMoonListModal(width, height)
-	} else if ur.state.IsShowingSystemList() {
-		ur.drawSystemListModal(width, height)
-	} else if ur.state.IsShowingDetails() {
-		ur.drawPlanetDetailsModal(width, height)
+	if ur.state.Loading != "" {
+		ur.drawText(2, height-1, tcell.StyleDefault.Foreground(tcell.ColorGreen), loadingSpinner(ur.clock.Now())+" "+ur.state.Loading)
+	} else if status := ur.state.GetStatusMessage(); status != "" {
+		ur.drawText(2, height-1, tcell.StyleDefault.Foreground(tcell.ColorGreen), status)
 	}
 
 	ur.screen.Show()
+	if ur.images != nil {
+		ur.images.flush(ur.screen)
+	}
+	ur.budget.observe(time.Since(started))
+	ur.live.setLayout(ur.state.drawnLayout())
+
+	ur.runFrameHooks()
+	ur.advanceClock()
+}
+
+// FrameInterval returns how long to wait between frames, which grows while frames
+// are slow to draw or the app is idle
+func (ur *UIRenderer) FrameInterval() time.Duration {
+	ur.drawMu.Lock()
+	defer ur.drawMu.Unlock()
+	return ur.frameInterval()
+}
+
+// frameInterval is FrameInterval, called under drawMu
+func (ur *UIRenderer) frameInterval() time.Duration {
+	switch {
+	case ur.deterministic():
+		return constants.DisplayUpdateRate
+	case ur.idle():
+		return max(ur.budget.current(), constants.IdleUpdateRate)
+	}
+	return ur.budget.current()
+}
+
+// AddFrameHook registers a hook to run after the next drawn frame. Hooks run on the
+// render goroutine, so they see a complete, stable screen.
+func (ur *UIRenderer) AddFrameHook(hook FrameHook) {
+	ur.hooksMu.Lock()
+	defer ur.hooksMu.Unlock()
+	ur.frameHooks = append(ur.frameHooks, hook)
+}
+
+// runFrameHooks calls the registered hooks, keeping those that ask to stay
+func (ur *UIRenderer) runFrameHooks() {
+	ur.hooksMu.Lock()
+	hooks := ur.frameHooks
+	ur.frameHooks = nil
+	ur.hooksMu.Unlock()
+
+	if len(hooks) == 0 {
+		return
+	}
+
+	frame := Frame{
+		Screen:    ur.screen,
+		Positions: ur.state.GetPlanetPositions(),
+		Camera:    ur.camera,
+		System:    ur.systemManager.GetCurrentSystemDisplayName(),
+		Selected:  ur.state.SelectedPlanet,
+		State:     ur.state,
+	}
+
+	var keep []FrameHook
+	for _, hook := range hooks {
+		if hook(frame) {
+			keep = append(keep, hook)
+		}
+	}
+
+	if len(keep) > 0 {
+		ur.hooksMu.Lock()
+		ur.frameHooks = append(keep, ur.frameHooks...)
+		ur.hooksMu.Unlock()
+	}
 }
 
 // drawText renders text at the specified position with given style
 func (ur *UIRenderer) drawText(x, y int, style tcell.Style, text string) {
-	for i, r := range text {
-		ur.screen.SetContent(x+i, y, r, nil, style)
+	if ur.clip != nil {
+		y -= ur.clip.offset
+		if y < ur.clip.top || y >= ur.clip.bottom {
+			return
+		}
 	}
+	ui.DrawText(ur.screen, x, y, style, text)
 }
 
-// drawPlanetList renders the horizontal list of planets
-func (ur *UIRenderer) drawPlanetList(x, y, maxWidth int) {
-	currentX := x
-	currentY := y
+// textColumn returns how many cells into text sub starts when drawn, or -1 when
+// text does not hold it
+func textColumn(text, sub string) int {
+	i := strings.Index(text, sub)
+	if i < 0 {
+		return -1
+	}
+	return ui.TextWidth(text[:i])
+}
+
+// drawInstructionBar draws the key hints, followed by the current system if it fits
+func (ur *UIRenderer) drawInstructionBar(area layout.Rect) {
+	instructions := ur.MainInstructions()
+	system := fmt.Sprintf("• Current System: %s", ur.systemManager.GetCurrentSystemDisplayName())
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue)
+	systemStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+
+	ur.drawText(area.X, area.Y, instructionStyle, instructions)
+	if ui.TextWidth(instructions)+3+ui.TextWidth(system) <= area.Width {
+		ur.drawText(area.X+ui.TextWidth(instructions)+3, area.Y, systemStyle, system)
+	}
+}
 
+// drawPlanetList renders the horizontal list of planets, wrapping within area, a
+// page of rows at a time
+func (ur *UIRenderer) drawPlanetList(area layout.Rect) {
 	ur.state.ClearPlanetListPositions()
 
-	for i, planet := range ur.state.GetPlanets() {
+	// Lay every name and group label out in rows first, then show the page holding
+	// the selection
+	groupStyle := tcell.StyleDefault.Foreground(tcell.ColorGray)
+	currentGroup := BodyGroup(-1)
+
+	planets := ur.state.GetPlanets()
+	cells := make([]listCell, 0, len(planets))
+	x, row, selectedRow := area.X, 0, 0
+	place := func(text string) (int, int) {
+		if x+ui.TextWidth(text) > area.X+area.Width && x > area.X {
+			row++
+			x = area.X
+		}
+		cellX := x
+		x += ui.TextWidth(text)
+		return cellX, row
+	}
+
+	for i, planet := range planets {
+		if !ur.state.Listed(planet) {
+			continue
+		}
+		if ur.state.GroupByType {
+			if group := bodyGroup(planet); group != currentGroup {
+				currentGroup = group
+				label := group.String() + ":"
+				labelX, labelRow := place(label + " ")
+				cells = append(cells, listCell{index: -1, x: labelX, row: labelRow, text: label, style: groupStyle})
+			}
+		}
+
 		symbol := ur.renderer.GetPlanetSymbol(planet.EnglishName)
-		name := planet.EnglishName
+		text := truncateText(listEntryText(fmt.Sprintf("%c %s", symbol, planet.EnglishName), i == ur.state.SelectedIndex), area.Width)
 
 		style := tcell.StyleDefault.Foreground(tcell.ColorWhite)
 		if i == ur.state.SelectedIndex {
 			style = tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true).Reverse(true)
 		}
 
-		planetText := fmt.Sprintf(" %c %s ", symbol, name)
-		textWidth := len(planetText)
-
-		if currentX+textWidth > x+maxWidth {
-			currentY++
-			currentX = x
+		cellX, cellRow := place(text)
+		cells = append(cells, listCell{index: i, x: cellX, row: cellRow, text: text, style: style})
+		if i == ur.state.SelectedIndex {
+			selectedRow = cellRow
 		}
+	}
 
-		ur.drawText(currentX, currentY, style, planetText)
+	if len(cells) == 0 && ur.state.ListFilter != nil {
+		ur.drawText(area.X, area.Y, groupStyle, truncateText("No bodies match the filter", area.Width))
+		return
+	}
 
-		ur.state.AddPlanetListPosition(PlanetListPosition{
-			Index: i,
-			X:     currentX,
-			Y:     currentY,
-			Width: textWidth,
-		})
+	ur.drawListPage(area, cells, selectedRow)
+}
 
-		currentX += textWidth
+// listEntryText pads a list entry to its cell. The selected entry is bracketed
+// rather than padded, so it stands out without color or reverse video.
+func listEntryText(name string, selected bool) string {
+	if selected {
+		return "[" + name + "]"
 	}
+	return " " + name + " "
+}
+
+// mainInstructionParts returns the clickable segments of the instruction bar
+func (ur *UIRenderer) mainInstructionParts() (systems, help, quit string) {
+	systems = ur.keys.Primary(keymap.ActionSystems) + " for systems"
+	help = ur.keys.Primary(keymap.ActionHelp) + " for help"
+	quit = ur.keys.Primary(keymap.ActionQuit) + " to quit"
+	return systems, help, quit
+}
+
+// MainInstructions returns the instruction bar text for the current keymap
+func (ur *UIRenderer) MainInstructions() string {
+	systems, help, quit := ur.mainInstructionParts()
+	return fmt.Sprintf("Arrow keys to navigate • Enter/Click to select • %s • %s • %s • 1-9 for direct selection", systems, help, quit)
+}
+
+// sortLabel describes the planet list order
+func (ur *UIRenderer) sortLabel() string {
+	label := "sorted by " + ur.state.SortMode.String()
+	if ur.state.GroupByType {
+		label += ", grouped by type"
+	}
+	return label + " (o/O)"
 }
 
 // drawSolarSystem renders the orbital visualization
@@ -122,46 +431,44 @@ func (ur *UIRenderer) drawSolarSystem(x, y, width, height int) {
 	grid, planetPositions := ur.renderer.RenderSolarSystemDataWithPositions(ur.state.GetPlanets(), width, height, screenWidth, screenHeight)
 	ur.state.UpdatePlanetPositions(x, y, planetPositions)
 
-	for row := 0; row < len(grid) && row < height; row++ {
-		for col := 0; col < len(grid[row]) && col < width; col++ {
-			if grid[row][col] != ' ' {
-				style := ur.getPlanetStyle(grid[row][col])
-				ur.screen.SetContent(x+col, y+row, grid[row][col], nil, style)
+	ur.camera = ur.renderer.GetCamera(width, height)
+	ur.camera.OriginX += x
+	ur.camera.OriginY += y
+
+	ur.drawGrid(ur.renderer, grid, x, y, width, height)
+}
+
+// drawGrid copies a rendered grid to the screen with its top-left at x, y,
+// coloured by the renderer that drew it, and labels any resonance links and marks
+// any transfer and barycenter
+func (ur *UIRenderer) drawGrid(renderer *visualization.Renderer, grid *visualization.Grid, x, y, width, height int) {
+	legend := ur.state.TopModal() == ModalLegend
+	for row := 0; row < grid.Height() && row < height; row++ {
+		for col := 0; col < grid.Width() && col < width; col++ {
+			if glyph, ink := grid.At(col, row); glyph != ' ' {
+				ur.screen.SetContent(x+col, y+row, glyph, nil, ur.inkStyle(renderer, ink))
+				if legend {
+					ur.inks.add(renderer, ink)
+				}
 			}
 		}
 	}
+	ur.drawResonanceLabels(renderer, x, y, width, height)
+	ur.drawTransferMarks(renderer, x, y, width, height)
+	ur.drawBarycenter(renderer, x, y, width, height)
 }
 
-// getPlanetStyle returns the appropriate style for a planet symbol
-func (ur *UIRenderer) getPlanetStyle(symbol rune) tcell.Style {
-	switch symbol {
-	case '☉': // Sun
-		return tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
-	case '☿': // Mercury
-		return tcell.StyleDefault.Foreground(tcell.ColorGray)
-	case '♀': // Venus
-		return tcell.StyleDefault.Foreground(tcell.ColorOrange)
-	case '♁': // Earth
-		return tcell.StyleDefault.Foreground(tcell.ColorBlue)
-	case '♂': // Mars
-		return tcell.StyleDefault.Foreground(tcell.ColorRed)
-	case '♃': // Jupiter
-		return tcell.StyleDefault.Foreground(tcell.ColorBrown)
-	case '♄': // Saturn
-		return tcell.StyleDefault.Foreground(tcell.ColorYellow)
-	case '♅': // Uranus
-		return tcell.StyleDefault.Foreground(tcell.ColorAqua)
-	case '♆': // Neptune
-		return tcell.StyleDefault.Foreground(tcell.ColorBlue)
-	case '♇': // Pluto
-		return tcell.StyleDefault.Foreground(tcell.ColorGray)
-	case '.': // Asteroids/debris
-		return tcell.StyleDefault.Foreground(tcell.ColorDarkGray)
-	case '·': // Kuiper belt
-		return tcell.StyleDefault.Foreground(tcell.ColorDarkGray)
-	default:
-		return tcell.StyleDefault.Foreground(tcell.ColorWhite)
+// inkStyle is the style for cells inked with symbol, preferring a color the
+// system file gave the body over the palette's
+func (ur *UIRenderer) inkStyle(renderer *visualization.Renderer, symbol rune) tcell.Style {
+	if c, ok := renderer.GetBodyColor(symbol); ok {
+		return tcell.StyleDefault.Foreground(c)
+	}
+	style := tcell.StyleDefault.Foreground(renderer.InkColor(symbol))
+	if symbol == renderer.GetSymbols().Sun {
+		style = style.Bold(true)
 	}
+	return style
 }
 
 // Modal rendering methods moved from app.go
@@ -169,7 +476,7 @@ func (ur *UIRenderer) drawPlanetDetailsModal(width, height int) {
 	planet := ur.state.SelectedPlanet
 	contentLines := ur.calculatePlanetDetailsLines(planet)
 	dynamicHeight := minimum(contentLines+6, height-4) // 6 for borders, title, instructions
-	modalX, modalY, _, modalHeight := ur.setupModal(width, height, dynamicHeight)
+	modalX, modalY, modalWidth, modalHeight := ur.setupModal(width, height, dynamicHeight)
 
 	symbol := ur.renderer.GetPlanetSymbol(planet.EnglishName)
 	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
@@ -179,7 +486,27 @@ func (ur *UIRenderer) drawPlanetDetailsModal(width, height int) {
 	detailStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
 	currentY := modalY + 3
 
-	currentY = ur.drawCelestialBodyDetails(planet, modalX+2, currentY, detailStyle)
+	textWidth := ur.contentWidth()
+	if ur.portraitFits() {
+		x, y := modalX+modalWidth-portrait.Width-2, modalY+2
+		if ur.images == nil || !ur.images.place(planet, x, y, portrait.Width, portrait.Height) {
+			picture := portrait.For(planet)
+			ur.drawPortrait(x, y, picture)
+			ur.drawRotationMarker(x, y, planet, picture)
+		}
+		textWidth = ur.portraitTextWidth()
+	}
+	ur.startScroll(ModalDetails, modalX+modalWidth-2, modalY+3, modalY+modalHeight-3)
+	currentY = ur.drawCelestialBodyDetails(planet, modalX+2, currentY, textWidth, detailStyle)
+	if season := ur.seasonDetail(planet); season != "" {
+		currentY = ur.drawWrappedTextAt(modalX+2, currentY, detailStyle, season, textWidth)
+	}
+	if speed := ur.speedDetail(planet); speed != "" {
+		currentY = ur.drawWrappedTextAt(modalX+2, currentY, detailStyle, speed, textWidth)
+	}
+	if light := ur.lightTimeDetail(planet); light != "" {
+		currentY = ur.drawWrappedTextAt(modalX+2, currentY, detailStyle, light, textWidth)
+	}
 
 	if len(planet.Moons) > 0 {
 		moonHandler := ur.renderer.GetMoonHandler()
@@ -195,11 +522,26 @@ func (ur *UIRenderer) drawPlanetDetailsModal(width, height int) {
 			}
 		}
 	}
+	ur.drawSourceNotes(planet, modalX+2, currentY, textWidth)
+	ur.endScroll()
 
 	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
 	instruction := "Press Enter, Escape, or 'b' to close"
 	if len(planet.Moons) > 0 {
-		instruction += " • 'm' for moons"
+		instruction += fmt.Sprintf(" • '%s' for moons", strings.ToLower(ur.keys.Primary(keymap.ActionMoons)))
+	}
+	if canEditOrbitalElements(planet, ur.systemManager.GetCurrentSystem()) {
+		instruction += fmt.Sprintf(" • '%s' orbit", strings.ToLower(ur.keys.Primary(keymap.ActionEditElements)))
+	}
+	if ur.canShowTransit(ur.state, planet) {
+		instruction += fmt.Sprintf(" • '%s' transit", strings.ToLower(ur.keys.Primary(keymap.ActionTransit)))
+	}
+	if ur.systemManager.GetCurrentSystem() == "solar-system" && planet.ID != "" {
+		verb := "watch"
+		if ur.state.IsWatched(planet.ID) {
+			verb = "unwatch"
+		}
+		instruction += fmt.Sprintf(" • '%s' %s", strings.ToLower(ur.keys.Primary(keymap.ActionWatch)), verb)
 	}
 	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, instruction)
 }
@@ -268,15 +610,24 @@ func (ur *UIRenderer) drawMoonListModal(width, height int) {
 		minimum(ur.state.MoonScrollIndex+visibleItems, len(moonNames)),
 		len(moonNames))
 	ur.drawText(modalX+2, modalY+modalHeight-3, statusStyle, statusText)
+	if search := ur.state.MoonSearch; search != "" {
+		searchText := "Find: " + search
+		if _, ok := findMoon(ur.moonSearchNames(ur.state.SelectedPlanet), search); !ok {
+			searchText += " (no match)"
+		}
+		searchStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue).Bold(true)
+		ur.drawText(modalX+4+ui.TextWidth(statusText), modalY+modalHeight-3, searchStyle, truncateText(searchText, modalWidth-8-ui.TextWidth(statusText)))
+	}
 
 	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
-	ur.drawWrappedTextAt(modalX+2, modalY+modalHeight-2, instructionStyle, "↑/↓ to navigate • Enter to select • Escape/'b' to go back", constants.ModalContentWidth)
+	instruction := "↑/↓ PgUp/PgDn Home/End • type a name to find it • Enter to select • Escape to go back"
+	ur.drawWrappedTextAt(modalX+2, modalY+modalHeight-2, instructionStyle, truncateText(instruction, ur.contentWidth()), ur.contentWidth())
 }
 
 func (ur *UIRenderer) drawMoonDetailsModal(width, height int) {
 	contentLines := ur.calculateMoonDetailsLines(ur.state.SelectedMoon)
 	dynamicHeight := minimum(contentLines+6, height-4) // 6 for borders, title, instructions
-	modalX, modalY, _, modalHeight := ur.setupModal(width, height, dynamicHeight)
+	modalX, modalY, modalWidth, modalHeight := ur.setupModal(width, height, dynamicHeight)
 
 	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
 	title := fmt.Sprintf(" %s (Moon of %s) ", ur.state.SelectedMoon.EnglishName, ur.state.SelectedPlanet.EnglishName)
@@ -285,28 +636,36 @@ func (ur *UIRenderer) drawMoonDetailsModal(width, height int) {
 	detailStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
 	currentY := modalY + 2
 	currentY++
+	ur.startScroll(ModalMoonDetails, modalX+modalWidth-2, modalY+3, modalY+modalHeight-3)
 
 	if ur.state.SelectedMoon.BodyType != "" {
-		currentY = ur.drawWrappedTextAt(modalX+2, currentY, detailStyle, fmt.Sprintf("Type: %s", ur.state.SelectedMoon.BodyType), constants.ModalContentWidth)
+		currentY = ur.drawWrappedTextAt(modalX+2, currentY, detailStyle, fmt.Sprintf("Type: %s", ur.state.SelectedMoon.BodyType), ur.contentWidth())
 		currentY++
 	}
 
-	currentY = ur.drawWrappedTextAt(modalX+2, currentY, detailStyle, fmt.Sprintf("Orbits: %s", ur.state.SelectedPlanet.EnglishName), constants.ModalContentWidth)
+	currentY = ur.drawWrappedTextAt(modalX+2, currentY, detailStyle, fmt.Sprintf("Orbits: %s", ur.state.SelectedPlanet.EnglishName), ur.contentWidth())
 	currentY++
 
-	if ur.state.SelectedMoon.Name != "" && ur.state.SelectedMoon.Name != ur.state.SelectedMoon.EnglishName {
-		currentY = ur.drawWrappedTextAt(modalX+2, currentY, detailStyle, fmt.Sprintf("Original Name: %s", ur.state.SelectedMoon.Name), constants.ModalContentWidth)
+	currentY = ur.drawCelestialBodyDetails(ur.state.SelectedMoon, modalX+2, currentY, ur.contentWidth(), detailStyle)
+
+	if facts := ur.moonFacts(ur.state.SelectedMoon); len(facts) > 0 {
+		factStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue)
 		currentY++
+		ur.drawText(modalX+2, currentY, factStyle.Bold(true), "Did you know?")
+		currentY++
+		for _, fact := range facts {
+			currentY = ur.drawWrappedTextAt(modalX+2, currentY, factStyle, "• "+fact, ur.contentWidth())
+		}
 	}
-
-	ur.drawCelestialBodyDetails(ur.state.SelectedMoon, modalX+2, currentY, detailStyle)
+	ur.drawSourceNotes(ur.state.SelectedMoon, modalX+2, currentY, ur.contentWidth())
+	ur.endScroll()
 
 	if ur.isAPIMoon(ur.state.SelectedMoon) {
-		ur.drawWrappedTextAt(modalX+2, modalY+modalHeight-3, tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue), "Note: Limited moon data available from API", constants.ModalContentWidth)
+		ur.drawWrappedTextAt(modalX+2, modalY+modalHeight-3, tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue), "Note: Limited moon data available from API", ur.contentWidth())
 	}
 
 	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
-	ur.drawWrappedTextAt(modalX+2, modalY+modalHeight-2, instructionStyle, "Press Enter, Escape, or 'b' to go back to moon list", constants.ModalContentWidth)
+	ur.drawWrappedTextAt(modalX+2, modalY+modalHeight-2, instructionStyle, "Press Enter, Escape, or 'b' to go back to moon list", ur.contentWidth())
 }
 
 func (ur *UIRenderer) drawSystemListModal(width, height int) {
@@ -314,6 +673,9 @@ func (ur *UIRenderer) drawSystemListModal(width, height int) {
 
 	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
 	title := " 🌌 Star System Selection "
+	if ur.state.PickingComparison {
+		title = " 🌌 Compare With Which System? "
+	}
 	ur.drawText(modalX+2, modalY+1, titleStyle, title)
 
 	systemInfo, err := ur.systemManager.ListSystemsWithInfo()
@@ -341,7 +703,7 @@ func (ur *UIRenderer) drawSystemListModal(width, height int) {
 			style = tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true).Reverse(true)
 		}
 
-		maxLineLength := constants.ModalContentWidth
+		maxLineLength := ur.contentWidth()
 		wrappedLines := ur.wrapText(systemLine, maxLineLength)
 
 		if len(wrappedLines) > 0 {
@@ -349,13 +711,33 @@ func (ur *UIRenderer) drawSystemListModal(width, height int) {
 		}
 	}
 
+	if line := systemUpdatesLine(ur.state); line != "" {
+		updateStyle := tcell.StyleDefault.Foreground(tcell.ColorLightGreen).Background(tcell.ColorDarkBlue)
+		ur.drawText(modalX+2, startY+visibleItems+1, updateStyle, truncateText(line, ur.contentWidth()))
+	}
+
 	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
-	ur.drawWrappedTextAt(modalX+2, modalY+modalHeight-2, instructionStyle, "↑/↓ to navigate • Enter to select • Escape/'b' to cancel", constants.ModalContentWidth)
+	instructions := "↑/↓ to navigate • Enter to select • 'e' edit details • Escape/'b' to cancel"
+	if len(ur.state.SystemUpdates) > 0 {
+		instructions = "↑/↓ to navigate • Enter to select • 'e' edit details • 'u' download updates • Escape/'b' to cancel"
+	}
+	ur.drawWrappedTextAt(modalX+2, modalY+modalHeight-2, instructionStyle, instructions, ur.contentWidth())
 }
 
-// UpdateDimensions handles screen resize events
-func (ur *UIRenderer) UpdateDimensions(width, height int) {
+// Reflow lays the screen out again for a new size and redraws it straight away.
+// Until then the planet list and map positions that clicks are matched against
+// would still describe the old layout.
+func (ur *UIRenderer) Reflow(width, height int) {
+	ur.drawMu.Lock()
 	ur.renderer.UpdateDimensions(width, height)
+	ur.live.ClearLayoutCaches()
+	ur.live.Publish()
+	if ur.images != nil {
+		ur.images.forget()
+	}
+	ur.drawMu.Unlock()
+
+	ur.DrawScreen()
 }
 
 // GetRenderer returns the visualization renderer
@@ -372,14 +754,7 @@ func (ur *UIRenderer) GetSystemManager() *systems.SystemManager {
 
 // setupModal handles all common modal configuration and drawing setup
 func (ur *UIRenderer) setupModal(screenWidth, screenHeight int, dynamicHeight ...int) (modalX, modalY, modalWidth, modalHeight int) {
-	modalWidth = constants.ModalWidth
-	if len(dynamicHeight) > 0 {
-		modalHeight = dynamicHeight[0]
-	} else {
-		modalHeight = constants.ModalHeight
-	}
-	modalX = screenWidth - modalWidth - constants.ModalMargin
-	modalY = 1
+	modalX, modalY, modalWidth, modalHeight = ur.GetModalDimensions(screenWidth, screenHeight, dynamicHeight...)
 
 	for y := modalY; y < modalY+modalHeight; y++ {
 		for x := modalX; x < modalX+modalWidth; x++ {
@@ -414,7 +789,7 @@ func (ur *UIRenderer) drawModalBorder(x, y, width, height int) {
 }
 
 func (ur *UIRenderer) wrapText(text string, maxWidth int) []string {
-	if len(text) <= maxWidth {
+	if ui.TextWidth(text) <= maxWidth {
 		return []string{text}
 	}
 
@@ -423,7 +798,7 @@ func (ur *UIRenderer) wrapText(text string, maxWidth int) []string {
 	currentLine := ""
 
 	for _, word := range words {
-		if len(currentLine)+1+len(word) > maxWidth {
+		if ui.TextWidth(currentLine)+1+ui.TextWidth(word) > maxWidth {
 			if currentLine != "" {
 				lines = append(lines, currentLine)
 				currentLine = word
@@ -458,20 +833,19 @@ func (ur *UIRenderer) drawWrappedTextAt(x, y int, style tcell.Style, text string
 
 // calculatePlanetDetailsLines calculates how many lines are needed for planet details
 func (ur *UIRenderer) calculatePlanetDetailsLines(planet models.CelestialBody) int {
-	lines := 0
+	lines := len(ur.detailLines(ur.state, planet))
 
-	fields := display.GetCelestialBodyFields()
-	for _, field := range fields {
-		if field.Condition(planet) {
-			lines++
-		}
+	textWidth := ur.contentWidth()
+	if ur.portraitFits() {
+		textWidth = ur.portraitTextWidth()
 	}
+	lines += ur.seasonDetailLines(planet, textWidth)
+	lines += ur.speedDetailLines(planet, textWidth)
+	lines += ur.lightTimeDetailLines(planet, textWidth)
 
-	stringFields := display.GetCelestialBodyStringFields()
-	for _, field := range stringFields {
-		if field.Condition(planet) {
-			lines++
-		}
+	// Leave room for the portrait beside short detail lists
+	if ur.portraitFits() && lines < portrait.Height {
+		lines = portrait.Height
 	}
 
 	// Count moon lines
@@ -481,111 +855,150 @@ func (ur *UIRenderer) calculatePlanetDetailsLines(planet models.CelestialBody) i
 		lines += len(moonLines) + 1 // +1 for spacing
 	}
 
+	lines += ur.sourceNotesLines(planet, textWidth)
+
 	return lines
 }
 
 // calculateMoonDetailsLines calculates how many lines are needed for moon details
 func (ur *UIRenderer) calculateMoonDetailsLines(moon models.CelestialBody) int {
-	lines := 1 // Type line (base)
-
-	fields := []func(models.CelestialBody) bool{
-		func(cb models.CelestialBody) bool { return cb.MeanRadius > 0 },
-		func(cb models.CelestialBody) bool { return cb.GetMassKg() > 0 },
-		func(cb models.CelestialBody) bool { return cb.Density > 0 },
-		func(cb models.CelestialBody) bool { return cb.Gravity > 0 },
-		func(cb models.CelestialBody) bool { return cb.SemimajorAxis > 0 },
-		func(cb models.CelestialBody) bool { return cb.SideralOrbit > 0 },
-		func(cb models.CelestialBody) bool { return cb.SideralRotation != 0 },
-		func(cb models.CelestialBody) bool { return cb.Escape > 0 },
-		func(cb models.CelestialBody) bool { return cb.EquaRadius > 0 },
-		func(cb models.CelestialBody) bool { return cb.PolarRadius > 0 },
-		func(cb models.CelestialBody) bool { return cb.Flattening > 0 },
-		func(cb models.CelestialBody) bool { return cb.Eccentricity > 0 },
-		func(cb models.CelestialBody) bool { return cb.Inclination != 0 },
-		func(cb models.CelestialBody) bool { return cb.GetVolumeKm3() > 0 },
-		func(cb models.CelestialBody) bool { return cb.Perihelion > 0 },
-		func(cb models.CelestialBody) bool { return cb.Aphelion > 0 },
-		func(cb models.CelestialBody) bool { return cb.Dimension != "" },
-		func(cb models.CelestialBody) bool { return cb.DiscoveredBy != "" },
-		func(cb models.CelestialBody) bool { return cb.DiscoveryDate != "" },
-		func(cb models.CelestialBody) bool { return cb.AlternativeName != "" },
-	}
-
-	for _, fieldCheck := range fields {
-		if fieldCheck(moon) {
-			lines++
-		}
+	lines := 1 // Orbits line
+	if moon.BodyType != "" {
+		lines++
 	}
+	lines += len(ur.detailLines(ur.state, moon))
 
 	if moon.ID != "" {
 		lines++
 	}
-	if moon.Name != "" && moon.Name != moon.EnglishName {
-		lines++
-	}
 
 	lines += 2 // Note about limited data + spacing
 
+	if facts := ur.moonFacts(moon); len(facts) > 0 {
+		lines += 2 // Spacing + heading
+		for _, fact := range facts {
+			lines += len(ur.wrapText("• "+fact, ur.contentWidth()))
+		}
+	}
+	lines += ur.sourceNotesLines(moon, ur.contentWidth())
+
 	return lines
 }
 
+// moonFacts returns the knowledge base's fun facts for a moon, if it has any
+func (ur *UIRenderer) moonFacts(moon models.CelestialBody) []string {
+	facts, ok := ur.renderer.GetMoonHandler().GetMoonFacts(moon.EnglishName)
+	if !ok {
+		return nil
+	}
+	return facts.Facts
+}
+
+// detailLines returns the details of a body in the configured layout, in the
+// units state asks for
+func (ur *UIRenderer) detailLines(state *AppState, body models.CelestialBody) []display.DetailLine {
+	layout := ur.detailFields
+	if state.DetailUnitsFlipped {
+		layout = layout.WithUnits(layout.Units().Other())
+	}
+	return layout.Lines(body)
+}
+
 // drawCelestialBodyDetails draws celestial body details using a data-driven approach
-func (ur *UIRenderer) drawCelestialBodyDetails(body models.CelestialBody, x, y int, style tcell.Style) int {
+func (ur *UIRenderer) drawCelestialBodyDetails(body models.CelestialBody, x, y, maxWidth int, style tcell.Style) int {
 	currentY := y
+	tagged := display.HasMixedSources(body)
 
-	stringFields := display.GetCelestialBodyStringFields()
-	for _, field := range stringFields {
-		if field.Condition(body) {
-			detail := field.FormatStringFieldValue(body)
-			currentY = ur.drawWrappedTextAt(x, currentY, style, detail, constants.ModalContentWidth)
+	for _, line := range ur.detailLines(ur.state, body) {
+		detail := line.Text
+		if tagged {
+			detail += " " + body.SourceOf(line.Field).Tag()
 		}
+		currentY = ur.drawWrappedTextAt(x, currentY, style, detail, maxWidth)
 	}
 
-	fields := display.GetCelestialBodyFields()
-	for _, field := range fields {
-		if field.Condition(body) {
-			detail := field.FormatFieldValue(body)
-			currentY = ur.drawWrappedTextAt(x, currentY, style, detail, constants.ModalContentWidth)
-		}
+	return currentY
+}
+
+// drawSourceNotes draws the footer saying where a body's values came from, then
+// links to pages about it
+func (ur *UIRenderer) drawSourceNotes(body models.CelestialBody, x, y, maxWidth int) int {
+	notes := display.SourceNotes(body)
+	links := display.Links(body)
+	if len(notes) == 0 && len(links) == 0 {
+		return y
 	}
 
+	noteStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	currentY := y + 1
+	for _, note := range notes {
+		currentY = ur.drawWrappedTextAt(x, currentY, noteStyle, note, maxWidth)
+	}
+	if len(links) > 0 {
+		ur.drawLinks(x, currentY, links, maxWidth)
+		currentY++
+	}
 	return currentY
 }
 
-func (ur *UIRenderer) GetModalDimensions(screenWidth, screenHeight int, dynamicHeight ...int) (modalX, modalY, modalWidth, modalHeight int) {
-	modalWidth = constants.ModalWidth
-	if len(dynamicHeight) > 0 {
-		modalHeight = dynamicHeight[0]
-	} else {
-		modalHeight = constants.ModalHeight
+// sourceNotesLines is how many lines drawSourceNotes takes, including the gap above
+func (ur *UIRenderer) sourceNotesLines(body models.CelestialBody, maxWidth int) int {
+	notes := display.SourceNotes(body)
+	links := display.Links(body)
+	if len(notes) == 0 && len(links) == 0 {
+		return 0
+	}
+
+	lines := 1
+	for _, note := range notes {
+		lines += len(ur.wrapText(note, maxWidth))
 	}
-	modalX = screenWidth - modalWidth - constants.ModalMargin
-	modalY = 1
-	return
+	if len(links) > 0 {
+		lines++
+	}
+	return lines
 }
 
-func (ur *UIRenderer) IsClickInModalArea(mouseX, mouseY int) bool {
-	if !ur.state.ShowingDetails && !ur.state.ShowingMoons && !ur.state.ShowingMoonDetails && !ur.state.ShowingSystemList {
-		return false
+// drawLinks draws links on one line as OSC 8 hyperlinks, which terminals without
+// them show as plain underlined text. Links that do not fit are left off.
+func (ur *UIRenderer) drawLinks(x, y int, links []display.Link, maxWidth int) {
+	labelStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	linkStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue).Underline(true)
+
+	label := "More: "
+	if ui.TextWidth(label) > maxWidth {
+		return
 	}
+	ur.drawText(x, y, labelStyle, label)
+	currentX := x + ui.TextWidth(label)
+	for i, link := range links {
+		separator := ""
+		if i > 0 {
+			separator = " • "
+		}
+		if currentX+ui.TextWidth(separator)+ui.TextWidth(link.Label) > x+maxWidth {
+			return
+		}
+		ur.drawText(currentX, y, labelStyle, separator)
+		currentX += ui.TextWidth(separator)
+		ur.drawText(currentX, y, linkStyle.Url(link.URL), link.Label)
+		currentX += ui.TextWidth(link.Label)
+	}
+}
 
-	screenWidth, screenHeight := ur.screen.Size()
-	var modalX, modalY, modalWidth, modalHeight int
-
-	if ur.state.ShowingDetails {
-		contentLines := ur.calculatePlanetDetailsLines(ur.state.SelectedPlanet)
-		dynamicHeight := minimum(contentLines+6, screenHeight-4)
-		modalX, modalY, modalWidth, modalHeight = ur.GetModalDimensions(screenWidth, screenHeight, dynamicHeight)
-	} else if ur.state.ShowingMoonDetails {
-		contentLines := ur.calculateMoonDetailsLines(ur.state.SelectedMoon)
-		dynamicHeight := minimum(contentLines+6, screenHeight-4)
-		modalX, modalY, modalWidth, modalHeight = ur.GetModalDimensions(screenWidth, screenHeight, dynamicHeight)
-	} else {
-		modalX, modalY, modalWidth, modalHeight = ur.GetModalDimensions(screenWidth, screenHeight)
+func (ur *UIRenderer) GetModalDimensions(screenWidth, screenHeight int, dynamicHeight ...int) (modalX, modalY, modalWidth, modalHeight int) {
+	height := 0
+	if len(dynamicHeight) > 0 {
+		height = dynamicHeight[0]
 	}
+	modal := layout.Compute(screenWidth, screenHeight).Modal(height)
+	return modal.X, modal.Y, modal.Width, modal.Height
+}
 
-	return mouseX >= modalX && mouseX < modalX+modalWidth &&
-		mouseY >= modalY && mouseY < modalY+modalHeight
+// contentWidth returns the width available to text inside a modal
+func (ur *UIRenderer) contentWidth() int {
+	width, height := ur.screen.Size()
+	return layout.Compute(width, height).ContentWidth()
 }
 
 func minimum(a, b int) int {
@@ -0,0 +1,257 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/api"
+	"github.com/furan917/go-solar-system/internal/config"
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/logging"
+	"github.com/furan917/go-solar-system/internal/watch"
+	"github.com/gdamore/tcell/v2"
+)
+
+// watchStartDelay lets the first system load before the first check competes with it
+const watchStartDelay = 5 * time.Second
+
+// watchToastDuration is how long a change alert stays on screen
+const watchToastDuration = 6 * time.Second
+
+// watchPoller re-fetches the watched bodies in the background and reports what the
+// API changed since the last fetch
+type watchPoller struct {
+	client  *api.Client
+	store   *watch.Store
+	state   *AppState
+	logger  *logging.Logger
+	trigger chan struct{}
+}
+
+func newWatchPoller(client *api.Client, store *watch.Store, state *AppState, logger *logging.Logger) *watchPoller {
+	return &watchPoller{
+		client:  client,
+		store:   store,
+		state:   state,
+		logger:  logger,
+		trigger: make(chan struct{}, 1),
+	}
+}
+
+// run checks shortly after startup, then every WatchPollInterval or when asked,
+// until ctx is done
+func (p *watchPoller) run(ctx context.Context) error {
+	timer := time.NewTimer(watchStartDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+			timer.Reset(constants.WatchPollInterval)
+		case <-p.trigger:
+		}
+		p.check(ctx)
+	}
+}
+
+// checkNow asks for a check without waiting for the next interval
+func (p *watchPoller) checkNow() {
+	select {
+	case p.trigger <- struct{}{}:
+	default: // a check is already pending
+	}
+}
+
+// check fetches every watched body once and raises an alert for each that changed
+func (p *watchPoller) check(ctx context.Context) {
+	watched := p.state.GetWatchlist()
+	if len(watched) == 0 {
+		return
+	}
+
+	var reports []watchReport
+	for _, body := range watched {
+		if ctx.Err() != nil {
+			return
+		}
+
+		fetched, err := p.client.GetBody(body.ID)
+		if err != nil {
+			p.logger.Printf("Watchlist: could not fetch %s: %v", body.ID, err)
+			continue
+		}
+		p.state.SetWatchedName(body.ID, fetched.EnglishName)
+
+		now := time.Now()
+		if changes := p.store.Update(*fetched, now); len(changes) > 0 {
+			reports = append(reports, watchReport{ID: body.ID, Name: fetched.EnglishName, At: now, Changes: changes})
+		}
+	}
+
+	if err := p.store.Save(); err != nil {
+		p.logger.Printf("Watchlist: %v", err)
+	}
+	p.state.RecordWatchCheck(reports, time.Now())
+
+	for _, report := range reports {
+		p.logger.Printf("Watchlist: %s changed upstream (%d fields)", report.Name, len(report.Changes))
+		p.state.PushToast(fmt.Sprintf("%s changed in the API: %d fields, see the watchlist", report.Name, len(report.Changes)), watchToastDuration)
+	}
+}
+
+// toggleWatch adds the body in the details modal to the watchlist or removes it
+func (ed *EventDispatcher) toggleWatch() {
+	body := ed.state.SelectedPlanet
+	if ed.uiRenderer.GetSystemManager().GetCurrentSystem() != "solar-system" || body.ID == "" {
+		ed.state.SetStatusMessage("Only Solar System bodies from the API can be watched", statusMessageDuration)
+		return
+	}
+
+	watched := ed.state.ToggleWatched(body.ID, body.EnglishName)
+	if !watched {
+		ed.watcher.store.Forget(body.ID)
+	}
+
+	ids := make([]string, 0)
+	for _, w := range ed.state.GetWatchlist() {
+		ids = append(ids, w.ID)
+	}
+	if err := ed.settings.update(func(cfg *config.Config) { cfg.Watchlist = ids }); err != nil {
+		ed.state.SetStatusMessage("Watchlist changed, but not saved: "+err.Error(), statusMessageDuration)
+		return
+	}
+
+	if watched {
+		ed.state.SetStatusMessage(fmt.Sprintf("Watching %s for changes in the API", body.EnglishName), statusMessageDuration)
+		ed.watcher.checkNow()
+	} else {
+		ed.state.SetStatusMessage(fmt.Sprintf("Stopped watching %s", body.EnglishName), statusMessageDuration)
+	}
+}
+
+// handleWatchlistKeys handles keyboard input while the watchlist is open
+func (ed *EventDispatcher) handleWatchlistKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyEnter:
+		ed.state.PopModal()
+	case tcell.KeyUp:
+		if ed.state.WatchlistScroll > 0 {
+			ed.state.WatchlistScroll--
+		}
+	case tcell.KeyDown:
+		if ed.state.WatchlistScroll < len(buildWatchlistLines(ed.state))-1 {
+			ed.state.WatchlistScroll++
+		}
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'r', 'R':
+			ed.watcher.checkNow()
+			ed.state.SetStatusMessage("Checking watched bodies", statusMessageDuration)
+		case 'x', 'X':
+			ed.state.ClearWatchReports()
+			ed.state.WatchlistScroll = 0
+		case 'q', 'Q', 'b', 'B':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// buildWatchlistLines lists the watched bodies followed by the changes found in
+// them, newest first. It reuses the statistics modal's label/value rows.
+func buildWatchlistLines(state *AppState) []statsLine {
+	watched := state.GetWatchlist()
+	reports, checked := state.GetWatchReports()
+
+	if len(watched) == 0 {
+		return []statsLine{
+			{heading: true, label: "Nothing is watched yet"},
+			{label: "Open a Solar System body's details and press w to watch it."},
+		}
+	}
+
+	lastCheck := "not yet"
+	if !checked.IsZero() {
+		lastCheck = checked.Format("2006-01-02 15:04")
+	}
+	names := ""
+	for i, body := range watched {
+		if i > 0 {
+			names += ", "
+		}
+		if body.Name != "" {
+			names += body.Name
+		} else {
+			names += body.ID
+		}
+	}
+
+	lines := []statsLine{
+		{heading: true, label: "Watching"},
+		{label: "Bodies", value: names},
+		{label: "Last check", value: lastCheck},
+		{label: "Checked every", value: constants.WatchPollInterval.String()},
+	}
+
+	if len(reports) == 0 {
+		return append(lines, statsLine{}, statsLine{heading: true, label: "No changes seen"})
+	}
+
+	for _, report := range reports {
+		lines = append(lines, statsLine{}, statsLine{heading: true, label: fmt.Sprintf("%s changed (%s)", report.Name, report.At.Format("2006-01-02 15:04"))})
+		for _, change := range report.Changes {
+			value := change.Old + " → " + change.New
+			switch {
+			case change.Old == "":
+				value = change.New
+			case change.New == "":
+				value = change.Old
+			}
+			lines = append(lines, statsLine{label: change.Field, value: value})
+		}
+	}
+	return lines
+}
+
+// calculateWatchlistLines returns the number of content lines in the watchlist modal
+func (ur *UIRenderer) calculateWatchlistLines(state *AppState) int {
+	return len(buildWatchlistLines(state))
+}
+
+// drawWatchlistModal renders the watched bodies and the diff of each change
+func (ur *UIRenderer) drawWatchlistModal(width, height int) {
+	dynamicHeight := minimum(ur.calculateWatchlistLines(ur.state)+6, height-4)
+	modalX, modalY, _, modalHeight := ur.setupModal(width, height, dynamicHeight)
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, " 👁 Watchlist ")
+
+	headingStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue).Bold(true)
+	labelStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	valueStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+
+	lines := buildWatchlistLines(ur.state)
+	visible := modalHeight - 5
+	scroll := minimum(ur.state.WatchlistScroll, max(len(lines)-visible, 0))
+	for i := 0; i < visible && scroll+i < len(lines); i++ {
+		line := lines[scroll+i]
+		y := modalY + 3 + i
+		if line.heading {
+			ur.drawText(modalX+2, y, headingStyle, truncateText(line.label, ur.contentWidth()))
+			continue
+		}
+		if line.value == "" {
+			ur.drawText(modalX+4, y, valueStyle, truncateText(line.label, ur.contentWidth()-2))
+			continue
+		}
+		ur.drawText(modalX+4, y, labelStyle, line.label)
+		ur.drawText(modalX+4+statsLabelColumn, y, valueStyle, truncateText(line.value, ur.contentWidth()-statsLabelColumn-2))
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "↑/↓ scroll • R check now • X clear changes • Esc close")
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/orbital"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// defaultWeightInput is the mass the weight calculator opens with, in kg
+	defaultWeightInput = "70"
+
+	// maxWeightInputLength keeps the typed mass to a sensible number of digits
+	maxWeightInputLength = 9
+)
+
+// openWeightCalculator shows what a mass would weigh on every loaded body
+func (ed *EventDispatcher) openWeightCalculator() {
+	ed.state.ShowWeightCalculator(defaultWeightInput)
+}
+
+// handleWeightKeys handles keyboard input while the weight calculator is open:
+// digits and a decimal point edit the mass, the arrows scroll the list
+func (ed *EventDispatcher) handleWeightKeys(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyEnter:
+		ed.state.PopModal()
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if input := ed.state.WeightInput; input != "" {
+			ed.state.WeightInput = input[:len(input)-1]
+		}
+	case tcell.KeyUp:
+		if ed.state.WeightScroll > 0 {
+			ed.state.WeightScroll--
+		}
+	case tcell.KeyDown:
+		if ed.state.WeightScroll < len(ed.state.GetPlanets())-1 {
+			ed.state.WeightScroll++
+		}
+	case tcell.KeyRune:
+		r := ev.Rune()
+		switch {
+		case r >= '0' && r <= '9', r == '.' && !strings.Contains(ed.state.WeightInput, "."):
+			if len(ed.state.WeightInput) < maxWeightInputLength {
+				ed.state.WeightInput += string(r)
+			}
+		case r == 'q', r == 'Q', r == 'b', r == 'B':
+			ed.state.PopModal()
+		}
+	default:
+		// do nothing
+	}
+}
+
+// buildWeightLines lists the weight of the typed mass on each loaded body
+func buildWeightLines(input string, state *AppState) []statsLine {
+	mass, err := strconv.ParseFloat(input, 64)
+	if err != nil || mass <= 0 {
+		return []statsLine{{heading: true, label: "Type a mass in kg to see what it weighs"}}
+	}
+
+	var lines []statsLine
+	for _, body := range state.GetPlanets() {
+		gravity, ok := orbital.SurfaceGravity(body)
+		if !ok {
+			lines = append(lines, statsLine{label: body.EnglishName, value: "unknown (no gravity or mass recorded)"})
+			continue
+		}
+		lines = append(lines, statsLine{
+			label: body.EnglishName,
+			value: fmt.Sprintf("%s kg on the scale • %s N • g = %.2f m/s²",
+				formatWeight(orbital.ScaleReading(mass, gravity)), formatWeight(orbital.Weight(mass, gravity)), gravity),
+		})
+	}
+	return lines
+}
+
+// formatWeight writes a weight with one decimal, or none with thousands separators
+// once it is large
+func formatWeight(value float64) string {
+	if value >= 1000 {
+		return formatCount(int(value + 0.5))
+	}
+	return strconv.FormatFloat(value, 'f', 1, 64)
+}
+
+// calculateWeightLines returns the number of content lines in the weight calculator
+func (ur *UIRenderer) calculateWeightLines(state *AppState) int {
+	return len(buildWeightLines(state.WeightInput, state)) + 2 // input and spacer
+}
+
+// drawWeightModal renders the mass input and the weight on every body
+func (ur *UIRenderer) drawWeightModal(width, height int) {
+	dynamicHeight := minimum(ur.calculateWeightLines(ur.state)+6, height-4)
+	modalX, modalY, _, modalHeight := ur.setupModal(width, height, dynamicHeight)
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	ur.drawText(modalX+2, modalY+1, titleStyle, " ⚖ What Would I Weigh? ")
+
+	inputStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorWhite)
+	labelStyle := tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorDarkBlue)
+	valueStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkBlue)
+	headingStyle := tcell.StyleDefault.Foreground(tcell.ColorLightBlue).Background(tcell.ColorDarkBlue).Bold(true)
+
+	ur.drawText(modalX+2, modalY+3, valueStyle, "Mass:")
+	ur.drawText(modalX+8, modalY+3, inputStyle, fmt.Sprintf(" %-*s ", maxWeightInputLength, ur.state.WeightInput+"_"))
+	ur.drawText(modalX+8+maxWeightInputLength+3, modalY+3, valueStyle, "kg")
+
+	lines := buildWeightLines(ur.state.WeightInput, ur.state)
+	top := modalY + 5
+	visible := modalY + modalHeight - 3 - top
+	scroll := minimum(ur.state.WeightScroll, max(len(lines)-visible, 0))
+	for i := 0; i < visible && scroll+i < len(lines); i++ {
+		line := lines[scroll+i]
+		if line.heading {
+			ur.drawText(modalX+2, top+i, headingStyle, line.label)
+			continue
+		}
+		ur.drawText(modalX+2, top+i, labelStyle, truncateText(line.label, statsLabelColumn-1))
+		ur.drawText(modalX+2+statsLabelColumn, top+i, valueStyle, truncateText(line.value, ur.contentWidth()-statsLabelColumn))
+	}
+
+	instructionStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue)
+	ur.drawText(modalX+2, modalY+modalHeight-2, instructionStyle, "Type a mass • ↑/↓ scroll • Enter or Esc to close")
+}
@@ -0,0 +1,145 @@
+package app
+
+import (
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/gdamore/tcell/v2"
+)
+
+// HandleWheel scrolls whatever the mouse wheel is turned over: the modal being
+// shown, or the planet list, where it moves the selection like the arrow keys.
+// It reports whether ev was a wheel event at all. Turning the wheel over the map
+// does nothing yet; it is where zooming will go.
+func (meh *MouseEventHandler) HandleWheel(ev *tcell.EventMouse) bool {
+	var direction int
+	switch {
+	case ev.Buttons()&tcell.WheelUp != 0:
+		direction = -1
+	case ev.Buttons()&tcell.WheelDown != 0:
+		direction = 1
+	default:
+		return false
+	}
+
+	mouseX, mouseY := ev.Position()
+	if modal := meh.state.TopModal(); modal != ModalNone {
+		spec := modalSpecFor(modal)
+		if meh.renderer.modalArea(meh.state, modal).Contains(mouseX, mouseY) {
+			if spec.wheel != nil {
+				spec.wheel(meh, direction)
+			}
+			return true
+		}
+		if !spec.passThrough {
+			return true
+		}
+	}
+
+	if layout.Compute(meh.renderer.screen.Size()).List.Contains(mouseX, mouseY) {
+		meh.state.StepListed(direction)
+	}
+	return true
+}
+
+// clampScroll returns scroll moved by lines, kept between 0 and limit
+func clampScroll(scroll, lines, limit int) int {
+	return max(0, min(scroll+lines, limit))
+}
+
+func (meh *MouseEventHandler) scrollMoonList(direction int) {
+	meh.state.SelectMoon(meh.state.MoonSelectedIndex+direction, len(meh.state.SelectedPlanet.Moons))
+}
+
+func (meh *MouseEventHandler) scrollSystemList(direction int) {
+	meh.state.HandleSystemNavigation(direction, len(meh.renderer.GetSystemManager().GetAvailableSystems()))
+}
+
+func (meh *MouseEventHandler) scrollDetails(direction int) {
+	meh.renderer.scrollDetailsBy(meh.state, direction*constants.WheelScrollLines)
+}
+
+func (meh *MouseEventHandler) scrollHelp(direction int) {
+	meh.state.HelpScroll = clampScroll(meh.state.HelpScroll, direction*constants.WheelScrollLines, meh.renderer.helpScrollLimit())
+}
+
+func (meh *MouseEventHandler) scrollEventLog(direction int) {
+	limit := max(len(meh.state.EventLog)-constants.MaxVisibleItems, 0)
+	meh.state.EventLogScroll = clampScroll(meh.state.EventLogScroll, direction*constants.WheelScrollLines, limit)
+}
+
+func (meh *MouseEventHandler) scrollWatchlist(direction int) {
+	limit := max(len(buildWatchlistLines(meh.state))-1, 0)
+	meh.state.WatchlistScroll = clampScroll(meh.state.WatchlistScroll, direction*constants.WheelScrollLines, limit)
+}
+
+func (meh *MouseEventHandler) scrollWeight(direction int) {
+	limit := max(len(meh.state.GetPlanets())-1, 0)
+	meh.state.WeightScroll = clampScroll(meh.state.WeightScroll, direction*constants.WheelScrollLines, limit)
+}
+
+func (meh *MouseEventHandler) scrollChart(direction int) {
+	limit := max(len(meh.state.GetPlanets())-1, 0)
+	meh.state.ChartScroll = clampScroll(meh.state.ChartScroll, direction*constants.WheelScrollLines, limit)
+}
+
+// scrollTree moves the hierarchy's selection, which its rows follow
+func (meh *MouseEventHandler) scrollTree(direction int) {
+	limit := max(len(meh.renderer.hierarchyRows(meh.state))-1, 0)
+	meh.state.TreeSelected = clampScroll(meh.state.TreeSelected, direction*constants.WheelScrollLines, limit)
+}
+
+func (meh *MouseEventHandler) scrollScale(direction int) {
+	limit := max(len(buildScaleLines(meh.state.ScaleInput, meh.state))-1, 0)
+	meh.state.ScaleScroll = clampScroll(meh.state.ScaleScroll, direction*constants.WheelScrollLines, limit)
+}
+
+// scrollRawJSON scrolls the raw JSON view
+func (meh *MouseEventHandler) scrollRawJSON(direction int) {
+	limit := max(len(meh.state.RawJSONLines)-1, 0)
+	meh.state.RawJSONScroll = clampScroll(meh.state.RawJSONScroll, direction*constants.WheelScrollLines, limit)
+}
+
+// textClip is the rows of a modal that scrolling content is drawn through
+type textClip struct {
+	offset      int // rows scrolled down
+	top, bottom int // first row shown and the row after the last
+}
+
+// startScroll draws what follows, until endScroll, as the scrollable content of
+// a details modal between rows top and bottom, scrolled by DetailsScroll. Arrows
+// at column x show when there is more above or below.
+func (ur *UIRenderer) startScroll(modal Modal, x, top, bottom int) {
+	limit := ur.detailsScrollLimit(ur.state, modal)
+	offset := min(ur.state.DetailsScroll, limit)
+
+	arrowStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDarkBlue).Bold(true)
+	if offset > 0 {
+		ur.drawText(x, top-1, arrowStyle, "↑")
+	}
+	if offset < limit {
+		ur.drawText(x, bottom, arrowStyle, "↓")
+	}
+	ur.clip = &textClip{offset: offset, top: top, bottom: bottom}
+}
+
+func (ur *UIRenderer) endScroll() {
+	ur.clip = nil
+}
+
+// scrollDetailsBy scrolls the details being shown by lines, down for positive
+func (ur *UIRenderer) scrollDetailsBy(state *AppState, lines int) {
+	state.DetailsScroll = clampScroll(state.DetailsScroll, lines, ur.detailsScrollLimit(state, state.TopModal()))
+}
+
+// detailsScrollLimit is how far the content of a details modal can scroll on the
+// current screen
+func (ur *UIRenderer) detailsScrollLimit(state *AppState, modal Modal) int {
+	var lines int
+	switch modal {
+	case ModalDetails:
+		lines = ur.calculatePlanetDetailsLines(state.SelectedPlanet)
+	case ModalMoonDetails:
+		lines = ur.calculateMoonDetailsLines(state.SelectedMoon)
+	}
+	return max(lines-(ur.modalArea(state, modal).Height-6), 0)
+}
@@ -0,0 +1,122 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+// wheel turns the mouse wheel steps times at a cell, down for a positive count
+func wheel(dispatcher *EventDispatcher, x, y, steps int) {
+	button := tcell.WheelDown
+	if steps < 0 {
+		button, steps = tcell.WheelUp, -steps
+	}
+	for range steps {
+		dispatcher.HandleEvent(tcell.NewEventMouse(x, y, button, tcell.ModNone))
+	}
+}
+
+// modalCentre returns the middle of the modal on top
+func modalCentre(dispatcher *EventDispatcher, state *AppState) (int, int) {
+	area := dispatcher.uiRenderer.modalArea(state, state.TopModal())
+	return area.X + area.Width/2, area.Y + area.Height/2
+}
+
+func TestClampScroll(t *testing.T) {
+	tests := []struct{ scroll, lines, limit, want int }{
+		{0, 3, 10, 3},
+		{9, 3, 10, 10},
+		{2, -3, 10, 0},
+		{0, 3, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := clampScroll(tt.scroll, tt.lines, tt.limit); got != tt.want {
+			t.Errorf("clampScroll(%d, %d, %d) = %d, want %d", tt.scroll, tt.lines, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestWheelScrollsDetails(t *testing.T) {
+	dispatcher, state, _ := newAppFixture(t, 80, 20)
+	earth := state.GetPlanets()[3]
+	earth.Mass = models.Mass{MassValue: 5.97237, MassExponent: 24}
+	earth.Eccentricity, earth.Inclination, earth.AxialTilt = 0.0167, 0, 23.44
+	earth.Density, earth.Gravity, earth.Escape, earth.AvgTemp = 5.514, 9.8, 11190, 288
+	earth.SideralRotation, earth.DiscoveredBy = 23.9345, "Everyone"
+	state.ShowPlanetDetails(earth, 3)
+	limit := dispatcher.uiRenderer.detailsScrollLimit(state, ModalDetails)
+	if limit == 0 {
+		t.Fatal("Expected Earth's details to overflow a 20-row screen")
+	}
+	x, y := modalCentre(dispatcher, state)
+
+	wheel(dispatcher, x, y, 1)
+	if want := min(3, limit); state.DetailsScroll != want {
+		t.Errorf("DetailsScroll = %d after one step, want %d", state.DetailsScroll, want)
+	}
+	wheel(dispatcher, x, y, limit)
+	if state.DetailsScroll != limit {
+		t.Errorf("DetailsScroll = %d, want it stopped at %d", state.DetailsScroll, limit)
+	}
+	wheel(dispatcher, x, y, -limit)
+	if state.DetailsScroll != 0 {
+		t.Errorf("DetailsScroll = %d, want it stopped at the top", state.DetailsScroll)
+	}
+}
+
+func TestWheelMovesMoonSelection(t *testing.T) {
+	dispatcher, state := jupiterFixture(t)
+	x, y := modalCentre(dispatcher, state)
+
+	wheel(dispatcher, x, y, 2)
+	if state.MoonSelectedIndex != 2 {
+		t.Errorf("MoonSelectedIndex = %d, want 2", state.MoonSelectedIndex)
+	}
+	wheel(dispatcher, x, y, 40)
+	if state.MoonSelectedIndex != 24 || state.MoonScrollIndex != 15 {
+		t.Errorf("selected %d scrolled to %d, want the last moon, 24, at 15", state.MoonSelectedIndex, state.MoonScrollIndex)
+	}
+	wheel(dispatcher, x, y, -40)
+	if state.MoonSelectedIndex != 0 || state.MoonScrollIndex != 0 {
+		t.Errorf("selected %d scrolled to %d, want back at the top", state.MoonSelectedIndex, state.MoonScrollIndex)
+	}
+}
+
+func TestWheelMovesSystemSelection(t *testing.T) {
+	dispatcher, state, _ := newLoadingFixture(t)
+	state.ShowSystemList()
+	x, y := modalCentre(dispatcher, state)
+
+	wheel(dispatcher, x, y, 3)
+	if state.SystemSelectedIndex != 1 {
+		t.Errorf("SystemSelectedIndex = %d, want the last of 2 systems", state.SystemSelectedIndex)
+	}
+	wheel(dispatcher, x, y, -3)
+	if state.SystemSelectedIndex != 0 {
+		t.Errorf("SystemSelectedIndex = %d, want the first", state.SystemSelectedIndex)
+	}
+}
+
+func TestWheelOverPlanetListAndMap(t *testing.T) {
+	dispatcher, state, _ := newAppFixture(t, 120, 40)
+	state.UpdatePlanetSelection(0, state.GetPlanets()[0])
+	list := layout.Compute(120, 40).List
+
+	wheel(dispatcher, list.X+1, list.Y+1, 2)
+	if state.SelectedPlanet.EnglishName != "Venus" {
+		t.Errorf("selected %s, want Venus two down the list", state.SelectedPlanet.EnglishName)
+	}
+	wheel(dispatcher, list.X+1, list.Y+1, 10)
+	if state.SelectedPlanet.EnglishName != "Jupiter" {
+		t.Errorf("selected %s, want it stopped at Jupiter", state.SelectedPlanet.EnglishName)
+	}
+
+	// Over the map, nothing moves
+	wheel(dispatcher, 100, 20, -3)
+	if state.SelectedPlanet.EnglishName != "Jupiter" || state.IsAnyModalShowing() {
+		t.Errorf("wheel over the map selected %s with %v on top", state.SelectedPlanet.EnglishName, state.TopModal())
+	}
+}
@@ -0,0 +1,27 @@
+package app
+
+// windowTitle is the terminal title for a system and the selected body
+func windowTitle(system, body string) string {
+	title := "Solar System — " + system
+	if body != "" {
+		title += " — " + body
+	}
+	return title
+}
+
+// titleUpdater keeps the terminal's title on the system and body being shown. It
+// runs as a frame hook and only sets the title when it changes.
+type titleUpdater struct {
+	last string
+}
+
+// onFrame retitles the terminal window after the frame's system and selection,
+// only when that has changed
+func (u *titleUpdater) onFrame(frame Frame) bool {
+	title := windowTitle(frame.System, frame.Selected.EnglishName)
+	if title != u.last {
+		frame.Screen.SetTitle(title)
+		u.last = title
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+// titleScreen records the titles set on a simulated screen
+type titleScreen struct {
+	tcell.SimulationScreen
+	titles []string
+}
+
+func (s *titleScreen) SetTitle(title string) {
+	s.titles = append(s.titles, title)
+}
+
+func TestWindowTitle(t *testing.T) {
+	tests := []struct {
+		system, body, want string
+	}{
+		{"Alpha Centauri", "Proxima b", "Solar System — Alpha Centauri — Proxima b"},
+		{"TRAPPIST-1", "", "Solar System — TRAPPIST-1"},
+	}
+	for _, tt := range tests {
+		if got := windowTitle(tt.system, tt.body); got != tt.want {
+			t.Errorf("windowTitle(%q, %q) = %q, want %q", tt.system, tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestTitleUpdaterSetsOnlyChanges(t *testing.T) {
+	screen := &titleScreen{SimulationScreen: tcell.NewSimulationScreen("UTF-8")}
+	updater := &titleUpdater{}
+	frame := func(system, body string) {
+		if !updater.onFrame(Frame{Screen: screen, System: system, Selected: models.CelestialBody{EnglishName: body}}) {
+			t.Fatal("Expected the title hook to stay registered")
+		}
+	}
+
+	frame("TRAPPIST-1", "TRAPPIST-1e")
+	frame("TRAPPIST-1", "TRAPPIST-1e")
+	frame("TRAPPIST-1", "TRAPPIST-1f")
+	frame("TRAPPIST-1", "TRAPPIST-1f")
+	frame("Kepler-90", "")
+
+	want := []string{
+		"Solar System — TRAPPIST-1 — TRAPPIST-1e",
+		"Solar System — TRAPPIST-1 — TRAPPIST-1f",
+		"Solar System — Kepler-90",
+	}
+	if len(screen.titles) != len(want) {
+		t.Fatalf("titles set = %q, want %q", screen.titles, want)
+	}
+	for i := range want {
+		if screen.titles[i] != want[i] {
+			t.Errorf("title %d = %q, want %q", i, screen.titles[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/furan917/go-solar-system/internal/visualization"
+	"github.com/gdamore/tcell/v2"
+)
+
+// wobbleObserver is the direction, measured like the map's angles, that radial
+// velocities are given for: an observer far off below the map, in its plane
+const wobbleObserver = math.Pi / 2
+
+// barycenterMark marks the barycenter on the map, in barycenterStyle
+const barycenterMark = '+'
+
+var barycenterStyle = tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack).Bold(true)
+
+// toggleWobble draws the star pulled off the barycenter by its planets, or back
+// in the middle, and says how fast they swing it round
+func (ed *EventDispatcher) toggleWobble() {
+	if !ed.uiRenderer.toggleWobble() {
+		ed.state.SetStatusMessage("Star wobble hidden", statusMessageDuration)
+		return
+	}
+	ed.state.SetStatusMessage("Star wobble shown, exaggerated: + marks the barycenter the star and its planets circle", statusMessageDuration)
+}
+
+// toggleWobble starts or stops drawing the wobble, between frames, and reports
+// whether it is now drawn
+func (ur *UIRenderer) toggleWobble() bool {
+	ur.drawMu.Lock()
+	defer ur.drawMu.Unlock()
+	shown := !ur.renderer.WobbleShown()
+	ur.renderer.SetWobbleShown(shown)
+	if ur.compareRenderer != nil {
+		ur.compareRenderer.SetWobbleShown(shown)
+	}
+	return shown
+}
+
+// drawBarycenter marks the barycenter the renderer drew the stars wobbling round,
+// in a grid placed at x, y
+func (ur *UIRenderer) drawBarycenter(renderer *visualization.Renderer, x, y, width, height int) {
+	marks, ok := renderer.WobbleMarks()
+	if !ok || marks.BarycenterX < 0 || marks.BarycenterX >= width || marks.BarycenterY < 0 || marks.BarycenterY >= height {
+		return
+	}
+	ur.screen.SetContent(x+marks.BarycenterX, y+marks.BarycenterY, barycenterMark, nil, barycenterStyle)
+}
+
+// wobbleWidgetLines returns the rows of the wobble readout: how far the star is
+// from the barycenter, how fast it moves and how much of that an observer below
+// the map would see as a Doppler shift
+func wobbleWidgetLines(marks visualization.WobbleMarks, stars []models.CelestialBody) []string {
+	name, radius := "Star", 0.0
+	switch {
+	case len(stars) == 1:
+		name, radius = stars[0].EnglishName, stars[0].MeanRadius
+	case len(stars) > 1:
+		name = "Stars"
+	}
+
+	wobble := marks.Wobble
+	offset := formatCount(int(math.Round(wobble.Offset()))) + " km off the barycenter"
+	if radius > 0 {
+		offset += fmt.Sprintf(" (%.2f radii)", wobble.Offset()/radius)
+	}
+	return []string{
+		name + "'s wobble (exaggerated)",
+		offset,
+		fmt.Sprintf("Moving at %.2f m/s", wobble.Speed()),
+		fmt.Sprintf("Radial velocity %+.2f m/s from below", wobble.RadialVelocity(wobbleObserver)),
+	}
+}
+
+// drawWobbleWidget draws the wobble readout in the bottom-right corner of the map
+func (ur *UIRenderer) drawWobbleWidget(area layout.Rect) {
+	marks, ok := ur.renderer.WobbleMarks()
+	if !ok || area.Width < hereWidgetMinWidth || area.Height < hereWidgetMinHeight {
+		return
+	}
+
+	var stars []models.CelestialBody
+	for _, body := range ur.state.GetPlanets() {
+		if body.BodyType == "Star" {
+			stars = append(stars, body)
+		}
+	}
+	lines := wobbleWidgetLines(marks, stars)
+	boxWidth := 0
+	for _, line := range lines {
+		boxWidth = max(boxWidth, ui.TextWidth(line))
+	}
+	boxWidth += 2
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorYellow).Bold(true)
+	lineStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorBlack)
+
+	x := area.X + max(area.Width-boxWidth, 0)
+	y := area.Y + area.Height - len(lines)
+	for i, line := range lines {
+		style := lineStyle
+		if i == 0 {
+			style = titleStyle
+		}
+		ur.drawText(x, y+i, style, truncateText(fmt.Sprintf(" %-*s", boxWidth-1, line), area.Width))
+	}
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/layout"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/ui"
+	"github.com/gdamore/tcell/v2"
+)
+
+// earthPulse is how long Earth's marker holds each of its two looks
+const earthPulse = 600 * time.Millisecond
+
+// hereWidgetMinWidth and hereWidgetMinHeight are the smallest map the corner
+// widget is drawn on; below that it would cover too much of the orbits
+const (
+	hereWidgetMinWidth  = 40
+	hereWidgetMinHeight = 10
+)
+
+// showsHome reports whether the map shows the Solar System on its own, where the
+// "you are here" marker and widget belong
+func (ur *UIRenderer) showsHome() bool {
+	return ur.systemManager.GetCurrentSystem() == "solar-system" && !ur.state.Comparing && !ur.state.Focusing
+}
+
+// homeBody returns Earth from the loaded bodies
+func (ur *UIRenderer) homeBody() (models.CelestialBody, bool) {
+	for _, body := range ur.state.GetPlanets() {
+		if body.EnglishName == "Earth" {
+			return body, true
+		}
+	}
+	return models.CelestialBody{}, false
+}
+
+// drawEarthMarker redraws Earth's glyph on the map, switching between two looks
+// so it stands out from the other planets
+func (ur *UIRenderer) drawEarthMarker(now time.Time) {
+	if !ur.showsHome() {
+		return
+	}
+	position, ok := ur.state.GetPlanetPositions()["Earth"]
+	if !ok {
+		return
+	}
+
+	style := tcell.StyleDefault.Foreground(tcell.ColorAqua).Bold(true)
+	if now.UnixMilli()/earthPulse.Milliseconds()%2 == 0 {
+		style = style.Reverse(true)
+	}
+	ur.screen.SetContent(position.X, position.Y, ur.renderer.GetPlanetSymbol("Earth"), nil, style)
+}
+
+// hereWidgetLines returns the rows of the "you are here" widget: the simulated
+// date and time, how fast it runs, where Earth is along its orbit and its season
+func (ur *UIRenderer) hereWidgetLines(earth models.CelestialBody) []string {
+	clock := ur.renderer.GetClock()
+	now := clock.Now()
+
+	lines := []string{
+		fmt.Sprintf("%c You are here", ur.renderer.GetPlanetSymbol("Earth")),
+		now.Local().Format("Mon 2 Jan 2006 15:04"),
+		formatSimulationSpeed(clock.Speed()),
+	}
+	if longitude, ok := ur.renderer.GetEphemeris().EclipticLongitude(earth, now); ok {
+		lines = append(lines, fmt.Sprintf("Earth at %.1f° longitude", longitude))
+	}
+	if season, _, ok := ur.seasonOf(earth); ok {
+		lines = append(lines, fmt.Sprintf("Northern %s, southern %s", season, season.Opposite()))
+	}
+	return lines
+}
+
+// drawHereWidget draws the "you are here" widget in the bottom-left corner of the map
+func (ur *UIRenderer) drawHereWidget(area layout.Rect) {
+	if !ur.showsHome() || area.Width < hereWidgetMinWidth || area.Height < hereWidgetMinHeight {
+		return
+	}
+	earth, ok := ur.homeBody()
+	if !ok {
+		return
+	}
+
+	lines := ur.hereWidgetLines(earth)
+	boxWidth := 0
+	for _, line := range lines {
+		boxWidth = max(boxWidth, ui.TextWidth(line))
+	}
+	boxWidth += 2
+
+	titleStyle := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorAqua).Bold(true)
+	lineStyle := tcell.StyleDefault.Foreground(tcell.ColorAqua).Background(tcell.ColorBlack)
+
+	y := area.Y + area.Height - len(lines)
+	for i, line := range lines {
+		style := lineStyle
+		if i == 0 {
+			style = titleStyle
+		}
+		ur.drawText(area.X, y+i, style, fmt.Sprintf(" %-*s", boxWidth-1, line))
+	}
+}
+
+// formatSimulationSpeed says how fast simulated time runs
+func formatSimulationSpeed(speed float64) string {
+	switch {
+	case speed >= 86400:
+		return fmt.Sprintf("%g days per second", speed/86400)
+	case speed >= 3600:
+		return fmt.Sprintf("%g hours per second", speed/3600)
+	case speed > 1:
+		return fmt.Sprintf("%g× real time", speed)
+	default:
+		return "Real time"
+	}
+}
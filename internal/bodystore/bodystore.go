@@ -0,0 +1,272 @@
+// Package bodystore keeps every body the app fetches or loads in a local SQLite
+// database: the latest copy of each for starting and browsing without the API,
+// every version that differed from the one before it, and an index of their
+// names to search without loading them all.
+package bodystore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/models"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS bodies (
+	system       TEXT NOT NULL,
+	key          TEXT NOT NULL,
+	english_name TEXT NOT NULL,
+	body_type    TEXT NOT NULL,
+	data         BLOB NOT NULL,
+	fetched      INTEGER NOT NULL,
+	PRIMARY KEY (system, key)
+);
+CREATE TABLE IF NOT EXISTS history (
+	system  TEXT NOT NULL,
+	key     TEXT NOT NULL,
+	data    BLOB NOT NULL,
+	fetched INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS history_body ON history (system, key, fetched);
+CREATE TABLE IF NOT EXISTS names (
+	system TEXT NOT NULL,
+	key    TEXT NOT NULL,
+	name   TEXT NOT NULL COLLATE NOCASE
+);
+CREATE INDEX IF NOT EXISTS names_name ON names (name);
+CREATE INDEX IF NOT EXISTS names_body ON names (system, key);
+`
+
+// Store is a body database. It is safe for concurrent use.
+type Store struct {
+	db *sql.DB
+}
+
+// Match is a body found by Search
+type Match struct {
+	System  string
+	Body    models.CelestialBody
+	Fetched time.Time
+}
+
+// Snapshot is one version of a body
+type Snapshot struct {
+	Body    models.CelestialBody
+	Fetched time.Time
+}
+
+// DefaultPath returns the database location inside the user's cache directory
+func DefaultPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "go-solar-system", constants.BodyStoreFileName)
+}
+
+// Open opens the database at path, creating it and its directory if needed
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create body store directory: %w", err)
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open body store %s: %w", path, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open body store %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// key identifies a body within its system: its id, or its name when a system file
+// gives it none
+func key(body models.CelestialBody) string {
+	if body.ID != "" {
+		return body.ID
+	}
+	return body.EnglishName
+}
+
+// SaveBodies records bodies of a system as fetched at fetched. A body that differs
+// from its stored copy is also added to its history.
+func (s *Store) SaveBodies(system string, bodies []models.CelestialBody, fetched time.Time) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to save bodies: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, body := range bodies {
+		if err := saveBody(tx, system, body, fetched.Unix()); err != nil {
+			return fmt.Errorf("failed to save %s: %w", body.EnglishName, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save bodies: %w", err)
+	}
+	return nil
+}
+
+func saveBody(tx *sql.Tx, system string, body models.CelestialBody, fetched int64) error {
+	k := key(body)
+	if k == "" {
+		return nil
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	var stored []byte
+	err = tx.QueryRow(`SELECT data FROM bodies WHERE system = ? AND key = ?`, system, k).Scan(&stored)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return err
+	case string(stored) == string(data):
+		_, err = tx.Exec(`UPDATE bodies SET fetched = ? WHERE system = ? AND key = ?`, fetched, system, k)
+		return err
+	}
+
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO bodies (system, key, english_name, body_type, data, fetched) VALUES (?, ?, ?, ?, ?, ?)`,
+		system, k, body.EnglishName, body.BodyType, data, fetched); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO history (system, key, data, fetched) VALUES (?, ?, ?, ?)`, system, k, data, fetched); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM names WHERE system = ? AND key = ?`, system, k); err != nil {
+		return err
+	}
+	for _, name := range append(body.Names(), body.ID) {
+		if name == "" {
+			continue
+		}
+		if _, err := tx.Exec(`INSERT INTO names (system, key, name) VALUES (?, ?, ?)`, system, k, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Bodies returns the stored bodies of a system, by name
+func (s *Store) Bodies(system string) ([]models.CelestialBody, error) {
+	rows, err := s.db.Query(`SELECT data FROM bodies WHERE system = ? ORDER BY english_name`, system)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bodies of %s: %w", system, err)
+	}
+	defer rows.Close()
+
+	var bodies []models.CelestialBody
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read bodies of %s: %w", system, err)
+		}
+		var body models.CelestialBody
+		if err := json.Unmarshal(data, &body); err != nil {
+			return nil, fmt.Errorf("failed to read bodies of %s: %w", system, err)
+		}
+		bodies = append(bodies, body)
+	}
+	return bodies, rows.Err()
+}
+
+// Body returns the stored copy of a body of a system by its id, or its name for a
+// body without one
+func (s *Store) Body(system, id string) (models.CelestialBody, bool, error) {
+	var data []byte
+	err := s.db.QueryRow(`SELECT data FROM bodies WHERE system = ? AND key = ?`, system, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.CelestialBody{}, false, nil
+	}
+	if err != nil {
+		return models.CelestialBody{}, false, fmt.Errorf("failed to read %s: %w", id, err)
+	}
+	var body models.CelestialBody
+	if err := json.Unmarshal(data, &body); err != nil {
+		return models.CelestialBody{}, false, fmt.Errorf("failed to read %s: %w", id, err)
+	}
+	return body, true, nil
+}
+
+// Search finds up to limit bodies in any system with an id or name containing
+// query, ignoring case. Bodies with a name starting with it come first.
+func (s *Store) Search(query string, limit int) ([]Match, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(query)
+
+	rows, err := s.db.Query(`
+		SELECT b.system, b.data, b.fetched
+		FROM names n JOIN bodies b ON b.system = n.system AND b.key = n.key
+		WHERE n.name LIKE ? ESCAPE '\'
+		GROUP BY b.system, b.key
+		ORDER BY MIN(CASE WHEN n.name LIKE ? ESCAPE '\' THEN 0 ELSE 1 END), b.english_name, b.system
+		LIMIT ?`,
+		"%"+escaped+"%", escaped+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for %q: %w", query, err)
+	}
+	defer rows.Close()
+
+	var matches []Match
+	for rows.Next() {
+		var match Match
+		var data []byte
+		var fetched int64
+		if err := rows.Scan(&match.System, &data, &fetched); err != nil {
+			return nil, fmt.Errorf("failed to search for %q: %w", query, err)
+		}
+		if err := json.Unmarshal(data, &match.Body); err != nil {
+			return nil, fmt.Errorf("failed to search for %q: %w", query, err)
+		}
+		match.Fetched = time.Unix(fetched, 0)
+		matches = append(matches, match)
+	}
+	return matches, rows.Err()
+}
+
+// History returns every version of a body that differed from the one before it,
+// oldest first
+func (s *Store) History(system, id string) ([]Snapshot, error) {
+	rows, err := s.db.Query(`SELECT data, fetched FROM history WHERE system = ? AND key = ? ORDER BY fetched, rowid`, system, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the history of %s: %w", id, err)
+	}
+	defer rows.Close()
+
+	var snapshots []Snapshot
+	for rows.Next() {
+		var snapshot Snapshot
+		var data []byte
+		var fetched int64
+		if err := rows.Scan(&data, &fetched); err != nil {
+			return nil, fmt.Errorf("failed to read the history of %s: %w", id, err)
+		}
+		if err := json.Unmarshal(data, &snapshot.Body); err != nil {
+			return nil, fmt.Errorf("failed to read the history of %s: %w", id, err)
+		}
+		snapshot.Fetched = time.Unix(fetched, 0)
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, rows.Err()
+}
@@ -0,0 +1,120 @@
+package bodystore
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func openStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), "store", "bodies.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+var (
+	day1 = time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	day2 = day1.AddDate(0, 0, 1)
+	day3 = day1.AddDate(0, 0, 2)
+)
+
+func TestSaveAndReadBodies(t *testing.T) {
+	store := openStore(t)
+	mars := models.CelestialBody{ID: "mars", Name: "Mars", EnglishName: "Mars", BodyType: "Planet", MeanRadius: 3389.5}
+	proxima := models.CelestialBody{EnglishName: "Proxima Centauri b", BodyType: "Planet"}
+
+	if err := store.SaveBodies("solar-system", []models.CelestialBody{mars}, day1); err != nil {
+		t.Fatalf("SaveBodies() error = %v", err)
+	}
+	if err := store.SaveBodies("alpha-centauri", []models.CelestialBody{proxima}, day1); err != nil {
+		t.Fatalf("SaveBodies() error = %v", err)
+	}
+
+	bodies, err := store.Bodies("solar-system")
+	if err != nil || len(bodies) != 1 || bodies[0].MeanRadius != 3389.5 {
+		t.Fatalf("Bodies() = %+v, %v; want Mars", bodies, err)
+	}
+	if _, ok, err := store.Body("alpha-centauri", "Proxima Centauri b"); !ok || err != nil {
+		t.Errorf("Body() of a body without an id = %v, %v; want it found by name", ok, err)
+	}
+	if _, ok, _ := store.Body("solar-system", "venus"); ok {
+		t.Error("Body() found a body never saved")
+	}
+}
+
+func TestHistoryKeepsChangedVersions(t *testing.T) {
+	store := openStore(t)
+	io := models.CelestialBody{ID: "io", EnglishName: "Io", MeanRadius: 1821}
+
+	store.SaveBodies("solar-system", []models.CelestialBody{io}, day1)
+	store.SaveBodies("solar-system", []models.CelestialBody{io}, day2) // unchanged
+	io.MeanRadius = 1821.6
+	store.SaveBodies("solar-system", []models.CelestialBody{io}, day3)
+
+	history, err := store.History("solar-system", "io")
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("History() has %d versions, want 2: %+v", len(history), history)
+	}
+	if history[0].Body.MeanRadius != 1821 || !history[0].Fetched.Equal(day1) {
+		t.Errorf("first version = %+v", history[0])
+	}
+	if history[1].Body.MeanRadius != 1821.6 || !history[1].Fetched.Equal(day3) {
+		t.Errorf("second version = %+v", history[1])
+	}
+}
+
+func TestSearch(t *testing.T) {
+	store := openStore(t)
+	store.SaveBodies("solar-system", []models.CelestialBody{
+		{ID: "terre", Name: "La Terre", EnglishName: "Earth"},
+		{ID: "europe", Name: "Europe", EnglishName: "Europa"},
+		{ID: "callisto", Name: "Callisto", EnglishName: "Callisto"},
+		{ID: "ariel", Name: "Ariel", EnglishName: "Ariel", AlternativeName: "Uranus I"},
+	}, day1)
+	store.SaveBodies("alpha-centauri", []models.CelestialBody{
+		{ID: "alpha-centauri-b", EnglishName: "Alpha Centauri B", Aliases: []string{"Toliman"}},
+	}, day1)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"eur", []string{"Europa"}},
+		{"TERRE", []string{"Earth"}},
+		{"li", []string{"Alpha Centauri B", "Callisto"}},
+		{"ur", []string{"Ariel", "Alpha Centauri B", "Europa"}}, // Uranus I starts with it
+		{"uranus i", []string{"Ariel"}},
+		{"toliman", []string{"Alpha Centauri B"}},
+		{"%", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			matches, err := store.Search(tt.query, 10)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			var got []string
+			for _, match := range matches {
+				got = append(got, match.Body.EnglishName)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Search(%q) = %q, want %q", tt.query, got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+// Package capture writes screenshot bundles of the running application:
+// the terminal frame as ANSI text and PNG, plus a JSON description of the scene.
+package capture
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Bundle file names
+const (
+	ANSIFileName  = "frame.ans"
+	PNGFileName   = "frame.png"
+	SceneFileName = "scene.json"
+)
+
+// PNG cell size in pixels, roughly matching a terminal character cell
+const (
+	cellWidth  = 8
+	cellHeight = 16
+)
+
+var (
+	defaultForeground = color.RGBA{R: 204, G: 204, B: 204, A: 255}
+	defaultBackground = color.RGBA{R: 0, G: 0, B: 0, A: 255}
+)
+
+// Cell is a single terminal character with its colors
+type Cell struct {
+	Rune  rune
+	Width int
+	Fg    tcell.Color
+	Bg    tcell.Color
+	Bold  bool
+}
+
+// Frame is a copy of the terminal contents at the moment of capture
+type Frame struct {
+	Width, Height int
+	Cells         [][]Cell
+}
+
+// Point is a position in the orbital plane in kilometres
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// ScreenPoint is a position on the terminal grid
+type ScreenPoint struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Radius int `json:"radius"`
+}
+
+// Body describes one rendered body in the scene
+type Body struct {
+	Name          string      `json:"name"`
+	ID            string      `json:"id,omitempty"`
+	BodyType      string      `json:"bodyType,omitempty"`
+	Symbol        string      `json:"symbol"`
+	SemimajorAxis float64     `json:"semimajorAxis"`
+	Eccentricity  float64     `json:"eccentricity"`
+	World         Point       `json:"world"`
+	Screen        ScreenPoint `json:"screen"`
+}
+
+// Camera describes how world coordinates were projected onto the terminal
+type Camera struct {
+	OriginX     int     `json:"originX"`
+	OriginY     int     `json:"originY"`
+	Width       int     `json:"width"`
+	Height      int     `json:"height"`
+	AspectRatio float64 `json:"aspectRatio"`
+	Scale       string  `json:"scale"`
+}
+
+// Scene is the machine-readable description written alongside the images
+type Scene struct {
+	CapturedAt time.Time `json:"capturedAt"`
+	System     string    `json:"system"`
+	Terminal   struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	} `json:"terminal"`
+	Camera   Camera `json:"camera"`
+	Selected string `json:"selected,omitempty"`
+	Bodies   []Body `json:"bodies"`
+}
+
+// SnapshotScreen copies the current contents of a screen
+func SnapshotScreen(screen tcell.Screen) Frame {
+	width, height := screen.Size()
+	frame := Frame{Width: width, Height: height, Cells: make([][]Cell, height)}
+
+	for y := 0; y < height; y++ {
+		row := make([]Cell, width)
+		for x := 0; x < width; x++ {
+			mainc, _, style, cellWidth := screen.GetContent(x, y)
+			fg, bg, attrs := style.Decompose()
+			row[x] = Cell{
+				Rune:  mainc,
+				Width: cellWidth,
+				Fg:    fg,
+				Bg:    bg,
+				Bold:  attrs&tcell.AttrBold != 0,
+			}
+		}
+		frame.Cells[y] = row
+	}
+
+	return frame
+}
+
+// WriteBundle writes the frame and scene into a new timestamped directory under
+// baseDir and returns the directory path
+func WriteBundle(baseDir string, frame Frame, scene Scene) (string, error) {
+	dir := filepath.Join(baseDir, "screenshot-"+scene.CapturedAt.Format("20060102-150405.000"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create bundle directory %s: %w", dir, err)
+	}
+
+	writers := []struct {
+		name  string
+		write func(path string) error
+	}{
+		{ANSIFileName, func(path string) error { return writeANSI(path, frame) }},
+		{PNGFileName, func(path string) error { return writePNG(path, frame) }},
+		{SceneFileName, func(path string) error { return writeScene(path, scene) }},
+	}
+
+	for _, w := range writers {
+		if err := w.write(filepath.Join(dir, w.name)); err != nil {
+			return dir, fmt.Errorf("failed to write %s: %w", w.name, err)
+		}
+	}
+
+	return dir, nil
+}
+
+// WriteFile writes one part of a bundle to path, choosing it by the extension:
+// .png for the image, .ans for the ANSI text or .json for the scene
+func WriteFile(path string, frame Frame, scene Scene) error {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".png":
+		return writePNG(path, frame)
+	case ".ans":
+		return writeANSI(path, frame)
+	case ".json":
+		return writeScene(path, scene)
+	default:
+		return fmt.Errorf("cannot write a screenshot as %q: use .png, .ans or .json", ext)
+	}
+}
+
+// writeANSI writes the frame as text with 24-bit color escape sequences
+func writeANSI(path string, frame Frame) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	out := bufio.NewWriter(file)
+	for _, row := range frame.Cells {
+		var last *Cell
+		for x := 0; x < len(row); x++ {
+			cell := row[x]
+			if last == nil || cell.Fg != last.Fg || cell.Bg != last.Bg || cell.Bold != last.Bold {
+				out.WriteString(sgr(cell))
+				last = &row[x]
+			}
+
+			r := cell.Rune
+			if r == 0 {
+				r = ' '
+			}
+			out.WriteRune(r)
+
+			// Wide characters already cover the following cell
+			if cell.Width > 1 {
+				x += cell.Width - 1
+			}
+		}
+		out.WriteString("\x1b[0m\n")
+	}
+
+	return out.Flush()
+}
+
+// sgr returns the escape sequence selecting a cell's colors
+func sgr(cell Cell) string {
+	fg := toRGBA(cell.Fg, defaultForeground)
+	bg := toRGBA(cell.Bg, defaultBackground)
+
+	bold := ""
+	if cell.Bold {
+		bold = "1;"
+	}
+
+	return fmt.Sprintf("\x1b[0;%s38;2;%d;%d;%d;48;2;%d;%d;%dm", bold, fg.R, fg.G, fg.B, bg.R, bg.G, bg.B)
+}
+
+// writePNG renders the frame as a color map: every cell is painted with its
+// background, and non-blank characters get a foreground block (or a dot for
+// orbit markers) so positions and colors survive without needing a font
+func writePNG(path string, frame Frame) error {
+	img := image.NewRGBA(image.Rect(0, 0, frame.Width*cellWidth, frame.Height*cellHeight))
+
+	for y, row := range frame.Cells {
+		for x, cell := range row {
+			originX, originY := x*cellWidth, y*cellHeight
+			fillRect(img, originX, originY, cellWidth, cellHeight, toRGBA(cell.Bg, defaultBackground))
+
+			switch cell.Rune {
+			case 0, ' ':
+				continue
+			case '·', '.', '∙':
+				fillRect(img, originX+3, originY+7, 2, 2, toRGBA(cell.Fg, defaultForeground))
+			default:
+				fillRect(img, originX+1, originY+3, cellWidth-2, cellHeight-6, toRGBA(cell.Fg, defaultForeground))
+			}
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return png.Encode(file, img)
+}
+
+func writeScene(path string, scene Scene) error {
+	data, err := json.MarshalIndent(scene, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+func fillRect(img *image.RGBA, x, y, width, height int, c color.RGBA) {
+	for py := y; py < y+height; py++ {
+		for px := x; px < x+width; px++ {
+			img.SetRGBA(px, py, c)
+		}
+	}
+}
+
+// toRGBA converts a tcell color, using fallback for the terminal default
+func toRGBA(c tcell.Color, fallback color.RGBA) color.RGBA {
+	if c == tcell.ColorDefault {
+		return fallback
+	}
+	r, g, b := c.RGB()
+	if r < 0 || g < 0 || b < 0 {
+		return fallback
+	}
+	return color.RGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 255}
+}
@@ -0,0 +1,140 @@
+// Package config loads user preferences from a JSON file in the user's config
+// directory. Every setting is optional; missing ones keep their defaults.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+)
+
+// Config holds user preferences
+type Config struct {
+	// RenderMode is how orbits are drawn: "cells", "halfblock" or "braille"
+	RenderMode string `json:"render_mode,omitempty"`
+
+	// Palette is the colors the map is drawn in: "default", "deuteranopia",
+	// "protanopia" or "tritanopia". Empty means default.
+	Palette string `json:"palette,omitempty"`
+
+	// Symbols replaces the symbols of classes of body, e.g. {"gas_giant": "◍"}, for
+	// bodies with none of their own
+	Symbols map[string]string `json:"symbols,omitempty"`
+
+	// DetailFields lists the fields detail modals show, in order, by their labels
+	// ("Mass", "Orbital Period"). "compact" and "expert" stand for preset lists.
+	// Empty means every field.
+	DetailFields []string `json:"detail_fields,omitempty"`
+
+	// Units is how detail values are written: "scaled" (years, AU, Earth masses
+	// and so on) or "raw" (the days, km and kg the data gives). Empty means scaled.
+	Units string `json:"units,omitempty"`
+
+	// UserAgent replaces the User-Agent sent to the API, e.g. to add a contact
+	// address. Empty sends the app's own.
+	UserAgent string `json:"user_agent,omitempty"`
+
+	// Keys remaps actions to comma-separated key names, e.g. {"quiz": "y"}
+	Keys map[string]string `json:"keys,omitempty"`
+
+	// AspectRatio is the height-to-width ratio of a terminal cell. Zero means
+	// measure it from the terminal, falling back to 2.0.
+	AspectRatio float64 `json:"aspect_ratio,omitempty"`
+
+	// Watchlist holds the API ids of bodies checked for upstream data changes
+	Watchlist []string `json:"watchlist,omitempty"`
+
+	// Graphics is how pictures of bodies are drawn: "auto" to detect, "kitty",
+	// "sixel" or "off". Empty means auto.
+	Graphics string `json:"graphics,omitempty"`
+
+	// ImageSource is where pictures come from: "wikipedia" (the default), a URL
+	// template with {name} or {id} in it, or "off"
+	ImageSource string `json:"image_source,omitempty"`
+
+	// Analytics records which systems and bodies are looked at, and for how long,
+	// in a file next to the config for `go-solar-system stats`. Nothing is sent
+	// anywhere. Off unless set.
+	Analytics bool `json:"analytics,omitempty"`
+
+	// Store keeps every body fetched or loaded in a local database, to start and
+	// browse without the API and for `go-solar-system search` and `history`.
+	// Off unless set; --offline turns it on.
+	Store bool `json:"store,omitempty"`
+
+	// UpdateIndex is the URL of a signed manifest of curated system files to
+	// check for new and updated systems. Off unless it and UpdateKey are set.
+	UpdateIndex string `json:"update_index,omitempty"`
+
+	// UpdateKey is the base64 Ed25519 public key the manifest must be signed with
+	UpdateKey string `json:"update_key,omitempty"`
+
+	// RefreshMinutes fetches the Solar System from the API again every so many
+	// minutes while it is shown. Off unless set; at least the API cache's 10.
+	RefreshMinutes int `json:"refresh_minutes,omitempty"`
+
+	// FPS is how many frames a second the map is drawn at, up to 60, while they
+	// are cheap enough to draw. 10 unless set.
+	FPS int `json:"fps,omitempty"`
+
+	// IdleSeconds drops the map to a frame a second after this many seconds
+	// without a key, click or controller input, until the next. Off unless set.
+	IdleSeconds int `json:"idle_seconds,omitempty"`
+}
+
+// Default returns the built-in settings
+func Default() Config {
+	return Config{
+		RenderMode: "cells",
+	}
+}
+
+// DefaultPath returns the config file location inside the user's config directory
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return constants.ConfigFileName
+	}
+	return filepath.Join(dir, "go-solar-system", constants.ConfigFileName)
+}
+
+// Load reads the config file at path on top of the defaults. A missing file is
+// not an error.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Default(), fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Save writes cfg to path, creating its directory if needed
+func Save(path string, cfg Config) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write config %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadMissingFileUsesDefaults(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.RenderMode != Default().RenderMode || cfg.Keys != nil {
+		t.Errorf("Load() = %+v, want defaults", cfg)
+	}
+}
+
+func TestLoadOverridesDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"render_mode": "braille", "keys": {"quiz": "x"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.RenderMode != "braille" {
+		t.Errorf("RenderMode = %q, want braille", cfg.RenderMode)
+	}
+	if cfg.Keys["quiz"] != "x" {
+		t.Errorf("Keys = %v, want quiz remapped to x", cfg.Keys)
+	}
+}
+
+func TestLoadInvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"render_mode": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err == nil {
+		t.Fatal("Load() should fail on invalid JSON")
+	}
+	if cfg.RenderMode != Default().RenderMode {
+		t.Errorf("Load() = %+v, want defaults on error", cfg)
+	}
+}
+
+func TestSaveRoundTrips(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.json")
+	want := Config{RenderMode: "halfblock", Keys: map[string]string{"quiz": "x"}, AspectRatio: 2.25}
+
+	if err := Save(path, want); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got.RenderMode != want.RenderMode || got.Keys["quiz"] != "x" || got.AspectRatio != want.AspectRatio {
+		t.Errorf("Load() after Save() = %+v, want %+v", got, want)
+	}
+}
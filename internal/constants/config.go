@@ -8,6 +8,61 @@ import "time"
 const (
 	SolarSystemAPIBase = "https://api.le-systeme-solaire.net/rest"
 	DefaultTimeout     = 10 * time.Second
+
+	DefaultUserAgent         = "go-solar-system (+https://github.com/furan917/go-solar-system)"
+	DefaultRequestsPerSecond = 5.0
+	DefaultRequestBurst      = 10
+
+	// DefaultCacheTTL is how long successful API responses are reused
+	DefaultCacheTTL = 10 * time.Minute
+
+	// HTTPCacheDirName holds API responses kept between runs, in the user cache dir
+	HTTPCacheDirName = "http-cache"
+
+	// BodyStoreFileName is the database of fetched and loaded bodies, in the user
+	// cache dir
+	BodyStoreFileName = "bodies.db"
+
+	// ImageCacheDirName holds downloaded pictures of bodies, in the user cache dir
+	ImageCacheDirName = "images"
+)
+
+// User Configuration
+const (
+	ConfigFileName = "config.json"
+
+	// WatchFileName holds the last fetch of each watched body, next to the config
+	WatchFileName = "watch.json"
+
+	// AnalyticsFileName holds opt-in usage analytics, next to the config
+	AnalyticsFileName = "analytics.jsonl"
+
+	// WatchPollInterval is how often watched bodies are re-fetched. It is longer
+	// than DefaultCacheTTL so each check reaches the API.
+	WatchPollInterval = 30 * time.Minute
+)
+
+// System Files
+const (
+	// StreamSystemFileSize is the size from which a system file is parsed as it is
+	// read, with progress shown, rather than read into memory whole
+	StreamSystemFileSize = 8 * 1024 * 1024
+)
+
+// Logging Configuration
+const (
+	LogFileName      = "solar-system.log"
+	LogMaxSize       = 1024 * 1024
+	LogMaxBackups    = 3
+	LogPrefix        = "[SolarSystem] "
+	DebugStatsWindow = time.Second
+)
+
+// Input Recording
+const (
+	// InputRecordingsKept is how many input recordings are kept; starting another
+	// removes the oldest
+	InputRecordingsKept = 5
 )
 
 // UI Layout Constants
@@ -18,9 +73,35 @@ const (
 	ModalHeight       = 20
 	MaxVisibleItems   = 10
 
+	// WheelScrollLines is how far one step of the mouse wheel scrolls text
+	WheelScrollLines = 3
+
 	AspectRatio = 2.0
 
 	DisplayUpdateRate = 100 * time.Millisecond
+
+	// SlowestUpdateRate is as far as the frame rate is lowered when frames take
+	// longer to draw than DisplayUpdateRate allows
+	SlowestUpdateRate = 500 * time.Millisecond
+
+	// MaxFPS is the highest frame rate the fps setting can ask for
+	MaxFPS = 60
+
+	// IdleUpdateRate is how often frames are drawn once the app has gone idle
+	IdleUpdateRate = time.Second
+
+	// SimulationSpeed is simulated seconds per real second: each real
+	// second of animation covers ten days of orbital motion
+	SimulationSpeed = 864000.0
+
+	// TimelineYears is how far either side of today the time scrubber reaches
+	TimelineYears = 20
+)
+
+// Physical Constants
+const (
+	// AstronomicalUnit is the mean Earth-Sun distance in km
+	AstronomicalUnit = 149597870.7
 )
 
 // Modal position enumeration
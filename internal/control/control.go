@@ -0,0 +1,98 @@
+// Package control reads the automation commands given with --control: one
+// command per line, such as "select Mars" or "screenshot out.png", so demos,
+// integration tests and kiosks can drive the app without a keyboard.
+package control
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Name is a command's name, the first word of its line
+type Name string
+
+const (
+	Select       Name = "select"        // select <body>: select a body and show its details
+	OpenMoons    Name = "open-moons"    // list the selected body's moons
+	SwitchSystem Name = "switch-system" // switch-system <system>: load another system
+	Screenshot   Name = "screenshot"    // screenshot [file]: a bundle, or one .png, .ans or .json file
+	Close        Name = "close"         // close every open modal
+	Wait         Name = "wait"          // wait <duration>: pause before the next command, e.g. 2s
+	Quit         Name = "quit"
+)
+
+// commands says which commands exist and whether their argument is required,
+// optional or not allowed
+var commands = map[Name]argument{
+	Select:       required,
+	OpenMoons:    none,
+	SwitchSystem: required,
+	Screenshot:   optional,
+	Close:        none,
+	Wait:         required,
+	Quit:         none,
+}
+
+type argument int
+
+const (
+	none argument = iota
+	optional
+	required
+)
+
+// Command is one parsed line
+type Command struct {
+	Name Name
+	Arg  string // everything after the name, so body names may contain spaces
+}
+
+// String writes the command back as a line
+func (c Command) String() string {
+	if c.Arg == "" {
+		return string(c.Name)
+	}
+	return string(c.Name) + " " + c.Arg
+}
+
+// Duration is the pause a wait command asks for
+func (c Command) Duration() (time.Duration, error) {
+	d, err := time.ParseDuration(c.Arg)
+	if err != nil {
+		return 0, fmt.Errorf("wait: %w", err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("wait: negative duration %s", c.Arg)
+	}
+	return d, nil
+}
+
+// Parse reads one line. Blank lines and lines starting with # are not commands:
+// ok is false for them and err is nil.
+func Parse(line string) (command Command, ok bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return Command{}, false, nil
+	}
+
+	name, arg, _ := strings.Cut(line, " ")
+	command = Command{Name: Name(strings.ToLower(name)), Arg: strings.TrimSpace(arg)}
+
+	kind, known := commands[command.Name]
+	switch {
+	case !known:
+		return Command{}, false, fmt.Errorf("unknown command %q", name)
+	case kind == required && command.Arg == "":
+		return Command{}, false, fmt.Errorf("%s needs an argument", command.Name)
+	case kind == none && command.Arg != "":
+		return Command{}, false, fmt.Errorf("%s takes no argument", command.Name)
+	}
+
+	if command.Name == Wait {
+		if _, err := command.Duration(); err != nil {
+			return Command{}, false, err
+		}
+	}
+	return command, true, nil
+}
@@ -0,0 +1,75 @@
+package control
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		line string
+		want Command
+	}{
+		{"select Mars", Command{Name: Select, Arg: "Mars"}},
+		{"  select   Halley's Comet  ", Command{Name: Select, Arg: "Halley's Comet"}},
+		{"SELECT mars", Command{Name: Select, Arg: "mars"}},
+		{"open-moons", Command{Name: OpenMoons}},
+		{"switch-system kepler-90", Command{Name: SwitchSystem, Arg: "kepler-90"}},
+		{"screenshot", Command{Name: Screenshot}},
+		{"screenshot out.png", Command{Name: Screenshot, Arg: "out.png"}},
+		{"wait 1.5s", Command{Name: Wait, Arg: "1.5s"}},
+		{"quit", Command{Name: Quit}},
+	}
+
+	for _, tt := range tests {
+		got, ok, err := Parse(tt.line)
+		if err != nil || !ok {
+			t.Errorf("Parse(%q) = %v, %v, %v", tt.line, got, ok, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestParseSkipsBlankLinesAndComments(t *testing.T) {
+	for _, line := range []string{"", "   ", "# select Mars"} {
+		if _, ok, err := Parse(line); ok || err != nil {
+			t.Errorf("Parse(%q) = %v, %v; want not a command", line, ok, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, line := range []string{
+		"teleport Mars",
+		"select",
+		"switch-system",
+		"open-moons Mars",
+		"quit now",
+		"wait",
+		"wait soon",
+		"wait -1s",
+	} {
+		if _, ok, err := Parse(line); ok || err == nil {
+			t.Errorf("Parse(%q) = %v, %v; want an error", line, ok, err)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	command, _, _ := Parse("wait 250ms")
+	if d, err := command.Duration(); err != nil || d != 250*time.Millisecond {
+		t.Errorf("Duration() = %v, %v; want 250ms", d, err)
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, line := range []string{"select Mars", "close"} {
+		command, _, _ := Parse(line)
+		if command.String() != line {
+			t.Errorf("String() = %q, want %q", command.String(), line)
+		}
+	}
+}
@@ -4,22 +4,30 @@ package display
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
 )
 
 // FieldConfig defines how to display a specific field of a celestial body
 type FieldConfig struct {
 	Label     string
+	Field     string // JSON name of the body field the value comes from
 	Format    string
 	Unit      string
 	Condition func(models.CelestialBody) bool
 	Value     func(models.CelestialBody) interface{}
+
+	// Scaled writes the value in readable units, for fields whose raw values run
+	// long; nil always writes it with Format and Unit
+	Scaled func(float64) string
 }
 
 // StringFieldConfig defines how to display string fields of a celestial body
 type StringFieldConfig struct {
 	Label     string
+	Field     string // JSON name of the body field the value comes from
 	Condition func(models.CelestialBody) bool
 	Value     func(models.CelestialBody) string
 }
@@ -30,6 +38,7 @@ func GetCelestialBodyFields() []FieldConfig {
 	return []FieldConfig{
 		{
 			Label:     "Mean Radius",
+			Field:     "meanRadius",
 			Format:    "%.0f",
 			Unit:      "km",
 			Condition: func(cb models.CelestialBody) bool { return cb.MeanRadius > 0 },
@@ -37,13 +46,16 @@ func GetCelestialBodyFields() []FieldConfig {
 		},
 		{
 			Label:     "Mass",
+			Field:     "mass",
 			Format:    "%.2e",
 			Unit:      "kg",
 			Condition: func(cb models.CelestialBody) bool { return cb.GetMassKg() > 0 },
 			Value:     func(cb models.CelestialBody) interface{} { return cb.GetMassKg() },
+			Scaled:    scaleMass,
 		},
 		{
 			Label:     "Density",
+			Field:     "density",
 			Format:    "%.2f",
 			Unit:      "g/cm³",
 			Condition: func(cb models.CelestialBody) bool { return cb.Density > 0 },
@@ -51,6 +63,7 @@ func GetCelestialBodyFields() []FieldConfig {
 		},
 		{
 			Label:     "Volume",
+			Field:     "vol",
 			Format:    "%.2e",
 			Unit:      "km³",
 			Condition: func(cb models.CelestialBody) bool { return cb.GetVolumeKm3() > 0 },
@@ -58,6 +71,7 @@ func GetCelestialBodyFields() []FieldConfig {
 		},
 		{
 			Label:     "Gravity",
+			Field:     "gravity",
 			Format:    "%.2f",
 			Unit:      "m/s²",
 			Condition: func(cb models.CelestialBody) bool { return cb.Gravity > 0 },
@@ -65,6 +79,7 @@ func GetCelestialBodyFields() []FieldConfig {
 		},
 		{
 			Label:     "Escape Velocity",
+			Field:     "escape",
 			Format:    "%.2f",
 			Unit:      "km/s",
 			Condition: func(cb models.CelestialBody) bool { return cb.Escape > 0 },
@@ -72,6 +87,7 @@ func GetCelestialBodyFields() []FieldConfig {
 		},
 		{
 			Label:     "Equatorial Radius",
+			Field:     "equaRadius",
 			Format:    "%.0f",
 			Unit:      "km",
 			Condition: func(cb models.CelestialBody) bool { return cb.EquaRadius > 0 },
@@ -79,6 +95,7 @@ func GetCelestialBodyFields() []FieldConfig {
 		},
 		{
 			Label:     "Polar Radius",
+			Field:     "polarRadius",
 			Format:    "%.0f",
 			Unit:      "km",
 			Condition: func(cb models.CelestialBody) bool { return cb.PolarRadius > 0 },
@@ -86,6 +103,7 @@ func GetCelestialBodyFields() []FieldConfig {
 		},
 		{
 			Label:     "Flattening",
+			Field:     "flattening",
 			Format:    "%.6f",
 			Unit:      "",
 			Condition: func(cb models.CelestialBody) bool { return cb.Flattening > 0 },
@@ -93,34 +111,89 @@ func GetCelestialBodyFields() []FieldConfig {
 		},
 		{
 			Label:     "Distance from Sun",
+			Field:     "semimajorAxis",
 			Format:    "%.0f",
 			Unit:      "km",
 			Condition: func(cb models.CelestialBody) bool { return cb.SemimajorAxis > 0 },
 			Value:     func(cb models.CelestialBody) interface{} { return cb.SemimajorAxis },
+			Scaled:    scaleDistance,
 		},
 		{
 			Label:     "Orbital Period",
+			Field:     "sideralOrbit",
 			Format:    "%.2f",
 			Unit:      "days",
 			Condition: func(cb models.CelestialBody) bool { return cb.SideralOrbit > 0 },
 			Value:     func(cb models.CelestialBody) interface{} { return cb.SideralOrbit },
+			Scaled:    scaleDays,
+		},
+		{
+			Label:     "Specific Orbital Energy",
+			Field:     "semimajorAxis",
+			Format:    "%.2f",
+			Unit:      "MJ/kg",
+			Condition: hasOrbit,
+			Value: func(cb models.CelestialBody) interface{} {
+				mu, _ := orbital.OrbitParameter(cb)
+				return orbital.SpecificOrbitalEnergy(mu, cb.SemimajorAxis)
+			},
+		},
+		{
+			Label:     "Specific Angular Momentum",
+			Field:     "semimajorAxis",
+			Format:    "%.3e",
+			Unit:      "km²/s",
+			Condition: hasOrbit,
+			Value: func(cb models.CelestialBody) interface{} {
+				mu, _ := orbital.OrbitParameter(cb)
+				return orbital.SpecificAngularMomentum(mu, cb.SemimajorAxis, orbital.OrbitEccentricity(cb))
+			},
+		},
+		{
+			Label:     "Hill Sphere",
+			Field:     "mass",
+			Format:    "%.0f",
+			Unit:      "km",
+			Condition: hasHillSphere,
+			Value: func(cb models.CelestialBody) interface{} {
+				radius, _ := orbital.BodyHillRadius(cb)
+				return radius
+			},
+			Scaled: scaleDistance,
+		},
+		{
+			Label:     "Roche Limit",
+			Field:     "meanRadius",
+			Format:    "%.0f",
+			Unit:      "km",
+			Condition: hasRocheLimit,
+			Value: func(cb models.CelestialBody) interface{} {
+				limit, _ := orbital.BodyRocheLimit(cb)
+				return limit
+			},
+			Scaled: scaleDistance,
 		},
 		{
 			Label:     "Perihelion",
+			Field:     "perihelion",
 			Format:    "%.0f",
 			Unit:      "km",
 			Condition: func(cb models.CelestialBody) bool { return cb.Perihelion > 0 },
 			Value:     func(cb models.CelestialBody) interface{} { return cb.Perihelion },
+			Scaled:    scaleDistance,
 		},
 		{
 			Label:     "Aphelion",
+			Field:     "aphelion",
 			Format:    "%.0f",
 			Unit:      "km",
 			Condition: func(cb models.CelestialBody) bool { return cb.Aphelion > 0 },
 			Value:     func(cb models.CelestialBody) interface{} { return cb.Aphelion },
+			Scaled:    scaleDistance,
 		},
 		{
 			Label:     "Orbital Eccentricity",
+			Field:     "eccentricity",
 			Format:    "%.6f",
 			Unit:      "",
 			Condition: func(cb models.CelestialBody) bool { return cb.Eccentricity > 0 },
@@ -128,6 +201,7 @@ func GetCelestialBodyFields() []FieldConfig {
 		},
 		{
 			Label:     "Orbital Inclination",
+			Field:     "inclination",
 			Format:    "%.2f",
 			Unit:      "degrees",
 			Condition: func(cb models.CelestialBody) bool { return cb.Inclination != 0 },
@@ -135,53 +209,189 @@ func GetCelestialBodyFields() []FieldConfig {
 		},
 		{
 			Label:     "Rotation Period",
+			Field:     "sideralRotation",
 			Format:    "%.2f",
 			Unit:      "hours",
 			Condition: func(cb models.CelestialBody) bool { return cb.SideralRotation != 0 },
 			Value:     func(cb models.CelestialBody) interface{} { return cb.SideralRotation },
 		},
+		{
+			Label:     "Axial Tilt",
+			Field:     "axialTilt",
+			Format:    "%.2f",
+			Unit:      "degrees",
+			Condition: func(cb models.CelestialBody) bool { return cb.AxialTilt != 0 },
+			Value:     func(cb models.CelestialBody) interface{} { return cb.AxialTilt },
+		},
+		{
+			Label:     "Average Temperature",
+			Field:     "avgTemp",
+			Format:    "%.0f",
+			Unit:      "K",
+			Condition: func(cb models.CelestialBody) bool { return cb.AvgTemp > 0 },
+			Value:     func(cb models.CelestialBody) interface{} { return cb.AvgTemp },
+		},
+		{
+			Label:     "Effective Temperature",
+			Field:     "temperature",
+			Format:    "%.0f",
+			Unit:      "K",
+			Condition: func(cb models.CelestialBody) bool { return cb.Temperature > 0 },
+			Value:     func(cb models.CelestialBody) interface{} { return cb.Temperature },
+		},
+		{
+			Label:     "Luminosity",
+			Field:     "luminosity",
+			Format:    "%s",
+			Condition: hasLuminosity,
+			Value: func(cb models.CelestialBody) interface{} {
+				luminosity, derived := cb.GetLuminosity()
+				if derived {
+					return fmt.Sprintf("%.3g L☉ (from temperature and radius)", luminosity)
+				}
+				return fmt.Sprintf("%.3g L☉", luminosity)
+			},
+		},
+		{
+			Label:     "Habitable Zone",
+			Field:     "luminosity",
+			Format:    "%s",
+			Condition: hasLuminosity,
+			Value: func(cb models.CelestialBody) interface{} {
+				inner, outer, _ := cb.HabitableZone()
+				return fmt.Sprintf("%.3g-%.3g AU", inner, outer)
+			},
+		},
+		{
+			Label:     "Metallicity",
+			Field:     "metallicity",
+			Format:    "%+.2f",
+			Unit:      "dex [Fe/H]",
+			Condition: func(cb models.CelestialBody) bool { return cb.Metallicity != nil },
+			Value:     func(cb models.CelestialBody) interface{} { return *cb.Metallicity },
+		},
+		{
+			Label:     "Age",
+			Field:     "age",
+			Format:    "%.2f",
+			Unit:      "billion years",
+			Condition: func(cb models.CelestialBody) bool { return cb.Age > 0 },
+			Value:     func(cb models.CelestialBody) interface{} { return cb.Age / 1e9 },
+		},
+		{
+			Label:     "Mean Anomaly at Epoch",
+			Field:     "mainAnomaly",
+			Format:    "%.2f",
+			Unit:      "degrees",
+			Condition: func(cb models.CelestialBody) bool { return cb.OrbitalElements != nil },
+			Value:     func(cb models.CelestialBody) interface{} { return cb.OrbitalElements.MeanAnomaly },
+		},
+		{
+			Label:     "Argument of Periapsis",
+			Field:     "argPeriapsis",
+			Format:    "%.2f",
+			Unit:      "degrees",
+			Condition: func(cb models.CelestialBody) bool { return cb.OrbitalElements != nil },
+			Value:     func(cb models.CelestialBody) interface{} { return cb.OrbitalElements.ArgumentOfPeriapsis },
+		},
+		{
+			Label:     "Longitude of Ascending Node",
+			Field:     "longAscNode",
+			Format:    "%.2f",
+			Unit:      "degrees",
+			Condition: func(cb models.CelestialBody) bool { return cb.OrbitalElements != nil },
+			Value:     func(cb models.CelestialBody) interface{} { return cb.OrbitalElements.LongitudeOfAscendingNode },
+		},
 	}
 }
 
+// hasOrbit reports whether a body has the semi-major axis and period its derived
+// orbital quantities are worked out from
+func hasOrbit(cb models.CelestialBody) bool {
+	_, ok := orbital.OrbitParameter(cb)
+	return ok
+}
+
+// hasHillSphere reports whether a body's mass and orbit give its Hill sphere
+func hasHillSphere(cb models.CelestialBody) bool {
+	_, ok := orbital.BodyHillRadius(cb)
+	return ok
+}
+
+// hasRocheLimit reports whether a body's radius and density, or mass, give its
+// Roche limit
+func hasRocheLimit(cb models.CelestialBody) bool {
+	_, ok := orbital.BodyRocheLimit(cb)
+	return ok
+}
+
+// hasLuminosity reports whether a body is a star whose luminosity is given or
+// can be worked out, for it and its habitable zone
+func hasLuminosity(cb models.CelestialBody) bool {
+	luminosity, _ := cb.GetLuminosity()
+	return luminosity > 0
+}
+
 // GetCelestialBodyStringFields returns the standardized string field configurations
 // for displaying celestial body text data across the application
 func GetCelestialBodyStringFields() []StringFieldConfig {
 	return []StringFieldConfig{
 		{
 			Label:     "Type",
+			Field:     "bodyType",
 			Condition: func(cb models.CelestialBody) bool { return cb.BodyType != "" },
 			Value:     func(cb models.CelestialBody) string { return cb.BodyType },
 		},
+		{
+			Label:     "Spectral Type",
+			Field:     "spectralType",
+			Condition: func(cb models.CelestialBody) bool { return cb.GetSpectralType() != "" },
+			Value:     func(cb models.CelestialBody) string { return cb.GetSpectralType() },
+		},
 		{
 			Label:     "Discovered By",
+			Field:     "discoveredBy",
 			Condition: func(cb models.CelestialBody) bool { return cb.DiscoveredBy != "" },
 			Value:     func(cb models.CelestialBody) string { return cb.DiscoveredBy },
 		},
 		{
 			Label:     "Discovery Date",
+			Field:     "discoveryDate",
 			Condition: func(cb models.CelestialBody) bool { return cb.DiscoveryDate != "" },
 			Value:     func(cb models.CelestialBody) string { return cb.DiscoveryDate },
 		},
 		{
-			Label:     "Alternative Name",
-			Condition: func(cb models.CelestialBody) bool { return cb.AlternativeName != "" },
-			Value:     func(cb models.CelestialBody) string { return cb.AlternativeName },
+			Label:     "Also Known As",
+			Field:     "alternativeName",
+			Condition: func(cb models.CelestialBody) bool { return len(cb.OtherNames()) > 0 },
+			Value:     func(cb models.CelestialBody) string { return strings.Join(cb.OtherNames(), ", ") },
 		},
 		{
 			Label:     "Dimension",
+			Field:     "dimension",
 			Condition: func(cb models.CelestialBody) bool { return cb.Dimension != "" },
 			Value:     func(cb models.CelestialBody) string { return cb.Dimension },
 		},
 	}
 }
 
-// FormatFieldValue formats a field value according to its configuration
+// FormatFieldValue formats a field value according to its configuration, in the
+// units the data gives it
 func (fc FieldConfig) FormatFieldValue(body models.CelestialBody) string {
+	return fc.FormatFieldValueIn(body, UnitsRaw)
+}
+
+// FormatFieldValueIn formats a field value according to its configuration, scaled
+// to readable units if units asks for that and the field has them
+func (fc FieldConfig) FormatFieldValueIn(body models.CelestialBody, units Units) string {
 	if !fc.Condition(body) {
 		return ""
 	}
 
 	value := fc.Value(body)
+	if number, ok := value.(float64); ok && units == UnitsScaled && fc.Scaled != nil {
+		return fmt.Sprintf("%s: %s", fc.Label, fc.Scaled(number))
+	}
 	if fc.Unit != "" {
 		return fmt.Sprintf("%s: %s %s", fc.Label, fmt.Sprintf(fc.Format, value), fc.Unit)
 	}
@@ -195,3 +405,34 @@ func (sfc StringFieldConfig) FormatStringFieldValue(body models.CelestialBody) s
 	}
 	return fmt.Sprintf("%s: %s", sfc.Label, sfc.Value(body))
 }
+
+// HasMixedSources reports whether a body's values came from more than one place,
+// in which case each value is shown with its source's tag
+func HasMixedSources(body models.CelestialBody) bool {
+	return len(body.Sources()) > 1
+}
+
+// SourceNotes describes where a body's values came from, as a footer for its
+// details: one line naming the source, or one per tag when the sources are mixed
+func SourceNotes(body models.CelestialBody) []string {
+	sources := body.Sources()
+	if len(sources) == 0 {
+		return nil
+	}
+
+	describe := func(source models.Source) string {
+		if source == body.Provenance.Source && body.Provenance.Citation != "" {
+			return fmt.Sprintf("%s (%s)", source.Label(), body.Provenance.Citation)
+		}
+		return source.Label()
+	}
+
+	if len(sources) == 1 {
+		return []string{"Source: " + describe(sources[0])}
+	}
+	notes := make([]string, 0, len(sources))
+	for _, source := range sources {
+		notes = append(notes, source.Tag()+" "+describe(source))
+	}
+	return notes
+}
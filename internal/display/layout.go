@@ -0,0 +1,145 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// DetailLine is one line of a body's details and the field it shows
+type DetailLine struct {
+	Field string // JSON name of the body field, for its source tag
+	Text  string
+}
+
+// detailField is a string or numeric field, so both kinds can be ordered together
+type detailField struct {
+	label     string
+	field     string
+	condition func(models.CelestialBody) bool
+	format    func(models.CelestialBody, Units) string
+}
+
+// fieldPresets are layouts named in place of labels: compact keeps the few numbers
+// worth reading out in a classroom, expert puts the orbital elements first
+var fieldPresets = map[string][]string{
+	"compact": {
+		"Type", "Mean Radius", "Gravity", "Distance from Sun",
+		"Orbital Period", "Rotation Period", "Average Temperature",
+	},
+	"expert": {
+		"Orbital Eccentricity", "Orbital Inclination", "Mean Anomaly at Epoch",
+		"Argument of Periapsis", "Longitude of Ascending Node", "Perihelion",
+		"Aphelion", "Distance from Sun", "Orbital Period", "Specific Orbital Energy",
+		"Specific Angular Momentum", "Hill Sphere", "Roche Limit", "Axial Tilt",
+		"Rotation Period", "Flattening", "Equatorial Radius", "Polar Radius",
+		"Mean Radius", "Mass", "Density", "Volume", "Gravity", "Escape Velocity",
+		"Average Temperature", "Spectral Type", "Effective Temperature",
+		"Luminosity", "Habitable Zone", "Metallicity", "Age", "Dimension", "Type",
+		"Also Known As", "Discovered By", "Discovery Date",
+	},
+}
+
+// FieldLayout picks which detail fields are shown, in what order and in what
+// units. The zero value shows every field, the string fields then the numeric
+// ones, scaled to readable units.
+type FieldLayout struct {
+	fields []detailField
+	units  Units
+}
+
+// allDetailFields lists every field in the default order
+func allDetailFields() []detailField {
+	var fields []detailField
+	for _, sfc := range GetCelestialBodyStringFields() {
+		format := sfc.FormatStringFieldValue
+		fields = append(fields, detailField{sfc.Label, sfc.Field, sfc.Condition, func(body models.CelestialBody, _ Units) string { return format(body) }})
+	}
+	for _, fc := range GetCelestialBodyFields() {
+		fields = append(fields, detailField{fc.Label, fc.Field, fc.Condition, fc.FormatFieldValueIn})
+	}
+	return fields
+}
+
+// ParseFieldLayout builds a layout from field labels, matched without regard to
+// case, and preset names ("compact", "expert"), which stand for their fields.
+// Unknown names and repeats are left out and reported; no names means every field.
+func ParseFieldLayout(names []string) (FieldLayout, []error) {
+	if len(names) == 0 {
+		return FieldLayout{}, nil
+	}
+
+	byLabel := make(map[string]detailField)
+	for _, field := range allDetailFields() {
+		byLabel[strings.ToLower(field.label)] = field
+	}
+
+	var layout FieldLayout
+	var errs []error
+	seen := make(map[string]bool)
+	add := func(name string) {
+		key := strings.ToLower(strings.TrimSpace(name))
+		field, ok := byLabel[key]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("unknown detail field %q", name))
+		case seen[key]:
+			errs = append(errs, fmt.Errorf("detail field %q listed twice", name))
+		default:
+			seen[key] = true
+			layout.fields = append(layout.fields, field)
+		}
+	}
+	for _, name := range names {
+		if preset, ok := fieldPresets[strings.ToLower(strings.TrimSpace(name))]; ok {
+			for _, label := range preset {
+				if !seen[strings.ToLower(label)] {
+					add(label)
+				}
+			}
+			continue
+		}
+		add(name)
+	}
+	return layout, errs
+}
+
+// WithUnits returns the layout writing values in units
+func (l FieldLayout) WithUnits(units Units) FieldLayout {
+	l.units = units
+	return l
+}
+
+// Units returns the units the layout writes values in
+func (l FieldLayout) Units() Units {
+	return l.units
+}
+
+// Labels returns the labels of the fields the layout shows, in order
+func (l FieldLayout) Labels() []string {
+	var labels []string
+	for _, field := range l.all() {
+		labels = append(labels, field.label)
+	}
+	return labels
+}
+
+// Lines returns the details the layout shows for a body, skipping fields it has
+// no value for
+func (l FieldLayout) Lines(body models.CelestialBody) []DetailLine {
+	var lines []DetailLine
+	for _, field := range l.all() {
+		if field.condition(body) {
+			lines = append(lines, DetailLine{Field: field.field, Text: field.format(body, l.units)})
+		}
+	}
+	return lines
+}
+
+func (l FieldLayout) all() []detailField {
+	if l.fields == nil {
+		return allDetailFields()
+	}
+	return l.fields
+}
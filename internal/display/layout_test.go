@@ -0,0 +1,75 @@
+package display
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestParseFieldLayoutOrdersByLabel(t *testing.T) {
+	layout, errs := ParseFieldLayout([]string{"orbital period", "Type", "Nonsense", "Type"})
+	if len(errs) != 2 {
+		t.Errorf("errors = %v, want one unknown and one repeat", errs)
+	}
+	if got, want := layout.Labels(), []string{"Orbital Period", "Type"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("labels = %v, want %v", got, want)
+	}
+
+	body := models.CelestialBody{BodyType: "Planet", SideralOrbit: 687, Density: 3.9}
+	lines := layout.Lines(body)
+	if len(lines) != 2 || lines[0].Text != "Orbital Period: 687.00 days" || lines[1].Field != "bodyType" {
+		t.Errorf("lines = %+v", lines)
+	}
+}
+
+func TestFieldLayoutPresets(t *testing.T) {
+	for name := range fieldPresets {
+		if _, errs := ParseFieldLayout([]string{name}); len(errs) > 0 {
+			t.Errorf("preset %s: %v", name, errs)
+		}
+	}
+
+	expert, _ := ParseFieldLayout([]string{"expert"})
+	if got, want := len(expert.Labels()), len(allDetailFields()); got != want {
+		t.Errorf("expert shows %d fields, want all %d", got, want)
+	}
+
+	mixed, errs := ParseFieldLayout([]string{"Discovered By", "compact"})
+	if len(errs) > 0 || mixed.Labels()[0] != "Discovered By" || len(mixed.Labels()) != len(fieldPresets["compact"])+1 {
+		t.Errorf("mixed = %v, %v", mixed.Labels(), errs)
+	}
+}
+
+func TestDefaultFieldLayoutShowsEverything(t *testing.T) {
+	var layout FieldLayout
+	labels := layout.Labels()
+	if len(labels) != len(allDetailFields()) || labels[0] != "Type" {
+		t.Errorf("default labels = %v", labels)
+	}
+}
+
+func TestStarDetailFields(t *testing.T) {
+	layout, _ := ParseFieldLayout([]string{"Spectral Type", "Luminosity", "Habitable Zone", "Metallicity", "Age"})
+	metallicity := 0.0
+	star := models.CelestialBody{StellarClass: "M8V", Temperature: 2566, MeanRadius: 84180, Metallicity: &metallicity, Age: 7.6e9}
+
+	var texts []string
+	for _, line := range layout.Lines(star) {
+		texts = append(texts, line.Text)
+	}
+	want := []string{
+		"Spectral Type: M8V",
+		"Luminosity: 0.000572 L☉ (from temperature and radius)",
+		"Habitable Zone: 0.0228-0.0328 AU",
+		"Metallicity: +0.00 dex [Fe/H]",
+		"Age: 7.60 billion years",
+	}
+	if !reflect.DeepEqual(texts, want) {
+		t.Errorf("star details = %q, want %q", texts, want)
+	}
+
+	if lines := layout.Lines(models.CelestialBody{EnglishName: "Rogue", BodyType: "Planet"}); len(lines) != 0 {
+		t.Errorf("Expected no star fields for a planet, got %+v", lines)
+	}
+}
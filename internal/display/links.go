@@ -0,0 +1,37 @@
+package display
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// wikipediaSearch finds an article on the English Wikipedia; searching lets the
+// body type tell Mercury the planet from the metal
+const wikipediaSearch = "https://en.wikipedia.org/w/index.php?search="
+
+// Link is a web page about a body, shown as a hyperlink where the terminal
+// supports them and as plain text elsewhere
+type Link struct {
+	Label string
+	URL   string
+}
+
+// Links returns the pages about a body: its API entry when it came from the
+// API, and Wikipedia
+func Links(body models.CelestialBody) []Link {
+	var links []Link
+	if citation := body.Provenance.Citation; body.Provenance.Source == models.SourceAPI && strings.HasPrefix(citation, "http") {
+		links = append(links, Link{Label: "API page", URL: citation})
+	}
+
+	if body.EnglishName != "" {
+		query := body.EnglishName
+		if body.BodyType != "" && !strings.EqualFold(body.BodyType, body.EnglishName) {
+			query += " " + strings.ToLower(body.BodyType)
+		}
+		links = append(links, Link{Label: "Wikipedia", URL: wikipediaSearch + url.QueryEscape(query)})
+	}
+	return links
+}
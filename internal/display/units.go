@@ -0,0 +1,114 @@
+package display
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+)
+
+// Units is how detail values are written out
+type Units int
+
+const (
+	// UnitsScaled writes long periods in years, orbital distances in AU and light
+	// travel time, and masses against the Earth, Jupiter or the Sun
+	UnitsScaled Units = iota
+	// UnitsRaw writes values in the units the data gives them: days, km and kg
+	UnitsRaw
+)
+
+const (
+	earthMassKg   = 5.97237e24
+	jupiterMassKg = 1.89813e27
+	solarMassKg   = 1.98847e30
+
+	lightKmPerSecond = 299792.458
+	lightKmPerMinute = lightKmPerSecond * 60
+	daysPerYear      = 365.25
+)
+
+// ParseUnits reads the units setting: "scaled" (the default) or "raw"
+func ParseUnits(name string) (Units, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "scaled":
+		return UnitsScaled, nil
+	case "raw":
+		return UnitsRaw, nil
+	}
+	return UnitsScaled, fmt.Errorf("unknown units %q, want scaled or raw", name)
+}
+
+// Other returns the units details are not being shown in, to switch to
+func (u Units) Other() Units {
+	if u == UnitsRaw {
+		return UnitsScaled
+	}
+	return UnitsRaw
+}
+
+// String returns the setting's name for the units
+func (u Units) String() string {
+	if u == UnitsRaw {
+		return "raw"
+	}
+	return "scaled"
+}
+
+// scaleDays writes a period of days in hours when under a day and in years from
+// two years up
+func scaleDays(days float64) string {
+	switch {
+	case days < 1:
+		return fmt.Sprintf("%.1f hours", days*24)
+	case days < 2*daysPerYear:
+		return fmt.Sprintf("%.2f days", days)
+	}
+	return fmt.Sprintf("%.2f years", days/daysPerYear)
+}
+
+// scaleDistance writes an orbital distance in km in AU and how long light takes
+// to cross it, leaving distances under a hundredth of an AU, such as a moon's, in km
+func scaleDistance(km float64) string {
+	if km < 0.01*constants.AstronomicalUnit {
+		return fmt.Sprintf("%.0f km", km)
+	}
+	au := fmt.Sprintf("%.3f AU", km/constants.AstronomicalUnit)
+	minutes := km / lightKmPerMinute
+	if minutes < 120 {
+		return fmt.Sprintf("%s (%.1f light-minutes)", au, minutes)
+	}
+	return fmt.Sprintf("%s (%.1f light-hours)", au, minutes/60)
+}
+
+// LightTime writes how long light takes to cross a distance in km: in seconds
+// under a minute, minutes and seconds under an hour, and hours and minutes above
+func LightTime(km float64) string {
+	seconds := km / lightKmPerSecond
+	if seconds < 60 {
+		return fmt.Sprintf("%.1f s", seconds)
+	}
+	whole := int(math.Round(seconds))
+	if whole < 3600 {
+		return fmt.Sprintf("%d min %02d s", whole/60, whole%60)
+	}
+	return fmt.Sprintf("%d h %02d min", whole/3600, whole%3600/60)
+}
+
+// scaleMass writes a mass in kg against the Sun for stars and brown dwarfs, Jupiter
+// for gas giants, the Earth for smaller planets, and in kg for small bodies
+func scaleMass(kg float64) string {
+	switch {
+	case kg >= 0.01*solarMassKg:
+		return fmt.Sprintf("%.3f solar masses", kg/solarMassKg)
+	case kg >= 0.1*jupiterMassKg:
+		return fmt.Sprintf("%.3f Jupiter masses", kg/jupiterMassKg)
+	case kg >= 0.001*earthMassKg:
+		if earths := fmt.Sprintf("%.4g", kg/earthMassKg); earths != "1" {
+			return earths + " Earth masses"
+		}
+		return "1 Earth mass"
+	}
+	return fmt.Sprintf("%.2e kg", kg)
+}
@@ -0,0 +1,56 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestScaledUnits(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Neptune's year", scaleDays(60190.03), "164.79 years"},
+		{"Mars's year", scaleDays(686.98), "686.98 days"},
+		{"Phobos's orbit", scaleDays(0.319), "7.7 hours"},
+		{"Earth's distance", scaleDistance(149598262), "1.000 AU (8.3 light-minutes)"},
+		{"Neptune's distance", scaleDistance(4498396441), "30.070 AU (4.2 light-hours)"},
+		{"the Moon's distance", scaleDistance(384400), "384400 km"},
+		{"the Sun's mass", scaleMass(1.989e30), "1.000 solar masses"},
+		{"Jupiter's mass", scaleMass(1.898e27), "1.000 Jupiter masses"},
+		{"Earth's mass", scaleMass(5.97237e24), "1 Earth mass"},
+		{"Neptune's mass", scaleMass(1.024e26), "17.15 Earth masses"},
+		{"Phobos's mass", scaleMass(1.06e16), "1.06e+16 kg"},
+		{"light to the Moon", LightTime(384400), "1.3 s"},
+		{"light to the Earth", LightTime(149598262), "8 min 19 s"},
+		{"light to Neptune", LightTime(4498396441), "4 h 10 min"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestFieldLayoutUnits(t *testing.T) {
+	neptune := models.CelestialBody{SideralOrbit: 60190.03, Mass: models.Mass{MassValue: 1.024, MassExponent: 26}}
+	layout, _ := ParseFieldLayout([]string{"Orbital Period", "Mass"})
+
+	scaled := layout.Lines(neptune)
+	if scaled[0].Text != "Orbital Period: 164.79 years" || scaled[1].Text != "Mass: 17.15 Earth masses" {
+		t.Errorf("scaled lines = %+v", scaled)
+	}
+	raw := layout.WithUnits(UnitsRaw).Lines(neptune)
+	if raw[0].Text != "Orbital Period: 60190.03 days" || raw[1].Text != "Mass: 1.02e+26 kg" {
+		t.Errorf("raw lines = %+v", raw)
+	}
+
+	if units, err := ParseUnits("RAW"); err != nil || units != UnitsRaw || units.Other() != UnitsScaled {
+		t.Errorf("ParseUnits(RAW) = %v, %v", units, err)
+	}
+	if _, err := ParseUnits("imperial"); err == nil {
+		t.Error("ParseUnits(imperial) accepted an unknown setting")
+	}
+}
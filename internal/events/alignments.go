@@ -0,0 +1,145 @@
+package events
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// maxAlignmentSamples bounds the work of one alignment search; orbits of a few days
+// would otherwise take millions of samples to cover a century
+const maxAlignmentSamples = 50000
+
+// Alignment is a time the chosen bodies gather in one part of the sky, at their
+// closest while they stay within the threshold
+type Alignment struct {
+	Time       time.Time
+	Bodies     []string
+	Separation float64 // radians: the narrowest arc of longitude holding every body
+	Observer   string  // body they are seen from; empty when seen from the star
+}
+
+// FindAlignments returns the first limit alignments of the named bodies in
+// [from, to], in time order, and how far it searched: to, or sooner when the
+// bodies orbit too fast to sample that far. The bodies count as aligned while their
+// longitudes fit in an arc no wider than threshold radians. They are seen from
+// DefaultObserver when it is in the system but not among them, and from the star
+// otherwise.
+func (e *Engine) FindAlignments(bodies []models.CelestialBody, names []string, threshold float64, from, to time.Time, limit int) ([]Alignment, time.Time) {
+	planets := orbitingBodies(bodies)
+	var chosen []models.CelestialBody
+	var chosenNames []string
+	for _, name := range names {
+		if body, ok := findBody(planets, name); ok {
+			chosen = append(chosen, body)
+			chosenNames = append(chosenNames, name)
+		}
+	}
+	if len(chosen) < 2 || !to.After(from) || limit <= 0 {
+		return nil, to
+	}
+
+	observer, hasObserver := findBody(planets, DefaultObserver)
+	if _, chosenObserver := findBody(chosen, DefaultObserver); chosenObserver {
+		hasObserver = false
+	}
+
+	step := scanStep(chosen)
+	if hasObserver {
+		step = min(step, scanStep([]models.CelestialBody{observer}))
+	}
+	if last := from.Add(step * maxAlignmentSamples); last.Before(to) {
+		to = last
+	}
+
+	directions := make([]float64, len(chosen))
+	spread := func(t time.Time) float64 {
+		for i, body := range chosen {
+			if hasObserver {
+				directions[i] = e.direction(observer, body, t)
+			} else {
+				directions[i] = e.ephemeris.Longitude(body, t)
+			}
+		}
+		return arcSpan(directions)
+	}
+
+	// Each stretch of samples within the threshold is one alignment, refined
+	// around its closest sample
+	var found []Alignment
+	inside := false
+	var closest time.Time
+	closestSpread := math.MaxFloat64
+	finish := func() {
+		t := refineMinimum(spread, closest.Add(-step), closest.Add(step), from, to)
+		found = append(found, Alignment{Time: t, Bodies: chosenNames, Separation: spread(t)})
+		if hasObserver {
+			found[len(found)-1].Observer = observer.EnglishName
+		}
+		inside, closestSpread = false, math.MaxFloat64
+	}
+
+	for t := from; !t.After(to) && len(found) < limit; t = t.Add(step) {
+		value := spread(t)
+		if value > threshold {
+			if inside {
+				finish()
+			}
+			continue
+		}
+		inside = true
+		if value < closestSpread {
+			closest, closestSpread = t, value
+		}
+	}
+	if inside && len(found) < limit {
+		finish()
+	}
+	return found, to
+}
+
+// direction returns the angle, in radians, from observer towards target at time t
+func (e *Engine) direction(observer, target models.CelestialBody, t time.Time) float64 {
+	ox, oy := e.ephemeris.Position(observer, t)
+	tx, ty := e.ephemeris.Position(target, t)
+	return math.Atan2(ty-oy, tx-ox)
+}
+
+// arcSpan returns the width of the narrowest arc holding every angle: a full turn
+// less the widest gap between neighbouring angles
+func arcSpan(angles []float64) float64 {
+	sorted := make([]float64, len(angles))
+	for i, angle := range angles {
+		sorted[i] = math.Mod(angle+2*math.Pi, 2*math.Pi)
+	}
+	sort.Float64s(sorted)
+
+	widestGap := sorted[0] + 2*math.Pi - sorted[len(sorted)-1]
+	for i := 1; i < len(sorted); i++ {
+		widestGap = math.Max(widestGap, sorted[i]-sorted[i-1])
+	}
+	return 2*math.Pi - widestGap
+}
+
+// refineMinimum narrows the time spread is smallest between lo and hi, kept within
+// from and to, down to about a minute by ternary search
+func refineMinimum(spread func(time.Time) float64, lo, hi, from, to time.Time) time.Time {
+	if lo.Before(from) {
+		lo = from
+	}
+	if hi.After(to) {
+		hi = to
+	}
+	for hi.Sub(lo) > time.Minute {
+		third := hi.Sub(lo) / 3
+		a, b := lo.Add(third), hi.Add(-third)
+		if spread(a) < spread(b) {
+			hi = b
+		} else {
+			lo = a
+		}
+	}
+	return lo.Add(hi.Sub(lo) / 2)
+}
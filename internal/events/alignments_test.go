@@ -0,0 +1,93 @@
+package events
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+)
+
+var (
+	jupiter = models.CelestialBody{EnglishName: "Jupiter", SemimajorAxis: 778340821, SideralOrbit: 4332.589}
+	saturn  = models.CelestialBody{EnglishName: "Saturn", SemimajorAxis: 1426666422, SideralOrbit: 10759.22}
+)
+
+func TestFindAlignmentsWithoutObserver(t *testing.T) {
+	epoch := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	engine := NewEngine(orbital.NewEphemeris(epoch))
+
+	b := models.CelestialBody{EnglishName: "b", SemimajorAxis: 1000000, SideralOrbit: 2}
+	c := models.CelestialBody{EnglishName: "c", SemimajorAxis: 2000000, SideralOrbit: 4}
+
+	// Synodic period of 4 days, so 5 alignments in 20 days
+	threshold := 2 * math.Pi / 180
+	found, _ := engine.FindAlignments([]models.CelestialBody{b, c}, []string{"b", "c"}, threshold, epoch, epoch.AddDate(0, 0, 20), 10)
+	if len(found) != 5 {
+		t.Fatalf("found %d alignments, want 5: %+v", len(found), found)
+	}
+	for _, alignment := range found {
+		if alignment.Observer != "" {
+			t.Errorf("alignment seen from %q, want the star", alignment.Observer)
+		}
+		if alignment.Separation > 0.001 {
+			t.Errorf("alignment at %v is %.4f rad apart, want its closest point", alignment.Time, alignment.Separation)
+		}
+	}
+
+	if limited, _ := engine.FindAlignments([]models.CelestialBody{b, c}, []string{"b", "c"}, threshold, epoch, epoch.AddDate(0, 0, 20), 2); len(limited) != 2 {
+		t.Errorf("found %d alignments with a limit of 2", len(limited))
+	}
+}
+
+func TestFindAlignmentsSeenFromEarth(t *testing.T) {
+	epoch := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	engine := NewEngine(orbital.NewEphemeris(epoch))
+	bodies := []models.CelestialBody{sun, earth, jupiter, saturn}
+
+	// Jupiter and Saturn meet about every 20 years
+	threshold := math.Pi / 180
+	found, _ := engine.FindAlignments(bodies, []string{"Jupiter", "Saturn"}, threshold, epoch, epoch.AddDate(45, 0, 0), 10)
+	if len(found) < 2 {
+		t.Fatalf("found %d great conjunctions in 45 years, want at least 2", len(found))
+	}
+	for _, alignment := range found {
+		if alignment.Observer != "Earth" {
+			t.Errorf("alignment seen from %q, want Earth", alignment.Observer)
+		}
+		if alignment.Separation > threshold {
+			t.Errorf("alignment at %v is %.4f rad apart, past the threshold", alignment.Time, alignment.Separation)
+		}
+	}
+	if gap := found[len(found)-1].Time.Sub(found[0].Time).Hours() / 24 / 365.25; gap < 15 {
+		t.Errorf("alignments span %.1f years, want a second conjunction about 20 years on", gap)
+	}
+
+	// Earth among the chosen bodies cannot be the observer too
+	found, _ = engine.FindAlignments(bodies, []string{"Earth", "Jupiter"}, threshold, epoch, epoch.AddDate(2, 0, 0), 10)
+	if len(found) == 0 || found[0].Observer != "" {
+		t.Errorf("Earth-Jupiter alignments = %+v, want ones seen from the star", found)
+	}
+}
+
+func TestFindAlignmentsStopsEarlyForFastOrbits(t *testing.T) {
+	epoch := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	engine := NewEngine(orbital.NewEphemeris(epoch))
+
+	b := models.CelestialBody{EnglishName: "b", SemimajorAxis: 1700000, SideralOrbit: 1.51087}
+	c := models.CelestialBody{EnglishName: "c", SemimajorAxis: 2300000, SideralOrbit: 2.4218}
+
+	to := epoch.AddDate(100, 0, 0)
+	_, searched := engine.FindAlignments([]models.CelestialBody{b, c}, []string{"b", "c"}, 0.001, epoch, to, 20)
+	if !searched.Before(to) || !searched.After(epoch) {
+		t.Errorf("searched up to %v, want short of a century of day-long orbits", searched)
+	}
+}
+
+func TestArcSpanAcrossZero(t *testing.T) {
+	span := arcSpan([]float64{0.1, 2*math.Pi - 0.1, 0.05})
+	if math.Abs(span-0.2) > 1e-9 {
+		t.Errorf("arcSpan() = %v, want 0.2 across zero", span)
+	}
+}
@@ -0,0 +1,243 @@
+// Package events finds notable orbital configurations on the simulated timeline:
+// oppositions, conjunctions and perihelion passages.
+package events
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+)
+
+// Kind identifies the type of an orbital event
+type Kind string
+
+const (
+	KindOpposition          Kind = "Opposition"
+	KindConjunction         Kind = "Conjunction"
+	KindInferiorConjunction Kind = "Inferior conjunction"
+	KindSuperiorConjunction Kind = "Superior conjunction"
+	KindPerihelion          Kind = "Perihelion"
+)
+
+// DefaultObserver is the body alignments are reported relative to when it is present
+const DefaultObserver = "Earth"
+
+// samplesPerPeriod controls the scan resolution relative to the fastest orbit
+const samplesPerPeriod = 72
+
+// Event is a single orbital event
+type Event struct {
+	Kind        Kind
+	Time        time.Time
+	Bodies      []string
+	Description string
+}
+
+// Engine scans the simulated timeline for events
+type Engine struct {
+	ephemeris *orbital.Ephemeris
+}
+
+// NewEngine creates an engine that places bodies with the given ephemeris
+func NewEngine(ephemeris *orbital.Ephemeris) *Engine {
+	return &Engine{ephemeris: ephemeris}
+}
+
+// Find returns the events in (from, to], sorted by time. When the observer is among
+// the bodies, alignments are reported as seen from it (oppositions and conjunctions);
+// otherwise heliocentric conjunctions between every pair of planets are reported.
+func (e *Engine) Find(bodies []models.CelestialBody, from, to time.Time) []Event {
+	planets := orbitingBodies(bodies)
+	if len(planets) == 0 || !to.After(from) {
+		return nil
+	}
+
+	step := scanStep(planets)
+	var found []Event
+
+	for _, planet := range planets {
+		found = append(found, e.findPerihelia(planet, from, to, step)...)
+	}
+
+	observer, hasObserver := findBody(planets, DefaultObserver)
+	for i := range planets {
+		for j := i + 1; j < len(planets); j++ {
+			a, b := planets[i], planets[j]
+			if hasObserver {
+				if a.EnglishName != observer.EnglishName && b.EnglishName != observer.EnglishName {
+					continue
+				}
+				if b.EnglishName == observer.EnglishName {
+					a, b = b, a
+				}
+				found = append(found, e.findObserverAlignments(a, b, from, to, step)...)
+			} else {
+				found = append(found, e.findHeliocentricConjunctions(a, b, from, to, step)...)
+			}
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Time.Before(found[j].Time)
+	})
+	return found
+}
+
+// findPerihelia reports each time the body's mean anomaly passes through zero
+func (e *Engine) findPerihelia(body models.CelestialBody, from, to time.Time, step time.Duration) []Event {
+	phase := func(t time.Time) float64 {
+		return wrapAngle(e.ephemeris.MeanAnomaly(body, t))
+	}
+
+	var found []Event
+	for _, t := range findCrossings(phase, from, to, step) {
+		found = append(found, Event{
+			Kind:        KindPerihelion,
+			Time:        t,
+			Bodies:      []string{body.EnglishName},
+			Description: fmt.Sprintf("%s passes perihelion", body.EnglishName),
+		})
+	}
+	return found
+}
+
+// findObserverAlignments reports oppositions and conjunctions of target as seen from observer
+func (e *Engine) findObserverAlignments(observer, target models.CelestialBody, from, to time.Time, step time.Duration) []Event {
+	inner := target.SemimajorAxis < observer.SemimajorAxis
+	var found []Event
+
+	// Same heliocentric longitude: the target is opposite the star (outer planet)
+	// or between us and the star (inner planet)
+	aligned := func(t time.Time) float64 {
+		return wrapAngle(e.ephemeris.Longitude(target, t) - e.ephemeris.Longitude(observer, t))
+	}
+	for _, t := range findCrossings(aligned, from, to, step) {
+		kind, description := KindOpposition, fmt.Sprintf("%s at opposition", target.EnglishName)
+		if inner {
+			kind, description = KindInferiorConjunction, fmt.Sprintf("%s at inferior conjunction", target.EnglishName)
+		}
+		found = append(found, Event{Kind: kind, Time: t, Bodies: []string{observer.EnglishName, target.EnglishName}, Description: description})
+	}
+
+	// Opposite heliocentric longitudes: the target is behind the star
+	behind := func(t time.Time) float64 {
+		return wrapAngle(e.ephemeris.Longitude(target, t) - e.ephemeris.Longitude(observer, t) - math.Pi)
+	}
+	for _, t := range findCrossings(behind, from, to, step) {
+		kind, description := KindConjunction, fmt.Sprintf("%s in conjunction with the Sun", target.EnglishName)
+		if inner {
+			kind, description = KindSuperiorConjunction, fmt.Sprintf("%s at superior conjunction", target.EnglishName)
+		}
+		found = append(found, Event{Kind: kind, Time: t, Bodies: []string{observer.EnglishName, target.EnglishName}, Description: description})
+	}
+
+	return found
+}
+
+// findHeliocentricConjunctions reports when two planets line up on the same side of their star
+func (e *Engine) findHeliocentricConjunctions(a, b models.CelestialBody, from, to time.Time, step time.Duration) []Event {
+	aligned := func(t time.Time) float64 {
+		return wrapAngle(e.ephemeris.Longitude(a, t) - e.ephemeris.Longitude(b, t))
+	}
+
+	var found []Event
+	for _, t := range findCrossings(aligned, from, to, step) {
+		found = append(found, Event{
+			Kind:        KindConjunction,
+			Time:        t,
+			Bodies:      []string{a.EnglishName, b.EnglishName},
+			Description: fmt.Sprintf("%s and %s in conjunction", a.EnglishName, b.EnglishName),
+		})
+	}
+	return found
+}
+
+// findCrossings returns the times an angle in (-π, π] passes through zero in either
+// direction. Wrap-around jumps between π and -π are ignored; each crossing is refined
+// by bisection.
+func findCrossings(angle func(time.Time) float64, from, to time.Time, step time.Duration) []time.Time {
+	var crossings []time.Time
+
+	prevTime, prevValue := from, angle(from)
+	for prevTime.Before(to) {
+		t := prevTime.Add(step)
+		if t.After(to) {
+			t = to
+		}
+		value := angle(t)
+
+		if (prevValue < 0) != (value < 0) && math.Abs(value-prevValue) < math.Pi {
+			crossings = append(crossings, bisect(angle, prevTime, t))
+		}
+
+		prevTime, prevValue = t, value
+	}
+
+	return crossings
+}
+
+// bisect narrows a zero crossing between lo and hi down to about a minute
+func bisect(angle func(time.Time) float64, lo, hi time.Time) time.Time {
+	loNegative := angle(lo) < 0
+	for hi.Sub(lo) > time.Minute {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if (angle(mid) < 0) == loNegative {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+// scanStep picks a step small enough to catch every crossing of the fastest body
+func scanStep(planets []models.CelestialBody) time.Duration {
+	shortest := math.MaxFloat64
+	for _, planet := range planets {
+		if planet.SideralOrbit > 0 && planet.SideralOrbit < shortest {
+			shortest = planet.SideralOrbit
+		}
+	}
+
+	step := time.Duration(shortest / samplesPerPeriod * 24 * float64(time.Hour))
+	if step < time.Minute {
+		step = time.Minute
+	}
+	return step
+}
+
+// orbitingBodies filters out stars and bodies without a known orbit
+func orbitingBodies(bodies []models.CelestialBody) []models.CelestialBody {
+	var planets []models.CelestialBody
+	for _, body := range bodies {
+		if body.BodyType == "Star" || body.SemimajorAxis <= 0 || body.SideralOrbit <= 0 {
+			continue
+		}
+		planets = append(planets, body)
+	}
+	return planets
+}
+
+func findBody(bodies []models.CelestialBody, name string) (models.CelestialBody, bool) {
+	for _, body := range bodies {
+		if body.EnglishName == name {
+			return body, true
+		}
+	}
+	return models.CelestialBody{}, false
+}
+
+// wrapAngle maps an angle into (-π, π]
+func wrapAngle(angle float64) float64 {
+	angle = math.Mod(angle, 2*math.Pi)
+	if angle > math.Pi {
+		angle -= 2 * math.Pi
+	} else if angle <= -math.Pi {
+		angle += 2 * math.Pi
+	}
+	return angle
+}
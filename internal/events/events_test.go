@@ -0,0 +1,105 @@
+package events
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+)
+
+var (
+	earth = models.CelestialBody{EnglishName: "Earth", SemimajorAxis: 149598023, SideralOrbit: 365.256, Eccentricity: 0.0167}
+	mars  = models.CelestialBody{EnglishName: "Mars", SemimajorAxis: 227939200, SideralOrbit: 686.98, Eccentricity: 0.0934}
+	venus = models.CelestialBody{EnglishName: "Venus", SemimajorAxis: 108208000, SideralOrbit: 224.701, Eccentricity: 0.0067}
+	sun   = models.CelestialBody{EnglishName: "Sun", BodyType: "Star"}
+)
+
+func TestFindPerihelionOncePerOrbit(t *testing.T) {
+	epoch := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	engine := NewEngine(orbital.NewEphemeris(epoch))
+
+	found := engine.Find([]models.CelestialBody{sun, earth}, epoch, epoch.AddDate(3, 0, 0))
+
+	count := 0
+	for _, event := range found {
+		if event.Kind == KindPerihelion {
+			count++
+		}
+	}
+	if count != 3 {
+		t.Errorf("expected 3 Earth perihelion passages in 3 years, got %d", count)
+	}
+}
+
+func TestFindOppositionAlignsLongitudes(t *testing.T) {
+	epoch := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ephemeris := orbital.NewEphemeris(epoch)
+	engine := NewEngine(ephemeris)
+
+	// The Earth-Mars synodic period is about 780 days
+	found := engine.Find([]models.CelestialBody{sun, earth, mars}, epoch, epoch.AddDate(0, 0, 800))
+
+	var oppositions, conjunctions int
+	for _, event := range found {
+		switch event.Kind {
+		case KindOpposition:
+			oppositions++
+			diff := math.Abs(wrapAngle(ephemeris.Longitude(mars, event.Time) - ephemeris.Longitude(earth, event.Time)))
+			if diff > 0.01 {
+				t.Errorf("opposition at %v has longitude difference %.4f rad", event.Time, diff)
+			}
+		case KindConjunction:
+			conjunctions++
+		}
+	}
+
+	if oppositions != 1 {
+		t.Errorf("expected 1 Mars opposition in 800 days, got %d", oppositions)
+	}
+	if conjunctions != 1 {
+		t.Errorf("expected 1 Mars conjunction in 800 days, got %d", conjunctions)
+	}
+}
+
+func TestFindInnerPlanetConjunctions(t *testing.T) {
+	epoch := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	engine := NewEngine(orbital.NewEphemeris(epoch))
+
+	// The Earth-Venus synodic period is about 584 days
+	found := engine.Find([]models.CelestialBody{earth, venus}, epoch, epoch.AddDate(0, 0, 600))
+
+	kinds := make(map[Kind]int)
+	for _, event := range found {
+		kinds[event.Kind]++
+	}
+
+	if kinds[KindInferiorConjunction] != 1 || kinds[KindSuperiorConjunction] != 1 {
+		t.Errorf("expected one inferior and one superior conjunction of Venus, got %v", kinds)
+	}
+	if kinds[KindOpposition] != 0 {
+		t.Errorf("inner planets never reach opposition, got %d", kinds[KindOpposition])
+	}
+}
+
+func TestFindHeliocentricConjunctionsWithoutObserver(t *testing.T) {
+	epoch := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	engine := NewEngine(orbital.NewEphemeris(epoch))
+
+	b := models.CelestialBody{EnglishName: "b", SemimajorAxis: 1000000, SideralOrbit: 2}
+	c := models.CelestialBody{EnglishName: "c", SemimajorAxis: 2000000, SideralOrbit: 4}
+
+	// Synodic period of 4 days, so 5 conjunctions in 20 days
+	found := engine.Find([]models.CelestialBody{b, c}, epoch, epoch.AddDate(0, 0, 20))
+
+	count := 0
+	for _, event := range found {
+		if event.Kind == KindConjunction {
+			count++
+		}
+	}
+	if count != 5 {
+		t.Errorf("expected 5 conjunctions, got %d", count)
+	}
+}
@@ -0,0 +1,196 @@
+// Package filter parses the filter expressions that narrow body lists, such as
+// `mass>1e24 && moons>=2` or `bodyType=Moon`, into predicates on bodies.
+//
+// An expression compares fields with values using = (or ==), !=, <, <=, >, >=
+// and ~ (contains), joins comparisons with && and ||, negates them with ! and
+// groups them with parentheses. Text compares without regard to case; values
+// with spaces go in double quotes. A word on its own matches names containing
+// it. A numeric field a body has no value for matches no comparison.
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// Filter is a parsed expression
+type Filter struct {
+	source string
+	match  predicate
+}
+
+type predicate func(models.CelestialBody) bool
+
+// Match reports whether a body passes the filter
+func (f *Filter) Match(body models.CelestialBody) bool {
+	return f.match(body)
+}
+
+// String returns the expression the filter was parsed from
+func (f *Filter) String() string {
+	return f.source
+}
+
+// Apply returns the bodies that pass the filter, in order
+func (f *Filter) Apply(bodies []models.CelestialBody) []models.CelestialBody {
+	var matched []models.CelestialBody
+	for _, body := range bodies {
+		if f.match(body) {
+			matched = append(matched, body)
+		}
+	}
+	return matched
+}
+
+// field is a body field expressions can name: a number, or text
+type field struct {
+	number func(models.CelestialBody) (float64, bool) // false when the body has no value
+	text   func(models.CelestialBody) []string        // any of these may match
+}
+
+// known treats zero as not recorded, as the API and system files do
+func known(value func(models.CelestialBody) float64) func(models.CelestialBody) (float64, bool) {
+	return func(cb models.CelestialBody) (float64, bool) {
+		v := value(cb)
+		return v, v != 0
+	}
+}
+
+func text(value func(models.CelestialBody) string) func(models.CelestialBody) []string {
+	return func(cb models.CelestialBody) []string { return []string{value(cb)} }
+}
+
+// fields are the names expressions can use, lower case, with their aliases
+var fields = map[string]field{
+	"name":          {text: func(cb models.CelestialBody) []string { return cb.Names() }},
+	"id":            {text: text(func(cb models.CelestialBody) string { return cb.ID })},
+	"bodytype":      {text: text(func(cb models.CelestialBody) string { return cb.BodyType })},
+	"type":          {text: text(func(cb models.CelestialBody) string { return cb.BodyType })},
+	"isplanet":      {text: text(func(cb models.CelestialBody) string { return strconv.FormatBool(cb.IsPlanet) })},
+	"discoveredby":  {text: text(func(cb models.CelestialBody) string { return cb.DiscoveredBy })},
+	"discoverydate": {text: text(func(cb models.CelestialBody) string { return cb.DiscoveryDate })},
+	"orbits": {text: func(cb models.CelestialBody) []string {
+		if cb.AroundPlanet == nil {
+			return nil
+		}
+		return []string{cb.AroundPlanet.Planet, cb.AroundPlanet.EnglishName, cb.AroundPlanet.Name}
+	}},
+
+	"mass":            {number: known(func(cb models.CelestialBody) float64 { return cb.GetMassKg() })},
+	"moons":           {number: func(cb models.CelestialBody) (float64, bool) { return float64(len(cb.Moons)), true }},
+	"radius":          {number: known(func(cb models.CelestialBody) float64 { return cb.MeanRadius })},
+	"meanradius":      {number: known(func(cb models.CelestialBody) float64 { return cb.MeanRadius })},
+	"density":         {number: known(func(cb models.CelestialBody) float64 { return cb.Density })},
+	"gravity":         {number: known(func(cb models.CelestialBody) float64 { return cb.Gravity })},
+	"escape":          {number: known(func(cb models.CelestialBody) float64 { return cb.Escape })},
+	"semimajoraxis":   {number: known(func(cb models.CelestialBody) float64 { return cb.SemimajorAxis })},
+	"a":               {number: known(func(cb models.CelestialBody) float64 { return cb.SemimajorAxis })},
+	"perihelion":      {number: known(func(cb models.CelestialBody) float64 { return cb.Perihelion })},
+	"aphelion":        {number: known(func(cb models.CelestialBody) float64 { return cb.Aphelion })},
+	"period":          {number: known(func(cb models.CelestialBody) float64 { return cb.SideralOrbit })},
+	"sideralorbit":    {number: known(func(cb models.CelestialBody) float64 { return cb.SideralOrbit })},
+	"rotation":        {number: known(func(cb models.CelestialBody) float64 { return cb.SideralRotation })},
+	"sideralrotation": {number: known(func(cb models.CelestialBody) float64 { return cb.SideralRotation })},
+	"eccentricity":    {number: known(func(cb models.CelestialBody) float64 { return cb.Eccentricity })},
+	"inclination":     {number: known(func(cb models.CelestialBody) float64 { return cb.Inclination })},
+	"axialtilt":       {number: known(func(cb models.CelestialBody) float64 { return cb.AxialTilt })},
+	"temp":            {number: known(func(cb models.CelestialBody) float64 { return cb.AvgTemp })},
+	"avgtemp":         {number: known(func(cb models.CelestialBody) float64 { return cb.AvgTemp })},
+}
+
+// Fields returns the field names expressions can use, sorted
+func Fields() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse parses an expression. An empty one is an error; callers treat it as no
+// filter at all.
+func Parse(expression string) (*Filter, error) {
+	tokens, err := lex(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty filter")
+	}
+
+	p := &parser{tokens: tokens}
+	match, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q", p.peek().text)
+	}
+	return &Filter{source: strings.TrimSpace(expression), match: match}, nil
+}
+
+// comparison builds the predicate comparing a field with a value
+func comparison(name, op, value string) (predicate, error) {
+	f, ok := fields[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q (fields: %s)", name, strings.Join(Fields(), ", "))
+	}
+
+	if f.number != nil {
+		want, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s needs a number, got %q", name, value)
+		}
+		compare, ok := numberOps[op]
+		if !ok {
+			return nil, fmt.Errorf("%s is a number and cannot be compared with %s", name, op)
+		}
+		return func(cb models.CelestialBody) bool {
+			got, ok := f.number(cb)
+			return ok && compare(got, want)
+		}, nil
+	}
+
+	value = strings.ToLower(value)
+	var compare func(got string) bool
+	switch op {
+	case "=", "==":
+		compare = func(got string) bool { return strings.ToLower(got) == value }
+	case "!=":
+		return func(cb models.CelestialBody) bool {
+			for _, got := range f.text(cb) {
+				if strings.ToLower(got) == value {
+					return false
+				}
+			}
+			return true
+		}, nil
+	case "~":
+		compare = func(got string) bool { return strings.Contains(strings.ToLower(got), value) }
+	default:
+		return nil, fmt.Errorf("%s is text and cannot be compared with %s", name, op)
+	}
+	return func(cb models.CelestialBody) bool {
+		for _, got := range f.text(cb) {
+			if compare(got) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+var numberOps = map[string]func(got, want float64) bool{
+	"=":  func(got, want float64) bool { return got == want },
+	"==": func(got, want float64) bool { return got == want },
+	"!=": func(got, want float64) bool { return got != want },
+	"<":  func(got, want float64) bool { return got < want },
+	"<=": func(got, want float64) bool { return got <= want },
+	">":  func(got, want float64) bool { return got > want },
+	">=": func(got, want float64) bool { return got >= want },
+}
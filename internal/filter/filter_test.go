@@ -0,0 +1,76 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+var testBodies = []models.CelestialBody{
+	{ID: "terre", EnglishName: "Earth", BodyType: "Planet", IsPlanet: true, Mass: models.Mass{MassValue: 5.97, MassExponent: 24}, MeanRadius: 6371,
+		Moons: []models.Moon{{Name: "La Lune"}}},
+	{ID: "mars", EnglishName: "Mars", BodyType: "Planet", IsPlanet: true, Mass: models.Mass{MassValue: 6.42, MassExponent: 23}, MeanRadius: 3389,
+		Moons: []models.Moon{{Name: "Phobos"}, {Name: "Deimos"}}},
+	{ID: "jupiter", EnglishName: "Jupiter", BodyType: "Planet", IsPlanet: true, Mass: models.Mass{MassValue: 1.9, MassExponent: 27}, MeanRadius: 69911,
+		Moons: []models.Moon{{Name: "Io"}, {Name: "Europe"}, {Name: "Ganymède"}, {Name: "Callisto"}}},
+	{ID: "lune", EnglishName: "Moon", Name: "La Lune", BodyType: "Moon", MeanRadius: 1737, AroundPlanet: &models.Planet{Planet: "terre"}},
+	{ID: "ceres", EnglishName: "Ceres", BodyType: "Dwarf Planet"},
+}
+
+func matchNames(t *testing.T, expression string) string {
+	t.Helper()
+	f, err := Parse(expression)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", expression, err)
+	}
+	var names []string
+	for _, body := range f.Apply(testBodies) {
+		names = append(names, body.EnglishName)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestFilterMatches(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+	}{
+		{"mass>1e24 && moons>=2", "Jupiter"},
+		{"mass>1e24", "Earth,Jupiter"},
+		{"bodyType=Moon", "Moon"},
+		{"type = \"dwarf planet\"", "Ceres"},
+		{"moons=0", "Moon,Ceres"},
+		{"mass<1e24", "Mars"}, // bodies with no mass never match
+		{"radius<2000 || name~cer", "Moon,Ceres"},
+		{"!(isPlanet=true) && not bodyType=Moon", "Ceres"},
+		{"lune", "Moon"}, // a lone word looks for a name, French ones too
+		{"orbits=terre", "Moon"},
+		{"bodyType!=planet", "Moon,Ceres"},
+		{"MOONS >= 1 and Radius > 5000", "Earth,Jupiter"},
+	}
+
+	for _, tt := range tests {
+		if got := matchNames(t, tt.expression); got != tt.want {
+			t.Errorf("%q matched %q, want %q", tt.expression, got, tt.want)
+		}
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"weight>3",
+		"mass>heavy",
+		"bodyType<Moon",
+		"mass>",
+		"(mass>1",
+		"mass>1 &&",
+		"name=\"unterminated",
+		"mass>1 moons",
+	} {
+		if _, err := Parse(expression); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", expression)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota // a field name or a value
+	tokenQuoted
+	tokenOp // a comparison
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// comparisons are the comparison operators, longest first so <= is not read as <
+var comparisons = []string{"==", "!=", "<=", ">=", "=", "<", ">", "~"}
+
+// lex splits an expression into tokens. "and", "or" and "not" may stand in for
+// &&, || and !.
+func lex(expression string) ([]token, error) {
+	var tokens []token
+	s := expression
+	for {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if s == "" {
+			return tokens, nil
+		}
+
+		switch {
+		case strings.HasPrefix(s, "&&"):
+			tokens, s = append(tokens, token{tokenAnd, "&&"}), s[2:]
+			continue
+		case strings.HasPrefix(s, "||"):
+			tokens, s = append(tokens, token{tokenOr, "||"}), s[2:]
+			continue
+		case s[0] == '(':
+			tokens, s = append(tokens, token{tokenOpen, "("}), s[1:]
+			continue
+		case s[0] == ')':
+			tokens, s = append(tokens, token{tokenClose, ")"}), s[1:]
+			continue
+		case s[0] == '"':
+			end := strings.IndexByte(s[1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("missing closing quote")
+			}
+			tokens, s = append(tokens, token{tokenQuoted, s[1 : end+1]}), s[end+2:]
+			continue
+		}
+
+		if op := comparisonAt(s); op != "" {
+			tokens, s = append(tokens, token{tokenOp, op}), s[len(op):]
+			continue
+		}
+		if s[0] == '!' {
+			tokens, s = append(tokens, token{tokenNot, "!"}), s[1:]
+			continue
+		}
+
+		end := strings.IndexFunc(s, func(r rune) bool {
+			return unicode.IsSpace(r) || strings.ContainsRune(`()"&|!=<>~`, r)
+		})
+		if end < 0 {
+			end = len(s)
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("unexpected %q", s[:1])
+		}
+		word := s[:end]
+		switch strings.ToLower(word) {
+		case "and":
+			tokens = append(tokens, token{tokenAnd, word})
+		case "or":
+			tokens = append(tokens, token{tokenOr, word})
+		case "not":
+			tokens = append(tokens, token{tokenNot, word})
+		default:
+			tokens = append(tokens, token{tokenWord, word})
+		}
+		s = s[end:]
+	}
+}
+
+// comparisonAt returns the comparison operator s starts with, if any
+func comparisonAt(s string) string {
+	for _, op := range comparisons {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// parser reads tokens by recursive descent: || binds loosest, then &&, then !
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) accept(kind tokenKind) bool {
+	if !p.done() && p.peek().kind == kind {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) or() (predicate, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(tokenOr) {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(cb models.CelestialBody) bool { return l(cb) || right(cb) }
+	}
+	return left, nil
+}
+
+func (p *parser) and() (predicate, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(tokenAnd) {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(cb models.CelestialBody) bool { return l(cb) && right(cb) }
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (predicate, error) {
+	if p.done() {
+		return nil, fmt.Errorf("expression ends too soon")
+	}
+
+	switch {
+	case p.accept(tokenNot):
+		inner, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(cb models.CelestialBody) bool { return !inner(cb) }, nil
+	case p.accept(tokenOpen):
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(tokenClose) {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return inner, nil
+	}
+
+	first := p.peek()
+	if first.kind != tokenWord && first.kind != tokenQuoted {
+		return nil, fmt.Errorf("unexpected %q", first.text)
+	}
+	p.pos++
+
+	// A word with no comparison after it looks for a name
+	if p.done() || p.peek().kind != tokenOp {
+		return comparison("name", "~", first.text)
+	}
+	if first.kind != tokenWord {
+		return nil, fmt.Errorf("expected a field name before %s, got %q", p.peek().text, first.text)
+	}
+
+	op := p.peek().text
+	p.pos++
+	if p.done() || (p.peek().kind != tokenWord && p.peek().kind != tokenQuoted) {
+		return nil, fmt.Errorf("%s%s needs a value", first.text, op)
+	}
+	value := p.peek().text
+	p.pos++
+	return comparison(first.text, op, value)
+}
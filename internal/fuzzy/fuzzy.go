@@ -0,0 +1,66 @@
+// Package fuzzy matches typed abbreviations against names, the way command
+// palettes do: "swtr" finds "Switch to TRAPPIST-1".
+package fuzzy
+
+import (
+	"sort"
+	"unicode"
+)
+
+const (
+	matchScore       = 1
+	wordStartBonus   = 8
+	consecutiveBonus = 4
+)
+
+// Score reports whether every rune of query appears in text in order, ignoring
+// case, and how well: letters starting words and runs of adjacent letters score
+// higher, and a match that starts later scores a little lower. An empty query
+// matches everything with a score of zero.
+func Score(query, text string) (int, bool) {
+	needle := []rune(query)
+	if len(needle) == 0 {
+		return 0, true
+	}
+
+	score, matched, last, first := 0, 0, -2, -1
+	previous := ' '
+	for i, r := range []rune(text) {
+		if matched < len(needle) && unicode.ToLower(r) == unicode.ToLower(needle[matched]) {
+			score += matchScore
+			if !unicode.IsLetter(previous) && !unicode.IsDigit(previous) {
+				score += wordStartBonus
+			}
+			if last == i-1 {
+				score += consecutiveBonus
+			}
+			if first < 0 {
+				first = i
+			}
+			last = i
+			matched++
+		}
+		previous = r
+	}
+	if matched < len(needle) {
+		return 0, false
+	}
+	return score - first/4, true
+}
+
+// Rank returns the indexes of the texts query matches, best first; texts that
+// score the same keep their order
+func Rank(query string, texts []string) []int {
+	var indexes []int
+	scores := make(map[int]int)
+	for i, text := range texts {
+		if score, ok := Score(query, text); ok {
+			indexes = append(indexes, i)
+			scores[i] = score
+		}
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return scores[indexes[a]] > scores[indexes[b]]
+	})
+	return indexes
+}
@@ -0,0 +1,49 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScoreMatchesInOrder(t *testing.T) {
+	tests := []struct {
+		query, text string
+		want        bool
+	}{
+		{"", "anything", true},
+		{"swtr", "Switch to TRAPPIST-1", true},
+		{"GALAXY", "Galaxy map", true},
+		{"belts", "Hide the asteroid and Kuiper belts", true},
+		{"trs", "Switch to Kepler-452", false},
+		{"ba", "ab", false},
+	}
+	for _, test := range tests {
+		if _, got := Score(test.query, test.text); got != test.want {
+			t.Errorf("Score(%q, %q) matched = %v, want %v", test.query, test.text, got, test.want)
+		}
+	}
+}
+
+func TestScorePrefersWordStarts(t *testing.T) {
+	starts, _ := Score("gm", "Galaxy map")
+	inside, _ := Score("gm", "Debugging mode")
+	if starts <= inside {
+		t.Errorf("word starts scored %d, letters inside words %d; want starts higher", starts, inside)
+	}
+
+	run, _ := Score("moon", "Go to Moon")
+	spread, _ := Score("moon", "Mission planner: transfer Δv, travel time, launch window")
+	if run <= spread {
+		t.Errorf("adjacent letters scored %d, spread ones %d; want adjacent higher", run, spread)
+	}
+}
+
+func TestRank(t *testing.T) {
+	texts := []string{"Quiz mode", "Switch star system", "Go to Saturn", "System statistics"}
+	if got, want := Rank("sys", texts), []int{3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Rank(sys) = %v, want %v", got, want)
+	}
+	if got, want := Rank("", texts), []int{0, 1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Rank(\"\") = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,149 @@
+// Package gamepad turns a game controller or kiosk remote into navigation inputs
+// (up, down, confirm, back...) for installations where a keyboard is out of reach.
+//
+// Controllers are read through a small HID bridge: the Linux joystick device
+// (/dev/input/js0), which the kernel offers for any USB or Bluetooth gamepad, or
+// any program that writes one input name per line to a FIFO, so a remote on a
+// microcontroller or another OS's HID stack can drive the app too.
+package gamepad
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// Input is a navigation intent, whatever button or stick produced it
+type Input string
+
+const (
+	Up      Input = "up"
+	Down    Input = "down"
+	Left    Input = "left"
+	Right   Input = "right"
+	Confirm Input = "confirm" // show details, pick the highlighted item
+	Back    Input = "back"    // close the top window; never quits
+	Menu    Input = "menu"    // the help screen
+	Systems Input = "systems" // the star system list
+)
+
+// Inputs are every input, in the order the README lists them
+var Inputs = []Input{Up, Down, Left, Right, Confirm, Back, Menu, Systems}
+
+// ParseInput reads an input name, as a bridge writes it. Blank lines and lines
+// starting with # are not inputs: ok is false for them and err is nil.
+func ParseInput(line string) (input Input, ok bool, err error) {
+	line = strings.ToLower(strings.TrimSpace(line))
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", false, nil
+	}
+	for _, known := range Inputs {
+		if Input(line) == known {
+			return known, true, nil
+		}
+	}
+	return "", false, fmt.Errorf("unknown input %q", line)
+}
+
+// EventSize is the size of one Linux joystick event
+const EventSize = 8
+
+// Event kinds in a Linux joystick event
+const (
+	kindButton = 0x01
+	kindAxis   = 0x02
+	kindInit   = 0x80 // set on the events describing the state when the device opens
+)
+
+// Event is one Linux joystick event: a button pressed or released, or an axis moved
+type Event struct {
+	Time   uint32 // milliseconds, from an arbitrary start
+	Value  int16  // 1 or 0 for buttons, -32767 to 32767 for axes
+	Button bool   // a button rather than an axis
+	Init   bool   // the state on opening rather than a change
+	Number uint8  // which button or axis
+}
+
+// Decode reads one event in the kernel's layout: time, value, type, number
+func Decode(b [EventSize]byte) Event {
+	kind := b[6]
+	return Event{
+		Time:   binary.LittleEndian.Uint32(b[0:4]),
+		Value:  int16(binary.LittleEndian.Uint16(b[4:6])),
+		Button: kind&^kindInit == kindButton,
+		Init:   kind&kindInit != 0,
+		Number: b[7],
+	}
+}
+
+// Mapping says which buttons and axes give which inputs
+type Mapping struct {
+	Buttons map[uint8]Input
+	// Axes map an axis to the inputs for pushing it negative and positive
+	Axes map[uint8][2]Input
+	// Deadzone is how far an axis must move, out of 32767, to count as pushed
+	Deadzone int16
+}
+
+// DefaultMapping suits Xbox-layout pads as Linux numbers them, which most USB
+// pads follow: A confirms, B goes back, Y opens the systems, Start the help; the
+// left stick and the D-pad (axes 6 and 7) move
+func DefaultMapping() Mapping {
+	return Mapping{
+		Buttons: map[uint8]Input{0: Confirm, 1: Back, 3: Systems, 6: Back, 7: Menu},
+		Axes: map[uint8][2]Input{
+			0: {Left, Right},
+			1: {Up, Down},
+			6: {Left, Right},
+			7: {Up, Down},
+		},
+		Deadzone: 16000,
+	}
+}
+
+// Translator turns joystick events into inputs. An axis gives its input once as
+// it passes the deadzone, and again only after coming back to the middle, so a
+// held stick moves one step.
+type Translator struct {
+	mapping Mapping
+	pushed  map[uint8]int // -1, 0 or 1 for each axis
+}
+
+// NewTranslator returns a translator for a mapping
+func NewTranslator(mapping Mapping) *Translator {
+	return &Translator{mapping: mapping, pushed: make(map[uint8]int)}
+}
+
+// Translate returns the input an event gives, if any. The events sent on opening
+// only record where the axes rest.
+func (t *Translator) Translate(event Event) (Input, bool) {
+	if event.Button {
+		if event.Init || event.Value == 0 {
+			return "", false
+		}
+		input, ok := t.mapping.Buttons[event.Number]
+		return input, ok
+	}
+
+	direction := 0
+	switch {
+	case event.Value <= -t.mapping.Deadzone:
+		direction = -1
+	case event.Value >= t.mapping.Deadzone:
+		direction = 1
+	}
+	previous := t.pushed[event.Number]
+	t.pushed[event.Number] = direction
+	if event.Init || direction == 0 || direction == previous {
+		return "", false
+	}
+
+	inputs, ok := t.mapping.Axes[event.Number]
+	if !ok {
+		return "", false
+	}
+	if direction < 0 {
+		return inputs[0], true
+	}
+	return inputs[1], true
+}
@@ -0,0 +1,75 @@
+package gamepad
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func encode(value int16, kind, number uint8) [EventSize]byte {
+	var b [EventSize]byte
+	binary.LittleEndian.PutUint32(b[0:4], 1234)
+	binary.LittleEndian.PutUint16(b[4:6], uint16(value))
+	b[6] = kind
+	b[7] = number
+	return b
+}
+
+func TestDecode(t *testing.T) {
+	got := Decode(encode(-32767, kindAxis|kindInit, 1))
+	want := Event{Time: 1234, Value: -32767, Init: true, Number: 1}
+	if got != want {
+		t.Errorf("Decode = %+v, want %+v", got, want)
+	}
+	if got := Decode(encode(1, kindButton, 7)); !got.Button || got.Init || got.Number != 7 || got.Value != 1 {
+		t.Errorf("Expected a press of button 7, got %+v", got)
+	}
+}
+
+func TestTranslateButtons(t *testing.T) {
+	tr := NewTranslator(DefaultMapping())
+	if input, ok := tr.Translate(Decode(encode(1, kindButton, 0))); !ok || input != Confirm {
+		t.Errorf("Expected A to confirm, got %q, %v", input, ok)
+	}
+	if _, ok := tr.Translate(Decode(encode(0, kindButton, 0))); ok {
+		t.Error("Expected a release to give nothing")
+	}
+	if _, ok := tr.Translate(Decode(encode(1, kindButton|kindInit, 1))); ok {
+		t.Error("Expected the state on opening to give nothing")
+	}
+	if _, ok := tr.Translate(Decode(encode(1, kindButton, 12))); ok {
+		t.Error("Expected an unmapped button to give nothing")
+	}
+}
+
+func TestTranslateAxisStepsOncePerPush(t *testing.T) {
+	tr := NewTranslator(DefaultMapping())
+	var got []Input
+	for _, value := range []int16{5000, 20000, 32767, 20000, 0, 32767, -32767} {
+		if input, ok := tr.Translate(Decode(encode(value, kindAxis, 1))); ok {
+			got = append(got, input)
+		}
+	}
+	want := []Input{Down, Down, Up}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	if input, ok, err := ParseInput("  Confirm "); !ok || err != nil || input != Confirm {
+		t.Errorf("ParseInput(Confirm) = %q, %v, %v", input, ok, err)
+	}
+	for _, line := range []string{"", "# a comment"} {
+		if _, ok, err := ParseInput(line); ok || err != nil {
+			t.Errorf("ParseInput(%q) = %v, %v; want not an input", line, ok, err)
+		}
+	}
+	if _, _, err := ParseInput("jump"); err == nil {
+		t.Error("Expected an unknown input to fail")
+	}
+}
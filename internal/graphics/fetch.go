@@ -0,0 +1,230 @@
+package graphics
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // decoders for the formats pictures come in
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+const (
+	// WikipediaSource takes the lead picture of the body's Wikipedia article
+	WikipediaSource = "wikipedia"
+
+	// maxImageSize caps a downloaded picture
+	maxImageSize = 10 * 1024 * 1024
+
+	// maxImagePixels caps a picture's width times height, since a small file can
+	// declare dimensions that take gigabytes to decode. Pictures are scaled down
+	// to a few terminal cells, so this is plenty.
+	maxImagePixels = 4096 * 4096
+
+	wikipediaSummaryBase = "https://en.wikipedia.org/api/rest_v1/page/summary/"
+)
+
+// ErrNoImage is returned when the source has no picture of a body
+var ErrNoImage = errors.New("no picture of this body")
+
+// Fetcher downloads pictures of bodies and keeps them on disk, so each is only
+// downloaded once
+type Fetcher struct {
+	source string
+	dir    string
+	client *http.Client
+
+	// wikipediaBase is where article summaries are asked for; tests point it elsewhere
+	wikipediaBase string
+}
+
+// NewFetcher creates a fetcher. source is WikipediaSource (also used when empty)
+// or a URL template in which {name} is replaced by the body's English name and
+// {id} by its API id. Pictures are kept in dir; an empty dir keeps none.
+func NewFetcher(source, dir string, client *http.Client) *Fetcher {
+	if source == "" {
+		source = WikipediaSource
+	}
+	if client == nil {
+		client = &http.Client{Timeout: constants.DefaultTimeout}
+	}
+	return &Fetcher{source: source, dir: dir, client: client, wikipediaBase: wikipediaSummaryBase}
+}
+
+// DefaultCacheDir returns where pictures are kept, inside the user's cache directory
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "go-solar-system", constants.ImageCacheDirName)
+}
+
+// Fetch returns a picture of the body, from disk if it was downloaded before
+func (f *Fetcher) Fetch(body models.CelestialBody) (image.Image, error) {
+	key := f.source + "\x00" + body.ID + "\x00" + body.EnglishName
+	data, err := f.load(key)
+	downloaded := err != nil
+	if downloaded {
+		if data, err = f.download(body); err != nil {
+			return nil, err
+		}
+	}
+
+	img, err := decodePicture(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode picture of %s: %w", body.EnglishName, err)
+	}
+	if downloaded {
+		f.store(key, data)
+	}
+	return img, nil
+}
+
+// decodePicture decodes a picture, refusing one whose header gives it more than
+// maxImagePixels before any pixels are read
+func decodePicture(data []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width > maxImagePixels/config.Height {
+		return nil, fmt.Errorf("picture is %dx%d, more than %d pixels", config.Width, config.Height, maxImagePixels)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
+
+// download fetches the picture's bytes from the configured source
+func (f *Fetcher) download(body models.CelestialBody) ([]byte, error) {
+	if f.source != WikipediaSource {
+		replacer := strings.NewReplacer("{name}", url.PathEscape(body.EnglishName), "{id}", url.PathEscape(body.ID))
+		return f.get(replacer.Replace(f.source))
+	}
+
+	for _, title := range wikipediaTitles(body) {
+		imageURL, err := f.wikipediaImage(title)
+		if errors.Is(err, ErrNoImage) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return f.get(imageURL)
+	}
+	return nil, ErrNoImage
+}
+
+// wikipediaTitles are the articles that may be about a body, most specific first:
+// "Mercury (planet)" before the disambiguation page "Mercury"
+func wikipediaTitles(body models.CelestialBody) []string {
+	name := body.EnglishName
+	if name == "" {
+		return nil
+	}
+	titles := []string{}
+	if bodyType := strings.ToLower(body.BodyType); bodyType != "" {
+		titles = append(titles, fmt.Sprintf("%s (%s)", name, bodyType))
+	}
+	return append(titles, name)
+}
+
+// wikipediaImage returns the URL of an article's lead picture
+func (f *Fetcher) wikipediaImage(title string) (string, error) {
+	data, err := f.get(f.wikipediaBase + url.PathEscape(strings.ReplaceAll(title, " ", "_")))
+	if err != nil {
+		return "", err
+	}
+
+	var summary struct {
+		Type      string `json:"type"`
+		Thumbnail struct {
+			Source string `json:"source"`
+		} `json:"thumbnail"`
+	}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return "", fmt.Errorf("failed to parse Wikipedia summary of %s: %w", title, err)
+	}
+	if summary.Type == "disambiguation" || summary.Thumbnail.Source == "" {
+		return "", ErrNoImage
+	}
+	return summary.Thumbnail.Source, nil
+}
+
+// get downloads a URL. A missing page is ErrNoImage, so the next title is tried.
+func (f *Fetcher) get(address string) ([]byte, error) {
+	request, err := http.NewRequest(http.MethodGet, address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bad picture URL %q: %w", address, err)
+	}
+	request.Header.Set("User-Agent", constants.DefaultUserAgent)
+
+	response, err := f.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", address, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, ErrNoImage
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", address, response.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", address, err)
+	}
+	if len(data) > maxImageSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", address, maxImageSize)
+	}
+	return data, nil
+}
+
+// path names a cached picture after a hash of its key
+func (f *Fetcher) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".img")
+}
+
+func (f *Fetcher) load(key string) ([]byte, error) {
+	if f.dir == "" {
+		return nil, os.ErrNotExist
+	}
+	return os.ReadFile(f.path(key))
+}
+
+// store keeps a picture for later runs. Failing to is not worth reporting: it is
+// downloaded again next time.
+func (f *Fetcher) store(key string, data []byte) {
+	if f.dir == "" {
+		return
+	}
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(f.dir, "picture-*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	_, writeErr := tmp.Write(data)
+	if closeErr := tmp.Close(); writeErr != nil || closeErr != nil {
+		return
+	}
+	_ = os.Rename(tmp.Name(), f.path(key))
+}
@@ -0,0 +1,157 @@
+package graphics
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func pngBytes(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// fakeWikipedia serves a summary for "Mars" and "Io_(moon)" and a disambiguation
+// page for "Io", counting every request
+func fakeWikipedia(t *testing.T, requests *int32) *httptest.Server {
+	picture := pngBytes(t, 4, 3)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		switch r.URL.Path {
+		case "/summary/Mars", "/summary/Io_(moon)":
+			fmt.Fprintf(w, `{"type":"standard","thumbnail":{"source":%q}}`, server.URL+"/picture.png")
+		case "/summary/Io":
+			fmt.Fprint(w, `{"type":"disambiguation","thumbnail":{"source":"ignored"}}`)
+		case "/picture.png":
+			w.Write(picture)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchFromWikipedia(t *testing.T) {
+	var requests int32
+	server := fakeWikipedia(t, &requests)
+
+	tests := []struct {
+		name string
+		body models.CelestialBody
+	}{
+		{"falls back to the plain title", models.CelestialBody{EnglishName: "Mars", BodyType: "Planet"}},
+		{"prefers the specific title", models.CelestialBody{EnglishName: "Io", BodyType: "Moon"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := NewFetcher("", "", server.Client())
+			fetcher.wikipediaBase = server.URL + "/summary/"
+
+			img, err := fetcher.Fetch(tt.body)
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if img.Bounds().Dx() != 4 || img.Bounds().Dy() != 3 {
+				t.Errorf("Fetch() picture is %v, want 4x3", img.Bounds())
+			}
+		})
+	}
+}
+
+func TestFetchWithoutPicture(t *testing.T) {
+	var requests int32
+	server := fakeWikipedia(t, &requests)
+	fetcher := NewFetcher(WikipediaSource, "", server.Client())
+	fetcher.wikipediaBase = server.URL + "/summary/"
+
+	_, err := fetcher.Fetch(models.CelestialBody{EnglishName: "Arrokoth", BodyType: "Asteroid"})
+	if !errors.Is(err, ErrNoImage) {
+		t.Errorf("Fetch() error = %v, want ErrNoImage", err)
+	}
+}
+
+func TestFetchCachesOnDisk(t *testing.T) {
+	var requests int32
+	server := fakeWikipedia(t, &requests)
+	dir := t.TempDir()
+	mars := models.CelestialBody{ID: "mars", EnglishName: "Mars"}
+
+	first := NewFetcher("", dir, server.Client())
+	first.wikipediaBase = server.URL + "/summary/"
+	if _, err := first.Fetch(mars); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	downloaded := atomic.LoadInt32(&requests)
+
+	// A later run finds the picture on disk without asking again
+	second := NewFetcher("", dir, server.Client())
+	second.wikipediaBase = server.URL + "/summary/"
+	if _, err := second.Fetch(mars); err != nil {
+		t.Fatalf("Fetch() from disk error = %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != downloaded {
+		t.Errorf("Fetch() made %d more requests, want none", got-downloaded)
+	}
+}
+
+func TestFetchFromTemplate(t *testing.T) {
+	picture := pngBytes(t, 2, 2)
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		w.Write(picture)
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(server.URL+"/pictures/{id}/{name}.png", "", server.Client())
+	if _, err := fetcher.Fetch(models.CelestialBody{ID: "terre", EnglishName: "Halley's Comet"}); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if want := "/pictures/terre/Halley%27s%20Comet.png"; path != want {
+		t.Errorf("Fetch() asked for %s, want %s", path, want)
+	}
+}
+
+func TestFetchRefusesHugePictures(t *testing.T) {
+	// A tiny GIF whose header claims a 65535x65535 screen
+	var buf bytes.Buffer
+	if err := gif.Encode(&buf, image.NewPaletted(image.Rect(0, 0, 1, 1), color.Palette{color.Black}), nil); err != nil {
+		t.Fatal(err)
+	}
+	picture := buf.Bytes()
+	copy(picture[6:10], []byte{0xff, 0xff, 0xff, 0xff})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(picture)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	fetcher := NewFetcher(server.URL+"/{name}.gif", dir, server.Client())
+	_, err := fetcher.Fetch(models.CelestialBody{ID: "jupiter", EnglishName: "Jupiter"})
+	if err == nil || !strings.Contains(err.Error(), "65535x65535") {
+		t.Fatalf("Fetch() error = %v, want the picture refused for its size", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("the refused picture was kept on disk: %v", entries)
+	}
+}
@@ -0,0 +1,162 @@
+// Package graphics draws pictures of bodies in terminals that can show images,
+// through the kitty graphics protocol or sixel, and fetches and caches the
+// pictures themselves.
+package graphics
+
+import (
+	"fmt"
+	"image"
+	"strings"
+)
+
+// Protocol is a way of drawing images in a terminal
+type Protocol int
+
+const (
+	// None means the terminal cannot draw images, or they are turned off
+	None Protocol = iota
+	// Kitty is the kitty graphics protocol, also spoken by WezTerm and Ghostty
+	Kitty
+	// Sixel is DEC's sixel format, spoken by foot, mlterm, iTerm2 and others
+	Sixel
+)
+
+// String returns the protocol's setting name
+func (p Protocol) String() string {
+	switch p {
+	case Kitty:
+		return "kitty"
+	case Sixel:
+		return "sixel"
+	default:
+		return "off"
+	}
+}
+
+// Resolve turns the graphics setting into a protocol: "kitty", "sixel" or "off",
+// or "auto" (and empty) to detect one from the environment. An unknown setting
+// is an error and detects as well.
+func Resolve(setting string, getenv func(string) string) (Protocol, error) {
+	switch strings.ToLower(strings.TrimSpace(setting)) {
+	case "", "auto":
+		return Detect(getenv), nil
+	case "kitty":
+		return Kitty, nil
+	case "sixel":
+		return Sixel, nil
+	case "off", "none":
+		return None, nil
+	default:
+		return Detect(getenv), fmt.Errorf("unknown graphics setting %q (want auto, kitty, sixel or off)", setting)
+	}
+}
+
+// Detect guesses the terminal's image protocol from its environment variables.
+// Multiplexers pass neither protocol through without extra setup, so inside tmux
+// or screen it gives up.
+func Detect(getenv func(string) string) Protocol {
+	if getenv == nil {
+		return None
+	}
+	term := getenv("TERM")
+	program := getenv("TERM_PROGRAM")
+
+	if getenv("TMUX") != "" || getenv("STY") != "" || strings.HasPrefix(term, "screen") || strings.HasPrefix(term, "tmux") {
+		return None
+	}
+
+	switch {
+	case term == "xterm-kitty" || getenv("KITTY_WINDOW_ID") != "":
+		return Kitty
+	case term == "xterm-ghostty" || program == "ghostty" || program == "WezTerm":
+		return Kitty
+	case program == "iTerm.app" || program == "mlterm" || strings.Contains(term, "sixel"):
+		return Sixel
+	}
+	switch term {
+	case "foot", "foot-extra", "mlterm", "contour", "yaft-256color":
+		return Sixel
+	}
+	return None
+}
+
+// Fit works out how many cells an image covers when scaled to fit a box of cols
+// by rows cells, keeping its shape. cellWidth and cellHeight are a cell's size
+// in pixels.
+func Fit(bounds image.Rectangle, cols, rows, cellWidth, cellHeight int) (int, int) {
+	if bounds.Dx() <= 0 || bounds.Dy() <= 0 || cols <= 0 || rows <= 0 || cellWidth <= 0 || cellHeight <= 0 {
+		return 0, 0
+	}
+
+	boxWidth := float64(cols * cellWidth)
+	boxHeight := float64(rows * cellHeight)
+	scale := min(boxWidth/float64(bounds.Dx()), boxHeight/float64(bounds.Dy()))
+
+	fitCols := int(float64(bounds.Dx()) * scale / float64(cellWidth))
+	fitRows := int(float64(bounds.Dy()) * scale / float64(cellHeight))
+	return max(1, min(fitCols, cols)), max(1, min(fitRows, rows))
+}
+
+// Encode returns the escape sequence that draws img over cols by rows cells from
+// the cursor. cellWidth and cellHeight are a cell's size in pixels; the picture
+// is scaled to fill the cells.
+func Encode(protocol Protocol, img image.Image, cols, rows, cellWidth, cellHeight int) (string, error) {
+	scaled := Scale(img, cols*cellWidth, rows*cellHeight)
+	switch protocol {
+	case Kitty:
+		return encodeKitty(scaled, cols, rows)
+	case Sixel:
+		return encodeSixel(scaled), nil
+	default:
+		return "", fmt.Errorf("no image protocol to draw with")
+	}
+}
+
+// At wraps an escape sequence so that it is drawn with its top-left corner in the
+// cell at col, row (counted from 0) and the cursor is put back afterwards
+func At(col, row int, sequence string) string {
+	return fmt.Sprintf("\x1b7\x1b[%d;%dH%s\x1b8", row+1, col+1, sequence)
+}
+
+// Clear returns the escape sequence that removes images drawn with the protocol.
+// Sixel pictures are ordinary cells once drawn, so they go when the screen under
+// them is redrawn, and there is nothing to send.
+func Clear(protocol Protocol) string {
+	if protocol == Kitty {
+		return kittyDeleteAll
+	}
+	return ""
+}
+
+// Scale resizes an image to width by height pixels, averaging the source pixels
+// each one covers
+func Scale(img image.Image, width, height int) *image.RGBA {
+	out := image.NewRGBA(image.Rect(0, 0, max(width, 1), max(height, 1)))
+	src := img.Bounds()
+	if src.Empty() {
+		return out
+	}
+
+	for y := 0; y < out.Rect.Dy(); y++ {
+		y0 := src.Min.Y + y*src.Dy()/out.Rect.Dy()
+		y1 := max(y0+1, src.Min.Y+(y+1)*src.Dy()/out.Rect.Dy())
+		for x := 0; x < out.Rect.Dx(); x++ {
+			x0 := src.Min.X + x*src.Dx()/out.Rect.Dx()
+			x1 := max(x0+1, src.Min.X+(x+1)*src.Dx()/out.Rect.Dx())
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, b, a, n = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+			i := out.PixOffset(x, y)
+			out.Pix[i] = uint8(r / n >> 8)
+			out.Pix[i+1] = uint8(g / n >> 8)
+			out.Pix[i+2] = uint8(b / n >> 8)
+			out.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+	return out
+}
@@ -0,0 +1,174 @@
+package graphics
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func envOf(vars map[string]string) func(string) string {
+	return func(name string) string { return vars[name] }
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want Protocol
+	}{
+		{"kitty", map[string]string{"TERM": "xterm-kitty"}, Kitty},
+		{"kitty over ssh", map[string]string{"TERM": "xterm-256color", "KITTY_WINDOW_ID": "1"}, Kitty},
+		{"WezTerm", map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "WezTerm"}, Kitty},
+		{"Ghostty", map[string]string{"TERM": "xterm-ghostty"}, Kitty},
+		{"foot", map[string]string{"TERM": "foot"}, Sixel},
+		{"iTerm2", map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "iTerm.app"}, Sixel},
+		{"xterm with sixel terminfo", map[string]string{"TERM": "xterm-sixel"}, Sixel},
+		{"plain xterm", map[string]string{"TERM": "xterm-256color"}, None},
+		{"kitty inside tmux", map[string]string{"TERM": "tmux-256color", "KITTY_WINDOW_ID": "1", "TMUX": "/tmp/tmux"}, None},
+		{"screen", map[string]string{"TERM": "screen", "TERM_PROGRAM": "WezTerm"}, None},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(envOf(tt.env)); got != tt.want {
+				t.Errorf("Detect() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	kittyEnv := envOf(map[string]string{"TERM": "xterm-kitty"})
+	tests := []struct {
+		setting string
+		want    Protocol
+		wantErr bool
+	}{
+		{"", Kitty, false},
+		{"auto", Kitty, false},
+		{"sixel", Sixel, false},
+		{" Kitty ", Kitty, false},
+		{"off", None, false},
+		{"iterm", Kitty, true},
+	}
+
+	for _, tt := range tests {
+		got, err := Resolve(tt.setting, kittyEnv)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Resolve(%q) error = %v, wantErr %v", tt.setting, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("Resolve(%q) = %s, want %s", tt.setting, got, tt.want)
+		}
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name               string
+		width, height      int
+		cols, rows, cw, ch int
+		wantCols, wantRows int
+	}{
+		{name: "square picture in a wide box", width: 100, height: 100, cols: 20, rows: 8, cw: 10, ch: 20, wantCols: 16, wantRows: 8},
+		{name: "wide picture in a wide box", width: 400, height: 100, cols: 20, rows: 8, cw: 10, ch: 20, wantCols: 20, wantRows: 2},
+		{name: "empty picture", width: 0, height: 0, cols: 20, rows: 8, cw: 10, ch: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cols, rows := Fit(image.Rect(0, 0, tt.width, tt.height), tt.cols, tt.rows, tt.cw, tt.ch)
+			if cols != tt.wantCols || rows != tt.wantRows {
+				t.Errorf("Fit() = %dx%d, want %dx%d", cols, rows, tt.wantCols, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestScaleAverages(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	src.Set(0, 0, color.RGBA{R: 255, A: 255})
+	src.Set(1, 0, color.RGBA{R: 255, A: 255})
+	src.Set(0, 1, color.RGBA{A: 255})
+	src.Set(1, 1, color.RGBA{A: 255})
+
+	got := Scale(src, 1, 1).RGBAAt(0, 0)
+	if got.R < 126 || got.R > 128 || got.A != 255 {
+		t.Errorf("Scale() = %v, want half red and opaque", got)
+	}
+}
+
+func TestAt(t *testing.T) {
+	got := At(4, 2, "IMG")
+	if got != "\x1b7\x1b[3;5HIMG\x1b8" {
+		t.Errorf("At() = %q", got)
+	}
+}
+
+func TestEncodeKittyChunks(t *testing.T) {
+	// Noise compresses badly, so the PNG needs several chunks
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	random := rand.New(rand.NewSource(1))
+	random.Read(img.Pix)
+
+	sequence, err := Encode(Kitty, img, 4, 2, 16, 32)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	chunks := strings.Split(strings.TrimSuffix(sequence, "\x1b\\"), "\x1b\\")
+	if len(chunks) < 2 {
+		t.Fatalf("Encode() sent %d chunk, want several", len(chunks))
+	}
+	if !strings.HasPrefix(chunks[0], "\x1b_Ga=T,f=100,q=2,C=1,c=4,r=2,m=1;") {
+		t.Errorf("first chunk starts %q", chunks[0][:40])
+	}
+	if !strings.HasPrefix(chunks[len(chunks)-1], "\x1b_Gm=0;") {
+		t.Errorf("last chunk starts %q, want m=0", chunks[len(chunks)-1][:8])
+	}
+	for i, chunk := range chunks {
+		if payload := chunk[strings.IndexByte(chunk, ';')+1:]; len(payload) > kittyChunkSize {
+			t.Errorf("chunk %d carries %d bytes, more than %d", i, len(payload), kittyChunkSize)
+		}
+	}
+}
+
+func TestEncodeSixel(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 12))
+	for y := 0; y < 12; y++ {
+		for x := 0; x < 8; x++ {
+			img.Set(x, y, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+		}
+	}
+	img.Set(0, 0, color.RGBA{}) // transparent, left out
+
+	sequence, err := Encode(Sixel, img, 1, 1, 8, 12)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !strings.HasPrefix(sequence, "\x1bP0;1;0q\"1;1;8;12") || !strings.HasSuffix(sequence, "\x1b\\") {
+		t.Fatalf("Encode() = %q, want a sixel sequence", sequence)
+	}
+	if got := strings.Count(sequence, "-"); got != 2 {
+		t.Errorf("Encode() has %d bands, want 2", got)
+	}
+	// White is the last web-safe colour; the first band misses the corner pixel
+	if !strings.Contains(sequence, "#215}!7~$-") {
+		t.Errorf("Encode() first band is not white with the corner left out: %q", sequence)
+	}
+}
+
+func TestEncodeWithoutProtocol(t *testing.T) {
+	if _, err := Encode(None, image.NewRGBA(image.Rect(0, 0, 1, 1)), 1, 1, 1, 1); err == nil {
+		t.Error("Encode(None) did not fail")
+	}
+}
+
+func TestWriteSixelRowRunLength(t *testing.T) {
+	var out strings.Builder
+	writeSixelRow(&out, []byte("~~~~~??@"))
+	if got := out.String(); got != "!5~??@" {
+		t.Errorf("writeSixelRow() = %q, want %q", got, "!5~??@")
+	}
+}
@@ -0,0 +1,46 @@
+package graphics
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+)
+
+const (
+	// kittyChunkSize is the most base64 a single kitty escape may carry
+	kittyChunkSize = 4096
+
+	// kittyDeleteAll removes every image placement, freeing the image data too
+	kittyDeleteAll = "\x1b_Ga=d,d=A,q=2\x1b\\"
+)
+
+// encodeKitty sends img as a PNG to be shown over cols by rows cells. C=1 keeps
+// the cursor still and q=2 stops the terminal answering, which would otherwise
+// arrive as keyboard input.
+func encodeKitty(img image.Image, cols, rows int) (string, error) {
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		return "", fmt.Errorf("failed to encode image: %w", err)
+	}
+	data := base64.StdEncoding.EncodeToString(encoded.Bytes())
+
+	var out strings.Builder
+	for first := true; first || data != ""; first = false {
+		chunk := data[:min(len(data), kittyChunkSize)]
+		data = data[len(chunk):]
+
+		more := 0
+		if data != "" {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(&out, "\x1b_Ga=T,f=100,q=2,C=1,c=%d,r=%d,m=%d;%s\x1b\\", cols, rows, more, chunk)
+		} else {
+			fmt.Fprintf(&out, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,79 @@
+package graphics
+
+import (
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"strings"
+)
+
+// encodeSixel draws img as sixels: the picture is dithered to the web-safe
+// palette, then sent six pixel rows at a time, one pass per colour in each band.
+// Pixels that are mostly transparent are left out and keep what is behind them.
+func encodeSixel(img *image.RGBA) string {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	paletted := image.NewPaletted(bounds, palette.WebSafe)
+	draw.FloydSteinberg.Draw(paletted, bounds, img, bounds.Min)
+
+	var out strings.Builder
+	// P2=1 keeps pixels that are not drawn; the raster sets a 1:1 pixel shape
+	fmt.Fprintf(&out, "\x1bP0;1;0q\"1;1;%d;%d", width, height)
+	for i, c := range palette.WebSafe {
+		r, g, b, _ := c.RGBA()
+		fmt.Fprintf(&out, "#%d;2;%d;%d;%d", i, r*100/0xffff, g*100/0xffff, b*100/0xffff)
+	}
+
+	row := make([]byte, width)
+	for top := 0; top < height; top += 6 {
+		used := make([]bool, len(palette.WebSafe))
+		for y := top; y < min(top+6, height); y++ {
+			for x := 0; x < width; x++ {
+				if img.Pix[img.PixOffset(bounds.Min.X+x, bounds.Min.Y+y)+3] >= 0x80 {
+					used[paletted.ColorIndexAt(bounds.Min.X+x, bounds.Min.Y+y)] = true
+				}
+			}
+		}
+
+		for index, inBand := range used {
+			if !inBand {
+				continue
+			}
+			for x := 0; x < width; x++ {
+				bits := byte(0)
+				for dy := 0; dy < 6 && top+dy < height; dy++ {
+					px, py := bounds.Min.X+x, bounds.Min.Y+top+dy
+					if img.Pix[img.PixOffset(px, py)+3] >= 0x80 && int(paletted.ColorIndexAt(px, py)) == index {
+						bits |= 1 << dy
+					}
+				}
+				row[x] = '?' + bits
+			}
+			fmt.Fprintf(&out, "#%d", index)
+			writeSixelRow(&out, row)
+			out.WriteByte('$')
+		}
+		out.WriteByte('-')
+	}
+
+	out.WriteString("\x1b\\")
+	return out.String()
+}
+
+// writeSixelRow writes a row of sixel characters, run-length encoding repeats
+func writeSixelRow(out *strings.Builder, row []byte) {
+	for start := 0; start < len(row); {
+		end := start + 1
+		for end < len(row) && row[end] == row[start] {
+			end++
+		}
+		if count := end - start; count > 3 {
+			fmt.Fprintf(out, "!%d%c", count, row[start])
+		} else {
+			out.WriteString(strings.Repeat(string(row[start]), count))
+		}
+		start = end
+	}
+}
@@ -0,0 +1,68 @@
+package inputlog
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// EnvironmentFileName is the file in a bug report describing where it was made
+const EnvironmentFileName = "environment.txt"
+
+// WriteBugReport writes a gzipped tarball to w holding a description of env and
+// each of files that exists, by its base name. It returns the files it found.
+func WriteBugReport(w io.Writer, env Environment, files []string) ([]string, error) {
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+
+	description := []byte(env.String())
+	if err := writeEntry(archive, EnvironmentFileName, int64(len(description)), env.Time, func(w io.Writer) error {
+		_, err := w.Write(description)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	var included []string
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return included, err
+		}
+		if err := writeEntry(archive, filepath.Base(path), info.Size(), info.ModTime(), func(w io.Writer) error {
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			_, err = io.CopyN(w, file, info.Size())
+			return err
+		}); err != nil {
+			return included, fmt.Errorf("failed to add %s: %w", path, err)
+		}
+		included = append(included, path)
+	}
+
+	if err := archive.Close(); err != nil {
+		return included, err
+	}
+	return included, gz.Close()
+}
+
+// writeEntry adds one file of size bytes, last changed at modified, to the
+// archive, its contents written by write
+func writeEntry(archive *tar.Writer, name string, size int64, modified time.Time, write func(io.Writer) error) error {
+	if err := archive.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: size, ModTime: modified}); err != nil {
+		return err
+	}
+	return write(archive)
+}
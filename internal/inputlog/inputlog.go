@@ -0,0 +1,286 @@
+// Package inputlog records, when asked to, every key, mouse and resize event a
+// session receives along with the terminal it ran in, one JSON entry per line,
+// so a rendering bug can be played back the way it happened.
+package inputlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Kinds of entry
+const (
+	KindEnvironment = "env"    // the first line: where the session ran
+	KindKey         = "key"    // a key press
+	KindMouse       = "mouse"  // a click, drag, wheel turn or move
+	KindResize      = "resize" // the terminal changed size
+)
+
+// filePrefix and fileExt name recordings, e.g. input-20260102-150405.jsonl
+const (
+	filePrefix = "input-"
+	fileExt    = ".jsonl"
+)
+
+// Environment is what the terminal and system a session ran in looked like
+type Environment struct {
+	Time        time.Time `json:"time"`
+	Width       int       `json:"width,omitempty"`
+	Height      int       `json:"height,omitempty"`
+	Term        string    `json:"term"`
+	ColorTerm   string    `json:"colorTerm,omitempty"`
+	TermProgram string    `json:"termProgram,omitempty"`
+	Locale      string    `json:"locale,omitempty"`
+	OS          string    `json:"os"`
+	Arch        string    `json:"arch"`
+	GoVersion   string    `json:"goVersion"`
+}
+
+// CurrentEnvironment describes this process's terminal, width by height cells,
+// at now
+func CurrentEnvironment(width, height int, now time.Time) Environment {
+	return Environment{
+		Time:        now,
+		Width:       width,
+		Height:      height,
+		Term:        os.Getenv("TERM"),
+		ColorTerm:   os.Getenv("COLORTERM"),
+		TermProgram: os.Getenv("TERM_PROGRAM"),
+		Locale:      locale(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		GoVersion:   runtime.Version(),
+	}
+}
+
+// locale returns the locale the terminal's text is decoded in, taking the
+// variables in the order the C library does
+func locale() string {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// String writes the environment out one "name: value" line at a time, for people
+func (e Environment) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "time: %s\n", e.Time.Format(time.RFC3339))
+	if e.Width > 0 {
+		fmt.Fprintf(&b, "size: %dx%d\n", e.Width, e.Height)
+	}
+	fmt.Fprintf(&b, "TERM: %s\n", e.Term)
+	fmt.Fprintf(&b, "COLORTERM: %s\n", e.ColorTerm)
+	fmt.Fprintf(&b, "TERM_PROGRAM: %s\n", e.TermProgram)
+	fmt.Fprintf(&b, "locale: %s\n", e.Locale)
+	fmt.Fprintf(&b, "os: %s/%s\n", e.OS, e.Arch)
+	fmt.Fprintf(&b, "go: %s\n", e.GoVersion)
+	return b.String()
+}
+
+// Entry is one line of a recording. Ms is how long after the recording started
+// the event came; the other fields are filled in as its kind needs.
+type Entry struct {
+	Ms   int64  `json:"ms"`
+	Kind string `json:"kind"`
+
+	Env *Environment `json:"env,omitempty"`
+
+	// Keys: tcell's key code, the rune for rune keys and the modifiers, plus the
+	// key's name, e.g. "Rune[m]" or "Ctrl+P", for reading the file by eye
+	Name string        `json:"name,omitempty"`
+	Key  tcell.Key     `json:"key,omitempty"`
+	Rune string        `json:"rune,omitempty"`
+	Mods tcell.ModMask `json:"mods,omitempty"`
+
+	// Mouse events: the cell and the buttons held, with Mods
+	X       int              `json:"x,omitempty"`
+	Y       int              `json:"y,omitempty"`
+	Buttons tcell.ButtonMask `json:"buttons,omitempty"`
+
+	// Resizes
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+}
+
+// Event rebuilds the event an entry recorded, or returns nil for the environment
+func (e Entry) Event() tcell.Event {
+	switch e.Kind {
+	case KindKey:
+		r, _ := firstRune(e.Rune)
+		return tcell.NewEventKey(e.Key, r, e.Mods)
+	case KindMouse:
+		return tcell.NewEventMouse(e.X, e.Y, e.Buttons, e.Mods)
+	case KindResize:
+		return tcell.NewEventResize(e.Width, e.Height)
+	}
+	return nil
+}
+
+func firstRune(s string) (rune, bool) {
+	for _, r := range s {
+		return r, true
+	}
+	return 0, false
+}
+
+// Recorder appends the events it is given to a recording. It is safe for
+// concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	file    *os.File
+	started time.Time
+	closed  bool
+}
+
+// DefaultDir returns where recordings are kept: beside the log in the user's
+// cache directory
+func DefaultDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "go-solar-system", "input")
+}
+
+// Create starts a recording in dir named after when env was taken, writing env
+// as its first line. Older recordings past the newest few are removed.
+func Create(dir string, env Environment) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create input recording directory: %w", err)
+	}
+	path := filepath.Join(dir, filePrefix+env.Time.Format("20060102-150405")+fileExt)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create input recording %s: %w", path, err)
+	}
+
+	r := &Recorder{file: file, started: env.Time}
+	if err := r.write(Entry{Kind: KindEnvironment, Env: &env}); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write input recording %s: %w", path, err)
+	}
+	prune(dir, constants.InputRecordingsKept)
+	return r, nil
+}
+
+// Path returns the file being recorded to
+func (r *Recorder) Path() string {
+	return r.file.Name()
+}
+
+// Record appends a key, mouse or resize event; other events are not input and
+// are skipped
+func (r *Recorder) Record(ev tcell.Event) {
+	entry := Entry{}
+	switch ev := ev.(type) {
+	case *tcell.EventKey:
+		entry = Entry{Kind: KindKey, Name: ev.Name(), Key: ev.Key(), Mods: ev.Modifiers()}
+		if ev.Key() == tcell.KeyRune {
+			entry.Rune = string(ev.Rune())
+		}
+	case *tcell.EventMouse:
+		x, y := ev.Position()
+		entry = Entry{Kind: KindMouse, X: x, Y: y, Buttons: ev.Buttons(), Mods: ev.Modifiers()}
+	case *tcell.EventResize:
+		width, height := ev.Size()
+		entry = Entry{Kind: KindResize, Width: width, Height: height}
+	default:
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	entry.Ms = ev.When().Sub(r.started).Milliseconds()
+	// Written straight through, so a crash loses nothing that led up to it.
+	// Recording is best effort: an entry that cannot be written is dropped rather
+	// than disturbing the app.
+	_ = r.write(entry)
+}
+
+// Close finishes the recording
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	return r.file.Close()
+}
+
+// write appends an entry as one line
+func (r *Recorder) write(entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = r.file.Write(append(line, '\n'))
+	return err
+}
+
+// Read parses a recording, returning the environment it was made in and the
+// events after it
+func Read(input io.Reader) (Environment, []Entry, error) {
+	var env Environment
+	var entries []Entry
+	scanner := bufio.NewScanner(input)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return env, entries, fmt.Errorf("line %d: %w", line, err)
+		}
+		if entry.Kind == KindEnvironment && entry.Env != nil {
+			env = *entry.Env
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return env, entries, scanner.Err()
+}
+
+// recordings lists the recordings in dir, oldest first
+func recordings(dir string) []string {
+	matches, _ := filepath.Glob(filepath.Join(dir, filePrefix+"*"+fileExt))
+	// The names sort by when they were started
+	sort.Strings(matches)
+	return matches
+}
+
+// Latest returns the most recent recording in dir, or false if there is none
+func Latest(dir string) (string, bool) {
+	all := recordings(dir)
+	if len(all) == 0 {
+		return "", false
+	}
+	return all[len(all)-1], true
+}
+
+// prune removes all but the newest keep recordings in dir
+func prune(dir string, keep int) {
+	all := recordings(dir)
+	for len(all) > keep {
+		_ = os.Remove(all[0])
+		all = all[1:]
+	}
+}
@@ -0,0 +1,138 @@
+package inputlog
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/gdamore/tcell/v2"
+)
+
+var started = time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+
+func TestRecordAndRead(t *testing.T) {
+	dir := t.TempDir()
+	env := Environment{Time: time.Now(), Width: 120, Height: 40, Term: "xterm-256color", Locale: "en_GB.UTF-8", OS: "linux", Arch: "amd64", GoVersion: "go1.22"}
+	recorder, err := Create(dir, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := filepath.Base(recorder.Path()), "input-"+env.Time.Format("20060102-150405")+".jsonl"; got != want {
+		t.Errorf("recording named %s, want %s", got, want)
+	}
+
+	events := []tcell.Event{
+		tcell.NewEventKey(tcell.KeyRune, 'm', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyCtrlP, 0, tcell.ModCtrl),
+		tcell.NewEventMouse(12, 7, tcell.Button1, tcell.ModShift),
+		tcell.NewEventResize(80, 24),
+	}
+	for _, ev := range events {
+		recorder.Record(ev)
+	}
+	recorder.Record(tcell.NewEventInterrupt(nil))
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+	recorder.Record(events[0])
+
+	file, err := os.Open(recorder.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gotEnv, entries, err := Read(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gotEnv.Time.Equal(env.Time) || gotEnv.Term != env.Term || gotEnv.Width != 120 {
+		t.Errorf("environment = %+v, want %+v", gotEnv, env)
+	}
+	if len(entries) != len(events) {
+		t.Fatalf("read %d entries, want %d: %+v", len(entries), len(events), entries)
+	}
+	if entries[0].Name != "Rune[m]" || entries[0].Ms < 0 || entries[3].Ms < entries[0].Ms {
+		t.Errorf("entries = %+v", entries)
+	}
+
+	// Every entry plays back as the event it recorded
+	key := entries[0].Event().(*tcell.EventKey)
+	if key.Key() != tcell.KeyRune || key.Rune() != 'm' {
+		t.Errorf("key played back as %s", key.Name())
+	}
+	if ctrl := entries[1].Event().(*tcell.EventKey); ctrl.Key() != tcell.KeyCtrlP {
+		t.Errorf("Ctrl+P played back as %s", ctrl.Name())
+	}
+	mouse := entries[2].Event().(*tcell.EventMouse)
+	if x, y := mouse.Position(); x != 12 || y != 7 || mouse.Buttons() != tcell.Button1 || mouse.Modifiers() != tcell.ModShift {
+		t.Errorf("click played back at %d,%d with %v %v", x, y, mouse.Buttons(), mouse.Modifiers())
+	}
+	if w, h := entries[3].Event().(*tcell.EventResize).Size(); w != 80 || h != 24 {
+		t.Errorf("resize played back as %dx%d", w, h)
+	}
+}
+
+func TestCreateKeepsTheNewestRecordings(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < constants.InputRecordingsKept+2; i++ {
+		recorder, err := Create(dir, Environment{Time: started.Add(time.Duration(i) * time.Minute)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		recorder.Close()
+	}
+
+	if got := len(recordings(dir)); got != constants.InputRecordingsKept {
+		t.Errorf("kept %d recordings, want %d", got, constants.InputRecordingsKept)
+	}
+	latest, ok := Latest(dir)
+	if want := "input-20260314-151526.jsonl"; !ok || filepath.Base(latest) != want {
+		t.Errorf("Latest() = %s, %v, want %s", latest, ok, want)
+	}
+}
+
+func TestWriteBugReport(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "solar-system.log")
+	if err := os.WriteFile(logPath, []byte("[SolarSystem] started\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	env := Environment{Time: started, Term: "xterm"}
+	included, err := WriteBugReport(&buf, env, []string{logPath, filepath.Join(dir, "solar-system.log.1")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(included, []string{logPath}) {
+		t.Errorf("included %v, want only the log that exists", included)
+	}
+
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := tar.NewReader(gz)
+	contents := map[string]string{}
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(archive)
+		contents[header.Name] = string(data)
+	}
+	if contents["solar-system.log"] != "[SolarSystem] started\n" || contents[EnvironmentFileName] != env.String() {
+		t.Errorf("bug report holds %q", contents)
+	}
+}
@@ -4,6 +4,7 @@ package interfaces
 
 import (
 	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/visualization"
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -70,7 +71,7 @@ type Screen interface {
 
 // CircleDrawer defines the interface for drawing circular shapes
 type CircleDrawer interface {
-	DrawCircle(grid [][]rune, centerX, centerY int, radius float64, symbol rune)
+	DrawCircle(grid *visualization.Grid, centerX, centerY int, radius float64, symbol rune)
 }
 
 // DistanceScaler defines the interface for scaling astronomical distances
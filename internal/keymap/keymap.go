@@ -0,0 +1,373 @@
+// Package keymap is the registry of keyboard bindings. Every remappable key the
+// app responds to is declared here once, so that dispatch, the help screen and
+// the instruction bar all read from the same table.
+package keymap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Context is the screen a binding applies in
+type Context string
+
+const (
+	// ContextGlobal bindings work everywhere, including inside modals
+	ContextGlobal  Context = "Anywhere"
+	ContextMain    Context = "Main view"
+	ContextDetails Context = "Planet details"
+)
+
+// contextOrder is the order contexts are listed in the help screen
+var contextOrder = []Context{ContextGlobal, ContextMain, ContextDetails}
+
+// Action names something the user can do; the names are used in the config file
+type Action string
+
+const (
+	ActionScreenshot Action = "screenshot"
+	ActionDebug      Action = "debug"
+
+	ActionQuit         Action = "quit"
+	ActionHelp         Action = "help"
+	ActionPrevious     Action = "previous"
+	ActionNext         Action = "next"
+	ActionPagePrevious Action = "page_previous"
+	ActionPageNext     Action = "page_next"
+	ActionSelect       Action = "select"
+	ActionSelectNumber Action = "select_number"
+	ActionSystems      Action = "systems"
+	ActionQuiz         Action = "quiz"
+	ActionEvents       Action = "events"
+	ActionSort         Action = "sort"
+	ActionGroup        Action = "group"
+	ActionMission      Action = "mission"
+	ActionConjunctions Action = "conjunctions"
+	ActionStats        Action = "stats"
+	ActionCalibrate    Action = "calibrate"
+	ActionWatchlist    Action = "watchlist"
+	ActionWeight       Action = "weight"
+	ActionChart        Action = "chart"
+	ActionTree         Action = "tree"
+	ActionScaleModel   Action = "scale_model"
+	ActionLaunch       Action = "launch"
+	ActionGalaxy       Action = "galaxy"
+	ActionDiagnostics  Action = "diagnostics"
+	ActionLegend       Action = "legend"
+	ActionAPIStatus    Action = "api_status"
+	ActionFilter       Action = "filter"
+	ActionCompare      Action = "compare"
+	ActionFocus        Action = "focus"
+	ActionMapNext      Action = "map_next"
+	ActionMapPrevious  Action = "map_previous"
+	ActionTab          Action = "tab"
+	ActionPalette      Action = "palette"
+	ActionResonances   Action = "resonances"
+	ActionWobble       Action = "wobble"
+	ActionHabitable    Action = "habitable_zone"
+	ActionSpheres      Action = "spheres"
+	ActionEvolution    Action = "evolution"
+	ActionView         Action = "view"
+
+	ActionClose        Action = "close"
+	ActionMoons        Action = "moons"
+	ActionEditElements Action = "edit_elements"
+	ActionWatch        Action = "watch"
+	ActionTransit      Action = "transit"
+	ActionCopy         Action = "copy"
+	ActionCopyJSON     Action = "copy_json"
+	ActionRawJSON      Action = "raw_json"
+	ActionUnits        Action = "units"
+)
+
+// Key is a single key press: either a special key or a rune
+type Key struct {
+	Code tcell.Key
+	Rune rune
+}
+
+// RuneKey returns the key for a printable character
+func RuneKey(r rune) Key {
+	return Key{Code: tcell.KeyRune, Rune: r}
+}
+
+// SpecialKey returns the key for a non-printable key such as F12 or Enter
+func SpecialKey(code tcell.Key) Key {
+	return Key{Code: code}
+}
+
+// FromEvent returns the key of a key event
+func FromEvent(ev *tcell.EventKey) Key {
+	if ev.Key() == tcell.KeyRune {
+		return RuneKey(ev.Rune())
+	}
+	return SpecialKey(ev.Key())
+}
+
+// String returns the key as written in the config file and shown in help
+func (k Key) String() string {
+	if k.Code == tcell.KeyRune {
+		if k.Rune == ' ' {
+			return "Space"
+		}
+		return string(k.Rune)
+	}
+	if name, ok := tcell.KeyNames[k.Code]; ok {
+		return name
+	}
+	return fmt.Sprintf("Key(%d)", k.Code)
+}
+
+// ParseKey reads a key name such as "x", "F12", "Enter", "Esc" or "Ctrl-P"
+func ParseKey(name string) (Key, error) {
+	if name == "" {
+		return Key{}, fmt.Errorf("empty key name")
+	}
+	if runes := []rune(name); len(runes) == 1 {
+		return RuneKey(runes[0]), nil
+	}
+	if strings.EqualFold(name, "Space") {
+		return RuneKey(' '), nil
+	}
+
+	normalized := strings.ReplaceAll(name, "+", "-")
+	for code, keyName := range tcell.KeyNames {
+		if strings.EqualFold(keyName, normalized) {
+			return SpecialKey(code), nil
+		}
+	}
+	if strings.EqualFold(normalized, "Escape") {
+		return SpecialKey(tcell.KeyEscape), nil
+	}
+
+	return Key{}, fmt.Errorf("unknown key %q", name)
+}
+
+// Binding ties an action in one context to the keys that trigger it
+type Binding struct {
+	Action      Action
+	Context     Context
+	Keys        []Key
+	Description string
+	// Fixed bindings are listed in help but cannot be remapped
+	Fixed bool
+}
+
+// Label returns the binding's keys for display, folding a letter bound in both
+// cases into its capital ("q" and "Q" show as "Q")
+func (b Binding) Label() string {
+	present := make(map[Key]bool, len(b.Keys))
+	for _, key := range b.Keys {
+		present[key] = true
+	}
+
+	var labels []string
+	for _, key := range b.Keys {
+		if key.Code == tcell.KeyRune && unicode.IsLetter(key.Rune) {
+			upper, lower := unicode.ToUpper(key.Rune), unicode.ToLower(key.Rune)
+			if present[RuneKey(upper)] && present[RuneKey(lower)] {
+				if key.Rune == lower {
+					labels = append(labels, string(upper))
+				}
+				continue
+			}
+		}
+		labels = append(labels, key.String())
+	}
+	return strings.Join(labels, "/")
+}
+
+// Keymap is the set of active bindings
+type Keymap struct {
+	bindings []Binding
+	lookup   map[Context]map[Key]Action
+}
+
+// Default returns the built-in bindings
+func Default() *Keymap {
+	runes := func(rs ...rune) []Key {
+		keys := make([]Key, len(rs))
+		for i, r := range rs {
+			keys[i] = RuneKey(r)
+		}
+		return keys
+	}
+
+	km := &Keymap{bindings: []Binding{
+		{Action: ActionScreenshot, Context: ContextGlobal, Keys: []Key{SpecialKey(tcell.KeyF12)}, Description: "Save a screenshot bundle"},
+		{Action: ActionDebug, Context: ContextGlobal, Keys: []Key{SpecialKey(tcell.KeyF9)}, Description: "Toggle the debug overlay"},
+
+		{Action: ActionPrevious, Context: ContextMain, Keys: []Key{SpecialKey(tcell.KeyUp)}, Description: "Previous body"},
+		{Action: ActionNext, Context: ContextMain, Keys: []Key{SpecialKey(tcell.KeyDown)}, Description: "Next body"},
+		{Action: ActionPagePrevious, Context: ContextMain, Keys: []Key{SpecialKey(tcell.KeyLeft), SpecialKey(tcell.KeyPgUp)}, Description: "Previous page of the body list"},
+		{Action: ActionPageNext, Context: ContextMain, Keys: []Key{SpecialKey(tcell.KeyRight), SpecialKey(tcell.KeyPgDn)}, Description: "Next page of the body list"},
+		{Action: ActionSelect, Context: ContextMain, Keys: []Key{SpecialKey(tcell.KeyEnter)}, Description: "Show details of the selected body"},
+		{Action: ActionSelectNumber, Context: ContextMain, Keys: runes('1', '2', '3', '4', '5', '6', '7', '8', '9'), Description: "Jump straight to a body's details", Fixed: true},
+		{Action: ActionSystems, Context: ContextMain, Keys: runes('s', 'S'), Description: "Switch star system"},
+		{Action: ActionGalaxy, Context: ContextMain, Keys: runes('g', 'G'), Description: "Galaxy map: pick a system by where it is"},
+		{Action: ActionHelp, Context: ContextMain, Keys: runes('h', 'H', '?'), Description: "Show this help"},
+		{Action: ActionPalette, Context: ContextMain, Keys: []Key{SpecialKey(tcell.KeyCtrlP)}, Description: "Command palette: find any action, body, system or theme by name"},
+		{Action: ActionQuiz, Context: ContextMain, Keys: runes('z', 'Z'), Description: "Quiz mode"},
+		{Action: ActionEvents, Context: ContextMain, Keys: runes('e', 'E'), Description: "Upcoming orbital events"},
+		{Action: ActionMission, Context: ContextMain, Keys: runes('d', 'D'), Description: "Mission planner: transfer Δv, travel time, launch window"},
+		{Action: ActionConjunctions, Context: ContextMain, Keys: runes('j', 'J'), Description: "Conjunction finder: when chosen planets next gather in the sky"},
+		{Action: ActionStats, Context: ContextMain, Keys: runes('i', 'I'), Description: "System statistics and known object counts"},
+		{Action: ActionCompare, Context: ContextMain, Keys: runes('c', 'C'), Description: "Compare with another system side by side, or stop comparing"},
+		{Action: ActionFocus, Context: ContextMain, Keys: runes('x', 'X'), Description: "Centre the map on the selected planet and its moons, or on the star again"},
+		{Action: ActionMapNext, Context: ContextMain, Keys: runes(']'), Description: "Focus the next body on the map, clockwise round the star"},
+		{Action: ActionMapPrevious, Context: ContextMain, Keys: runes('['), Description: "Focus the previous body on the map, anticlockwise"},
+		{Action: ActionResonances, Context: ContextMain, Keys: runes('r', 'R'), Description: "Show or hide links between orbits in resonance, labelled with their period ratio"},
+		{Action: ActionWobble, Context: ContextMain, Keys: runes('b', 'B'), Description: "Show or hide the star's wobble round the barycenter, exaggerated, with its speed"},
+		{Action: ActionHabitable, Context: ContextMain, Keys: runes('t'), Description: "Show or hide the habitable zone, where a planet could keep liquid water"},
+		{Action: ActionSpheres, Context: ContextMain, Keys: runes('u', 'U'), Description: "Show or hide the selected body's Hill sphere and Roche limit as faint circles"},
+		{Action: ActionTree, Context: ContextMain, Keys: runes('T'), Description: "Hierarchy: the star, its planets and their moons as a tree"},
+		{Action: ActionEvolution, Context: ContextMain, Keys: runes('f', 'F'), Description: "Fast-forward the star's life, from main sequence to giant to remnant, or stop"},
+		{Action: ActionView, Context: ContextMain, Keys: runes('v', 'V'), Description: "Switch between the orbit map and a strip of bodies by distance"},
+		{Action: ActionTab, Context: ContextMain, Keys: []Key{SpecialKey(tcell.KeyTab)}, Description: "Next list tab: planets, moons, asteroids, comets. While comparing, the other system"},
+		{Action: ActionWatchlist, Context: ContextMain, Keys: runes('w', 'W'), Description: "Watchlist: bodies checked for changes in the API data"},
+		{Action: ActionWeight, Context: ContextMain, Keys: runes('k', 'K'), Description: "What would I weigh on each body?"},
+		{Action: ActionChart, Context: ContextMain, Keys: runes('p', 'P'), Description: "Bar chart of the bodies' masses or radii, on a log scale"},
+		{Action: ActionScaleModel, Context: ContextMain, Keys: runes('m', 'M'), Description: "Scale model: the system shrunk so the star is a football, or any size"},
+		{Action: ActionLaunch, Context: ContextMain, Keys: runes('a', 'A'), Description: "Launch game: escape, orbit or fall back?"},
+		{Action: ActionDiagnostics, Context: ContextMain, Keys: runes('L'), Description: "Physics diagnostics: periods that break Kepler's third law"},
+		{Action: ActionLegend, Context: ContextMain, Keys: runes('l'), Description: "Legend: what each symbol and color on the map stands for"},
+		{Action: ActionAPIStatus, Context: ContextMain, Keys: runes('n', 'N'), Description: "API status: is it reachable, when it last answered, caches and endpoints"},
+		{Action: ActionCalibrate, Context: ContextMain, Keys: []Key{SpecialKey(tcell.KeyF8)}, Description: "Calibrate the orbit shape for your font"},
+		{Action: ActionFilter, Context: ContextMain, Keys: runes('/'), Description: "Filter the list, e.g. mass>1e24 && moons>=2"},
+		{Action: ActionSort, Context: ContextMain, Keys: runes('o'), Description: "Cycle the planet list order"},
+		{Action: ActionGroup, Context: ContextMain, Keys: runes('O'), Description: "Group the planet list by body type"},
+		{Action: ActionQuit, Context: ContextMain, Keys: append(runes('q', 'Q'), SpecialKey(tcell.KeyEscape), SpecialKey(tcell.KeyCtrlC)), Description: "Quit"},
+
+		{Action: ActionClose, Context: ContextDetails, Keys: append(runes('b', 'B', 'q', 'Q'), SpecialKey(tcell.KeyEscape), SpecialKey(tcell.KeyEnter)), Description: "Close the details"},
+		{Action: ActionMoons, Context: ContextDetails, Keys: runes('m', 'M'), Description: "List the body's moons"},
+		{Action: ActionEditElements, Context: ContextDetails, Keys: runes('e', 'E'), Description: "Edit orbital elements (system files only)"},
+		{Action: ActionWatch, Context: ContextDetails, Keys: runes('w', 'W'), Description: "Watch or unwatch the body for changes in the API data"},
+		{Action: ActionTransit, Context: ContextDetails, Keys: runes('t', 'T'), Description: "Transit light curve: how the planet dims its star (other star systems)"},
+		{Action: ActionCopy, Context: ContextDetails, Keys: runes('y'), Description: "Copy the details to the clipboard as text (moon details too)"},
+		{Action: ActionCopyJSON, Context: ContextDetails, Keys: runes('Y'), Description: "Copy the body to the clipboard as JSON (moon details too)"},
+		{Action: ActionRawJSON, Context: ContextDetails, Keys: runes('r', 'R'), Description: "Show the body's data as raw JSON, or the details again (moon details too)"},
+		{Action: ActionUnits, Context: ContextDetails, Keys: runes('u', 'U'), Description: "Switch values between readable units and the days, km and kg the data gives (moon details too)"},
+	}}
+	km.rebuild()
+	return km
+}
+
+// Remap replaces the keys of actions named in overrides, each a comma-separated
+// list of key names. Invalid or conflicting entries are skipped and reported.
+func (km *Keymap) Remap(overrides map[string]string) []error {
+	actions := make([]string, 0, len(overrides))
+	for action := range overrides {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	var errs []error
+	for _, action := range actions {
+		if err := km.remap(Action(action), overrides[action]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	km.rebuild()
+	return errs
+}
+
+func (km *Keymap) remap(action Action, spec string) error {
+	index := km.indexOf(action)
+	if index < 0 {
+		return fmt.Errorf("unknown action %q", action)
+	}
+	if km.bindings[index].Fixed {
+		return fmt.Errorf("action %q cannot be remapped", action)
+	}
+
+	var keys []Key
+	for _, name := range strings.Split(spec, ",") {
+		key, err := ParseKey(strings.TrimSpace(name))
+		if err != nil {
+			return fmt.Errorf("action %q: %w", action, err)
+		}
+		keys = append(keys, key)
+	}
+
+	context := km.bindings[index].Context
+	for i, other := range km.bindings {
+		if i == index || !contextsOverlap(context, other.Context) {
+			continue
+		}
+		for _, key := range keys {
+			for _, taken := range other.Keys {
+				if key == taken {
+					return fmt.Errorf("action %q: key %s is already used by %q", action, key, other.Action)
+				}
+			}
+		}
+	}
+
+	km.bindings[index].Keys = keys
+	return nil
+}
+
+// contextsOverlap reports whether a key pressed in one context could reach the other
+func contextsOverlap(a, b Context) bool {
+	return a == b || a == ContextGlobal || b == ContextGlobal
+}
+
+func (km *Keymap) indexOf(action Action) int {
+	for i, binding := range km.bindings {
+		if binding.Action == action {
+			return i
+		}
+	}
+	return -1
+}
+
+func (km *Keymap) rebuild() {
+	km.lookup = make(map[Context]map[Key]Action)
+	for _, binding := range km.bindings {
+		if km.lookup[binding.Context] == nil {
+			km.lookup[binding.Context] = make(map[Key]Action)
+		}
+		for _, key := range binding.Keys {
+			km.lookup[binding.Context][key] = binding.Action
+		}
+	}
+}
+
+// Action returns the action bound to a key event in the given context
+func (km *Keymap) Action(context Context, ev *tcell.EventKey) (Action, bool) {
+	action, ok := km.lookup[context][FromEvent(ev)]
+	return action, ok
+}
+
+// Label returns the display label for an action's keys
+func (km *Keymap) Label(action Action) string {
+	if index := km.indexOf(action); index >= 0 {
+		return km.bindings[index].Label()
+	}
+	return ""
+}
+
+// Contexts returns the contexts in help order
+func (km *Keymap) Contexts() []Context {
+	return contextOrder
+}
+
+// Bindings returns the bindings of one context in declaration order
+func (km *Keymap) Bindings(context Context) []Binding {
+	var bindings []Binding
+	for _, binding := range km.bindings {
+		if binding.Context == context {
+			bindings = append(bindings, binding)
+		}
+	}
+	return bindings
+}
+
+// Primary returns the label of the first key bound to an action, for short hints
+func (km *Keymap) Primary(action Action) string {
+	return strings.SplitN(km.Label(action), "/", 2)[0]
+}
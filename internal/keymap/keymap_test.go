@@ -0,0 +1,80 @@
+package keymap
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestDefaultLookup(t *testing.T) {
+	km := Default()
+
+	ev := tcell.NewEventKey(tcell.KeyRune, 'Q', tcell.ModNone)
+	if action, ok := km.Action(ContextMain, ev); !ok || action != ActionQuit {
+		t.Errorf("Q in main view = %v, %v; want quit", action, ok)
+	}
+
+	ev = tcell.NewEventKey(tcell.KeyF12, 0, tcell.ModNone)
+	if action, ok := km.Action(ContextGlobal, ev); !ok || action != ActionScreenshot {
+		t.Errorf("F12 anywhere = %v, %v; want screenshot", action, ok)
+	}
+
+	ev = tcell.NewEventKey(tcell.KeyF9, 0, tcell.ModNone)
+	if action, ok := km.Action(ContextGlobal, ev); !ok || action != ActionDebug {
+		t.Errorf("F9 anywhere = %v, %v; want debug", action, ok)
+	}
+}
+
+func TestRemap(t *testing.T) {
+	km := Default()
+
+	if errs := km.Remap(map[string]string{"quiz": "y, Y"}); len(errs) > 0 {
+		t.Fatalf("Remap() errors = %v", errs)
+	}
+
+	if action, ok := km.Action(ContextMain, tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone)); !ok || action != ActionQuiz {
+		t.Errorf("y = %v, %v; want quiz", action, ok)
+	}
+	if _, ok := km.Action(ContextMain, tcell.NewEventKey(tcell.KeyRune, 'z', tcell.ModNone)); ok {
+		t.Error("z should no longer be bound")
+	}
+	if got := km.Label(ActionQuiz); got != "Y" {
+		t.Errorf("Label(quiz) = %q, want Y", got)
+	}
+}
+
+func TestRemapRejectsConflictsAndUnknowns(t *testing.T) {
+	km := Default()
+
+	errs := km.Remap(map[string]string{
+		"quiz":          "s",     // systems already uses s
+		"teleport":      "t",     // no such action
+		"events":        "F12",   // screenshot is global
+		"select_number": "0",     // fixed
+		"sort":          "Bogus", // not a key
+	})
+	if len(errs) != 5 {
+		t.Fatalf("expected 5 errors, got %v", errs)
+	}
+
+	if got := km.Label(ActionQuiz); got != "Z" {
+		t.Errorf("quiz should keep its default keys, got %q", got)
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := map[string]Key{
+		"a":      RuneKey('a'),
+		"F12":    SpecialKey(tcell.KeyF12),
+		"enter":  SpecialKey(tcell.KeyEnter),
+		"Escape": SpecialKey(tcell.KeyEscape),
+		"Ctrl+P": SpecialKey(tcell.KeyCtrlP),
+		"Space":  RuneKey(' '),
+	}
+	for name, want := range tests {
+		got, err := ParseKey(name)
+		if err != nil || got != want {
+			t.Errorf("ParseKey(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+}
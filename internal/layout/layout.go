@@ -0,0 +1,139 @@
+// Package layout splits the terminal into the regions the UI draws into. Both
+// renderers ask it where things go, so the planet list, orbital map, status bar
+// and modals never overlap however small the terminal gets.
+package layout
+
+import "github.com/furan917/go-solar-system/internal/constants"
+
+// Breakpoint is the broad size class of the terminal
+type Breakpoint int
+
+const (
+	// Wide terminals float modals top-right and keep the planet list clear of them
+	Wide Breakpoint = iota
+	// Narrow terminals stack modals over the whole screen
+	Narrow
+	// Tiny terminals also hide the planet list to leave room for the map
+	Tiny
+)
+
+const (
+	// NarrowWidth is the width below which modals go full-screen
+	NarrowWidth = 90
+
+	// TinyWidth and TinyHeight are the sizes below which the planet list is hidden
+	TinyWidth  = 50
+	TinyHeight = 16
+
+	margin       = 2
+	headerY      = 1
+	tabsY        = 2
+	listY        = 3
+	listRows     = 3
+	statusRows   = 2
+	modalPadding = 3 // border plus one space on each side
+)
+
+// Rect is a rectangle of terminal cells
+type Rect struct {
+	X, Y, Width, Height int
+}
+
+// Contains reports whether the cell at x, y is inside the rectangle
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// Empty reports whether the rectangle covers no cells
+func (r Rect) Empty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// Layout is the set of regions for one screen size
+type Layout struct {
+	Breakpoint Breakpoint
+	Screen     Rect
+
+	Header   Rect // title and sort label
+	Tabs     Rect // body class tabs over the list; empty on tiny terminals
+	List     Rect // planet list; empty on tiny terminals
+	Map      Rect // orbital view
+	Timeline Rect // time scrubber under the map; empty on tiny terminals
+	Status   Rect // instruction bar and status line
+
+	modal Rect // the largest area a modal may cover
+}
+
+// Compute lays out a screen of the given size
+func Compute(width, height int) Layout {
+	width, height = max(width, 0), max(height, 0)
+
+	l := Layout{
+		Breakpoint: breakpointFor(width, height),
+		Screen:     Rect{Width: width, Height: height},
+	}
+
+	contentWidth := max(width-2*margin, 0)
+	l.Header = Rect{X: margin, Y: headerY, Width: contentWidth, Height: 1}
+	l.Status = Rect{X: margin, Y: max(height-statusRows, 0), Width: contentWidth, Height: min(statusRows, height)}
+
+	mapTop := listY + listRows
+	switch l.Breakpoint {
+	case Wide:
+		// Leave the right-hand column free for floating modals
+		listWidth := width - constants.ModalWidth - 3*constants.ModalMargin
+		l.List = Rect{X: margin, Y: listY, Width: max(listWidth, 0), Height: listRows}
+		l.Tabs = Rect{X: margin, Y: tabsY, Width: l.List.Width, Height: 1}
+		l.modal = Rect{X: width - constants.ModalWidth - constants.ModalMargin, Y: 1, Width: constants.ModalWidth, Height: max(height-2, 0)}
+	case Narrow:
+		l.List = Rect{X: margin, Y: listY, Width: contentWidth, Height: listRows}
+		l.Tabs = Rect{X: margin, Y: tabsY, Width: contentWidth, Height: 1}
+		l.modal = l.Screen
+	case Tiny:
+		mapTop = listY
+		l.modal = l.Screen
+	}
+
+	mapBottom := l.Status.Y
+	if l.Breakpoint != Tiny {
+		mapBottom--
+		l.Timeline = Rect{X: margin, Y: mapBottom, Width: contentWidth, Height: 1}
+	}
+	l.Map = Rect{X: margin, Y: mapTop, Width: contentWidth, Height: max(mapBottom-mapTop, 0)}
+	return l
+}
+
+func breakpointFor(width, height int) Breakpoint {
+	switch {
+	case width < TinyWidth || height < TinyHeight:
+		return Tiny
+	case width < NarrowWidth:
+		return Narrow
+	default:
+		return Wide
+	}
+}
+
+// FullScreenModals reports whether modals cover the whole screen
+func (l Layout) FullScreenModals() bool {
+	return l.Breakpoint != Wide
+}
+
+// Modal returns the area for a modal that wants the given height; zero asks for
+// the default height. On narrow terminals every modal fills the screen.
+func (l Layout) Modal(height int) Rect {
+	if l.FullScreenModals() {
+		return l.modal
+	}
+	if height <= 0 {
+		height = constants.ModalHeight
+	}
+	r := l.modal
+	r.Height = height
+	return r
+}
+
+// ContentWidth is the width available to text inside a modal
+func (l Layout) ContentWidth() int {
+	return max(l.modal.Width-2*modalPadding, 0)
+}
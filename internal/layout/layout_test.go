@@ -0,0 +1,131 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+)
+
+func TestBreakpoints(t *testing.T) {
+	tests := []struct {
+		width, height int
+		want          Breakpoint
+	}{
+		{200, 50, Wide},
+		{NarrowWidth, 40, Wide},
+		{NarrowWidth - 1, 40, Narrow},
+		{60, 30, Narrow},
+		{TinyWidth - 1, 30, Tiny},
+		{120, TinyHeight - 1, Tiny},
+		{0, 0, Tiny},
+	}
+
+	for _, tt := range tests {
+		if got := Compute(tt.width, tt.height).Breakpoint; got != tt.want {
+			t.Errorf("Compute(%d, %d).Breakpoint = %v, want %v", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestWideLayoutMatchesClassicPositions(t *testing.T) {
+	l := Compute(160, 48)
+
+	if l.List != (Rect{X: 2, Y: 3, Width: 160 - constants.ModalWidth - 3*constants.ModalMargin, Height: 3}) {
+		t.Errorf("List = %+v", l.List)
+	}
+	if l.Tabs != (Rect{X: 2, Y: 2, Width: l.List.Width, Height: 1}) {
+		t.Errorf("Tabs = %+v", l.Tabs)
+	}
+	if l.Map != (Rect{X: 2, Y: 6, Width: 156, Height: 39}) {
+		t.Errorf("Map = %+v", l.Map)
+	}
+	if l.Timeline != (Rect{X: 2, Y: 45, Width: 156, Height: 1}) {
+		t.Errorf("Timeline = %+v", l.Timeline)
+	}
+	if l.Status.Y != 46 {
+		t.Errorf("Status.Y = %d, want 46", l.Status.Y)
+	}
+
+	modal := l.Modal(0)
+	if modal != (Rect{X: 160 - constants.ModalWidth - constants.ModalMargin, Y: 1, Width: constants.ModalWidth, Height: constants.ModalHeight}) {
+		t.Errorf("Modal(0) = %+v", modal)
+	}
+	if l.Modal(12).Height != 12 {
+		t.Errorf("Modal(12).Height = %d", l.Modal(12).Height)
+	}
+	if l.ContentWidth() != constants.ModalContentWidth {
+		t.Errorf("ContentWidth() = %d, want %d", l.ContentWidth(), constants.ModalContentWidth)
+	}
+}
+
+func TestWideListStaysClearOfModal(t *testing.T) {
+	l := Compute(NarrowWidth, 30)
+	modal := l.Modal(0)
+	if l.List.X+l.List.Width > modal.X {
+		t.Errorf("list %+v overlaps modal %+v", l.List, modal)
+	}
+}
+
+func TestNarrowModalsFillScreen(t *testing.T) {
+	l := Compute(70, 30)
+
+	if !l.FullScreenModals() {
+		t.Fatal("narrow layout should use full-screen modals")
+	}
+	if got := l.Modal(12); got != l.Screen {
+		t.Errorf("Modal(12) = %+v, want the whole screen %+v", got, l.Screen)
+	}
+	if l.ContentWidth() != 64 {
+		t.Errorf("ContentWidth() = %d, want 64", l.ContentWidth())
+	}
+	if l.List.Width != 66 {
+		t.Errorf("narrow list should span the screen, got %+v", l.List)
+	}
+}
+
+func TestTinyHidesList(t *testing.T) {
+	l := Compute(40, 20)
+
+	if !l.List.Empty() || !l.Tabs.Empty() || !l.Timeline.Empty() {
+		t.Errorf("List = %+v, Tabs = %+v, Timeline = %+v, want all hidden", l.List, l.Tabs, l.Timeline)
+	}
+	if l.Map.Y != 3 || l.Map.Y+l.Map.Height != l.Status.Y {
+		t.Errorf("map %+v should run from the header to the status bar %+v", l.Map, l.Status)
+	}
+}
+
+func TestRegionsNeverOverlap(t *testing.T) {
+	for width := 0; width <= 200; width += 7 {
+		for height := 0; height <= 60; height += 5 {
+			l := Compute(width, height)
+			regions := []Rect{l.Header, l.Tabs, l.List, l.Map, l.Timeline, l.Status}
+			for i := range regions {
+				if regions[i].Width < 0 || regions[i].Height < 0 {
+					t.Fatalf("%dx%d: negative region %+v", width, height, regions[i])
+				}
+				for j := i + 1; j < len(regions); j++ {
+					if overlaps(regions[i], regions[j]) {
+						t.Errorf("%dx%d: %+v overlaps %+v", width, height, regions[i], regions[j])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRectContains(t *testing.T) {
+	r := Rect{X: 2, Y: 3, Width: 4, Height: 2}
+	if !r.Contains(2, 3) || !r.Contains(5, 4) {
+		t.Error("corners should be inside")
+	}
+	if r.Contains(6, 3) || r.Contains(2, 5) || r.Contains(1, 3) {
+		t.Error("cells past the edges should be outside")
+	}
+}
+
+func overlaps(a, b Rect) bool {
+	if a.Empty() || b.Empty() {
+		return false
+	}
+	return a.X < b.X+b.Width && b.X < a.X+a.Width && a.Y < b.Y+b.Height && b.Y < a.Y+a.Height
+}
@@ -0,0 +1,79 @@
+package livesync
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// dialTimeout bounds connecting and the opening handshake
+const dialTimeout = 5 * time.Second
+
+// Dial connects to a live-sync server, e.g. ws://localhost:7817/sync. A URL
+// without a path gets Path.
+func Dial(rawURL string) (*Conn, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sync URL %q: %w", rawURL, err)
+	}
+	if target.Scheme != "ws" {
+		return nil, fmt.Errorf("sync URL %q must start with ws://", rawURL)
+	}
+	if target.Path == "" {
+		target.Path = Path
+	}
+	host := target.Host
+	if target.Port() == "" {
+		host = net.JoinHostPort(target.Hostname(), "80")
+	}
+
+	netConn, err := net.DialTimeout("tcp", host, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce[:])
+
+	request := fmt.Sprintf("GET %s HTTP/1.1\r\n"+
+		"Host: %s\r\n"+
+		"Upgrade: websocket\r\n"+
+		"Connection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: %s\r\n"+
+		"Sec-WebSocket-Version: 13\r\n\r\n", target.RequestURI(), target.Host, key)
+
+	_ = netConn.SetDeadline(time.Now().Add(dialTimeout))
+	if _, err := netConn.Write([]byte(request)); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	reader := bufio.NewReader(netConn)
+	response, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("websocket handshake failed: %w", err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		netConn.Close()
+		return nil, fmt.Errorf("websocket handshake failed: %s", response.Status)
+	}
+	if response.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		netConn.Close()
+		return nil, fmt.Errorf("websocket handshake failed: bad accept key")
+	}
+	_ = netConn.SetDeadline(time.Time{})
+
+	return newConn(netConn, reader, true), nil
+}
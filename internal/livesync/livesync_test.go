@@ -0,0 +1,138 @@
+package livesync
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func dialTestServer(t *testing.T, server *Server) (*Conn, func()) {
+	t.Helper()
+	httpServer := httptest.NewServer(server)
+	conn, err := Dial("ws://" + strings.TrimPrefix(httpServer.URL, "http://") + Path)
+	if err != nil {
+		httpServer.Close()
+		t.Fatalf("Dial() error = %v", err)
+	}
+	return conn, func() {
+		conn.Close()
+		server.Close()
+		httpServer.Close()
+	}
+}
+
+func waitForClients(t *testing.T, server *Server, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for server.Clients() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("Clients() = %d, want %d", server.Clients(), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestServerGreetsAndBroadcasts(t *testing.T) {
+	server := NewServer([]byte("hello"))
+	server.Broadcast([]byte("first"))
+
+	conn, cleanup := dialTestServer(t, server)
+	defer cleanup()
+
+	for _, want := range []string{"hello", "first"} {
+		got, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("ReadMessage() error = %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("ReadMessage() = %q, want %q", got, want)
+		}
+	}
+
+	waitForClients(t, server, 1)
+	large := bytes.Repeat([]byte("x"), 70000) // needs the 64-bit length form
+	server.Broadcast(large)
+	got, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	if !bytes.Equal(got, large) {
+		t.Errorf("ReadMessage() returned %d bytes, want %d", len(got), len(large))
+	}
+}
+
+func TestServerForgetsClosedFollowers(t *testing.T) {
+	server := NewServer(nil)
+	conn, cleanup := dialTestServer(t, server)
+	defer cleanup()
+
+	waitForClients(t, server, 1)
+	conn.Close()
+	waitForClients(t, server, 0)
+}
+
+func TestConnMasksClientFrames(t *testing.T) {
+	clientSide, serverSide := net.Pipe()
+	defer clientSide.Close()
+	defer serverSide.Close()
+
+	client := newConn(clientSide, nil, true)
+	go func() { _ = client.WriteMessage([]byte("masked")) }()
+
+	header := make([]byte, 2)
+	if _, err := serverSide.Read(header); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if header[1]&0x80 == 0 {
+		t.Error("client frame is not masked")
+	}
+}
+
+func TestDialRejectsOtherSchemes(t *testing.T) {
+	if _, err := Dial("http://localhost:7817/sync"); err == nil {
+		t.Error("Dial() with http:// succeeded, want an error")
+	}
+}
+
+func TestAcceptKey(t *testing.T) {
+	// Example from RFC 6455 section 1.3
+	if got := acceptKey("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("acceptKey() = %q", got)
+	}
+}
+
+func TestServerRejectsOtherOrigins(t *testing.T) {
+	tests := []struct {
+		origin string
+		want   int
+	}{
+		{"", http.StatusBadRequest}, // not a browser; only failing to hijack the recorder
+		{"http://localhost:7817", http.StatusBadRequest},
+		{"http://LOCALHOST:7817", http.StatusBadRequest},
+		{"https://evil.example", http.StatusForbidden},
+		{"http://localhost:8080", http.StatusForbidden},
+		{"null", http.StatusForbidden},
+		{"http://localhost:3000", http.StatusBadRequest}, // allowed below
+	}
+	for _, tt := range tests {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:7817"+Path, nil)
+		request.Header.Set("Connection", "Upgrade")
+		request.Header.Set("Upgrade", "websocket")
+		request.Header.Set("Sec-WebSocket-Version", "13")
+		request.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		if tt.origin != "" {
+			request.Header.Set("Origin", tt.origin)
+		}
+		recorder := httptest.NewRecorder()
+		server := NewServer(nil)
+		server.AllowOrigins("http://localhost:3000/")
+		server.ServeHTTP(recorder, request)
+		if recorder.Code != tt.want {
+			t.Errorf("Origin %q answered %d, want %d", tt.origin, recorder.Code, tt.want)
+		}
+	}
+}
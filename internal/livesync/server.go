@@ -0,0 +1,206 @@
+package livesync
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Path is where the server accepts WebSocket connections
+const Path = "/sync"
+
+// clientQueue is how many messages may wait for a slow client before it is dropped
+const clientQueue = 16
+
+// Server broadcasts messages to every connected follower. New followers are sent
+// the latest message straight away so they do not wait for the next change.
+type Server struct {
+	mu      sync.Mutex
+	clients map[*Conn]chan []byte
+	latest  []byte
+	greet   []byte
+
+	// origins are the browser origins let in besides the server's own host
+	origins map[string]bool
+}
+
+// NewServer creates a server; greeting, if not nil, is sent first to every follower
+func NewServer(greeting []byte) *Server {
+	return &Server{
+		clients: make(map[*Conn]chan []byte),
+		greet:   greeting,
+	}
+}
+
+// AllowOrigins lets pages from these origins, such as http://localhost:3000,
+// follow as well as pages on the server's own host. Call it before serving.
+func (s *Server) AllowOrigins(origins ...string) {
+	if s.origins == nil {
+		s.origins = make(map[string]bool)
+	}
+	for _, origin := range origins {
+		s.origins[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+	}
+}
+
+// Broadcast queues a message for every follower without waiting on the network.
+// Followers too slow to keep up are disconnected.
+func (s *Server) Broadcast(message []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latest = message
+	for conn, queue := range s.clients {
+		select {
+		case queue <- message:
+		default:
+			s.removeLocked(conn)
+		}
+	}
+}
+
+// Clients returns the number of connected followers
+func (s *Server) Clients() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.clients)
+}
+
+// Close disconnects every follower
+func (s *Server) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.clients {
+		s.removeLocked(conn)
+	}
+}
+
+// ServeHTTP upgrades the request to a WebSocket and streams messages until the
+// follower goes away. Browsers connecting from a page on another site are turned
+// away unless it was allowed, so any web page cannot read the session.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.allowedOrigin(r) {
+		http.Error(w, "live sync only accepts followers from the same host", http.StatusForbidden)
+		return
+	}
+	conn, err := upgrade(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	queue := make(chan []byte, clientQueue)
+	s.mu.Lock()
+	if s.greet != nil {
+		queue <- s.greet
+	}
+	if s.latest != nil {
+		queue <- s.latest
+	}
+	s.clients[conn] = queue
+	s.mu.Unlock()
+
+	go s.write(conn, queue)
+
+	// Followers have nothing to say; reading keeps pings answered and notices closes
+	for {
+		if _, err := conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	s.removeLocked(conn)
+	s.mu.Unlock()
+}
+
+func (s *Server) write(conn *Conn, queue chan []byte) {
+	for message := range queue {
+		if err := conn.WriteMessage(message); err != nil {
+			s.mu.Lock()
+			s.removeLocked(conn)
+			s.mu.Unlock()
+			return
+		}
+	}
+}
+
+// removeLocked disconnects a follower; s.mu must be held
+func (s *Server) removeLocked(conn *Conn) {
+	queue, ok := s.clients[conn]
+	if !ok {
+		return
+	}
+	delete(s.clients, conn)
+	close(queue)
+	conn.Close()
+}
+
+// allowedOrigin reports whether a request comes from a page on the server's own
+// host or an allowed origin, or from outside a browser, which sends no Origin
+func (s *Server) allowedOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || s.origins[strings.ToLower(origin)] {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// upgrade performs the server side of the opening handshake
+func upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	if r.Method != http.MethodGet {
+		return nil, fmt.Errorf("websocket handshake must be a GET")
+	}
+	if !headerContains(r.Header, "Connection", "upgrade") || !headerContains(r.Header, "Upgrade", "websocket") {
+		return nil, fmt.Errorf("not a websocket handshake")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return nil, fmt.Errorf("unsupported websocket version %q", r.Header.Get("Sec-WebSocket-Version"))
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		return nil, fmt.Errorf("missing Sec-WebSocket-Key")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, fmt.Errorf("connection cannot be upgraded")
+	}
+	netConn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	if _, err := rw.WriteString(response); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	if err := rw.Flush(); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	return newConn(netConn, rw.Reader, false), nil
+}
+
+// headerContains reports whether a comma-separated header lists the token
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
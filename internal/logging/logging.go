@@ -0,0 +1,178 @@
+// Package logging writes application logs to a size-rotated file so that nothing
+// is ever printed over the terminal UI.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+)
+
+// Logger is a standard logger with an optional debug level
+type Logger struct {
+	*log.Logger
+	debug  bool
+	closer io.Closer
+}
+
+// New creates a logger writing to w
+func New(w io.Writer, debug bool) *Logger {
+	return &Logger{
+		Logger: log.New(w, constants.LogPrefix, log.LstdFlags|log.Lshortfile),
+		debug:  debug,
+	}
+}
+
+// Open creates a logger writing to a rotating file at path
+func Open(path string, debug bool) (*Logger, error) {
+	file, err := OpenRotatingFile(path, constants.LogMaxSize, constants.LogMaxBackups)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := New(file, debug)
+	logger.closer = file
+	return logger, nil
+}
+
+// Discard returns a logger that drops everything
+func Discard() *Logger {
+	return New(io.Discard, false)
+}
+
+// DefaultPath returns the log file location inside the user's cache directory
+func DefaultPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "go-solar-system", constants.LogFileName)
+}
+
+// Debugf logs only when debug logging is enabled
+func (l *Logger) Debugf(format string, v ...interface{}) {
+	if l.debug {
+		_ = l.Output(2, "DEBUG "+fmt.Sprintf(format, v...))
+	}
+}
+
+// DebugEnabled reports whether debug logging is on
+func (l *Logger) DebugEnabled() bool {
+	return l.debug
+}
+
+// Close closes the underlying log file, if any
+func (l *Logger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// RotatingFile is an io.Writer that renames the file to path.1, path.2, ...
+// once it grows past maxSize, keeping at most maxBackups old files
+type RotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// OpenRotatingFile opens (or creates) the file at path for appending
+func OpenRotatingFile(path string, maxSize int64, maxBackups int) (*RotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	r := &RotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write appends p, rotating first if it would push the file past its size limit
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// rotate shifts existing backups up by one, dropping the oldest, and starts a new file
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	r.file = nil
+
+	if r.maxBackups > 0 {
+		for i := r.maxBackups - 1; i >= 1; i-- {
+			_ = os.Rename(r.backupPath(i), r.backupPath(i+1))
+		}
+		if err := os.Rename(r.path, r.backupPath(1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate log file: %w", err)
+		}
+	} else if err := os.Remove(r.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to truncate log file: %w", err)
+	}
+
+	return r.open()
+}
+
+func (r *RotatingFile) backupPath(index int) string {
+	return fmt.Sprintf("%s.%d", r.path, index)
+}
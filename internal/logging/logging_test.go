@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFileRotatesAndKeepsBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	file, err := OpenRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("OpenRotatingFile() error = %v", err)
+	}
+	defer file.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	expected := map[string]string{
+		path:        "fourth\n",
+		path + ".1": "third\n",
+		path + ".2": "second\n",
+	}
+	for name, want := range expected {
+		got, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error = %v", name, err)
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", filepath.Base(name), got, want)
+		}
+	}
+
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected only 2 backups to be kept")
+	}
+}
+
+func TestDebugfOnlyWhenEnabled(t *testing.T) {
+	var quiet, verbose strings.Builder
+	New(&quiet, false).Debugf("hidden %d", 1)
+	New(&verbose, true).Debugf("shown %d", 2)
+
+	if quiet.Len() != 0 {
+		t.Errorf("debug output written with debug disabled: %q", quiet.String())
+	}
+	if !strings.Contains(verbose.String(), "DEBUG shown 2") {
+		t.Errorf("expected debug line, got %q", verbose.String())
+	}
+}
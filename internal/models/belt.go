@@ -0,0 +1,55 @@
+package models
+
+// BeltKind is what a debris belt is made of, which picks how it is drawn
+type BeltKind string
+
+const (
+	// BeltRocky is a belt of rock and metal, like the main asteroid belt
+	BeltRocky BeltKind = "rocky"
+	// BeltIcy is a belt of ices beyond the snow line, like the Kuiper belt
+	BeltIcy BeltKind = "icy"
+)
+
+// DefaultBeltDensity is how much of a belt is marked when its file does not say
+const DefaultBeltDensity = 0.3
+
+// Belt is a ring of debris round a system's star that a system file declares:
+// where it lies, how thickly it is drawn and with what
+type Belt struct {
+	Name        string   `json:"name"`
+	InnerRadius float64  `json:"innerRadius"` // km from the star
+	OuterRadius float64  `json:"outerRadius"` // km from the star
+	Kind        BeltKind `json:"kind,omitempty"`
+
+	// Density is the share of the belt marked, above 0 up to 1; 0 means
+	// DefaultBeltDensity
+	Density float64 `json:"density,omitempty"`
+
+	// Symbol draws the belt in place of the one for its kind
+	Symbol string `json:"symbol,omitempty"`
+
+	// Rings is how many rings of marks the belt is drawn as; 0 picks from how
+	// wide it is on screen
+	Rings int `json:"rings,omitempty"`
+}
+
+// MarkDensity returns the share of the belt to mark
+func (b Belt) MarkDensity() float64 {
+	if b.Density <= 0 {
+		return DefaultBeltDensity
+	}
+	return min(b.Density, 1)
+}
+
+// SolarSystemBelts are the Solar System's belts, which the API does not list:
+// the main asteroid belt between Mars and Jupiter and the Kuiper belt past Neptune.
+// They are drawn where and as the map always drew them: the asteroid belt from
+// half as far again as Mars to 0.6 of the way to Jupiter, 36 marks round in 3
+// rings, and the Kuiper belt from 1.2 to 1.7 times Neptune's distance, 30 marks
+// round in 4 rings.
+func SolarSystemBelts() []Belt {
+	return []Belt{
+		{Name: "Asteroid belt", InnerRadius: 341915736, OuterRadius: 467004493, Kind: BeltRocky, Rings: 3},
+		{Name: "Kuiper belt", InnerRadius: 5398075729, OuterRadius: 7647273950, Kind: BeltIcy, Density: 0.25, Rings: 4},
+	}
+}
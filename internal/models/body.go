@@ -28,6 +28,11 @@ type CelestialBody struct {
 	Dimension       string  `json:"dimension"`
 	SideralOrbit    float64 `json:"sideralOrbit"`
 	SideralRotation float64 `json:"sideralRotation"`
+	AxialTilt       float64 `json:"axialTilt"`
+	AvgTemp         float64 `json:"avgTemp"`
+	MainAnomaly     float64 `json:"mainAnomaly"`
+	ArgPeriapsis    float64 `json:"argPeriapsis"`
+	LongAscNode     float64 `json:"longAscNode"`
 	AroundPlanet    *Planet `json:"aroundPlanet"`
 	DiscoveredBy    string  `json:"discoveredBy"`
 	DiscoveryDate   string  `json:"discoveryDate"`
@@ -35,17 +40,38 @@ type CelestialBody struct {
 	BodyType        string  `json:"bodyType"`
 	Rel             string  `json:"rel"`
 
+	// Further names a system file gives the body (optional); it can be found by
+	// any of them
+	Aliases []string `json:"aliases,omitempty"`
+
 	// Stellar properties
 	Temperature  float64 `json:"temperature"`
 	StellarClass string  `json:"stellarClass"`
 	Age          float64 `json:"age"`
 
+	// Further stellar properties system files can give (optional): luminosity in
+	// solar luminosities, worked out from temperature and radius when missing;
+	// metallicity as [Fe/H] in dex, where 0 is the Sun's; and the full spectral
+	// type, e.g. "G2V", where stellarClass may give only the class
+	Luminosity   float64  `json:"luminosity,omitempty"`
+	Metallicity  *float64 `json:"metallicity,omitempty"`
+	SpectralType string   `json:"spectralType,omitempty"`
+
 	// Orbital elements for precise positioning (optional)
 	OrbitalElements *OrbitalElement `json:"orbitalElements,omitempty"`
+
+	// Display overrides set by system files (optional): a color name or #rrggbb,
+	// and a single character to draw the body with
+	DisplayColor string `json:"displayColor,omitempty"`
+	Symbol       string `json:"symbol,omitempty"`
+
+	// Where the values came from; filled in by whatever loaded the body
+	Provenance Provenance `json:"-"`
 }
 
 type Planet struct {
 	ID          string `json:"id"`
+	Planet      string `json:"planet"` // the API names the body orbited here, by id
 	Name        string `json:"name"`
 	EnglishName string `json:"englishName"`
 	Rel         string `json:"rel"`
@@ -56,6 +82,10 @@ type Moon struct {
 	Name        string `json:"name"`
 	EnglishName string `json:"englishName"`
 	Rel         string `json:"rel"`
+
+	// Body is the moon itself when a system file describes it in place rather than
+	// only naming it; nil for the API's moons
+	Body *CelestialBody `json:"-"`
 }
 
 type Mass struct {
@@ -72,6 +102,18 @@ type APIResponse struct {
 	Bodies []CelestialBody `json:"bodies"`
 }
 
+// KnownCount is the API's running total of one kind of object, e.g. known asteroids
+type KnownCount struct {
+	ID         string `json:"id"`
+	KnownCount int    `json:"knownCount"`
+	UpdateDate string `json:"updateDate"`
+	Rel        string `json:"rel"`
+}
+
+type KnownCountResponse struct {
+	KnownCount []KnownCount `json:"knowncount"`
+}
+
 type Position struct {
 	X float64
 	Y float64
@@ -79,13 +121,13 @@ type Position struct {
 }
 
 type OrbitalElement struct {
-	SemimajorAxis            float64
-	Eccentricity             float64
-	Inclination              float64
-	ArgumentOfPeriapsis      float64
-	LongitudeOfAscendingNode float64
-	MeanAnomaly              float64
-	Epoch                    time.Time
+	SemimajorAxis            float64   `json:"semimajorAxis"`
+	Eccentricity             float64   `json:"eccentricity"`
+	Inclination              float64   `json:"inclination"`
+	ArgumentOfPeriapsis      float64   `json:"argumentOfPeriapsis"`
+	LongitudeOfAscendingNode float64   `json:"longitudeOfAscendingNode"`
+	MeanAnomaly              float64   `json:"meanAnomaly"`
+	Epoch                    time.Time `json:"epoch"`
 }
 
 func (cb *CelestialBody) GetMassKg() float64 {
@@ -95,6 +137,30 @@ func (cb *CelestialBody) GetMassKg() float64 {
 	return cb.Mass.MassValue * math.Pow10(cb.Mass.MassExponent)
 }
 
+// J2000 is the epoch the API's orbital angles are given for
+var J2000 = time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// FillOrbitalElements builds the body's orbital elements from the API's separate
+// mainAnomaly, argPeriapsis and longAscNode angles, so its position is worked out
+// from its own data. Bodies that already have elements, or no angles, are left alone.
+func (cb *CelestialBody) FillOrbitalElements() {
+	if cb.OrbitalElements != nil || cb.SemimajorAxis <= 0 {
+		return
+	}
+	if cb.MainAnomaly == 0 && cb.ArgPeriapsis == 0 && cb.LongAscNode == 0 {
+		return
+	}
+	cb.OrbitalElements = &OrbitalElement{
+		SemimajorAxis:            cb.SemimajorAxis,
+		Eccentricity:             cb.Eccentricity,
+		Inclination:              cb.Inclination,
+		ArgumentOfPeriapsis:      cb.ArgPeriapsis,
+		LongitudeOfAscendingNode: cb.LongAscNode,
+		MeanAnomaly:              cb.MainAnomaly,
+		Epoch:                    J2000,
+	}
+}
+
 func (cb *CelestialBody) GetVolumeKm3() float64 {
 	if cb.Vol.VolValue == 0 {
 		return 0
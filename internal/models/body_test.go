@@ -1,7 +1,9 @@
 package models
 
 import (
+	"encoding/json"
 	"math"
+	"strings"
 	"testing"
 )
 
@@ -114,3 +116,103 @@ func TestCelestialBody_GetVolumeKm3(t *testing.T) {
 		})
 	}
 }
+
+func TestCelestialBody_FillOrbitalElements(t *testing.T) {
+	mars := CelestialBody{
+		EnglishName:   "Mars",
+		SemimajorAxis: 227939200,
+		Eccentricity:  0.0934,
+		Inclination:   1.85,
+		MainAnomaly:   19.412,
+		ArgPeriapsis:  286.5,
+		LongAscNode:   49.57,
+	}
+	mars.FillOrbitalElements()
+
+	elements := mars.OrbitalElements
+	if elements == nil {
+		t.Fatal("FillOrbitalElements() left no elements")
+	}
+	if elements.MeanAnomaly != 19.412 || elements.ArgumentOfPeriapsis != 286.5 || elements.LongitudeOfAscendingNode != 49.57 {
+		t.Errorf("FillOrbitalElements() angles = %+v", *elements)
+	}
+	if elements.SemimajorAxis != mars.SemimajorAxis || elements.Eccentricity != mars.Eccentricity || !elements.Epoch.Equal(J2000) {
+		t.Errorf("FillOrbitalElements() orbit = %+v", *elements)
+	}
+
+	// Bodies without angles, or with elements of their own, are left alone
+	sun := CelestialBody{EnglishName: "Sun"}
+	sun.FillOrbitalElements()
+	if sun.OrbitalElements != nil {
+		t.Error("FillOrbitalElements() gave the Sun elements")
+	}
+	own := &OrbitalElement{MeanAnomaly: 1}
+	custom := CelestialBody{SemimajorAxis: 1, MainAnomaly: 5, OrbitalElements: own}
+	custom.FillOrbitalElements()
+	if custom.OrbitalElements != own {
+		t.Error("FillOrbitalElements() replaced existing elements")
+	}
+}
+
+func TestCelestialBody_Names(t *testing.T) {
+	earth := CelestialBody{
+		ID:              "terre",
+		Name:            "La Terre",
+		EnglishName:     "Earth",
+		AlternativeName: "earth",
+		Aliases:         []string{"Terra", " Gaia ", "terra"},
+	}
+
+	want := []string{"Earth", "La Terre", "Terra", "Gaia"}
+	if got := earth.Names(); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Names() = %q, want %q", got, want)
+	}
+	if got := earth.OtherNames(); strings.Join(got, "|") != "La Terre|Terra|Gaia" {
+		t.Errorf("OtherNames() = %q", got)
+	}
+
+	for _, name := range []string{"earth", "TERRE", "la terre", "gaia"} {
+		if !earth.MatchesName(name) {
+			t.Errorf("MatchesName(%q) = false, want true", name)
+		}
+	}
+	for _, name := range []string{"", "Mars", "Ter"} {
+		if earth.MatchesName(name) {
+			t.Errorf("MatchesName(%q) = true, want false", name)
+		}
+	}
+}
+
+func TestMoonEntries(t *testing.T) {
+	var planet CelestialBody
+	data := `{"englishName": "b", "moons": [
+		{"moon": "Phobos", "rel": "https://api.example/bodies/phobos"},
+		{"id": "b-i", "englishName": "b I", "meanRadius": 1200, "mass": {"massValue": 7.3, "massExponent": 22}}
+	]}`
+	if err := json.Unmarshal([]byte(data), &planet); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	named, described := planet.Moons[0], planet.Moons[1]
+	if named.Body != nil || named.Rel == "" {
+		t.Errorf("API moon entry = %+v, want only its reference", named)
+	}
+	if described.EnglishName != "b I" || described.Body == nil || described.Body.MeanRadius != 1200 {
+		t.Fatalf("described moon = %+v, want its body kept", described)
+	}
+
+	encoded, err := json.Marshal(planet)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var again CelestialBody
+	if err := json.Unmarshal(encoded, &again); err != nil {
+		t.Fatalf("Unmarshal() of encoded = %v", err)
+	}
+	if again.Moons[0].Body != nil || again.Moons[1].Body == nil || again.Moons[1].Body.GetMassKg() != described.Body.GetMassKg() {
+		t.Errorf("moons after a round trip = %+v", again.Moons)
+	}
+	if !strings.Contains(string(encoded), `"meanRadius":1200`) {
+		t.Errorf("described moon written without its body: %s", encoded)
+	}
+}
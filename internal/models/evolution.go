@@ -0,0 +1,206 @@
+package models
+
+import "math"
+
+// StellarPhase is a stage of a star's life
+type StellarPhase int
+
+const (
+	PhaseMainSequence StellarPhase = iota // burning hydrogen in its core
+	PhaseGiant                            // swollen and cool once the core's hydrogen runs out
+	PhaseSupergiant                       // the same for stars of 8 solar masses and more
+	PhaseWhiteDwarf                       // the cooling core a giant leaves
+	PhaseNeutronStar                      // what a supergiant's core collapses to
+	PhaseBlackHole                        // what the heaviest stars' cores collapse to
+)
+
+// String names the phase
+func (p StellarPhase) String() string {
+	switch p {
+	case PhaseGiant:
+		return "red giant"
+	case PhaseSupergiant:
+		return "red supergiant"
+	case PhaseWhiteDwarf:
+		return "white dwarf"
+	case PhaseNeutronStar:
+		return "neutron star"
+	case PhaseBlackHole:
+		return "black hole"
+	default:
+		return "main sequence"
+	}
+}
+
+// Remnant reports whether the phase is what is left once the star has died
+func (p StellarPhase) Remnant() bool {
+	return p >= PhaseWhiteDwarf
+}
+
+const (
+	// giantMass, supergiantMass and blackHoleMass, in solar masses, are the
+	// lightest stars that swell into giants, that swell into supergiants and end
+	// as neutron stars, and that end as black holes. Lighter red dwarfs burn all
+	// their hydrogen and fade into white dwarfs without swelling.
+	giantMass      = 0.5
+	supergiantMass = 8.0
+	blackHoleMass  = 25.0
+
+	// giantShare is how long a star spends as a giant, as a share of its time on
+	// the main sequence
+	giantShare = 0.1
+
+	// giantTemperature is how cool, in kelvin, a giant's surface gets
+	giantTemperature = 3100.0
+
+	// whiteDwarfRadius is a white dwarf's radius in solar radii, about the
+	// Earth's; whiteDwarfTemperature how hot it is when the giant's envelope
+	// blows off, and whiteDwarfCooling, in billion years, how quickly it cools
+	whiteDwarfRadius      = 0.012
+	whiteDwarfTemperature = 100000.0
+	whiteDwarfCooling     = 0.01
+
+	// neutronStarRadiusKm is a neutron star's radius, and schwarzschildKm the
+	// radius of a black hole's event horizon per solar mass
+	neutronStarRadiusKm = 12.0
+	schwarzschildKm     = 2.95
+
+	// remnantShare is how much of the star's mass its core keeps when it
+	// collapses
+	remnantShare = 1.0 / 3
+)
+
+// StellarStage is how a star looks at one age
+type StellarStage struct {
+	Age   float64 // billion years
+	Phase StellarPhase
+
+	// Class is the spectral class and luminosity class, e.g. "G V" or "M III";
+	// "D" for white dwarfs and empty for neutron stars and black holes
+	Class string
+
+	// Luminosity is 0 for neutron stars and black holes, which give off next to
+	// no light a planet could use
+	Radius      float64 // solar radii
+	Luminosity  float64 // solar luminosities
+	Temperature float64 // kelvin; 0 for black holes
+}
+
+// EvolutionTrack is a rough evolutionary track for a star of Mass solar masses:
+// power laws in its mass for its main sequence, a swell into a giant for a tenth
+// as long again, and the remnant its mass leaves. It shows the shape of a star's
+// life, not its details.
+type EvolutionTrack struct {
+	Mass         float64 // solar masses
+	MainSequence float64 // how long it burns hydrogen in its core, in billion years
+	Giant        float64 // how long it is a giant after, in billion years; 0 for red dwarfs
+}
+
+// NewEvolutionTrack returns the track of a star of mass solar masses
+func NewEvolutionTrack(mass float64) EvolutionTrack {
+	track := EvolutionTrack{Mass: mass, MainSequence: 10 * math.Pow(mass, -2.5)}
+	if mass >= giantMass {
+		track.Giant = giantShare * track.MainSequence
+	}
+	return track
+}
+
+// Death returns the age, in billion years, at which the star leaves its remnant
+func (t EvolutionTrack) Death() float64 {
+	return t.MainSequence + t.Giant
+}
+
+// At returns the star at age billion years
+func (t EvolutionTrack) At(age float64) StellarStage {
+	age = math.Max(age, 0)
+	switch {
+	case age < t.MainSequence:
+		return t.mainSequence(age)
+	case age < t.Death():
+		return t.giant(age)
+	}
+	return t.remnant(age)
+}
+
+// mainSequence brightens and swells the star slowly as helium builds up in its
+// core: the Sun started at 0.7 of today's luminosity
+func (t EvolutionTrack) mainSequence(age float64) StellarStage {
+	done := math.Min(age/t.MainSequence, 1)
+	stage := StellarStage{
+		Age:        age,
+		Phase:      PhaseMainSequence,
+		Luminosity: 0.7 * math.Pow(t.Mass, 3.5) * (1 + 0.9*done),
+		Radius:     0.89 * math.Pow(t.Mass, 0.8) * (1 + 0.35*done),
+	}
+	stage.Temperature = surfaceTemperature(stage.Luminosity, stage.Radius)
+	stage.Class = spectralClass(stage.Temperature) + " V"
+	return stage
+}
+
+// giant brightens the star ever faster while its surface cools to red, so it
+// swells to hundreds of times its size
+func (t EvolutionTrack) giant(age float64) StellarStage {
+	start := t.mainSequence(t.MainSequence)
+	done := (age - t.MainSequence) / t.Giant
+
+	phase, peak, class := PhaseGiant, 3000*math.Pow(t.Mass, 1.5), " III"
+	if t.Mass >= supergiantMass {
+		phase, peak, class = PhaseSupergiant, 2e4*math.Pow(t.Mass/supergiantMass, 2), " I"
+	}
+	peak = math.Max(peak, start.Luminosity)
+
+	stage := StellarStage{
+		Age:         age,
+		Phase:       phase,
+		Luminosity:  start.Luminosity * math.Pow(peak/start.Luminosity, done*done),
+		Temperature: start.Temperature + (giantTemperature-start.Temperature)*math.Min(1, 3*done),
+	}
+	stage.Radius = math.Sqrt(stage.Luminosity) / math.Pow(stage.Temperature/SolarTemperatureK, 2)
+	stage.Class = spectralClass(stage.Temperature) + class
+	return stage
+}
+
+// remnant is what the star leaves, by its mass
+func (t EvolutionTrack) remnant(age float64) StellarStage {
+	switch {
+	case t.Mass >= blackHoleMass:
+		return StellarStage{Age: age, Phase: PhaseBlackHole, Radius: schwarzschildKm * t.Mass * remnantShare / SolarRadiusKm}
+	case t.Mass >= supergiantMass:
+		return StellarStage{Age: age, Phase: PhaseNeutronStar, Radius: neutronStarRadiusKm / SolarRadiusKm, Temperature: 1e6}
+	}
+	stage := StellarStage{
+		Age:         age,
+		Phase:       PhaseWhiteDwarf,
+		Class:       "D",
+		Radius:      whiteDwarfRadius,
+		Temperature: whiteDwarfTemperature * math.Pow(1+(age-t.Death())/whiteDwarfCooling, -0.4),
+	}
+	stage.Luminosity = stage.Radius * stage.Radius * math.Pow(stage.Temperature/SolarTemperatureK, 4)
+	return stage
+}
+
+// surfaceTemperature returns the effective temperature, in kelvin, of a star of
+// luminosity solar luminosities and radius solar radii
+func surfaceTemperature(luminosity, radius float64) float64 {
+	return SolarTemperatureK * math.Pow(luminosity/(radius*radius), 0.25)
+}
+
+// spectralClass returns the letter of the spectral class of a surface at
+// temperature kelvin
+func spectralClass(temperature float64) string {
+	switch {
+	case temperature >= 30000:
+		return "O"
+	case temperature >= 10000:
+		return "B"
+	case temperature >= 7500:
+		return "A"
+	case temperature >= 6000:
+		return "F"
+	case temperature >= 5200:
+		return "G"
+	case temperature >= 3700:
+		return "K"
+	}
+	return "M"
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestSunEvolution(t *testing.T) {
+	track := NewEvolutionTrack(1)
+	if !almostEqual(track.MainSequence, 10, 1e-9) || !almostEqual(track.Death(), 11, 1e-9) {
+		t.Fatalf("the Sun's track = %+v, want 10 billion years on the main sequence and 1 as a giant", track)
+	}
+
+	today := track.At(4.6)
+	if today.Phase != PhaseMainSequence || today.Class != "G V" || !almostEqual(today.Luminosity, 1, 0.05) || !almostEqual(today.Radius, 1, 0.05) {
+		t.Errorf("the Sun today = %+v, want a G V star of about 1 solar luminosity and radius", today)
+	}
+	if young := track.At(0); !almostEqual(young.Luminosity, 0.7, 1e-9) {
+		t.Errorf("the young Sun shone at %.2f, want 0.7 of today", young.Luminosity)
+	}
+
+	// Near the top of the giant branch it reaches past where Venus orbits
+	giant := track.At(10.99)
+	if giant.Phase != PhaseGiant || giant.Class != "M III" || giant.Radius*SolarRadiusKm < 108e6 {
+		t.Errorf("the Sun as a giant = %+v, want an M III star past Venus's orbit", giant)
+	}
+	if inner, _ := HabitableZone(giant.Luminosity); inner < 30 {
+		t.Errorf("the giant's habitable zone starts at %.1f AU, want out past Saturn", inner)
+	}
+
+	dwarf := track.At(12)
+	if dwarf.Phase != PhaseWhiteDwarf || dwarf.Class != "D" || dwarf.Radius != whiteDwarfRadius || dwarf.Luminosity >= 1 {
+		t.Errorf("the Sun's remnant = %+v, want a faint white dwarf", dwarf)
+	}
+	if older := track.At(15); older.Temperature >= dwarf.Temperature {
+		t.Errorf("the white dwarf warmed from %.0f K to %.0f K, want it cooling", dwarf.Temperature, older.Temperature)
+	}
+}
+
+func TestEvolutionByMass(t *testing.T) {
+	tests := []struct {
+		name    string
+		mass    float64
+		giant   StellarPhase
+		remnant StellarPhase
+	}{
+		{"red dwarf", 0.12, PhaseMainSequence, PhaseWhiteDwarf},
+		{"A star", 3, PhaseGiant, PhaseWhiteDwarf},
+		{"B star", 10, PhaseSupergiant, PhaseNeutronStar},
+		{"O star", 30, PhaseSupergiant, PhaseBlackHole},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			track := NewEvolutionTrack(tt.mass)
+			if got := track.At(track.MainSequence + track.Giant/2).Phase; tt.giant != PhaseMainSequence && got != tt.giant {
+				t.Errorf("after the main sequence it is a %s, want a %s", got, tt.giant)
+			}
+			if tt.giant == PhaseMainSequence && track.Giant != 0 {
+				t.Errorf("a %g solar mass star spends %g billion years as a giant, want none", tt.mass, track.Giant)
+			}
+			remnant := track.At(track.Death() * 1.5)
+			if remnant.Phase != tt.remnant || !remnant.Phase.Remnant() {
+				t.Errorf("it ends as a %s, want a %s", remnant.Phase, tt.remnant)
+			}
+		})
+	}
+
+	// Heavier stars burn out sooner
+	if NewEvolutionTrack(2).MainSequence >= NewEvolutionTrack(1).MainSequence {
+		t.Error("a 2 solar mass star outlives the Sun")
+	}
+}
+
+func TestSolarMasses(t *testing.T) {
+	tests := []struct {
+		name string
+		body CelestialBody
+		want float64
+	}{
+		{"from mass", CelestialBody{Mass: Mass{MassValue: 2.188, MassExponent: 30}}, 1.1},
+		{"from luminosity", CelestialBody{Luminosity: 11.3}, 2},
+		{"the Sun with nothing known", CelestialBody{EnglishName: "Sun"}, 1},
+		{"unknown", CelestialBody{EnglishName: "Vega"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.body.SolarMasses(); !almostEqual(got, tt.want, 0.01) {
+				t.Errorf("SolarMasses() = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import "encoding/json"
+
+// moonReferenceFields are the keys of a moon entry that only names the moon. The
+// API's lists give the name under "moon".
+var moonReferenceFields = map[string]bool{"id": true, "name": true, "englishName": true, "rel": true, "moon": true}
+
+// EmbedsBody reports whether a moon entry describes the moon itself, with fields
+// beyond those that name it
+func EmbedsBody(fields map[string]interface{}) bool {
+	for key := range fields {
+		if !moonReferenceFields[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalJSON reads a moon entry, keeping the whole body when the entry
+// describes the moon rather than only naming it
+func (m *Moon) UnmarshalJSON(data []byte) error {
+	type reference Moon
+	var ref reference
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return err
+	}
+	*m = Moon(ref)
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if !EmbedsBody(fields) {
+		return nil
+	}
+	var body CelestialBody
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+	m.Body = &body
+	return nil
+}
+
+// MarshalJSON writes a moon entry, with the whole body when it has one, so a
+// system file converted to another format keeps its moons
+func (m Moon) MarshalJSON() ([]byte, error) {
+	type reference Moon
+	if m.Body == nil {
+		return json.Marshal(reference(m))
+	}
+
+	body := *m.Body
+	if body.ID == "" {
+		body.ID = m.ID
+	}
+	if body.Name == "" {
+		body.Name = m.Name
+	}
+	if body.EnglishName == "" {
+		body.EnglishName = m.EnglishName
+	}
+	if body.Rel == "" {
+		body.Rel = m.Rel
+	}
+	return json.Marshal(body)
+}
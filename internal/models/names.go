@@ -0,0 +1,46 @@
+package models
+
+import "strings"
+
+// Names returns every name the body is known by: its English name, its name in
+// the API (French for the Solar System), its alternative name and any aliases its
+// system file gives it. Names differing only in case are listed once.
+func (cb CelestialBody) Names() []string {
+	var names []string
+	for _, name := range append([]string{cb.EnglishName, cb.Name, cb.AlternativeName}, cb.Aliases...) {
+		name = strings.TrimSpace(name)
+		if name == "" || containsFold(names, name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// OtherNames returns the names of the body besides the one it is shown by
+func (cb CelestialBody) OtherNames() []string {
+	names := cb.Names()
+	if len(names) > 0 && strings.EqualFold(names[0], cb.EnglishName) {
+		return names[1:]
+	}
+	return names
+}
+
+// MatchesName reports whether name, ignoring case, is the body's id or one of its
+// names
+func (cb CelestialBody) MatchesName(name string) bool {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false
+	}
+	return strings.EqualFold(cb.ID, name) || containsFold(cb.Names(), name)
+}
+
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package models
+
+// Source is where a body's values came from
+type Source string
+
+const (
+	SourceUnknown       Source = ""
+	SourceAPI           Source = "api"       // the Solar System OpenData API
+	SourceSystemFile    Source = "file"      // a system file in the systems directory
+	SourceKnowledgeBase Source = "knowledge" // the built-in moon knowledge base
+)
+
+// sourceOrder is the order sources are listed in when a body has several
+var sourceOrder = []Source{SourceAPI, SourceSystemFile, SourceKnowledgeBase}
+
+// Label names a source for display
+func (s Source) Label() string {
+	switch s {
+	case SourceAPI:
+		return "Solar System OpenData API"
+	case SourceSystemFile:
+		return "System file"
+	case SourceKnowledgeBase:
+		return "Built-in moon knowledge base"
+	default:
+		return "Unknown source"
+	}
+}
+
+// Tag is the short mark put after a value to show its source
+func (s Source) Tag() string {
+	switch s {
+	case SourceAPI:
+		return "[A]"
+	case SourceSystemFile:
+		return "[F]"
+	case SourceKnowledgeBase:
+		return "[K]"
+	default:
+		return "[?]"
+	}
+}
+
+// Provenance records where a body's values came from. Every value comes from
+// Source unless Fields, keyed by JSON field name, says otherwise.
+type Provenance struct {
+	Source   Source
+	Citation string // where Source can be checked: an API URL or a file path
+	Fields   map[string]Source
+}
+
+// SetSource records where the body as a whole came from, clearing any per-field sources
+func (cb *CelestialBody) SetSource(source Source, citation string) {
+	cb.Provenance = Provenance{Source: source, Citation: citation}
+}
+
+// SetFieldSource records that one field, named as in JSON, came from elsewhere.
+// Bodies are copied by value, so the map is copied rather than shared.
+func (cb *CelestialBody) SetFieldSource(field string, source Source) {
+	fields := make(map[string]Source, len(cb.Provenance.Fields)+1)
+	for name, fieldSource := range cb.Provenance.Fields {
+		fields[name] = fieldSource
+	}
+	fields[field] = source
+	cb.Provenance.Fields = fields
+}
+
+// SourceOf returns where a field, named as in JSON, came from
+func (cb CelestialBody) SourceOf(field string) Source {
+	if source, ok := cb.Provenance.Fields[field]; ok {
+		return source
+	}
+	return cb.Provenance.Source
+}
+
+// Sources lists every known source the body's values came from, its own first
+func (cb CelestialBody) Sources() []Source {
+	var sources []Source
+	if cb.Provenance.Source != SourceUnknown {
+		sources = append(sources, cb.Provenance.Source)
+	}
+	for _, source := range sourceOrder {
+		if source == cb.Provenance.Source {
+			continue
+		}
+		for _, fieldSource := range cb.Provenance.Fields {
+			if fieldSource == source {
+				sources = append(sources, source)
+				break
+			}
+		}
+	}
+	return sources
+}
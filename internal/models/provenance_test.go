@@ -0,0 +1,40 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSourceOfFallsBackToBody(t *testing.T) {
+	var body CelestialBody
+	body.SetSource(SourceAPI, "https://example.org/bodies/europe")
+	body.SetFieldSource("meanRadius", SourceKnowledgeBase)
+
+	if got := body.SourceOf("meanRadius"); got != SourceKnowledgeBase {
+		t.Errorf("SourceOf(meanRadius) = %q, want %q", got, SourceKnowledgeBase)
+	}
+	if got := body.SourceOf("mass"); got != SourceAPI {
+		t.Errorf("SourceOf(mass) = %q, want %q", got, SourceAPI)
+	}
+	if got, want := body.Sources(), []Source{SourceAPI, SourceKnowledgeBase}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Sources() = %v, want %v", got, want)
+	}
+}
+
+func TestSetFieldSourceDoesNotShareMap(t *testing.T) {
+	var original CelestialBody
+	original.SetFieldSource("meanRadius", SourceKnowledgeBase)
+
+	copied := original
+	copied.SetFieldSource("sideralOrbit", SourceKnowledgeBase)
+
+	if got := original.SourceOf("sideralOrbit"); got != SourceUnknown {
+		t.Errorf("original SourceOf(sideralOrbit) = %q, want it unchanged", got)
+	}
+}
+
+func TestSourcesOfUnknownBody(t *testing.T) {
+	if got := (CelestialBody{}).Sources(); len(got) != 0 {
+		t.Errorf("Sources() = %v, want none", got)
+	}
+}
@@ -0,0 +1,73 @@
+package models
+
+import "math"
+
+const (
+	// SolarRadiusKm and SolarTemperatureK are the Sun's radius and effective
+	// temperature, which luminosities are worked out against, and SolarMassKg its
+	// mass
+	SolarRadiusKm     = 695700.0
+	SolarTemperatureK = 5772.0
+	SolarMassKg       = 1.98847e30
+
+	// habitableInnerFlux and habitableOuterFlux are the starlight, as a share of
+	// what the Earth gets, at the inner and outer edges of the habitable zone
+	habitableInnerFlux = 1.1
+	habitableOuterFlux = 0.53
+)
+
+// GetSpectralType returns the star's spectral type, e.g. "G2V", falling back to
+// its stellar class for system files that only give that
+func (cb *CelestialBody) GetSpectralType() string {
+	if cb.SpectralType != "" {
+		return cb.SpectralType
+	}
+	return cb.StellarClass
+}
+
+// GetLuminosity returns the star's luminosity in solar luminosities: the one
+// given, else one worked out from its temperature and radius by the
+// Stefan-Boltzmann law, L = (R/R☉)²(T/T☉)⁴, with derived true. The Sun is 1 when
+// nothing is known of it; other bodies are 0.
+func (cb *CelestialBody) GetLuminosity() (luminosity float64, derived bool) {
+	switch {
+	case cb.Luminosity > 0:
+		return cb.Luminosity, false
+	case cb.Temperature > 0 && cb.MeanRadius > 0:
+		radius := cb.MeanRadius / SolarRadiusKm
+		return radius * radius * math.Pow(cb.Temperature/SolarTemperatureK, 4), true
+	case cb.EnglishName == "Sun":
+		return 1, false
+	}
+	return 0, false
+}
+
+// SolarMasses returns the star's mass in solar masses: from its mass, else from
+// its luminosity by the main sequence's L ∝ M³·⁵, else 0
+func (cb *CelestialBody) SolarMasses() float64 {
+	if kg := cb.GetMassKg(); kg > 0 {
+		return kg / SolarMassKg
+	}
+	if luminosity, _ := cb.GetLuminosity(); luminosity > 0 {
+		return math.Pow(luminosity, 1/3.5)
+	}
+	return 0
+}
+
+// HabitableZone returns the inner and outer edges, in AU, of the zone round a
+// star of luminosity (in solar luminosities) where a planet like the Earth could
+// keep liquid water on its surface
+func HabitableZone(luminosity float64) (inner, outer float64) {
+	return math.Sqrt(luminosity / habitableInnerFlux), math.Sqrt(luminosity / habitableOuterFlux)
+}
+
+// HabitableZone returns the edges of the star's habitable zone in AU, and false
+// when its luminosity is not known
+func (cb *CelestialBody) HabitableZone() (inner, outer float64, ok bool) {
+	luminosity, _ := cb.GetLuminosity()
+	if luminosity <= 0 {
+		return 0, 0, false
+	}
+	inner, outer = HabitableZone(luminosity)
+	return inner, outer, true
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGetLuminosity(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        CelestialBody
+		want        float64
+		wantDerived bool
+	}{
+		{"given", CelestialBody{Luminosity: 0.52, Temperature: 5260, MeanRadius: 631700}, 0.52, false},
+		{"from temperature and radius", CelestialBody{Temperature: SolarTemperatureK, MeanRadius: 2 * SolarRadiusKm}, 4, true},
+		{"cool dwarf", CelestialBody{Temperature: 2566, MeanRadius: 84180}, 0.000571, true},
+		{"the Sun with nothing known", CelestialBody{EnglishName: "Sun", MeanRadius: 695508}, 1, false},
+		{"unknown", CelestialBody{EnglishName: "Vega"}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, derived := tt.body.GetLuminosity()
+			if !almostEqual(got, tt.want, 0.01) || derived != tt.wantDerived {
+				t.Errorf("GetLuminosity() = %g, %v, want %g, %v", got, derived, tt.want, tt.wantDerived)
+			}
+		})
+	}
+}
+
+func TestHabitableZone(t *testing.T) {
+	sun := CelestialBody{EnglishName: "Sun"}
+	inner, outer, ok := sun.HabitableZone()
+	if !ok || !almostEqual(inner, 0.953, 0.01) || !almostEqual(outer, 1.374, 0.01) {
+		t.Errorf("Sun's habitable zone = %.3f-%.3f AU (%v), want 0.953-1.374 AU", inner, outer, ok)
+	}
+
+	// A star four times as bright has its zone twice as far out
+	bright := CelestialBody{Luminosity: 4}
+	if brightInner, brightOuter, _ := bright.HabitableZone(); !almostEqual(brightInner, 2*inner, 1e-9) || !almostEqual(brightOuter, 2*outer, 1e-9) {
+		t.Errorf("Habitable zone at 4 L☉ = %.3f-%.3f AU, want twice the Sun's", brightInner, brightOuter)
+	}
+
+	if _, _, ok := (&CelestialBody{EnglishName: "Rogue"}).HabitableZone(); ok {
+		t.Error("HabitableZone() found a zone for a body with no luminosity")
+	}
+}
+
+func TestStellarFieldsFromJSON(t *testing.T) {
+	var star CelestialBody
+	data := `{"englishName": "Alpha Centauri A", "stellarClass": "G", "spectralType": "G2V", "luminosity": 1.519, "metallicity": 0}`
+	if err := json.Unmarshal([]byte(data), &star); err != nil {
+		t.Fatal(err)
+	}
+	if star.GetSpectralType() != "G2V" || star.Luminosity != 1.519 {
+		t.Errorf("Got spectral type %q and luminosity %g", star.GetSpectralType(), star.Luminosity)
+	}
+	if star.Metallicity == nil || *star.Metallicity != 0 {
+		t.Errorf("Expected a solar metallicity of 0 kept, got %v", star.Metallicity)
+	}
+
+	classOnly := CelestialBody{StellarClass: "K1V"}
+	if got := classOnly.GetSpectralType(); got != "K1V" {
+		t.Errorf("GetSpectralType() = %q, want the stellar class K1V", got)
+	}
+}
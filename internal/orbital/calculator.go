@@ -53,7 +53,7 @@ func NewExactCalculator() *ExactCalculator {
 // CalculateMeanAnomaly for Solar System using J2000.0 epoch positions
 func (sc *SolarSystemCalculator) CalculateMeanAnomaly(body models.CelestialBody, currentTime time.Time) float64 {
 	// J2000.0 epoch: January 1, 2000, 12:00 TT
-	j2000 := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	j2000 := models.J2000
 
 	// Accurate mean anomalies at J2000.0 epoch (in radians)
 	j2000MeanAnomalies := map[string]float64{
@@ -143,16 +143,17 @@ func NewCalculatorFactory() *CalculatorFactory {
 	return &CalculatorFactory{}
 }
 
-// CreateCalculator returns appropriate calculator for the given body and system
+// CreateCalculator returns appropriate calculator for the given body and system.
+// A body's own orbital elements win over the built-in Solar System table.
 func (cf *CalculatorFactory) CreateCalculator(body models.CelestialBody, epochTime time.Time) Calculator {
-	if cf.isSolarSystemBody(body) {
-		return NewSolarSystemCalculator(epochTime)
-	}
-
 	if body.OrbitalElements != nil {
 		return NewExactCalculator()
 	}
 
+	if cf.isSolarSystemBody(body) {
+		return NewSolarSystemCalculator(epochTime)
+	}
+
 	return NewGenericCalculator(epochTime)
 }
 
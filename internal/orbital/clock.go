@@ -0,0 +1,121 @@
+package orbital
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is where the real time comes from. SystemClock reads the computer's clock;
+// a StepClock only moves when it is advanced, so a run can be repeated frame for
+// frame.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the computer's clock
+type SystemClock struct{}
+
+// Now returns the current time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ClockFunc adapts a function to a Clock
+type ClockFunc func() time.Time
+
+// Now calls f
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// StepClock starts at a fixed time and moves on by a fixed step each time it is
+// advanced, once per drawn frame in deterministic mode
+type StepClock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+// NewStepClock returns a clock standing at start that advances by step
+func NewStepClock(start time.Time, step time.Duration) *StepClock {
+	return &StepClock{now: start, step: step}
+}
+
+// Now returns the clock's time, which only changes when it is advanced
+func (c *StepClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock on by one step and returns the new time
+func (c *StepClock) Advance() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(c.step)
+	return c.now
+}
+
+// SimulationClock maps real elapsed time onto the simulated timeline shown on screen
+type SimulationClock struct {
+	mu       sync.RWMutex
+	simStart time.Time
+	speed    float64
+
+	// Now is anchorSim plus the real time since anchorReal, scaled by speed
+	anchorReal time.Time
+	anchorSim  time.Time
+
+	source Clock
+}
+
+// NewSimulationClock starts a clock at the current time, running speed simulated
+// seconds per real second
+func NewSimulationClock(speed float64) *SimulationClock {
+	return NewSimulationClockWithSource(speed, SystemClock{})
+}
+
+// NewSimulationClockWithSource is NewSimulationClock reading real time from source,
+// so tests can hold the clock still and deterministic runs can step it
+func NewSimulationClockWithSource(speed float64, source Clock) *SimulationClock {
+	now := source.Now()
+	return &SimulationClock{
+		source:     source,
+		simStart:   now,
+		speed:      speed,
+		anchorReal: now,
+		anchorSim:  now,
+	}
+}
+
+// Now returns the current simulated time
+func (c *SimulationClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	elapsed := c.source.Now().Sub(c.anchorReal).Seconds() * c.speed
+	return c.anchorSim.Add(time.Duration(elapsed * float64(time.Second)))
+}
+
+// Start returns the simulated time the clock started at
+func (c *SimulationClock) Start() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.simStart
+}
+
+// Speed returns how many simulated seconds pass per real second
+func (c *SimulationClock) Speed() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.speed
+}
+
+// Set jumps the simulated timeline to the given time and speed, e.g. to follow
+// another session. Start keeps reporting where the clock began.
+func (c *SimulationClock) Set(simulated time.Time, speed float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.anchorReal = c.source.Now()
+	c.anchorSim = simulated
+	c.speed = speed
+}
@@ -0,0 +1,48 @@
+package orbital
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimulationClockSet(t *testing.T) {
+	clock := NewSimulationClock(1)
+	start := clock.Start()
+
+	target := time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock.Set(target, 3600)
+
+	if got := clock.Now(); got.Before(target) || got.Sub(target) > time.Hour {
+		t.Errorf("Now() = %v, want just after %v", got, target)
+	}
+	if clock.Speed() != 3600 {
+		t.Errorf("Speed() = %v, want 3600", clock.Speed())
+	}
+	if !clock.Start().Equal(start) {
+		t.Errorf("Start() = %v, want it unchanged at %v", clock.Start(), start)
+	}
+}
+
+func TestSimulationClockOnStepClock(t *testing.T) {
+	start := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	run := func() []time.Time {
+		step := NewStepClock(start, 100*time.Millisecond)
+		clock := NewSimulationClockWithSource(3600, step)
+		var times []time.Time
+		for i := 0; i < 3; i++ {
+			times = append(times, clock.Now())
+			step.Advance()
+		}
+		return times
+	}
+
+	first, second := run(), run()
+	for i := range first {
+		if !first[i].Equal(second[i]) {
+			t.Errorf("frame %d at %v, then %v on a second run", i, first[i], second[i])
+		}
+	}
+	if want := start.Add(6 * time.Minute); !first[1].Equal(want) {
+		t.Errorf("after one step Now() = %v, want %v", first[1], want)
+	}
+}
@@ -0,0 +1,68 @@
+package orbital
+
+import (
+	"math"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// OrbitParameter returns μ = G(M + m) in km³/s² for a body's orbit, the central
+// mass and the body's own together, as Kepler's third law gives it from the
+// semi-major axis and period. Going by the orbit itself works for moons and for
+// systems whose star has no recorded mass. ok is false without both figures.
+func OrbitParameter(body models.CelestialBody) (mu float64, ok bool) {
+	if body.SemimajorAxis <= 0 || body.SideralOrbit <= 0 {
+		return 0, false
+	}
+	n := 2 * math.Pi / (body.SideralOrbit * 86400)
+	return n * n * body.SemimajorAxis * body.SemimajorAxis * body.SemimajorAxis, true
+}
+
+// OrbitEccentricity returns a body's eccentricity, preferring its orbital
+// elements, clamped to a closed orbit
+func OrbitEccentricity(body models.CelestialBody) float64 {
+	if body.OrbitalElements != nil {
+		return ClampEccentricity(body.OrbitalElements.Eccentricity)
+	}
+	return ClampEccentricity(body.Eccentricity)
+}
+
+// SpecificOrbitalEnergy returns the orbit's energy per kg of the orbiting body,
+// ε = -μ/2a, in km²/s² (MJ/kg). The more negative, the more tightly bound.
+func SpecificOrbitalEnergy(mu, semimajorAxis float64) float64 {
+	if mu <= 0 || semimajorAxis <= 0 {
+		return 0
+	}
+	return -mu / (2 * semimajorAxis)
+}
+
+// SpecificAngularMomentum returns the orbit's angular momentum per kg of the
+// orbiting body, h = √(μa(1 - e²)), in km²/s
+func SpecificAngularMomentum(mu, semimajorAxis, eccentricity float64) float64 {
+	if mu <= 0 || semimajorAxis <= 0 {
+		return 0
+	}
+	return math.Sqrt(mu * semimajorAxis * (1 - eccentricity*eccentricity))
+}
+
+// OrbitalSpeed returns the speed in km/s at distance r km from the focus of an
+// orbit with semi-major axis a km, by the vis-viva equation v² = μ(2/r - 1/a)
+func OrbitalSpeed(mu, r, semimajorAxis float64) float64 {
+	if mu <= 0 || r <= 0 || semimajorAxis <= 0 {
+		return 0
+	}
+	return math.Sqrt(math.Max(mu*(2/r-1/semimajorAxis), 0))
+}
+
+// SpeedAt returns a body's orbital speed in km/s at the simulated time t, with
+// its distance from the focus in km. ok is false for bodies OrbitParameter
+// cannot work with.
+func (e *Ephemeris) SpeedAt(body models.CelestialBody, t time.Time) (speed, r float64, ok bool) {
+	mu, ok := OrbitParameter(body)
+	if !ok {
+		return 0, 0, false
+	}
+	r = OrbitalRadius(body.SemimajorAxis, OrbitEccentricity(body), e.TrueAnomaly(body, t))
+	return OrbitalSpeed(mu, r, body.SemimajorAxis), r, true
+}
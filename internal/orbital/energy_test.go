@@ -0,0 +1,46 @@
+package orbital
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestOrbitalEnergyOfEarth(t *testing.T) {
+	earth := models.CelestialBody{EnglishName: "Earth", SemimajorAxis: 149598023, SideralOrbit: 365.256, Eccentricity: 0.0167}
+
+	mu, ok := OrbitParameter(earth)
+	if !ok || math.Abs(mu/GravitationalParameter(SolarMass)-1) > 0.01 {
+		t.Fatalf("OrbitParameter(Earth) = %.4e, %v; want the Sun's μ", mu, ok)
+	}
+	if got := SpecificOrbitalEnergy(mu, earth.SemimajorAxis); math.Abs(got+443.5) > 1 {
+		t.Errorf("SpecificOrbitalEnergy(Earth) = %.1f MJ/kg, want about -443.5", got)
+	}
+	if got := SpecificAngularMomentum(mu, earth.SemimajorAxis, OrbitEccentricity(earth)); math.Abs(got/4.455e9-1) > 0.01 {
+		t.Errorf("SpecificAngularMomentum(Earth) = %.4e km²/s, want about 4.455e9", got)
+	}
+
+	a := earth.SemimajorAxis
+	if got := OrbitalSpeed(mu, a*(1-earth.Eccentricity), a); math.Abs(got-30.29) > 0.05 {
+		t.Errorf("speed at perihelion = %.2f km/s, want about 30.29", got)
+	}
+	if got := OrbitalSpeed(mu, a*(1+earth.Eccentricity), a); math.Abs(got-29.29) > 0.05 {
+		t.Errorf("speed at aphelion = %.2f km/s, want about 29.29", got)
+	}
+
+	speed, r, ok := NewEphemeris(time.Now()).SpeedAt(earth, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))
+	if !ok || speed < 29.2 || speed > 30.4 || r < a*(1-earth.Eccentricity)-1 || r > a*(1+earth.Eccentricity)+1 {
+		t.Errorf("SpeedAt(Earth) = %.2f km/s at %.0f km, %v; want a speed and distance on its orbit", speed, r, ok)
+	}
+}
+
+func TestOrbitParameterNeedsAxisAndPeriod(t *testing.T) {
+	if _, ok := OrbitParameter(models.CelestialBody{SemimajorAxis: 1e8}); ok {
+		t.Error("Expected no μ without a period")
+	}
+	if got := SpecificOrbitalEnergy(0, 1e8); got != 0 {
+		t.Errorf("SpecificOrbitalEnergy(0) = %v, want 0", got)
+	}
+}
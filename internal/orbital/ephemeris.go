@@ -0,0 +1,123 @@
+package orbital
+
+import (
+	"math"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// Ephemeris works out where bodies are at any point on the simulated timeline
+type Ephemeris struct {
+	factory *CalculatorFactory
+	epoch   time.Time
+}
+
+// NewEphemeris creates an ephemeris; epoch anchors bodies whose position is not
+// otherwise known (see GenericCalculator)
+func NewEphemeris(epoch time.Time) *Ephemeris {
+	return &Ephemeris{
+		factory: NewCalculatorFactory(),
+		epoch:   epoch,
+	}
+}
+
+// MeanAnomaly returns a body's mean anomaly in radians at time t
+func (e *Ephemeris) MeanAnomaly(body models.CelestialBody, t time.Time) float64 {
+	return e.factory.CreateCalculator(body, e.epoch).CalculateMeanAnomaly(body, t)
+}
+
+// TrueAnomaly returns the angle from periapsis in radians at time t
+func (e *Ephemeris) TrueAnomaly(body models.CelestialBody, t time.Time) float64 {
+	if body.SideralOrbit <= 0 {
+		return 0
+	}
+	return BodyTrueAnomaly(body, e.MeanAnomaly(body, t))
+}
+
+// Longitude returns the body's heliocentric longitude in radians at time t
+func (e *Ephemeris) Longitude(body models.CelestialBody, t time.Time) float64 {
+	return math.Mod(e.TrueAnomaly(body, t)+PeriapsisLongitude(body), 2*math.Pi)
+}
+
+// j2000PerihelionLongitudes are the longitudes of perihelion (ϖ) of the Solar
+// System's planets at J2000.0 in degrees. They drift by well under a degree a century.
+var j2000PerihelionLongitudes = map[string]float64{
+	"Mercury": 77.456,
+	"Venus":   131.533,
+	"Earth":   102.947,
+	"Mars":    336.041,
+	"Jupiter": 14.753,
+	"Saturn":  92.432,
+	"Uranus":  170.964,
+	"Neptune": 44.971,
+	"Pluto":   224.067,
+}
+
+// EclipticLongitude returns a body's heliocentric ecliptic longitude in degrees,
+// from 0 up to 360, at time t: its true anomaly plus its longitude of perihelion.
+// ok is false when neither the body's orbital elements nor the table of Solar
+// System perihelia say where its perihelion is.
+func (e *Ephemeris) EclipticLongitude(body models.CelestialBody, t time.Time) (float64, bool) {
+	perihelion := PeriapsisLongitude(body) * 180 / math.Pi
+	if body.OrbitalElements == nil {
+		known := false
+		if perihelion, known = j2000PerihelionLongitudes[body.EnglishName]; !known {
+			return 0, false
+		}
+	}
+
+	longitude := math.Mod(e.TrueAnomaly(body, t)*180/math.Pi+perihelion, 360)
+	if longitude < 0 {
+		longitude += 360
+	}
+	return longitude, true
+}
+
+// Position returns the body's heliocentric position in the orbital plane in km at time t
+func (e *Ephemeris) Position(body models.CelestialBody, t time.Time) (x, y float64) {
+	trueAnomaly := e.TrueAnomaly(body, t)
+	distance := body.SemimajorAxis
+	if body.OrbitalElements != nil {
+		distance = OrbitalRadius(body.SemimajorAxis, ClampEccentricity(body.OrbitalElements.Eccentricity), trueAnomaly)
+	}
+
+	angle := trueAnomaly + PeriapsisLongitude(body)
+	return distance * math.Cos(angle), distance * math.Sin(angle)
+}
+
+// BodyTrueAnomaly converts a mean anomaly into a true anomaly. Bodies with full
+// orbital elements get an exact solution of Kepler's equation; the rest use the
+// first-order approximation ν ≈ M + 2e·sin(M), which is plenty for near-circular orbits.
+func BodyTrueAnomaly(body models.CelestialBody, meanAnomaly float64) float64 {
+	if body.OrbitalElements != nil {
+		return TrueAnomaly(meanAnomaly, ClampEccentricity(body.OrbitalElements.Eccentricity))
+	}
+
+	if body.Eccentricity > 0 {
+		trueAnomaly := meanAnomaly + 2*body.Eccentricity*math.Sin(meanAnomaly)
+		return math.Mod(trueAnomaly, 2*math.Pi)
+	}
+
+	return math.Mod(meanAnomaly, 2*math.Pi)
+}
+
+// PeriapsisLongitude returns the longitude of periapsis (Ω + ω) in radians, or 0
+// for bodies without orbital elements
+func PeriapsisLongitude(body models.CelestialBody) float64 {
+	if body.OrbitalElements == nil {
+		return 0
+	}
+	return (body.OrbitalElements.LongitudeOfAscendingNode + body.OrbitalElements.ArgumentOfPeriapsis) * math.Pi / 180.0
+}
+
+// ClampEccentricity keeps eccentricity within the range of closed orbits we can draw
+func ClampEccentricity(eccentricity float64) float64 {
+	if eccentricity < 0 {
+		return 0
+	}
+	if eccentricity > 0.99 {
+		return 0.99
+	}
+	return eccentricity
+}
@@ -0,0 +1,75 @@
+package orbital
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestEclipticLongitudeOfEarth(t *testing.T) {
+	earth := models.CelestialBody{EnglishName: "Earth", SideralOrbit: 365.256, Eccentricity: 0.0167}
+	ephemeris := NewEphemeris(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// Seen from the Sun, Earth is opposite the Sun's place in Earth's sky
+	tests := []struct {
+		name string
+		at   time.Time
+		want float64
+	}{
+		{"March equinox", time.Date(2025, 3, 20, 9, 1, 0, 0, time.UTC), 180},
+		{"June solstice", time.Date(2025, 6, 21, 2, 42, 0, 0, time.UTC), 270},
+		{"September equinox", time.Date(2025, 9, 22, 18, 19, 0, 0, time.UTC), 0},
+		{"December solstice", time.Date(2025, 12, 21, 15, 3, 0, 0, time.UTC), 90},
+	}
+
+	for _, tt := range tests {
+		got, ok := ephemeris.EclipticLongitude(earth, tt.at)
+		if !ok {
+			t.Fatalf("%s: EclipticLongitude() not known", tt.name)
+		}
+		diff := math.Abs(math.Mod(got-tt.want+540, 360) - 180)
+		if diff > 1 {
+			t.Errorf("%s: EclipticLongitude() = %.2f°, want %.0f° ± 1°", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEclipticLongitudeUnknownPerihelion(t *testing.T) {
+	body := models.CelestialBody{EnglishName: "Kepler-452b", SideralOrbit: 384.8}
+	if _, ok := NewEphemeris(time.Now()).EclipticLongitude(body, time.Now()); ok {
+		t.Error("EclipticLongitude() known for a body without a perihelion")
+	}
+}
+
+func TestOwnElementsWinOverSolarSystemTable(t *testing.T) {
+	mars := models.CelestialBody{EnglishName: "Mars", SideralOrbit: 686.98, SemimajorAxis: 227939200, MainAnomaly: 90}
+	mars.FillOrbitalElements()
+	ephemeris := NewEphemeris(models.J2000)
+
+	if got := NewCalculatorFactory().CreateCalculator(mars, models.J2000).GetSystemType(); got != SystemTypeExact {
+		t.Errorf("CreateCalculator() = %s, want %s", got, SystemTypeExact)
+	}
+	if got := ephemeris.MeanAnomaly(mars, models.J2000); math.Abs(got-math.Pi/2) > 1e-9 {
+		t.Errorf("MeanAnomaly() at J2000 = %.4f rad, want the elements' π/2", got)
+	}
+}
+
+func TestEclipticLongitudeFromAPIElements(t *testing.T) {
+	// Earth's orbital angles as the Solar System API gives them
+	earth := models.CelestialBody{
+		EnglishName: "Earth", SideralOrbit: 365.256, SemimajorAxis: 149598023, Eccentricity: 0.0167,
+		MainAnomaly: 358.617, ArgPeriapsis: 85.901, LongAscNode: 18.272,
+	}
+	earth.FillOrbitalElements()
+	ephemeris := NewEphemeris(models.J2000)
+
+	got, ok := ephemeris.EclipticLongitude(earth, time.Date(2025, 3, 20, 9, 1, 0, 0, time.UTC))
+	if !ok {
+		t.Fatal("EclipticLongitude() not known")
+	}
+	if diff := math.Abs(math.Mod(got-180+540, 360) - 180); diff > 3 {
+		t.Errorf("EclipticLongitude() at the March equinox = %.2f°, want 180° ± 3°", got)
+	}
+}
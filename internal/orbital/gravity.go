@@ -0,0 +1,34 @@
+package orbital
+
+import "github.com/furan917/go-solar-system/internal/models"
+
+// StandardGravity is Earth's surface gravity in m/s², used to turn a weight back
+// into the mass a bathroom scale would show
+const StandardGravity = 9.80665
+
+// SurfaceGravity returns a body's surface gravity in m/s²: the recorded value, or
+// GM/r² from its mass and mean radius. ok is false when neither is known.
+func SurfaceGravity(body models.CelestialBody) (gravity float64, ok bool) {
+	if body.Gravity > 0 {
+		return body.Gravity, true
+	}
+
+	mass := body.GetMassKg()
+	if mass <= 0 || body.MeanRadius <= 0 {
+		return 0, false
+	}
+	// GM/r² comes out in km/s²
+	return GravitationalParameter(mass) / (body.MeanRadius * body.MeanRadius) * 1000, true
+}
+
+// Weight is the force in newtons on a mass in kg standing on a surface with the
+// given gravity
+func Weight(mass, gravity float64) float64 {
+	return mass * gravity
+}
+
+// ScaleReading is what a bathroom scale calibrated on Earth would show, in kg, for
+// a mass in kg on a surface with the given gravity
+func ScaleReading(mass, gravity float64) float64 {
+	return mass * gravity / StandardGravity
+}
@@ -0,0 +1,37 @@
+package orbital
+
+import (
+	"math"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestSurfaceGravity(t *testing.T) {
+	recorded := models.CelestialBody{Gravity: 3.71}
+	if g, ok := SurfaceGravity(recorded); !ok || g != 3.71 {
+		t.Errorf("SurfaceGravity(recorded) = %v, %v, want 3.71", g, ok)
+	}
+
+	// Earth from mass and radius alone
+	earth := models.CelestialBody{Mass: models.Mass{MassValue: 5.97237, MassExponent: 24}, MeanRadius: 6371.0084}
+	if g, ok := SurfaceGravity(earth); !ok || math.Abs(g-9.82) > 0.02 {
+		t.Errorf("SurfaceGravity(earth) = %v, %v, want about 9.82", g, ok)
+	}
+
+	if _, ok := SurfaceGravity(models.CelestialBody{MeanRadius: 100}); ok {
+		t.Error("SurfaceGravity() without mass or gravity should not be ok")
+	}
+}
+
+func TestWeightAndScaleReading(t *testing.T) {
+	if got := Weight(70, 1.62); math.Abs(got-113.4) > 1e-9 {
+		t.Errorf("Weight() = %v, want 113.4", got)
+	}
+	if got := ScaleReading(70, StandardGravity); math.Abs(got-70) > 1e-9 {
+		t.Errorf("ScaleReading() on Earth = %v, want 70", got)
+	}
+	if got := ScaleReading(70, 1.62); math.Abs(got-11.563) > 0.001 {
+		t.Errorf("ScaleReading() on the Moon = %v, want about 11.56", got)
+	}
+}
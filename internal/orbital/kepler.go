@@ -0,0 +1,60 @@
+package orbital
+
+import "math"
+
+// keplerTolerance is the convergence threshold (radians) for Kepler's equation
+const keplerTolerance = 1e-10
+
+// SolveKeplerEquation returns the eccentric anomaly E for mean anomaly M (radians)
+// by solving M = E - e·sin(E) with Newton-Raphson iteration
+func SolveKeplerEquation(meanAnomaly, eccentricity float64) float64 {
+	m := math.Mod(meanAnomaly, 2*math.Pi)
+	if m < 0 {
+		m += 2 * math.Pi
+	}
+
+	if eccentricity <= 0 {
+		return m
+	}
+
+	// Starting at π converges reliably for highly eccentric orbits
+	e := m
+	if eccentricity > 0.8 {
+		e = math.Pi
+	}
+
+	for i := 0; i < 50; i++ {
+		delta := (e - eccentricity*math.Sin(e) - m) / (1 - eccentricity*math.Cos(e))
+		e -= delta
+		if math.Abs(delta) < keplerTolerance {
+			break
+		}
+	}
+
+	return e
+}
+
+// TrueAnomaly converts a mean anomaly (radians) into the true anomaly (radians)
+// for an elliptical orbit with the given eccentricity
+func TrueAnomaly(meanAnomaly, eccentricity float64) float64 {
+	if eccentricity <= 0 {
+		return math.Mod(meanAnomaly, 2*math.Pi)
+	}
+
+	e := SolveKeplerEquation(meanAnomaly, eccentricity)
+	nu := 2 * math.Atan2(
+		math.Sqrt(1+eccentricity)*math.Sin(e/2),
+		math.Sqrt(1-eccentricity)*math.Cos(e/2),
+	)
+
+	if nu < 0 {
+		nu += 2 * math.Pi
+	}
+	return nu
+}
+
+// OrbitalRadius returns the distance from the focus at the given true anomaly,
+// in the same units as the semi-major axis
+func OrbitalRadius(semimajorAxis, eccentricity, trueAnomaly float64) float64 {
+	return semimajorAxis * (1 - eccentricity*eccentricity) / (1 + eccentricity*math.Cos(trueAnomaly))
+}
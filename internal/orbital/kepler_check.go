@@ -0,0 +1,102 @@
+package orbital
+
+import (
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// KeplerTolerance is how far, as a fraction, a recorded period may stray from
+// the one Kepler's third law gives before it is flagged
+const KeplerTolerance = 0.1
+
+// KeplerPeriod returns the period in days of an orbit with a semi-major axis in km
+// around a central mass, counting the orbiting body's own mass (both in kg)
+func KeplerPeriod(semimajorAxis, centralMass, bodyMass float64) float64 {
+	mu := GravitationalParameter(centralMass + bodyMass)
+	if mu <= 0 || semimajorAxis <= 0 {
+		return 0
+	}
+	return 2 * math.Pi * math.Sqrt(semimajorAxis*semimajorAxis*semimajorAxis/mu) / 86400
+}
+
+// KeplerIssue is a body whose recorded period disagrees with its orbit
+type KeplerIssue struct {
+	Body      string
+	Host      string  // what it was taken to orbit
+	Recorded  float64 // days, as loaded
+	Expected  float64 // days, from the semi-major axis and the host's mass
+	Deviation float64 // (Recorded - Expected) / Expected
+}
+
+// KeplerReport is the outcome of checking a system against Kepler's third law
+type KeplerReport struct {
+	Checked     int // bodies with both a semi-major axis and a period
+	Issues      []KeplerIssue
+	AssumedMass bool // no star has a recorded mass, so a Sun's was used
+}
+
+// keplerHost is a mass a body may orbit
+type keplerHost struct {
+	name string
+	mass float64
+}
+
+// keplerHosts lists what the bodies of a system may orbit. Files do not say which
+// star a planet goes round, so with several stars each is a candidate, and so are
+// all of them together for a planet circling a close pair.
+func keplerHosts(bodies []models.CelestialBody) []keplerHost {
+	var hosts []keplerHost
+	total := 0.0
+	for _, body := range bodies {
+		if body.BodyType == "Star" && body.GetMassKg() > 0 {
+			hosts = append(hosts, keplerHost{body.EnglishName, body.GetMassKg()})
+			total += body.GetMassKg()
+		}
+	}
+	if len(hosts) > 1 {
+		hosts = append(hosts, keplerHost{"all the stars", total})
+	}
+	return hosts
+}
+
+// CheckKepler compares each body's recorded sidereal period with the one its
+// semi-major axis gives, flagging bodies that differ by more than tolerance. With
+// several stars a body is measured against whichever fits it best. Stars, moons
+// and bodies missing either figure are skipped.
+func CheckKepler(bodies []models.CelestialBody, tolerance float64) KeplerReport {
+	var report KeplerReport
+	hosts := keplerHosts(bodies)
+	if len(hosts) == 0 {
+		hosts = []keplerHost{{"a Sun-like star", SolarMass}}
+		report.AssumedMass = true
+	}
+
+	for _, body := range bodies {
+		if body.BodyType == "Star" || body.BodyType == "Moon" || body.AroundPlanet != nil ||
+			body.SemimajorAxis <= 0 || body.SideralOrbit <= 0 {
+			continue
+		}
+
+		var best KeplerIssue
+		for _, host := range hosts {
+			expected := KeplerPeriod(body.SemimajorAxis, host.mass, body.GetMassKg())
+			deviation := (body.SideralOrbit - expected) / expected
+			if best.Host == "" || math.Abs(deviation) < math.Abs(best.Deviation) {
+				best = KeplerIssue{
+					Body:      body.EnglishName,
+					Host:      host.name,
+					Recorded:  body.SideralOrbit,
+					Expected:  expected,
+					Deviation: deviation,
+				}
+			}
+		}
+
+		report.Checked++
+		if math.Abs(best.Deviation) > tolerance {
+			report.Issues = append(report.Issues, best)
+		}
+	}
+	return report
+}
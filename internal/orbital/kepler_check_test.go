@@ -0,0 +1,66 @@
+package orbital
+
+import (
+	"math"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestKeplerPeriodGivesEarthsYear(t *testing.T) {
+	earthMass := 5.972e24
+	if got := KeplerPeriod(149598023, SolarMass, earthMass); math.Abs(got-365.25) > 0.5 {
+		t.Errorf("KeplerPeriod(1 AU, Sun) = %.2f days, want about 365.25", got)
+	}
+	if got := KeplerPeriod(0, SolarMass, 0); got != 0 {
+		t.Errorf("KeplerPeriod(0, Sun) = %v, want 0", got)
+	}
+}
+
+func TestCheckKepler(t *testing.T) {
+	bodies := []models.CelestialBody{
+		{EnglishName: "Sun", BodyType: "Star", Mass: models.Mass{MassValue: 1.989, MassExponent: 30}},
+		{EnglishName: "Earth", BodyType: "Planet", SemimajorAxis: 149598023, SideralOrbit: 365.256},
+		{EnglishName: "Mars", BodyType: "Planet", SemimajorAxis: 227939200, SideralOrbit: 686.98},
+		{EnglishName: "Typo", BodyType: "Planet", SemimajorAxis: 227939200, SideralOrbit: 68.698},
+		{EnglishName: "Moon", BodyType: "Moon", SemimajorAxis: 384400, SideralOrbit: 27.32},
+		{EnglishName: "Unknown", BodyType: "Planet", SemimajorAxis: 1e8},
+	}
+
+	report := CheckKepler(bodies, KeplerTolerance)
+	if report.Checked != 3 || report.AssumedMass {
+		t.Errorf("report = %+v, want 3 bodies checked (Earth, Mars, Typo) against the Sun's mass", report)
+	}
+	if len(report.Issues) != 1 || report.Issues[0].Body != "Typo" {
+		t.Fatalf("issues = %+v, want only Typo", report.Issues)
+	}
+	if issue := report.Issues[0]; issue.Host != "Sun" || issue.Deviation > -0.85 || math.Abs(issue.Expected-687) > 2 {
+		t.Errorf("Typo = %+v, want about -90%% of 687 days around the Sun", issue)
+	}
+}
+
+func TestCheckKeplerPicksTheBestFittingStar(t *testing.T) {
+	// A small star's planet in a system whose heaviest star is a Sun
+	bodies := []models.CelestialBody{
+		{EnglishName: "Big", BodyType: "Star", Mass: models.Mass{MassValue: 1.989, MassExponent: 30}},
+		{EnglishName: "Small", BodyType: "Star", Mass: models.Mass{MassValue: 1.989, MassExponent: 29}},
+		{EnglishName: "b", BodyType: "Planet", SemimajorAxis: 149598023, SideralOrbit: 365.256 * math.Sqrt(10)},
+	}
+
+	report := CheckKepler(bodies, KeplerTolerance)
+	if len(report.Issues) != 0 || report.Checked != 1 {
+		t.Errorf("report = %+v, want b to fit the small star", report)
+	}
+}
+
+func TestCheckKeplerAssumesASunWithoutStarMasses(t *testing.T) {
+	bodies := []models.CelestialBody{
+		{EnglishName: "Star", BodyType: "Star"},
+		{EnglishName: "b", BodyType: "Planet", SemimajorAxis: 149598023, SideralOrbit: 36.5},
+	}
+
+	report := CheckKepler(bodies, KeplerTolerance)
+	if !report.AssumedMass || len(report.Issues) != 1 || report.Issues[0].Host != "a Sun-like star" {
+		t.Errorf("report = %+v, want b flagged against an assumed solar mass", report)
+	}
+}
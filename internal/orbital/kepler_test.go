@@ -0,0 +1,58 @@
+package orbital
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSolveKeplerEquation(t *testing.T) {
+	tests := []struct {
+		name         string
+		meanAnomaly  float64
+		eccentricity float64
+	}{
+		{"Circular orbit", 1.2, 0},
+		{"Earth-like orbit", 2.5, 0.0167},
+		{"Mercury-like orbit", 4.0, 0.2056},
+		{"Highly eccentric orbit", 0.3, 0.95},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := SolveKeplerEquation(tt.meanAnomaly, tt.eccentricity)
+			residual := e - tt.eccentricity*math.Sin(e) - tt.meanAnomaly
+			if math.Abs(residual) > 1e-9 {
+				t.Errorf("SolveKeplerEquation(%v, %v) residual = %g", tt.meanAnomaly, tt.eccentricity, residual)
+			}
+		})
+	}
+}
+
+func TestTrueAnomaly(t *testing.T) {
+	if nu := TrueAnomaly(1.0, 0); math.Abs(nu-1.0) > 1e-12 {
+		t.Errorf("TrueAnomaly for circular orbit = %v, want 1.0", nu)
+	}
+
+	// Periapsis and apoapsis are fixed points regardless of eccentricity
+	if nu := TrueAnomaly(0, 0.5); math.Abs(nu) > 1e-9 {
+		t.Errorf("TrueAnomaly at periapsis = %v, want 0", nu)
+	}
+	if nu := TrueAnomaly(math.Pi, 0.5); math.Abs(nu-math.Pi) > 1e-9 {
+		t.Errorf("TrueAnomaly at apoapsis = %v, want π", nu)
+	}
+
+	// A body moves ahead of its mean position after periapsis
+	if nu := TrueAnomaly(0.5, 0.3); nu <= 0.5 {
+		t.Errorf("TrueAnomaly(0.5, 0.3) = %v, expected to lead the mean anomaly", nu)
+	}
+}
+
+func TestOrbitalRadius(t *testing.T) {
+	a, e := 100.0, 0.2
+	if r := OrbitalRadius(a, e, 0); math.Abs(r-80) > 1e-9 {
+		t.Errorf("periapsis radius = %v, want 80", r)
+	}
+	if r := OrbitalRadius(a, e, math.Pi); math.Abs(r-120) > 1e-9 {
+		t.Errorf("apoapsis radius = %v, want 120", r)
+	}
+}
@@ -0,0 +1,154 @@
+package orbital
+
+import (
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// LaunchOutcome is what happens to a projectile fired sideways from a body's surface
+type LaunchOutcome int
+
+const (
+	LaunchFallsBack LaunchOutcome = iota // slower than a circular orbit: it comes back down
+	LaunchOrbits                         // between circular and escape speed: a closed orbit
+	LaunchEscapes                        // at or above escape speed: it never comes back
+)
+
+func (o LaunchOutcome) String() string {
+	switch o {
+	case LaunchOrbits:
+		return "orbits"
+	case LaunchEscapes:
+		return "escapes"
+	default:
+		return "falls back"
+	}
+}
+
+// launchEscapeRadius is how far out, in body radii, an escaping flight is followed
+const launchEscapeRadius = 8.0
+
+// launchSteps caps the integration so a flight always ends
+const launchSteps = 20000
+
+// LaunchSpeeds returns the circular orbit speed and the escape speed at a body's
+// surface, in km/s. The recorded escape velocity (m/s) is used when there is one,
+// otherwise both come from the surface gravity and radius. ok is false when
+// neither is known.
+func LaunchSpeeds(body models.CelestialBody) (circular, escape float64, ok bool) {
+	if body.Escape > 0 {
+		escape = body.Escape / 1000
+		return escape / math.Sqrt2, escape, true
+	}
+
+	gravity, known := SurfaceGravity(body)
+	if !known || body.MeanRadius <= 0 {
+		return 0, 0, false
+	}
+	// v = √(g·r), with g in m/s² and r in m, then back to km/s
+	circular = math.Sqrt(gravity*body.MeanRadius*1000) / 1000
+	return circular, circular * math.Sqrt2, true
+}
+
+// ClassifyLaunch says what a horizontal launch at speed does, given the body's
+// circular and escape speeds in the same units
+func ClassifyLaunch(speed, circular, escape float64) LaunchOutcome {
+	switch {
+	case speed >= escape:
+		return LaunchEscapes
+	case speed >= circular:
+		return LaunchOrbits
+	default:
+		return LaunchFallsBack
+	}
+}
+
+// LaunchPoint is a point on a flight path in body radii, with the body's centre at
+// the origin and the launch site at (0, 1)
+type LaunchPoint struct {
+	X, Y float64
+}
+
+// LaunchFlight is a simulated flight
+type LaunchFlight struct {
+	Outcome  LaunchOutcome
+	Path     []LaunchPoint
+	Duration float64 // seconds until it lands, completes an orbit or leaves the frame
+	Period   float64 // seconds per lap for a flight that orbits, even one that leaves the frame
+}
+
+// SimulateLaunch follows a projectile fired horizontally from the surface at
+// speed km/s, ignoring air and the body's spin. circular is the body's circular
+// orbit speed in km/s and radius its radius in km, which set the time scale.
+//
+// The flight is integrated in units where the radius and the circular speed are
+// 1, so every body gives the same shapes for the same speed ratio.
+func SimulateLaunch(speed, circular, radius float64) LaunchFlight {
+	escape := circular * math.Sqrt2
+	flight := LaunchFlight{Outcome: ClassifyLaunch(speed, circular, escape)}
+	if circular <= 0 || radius <= 0 {
+		return flight
+	}
+
+	x, y := 0.0, 1.0
+	vx, vy := speed/circular, 0.0
+	ax, ay := gravityAt(x, y)
+	t, swept, lastAngle := 0.0, 0.0, math.Atan2(y, x)
+	flight.Path = append(flight.Path, LaunchPoint{x, y})
+
+	for step := 0; step < launchSteps; step++ {
+		// Smaller steps close in, where the pull changes fastest
+		r := math.Hypot(x, y)
+		dt := 0.005 * r * math.Sqrt(r)
+
+		// Velocity Verlet keeps closed orbits closed
+		x += vx*dt + 0.5*ax*dt*dt
+		y += vy*dt + 0.5*ay*dt*dt
+		nax, nay := gravityAt(x, y)
+		vx += 0.5 * (ax + nax) * dt
+		vy += 0.5 * (ay + nay) * dt
+		ax, ay = nax, nay
+		t += dt
+
+		angle := math.Atan2(y, x)
+		delta := angle - lastAngle
+		if delta > math.Pi {
+			delta -= 2 * math.Pi
+		} else if delta < -math.Pi {
+			delta += 2 * math.Pi
+		}
+		swept += math.Abs(delta)
+		lastAngle = angle
+
+		r = math.Hypot(x, y)
+		if r < 1 && flight.Outcome == LaunchFallsBack {
+			// Put the landing point on the surface
+			x, y = x/r, y/r
+			flight.Path = append(flight.Path, LaunchPoint{x, y})
+			break
+		}
+		flight.Path = append(flight.Path, LaunchPoint{x, y})
+		if r > launchEscapeRadius || swept >= 2*math.Pi {
+			break
+		}
+	}
+
+	// One time unit is r/v_circ seconds
+	unit := radius / circular
+	flight.Duration = t * unit
+	if flight.Outcome == LaunchOrbits {
+		// Kepler's third law, with the semi-major axis from the vis-viva equation
+		ratio := speed / circular
+		a := 1 / (2 - ratio*ratio)
+		flight.Period = 2 * math.Pi * a * math.Sqrt(a) * unit
+	}
+	return flight
+}
+
+// gravityAt is the pull towards the origin with GM = 1
+func gravityAt(x, y float64) (float64, float64) {
+	r := math.Hypot(x, y)
+	r3 := r * r * r
+	return -x / r3, -y / r3
+}
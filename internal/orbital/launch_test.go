@@ -0,0 +1,73 @@
+package orbital
+
+import (
+	"math"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestLaunchSpeeds(t *testing.T) {
+	// Earth's recorded escape velocity is in m/s
+	circular, escape, ok := LaunchSpeeds(models.CelestialBody{Escape: 11190})
+	if !ok || math.Abs(escape-11.19) > 1e-9 || math.Abs(circular-7.91) > 0.01 {
+		t.Errorf("LaunchSpeeds(recorded) = %v, %v, %v, want about 7.91 and 11.19", circular, escape, ok)
+	}
+
+	// The Moon from gravity and radius alone
+	circular, escape, ok = LaunchSpeeds(models.CelestialBody{Gravity: 1.62, MeanRadius: 1737.4})
+	if !ok || math.Abs(escape-2.37) > 0.01 || math.Abs(circular-1.68) > 0.01 {
+		t.Errorf("LaunchSpeeds(moon) = %v, %v, %v, want about 1.68 and 2.37", circular, escape, ok)
+	}
+
+	if _, _, ok := LaunchSpeeds(models.CelestialBody{MeanRadius: 100}); ok {
+		t.Error("LaunchSpeeds() without gravity, mass or escape velocity should not be ok")
+	}
+}
+
+func TestClassifyLaunch(t *testing.T) {
+	tests := []struct {
+		speed float64
+		want  LaunchOutcome
+	}{
+		{3, LaunchFallsBack},
+		{7.9, LaunchOrbits},
+		{10, LaunchOrbits},
+		{11.2, LaunchEscapes},
+		{20, LaunchEscapes},
+	}
+	for _, tt := range tests {
+		if got := ClassifyLaunch(tt.speed, 7.9, 11.2); got != tt.want {
+			t.Errorf("ClassifyLaunch(%v) = %v, want %v", tt.speed, got, tt.want)
+		}
+	}
+}
+
+func TestSimulateLaunch(t *testing.T) {
+	const circular, radius = 7.9, 6371.0
+
+	fall := SimulateLaunch(5, circular, radius)
+	last := fall.Path[len(fall.Path)-1]
+	if fall.Outcome != LaunchFallsBack || math.Abs(math.Hypot(last.X, last.Y)-1) > 1e-9 {
+		t.Errorf("slow launch = %v ending at %+v, want it to land on the surface", fall.Outcome, last)
+	}
+
+	orbit := SimulateLaunch(circular, circular, radius)
+	last = orbit.Path[len(orbit.Path)-1]
+	if orbit.Outcome != LaunchOrbits || math.Abs(math.Hypot(last.X, last.Y)-1) > 0.01 {
+		t.Errorf("circular launch = %v ending at %+v, want one full lap at the surface", orbit.Outcome, last)
+	}
+	// A low Earth orbit at the surface takes about 84 minutes
+	if minutes := orbit.Duration / 60; math.Abs(minutes-84.4) > 1 {
+		t.Errorf("circular orbit took %.1f minutes, want about 84", minutes)
+	}
+	if math.Abs(orbit.Period-orbit.Duration) > 60 {
+		t.Errorf("circular orbit period %.0f s differs from the simulated lap of %.0f s", orbit.Period, orbit.Duration)
+	}
+
+	escape := SimulateLaunch(12, circular, radius)
+	last = escape.Path[len(escape.Path)-1]
+	if escape.Outcome != LaunchEscapes || math.Hypot(last.X, last.Y) <= launchEscapeRadius {
+		t.Errorf("fast launch = %v ending at %+v, want it to leave the frame", escape.Outcome, last)
+	}
+}
@@ -0,0 +1,99 @@
+package orbital
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+const (
+	// ResonanceTolerance is how far, as a fraction, a period ratio may stray from a
+	// whole-number one and still count as a resonance
+	ResonanceTolerance = 0.015
+
+	// maxResonanceOrder is the largest difference between the two orbit counts.
+	// Higher orders are too weak to shape an orbit, and with enough of them any
+	// ratio lands near one.
+	maxResonanceOrder = 3
+
+	// maxResonanceOrbits is the most orbits the outer body may make in the cycle
+	maxResonanceOrbits = 8
+)
+
+// Resonance is a pair of neighbouring orbits whose periods are close to a ratio of
+// small whole numbers: the inner body goes round InnerOrbits times while the
+// outer goes round OuterOrbits times
+type Resonance struct {
+	Inner       models.CelestialBody
+	Outer       models.CelestialBody
+	InnerOrbits int
+	OuterOrbits int
+	Offset      float64 // (actual ratio - whole-number ratio) / whole-number ratio
+}
+
+// Label writes the resonance as the ratio of the periods, inner to outer, so
+// Neptune and Pluto are 2:3
+func (r Resonance) Label() string {
+	return fmt.Sprintf("%d:%d", r.OuterOrbits, r.InnerOrbits)
+}
+
+// FindResonances looks for resonances between each body and the next one out, by
+// period. Stars and bodies without a period are left out. Of the ratios within
+// ResonanceTolerance the one with the fewest orbits wins.
+func FindResonances(bodies []models.CelestialBody) []Resonance {
+	var orbiting []models.CelestialBody
+	for _, body := range bodies {
+		if body.BodyType != "Star" && body.SideralOrbit > 0 {
+			orbiting = append(orbiting, body)
+		}
+	}
+	sort.SliceStable(orbiting, func(i, j int) bool {
+		return orbiting[i].SideralOrbit < orbiting[j].SideralOrbit
+	})
+
+	var resonances []Resonance
+	for i := 1; i < len(orbiting); i++ {
+		inner, outer := orbiting[i-1], orbiting[i]
+		if resonance, ok := resonanceBetween(inner, outer); ok {
+			resonances = append(resonances, resonance)
+		}
+	}
+	return resonances
+}
+
+// resonanceBetween finds the simplest whole-number ratio near the periods of two
+// bodies, the inner one having the shorter period
+func resonanceBetween(inner, outer models.CelestialBody) (Resonance, bool) {
+	ratio := outer.SideralOrbit / inner.SideralOrbit
+	best := Resonance{}
+	found := false
+	for outerOrbits := 1; outerOrbits <= maxResonanceOrbits; outerOrbits++ {
+		for innerOrbits := outerOrbits + 1; innerOrbits <= outerOrbits+maxResonanceOrder; innerOrbits++ {
+			if gcd(innerOrbits, outerOrbits) != 1 {
+				continue
+			}
+			ideal := float64(innerOrbits) / float64(outerOrbits)
+			offset := ratio/ideal - 1
+			if math.Abs(offset) > ResonanceTolerance {
+				continue
+			}
+			simpler := innerOrbits+outerOrbits < best.InnerOrbits+best.OuterOrbits
+			closer := innerOrbits+outerOrbits == best.InnerOrbits+best.OuterOrbits && math.Abs(offset) < math.Abs(best.Offset)
+			if !found || simpler || closer {
+				best = Resonance{Inner: inner, Outer: outer, InnerOrbits: innerOrbits, OuterOrbits: outerOrbits, Offset: offset}
+				found = true
+			}
+		}
+	}
+	return best, found
+}
+
+// gcd returns the greatest common divisor of two positive numbers
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,79 @@
+package orbital
+
+import (
+	"math"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestFindResonancesTrappistChain(t *testing.T) {
+	bodies := []models.CelestialBody{
+		{EnglishName: "TRAPPIST-1", BodyType: "Star"},
+		{EnglishName: "h", SideralOrbit: 18.766},
+		{EnglishName: "b", SideralOrbit: 1.51087},
+		{EnglishName: "c", SideralOrbit: 2.4218},
+		{EnglishName: "d", SideralOrbit: 4.04961},
+		{EnglishName: "e", SideralOrbit: 6.099615},
+		{EnglishName: "f", SideralOrbit: 9.20669},
+		{EnglishName: "g", SideralOrbit: 12.35294},
+	}
+
+	resonances := FindResonances(bodies)
+	want := []struct{ inner, outer, label string }{
+		{"b", "c", "5:8"},
+		{"c", "d", "3:5"},
+		{"d", "e", "2:3"},
+		{"e", "f", "2:3"},
+		{"f", "g", "3:4"},
+		{"g", "h", "2:3"},
+	}
+	if len(resonances) != len(want) {
+		t.Fatalf("FindResonances() found %d resonances, want %d: %+v", len(resonances), len(want), resonances)
+	}
+	for i, w := range want {
+		got := resonances[i]
+		if got.Inner.EnglishName != w.inner || got.Outer.EnglishName != w.outer || got.Label() != w.label {
+			t.Errorf("resonance %d = %s-%s %s, want %s-%s %s", i, got.Inner.EnglishName, got.Outer.EnglishName, got.Label(), w.inner, w.outer, w.label)
+		}
+		if math.Abs(got.Offset) > ResonanceTolerance {
+			t.Errorf("resonance %d Offset = %v, beyond the tolerance", i, got.Offset)
+		}
+	}
+}
+
+func TestFindResonancesSolarSystem(t *testing.T) {
+	bodies := []models.CelestialBody{
+		{EnglishName: "Mercury", SideralOrbit: 87.969},
+		{EnglishName: "Venus", SideralOrbit: 224.701},
+		{EnglishName: "Earth", SideralOrbit: 365.256},
+		{EnglishName: "Mars", SideralOrbit: 686.98},
+		{EnglishName: "Jupiter", SideralOrbit: 4332.589},
+		{EnglishName: "Saturn", SideralOrbit: 10759.22},
+		{EnglishName: "Uranus", SideralOrbit: 30685.4},
+		{EnglishName: "Neptune", SideralOrbit: 60189},
+		{EnglishName: "Pluto", SideralOrbit: 90560},
+	}
+
+	resonances := FindResonances(bodies)
+	if len(resonances) != 2 {
+		t.Fatalf("FindResonances() = %+v, want Jupiter-Saturn and Neptune-Pluto", resonances)
+	}
+	if got := resonances[0]; got.Inner.EnglishName != "Jupiter" || got.Label() != "2:5" {
+		t.Errorf("first resonance = %s %s, want Jupiter 2:5", got.Inner.EnglishName, got.Label())
+	}
+	if got := resonances[1]; got.Outer.EnglishName != "Pluto" || got.Label() != "2:3" {
+		t.Errorf("second resonance = %s %s, want Pluto 2:3", got.Outer.EnglishName, got.Label())
+	}
+}
+
+func TestFindResonancesNeedsPeriods(t *testing.T) {
+	bodies := []models.CelestialBody{
+		{EnglishName: "a", SideralOrbit: 10},
+		{EnglishName: "unknown"},
+		{EnglishName: "b", SideralOrbit: 20.5}, // 2.5% off 1:2
+	}
+	if resonances := FindResonances(bodies); len(resonances) != 0 {
+		t.Errorf("FindResonances() = %+v, want none", resonances)
+	}
+}
@@ -0,0 +1,86 @@
+package orbital
+
+import (
+	"math"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// Season is a quarter of a planet's year, as one of its hemispheres sees it
+type Season int
+
+const (
+	Spring Season = iota
+	Summer
+	Autumn
+	Winter
+)
+
+// String returns the season's name in lower case
+func (s Season) String() string {
+	switch s {
+	case Summer:
+		return "summer"
+	case Autumn:
+		return "autumn"
+	case Winter:
+		return "winter"
+	default:
+		return "spring"
+	}
+}
+
+// Opposite returns the season the other hemisphere has at the same time
+func (s Season) Opposite() Season {
+	return (s + 2) % 4
+}
+
+// springEquinoxLongitudes are the heliocentric ecliptic longitudes in degrees at
+// which a planet reaches its northern spring equinox. For Earth the Sun then sits
+// at the vernal point, so Earth is on the far side of it; Mars's comes from its
+// perihelion falling at Ls 251°.
+var springEquinoxLongitudes = map[string]float64{
+	"Earth": 180,
+	"Mars":  85.061,
+}
+
+// SolarLongitude returns Ls, how far a planet has gone round its orbit since its
+// northern spring equinox, in degrees from 0 up to 360: 90 is the northern summer
+// solstice, 180 the autumn equinox and 270 the winter solstice. ok is false for
+// bodies whose equinox is not known.
+func (e *Ephemeris) SolarLongitude(body models.CelestialBody, t time.Time) (float64, bool) {
+	equinox, known := springEquinoxLongitudes[body.EnglishName]
+	if !known {
+		return 0, false
+	}
+	longitude, ok := e.EclipticLongitude(body, t)
+	if !ok {
+		return 0, false
+	}
+
+	ls := math.Mod(longitude-equinox, 360)
+	if ls < 0 {
+		ls += 360
+	}
+	return ls, true
+}
+
+// SeasonAt returns the northern hemisphere's season at a solar longitude Ls; the
+// southern one has its Opposite
+func SeasonAt(solarLongitude float64) Season {
+	quarter := int(math.Floor(math.Mod(solarLongitude, 360) / 90))
+	if quarter < 0 {
+		quarter += 4
+	}
+	return Season(quarter)
+}
+
+// SubsolarLatitude returns the latitude in degrees, north positive, where the Sun
+// is overhead on a planet with this axial tilt at solar longitude Ls. Seasons are
+// stronger the further it swings from the equator.
+func SubsolarLatitude(axialTilt, solarLongitude float64) float64 {
+	tilt := axialTilt * math.Pi / 180
+	ls := solarLongitude * math.Pi / 180
+	return math.Asin(math.Sin(tilt)*math.Sin(ls)) * 180 / math.Pi
+}
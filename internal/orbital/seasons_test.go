@@ -0,0 +1,86 @@
+package orbital
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestSolarLongitude(t *testing.T) {
+	earth := models.CelestialBody{EnglishName: "Earth", SideralOrbit: 365.256, Eccentricity: 0.0167}
+	mars := models.CelestialBody{EnglishName: "Mars", SideralOrbit: 686.98, Eccentricity: 0.0934}
+	ephemeris := NewEphemeris(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		body models.CelestialBody
+		at   time.Time
+		want float64
+	}{
+		{"Earth March equinox", earth, time.Date(2025, 3, 20, 9, 1, 0, 0, time.UTC), 0},
+		{"Earth June solstice", earth, time.Date(2025, 6, 21, 2, 42, 0, 0, time.UTC), 90},
+		{"Earth December solstice", earth, time.Date(2025, 12, 21, 15, 3, 0, 0, time.UTC), 270},
+		// Mars years 37 and 38 began at these northern spring equinoxes
+		{"Mars year 37", mars, time.Date(2022, 12, 26, 0, 0, 0, 0, time.UTC), 0},
+		{"Mars year 38", mars, time.Date(2024, 11, 12, 0, 0, 0, 0, time.UTC), 0},
+	}
+
+	for _, tt := range tests {
+		got, ok := ephemeris.SolarLongitude(tt.body, tt.at)
+		if !ok {
+			t.Fatalf("%s: SolarLongitude() not known", tt.name)
+		}
+		diff := math.Abs(math.Mod(got-tt.want+540, 360) - 180)
+		if diff > 2 {
+			t.Errorf("%s: SolarLongitude() = %.2f°, want %.0f° ± 2°", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSolarLongitudeUnknownEquinox(t *testing.T) {
+	jupiter := models.CelestialBody{EnglishName: "Jupiter", SideralOrbit: 4332.59}
+	if _, ok := NewEphemeris(time.Now()).SolarLongitude(jupiter, time.Now()); ok {
+		t.Error("SolarLongitude() known for a planet without a listed equinox")
+	}
+}
+
+func TestSeasonAt(t *testing.T) {
+	tests := []struct {
+		ls       float64
+		northern Season
+		southern Season
+	}{
+		{0, Spring, Autumn},
+		{45, Spring, Autumn},
+		{90, Summer, Winter},
+		{200, Autumn, Spring},
+		{359.9, Winter, Summer},
+		{-10, Winter, Summer},
+	}
+
+	for _, tt := range tests {
+		got := SeasonAt(tt.ls)
+		if got != tt.northern || got.Opposite() != tt.southern {
+			t.Errorf("SeasonAt(%.1f) = %s/%s, want %s/%s", tt.ls, got, got.Opposite(), tt.northern, tt.southern)
+		}
+	}
+}
+
+func TestSubsolarLatitude(t *testing.T) {
+	tests := []struct {
+		tilt, ls, want float64
+	}{
+		{23.44, 0, 0},
+		{23.44, 90, 23.44},
+		{23.44, 270, -23.44},
+		{25.19, 180, 0},
+	}
+
+	for _, tt := range tests {
+		if got := SubsolarLatitude(tt.tilt, tt.ls); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("SubsolarLatitude(%.2f, %.0f) = %.4f, want %.4f", tt.tilt, tt.ls, got, tt.want)
+		}
+	}
+}
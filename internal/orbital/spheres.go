@@ -0,0 +1,60 @@
+package orbital
+
+import (
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// RocheMoonDensity is the density in g/cm³ of the moon Roche limits are given for:
+// an icy one, held together by its own gravity alone
+const RocheMoonDensity = 1.0
+
+// HillRadius returns the radius in km of a body's Hill sphere, inside which its
+// own gravity holds on to satellites against its primary's: a(1-e)∛(m/3M). mu is
+// the primary's gravitational parameter in km³/s² and mass the body's in kg.
+func HillRadius(mu, mass, semimajorAxis, eccentricity float64) float64 {
+	if mu <= 0 || mass <= 0 || semimajorAxis <= 0 {
+		return 0
+	}
+	return semimajorAxis * (1 - eccentricity) * math.Cbrt(GravitationalParameter(mass)/(3*mu))
+}
+
+// BodyHillRadius returns a body's Hill radius in km, taking its primary's mass
+// from the body's own orbit. ok is false without a mass and an orbit.
+func BodyHillRadius(body models.CelestialBody) (radius float64, ok bool) {
+	mu, ok := OrbitParameter(body)
+	if !ok || body.GetMassKg() <= 0 {
+		return 0, false
+	}
+	return HillRadius(mu, body.GetMassKg(), body.SemimajorAxis, OrbitEccentricity(body)), true
+}
+
+// RocheLimit returns how close in km a fluid moon of satelliteDensity can orbit a
+// body of radius km and density before its tides pull the moon apart:
+// 2.44R∛(ρ/ρm). Densities are in g/cm³.
+func RocheLimit(radius, density, satelliteDensity float64) float64 {
+	if radius <= 0 || density <= 0 || satelliteDensity <= 0 {
+		return 0
+	}
+	return 2.44 * radius * math.Cbrt(density/satelliteDensity)
+}
+
+// BodyRocheLimit returns a body's Roche limit in km for an icy moon, from its
+// recorded density or its mass and radius. ok is false when neither is known.
+func BodyRocheLimit(body models.CelestialBody) (limit float64, ok bool) {
+	if body.MeanRadius <= 0 {
+		return 0, false
+	}
+	density := body.Density
+	if density <= 0 {
+		mass := body.GetMassKg()
+		if mass <= 0 {
+			return 0, false
+		}
+		// kg per km³ to g per cm³
+		volume := 4.0 / 3.0 * math.Pi * body.MeanRadius * body.MeanRadius * body.MeanRadius
+		density = mass / volume * 1e-12
+	}
+	return RocheLimit(body.MeanRadius, density, RocheMoonDensity), true
+}
@@ -0,0 +1,46 @@
+package orbital
+
+import (
+	"math"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestHillRadiusOfEarthAndJupiter(t *testing.T) {
+	tests := []struct {
+		body models.CelestialBody
+		want float64 // km
+	}{
+		{models.CelestialBody{EnglishName: "Earth", SemimajorAxis: 149598023, SideralOrbit: 365.256, Eccentricity: 0.0167, Mass: models.Mass{MassValue: 5.97237, MassExponent: 24}}, 1.47e6},
+		{models.CelestialBody{EnglishName: "Jupiter", SemimajorAxis: 778340821, SideralOrbit: 4332.59, Eccentricity: 0.0489, Mass: models.Mass{MassValue: 1.8982, MassExponent: 27}}, 5.06e7},
+	}
+	for _, tt := range tests {
+		got, ok := BodyHillRadius(tt.body)
+		if !ok || math.Abs(got/tt.want-1) > 0.02 {
+			t.Errorf("BodyHillRadius(%s) = %.4e km, %v; want about %.3e", tt.body.EnglishName, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := BodyHillRadius(models.CelestialBody{SemimajorAxis: 1e8, SideralOrbit: 100}); ok {
+		t.Error("Expected no Hill radius without a mass")
+	}
+}
+
+func TestRocheLimit(t *testing.T) {
+	// Earth's fluid Roche limit for a moon as dense as ours is about 18,400 km
+	if got := RocheLimit(6371, 5.514, 3.344); math.Abs(got-18381) > 200 {
+		t.Errorf("RocheLimit(Earth, Moon) = %.0f km, want about 18,381", got)
+	}
+
+	// Saturn's, for ice, falls just inside the outer edge of its main rings
+	saturn := models.CelestialBody{MeanRadius: 58232, Mass: models.Mass{MassValue: 5.68319, MassExponent: 26}}
+	got, ok := BodyRocheLimit(saturn)
+	if !ok || got < 120000 || got > 140000 {
+		t.Errorf("BodyRocheLimit(Saturn) = %.0f km, %v; want 120,000-140,000", got, ok)
+	}
+
+	if _, ok := BodyRocheLimit(models.CelestialBody{MeanRadius: 1000}); ok {
+		t.Error("Expected no Roche limit without a density or mass")
+	}
+}
@@ -0,0 +1,204 @@
+package orbital
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+const (
+	// GravitationalConstant in km³ kg⁻¹ s⁻²
+	GravitationalConstant = 6.674e-20
+
+	// SolarMass in kg, assumed for a system whose star has no mass recorded
+	SolarMass = 1.989e30
+
+	// launchWindowSamples controls the launch window scan resolution relative to
+	// the faster of the two orbits
+	launchWindowSamples = 72
+)
+
+// Transfer is a Hohmann transfer between two circular, coplanar orbits
+type Transfer struct {
+	DepartureDeltaV float64 // km/s, burn leaving the origin's orbit
+	ArrivalDeltaV   float64 // km/s, burn matching the destination's orbit
+	TravelTime      time.Duration
+
+	// PhaseAngle is how far the destination must lead the origin at departure,
+	// in radians within [0, 2π)
+	PhaseAngle float64
+}
+
+// TotalDeltaV returns the sum of both burns in km/s
+func (t Transfer) TotalDeltaV() float64 {
+	return t.DepartureDeltaV + t.ArrivalDeltaV
+}
+
+// GravitationalParameter returns μ = GM in km³/s² for a mass in kg
+func GravitationalParameter(mass float64) float64 {
+	return GravitationalConstant * mass
+}
+
+// HohmannTransfer works out the transfer between circular orbits of radius r1 and
+// r2 km around a central body with gravitational parameter mu
+func HohmannTransfer(mu, r1, r2 float64) (Transfer, error) {
+	if mu <= 0 {
+		return Transfer{}, errors.New("central body has no mass")
+	}
+	if r1 <= 0 || r2 <= 0 {
+		return Transfer{}, errors.New("orbit radius must be positive")
+	}
+	if r1 == r2 {
+		return Transfer{}, errors.New("origin and destination share an orbit")
+	}
+
+	transferAxis := (r1 + r2) / 2
+	departure := math.Sqrt(mu/r1) * (math.Sqrt(r2/transferAxis) - 1)
+	arrival := math.Sqrt(mu/r2) * (1 - math.Sqrt(r1/transferAxis))
+
+	seconds := math.Pi * math.Sqrt(transferAxis*transferAxis*transferAxis/mu)
+
+	// The destination moves through this angle while the craft flies half an orbit
+	destinationMotion := math.Sqrt(mu/(r2*r2*r2)) * seconds
+	phase := math.Mod(math.Pi-destinationMotion, 2*math.Pi)
+	if phase < 0 {
+		phase += 2 * math.Pi
+	}
+
+	return Transfer{
+		DepartureDeltaV: math.Abs(departure),
+		ArrivalDeltaV:   math.Abs(arrival),
+		TravelTime:      time.Duration(seconds * float64(time.Second)),
+		PhaseAngle:      phase,
+	}, nil
+}
+
+// TransferPoint returns where a craft on the Hohmann transfer from radius r1 to r2
+// is once a fraction of its flight time has passed: its distance from the central
+// body, and the angle it has swept round it since leaving, from 0 to π
+func TransferPoint(r1, r2, fraction float64) (distance, swept float64) {
+	fraction = math.Max(0, math.Min(fraction, 1))
+	transferAxis := (r1 + r2) / 2
+	eccentricity := math.Abs(r2-r1) / (r1 + r2)
+
+	// Going out the craft leaves from periapsis, coming in from apoapsis
+	start := 0.0
+	if r2 < r1 {
+		start = math.Pi
+	}
+	if fraction == 1 {
+		return r2, math.Pi
+	}
+
+	trueAnomaly := TrueAnomaly(start+math.Pi*fraction, eccentricity)
+	swept = math.Mod(trueAnomaly-start+2*math.Pi, 2*math.Pi)
+	return OrbitalRadius(transferAxis, eccentricity, trueAnomaly), swept
+}
+
+// EscapeBurn returns the burn in km/s needed to leave a low circular orbit around a
+// body of the given mass (kg) and radius (km) with excess hyperbolic speed vInf km/s.
+// The parking orbit is taken to sit at the surface, which is close enough for a
+// planning estimate.
+func EscapeBurn(mass, radius, vInf float64) float64 {
+	if mass <= 0 || radius <= 0 {
+		return vInf
+	}
+	mu := GravitationalParameter(mass)
+	escape := math.Sqrt(2 * mu / radius)
+	circular := math.Sqrt(mu / radius)
+	return math.Sqrt(vInf*vInf+escape*escape) - circular
+}
+
+// SynodicPeriod returns how long it takes two orbits with the given periods to
+// return to the same relative position, or 0 if they never drift apart
+func SynodicPeriod(period1, period2 time.Duration) time.Duration {
+	if period1 <= 0 || period2 <= 0 {
+		return 0
+	}
+	rate := math.Abs(1/period1.Seconds() - 1/period2.Seconds())
+	if rate == 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Second) / rate)
+}
+
+// PhaseAngle returns how far destination leads origin in heliocentric longitude at
+// time t, in radians within [0, 2π)
+func (e *Ephemeris) PhaseAngle(origin, destination models.CelestialBody, t time.Time) float64 {
+	angle := math.Mod(e.Longitude(destination, t)-e.Longitude(origin, t), 2*math.Pi)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+	return angle
+}
+
+// NextLaunchWindow returns the first time after from when the destination leads
+// the origin by the transfer's phase angle. It looks at most one synodic period
+// ahead and reports false if the bodies never line up.
+func (e *Ephemeris) NextLaunchWindow(origin, destination models.CelestialBody, transfer Transfer, from time.Time) (time.Time, bool) {
+	originPeriod := orbitalPeriod(origin)
+	destinationPeriod := orbitalPeriod(destination)
+	synodic := SynodicPeriod(originPeriod, destinationPeriod)
+	if synodic == 0 {
+		return time.Time{}, false
+	}
+
+	offset := func(t time.Time) float64 {
+		return wrapAngle(e.PhaseAngle(origin, destination, t) - transfer.PhaseAngle)
+	}
+
+	step := min(originPeriod, destinationPeriod) / launchWindowSamples
+	if step < time.Minute {
+		step = time.Minute
+	}
+	until := from.Add(synodic + synodic/20)
+
+	prevTime, prevValue := from, offset(from)
+	for prevTime.Before(until) {
+		t := prevTime.Add(step)
+		value := offset(t)
+
+		// A sign change without a wrap-around jump means the offset passed zero
+		if (prevValue < 0) != (value < 0) && math.Abs(value-prevValue) < math.Pi {
+			return bisectZero(offset, prevTime, t), true
+		}
+		prevTime, prevValue = t, value
+	}
+
+	return time.Time{}, false
+}
+
+// orbitalPeriod returns the body's sidereal period, or 0 if it is unknown
+func orbitalPeriod(body models.CelestialBody) time.Duration {
+	if body.SideralOrbit <= 0 {
+		return 0
+	}
+	return time.Duration(body.SideralOrbit * 24 * float64(time.Hour))
+}
+
+// bisectZero narrows a zero crossing between lo and hi down to about a minute
+func bisectZero(f func(time.Time) float64, lo, hi time.Time) time.Time {
+	loNegative := f(lo) < 0
+	for hi.Sub(lo) > time.Minute {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if (f(mid) < 0) == loNegative {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+// wrapAngle maps an angle into (-π, π]
+func wrapAngle(angle float64) float64 {
+	angle = math.Mod(angle, 2*math.Pi)
+	if angle > math.Pi {
+		angle -= 2 * math.Pi
+	} else if angle <= -math.Pi {
+		angle += 2 * math.Pi
+	}
+	return angle
+}
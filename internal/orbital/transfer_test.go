@@ -0,0 +1,134 @@
+package orbital
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+const (
+	earthOrbit = 149598023.0 // km
+	marsOrbit  = 227939200.0 // km
+)
+
+func TestHohmannTransferEarthToMars(t *testing.T) {
+	transfer, err := HohmannTransfer(GravitationalParameter(SolarMass), earthOrbit, marsOrbit)
+	if err != nil {
+		t.Fatalf("HohmannTransfer() error = %v", err)
+	}
+
+	// Textbook values: 2.94 + 2.65 km/s, about 259 days, Mars 44° ahead
+	if math.Abs(transfer.DepartureDeltaV-2.94) > 0.02 {
+		t.Errorf("DepartureDeltaV = %.3f, want about 2.94", transfer.DepartureDeltaV)
+	}
+	if math.Abs(transfer.ArrivalDeltaV-2.65) > 0.02 {
+		t.Errorf("ArrivalDeltaV = %.3f, want about 2.65", transfer.ArrivalDeltaV)
+	}
+	if days := transfer.TravelTime.Hours() / 24; math.Abs(days-259) > 2 {
+		t.Errorf("TravelTime = %.1f days, want about 259", days)
+	}
+	if degrees := transfer.PhaseAngle * 180 / math.Pi; math.Abs(degrees-44) > 1 {
+		t.Errorf("PhaseAngle = %.1f°, want about 44°", degrees)
+	}
+}
+
+func TestHohmannTransferIsSymmetric(t *testing.T) {
+	mu := GravitationalParameter(SolarMass)
+	out, _ := HohmannTransfer(mu, earthOrbit, marsOrbit)
+	back, _ := HohmannTransfer(mu, marsOrbit, earthOrbit)
+
+	if math.Abs(out.TotalDeltaV()-back.TotalDeltaV()) > 1e-9 {
+		t.Errorf("total Δv out %.4f != back %.4f", out.TotalDeltaV(), back.TotalDeltaV())
+	}
+	if out.TravelTime != back.TravelTime {
+		t.Errorf("travel time out %v != back %v", out.TravelTime, back.TravelTime)
+	}
+}
+
+func TestHohmannTransferErrors(t *testing.T) {
+	mu := GravitationalParameter(SolarMass)
+	cases := map[string][3]float64{
+		"no mass":     {0, earthOrbit, marsOrbit},
+		"no radius":   {mu, 0, marsOrbit},
+		"same orbit":  {mu, earthOrbit, earthOrbit},
+		"negative r2": {mu, earthOrbit, -1},
+	}
+	for name, c := range cases {
+		if _, err := HohmannTransfer(c[0], c[1], c[2]); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestEscapeBurnFromLowEarthOrbit(t *testing.T) {
+	// Leaving LEO towards Mars takes roughly 3.6 km/s
+	burn := EscapeBurn(5.972e24, 6371, 2.94)
+	if math.Abs(burn-3.6) > 0.15 {
+		t.Errorf("EscapeBurn() = %.2f, want about 3.6", burn)
+	}
+	if got := EscapeBurn(0, 0, 2.5); got != 2.5 {
+		t.Errorf("EscapeBurn() without a body = %v, want the excess speed", got)
+	}
+}
+
+func TestSynodicPeriod(t *testing.T) {
+	day := 24 * time.Hour
+	synodic := SynodicPeriod(365*day, 687*day)
+	if days := synodic.Hours() / 24; math.Abs(days-780) > 2 {
+		t.Errorf("SynodicPeriod(Earth, Mars) = %.0f days, want about 780", days)
+	}
+	if SynodicPeriod(100*day, 100*day) != 0 {
+		t.Error("matching periods should never line up again")
+	}
+}
+
+func TestNextLaunchWindowMatchesPhaseAngle(t *testing.T) {
+	epoch := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ephemeris := NewEphemeris(epoch)
+	inner := models.CelestialBody{EnglishName: "Inner", SemimajorAxis: earthOrbit, SideralOrbit: 365.25}
+	outer := models.CelestialBody{EnglishName: "Outer", SemimajorAxis: marsOrbit, SideralOrbit: 687}
+
+	transfer, err := HohmannTransfer(GravitationalParameter(SolarMass), inner.SemimajorAxis, outer.SemimajorAxis)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	window, ok := ephemeris.NextLaunchWindow(inner, outer, transfer, epoch)
+	if !ok {
+		t.Fatal("NextLaunchWindow() found no window")
+	}
+	if window.Before(epoch) || window.After(epoch.Add(800*24*time.Hour)) {
+		t.Errorf("window %v is outside one synodic period", window)
+	}
+
+	got := ephemeris.PhaseAngle(inner, outer, window)
+	if math.Abs(wrapAngle(got-transfer.PhaseAngle)) > 0.01 {
+		t.Errorf("phase at window = %.3f, want %.3f", got, transfer.PhaseAngle)
+	}
+
+	if _, ok := ephemeris.NextLaunchWindow(inner, models.CelestialBody{EnglishName: "Lost"}, transfer, epoch); ok {
+		t.Error("a body without a period should have no window")
+	}
+}
+
+func TestTransferPoint(t *testing.T) {
+	for _, c := range []struct{ r1, r2 float64 }{{earthOrbit, marsOrbit}, {marsOrbit, earthOrbit}} {
+		distance, swept := TransferPoint(c.r1, c.r2, 0)
+		if math.Abs(distance-c.r1) > 1 || swept != 0 {
+			t.Errorf("%.0f to %.0f: start at %.0f km after %.3f rad, want %.0f km after 0", c.r1, c.r2, distance, swept, c.r1)
+		}
+		distance, swept = TransferPoint(c.r1, c.r2, 1)
+		if math.Abs(distance-c.r2) > 1 || math.Abs(swept-math.Pi) > 1e-9 {
+			t.Errorf("%.0f to %.0f: end at %.0f km after %.3f rad, want %.0f km after π", c.r1, c.r2, distance, swept, c.r2)
+		}
+
+		// Halfway through the flight the craft has swept more than a quarter turn
+		// going out, where it is fastest early on, and less coming in
+		_, swept = TransferPoint(c.r1, c.r2, 0.5)
+		if (c.r2 > c.r1) != (swept > math.Pi/2) {
+			t.Errorf("%.0f to %.0f: swept %.3f rad halfway", c.r1, c.r2, swept)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package orbital
+
+import (
+	"math"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// Transit is a planet crossing in front of its star as seen from far away, the
+// dip in starlight most exoplanets were found by. The star is taken to be a
+// uniformly bright disc and the orbit a circle.
+type Transit struct {
+	StarRadius    float64 // km
+	PlanetRadius  float64 // km
+	SemimajorAxis float64 // km
+	Period        time.Duration
+
+	// Impact is how far from the star's centre, in km, the planet's path crosses
+	// it: zero for an orbit seen exactly edge-on
+	Impact float64
+}
+
+// NewTransit describes planet crossing star. A recorded inclination (in degrees,
+// 90 being edge-on) tilts the path away from the star's centre; without one the
+// orbit is taken to be seen edge-on. ok is false when the radii, the distance or
+// the period are missing.
+func NewTransit(star, planet models.CelestialBody) (Transit, bool) {
+	if star.MeanRadius <= 0 || planet.MeanRadius <= 0 || planet.SemimajorAxis <= star.MeanRadius || planet.SideralOrbit <= 0 {
+		return Transit{}, false
+	}
+
+	transit := Transit{
+		StarRadius:    star.MeanRadius,
+		PlanetRadius:  planet.MeanRadius,
+		SemimajorAxis: planet.SemimajorAxis,
+		Period:        time.Duration(planet.SideralOrbit * float64(24*time.Hour)),
+	}
+	if planet.Inclination != 0 {
+		transit.Impact = math.Abs(planet.SemimajorAxis * math.Cos(planet.Inclination*math.Pi/180))
+	}
+	return transit, true
+}
+
+// Transits reports whether the planet's path crosses the star's disc at all
+func (tr Transit) Transits() bool {
+	return tr.Impact < tr.StarRadius+tr.PlanetRadius
+}
+
+// Duration is how long the planet overlaps the star, from first to last contact
+func (tr Transit) Duration() time.Duration {
+	if !tr.Transits() {
+		return 0
+	}
+	contact := tr.StarRadius + tr.PlanetRadius
+	sine := math.Sqrt((contact*contact - tr.Impact*tr.Impact) / (tr.SemimajorAxis*tr.SemimajorAxis - tr.Impact*tr.Impact))
+	return time.Duration(math.Asin(math.Min(sine, 1)) / math.Pi * float64(tr.Period))
+}
+
+// Depth is the share of the starlight blocked at mid-transit; (Rp/R★)² for a
+// planet wholly inside the disc
+func (tr Transit) Depth() float64 {
+	return 1 - tr.Flux(0)
+}
+
+// Flux returns the star's brightness, 1 being unobstructed, at a time from the
+// middle of the transit
+func (tr Transit) Flux(fromMiddle time.Duration) float64 {
+	phase := 2 * math.Pi * float64(fromMiddle) / float64(tr.Period)
+	if math.Cos(phase) <= 0 {
+		return 1 // behind the star, or off to the side
+	}
+
+	x := tr.SemimajorAxis * math.Sin(phase)
+	y := tr.Impact * math.Cos(phase)
+	covered := discOverlap(math.Hypot(x, y), tr.StarRadius, tr.PlanetRadius)
+	return 1 - covered/(math.Pi*tr.StarRadius*tr.StarRadius)
+}
+
+// Offset returns where the planet is across the star's face at a time from the
+// middle of the transit, in star radii from its centre, negative before the middle
+func (tr Transit) Offset(fromMiddle time.Duration) float64 {
+	phase := 2 * math.Pi * float64(fromMiddle) / float64(tr.Period)
+	return tr.SemimajorAxis * math.Sin(phase) / tr.StarRadius
+}
+
+// discOverlap is the area two discs of radii r1 and r2 share when their centres
+// are d apart
+func discOverlap(d, r1, r2 float64) float64 {
+	switch {
+	case d >= r1+r2:
+		return 0
+	case d <= math.Abs(r1-r2):
+		r := math.Min(r1, r2)
+		return math.Pi * r * r
+	}
+
+	a1 := r1 * r1 * math.Acos((d*d+r1*r1-r2*r2)/(2*d*r1))
+	a2 := r2 * r2 * math.Acos((d*d+r2*r2-r1*r1)/(2*d*r2))
+	kite := 0.5 * math.Sqrt((-d+r1+r2)*(d+r1-r2)*(d-r1+r2)*(d+r1+r2))
+	return a1 + a2 - kite
+}
+
+// UntilTransit returns how long after t the planet next passes in front of its
+// star for an observer below the map, that is at a longitude of 90°, assuming it
+// moves around its orbit at a steady rate
+func (e *Ephemeris) UntilTransit(planet models.CelestialBody, t time.Time) time.Duration {
+	if planet.SideralOrbit <= 0 {
+		return 0
+	}
+	remaining := math.Mod(math.Pi/2-e.Longitude(planet, t), 2*math.Pi)
+	if remaining < 0 {
+		remaining += 2 * math.Pi
+	}
+	return time.Duration(remaining / (2 * math.Pi) * planet.SideralOrbit * float64(24*time.Hour))
+}
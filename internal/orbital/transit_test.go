@@ -0,0 +1,101 @@
+package orbital
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// hotJupiter is a Jupiter-sized planet on a 3-day orbit round a Sun-like star
+func hotJupiter() (models.CelestialBody, models.CelestialBody) {
+	star := models.CelestialBody{EnglishName: "Star", MeanRadius: 695700}
+	planet := models.CelestialBody{EnglishName: "Planet", MeanRadius: 69911, SemimajorAxis: 0.04 * 149597870.7, SideralOrbit: 3}
+	return star, planet
+}
+
+func TestTransitDepthAndDuration(t *testing.T) {
+	star, planet := hotJupiter()
+	transit, ok := NewTransit(star, planet)
+	if !ok {
+		t.Fatal("NewTransit() not ok")
+	}
+
+	want := math.Pow(69911.0/695700, 2)
+	if math.Abs(transit.Depth()-want) > 1e-9 {
+		t.Errorf("Depth() = %v, want (Rp/R★)² = %v", transit.Depth(), want)
+	}
+
+	// P/π · asin((R★+Rp)/a) for an edge-on orbit: a little under 3 hours
+	duration := transit.Duration()
+	if duration < 2*time.Hour+50*time.Minute || duration > 3*time.Hour+10*time.Minute {
+		t.Errorf("Duration() = %v, want about 3h", duration)
+	}
+
+	if got := transit.Flux(duration/2 + time.Minute); got != 1 {
+		t.Errorf("Flux() after last contact = %v, want 1", got)
+	}
+	if got := transit.Flux(duration / 4); got >= 1 || got <= 1-want-1e-9 {
+		t.Errorf("Flux() partway through = %v, want dimmed by at most the depth", got)
+	}
+	if got := transit.Flux(transit.Period / 2); got != 1 {
+		t.Errorf("Flux() behind the star = %v, want 1", got)
+	}
+	if transit.Offset(-duration/4) >= 0 || transit.Offset(duration/4) <= 0 {
+		t.Error("Offset() should be negative before mid-transit and positive after")
+	}
+}
+
+func TestTransitInclination(t *testing.T) {
+	star, planet := hotJupiter()
+
+	planet.Inclination = 89
+	grazing, _ := NewTransit(star, planet)
+	edgeOn, _ := NewTransit(star, models.CelestialBody{MeanRadius: planet.MeanRadius, SemimajorAxis: planet.SemimajorAxis, SideralOrbit: planet.SideralOrbit})
+	if !grazing.Transits() || grazing.Duration() >= edgeOn.Duration() {
+		t.Errorf("a tilted orbit should cross a shorter chord: %v vs %v", grazing.Duration(), edgeOn.Duration())
+	}
+
+	planet.Inclination = 80
+	missed, _ := NewTransit(star, planet)
+	if missed.Transits() || missed.Duration() != 0 || missed.Depth() != 0 {
+		t.Errorf("an orbit tilted 10° should miss the star: %+v", missed)
+	}
+}
+
+func TestNewTransitNeedsSizes(t *testing.T) {
+	star, planet := hotJupiter()
+	planet.MeanRadius = 0
+	if _, ok := NewTransit(star, planet); ok {
+		t.Error("NewTransit() without the planet's radius should not be ok")
+	}
+}
+
+func TestDiscOverlap(t *testing.T) {
+	if got := discOverlap(0, 2, 1); math.Abs(got-math.Pi) > 1e-9 {
+		t.Errorf("inside = %v, want π", got)
+	}
+	if got := discOverlap(3, 2, 1); got != 0 {
+		t.Errorf("touching = %v, want 0", got)
+	}
+	// Two unit discs a radius apart share 2π/3 - √3/2
+	if got, want := discOverlap(1, 1, 1), 2*math.Pi/3-math.Sqrt(3)/2; math.Abs(got-want) > 1e-9 {
+		t.Errorf("half over = %v, want %v", got, want)
+	}
+}
+
+func TestUntilTransit(t *testing.T) {
+	epoch := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	ephemeris := NewEphemeris(epoch)
+	_, planet := hotJupiter()
+
+	wait := ephemeris.UntilTransit(planet, epoch)
+	if wait < 0 || wait >= 3*24*time.Hour {
+		t.Fatalf("UntilTransit() = %v, want within one 3-day orbit", wait)
+	}
+	longitude := ephemeris.Longitude(planet, epoch.Add(wait))
+	if diff := math.Abs(math.Remainder(longitude-math.Pi/2, 2*math.Pi)); diff > 1e-3 {
+		t.Errorf("at the next transit the planet is at %v rad, want π/2", longitude)
+	}
+}
@@ -0,0 +1,93 @@
+package orbital
+
+import (
+	"math"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// wobbleStep is the time either side of a moment the star's velocity is measured over
+const wobbleStep = time.Hour
+
+// Wobble is where a star's planets pull it to, relative to the system's barycenter,
+// and how fast they swing it round: the motion the radial-velocity method detects
+type Wobble struct {
+	X, Y   float64 // km from the barycenter, in the orbital plane
+	VX, VY float64 // m/s
+}
+
+// Offset returns how far the star is from the barycenter in km
+func (w Wobble) Offset() float64 {
+	return math.Hypot(w.X, w.Y)
+}
+
+// Speed returns how fast the star moves round the barycenter in m/s
+func (w Wobble) Speed() float64 {
+	return math.Hypot(w.VX, w.VY)
+}
+
+// RadialVelocity returns the star's speed away from an observer far off in the
+// orbital plane in the direction angle (radians, measured like the map's), in m/s.
+// Positive is receding, which redshifts the starlight.
+func (w Wobble) RadialVelocity(angle float64) float64 {
+	return -(w.VX*math.Cos(angle) + w.VY*math.Sin(angle))
+}
+
+// StarWobble returns the wobble of a star of starMass kg at time t. The star and
+// its planets circle their common barycenter, so the star sits opposite the
+// planets' mass-weighted position. Bodies with no known mass or orbit pull nothing.
+func (e *Ephemeris) StarWobble(starMass float64, planets []models.CelestialBody, t time.Time) Wobble {
+	x, y := e.wobblePosition(starMass, planets, t)
+	beforeX, beforeY := e.wobblePosition(starMass, planets, t.Add(-wobbleStep))
+	afterX, afterY := e.wobblePosition(starMass, planets, t.Add(wobbleStep))
+
+	seconds := 2 * wobbleStep.Seconds()
+	return Wobble{
+		X:  x,
+		Y:  y,
+		VX: (afterX - beforeX) * 1000 / seconds,
+		VY: (afterY - beforeY) * 1000 / seconds,
+	}
+}
+
+// MaxWobble returns the furthest in km the planets could pull a star of starMass
+// kg from the barycenter: with them all lined up, each at its aphelion
+func MaxWobble(starMass float64, planets []models.CelestialBody) float64 {
+	total, reach := starMass, 0.0
+	for _, planet := range planets {
+		mass := planet.GetMassKg()
+		if mass <= 0 || planet.SemimajorAxis <= 0 {
+			continue
+		}
+		eccentricity := 0.0
+		if planet.OrbitalElements != nil {
+			eccentricity = ClampEccentricity(planet.OrbitalElements.Eccentricity)
+		}
+		total += mass
+		reach += mass * planet.SemimajorAxis * (1 + eccentricity)
+	}
+	if total <= 0 {
+		return 0
+	}
+	return reach / total
+}
+
+// wobblePosition returns the star's position relative to the barycenter in km
+func (e *Ephemeris) wobblePosition(starMass float64, planets []models.CelestialBody, t time.Time) (float64, float64) {
+	total, sumX, sumY := starMass, 0.0, 0.0
+	for _, planet := range planets {
+		mass := planet.GetMassKg()
+		if mass <= 0 || planet.SemimajorAxis <= 0 {
+			continue
+		}
+		x, y := e.Position(planet, t)
+		total += mass
+		sumX += mass * x
+		sumY += mass * y
+	}
+	if total <= 0 {
+		return 0, 0
+	}
+	return -sumX / total, -sumY / total
+}
@@ -0,0 +1,46 @@
+package orbital
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// Jupiter alone swings the Sun about 742,000 km from the barycenter at 12.5 m/s
+func TestStarWobbleFromJupiter(t *testing.T) {
+	jupiter := models.CelestialBody{
+		EnglishName:   "Jupiter",
+		SemimajorAxis: 778340821,
+		SideralOrbit:  4332.59,
+		Mass:          models.Mass{MassValue: 1.89819, MassExponent: 27},
+	}
+	massless := models.CelestialBody{EnglishName: "Ceres", SemimajorAxis: 413690250, SideralOrbit: 1680}
+	planets := []models.CelestialBody{jupiter, massless}
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	wobble := NewEphemeris(models.J2000).StarWobble(SolarMass, planets, at)
+	if offset := wobble.Offset(); math.Abs(offset-742000) > 5000 {
+		t.Errorf("Offset() = %.0f km, want about 742,000", offset)
+	}
+	if speed := wobble.Speed(); math.Abs(speed-12.5) > 0.3 {
+		t.Errorf("Speed() = %.2f m/s, want about 12.5", speed)
+	}
+
+	// The star is opposite Jupiter and moves at right angles to that line
+	x, y := NewEphemeris(models.J2000).Position(jupiter, at)
+	if x*wobble.X+y*wobble.Y >= 0 {
+		t.Errorf("star at %.0f,%.0f is not opposite Jupiter at %.0f,%.0f", wobble.X, wobble.Y, x, y)
+	}
+	if radial := wobble.RadialVelocity(math.Atan2(wobble.Y, wobble.X)); math.Abs(radial) > 0.1 {
+		t.Errorf("RadialVelocity() along the star's offset = %.2f m/s, want about 0 on a circular orbit", radial)
+	}
+
+	if reach := MaxWobble(SolarMass, planets); math.Abs(reach-wobble.Offset()) > 1 {
+		t.Errorf("MaxWobble() = %.0f km, want the offset %.0f of a circular orbit", reach, wobble.Offset())
+	}
+	if wobble := NewEphemeris(models.J2000).StarWobble(SolarMass, []models.CelestialBody{massless}, at); wobble.Offset() != 0 || wobble.Speed() != 0 {
+		t.Errorf("StarWobble() = %+v from a body with no mass, want none", wobble)
+	}
+}
@@ -0,0 +1,264 @@
+package portrait
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// bodyClass is the broad look a generated portrait takes
+type bodyClass int
+
+const (
+	classRocky bodyClass = iota
+	classMolten
+	classIcy
+	classIceGiant
+	classGasGiant
+	classStar
+)
+
+const (
+	// Disc shape, in cells. Terminal cells are about twice as tall as they are wide.
+	discCenterX = 9.5
+	discCenterY = 3.5
+	discRadiusX = 8.6
+	discRadiusY = 3.7
+
+	gasGiantRadius = 40000 // km; anything bigger gets bands
+	iceGiantRadius = 15000 // km
+	moltenTemp     = 700   // K
+	icyTemp        = 150   // K
+)
+
+// Light comes from the upper left, slightly in front of the body
+var lightX, lightY, lightZ = normalize(-0.5, -0.45, 0.75)
+
+// shadeGlyphs go from lit to dark
+var shadeGlyphs = []rune{'█', '▓', '▒', '░'}
+
+// Generate builds a portrait from the body's class and physical properties. The
+// same body always gets the same picture.
+func Generate(body models.CelestialBody) Portrait {
+	class := classify(body)
+	seed := seedFor(body.EnglishName)
+	base := baseColor(class, body, seed)
+
+	p := Portrait{Title: fmt.Sprintf("%s - %s", body.EnglishName, class)}
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width; x++ {
+			dx := (float64(x) - discCenterX) / discRadiusX
+			dy := (float64(y) - discCenterY) / discRadiusY
+			if dx*dx+dy*dy > 1 {
+				continue
+			}
+
+			dz := math.Sqrt(1 - dx*dx - dy*dy)
+			light := dx*lightX + dy*lightY + dz*lightZ
+			if class == classStar {
+				// Stars glow rather than being lit, so only darken towards the limb
+				light = 0.45 + 0.55*dz
+			}
+
+			color := surfaceColor(class, base, x, y, seed)
+			p.Cells[y][x] = shadeCell(color, light)
+		}
+	}
+	return p
+}
+
+// minMarkerDepth keeps a surface marker off the very edge of the disc, where
+// it would sit on the limb rather than the face
+const minMarkerDepth = 0.2
+
+// EquatorCell returns the cell of a portrait disc that a point on the body's
+// equator falls on, at longitude radians east of the centre of the face, and
+// whether that point is on the side facing the viewer. Turning longitude up moves
+// the point from left to right, the way a prograde body turns seen from above
+// its north pole.
+func EquatorCell(longitude float64) (x, y int, visible bool) {
+	if math.Cos(longitude) < minMarkerDepth {
+		return 0, 0, false
+	}
+	x = int(math.Round(discCenterX + discRadiusX*math.Sin(longitude)))
+	return x, int(math.Floor(discCenterY)), true
+}
+
+func (c bodyClass) String() string {
+	switch c {
+	case classMolten:
+		return "molten rocky world"
+	case classIcy:
+		return "icy world"
+	case classIceGiant:
+		return "ice giant"
+	case classGasGiant:
+		return "gas giant"
+	case classStar:
+		return "star"
+	default:
+		return "rocky world"
+	}
+}
+
+func classify(body models.CelestialBody) bodyClass {
+	switch {
+	case body.BodyType == "Star":
+		return classStar
+	case body.MeanRadius >= gasGiantRadius:
+		return classGasGiant
+	case body.MeanRadius >= iceGiantRadius:
+		return classIceGiant
+	case body.Temperature >= moltenTemp:
+		return classMolten
+	case body.Temperature > 0 && body.Temperature <= icyTemp, body.Density > 0 && body.Density < 2.5:
+		return classIcy
+	default:
+		return classRocky
+	}
+}
+
+// baseColor picks the body's main colour, varied a little by seed so neighbouring
+// exoplanets don't all look identical
+func baseColor(class bodyClass, body models.CelestialBody, seed uint32) rgb {
+	var c rgb
+	switch class {
+	case classStar:
+		return starColor(body)
+	case classGasGiant:
+		c = rgb{200, 160, 110}
+	case classIceGiant:
+		c = rgb{110, 180, 220}
+	case classMolten:
+		c = rgb{200, 70, 30}
+	case classIcy:
+		c = rgb{210, 225, 235}
+	default:
+		c = rgb{150, 120, 95}
+	}
+	return c.vary(seed)
+}
+
+// starColor follows the star's spectral class, falling back to its temperature
+func starColor(body models.CelestialBody) rgb {
+	class := strings.ToUpper(strings.TrimSpace(body.GetSpectralType()))
+	if class == "" && body.Temperature > 0 {
+		switch {
+		case body.Temperature >= 10000:
+			class = "B"
+		case body.Temperature >= 7500:
+			class = "A"
+		case body.Temperature >= 6000:
+			class = "F"
+		case body.Temperature >= 5200:
+			class = "G"
+		case body.Temperature >= 3700:
+			class = "K"
+		default:
+			class = "M"
+		}
+	}
+
+	if class == "" {
+		return rgb{255, 210, 63}
+	}
+	switch class[0] {
+	case 'O', 'B':
+		return rgb{155, 176, 255}
+	case 'A':
+		return rgb{202, 215, 255}
+	case 'F':
+		return rgb{248, 247, 255}
+	case 'K':
+		return rgb{255, 163, 81}
+	case 'M':
+		return rgb{255, 111, 60}
+	default:
+		return rgb{255, 210, 63}
+	}
+}
+
+// surfaceColor adds the class's markings: bands on giants, speckles on rock
+func surfaceColor(class bodyClass, base rgb, x, y int, seed uint32) rgb {
+	switch class {
+	case classGasGiant:
+		bands := []float64{1.1, 0.85, 1.05, 0.7, 1.1, 0.9, 0.75, 1.0}
+		return base.scale(bands[(y+int(seed%3))%len(bands)])
+	case classIceGiant:
+		if y == int(seed%2)+2 {
+			return base.scale(1.12)
+		}
+		return base
+	case classRocky, classMolten, classIcy:
+		if noise(x, y, seed)%7 == 0 {
+			return base.scale(0.75)
+		}
+		if class == classMolten && noise(x, y, seed+1)%9 == 0 {
+			return rgb{255, 170, 40}
+		}
+		return base
+	default:
+		return base
+	}
+}
+
+// shadeCell turns a colour and a light level into a glyph
+func shadeCell(c rgb, light float64) Cell {
+	light = math.Max(light, 0)
+	glyph := shadeGlyphs[len(shadeGlyphs)-1]
+	switch {
+	case light > 0.55:
+		glyph = shadeGlyphs[0]
+	case light > 0.3:
+		glyph = shadeGlyphs[1]
+	case light > 0.1:
+		glyph = shadeGlyphs[2]
+	}
+	return Cell{Glyph: glyph, Color: c.scale(0.35 + 0.65*math.Min(light, 1)).hex()}
+}
+
+func seedFor(name string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return h.Sum32()
+}
+
+// noise is a cheap deterministic hash of a cell position
+func noise(x, y int, seed uint32) uint32 {
+	n := uint32(x)*374761393 + uint32(y)*668265263 + seed*2246822519
+	n = (n ^ (n >> 13)) * 1274126177
+	return n ^ (n >> 16)
+}
+
+func normalize(x, y, z float64) (float64, float64, float64) {
+	length := math.Sqrt(x*x + y*y + z*z)
+	return x / length, y / length, z / length
+}
+
+type rgb struct {
+	r, g, b float64
+}
+
+// vary shifts the colour by up to about 12% per channel
+func (c rgb) vary(seed uint32) rgb {
+	shift := func(v float64, bits uint32) float64 {
+		return v * (0.88 + 0.24*float64(bits&0xff)/255)
+	}
+	return rgb{shift(c.r, seed), shift(c.g, seed>>8), shift(c.b, seed>>16)}.clamp()
+}
+
+func (c rgb) scale(f float64) rgb {
+	return rgb{c.r * f, c.g * f, c.b * f}.clamp()
+}
+
+func (c rgb) clamp() rgb {
+	limit := func(v float64) float64 { return math.Max(0, math.Min(255, v)) }
+	return rgb{limit(c.r), limit(c.g), limit(c.b)}
+}
+
+func (c rgb) hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", int(math.Round(c.r)), int(math.Round(c.g)), int(math.Round(c.b)))
+}
@@ -0,0 +1,181 @@
+// Package portrait provides small coloured pictures of celestial bodies for the
+// details modal. Well-known bodies come from an embedded art pack; anything else
+// gets a portrait generated from its physical properties.
+package portrait
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+const (
+	// Width is the number of terminal columns a portrait covers
+	Width = 20
+
+	// Height is the number of terminal rows a portrait covers
+	Height = 8
+
+	paletteSeparator = "---"
+)
+
+//go:embed art/*.txt
+var artPack embed.FS
+
+// Cell is one character of a portrait. A zero Glyph is transparent.
+type Cell struct {
+	Glyph rune
+	Color string // #rrggbb
+}
+
+// Portrait is a Width x Height picture of a body
+type Portrait struct {
+	Title string
+	Cells [Height][Width]Cell
+}
+
+// At returns the cell at the given column and row
+func (p Portrait) At(x, y int) Cell {
+	if x < 0 || x >= Width || y < 0 || y >= Height {
+		return Cell{}
+	}
+	return p.Cells[y][x]
+}
+
+// For returns the art pack portrait for the body, or a generated one if the pack
+// has no picture of it
+func For(body models.CelestialBody) Portrait {
+	if p, ok := Load(body.EnglishName); ok {
+		return p
+	}
+	return Generate(body)
+}
+
+// Load returns the art pack portrait for the named body
+func Load(name string) (Portrait, bool) {
+	data, err := artPack.ReadFile(path.Join("art", artFileName(name)))
+	if err != nil {
+		return Portrait{}, false
+	}
+
+	p, err := parse(data)
+	if err != nil {
+		return Portrait{}, false
+	}
+	return p, true
+}
+
+// Names lists the bodies covered by the art pack
+func Names() []string {
+	entries, err := artPack.ReadDir("art")
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".txt"))
+	}
+	return names
+}
+
+func artFileName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.TrimPrefix(name, "the ")
+	return strings.ReplaceAll(name, " ", "-") + ".txt"
+}
+
+// parse reads an art file: an optional "# title" line, palette lines of the form
+// "key #rrggbb glyph", a "---" separator, then up to Height rows of palette keys
+// where a space is transparent
+func parse(data []byte) (Portrait, error) {
+	var p Portrait
+	palette := make(map[rune]Cell)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	inArt := false
+	row := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if !inArt {
+			switch {
+			case strings.HasPrefix(line, "# "):
+				p.Title = strings.TrimPrefix(line, "# ")
+			case line == paletteSeparator:
+				inArt = true
+			case strings.TrimSpace(line) == "":
+			default:
+				key, cell, err := parsePaletteEntry(line)
+				if err != nil {
+					return p, err
+				}
+				palette[key] = cell
+			}
+			continue
+		}
+
+		if row >= Height {
+			if strings.TrimSpace(line) != "" {
+				return p, fmt.Errorf("art is taller than %d rows", Height)
+			}
+			continue
+		}
+
+		col := 0
+		for _, key := range line {
+			if col >= Width {
+				return p, fmt.Errorf("row %d is wider than %d columns", row+1, Width)
+			}
+			if key != ' ' {
+				cell, ok := palette[key]
+				if !ok {
+					return p, fmt.Errorf("row %d uses %q which is not in the palette", row+1, key)
+				}
+				p.Cells[row][col] = cell
+			}
+			col++
+		}
+		row++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return p, err
+	}
+	if !inArt {
+		return p, fmt.Errorf("missing %q separator", paletteSeparator)
+	}
+	return p, nil
+}
+
+func parsePaletteEntry(line string) (rune, Cell, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 3 || utf8.RuneCountInString(fields[0]) != 1 || utf8.RuneCountInString(fields[2]) != 1 {
+		return 0, Cell{}, fmt.Errorf("bad palette entry %q, want \"key #rrggbb glyph\"", line)
+	}
+	if !isHexColor(fields[1]) {
+		return 0, Cell{}, fmt.Errorf("bad colour %q in palette entry %q", fields[1], line)
+	}
+
+	key, _ := utf8.DecodeRuneInString(fields[0])
+	glyph, _ := utf8.DecodeRuneInString(fields[2])
+	return key, Cell{Glyph: glyph, Color: strings.ToLower(fields[1])}, nil
+}
+
+func isHexColor(s string) bool {
+	if len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
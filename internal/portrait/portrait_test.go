@@ -0,0 +1,171 @@
+package portrait
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestArtPackParses(t *testing.T) {
+	names := Names()
+	if len(names) == 0 {
+		t.Fatal("Names() is empty, art pack not embedded")
+	}
+
+	for _, name := range names {
+		data, err := artPack.ReadFile("art/" + name + ".txt")
+		if err != nil {
+			t.Fatalf("reading %s: %v", name, err)
+		}
+		p, err := parse(data)
+		if err != nil {
+			t.Errorf("parse(%s) error = %v", name, err)
+			continue
+		}
+		if p.Title == "" {
+			t.Errorf("%s has no title", name)
+		}
+		if countInk(p) == 0 {
+			t.Errorf("%s is blank", name)
+		}
+	}
+}
+
+func TestForUsesArtPack(t *testing.T) {
+	art, ok := Load("Saturn")
+	if !ok {
+		t.Fatal("Load(Saturn) found nothing")
+	}
+	if got := For(models.CelestialBody{EnglishName: "Saturn", MeanRadius: 58232}); got != art {
+		t.Error("For(Saturn) did not use the art pack")
+	}
+	if _, ok := Load("The Moon"); !ok {
+		t.Error(`Load("The Moon") should match moon.txt`)
+	}
+}
+
+func TestForFallsBackToGenerated(t *testing.T) {
+	body := models.CelestialBody{EnglishName: "Kepler-452 b", MeanRadius: 10000, Density: 5}
+	if _, ok := Load(body.EnglishName); ok {
+		t.Fatal("unexpected art for Kepler-452 b")
+	}
+	if got := For(body); got != Generate(body) {
+		t.Error("For() should generate a portrait for unknown bodies")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"no separator":  "a #ffffff █\naaa\n",
+		"bad colour":    "a #fffff █\n---\naaa\n",
+		"bad entry":     "a #ffffff\n---\naaa\n",
+		"unknown key":   "a #ffffff █\n---\nab\n",
+		"too wide":      "a #ffffff █\n---\n" + strings.Repeat("a", Width+1) + "\n",
+		"too many rows": "a #ffffff █\n---\n" + strings.Repeat("a\n", Height+1),
+	}
+
+	for name, art := range tests {
+		if _, err := parse([]byte(art)); err == nil {
+			t.Errorf("%s: parse() should fail", name)
+		}
+	}
+}
+
+func TestParseTransparentCells(t *testing.T) {
+	p, err := parse([]byte("# Test\na #FF8000 ▓\n---\n a\n"))
+	if err != nil {
+		t.Fatalf("parse() error = %v", err)
+	}
+	if p.Title != "Test" {
+		t.Errorf("Title = %q, want Test", p.Title)
+	}
+	if p.At(0, 0).Glyph != 0 {
+		t.Error("space should be transparent")
+	}
+	if got := p.At(1, 0); got != (Cell{Glyph: '▓', Color: "#ff8000"}) {
+		t.Errorf("At(1, 0) = %+v", got)
+	}
+	if p.At(Width, 0) != (Cell{}) {
+		t.Error("At() out of range should be transparent")
+	}
+}
+
+func TestGenerateIsDeterministicDisc(t *testing.T) {
+	body := models.CelestialBody{EnglishName: "TRAPPIST-1e", MeanRadius: 5800, Density: 5.6}
+	p := Generate(body)
+	if p != Generate(body) {
+		t.Fatal("Generate() should be deterministic")
+	}
+
+	// Corners are outside the disc, the centre is inside
+	if p.At(0, 0).Glyph != 0 || p.At(Width-1, Height-1).Glyph != 0 {
+		t.Error("corners should be transparent")
+	}
+	if p.At(Width/2, Height/2).Glyph == 0 {
+		t.Error("centre should be drawn")
+	}
+	if countInk(p) < Width*Height/2 {
+		t.Errorf("disc covers only %d cells", countInk(p))
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		body models.CelestialBody
+		want bodyClass
+	}{
+		{models.CelestialBody{BodyType: "Star", MeanRadius: 700000}, classStar},
+		{models.CelestialBody{MeanRadius: 70000}, classGasGiant},
+		{models.CelestialBody{MeanRadius: 25000}, classIceGiant},
+		{models.CelestialBody{MeanRadius: 6000, Temperature: 1500}, classMolten},
+		{models.CelestialBody{MeanRadius: 1200, Density: 1.9}, classIcy},
+		{models.CelestialBody{MeanRadius: 6000, Density: 5.5}, classRocky},
+	}
+
+	for _, tt := range tests {
+		if got := classify(tt.body); got != tt.want {
+			t.Errorf("classify(%+v) = %v, want %v", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestStarColorFollowsClass(t *testing.T) {
+	red := starColor(models.CelestialBody{StellarClass: "M8V"})
+	blue := starColor(models.CelestialBody{Temperature: 20000})
+	if red.r <= red.b {
+		t.Errorf("M dwarf colour %v should be red", red)
+	}
+	if blue.b <= blue.r {
+		t.Errorf("hot star colour %v should be blue", blue)
+	}
+}
+
+func countInk(p Portrait) int {
+	n := 0
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width; x++ {
+			if p.At(x, y).Glyph != 0 {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestEquatorCell(t *testing.T) {
+	centre, row, ok := EquatorCell(0)
+	if !ok || centre < 9 || centre > 10 || row != 3 {
+		t.Fatalf("EquatorCell(0) = %d, %d, %v, want the middle of the disc", centre, row, ok)
+	}
+	if east, _, ok := EquatorCell(1); !ok || east <= centre {
+		t.Errorf("EquatorCell(1) = %d, want right of the centre", east)
+	}
+	if west, _, ok := EquatorCell(-1); !ok || west >= centre {
+		t.Errorf("EquatorCell(-1) = %d, want left of the centre", west)
+	}
+	if _, _, ok := EquatorCell(math.Pi); ok {
+		t.Error("EquatorCell(π) is on the far side but reported visible")
+	}
+}
@@ -0,0 +1,90 @@
+// Package protocol defines the messages exchanged in live-sync mode. Every message
+// is a JSON envelope carrying the protocol version, a type and a payload, so a
+// browser companion or a second terminal can tell whether it understands it.
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Version is the protocol version this build speaks. Bump it whenever a payload
+// changes in a way older peers would misread.
+const Version = 1
+
+// ErrUnsupportedVersion is returned when a message comes from a newer or older peer
+var ErrUnsupportedVersion = errors.New("unsupported protocol version")
+
+// MessageType identifies the payload of an envelope
+type MessageType string
+
+const (
+	// TypeHello is sent once when a peer connects
+	TypeHello MessageType = "hello"
+	// TypeState carries the session state to mirror
+	TypeState MessageType = "state"
+)
+
+// Envelope wraps every message
+type Envelope struct {
+	Version int             `json:"version"`
+	Type    MessageType     `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Hello introduces the broadcasting app
+type Hello struct {
+	App string `json:"app"`
+}
+
+// State is what a follower needs to mirror the session
+type State struct {
+	System        string    `json:"system"`       // system id, as listed on the systems screen
+	SystemName    string    `json:"systemName"`   // display name, for companions that only show it
+	SelectedBody  string    `json:"selectedBody"` // English name; empty when nothing is selected
+	SimulatedTime time.Time `json:"simulatedTime"`
+	Speed         float64   `json:"speed"` // simulated seconds per real second
+}
+
+// SameView reports whether two states show the same system and body, ignoring time
+func (s State) SameView(other State) bool {
+	return s.System == other.System && s.SelectedBody == other.SelectedBody
+}
+
+// Encode wraps a payload in an envelope for the current version
+func Encode(messageType MessageType, payload interface{}) ([]byte, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s payload: %w", messageType, err)
+	}
+	return json.Marshal(Envelope{Version: Version, Type: messageType, Payload: raw})
+}
+
+// Decode reads an envelope, rejecting other protocol versions
+func Decode(data []byte) (Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return Envelope{}, fmt.Errorf("failed to decode message: %w", err)
+	}
+	if envelope.Version != Version {
+		return Envelope{}, fmt.Errorf("%w %d (want %d)", ErrUnsupportedVersion, envelope.Version, Version)
+	}
+	if envelope.Type == "" {
+		return Envelope{}, fmt.Errorf("message has no type")
+	}
+	return envelope, nil
+}
+
+// State decodes a state payload
+func (e Envelope) State() (State, error) {
+	var state State
+	if e.Type != TypeState {
+		return state, fmt.Errorf("message is %s, not %s", e.Type, TypeState)
+	}
+	if err := json.Unmarshal(e.Payload, &state); err != nil {
+		return state, fmt.Errorf("failed to decode state: %w", err)
+	}
+	return state, nil
+}
@@ -0,0 +1,67 @@
+package protocol
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEncodeDecodeState(t *testing.T) {
+	want := State{
+		System:        "solar-system",
+		SystemName:    "Solar System",
+		SelectedBody:  "Mars",
+		SimulatedTime: time.Date(2030, 5, 1, 12, 0, 0, 0, time.UTC),
+		Speed:         86400,
+	}
+
+	data, err := Encode(TypeState, want)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	envelope, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if envelope.Type != TypeState {
+		t.Errorf("Type = %q, want %q", envelope.Type, TypeState)
+	}
+
+	got, err := envelope.State()
+	if err != nil {
+		t.Fatalf("State() error = %v", err)
+	}
+	if !got.SimulatedTime.Equal(want.SimulatedTime) || !got.SameView(want) || got.Speed != want.Speed || got.SystemName != want.SystemName {
+		t.Errorf("State() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeRejectsOtherVersions(t *testing.T) {
+	_, err := Decode([]byte(`{"version": 99, "type": "state", "payload": {}}`))
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Decode() error = %v, want ErrUnsupportedVersion", err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, data := range []string{`not json`, `{"version": 1, "payload": {}}`} {
+		if _, err := Decode([]byte(data)); err == nil {
+			t.Errorf("Decode(%s) succeeded, want an error", data)
+		}
+	}
+}
+
+func TestStateRejectsOtherTypes(t *testing.T) {
+	data, err := Encode(TypeHello, Hello{App: "go-solar-system"})
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	envelope, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if _, err := envelope.State(); err == nil {
+		t.Error("State() on a hello message succeeded, want an error")
+	}
+}
@@ -0,0 +1,206 @@
+// Package quiz generates multiple-choice questions from the loaded celestial bodies.
+package quiz
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// MaxOptions is the number of choices offered per question when enough bodies exist
+const MaxOptions = 4
+
+// Question is a single multiple-choice question
+type Question struct {
+	Prompt  string
+	Options []string
+	Answer  int
+}
+
+// IsCorrect reports whether the option at index answers the question
+func (q Question) IsCorrect(index int) bool {
+	return index == q.Answer
+}
+
+// Score tracks progress through a quiz session
+type Score struct {
+	Correct  int
+	Answered int
+	Streak   int
+	Best     int
+}
+
+// Record updates the score with the outcome of one question
+func (s *Score) Record(correct bool) {
+	s.Answered++
+	if !correct {
+		s.Streak = 0
+		return
+	}
+
+	s.Correct++
+	s.Streak++
+	if s.Streak > s.Best {
+		s.Best = s.Streak
+	}
+}
+
+// Percent returns the share of correct answers, 0-100
+func (s Score) Percent() int {
+	if s.Answered == 0 {
+		return 0
+	}
+	return s.Correct * 100 / s.Answered
+}
+
+// superlative asks which body has the highest or lowest value of a property
+type superlative struct {
+	prompt    string
+	value     func(body models.CelestialBody) float64
+	highest   bool
+	allowZero bool // zero is a real value rather than missing data
+}
+
+// countQuestion asks for a body's value of a whole-number property
+type countQuestion struct {
+	prompt string
+	value  func(body models.CelestialBody) int
+}
+
+var superlatives = []superlative{
+	{"Which of these has the highest surface gravity?", func(b models.CelestialBody) float64 { return b.Gravity }, true, false},
+	{"Which of these has the lowest surface gravity?", func(b models.CelestialBody) float64 { return b.Gravity }, false, false},
+	{"Which of these is the largest?", func(b models.CelestialBody) float64 { return b.MeanRadius }, true, false},
+	{"Which of these is the smallest?", func(b models.CelestialBody) float64 { return b.MeanRadius }, false, false},
+	{"Which of these is the most massive?", func(b models.CelestialBody) float64 { return b.GetMassKg() }, true, false},
+	{"Which of these is the densest?", func(b models.CelestialBody) float64 { return b.Density }, true, false},
+	{"Which of these orbits closest to its star?", func(b models.CelestialBody) float64 { return b.SemimajorAxis }, false, false},
+	{"Which of these orbits farthest from its star?", func(b models.CelestialBody) float64 { return b.SemimajorAxis }, true, false},
+	{"Which of these has the longest year?", func(b models.CelestialBody) float64 { return b.SideralOrbit }, true, false},
+	{"Which of these has the most moons?", func(b models.CelestialBody) float64 { return float64(len(b.Moons)) }, true, true},
+}
+
+var countQuestions = []countQuestion{
+	{"How many moons does %s have?", func(b models.CelestialBody) int { return len(b.Moons) }},
+}
+
+// Generator builds random questions from a set of bodies
+type Generator struct {
+	bodies []models.CelestialBody
+	rng    *rand.Rand
+}
+
+// NewGenerator creates a generator for the given bodies; stars and bodies without a
+// name are ignored. The seed makes question order reproducible.
+func NewGenerator(bodies []models.CelestialBody, seed int64) *Generator {
+	var candidates []models.CelestialBody
+	for _, body := range bodies {
+		if body.EnglishName == "" || body.BodyType == "Star" || body.SemimajorAxis <= 0 {
+			continue
+		}
+		candidates = append(candidates, body)
+	}
+
+	return &Generator{
+		bodies: candidates,
+		rng:    rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Next returns a new random question, or false if the bodies can't support one
+func (g *Generator) Next() (Question, bool) {
+	if len(g.bodies) < 2 {
+		return Question{}, false
+	}
+
+	// Try a handful of templates; some won't have enough data to ask fairly
+	for attempt := 0; attempt < 20; attempt++ {
+		var (
+			question Question
+			ok       bool
+		)
+		if g.rng.Intn(len(superlatives)+len(countQuestions)) < len(superlatives) {
+			question, ok = g.superlativeQuestion(superlatives[g.rng.Intn(len(superlatives))])
+		} else {
+			question, ok = g.countQuestion(countQuestions[g.rng.Intn(len(countQuestions))])
+		}
+		if ok {
+			return question, true
+		}
+	}
+
+	return Question{}, false
+}
+
+// superlativeQuestion picks bodies with distinct known values so exactly one answer is right
+func (g *Generator) superlativeQuestion(s superlative) (Question, bool) {
+	seen := make(map[float64]bool)
+	var pool []models.CelestialBody
+	for _, index := range g.rng.Perm(len(g.bodies)) {
+		body := g.bodies[index]
+		value := s.value(body)
+		if value < 0 || (value == 0 && !s.allowZero) {
+			continue
+		}
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		pool = append(pool, body)
+		if len(pool) == MaxOptions {
+			break
+		}
+	}
+
+	if len(pool) < 2 {
+		return Question{}, false
+	}
+
+	best := 0
+	for i, body := range pool {
+		if (s.highest && s.value(body) > s.value(pool[best])) || (!s.highest && s.value(body) < s.value(pool[best])) {
+			best = i
+		}
+	}
+
+	options := make([]string, len(pool))
+	for i, body := range pool {
+		options[i] = body.EnglishName
+	}
+
+	return Question{Prompt: s.prompt, Options: options, Answer: best}, true
+}
+
+// countQuestion asks for a body's count with nearby numbers as distractors
+func (g *Generator) countQuestion(c countQuestion) (Question, bool) {
+	body := g.bodies[g.rng.Intn(len(g.bodies))]
+	answer := c.value(body)
+
+	choices := map[int]bool{answer: true}
+	for spread := 1; len(choices) < MaxOptions && spread < 50; spread++ {
+		for _, candidate := range []int{answer + spread, answer - spread} {
+			if candidate >= 0 && len(choices) < MaxOptions && g.rng.Intn(2) == 0 {
+				choices[candidate] = true
+			}
+		}
+	}
+
+	values := make([]int, 0, len(choices))
+	for value := range choices {
+		values = append(values, value)
+	}
+	sort.Ints(values)
+
+	question := Question{Prompt: fmt.Sprintf(c.prompt, body.EnglishName)}
+	for i, value := range values {
+		question.Options = append(question.Options, strconv.Itoa(value))
+		if value == answer {
+			question.Answer = i
+		}
+	}
+
+	return question, true
+}
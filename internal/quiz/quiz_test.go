@@ -0,0 +1,85 @@
+package quiz
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func testBodies() []models.CelestialBody {
+	return []models.CelestialBody{
+		{EnglishName: "Sun", BodyType: "Star", Gravity: 274, MeanRadius: 695700},
+		{EnglishName: "Mercury", SemimajorAxis: 57909050, Gravity: 3.7, MeanRadius: 2439.7, Density: 5.43, SideralOrbit: 87.97},
+		{EnglishName: "Earth", SemimajorAxis: 149598023, Gravity: 9.8, MeanRadius: 6371, Density: 5.51, SideralOrbit: 365.26,
+			Moons: []models.Moon{{EnglishName: "Moon"}}},
+		{EnglishName: "Jupiter", SemimajorAxis: 778547200, Gravity: 24.79, MeanRadius: 69911, Density: 1.33, SideralOrbit: 4332.59,
+			Moons: []models.Moon{{EnglishName: "Io"}, {EnglishName: "Europa"}, {EnglishName: "Ganymede"}}},
+	}
+}
+
+func TestGeneratorQuestionsHaveSingleCorrectAnswer(t *testing.T) {
+	generator := NewGenerator(testBodies(), 42)
+
+	for i := 0; i < 200; i++ {
+		question, ok := generator.Next()
+		if !ok {
+			t.Fatalf("Next() returned no question on iteration %d", i)
+		}
+
+		if len(question.Options) < 2 || len(question.Options) > MaxOptions {
+			t.Fatalf("question %q has %d options", question.Prompt, len(question.Options))
+		}
+
+		if question.Answer < 0 || question.Answer >= len(question.Options) {
+			t.Fatalf("question %q answer index %d out of range", question.Prompt, question.Answer)
+		}
+
+		seen := make(map[string]bool)
+		for _, option := range question.Options {
+			if seen[option] {
+				t.Fatalf("question %q has duplicate option %q", question.Prompt, option)
+			}
+			if option == "Sun" {
+				t.Fatalf("question %q offers the star as an option", question.Prompt)
+			}
+			seen[option] = true
+		}
+	}
+}
+
+func TestGeneratorPicksRightSuperlative(t *testing.T) {
+	generator := NewGenerator(testBodies(), 1)
+
+	question, ok := generator.superlativeQuestion(superlatives[0]) // highest gravity
+	if !ok {
+		t.Fatal("superlativeQuestion() returned no question")
+	}
+
+	if got := question.Options[question.Answer]; got != "Jupiter" {
+		t.Errorf("highest gravity answer = %s, want Jupiter", got)
+	}
+}
+
+func TestGeneratorNeedsTwoBodies(t *testing.T) {
+	generator := NewGenerator(testBodies()[:2], 1)
+	if _, ok := generator.Next(); ok {
+		t.Error("Next() should fail with a single candidate body")
+	}
+}
+
+func TestScoreRecord(t *testing.T) {
+	var score Score
+	for _, correct := range []bool{true, true, false, true} {
+		score.Record(correct)
+	}
+
+	if score.Correct != 3 || score.Answered != 4 {
+		t.Errorf("score = %d/%d, want 3/4", score.Correct, score.Answered)
+	}
+	if score.Streak != 1 || score.Best != 2 {
+		t.Errorf("streak = %d best = %d, want 1 and 2", score.Streak, score.Best)
+	}
+	if score.Percent() != 75 {
+		t.Errorf("Percent() = %d, want 75", score.Percent())
+	}
+}
@@ -0,0 +1,139 @@
+// Package scalemodel shrinks a star system to a model whose star is the size of
+// an everyday object, giving every body's diameter and distance at that scale
+// from its recorded mean radius and semi-major axis.
+package scalemodel
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// Object is something to stand in for the star, with its diameter in metres
+type Object struct {
+	Name     string
+	Diameter float64
+}
+
+// Objects are the stand-ins offered for the star, smallest first
+var Objects = []Object{
+	{Name: "peppercorn", Diameter: 0.005},
+	{Name: "marble", Diameter: 0.015},
+	{Name: "golf ball", Diameter: 0.043},
+	{Name: "grapefruit", Diameter: 0.13},
+	{Name: "football", Diameter: 0.22},
+	{Name: "beach ball", Diameter: 0.5},
+	{Name: "exercise ball", Diameter: 0.65},
+}
+
+// ObjectSized returns the stand-in of exactly this diameter, if there is one
+func ObjectSized(diameter float64) (Object, bool) {
+	for _, object := range Objects {
+		if object.Diameter == diameter {
+			return object, true
+		}
+	}
+	return Object{}, false
+}
+
+// Body is one body in the model, in metres; a zero figure is not recorded
+type Body struct {
+	Name     string
+	Diameter float64
+	Distance float64 // from the star, the semi-major axis scaled
+}
+
+// Model is a system shrunk so its star has a chosen diameter
+type Model struct {
+	Star   string
+	Scale  float64 // metres of model per km of the real system
+	Bodies []Body
+}
+
+// ErrNoStar is returned for systems without a star whose radius is known
+var ErrNoStar = errors.New("no star with a known radius to scale from")
+
+// New builds the model of bodies whose star, the first listed, is starDiameter
+// metres across. Bodies with neither a radius nor a semi-major axis are left out.
+func New(bodies []models.CelestialBody, starDiameter float64) (Model, error) {
+	if starDiameter <= 0 {
+		return Model{}, fmt.Errorf("the star needs a size above zero")
+	}
+	var star *models.CelestialBody
+	for i := range bodies {
+		if bodies[i].BodyType == "Star" && bodies[i].MeanRadius > 0 {
+			star = &bodies[i]
+			break
+		}
+	}
+	if star == nil {
+		return Model{}, ErrNoStar
+	}
+
+	model := Model{Star: star.EnglishName, Scale: starDiameter / (2 * star.MeanRadius)}
+	for _, body := range bodies {
+		if body.BodyType == "Star" || (body.MeanRadius <= 0 && body.SemimajorAxis <= 0) {
+			continue
+		}
+		model.Bodies = append(model.Bodies, Body{
+			Name:     body.EnglishName,
+			Diameter: 2 * body.MeanRadius * model.Scale,
+			Distance: body.SemimajorAxis * model.Scale,
+		})
+	}
+	return model, nil
+}
+
+// FormatLength writes a model length in metres in the unit that suits it best
+func FormatLength(metres float64) string {
+	switch {
+	case metres <= 0:
+		return "unknown"
+	case metres < 0.0001:
+		return fmt.Sprintf("%.1f µm", metres*1e6)
+	case metres < 0.01:
+		return fmt.Sprintf("%.2f mm", metres*1000)
+	case metres < 1:
+		return fmt.Sprintf("%.1f cm", metres*100)
+	case metres < 1000:
+		return fmt.Sprintf("%.1f m", metres)
+	default:
+		return fmt.Sprintf("%.2f km", metres/1000)
+	}
+}
+
+// ParseSize reads a size for the star: an object's name ("football"), or a
+// number with mm, cm, m or km after it. A bare number is in centimetres.
+func ParseSize(size string) (float64, error) {
+	size = strings.ToLower(strings.TrimSpace(size))
+	for _, object := range Objects {
+		if size == object.Name {
+			return object.Diameter, nil
+		}
+	}
+
+	units := []struct {
+		suffix string
+		metres float64
+	}{{"mm", 0.001}, {"cm", 0.01}, {"km", 1000}, {"m", 1}}
+	scale := 0.01
+	for _, unit := range units {
+		if strings.HasSuffix(size, unit.suffix) {
+			size = strings.TrimSpace(strings.TrimSuffix(size, unit.suffix))
+			scale = unit.metres
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(size, 64)
+	if err != nil || value <= 0 {
+		names := make([]string, len(Objects))
+		for i, object := range Objects {
+			names[i] = object.Name
+		}
+		return 0, fmt.Errorf("size must be a length such as 22cm or one of: %s", strings.Join(names, ", "))
+	}
+	return value * scale, nil
+}
@@ -0,0 +1,75 @@
+package scalemodel
+
+import (
+	"math"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestNew(t *testing.T) {
+	bodies := []models.CelestialBody{
+		{EnglishName: "Sun", BodyType: "Star", MeanRadius: 695508},
+		{EnglishName: "Earth", BodyType: "Planet", MeanRadius: 6371, SemimajorAxis: 149598023},
+		{EnglishName: "Nameless", BodyType: "Asteroid"},
+	}
+	model, err := New(bodies, 0.22)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if model.Star != "Sun" || len(model.Bodies) != 1 {
+		t.Fatalf("Expected the Sun scaled with only Earth in the model, got %+v", model)
+	}
+	earth := model.Bodies[0]
+	if math.Abs(earth.Diameter-0.002015) > 1e-5 {
+		t.Errorf("Expected Earth about 2 mm across with a 22 cm Sun, got %v m", earth.Diameter)
+	}
+	if math.Abs(earth.Distance-23.66) > 0.01 {
+		t.Errorf("Expected Earth about 23.7 m away, got %v m", earth.Distance)
+	}
+
+	if _, err := New(bodies[1:], 0.22); err != ErrNoStar {
+		t.Errorf("Expected ErrNoStar without a star, got %v", err)
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"football", 0.22},
+		{"22", 0.22},
+		{"22cm", 0.22},
+		{"1.5 m", 1.5},
+		{"4mm", 0.004},
+		{"1km", 1000},
+	}
+	for _, tt := range tests {
+		got, err := ParseSize(tt.in)
+		if err != nil || math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("ParseSize(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "big", "-3cm", "0"} {
+		if _, err := ParseSize(bad); err == nil {
+			t.Errorf("ParseSize(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFormatLength(t *testing.T) {
+	tests := map[float64]string{
+		0:       "unknown",
+		0.00005: "50.0 µm",
+		0.002:   "2.00 mm",
+		0.22:    "22.0 cm",
+		23.66:   "23.7 m",
+		4523.4:  "4.52 km",
+	}
+	for in, want := range tests {
+		if got := FormatLength(in); got != want {
+			t.Errorf("FormatLength(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
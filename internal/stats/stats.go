@@ -0,0 +1,90 @@
+// Package stats computes aggregate figures for a loaded star system: how many
+// bodies of each kind it has, their combined mass, the extremes and the mean density.
+package stats
+
+import (
+	"math"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// Extreme is the body holding a record, e.g. the largest radius
+type Extreme struct {
+	Name  string
+	Value float64
+}
+
+// Summary is the set of aggregates for one system
+type Summary struct {
+	Bodies       int
+	Stars        int
+	Planets      int
+	DwarfPlanets int
+	Other        int
+	Moons        int // known moons of the loaded bodies
+
+	TotalMass      float64 // kg, every body with a known mass
+	NonStellarMass float64 // kg, excluding stars
+
+	// Records among non-stellar bodies; zero when nothing has the value recorded
+	Largest  Extreme // mean radius, km
+	Smallest Extreme // mean radius, km
+	Heaviest Extreme // kg
+
+	// MeanDensity is the combined mass over the combined volume of non-stellar
+	// bodies with both mass and radius known, in g/cm³
+	MeanDensity float64
+}
+
+// Summarize aggregates the bodies of a system
+func Summarize(bodies []models.CelestialBody) Summary {
+	var s Summary
+	var densityMass, densityVolume float64
+
+	for _, body := range bodies {
+		s.Bodies++
+		s.Moons += len(body.Moons)
+
+		mass := body.GetMassKg()
+		s.TotalMass += mass
+
+		switch strings.ToLower(body.BodyType) {
+		case "star":
+			s.Stars++
+			continue
+		case "dwarf planet":
+			s.DwarfPlanets++
+		default:
+			if body.IsPlanet || body.SemimajorAxis > 0 {
+				s.Planets++
+			} else {
+				s.Other++
+			}
+		}
+
+		s.NonStellarMass += mass
+		if body.MeanRadius > 0 {
+			if body.MeanRadius > s.Largest.Value {
+				s.Largest = Extreme{Name: body.EnglishName, Value: body.MeanRadius}
+			}
+			if s.Smallest.Name == "" || body.MeanRadius < s.Smallest.Value {
+				s.Smallest = Extreme{Name: body.EnglishName, Value: body.MeanRadius}
+			}
+		}
+		if mass > s.Heaviest.Value {
+			s.Heaviest = Extreme{Name: body.EnglishName, Value: mass}
+		}
+
+		if mass > 0 && body.MeanRadius > 0 {
+			densityMass += mass
+			densityVolume += 4.0 / 3.0 * math.Pi * math.Pow(body.MeanRadius, 3)
+		}
+	}
+
+	if densityVolume > 0 {
+		// kg/km³ to g/cm³: ×1000 g/kg, ÷1e15 cm³/km³
+		s.MeanDensity = densityMass / densityVolume * 1e3 / 1e15
+	}
+	return s
+}
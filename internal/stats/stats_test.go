@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func testBodies() []models.CelestialBody {
+	return []models.CelestialBody{
+		{EnglishName: "Sun", BodyType: "Star", MeanRadius: 695508, Mass: models.Mass{MassValue: 1.989, MassExponent: 30}},
+		{EnglishName: "Earth", BodyType: "Planet", IsPlanet: true, MeanRadius: 6371, SemimajorAxis: 149598023,
+			Mass: models.Mass{MassValue: 5.972, MassExponent: 24}, Moons: []models.Moon{{Name: "La Lune"}}},
+		{EnglishName: "Mars", BodyType: "Planet", IsPlanet: true, MeanRadius: 3389.5, SemimajorAxis: 227939200,
+			Mass: models.Mass{MassValue: 6.417, MassExponent: 23}, Moons: []models.Moon{{Name: "Phobos"}, {Name: "Deimos"}}},
+		{EnglishName: "Pluto", BodyType: "Dwarf Planet", MeanRadius: 1188.3, SemimajorAxis: 5906440628,
+			Mass: models.Mass{MassValue: 1.303, MassExponent: 22}},
+		{EnglishName: "Mystery", MeanRadius: 0},
+	}
+}
+
+func TestSummarizeCounts(t *testing.T) {
+	s := Summarize(testBodies())
+
+	if s.Bodies != 5 || s.Stars != 1 || s.Planets != 2 || s.DwarfPlanets != 1 || s.Other != 1 {
+		t.Errorf("counts = %+v", s)
+	}
+	if s.Moons != 3 {
+		t.Errorf("Moons = %d, want 3", s.Moons)
+	}
+}
+
+func TestSummarizeExtremesSkipStars(t *testing.T) {
+	s := Summarize(testBodies())
+
+	if s.Largest.Name != "Earth" || s.Largest.Value != 6371 {
+		t.Errorf("Largest = %+v, want Earth", s.Largest)
+	}
+	if s.Smallest.Name != "Pluto" {
+		t.Errorf("Smallest = %+v, want Pluto (unknown radii are ignored)", s.Smallest)
+	}
+	if s.Heaviest.Name != "Earth" {
+		t.Errorf("Heaviest = %+v, want Earth", s.Heaviest)
+	}
+}
+
+func TestSummarizeMass(t *testing.T) {
+	s := Summarize(testBodies())
+
+	nonStellar := 5.972e24 + 6.417e23 + 1.303e22
+	if math.Abs(s.NonStellarMass-nonStellar)/nonStellar > 1e-9 {
+		t.Errorf("NonStellarMass = %g, want %g", s.NonStellarMass, nonStellar)
+	}
+	if math.Abs(s.TotalMass-(nonStellar+1.989e30))/s.TotalMass > 1e-9 {
+		t.Errorf("TotalMass = %g", s.TotalMass)
+	}
+}
+
+func TestSummarizeMeanDensity(t *testing.T) {
+	earthOnly := []models.CelestialBody{
+		{EnglishName: "Earth", IsPlanet: true, MeanRadius: 6371, Mass: models.Mass{MassValue: 5.972, MassExponent: 24}},
+	}
+	if d := Summarize(earthOnly).MeanDensity; math.Abs(d-5.51) > 0.01 {
+		t.Errorf("MeanDensity = %.3f, want Earth's 5.51 g/cm³", d)
+	}
+
+	// Mass-weighted, so the heavy Earth dominates the light Pluto
+	d := Summarize(testBodies()).MeanDensity
+	if d < 3.9 || d > 5.51 {
+		t.Errorf("MeanDensity = %.3f, want between Mars and Earth", d)
+	}
+}
+
+func TestSummarizeEmpty(t *testing.T) {
+	if s := Summarize(nil); s != (Summary{}) {
+		t.Errorf("Summarize(nil) = %+v, want zero", s)
+	}
+}
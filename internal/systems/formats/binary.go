@@ -0,0 +1,162 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+// binaryMagic starts every binary system file, followed by binaryVersion
+var binaryMagic = []byte("GSSB")
+
+// binaryVersion is bumped whenever the encoded layout changes incompatibly
+const binaryVersion = 1
+
+// BinaryFormat implements the FileFormat interface for compact binary system
+// files, meant for generated or imported systems with hundreds of bodies where
+// parsing JSON gets slow. After the header come the gob values: the metadata,
+// so the system list can read it without decoding any bodies, then the bodies,
+// then any belts, which files written before belts existed end without.
+type BinaryFormat struct{}
+
+// NewBinaryFormat creates a new binary format handler
+func NewBinaryFormat() *BinaryFormat {
+	return &BinaryFormat{}
+}
+
+// GetSupportedExtensions returns the file extensions this handler supports
+func (bf *BinaryFormat) GetSupportedExtensions() []string {
+	return []string{".ssb"}
+}
+
+// GetFormatName returns a human-readable name for this format
+func (bf *BinaryFormat) GetFormatName() string {
+	return "Binary"
+}
+
+// ParseSystemData parses the complete system data from binary content
+func (bf *BinaryFormat) ParseSystemData(data []byte) (*SystemData, error) {
+	decoder, err := bf.decoder(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata SystemMetadata
+	if err := decoder.Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse binary system metadata: %w", err)
+	}
+	system := SystemData{
+		SystemName:     metadata.SystemName,
+		Description:    metadata.Description,
+		DiscoveryYear:  metadata.DiscoveryYear,
+		Distance:       metadata.Distance,
+		Galaxy:         metadata.Galaxy,
+		RightAscension: metadata.RightAscension,
+	}
+	if err := decoder.Decode(&system.Bodies); err != nil {
+		return nil, fmt.Errorf("failed to parse binary system bodies: %w", err)
+	}
+	if err := decoder.Decode(&system.Belts); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse binary system belts: %w", err)
+	}
+
+	if err := validateSystemData(&system); err != nil {
+		return nil, fmt.Errorf("invalid system data: %w", err)
+	}
+
+	return &system, nil
+}
+
+// ParseSystemMetadata parses only the metadata from binary content, leaving the
+// bodies undecoded
+func (bf *BinaryFormat) ParseSystemMetadata(data []byte) (*SystemMetadata, error) {
+	decoder, err := bf.decoder(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata SystemMetadata
+	if err := decoder.Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse binary system metadata: %w", err)
+	}
+
+	if err := validateSystemMetadata(&metadata); err != nil {
+		return nil, fmt.Errorf("invalid system metadata: %w", err)
+	}
+
+	return &metadata, nil
+}
+
+// ValidateFormat checks the header, so binary files are told apart from text ones
+func (bf *BinaryFormat) ValidateFormat(data []byte) error {
+	_, err := bf.decoder(data)
+	return err
+}
+
+// EncodeSystemData writes system data as a binary system file
+func (bf *BinaryFormat) EncodeSystemData(system *SystemData) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(binaryMagic)
+	buf.WriteByte(binaryVersion)
+
+	encoder := gob.NewEncoder(&buf)
+	metadata := SystemMetadata{
+		SystemName:     system.SystemName,
+		Description:    system.Description,
+		DiscoveryYear:  system.DiscoveryYear,
+		Distance:       system.Distance,
+		Galaxy:         system.Galaxy,
+		RightAscension: system.RightAscension,
+	}
+	if err := encoder.Encode(metadata); err != nil {
+		return nil, fmt.Errorf("failed to encode binary system metadata: %w", err)
+	}
+	if err := encoder.Encode(system.Bodies); err != nil {
+		return nil, fmt.Errorf("failed to encode binary system bodies: %w", err)
+	}
+	if len(system.Belts) > 0 {
+		if err := encoder.Encode(system.Belts); err != nil {
+			return nil, fmt.Errorf("failed to encode binary system belts: %w", err)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// WriteSystemMetadata replaces the metadata of binary content. The bodies are
+// decoded and written back unchanged, since a gob stream cannot be spliced.
+func (bf *BinaryFormat) WriteSystemMetadata(data []byte, metadata *SystemMetadata) ([]byte, error) {
+	if err := validateSystemMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("invalid system metadata: %w", err)
+	}
+
+	system, err := bf.ParseSystemData(data)
+	if err != nil {
+		return nil, err
+	}
+	system.SystemName = metadata.SystemName
+	system.Description = metadata.Description
+	system.DiscoveryYear = metadata.DiscoveryYear
+	system.Distance = metadata.Distance
+	system.Galaxy = metadata.Galaxy
+	system.RightAscension = metadata.RightAscension
+	return bf.EncodeSystemData(system)
+}
+
+// decoder checks the header and returns a decoder for what follows it
+func (bf *BinaryFormat) decoder(data []byte) (*gob.Decoder, error) {
+	header := len(binaryMagic) + 1
+	if len(data) < header || !bytes.Equal(data[:len(binaryMagic)], binaryMagic) {
+		return nil, fmt.Errorf("invalid binary format: missing %q header", binaryMagic)
+	}
+	if version := data[len(binaryMagic)]; version != binaryVersion {
+		return nil, fmt.Errorf("unsupported binary format version %d, want %d", version, binaryVersion)
+	}
+	return gob.NewDecoder(bytes.NewReader(data[header:])), nil
+}
+
+// GetMimeType returns the MIME type for binary system files
+func (bf *BinaryFormat) GetMimeType() string {
+	return "application/octet-stream"
+}
@@ -0,0 +1,143 @@
+package formats
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	system, err := NewTOMLFormat().ParseSystemData([]byte(sampleTOML))
+	if err != nil {
+		t.Fatalf("ParseSystemData() error = %v", err)
+	}
+	ra := 219.9
+	system.RightAscension = &ra
+	system.Belts = []models.Belt{{Name: "Dust ring", InnerRadius: 2e8, OuterRadius: 3e8, Kind: models.BeltIcy, Symbol: "~"}}
+
+	binary := NewBinaryFormat()
+	data, err := binary.EncodeSystemData(system)
+	if err != nil {
+		t.Fatalf("EncodeSystemData() error = %v", err)
+	}
+
+	decoded, err := binary.ParseSystemData(data)
+	if err != nil {
+		t.Fatalf("ParseSystemData() error = %v", err)
+	}
+	if decoded.SystemName != system.SystemName || decoded.Distance != system.Distance || *decoded.RightAscension != ra {
+		t.Errorf("metadata = %+v, want it to match %+v", decoded, system)
+	}
+	if len(decoded.Bodies) != 2 {
+		t.Fatalf("got %d bodies, want 2", len(decoded.Bodies))
+	}
+	star, planet := decoded.Bodies[0], decoded.Bodies[1]
+	if star.Mass != system.Bodies[0].Mass || planet.Symbol != "◆" {
+		t.Errorf("bodies = %+v, want them to match %+v", decoded.Bodies, system.Bodies)
+	}
+	if planet.OrbitalElements == nil || !planet.OrbitalElements.Epoch.Equal(system.Bodies[1].OrbitalElements.Epoch) {
+		t.Errorf("orbital elements = %+v, want the epoch kept", planet.OrbitalElements)
+	}
+	if len(planet.Moons) != 2 || planet.Moons[0].Body == nil || planet.Moons[0].Body.MeanRadius != 1200 {
+		t.Errorf("moons = %+v, want the described moon's body kept", planet.Moons)
+	}
+
+	if len(decoded.Belts) != 1 || decoded.Belts[0] != system.Belts[0] {
+		t.Errorf("belts = %+v, want %+v", decoded.Belts, system.Belts)
+	}
+
+	metadata, err := binary.ParseSystemMetadata(data)
+	if err != nil || metadata.SystemName != "Test System" {
+		t.Errorf("ParseSystemMetadata() = %+v, %v", metadata, err)
+	}
+}
+
+func TestBinaryRejectsOtherContent(t *testing.T) {
+	binary := NewBinaryFormat()
+	if err := binary.ValidateFormat([]byte(`{"systemName": "x", "bodies": []}`)); err == nil {
+		t.Error("ValidateFormat() accepted JSON")
+	}
+
+	data, err := binary.EncodeSystemData(&SystemData{SystemName: "x", Bodies: nil})
+	if err != nil {
+		t.Fatalf("EncodeSystemData() error = %v", err)
+	}
+	data[len(binaryMagic)] = binaryVersion + 1
+	if _, err := binary.ParseSystemData(data); err == nil || !strings.Contains(err.Error(), "version") {
+		t.Errorf("ParseSystemData() error = %v, want a version error", err)
+	}
+}
+
+func TestRegistryDetectsBinary(t *testing.T) {
+	data, err := NewBinaryFormat().EncodeSystemData(&SystemData{SystemName: "x"})
+	if err != nil {
+		t.Fatalf("EncodeSystemData() error = %v", err)
+	}
+	format, err := NewFormatRegistry().DetectFormat(data)
+	if err != nil || format.GetFormatName() != "Binary" {
+		t.Errorf("DetectFormat() = %v, %v; want the binary format", format, err)
+	}
+}
+
+func TestTOMLEncodeRoundTrip(t *testing.T) {
+	tomlFormat := NewTOMLFormat()
+	system, err := tomlFormat.ParseSystemData([]byte(sampleTOML))
+	if err != nil {
+		t.Fatalf("ParseSystemData() error = %v", err)
+	}
+
+	data, err := tomlFormat.EncodeSystemData(system)
+	if err != nil {
+		t.Fatalf("EncodeSystemData() error = %v", err)
+	}
+	if strings.Contains(string(data), "massExponent = 30.0") {
+		t.Errorf("massExponent written as a float:\n%s", data)
+	}
+
+	decoded, err := tomlFormat.ParseSystemData(data)
+	if err != nil {
+		t.Fatalf("ParseSystemData() of encoded data error = %v\n%s", err, data)
+	}
+	if decoded.Bodies[0].Mass != system.Bodies[0].Mass || decoded.Bodies[1].SemimajorAxis != system.Bodies[1].SemimajorAxis {
+		t.Errorf("bodies = %+v, want them to match %+v", decoded.Bodies, system.Bodies)
+	}
+	for _, issue := range tomlFormat.ValidateSystem(data) {
+		if issue.Severity == SeverityError {
+			t.Errorf("encoded file has error: %v", issue)
+		}
+	}
+}
+
+func TestBinaryWriteSystemMetadata(t *testing.T) {
+	system, err := NewTOMLFormat().ParseSystemData([]byte(sampleTOML))
+	if err != nil {
+		t.Fatalf("ParseSystemData() error = %v", err)
+	}
+	binary := NewBinaryFormat()
+	data, err := binary.EncodeSystemData(system)
+	if err != nil {
+		t.Fatalf("EncodeSystemData() error = %v", err)
+	}
+
+	metadata, err := binary.ParseSystemMetadata(data)
+	if err != nil {
+		t.Fatalf("ParseSystemMetadata() error = %v", err)
+	}
+	metadata.DiscoveryYear = "2031"
+	data, err = binary.WriteSystemMetadata(data, metadata)
+	if err != nil {
+		t.Fatalf("WriteSystemMetadata() error = %v", err)
+	}
+
+	rewritten, err := binary.ParseSystemData(data)
+	if err != nil {
+		t.Fatalf("ParseSystemData() error = %v", err)
+	}
+	if rewritten.DiscoveryYear != "2031" || rewritten.Description != system.Description {
+		t.Errorf("metadata = %+v, want only the discovery year changed", rewritten)
+	}
+	if len(rewritten.Bodies) != 2 || rewritten.Bodies[1].SemimajorAxis != system.Bodies[1].SemimajorAxis {
+		t.Errorf("bodies = %+v, want them unchanged", rewritten.Bodies)
+	}
+}
@@ -0,0 +1,50 @@
+package formats
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// lightYearsPerParsec converts parsecs to light-years
+const lightYearsPerParsec = 3.26156
+
+// distancePattern splits a distance such as "4.37 light-years" into its number and unit
+var distancePattern = regexp.MustCompile(`^~?\s*([0-9]*\.?[0-9]+(?:e[+-]?[0-9]+)?)\s*(.*)$`)
+
+// distanceUnits maps the units a system's distance may be given in to light-years
+var distanceUnits = map[string]float64{
+	"":            1,
+	"ly":          1,
+	"light-year":  1,
+	"light-years": 1,
+	"light year":  1,
+	"light years": 1,
+	"lightyears":  1,
+	"kly":         1e3,
+	"mly":         1e6,
+	"pc":          lightYearsPerParsec,
+	"parsec":      lightYearsPerParsec,
+	"parsecs":     lightYearsPerParsec,
+	"kpc":         lightYearsPerParsec * 1e3,
+	"mpc":         lightYearsPerParsec * 1e6,
+}
+
+// ParseDistance reads a system's distance, such as "4.37 light-years", "1.3 pc" or
+// "~2.5 Mly", as light-years. A bare number is taken to be light-years.
+func ParseDistance(distance string) (float64, bool) {
+	text := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(distance), ",", ""))
+	match := distancePattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0, false
+	}
+	scale, known := distanceUnits[strings.TrimSuffix(strings.TrimSpace(match[2]), ".")]
+	if !known {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	return value * scale, true
+}
@@ -0,0 +1,32 @@
+package formats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseDistance(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"4.37 light-years", 4.37, true},
+		{"1,402 light years", 1402, true},
+		{"40.7 ly", 40.7, true},
+		{"12", 12, true},
+		{"~2.5 Mly", 2.5e6, true},
+		{"1.3 pc", 1.3 * lightYearsPerParsec, true},
+		{"8.2 kpc", 8.2 * lightYearsPerParsec * 1e3, true},
+		{"", 0, false},
+		{"unknown", 0, false},
+		{"3 furlongs", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseDistance(tt.in)
+		if ok != tt.ok || math.Abs(got-tt.want) > 1e-9*math.Max(1, tt.want) {
+			t.Errorf("ParseDistance(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
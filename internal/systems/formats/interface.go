@@ -2,6 +2,7 @@ package formats
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/furan917/go-solar-system/internal/models"
 )
@@ -14,6 +15,14 @@ type SystemData struct {
 	Distance      string                 `json:"distance"`
 	Galaxy        string                 `json:"galaxy"`
 	Bodies        []models.CelestialBody `json:"bodies"`
+
+	// Right ascension in degrees (optional); the galaxy map uses it to point the
+	// system the right way from the Sun
+	RightAscension *float64 `json:"rightAscension,omitempty"`
+
+	// Debris belts round the star (optional); the map draws only these for a
+	// system from a file
+	Belts []models.Belt `json:"belts,omitempty"`
 }
 
 // SystemMetadata represents just the metadata portion (without celestial bodies)
@@ -23,6 +32,8 @@ type SystemMetadata struct {
 	DiscoveryYear string `json:"discoveryYear"`
 	Distance      string `json:"distance"`
 	Galaxy        string `json:"galaxy"`
+
+	RightAscension *float64 `json:"rightAscension,omitempty"`
 }
 
 // FileFormat defines the interface that all file format handlers must implement
@@ -47,6 +58,41 @@ type FileFormat interface {
 	GetMimeType() string
 }
 
+// Encoder is implemented by formats that can write system data as well as read
+// it, which is what converting a file into them needs
+type Encoder interface {
+	EncodeSystemData(system *SystemData) ([]byte, error)
+}
+
+// MetadataWriter is implemented by formats that can rewrite the metadata of a
+// system file while leaving its bodies as they are
+type MetadataWriter interface {
+	// WriteSystemMetadata returns the file content with its metadata replaced
+	WriteSystemMetadata(data []byte, metadata *SystemMetadata) ([]byte, error)
+}
+
+// metadataKeys are the file keys of the metadata fields, in the order they are
+// written when missing
+var metadataKeys = []string{"systemName", "description", "discoveryYear", "distance", "galaxy", "rightAscension"}
+
+// metadataValues maps each metadata key to its new value. A nil value means the
+// key should be left out; an empty string only replaces a key already present.
+func metadataValues(metadata *SystemMetadata) map[string]interface{} {
+	values := map[string]interface{}{
+		"systemName":    metadata.SystemName,
+		"description":   metadata.Description,
+		"discoveryYear": metadata.DiscoveryYear,
+		"distance":      metadata.Distance,
+		"galaxy":        metadata.Galaxy,
+	}
+	if metadata.RightAscension != nil {
+		values["rightAscension"] = *metadata.RightAscension
+	} else {
+		values["rightAscension"] = nil
+	}
+	return values
+}
+
 // FormatRegistry manages all available file format handlers
 type FormatRegistry struct {
 	handlers map[string]FileFormat // extension -> handler mapping
@@ -62,6 +108,8 @@ func NewFormatRegistry() *FormatRegistry {
 
 	// Register built-in formats
 	registry.RegisterFormat(NewJSONFormat())
+	registry.RegisterFormat(NewTOMLFormat())
+	registry.RegisterFormat(NewBinaryFormat())
 
 	// Example: To add YAML support, uncomment the line below and ensure yaml.go has proper implementation
 	// registry.RegisterFormat(NewYAMLFormat())
@@ -116,3 +164,32 @@ func (fr *FormatRegistry) DetectFormat(data []byte) (FileFormat, error) {
 
 	return nil, fmt.Errorf("no supported format detected")
 }
+
+// validateSystemData validates the complete system data structure
+func validateSystemData(system *SystemData) error {
+	if strings.TrimSpace(system.SystemName) == "" {
+		return fmt.Errorf("systemName cannot be empty")
+	}
+
+	if len(system.Bodies) == 0 {
+		return fmt.Errorf("system must contain at least one celestial body")
+	}
+
+	// Validate each celestial body has required fields
+	for i, body := range system.Bodies {
+		if strings.TrimSpace(body.EnglishName) == "" {
+			return fmt.Errorf("celestial body at index %d missing englishName", i)
+		}
+	}
+
+	return nil
+}
+
+// validateSystemMetadata validates the system metadata structure
+func validateSystemMetadata(metadata *SystemMetadata) error {
+	if strings.TrimSpace(metadata.SystemName) == "" {
+		return fmt.Errorf("systemName cannot be empty")
+	}
+
+	return nil
+}
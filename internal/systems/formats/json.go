@@ -1,9 +1,10 @@
 package formats
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
 )
 
 // JSONFormat implements the FileFormat interface for JSON files
@@ -32,7 +33,7 @@ func (jf *JSONFormat) ParseSystemData(data []byte) (*SystemData, error) {
 	}
 
 	// Validate required fields
-	if err := jf.validateSystemData(&system); err != nil {
+	if err := validateSystemData(&system); err != nil {
 		return nil, fmt.Errorf("invalid system data: %w", err)
 	}
 
@@ -47,7 +48,7 @@ func (jf *JSONFormat) ParseSystemMetadata(data []byte) (*SystemMetadata, error)
 	}
 
 	// Validate required fields
-	if err := jf.validateSystemMetadata(&metadata); err != nil {
+	if err := validateSystemMetadata(&metadata); err != nil {
 		return nil, fmt.Errorf("invalid system metadata: %w", err)
 	}
 
@@ -78,36 +79,181 @@ func (jf *JSONFormat) ValidateFormat(data []byte) error {
 	return nil
 }
 
-// GetMimeType returns the MIME type for JSON
-func (jf *JSONFormat) GetMimeType() string {
-	return "application/json"
+// EncodeSystemData writes system data as indented JSON
+func (jf *JSONFormat) EncodeSystemData(system *SystemData) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(system); err != nil {
+		return nil, fmt.Errorf("failed to encode JSON system data: %w", err)
+	}
+	return buf.Bytes(), nil
 }
 
-// validateSystemData validates the complete system data structure
-func (jf *JSONFormat) validateSystemData(system *SystemData) error {
-	if strings.TrimSpace(system.SystemName) == "" {
-		return fmt.Errorf("systemName cannot be empty")
+// WriteSystemMetadata replaces the metadata keys of JSON content. Every other key,
+// the bodies included, keeps its value and its place; new metadata keys go before
+// the bodies.
+func (jf *JSONFormat) WriteSystemMetadata(data []byte, metadata *SystemMetadata) ([]byte, error) {
+	if err := validateSystemMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("invalid system metadata: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, fmt.Errorf("JSON data is not an object")
+	}
+	var keys []string
+	values := make(map[string]json.RawMessage)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON format: %w", err)
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("invalid JSON format: %w", err)
+		}
+		if _, exists := values[key]; !exists {
+			keys = append(keys, key)
+		}
+		values[key] = value
 	}
 
-	if len(system.Bodies) == 0 {
-		return fmt.Errorf("system must contain at least one celestial body")
+	updates := metadataValues(metadata)
+	for _, key := range metadataKeys {
+		_, exists := values[key]
+		value := updates[key]
+		switch {
+		case value == nil:
+			delete(values, key)
+			continue
+		case value == "" && !exists:
+			continue
+		case !exists:
+			keys = insertBefore(keys, key, "bodies")
+		}
+
+		var raw bytes.Buffer
+		encoder := json.NewEncoder(&raw)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(value); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+		values[key] = bytes.TrimRight(raw.Bytes(), "\n")
 	}
 
-	// Validate each celestial body has required fields
-	for i, body := range system.Bodies {
-		if strings.TrimSpace(body.EnglishName) == "" {
-			return fmt.Errorf("celestial body at index %d missing englishName", i)
+	var compact bytes.Buffer
+	compact.WriteByte('{')
+	written := 0
+	for _, key := range keys {
+		value, exists := values[key]
+		if !exists {
+			continue
+		}
+		if written > 0 {
+			compact.WriteByte(',')
 		}
+		encodedKey, _ := json.Marshal(key)
+		compact.Write(encodedKey)
+		compact.WriteByte(':')
+		compact.Write(value)
+		written++
 	}
+	compact.WriteByte('}')
 
-	return nil
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, compact.Bytes(), "", "  "); err != nil {
+		return nil, fmt.Errorf("failed to format JSON system data: %w", err)
+	}
+	indented.WriteByte('\n')
+	return indented.Bytes(), nil
 }
 
-// validateSystemMetadata validates the system metadata structure
-func (jf *JSONFormat) validateSystemMetadata(metadata *SystemMetadata) error {
-	if strings.TrimSpace(metadata.SystemName) == "" {
-		return fmt.Errorf("systemName cannot be empty")
+// insertBefore adds key to keys in front of before, or at the end without it
+func insertBefore(keys []string, key, before string) []string {
+	for i, existing := range keys {
+		if existing == before {
+			return append(keys[:i], append([]string{key}, keys[i:]...)...)
+		}
 	}
+	return append(keys, key)
+}
 
-	return nil
+// ValidateSystem runs deep validation on JSON content, placing each issue on its line
+func (jf *JSONFormat) ValidateSystem(data []byte) []Issue {
+	var tree map[string]interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		issue := Issue{Message: fmt.Sprintf("invalid JSON: %v", err)}
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			issue.Line = lineAtOffset(data, syntaxErr.Offset)
+		case errors.As(err, &typeErr):
+			issue.Line = lineAtOffset(data, typeErr.Offset)
+		}
+		return []Issue{issue}
+	}
+	return checkSystem(tree, jsonLines(data))
+}
+
+// jsonLines maps every key and list item in a JSON document to the line it is on
+func jsonLines(data []byte) map[string]int {
+	lines := make(map[string]int)
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if _, seen := lines[path]; !seen && path != "" {
+			lines[path] = lineAtOffset(data, dec.InputOffset())
+		}
+
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				key, _ := keyTok.(string)
+				child := joinPath(path, key)
+				lines[child] = lineAtOffset(data, dec.InputOffset())
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err := walk(fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+		return err
+	}
+
+	// A malformed document is reported by the parse; keep whatever lines were found
+	_ = walk("")
+	return lines
+}
+
+// lineAtOffset returns the 1-based line a byte offset falls on
+func lineAtOffset(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// GetMimeType returns the MIME type for JSON
+func (jf *JSONFormat) GetMimeType() string {
+	return "application/json"
 }
@@ -0,0 +1,92 @@
+package formats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// StreamProgress is how far a streamed parse has got
+type StreamProgress struct {
+	Bodies    int   // bodies parsed so far
+	BytesRead int64 // bytes of the file consumed so far
+}
+
+// StreamParser is implemented by formats that can parse a system body by body as
+// it is read, rather than from the whole file held in memory. Huge files are
+// loaded through it.
+type StreamParser interface {
+	// ParseSystemDataStream parses the complete system data from r. progress, if
+	// not nil, is called after each body; the parse stops with ctx's error once
+	// ctx is done.
+	ParseSystemDataStream(ctx context.Context, r io.Reader, progress func(StreamProgress)) (*SystemData, error)
+}
+
+// ParseSystemDataStream parses JSON system data from r, decoding one body at a
+// time so a large file never has to be read into memory whole
+func (jf *JSONFormat) ParseSystemDataStream(ctx context.Context, r io.Reader, progress func(StreamProgress)) (*SystemData, error) {
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, fmt.Errorf("failed to parse JSON system data: not an object")
+	}
+
+	// Metadata keys are gathered as they come and decoded together, so they are
+	// read exactly as ParseSystemData reads them whichever side of the bodies
+	// they are on
+	metadata := make(map[string]json.RawMessage)
+	var bodies []models.CelestialBody
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JSON system data: %w", err)
+		}
+		key, _ := token.(string)
+		if key != "bodies" {
+			var value json.RawMessage
+			if err := decoder.Decode(&value); err != nil {
+				return nil, fmt.Errorf("failed to parse JSON system data: %w", err)
+			}
+			metadata[key] = value
+			continue
+		}
+
+		if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+			return nil, fmt.Errorf("failed to parse JSON system data: bodies is not a list")
+		}
+		for decoder.More() {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			var body models.CelestialBody
+			if err := decoder.Decode(&body); err != nil {
+				return nil, fmt.Errorf("failed to parse JSON body %d: %w", len(bodies), err)
+			}
+			bodies = append(bodies, body)
+			if progress != nil {
+				progress(StreamProgress{Bodies: len(bodies), BytesRead: decoder.InputOffset()})
+			}
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON system data: %w", err)
+		}
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JSON system data: %w", err)
+	}
+	var system SystemData
+	if err := json.Unmarshal(encoded, &system); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON system data: %w", err)
+	}
+	system.Bodies = bodies
+
+	if err := validateSystemData(&system); err != nil {
+		return nil, fmt.Errorf("invalid system data: %w", err)
+	}
+
+	return &system, nil
+}
@@ -0,0 +1,67 @@
+package formats
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONStreamMatchesParse(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "..", "systems", "trappist-1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jf := NewJSONFormat()
+	want, err := jf.ParseSystemData(data)
+	if err != nil {
+		t.Fatalf("ParseSystemData() error = %v", err)
+	}
+
+	var seen []StreamProgress
+	got, err := jf.ParseSystemDataStream(context.Background(), strings.NewReader(string(data)), func(progress StreamProgress) {
+		seen = append(seen, progress)
+	})
+	if err != nil {
+		t.Fatalf("ParseSystemDataStream() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("streamed system = %+v, want %+v", got, want)
+	}
+
+	if len(seen) != len(want.Bodies) {
+		t.Fatalf("got %d progress calls, want one per body (%d)", len(seen), len(want.Bodies))
+	}
+	for i := 1; i < len(seen); i++ {
+		if seen[i].Bodies != i+1 || seen[i].BytesRead <= seen[i-1].BytesRead {
+			t.Errorf("progress %d = %+v after %+v, want it to count up", i, seen[i], seen[i-1])
+		}
+	}
+}
+
+func TestJSONStreamMetadataAfterBodies(t *testing.T) {
+	data := `{"bodies": [{"englishName": "Star", "bodyType": "Star"}], "systemName": "Late", "distance": "3 ly"}`
+	system, err := NewJSONFormat().ParseSystemDataStream(context.Background(), strings.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("ParseSystemDataStream() error = %v", err)
+	}
+	if system.SystemName != "Late" || system.Distance != "3 ly" || len(system.Bodies) != 1 {
+		t.Errorf("system = %+v, want the metadata after the bodies read", system)
+	}
+}
+
+func TestJSONStreamStopsWhenCanceled(t *testing.T) {
+	data := `{"systemName": "Rubble", "bodies": [{"englishName": "A"}, {"englishName": "B"}, {"englishName": "C"}]}`
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := NewJSONFormat().ParseSystemDataStream(ctx, strings.NewReader(data), func(progress StreamProgress) {
+		if progress.Bodies == 1 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseSystemDataStream() error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,328 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// TOMLFormat implements the FileFormat interface for TOML files. Keys use the same
+// names as the JSON format (systemName, englishName, semimajorAxis...), and bodies
+// are written as [[bodies]] tables, which is easier to hand-edit than nested JSON.
+type TOMLFormat struct{}
+
+// NewTOMLFormat creates a new TOML format handler
+func NewTOMLFormat() *TOMLFormat {
+	return &TOMLFormat{}
+}
+
+// GetSupportedExtensions returns the file extensions this handler supports
+func (tf *TOMLFormat) GetSupportedExtensions() []string {
+	return []string{".toml"}
+}
+
+// GetFormatName returns a human-readable name for this format
+func (tf *TOMLFormat) GetFormatName() string {
+	return "TOML"
+}
+
+// ParseSystemData parses the complete system data from TOML content
+func (tf *TOMLFormat) ParseSystemData(data []byte) (*SystemData, error) {
+	var system SystemData
+	if _, err := toml.Decode(string(data), &system); err != nil {
+		return nil, fmt.Errorf("failed to parse TOML system data: %w", err)
+	}
+	if err := embedTOMLMoons(data, &system); err != nil {
+		return nil, fmt.Errorf("failed to parse TOML moons: %w", err)
+	}
+
+	if err := validateSystemData(&system); err != nil {
+		return nil, fmt.Errorf("invalid system data: %w", err)
+	}
+
+	return &system, nil
+}
+
+// embedTOMLMoons fills in the bodies of moon tables that describe the moon rather
+// than only naming it. The TOML decoder reads a moon as its names alone, so those
+// tables are decoded again through JSON, which keeps the whole body.
+func embedTOMLMoons(data []byte, system *SystemData) error {
+	var tree struct {
+		Bodies []struct {
+			Moons []map[string]interface{} `toml:"moons"`
+		} `toml:"bodies"`
+	}
+	if _, err := toml.Decode(string(data), &tree); err != nil {
+		return err
+	}
+
+	for i, body := range tree.Bodies {
+		if i >= len(system.Bodies) {
+			break
+		}
+		for j, fields := range body.Moons {
+			if j >= len(system.Bodies[i].Moons) || !models.EmbedsBody(fields) {
+				continue
+			}
+			encoded, err := json.Marshal(fields)
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(encoded, &system.Bodies[i].Moons[j]); err != nil {
+				return fmt.Errorf("%s moon %d: %w", system.Bodies[i].EnglishName, j+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseSystemMetadata parses only the metadata from TOML content
+func (tf *TOMLFormat) ParseSystemMetadata(data []byte) (*SystemMetadata, error) {
+	var metadata SystemMetadata
+	if _, err := toml.Decode(string(data), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse TOML system metadata: %w", err)
+	}
+
+	if err := validateSystemMetadata(&metadata); err != nil {
+		return nil, fmt.Errorf("invalid system metadata: %w", err)
+	}
+
+	return &metadata, nil
+}
+
+// ValidateFormat performs basic validation to ensure the data is valid TOML system data
+func (tf *TOMLFormat) ValidateFormat(data []byte) error {
+	var system map[string]interface{}
+	if _, err := toml.Decode(string(data), &system); err != nil {
+		return fmt.Errorf("invalid TOML format: %w", err)
+	}
+
+	requiredFields := []string{"systemName", "bodies"}
+	for _, field := range requiredFields {
+		if _, exists := system[field]; !exists {
+			return fmt.Errorf("missing required field: %s", field)
+		}
+	}
+
+	return nil
+}
+
+// EncodeSystemData writes system data as TOML. It goes through JSON first so the
+// keys keep the JSON names the format shares, and empty values are left out.
+func (tf *TOMLFormat) EncodeSystemData(system *SystemData) ([]byte, error) {
+	data, err := json.Marshal(system)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode TOML system data: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var tree map[string]interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, fmt.Errorf("failed to encode TOML system data: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(tomlValue(tree)); err != nil {
+		return nil, fmt.Errorf("failed to encode TOML system data: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteSystemMetadata replaces the metadata keys at the top of TOML content line
+// by line, so comments, layout and every table after them stay as written. New
+// metadata keys go after the last top-level key.
+func (tf *TOMLFormat) WriteSystemMetadata(data []byte, metadata *SystemMetadata) ([]byte, error) {
+	if err := validateSystemMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("invalid system metadata: %w", err)
+	}
+
+	lines := strings.SplitAfter(string(data), "\n")
+	found := make(map[string]int)
+	insertAt := 0
+	for i, line := range lines {
+		if tomlArrayTable.MatchString(line) || tomlTable.MatchString(line) {
+			break
+		}
+		if match := tomlKey.FindStringSubmatch(line); match != nil {
+			found[match[1]] = i
+			insertAt = i + 1
+		}
+	}
+
+	updates := metadataValues(metadata)
+	var added []string
+	for _, key := range metadataKeys {
+		index, exists := found[key]
+		value := updates[key]
+		if exists {
+			_, rest, _ := strings.Cut(lines[index], "=")
+			if strings.Contains(rest, `"""`) || strings.Contains(rest, "'''") {
+				return nil, fmt.Errorf("%s is a multi-line string, which cannot be rewritten", key)
+			}
+		}
+		switch {
+		case value == nil && exists:
+			lines[index] = ""
+		case value == nil, value == "" && !exists:
+			// nothing to write
+		case exists:
+			lines[index] = key + " = " + tomlLiteral(value) + "\n"
+		default:
+			added = append(added, key+" = "+tomlLiteral(value)+"\n")
+		}
+	}
+	if len(added) > 0 && insertAt > 0 && !strings.HasSuffix(lines[insertAt-1], "\n") {
+		lines[insertAt-1] += "\n"
+	}
+
+	rewritten := strings.Join(lines[:insertAt], "") + strings.Join(added, "") + strings.Join(lines[insertAt:], "")
+	if _, err := tf.ParseSystemMetadata([]byte(rewritten)); err != nil {
+		return nil, err
+	}
+	return []byte(rewritten), nil
+}
+
+// tomlLiteral writes a metadata value as TOML. JSON's string escapes are all valid
+// in TOML basic strings, and a float keeps its point so it reads back as one.
+func tomlLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		_ = encoder.Encode(v)
+		return strings.TrimRight(buf.String(), "\n")
+	case float64:
+		text := strconv.FormatFloat(v, 'f', -1, 64)
+		if !strings.Contains(text, ".") {
+			text += ".0"
+		}
+		return text
+	}
+	return fmt.Sprint(value)
+}
+
+// tomlValue readies a decoded JSON value for TOML: whole numbers stay integers,
+// which fields such as massExponent need, and nulls, which TOML cannot hold, are
+// dropped along with zeros and empty values, which read back the same when missing
+func tomlValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			child = tomlValue(child)
+			if isEmptyValue(child) {
+				delete(v, key)
+			} else {
+				v[key] = child
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = tomlValue(child)
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return value
+}
+
+// isEmptyValue reports whether a value is null, zero or empty
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case int64:
+		return v == 0
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// ValidateSystem runs deep validation on TOML content, placing each issue on its line
+func (tf *TOMLFormat) ValidateSystem(data []byte) []Issue {
+	var tree map[string]interface{}
+	if _, err := toml.Decode(string(data), &tree); err != nil {
+		issue := Issue{Message: fmt.Sprintf("invalid TOML: %v", err)}
+		var parseErr toml.ParseError
+		if errors.As(err, &parseErr) {
+			issue.Line = parseErr.Position.Line
+			issue.Message = "invalid TOML: " + parseErr.Message
+		}
+		return []Issue{issue}
+	}
+	normalizeTree(tree)
+	return checkSystem(tree, tomlLines(string(data)))
+}
+
+var (
+	tomlArrayTable = regexp.MustCompile(`^\s*\[\[\s*([\w.-]+)\s*\]\]`)
+	tomlTable      = regexp.MustCompile(`^\s*\[\s*([\w.-]+)\s*\]`)
+	tomlKey        = regexp.MustCompile(`^\s*"?([\w.-]+)"?\s*=`)
+)
+
+// tomlLines maps the tables and keys of a TOML document to the line they are on.
+// It reads the text rather than the parsed data, which has no positions; each
+// [[bodies]] header starts the next item of the bodies list.
+func tomlLines(text string) map[string]int {
+	lines := make(map[string]int)
+	counts := make(map[string]int) // list path -> items seen so far
+
+	// resolve turns a dotted table name into a data path, stepping into the latest
+	// item of each list on the way
+	resolve := func(name string, newItem bool) string {
+		parts := strings.Split(name, ".")
+		path := ""
+		for i, part := range parts {
+			path = joinPath(path, part)
+			if i == len(parts)-1 && newItem {
+				index := counts[path]
+				counts[path]++
+				return fmt.Sprintf("%s[%d]", path, index)
+			}
+			if n, ok := counts[path]; ok {
+				path = fmt.Sprintf("%s[%d]", path, n-1)
+			}
+		}
+		return path
+	}
+
+	table := ""
+	for n, line := range strings.Split(text, "\n") {
+		switch {
+		case tomlArrayTable.MatchString(line):
+			table = resolve(tomlArrayTable.FindStringSubmatch(line)[1], true)
+			lines[table] = n + 1
+		case tomlTable.MatchString(line):
+			table = resolve(tomlTable.FindStringSubmatch(line)[1], false)
+			lines[table] = n + 1
+		case tomlKey.MatchString(line):
+			lines[joinPath(table, tomlKey.FindStringSubmatch(line)[1])] = n + 1
+		}
+	}
+	return lines
+}
+
+// GetMimeType returns the MIME type for TOML
+func (tf *TOMLFormat) GetMimeType() string {
+	return "application/toml"
+}
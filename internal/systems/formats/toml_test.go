@@ -0,0 +1,166 @@
+package formats
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleTOML = `
+systemName = "Test System"
+description = "A hand-written system"
+distance = "12 light years"
+
+[[bodies]]
+id = "star"
+englishName = "Test Star"
+bodyType = "Star"
+temperature = 5200.0
+
+  [bodies.mass]
+  massValue = 1.9
+  massExponent = 30
+
+[[bodies]]
+id = "b"
+englishName = "Test b"
+isPlanet = true
+semimajorAxis = 150000000.0
+sideralOrbit = 365.0
+displayColor = "crimson"
+symbol = "◆"
+
+  [bodies.orbitalElements]
+  semimajorAxis = 150000000.0
+  eccentricity = 0.02
+  epoch = 2025-01-01T00:00:00Z
+
+  [[bodies.moons]]
+  id = "b-i"
+  englishName = "Test b I"
+  meanRadius = 1200.0
+  semimajorAxis = 400000.0
+
+    [bodies.moons.mass]
+    massValue = 7.3
+    massExponent = 22
+
+  [[bodies.moons]]
+  englishName = "Named Only"
+`
+
+func TestTOMLParseSystemData(t *testing.T) {
+	system, err := NewTOMLFormat().ParseSystemData([]byte(sampleTOML))
+	if err != nil {
+		t.Fatalf("ParseSystemData() error = %v", err)
+	}
+
+	if system.SystemName != "Test System" || system.Distance != "12 light years" {
+		t.Errorf("metadata = %q / %q", system.SystemName, system.Distance)
+	}
+	if len(system.Bodies) != 2 {
+		t.Fatalf("got %d bodies, want 2", len(system.Bodies))
+	}
+
+	star, planet := system.Bodies[0], system.Bodies[1]
+	if star.BodyType != "Star" || star.Mass.MassValue != 1.9 || star.Mass.MassExponent != 30 {
+		t.Errorf("star = %+v", star)
+	}
+	if !planet.IsPlanet || planet.SemimajorAxis != 150000000 || planet.SideralOrbit != 365 {
+		t.Errorf("planet = %+v", planet)
+	}
+	if planet.DisplayColor != "crimson" || planet.Symbol != "◆" {
+		t.Errorf("display overrides = %q / %q", planet.DisplayColor, planet.Symbol)
+	}
+	if planet.OrbitalElements == nil || planet.OrbitalElements.Eccentricity != 0.02 {
+		t.Fatalf("orbital elements = %+v", planet.OrbitalElements)
+	}
+	if want := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC); !planet.OrbitalElements.Epoch.Equal(want) {
+		t.Errorf("epoch = %v, want %v", planet.OrbitalElements.Epoch, want)
+	}
+
+	if len(planet.Moons) != 2 {
+		t.Fatalf("got %d moons, want 2", len(planet.Moons))
+	}
+	described, named := planet.Moons[0], planet.Moons[1]
+	if described.EnglishName != "Test b I" || described.Body == nil {
+		t.Fatalf("described moon = %+v, want its body kept", described)
+	}
+	if described.Body.MeanRadius != 1200 || described.Body.SemimajorAxis != 400000 || described.Body.Mass.MassExponent != 22 {
+		t.Errorf("moon body = %+v", described.Body)
+	}
+	if named.EnglishName != "Named Only" || named.Body != nil {
+		t.Errorf("named moon = %+v, want only its name", named)
+	}
+}
+
+func TestTOMLParseSystemMetadata(t *testing.T) {
+	metadata, err := NewTOMLFormat().ParseSystemMetadata([]byte(sampleTOML))
+	if err != nil {
+		t.Fatalf("ParseSystemMetadata() error = %v", err)
+	}
+	if metadata.Description != "A hand-written system" {
+		t.Errorf("Description = %q", metadata.Description)
+	}
+}
+
+func TestTOMLValidation(t *testing.T) {
+	format := NewTOMLFormat()
+
+	if err := format.ValidateFormat([]byte(sampleTOML)); err != nil {
+		t.Errorf("ValidateFormat() error = %v", err)
+	}
+	if err := format.ValidateFormat([]byte(`{"systemName": "JSON", "bodies": []}`)); err == nil {
+		t.Error("ValidateFormat() should reject JSON")
+	}
+	if err := format.ValidateFormat([]byte(`systemName = "No bodies"`)); err == nil {
+		t.Error("ValidateFormat() should require bodies")
+	}
+	if _, err := format.ParseSystemData([]byte("systemName = \"Empty\"\nbodies = []\n")); err == nil {
+		t.Error("ParseSystemData() should reject a system without bodies")
+	}
+}
+
+func TestRegistryDetectsTOML(t *testing.T) {
+	registry := NewFormatRegistry()
+
+	if _, ok := registry.GetHandlerForExtension(".toml"); !ok {
+		t.Fatal("no handler registered for .toml")
+	}
+	format, err := registry.DetectFormat([]byte(sampleTOML))
+	if err != nil {
+		t.Fatalf("DetectFormat() error = %v", err)
+	}
+	if format.GetFormatName() != "TOML" {
+		t.Errorf("DetectFormat() = %s, want TOML", format.GetFormatName())
+	}
+}
+
+func TestTOMLWriteSystemMetadata(t *testing.T) {
+	format := NewTOMLFormat()
+	metadata, err := format.ParseSystemMetadata([]byte(sampleTOML))
+	if err != nil {
+		t.Fatalf("ParseSystemMetadata() error = %v", err)
+	}
+	metadata.Description = `Rewritten with "quotes"`
+	metadata.Galaxy = "Milky Way"
+
+	data, err := format.WriteSystemMetadata([]byte(sampleTOML), metadata)
+	if err != nil {
+		t.Fatalf("WriteSystemMetadata() error = %v", err)
+	}
+
+	rewritten, err := format.ParseSystemData(data)
+	if err != nil {
+		t.Fatalf("ParseSystemData() error = %v\n%s", err, data)
+	}
+	if rewritten.Description != metadata.Description || rewritten.Galaxy != "Milky Way" || rewritten.Distance != "12 light years" {
+		t.Errorf("metadata = %+v, want the new description and galaxy", rewritten)
+	}
+
+	// Everything from the first table on is left exactly as written
+	tables := sampleTOML[strings.Index(sampleTOML, "[[bodies]]"):]
+	if !strings.HasSuffix(string(data), tables) {
+		t.Errorf("bodies were rewritten:\n%s", data)
+	}
+}
@@ -0,0 +1,638 @@
+package formats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/visualization"
+)
+
+// Severity says whether an issue stops a system file from loading properly
+type Severity int
+
+const (
+	SeverityError   Severity = iota // the file is wrong and will load badly or not at all
+	SeverityWarning                 // the file loads, but a value looks unlikely
+)
+
+// Issue is one problem found by deep validation of a system file
+type Issue struct {
+	Severity Severity
+	Line     int    // 1-based line in the file, 0 if it could not be placed
+	Path     string // where in the data, e.g. bodies[2].meanRadius
+	Message  string
+}
+
+// String formats the issue as "line 12: bodies[2].meanRadius: must not be negative"
+func (i Issue) String() string {
+	var b strings.Builder
+	if i.Line > 0 {
+		fmt.Fprintf(&b, "line %d: ", i.Line)
+	}
+	if i.Severity == SeverityWarning {
+		b.WriteString("warning: ")
+	}
+	if i.Path != "" {
+		b.WriteString(i.Path + ": ")
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// Validator is implemented by formats that can check a file beyond parsing it and
+// say on which line each problem is
+type Validator interface {
+	ValidateSystem(data []byte) []Issue
+}
+
+// knownBodyTypes are the body types the API and the renderer understand
+var knownBodyTypes = map[string]bool{
+	"Star": true, "Planet": true, "Dwarf Planet": true, "Moon": true, "Asteroid": true, "Comet": true,
+}
+
+// Plausible ranges. Anything outside them is almost certainly a unit mistake.
+const (
+	maxRadiusKm      = 2e9   // a little over the largest known star
+	maxSemimajorKm   = 1e13  // about 70,000 AU
+	minStarTempK     = 500   // brown dwarf territory
+	maxStarTempK     = 1e5   // the hottest Wolf-Rayet stars
+	maxLuminosity    = 1e7   // solar luminosities, past the brightest known stars
+	minMetallicity   = -5.0  // [Fe/H] in dex, the most metal-poor stars known
+	maxMetallicity   = 1.0   // [Fe/H] in dex, well past the most metal-rich
+	minMassExponent  = 10    // small asteroids
+	maxMassExponent  = 33    // tens of solar masses
+	earliestEpochYr  = 1000  // before this an epoch is probably a typo
+	latestEpochYr    = 3000  // as is one after this
+	maxInclinationDg = 180.0 // either way round
+)
+
+// checkSystem runs every check on a decoded file. lines maps data paths to the line
+// they start on; lookups fall back to the nearest enclosing value.
+func checkSystem(tree map[string]interface{}, lines map[string]int) []Issue {
+	c := &checker{lines: lines}
+	c.check(tree)
+
+	sort.SliceStable(c.issues, func(a, b int) bool {
+		return c.issues[a].Line < c.issues[b].Line
+	})
+	return c.issues
+}
+
+type checker struct {
+	lines  map[string]int
+	issues []Issue
+}
+
+func (c *checker) add(severity Severity, path, format string, args ...interface{}) {
+	c.issues = append(c.issues, Issue{
+		Severity: severity,
+		Line:     lineFor(c.lines, path),
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *checker) errorf(path, format string, args ...interface{}) {
+	c.add(SeverityError, path, format, args...)
+}
+
+func (c *checker) warnf(path, format string, args ...interface{}) {
+	c.add(SeverityWarning, path, format, args...)
+}
+
+func (c *checker) check(tree map[string]interface{}) {
+	if name, _ := tree["systemName"].(string); strings.TrimSpace(name) == "" {
+		c.errorf("systemName", "is required")
+	}
+	for _, field := range []string{"description", "discoveryYear", "distance"} {
+		if _, ok := tree[field]; !ok {
+			c.warnf(field, "is missing; it is shown in the system list")
+		}
+	}
+	if distance, ok := tree["distance"].(string); ok {
+		if _, known := ParseDistance(distance); !known {
+			c.warnf("distance", "%q is not a distance such as \"4.37 light-years\" or \"1.3 pc\"; the galaxy map leaves the system out", distance)
+		}
+	}
+	if ra, ok := c.number(tree, "", "rightAscension"); ok && (ra < 0 || ra >= 360) {
+		c.errorf("rightAscension", "%g is not an angle from 0 up to 360 degrees", ra)
+	}
+	c.checkBelts(tree)
+
+	raw, ok := tree["bodies"]
+	if !ok {
+		c.errorf("bodies", "is required")
+		return
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		c.errorf("bodies", "must be a list of bodies")
+		return
+	}
+	if len(list) == 0 {
+		c.errorf("bodies", "must contain at least one body")
+		return
+	}
+
+	bodies := make([]map[string]interface{}, len(list))
+	names := make(map[string]int)
+	ids := make(map[string]int)
+	for i, item := range list {
+		path := fmt.Sprintf("bodies[%d]", i)
+		body, ok := item.(map[string]interface{})
+		if !ok {
+			c.errorf(path, "must be an object")
+			continue
+		}
+		bodies[i] = body
+
+		if name := stringField(body, "englishName"); name == "" {
+			c.errorf(path+".englishName", "is required")
+		} else if first, dup := names[name]; dup {
+			c.errorf(path+".englishName", "%q is already used by bodies[%d]", name, first)
+		} else {
+			names[name] = i
+		}
+
+		if id := stringField(body, "id"); id == "" {
+			c.warnf(path+".id", "is missing; moons and saved elements are matched by id")
+		} else if first, dup := ids[id]; dup {
+			c.errorf(path+".id", "%q is already used by bodies[%d]", id, first)
+		} else {
+			ids[id] = i
+		}
+
+		c.checkBody(path, body)
+	}
+
+	c.checkAliases(bodies)
+	c.checkReferences(bodies, names, ids)
+}
+
+// checkBelts checks the debris belts a system declares. A kind or symbol the map
+// cannot use is only a warning, as the belt is then drawn as usual.
+func (c *checker) checkBelts(tree map[string]interface{}) {
+	raw, ok := tree["belts"]
+	if !ok {
+		return
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		c.errorf("belts", "must be a list of belts")
+		return
+	}
+
+	names := make(map[string]int)
+	for i, item := range list {
+		path := fmt.Sprintf("belts[%d]", i)
+		belt, ok := item.(map[string]interface{})
+		if !ok {
+			c.errorf(path, "must be an object")
+			continue
+		}
+
+		if name := stringField(belt, "name"); name == "" {
+			c.errorf(path+".name", "is required")
+		} else if first, dup := names[strings.ToLower(name)]; dup {
+			c.warnf(path+".name", "%q is already used by belts[%d]", name, first)
+		} else {
+			names[strings.ToLower(name)] = i
+		}
+
+		inner, hasInner := c.number(belt, path, "innerRadius")
+		outer, hasOuter := c.number(belt, path, "outerRadius")
+		switch {
+		case !hasInner || inner <= 0:
+			c.errorf(path+".innerRadius", "must be a distance from the star in km, greater than 0")
+		case !hasOuter || outer <= inner:
+			c.errorf(path+".outerRadius", "must be a distance from the star in km, greater than innerRadius")
+		}
+		if density, ok := c.number(belt, path, "density"); ok && (density < 0 || density > 1) {
+			c.errorf(path+".density", "%g is not a share of the belt from 0 to 1", density)
+		}
+		if rings, ok := c.number(belt, path, "rings"); ok && (rings < 0 || rings != math.Trunc(rings)) {
+			c.errorf(path+".rings", "%g is not a whole number of rings", rings)
+		}
+		if kind := stringField(belt, "kind"); kind != "" && kind != string(models.BeltRocky) && kind != string(models.BeltIcy) {
+			c.warnf(path+".kind", "%q is not %q or %q; the belt is drawn as rocky", kind, models.BeltRocky, models.BeltIcy)
+		}
+		if raw, ok := belt["symbol"]; ok {
+			if symbol, isString := raw.(string); !isString {
+				c.errorf(path+".symbol", "must be a single character")
+			} else if _, valid := visualization.ParseSymbol(symbol); !valid {
+				c.warnf(path+".symbol", "%q is not a single printable character; the usual symbol is used", symbol)
+			}
+		}
+	}
+}
+
+// checkAliases checks the bodies' aliases, which must be names no other body
+// already goes by, or selecting by them would be ambiguous
+func (c *checker) checkAliases(bodies []map[string]interface{}) {
+	owners := make(map[string]int)
+	for i, body := range bodies {
+		for _, field := range []string{"englishName", "name", "alternativeName"} {
+			if name := strings.ToLower(stringField(body, field)); name != "" {
+				if _, taken := owners[name]; !taken {
+					owners[name] = i
+				}
+			}
+		}
+	}
+
+	for i, body := range bodies {
+		raw, ok := body["aliases"]
+		if !ok || raw == nil {
+			continue
+		}
+		path := fmt.Sprintf("bodies[%d].aliases", i)
+		list, ok := raw.([]interface{})
+		if !ok {
+			c.errorf(path, "must be a list of names")
+			continue
+		}
+		for j, item := range list {
+			alias, ok := item.(string)
+			if !ok || strings.TrimSpace(alias) == "" {
+				c.errorf(fmt.Sprintf("%s[%d]", path, j), "must be a name")
+				continue
+			}
+			key := strings.ToLower(strings.TrimSpace(alias))
+			if owner, taken := owners[key]; taken && owner != i {
+				c.warnf(fmt.Sprintf("%s[%d]", path, j), "%q is also a name of bodies[%d]; selecting by it finds the first", alias, owner)
+			} else if !taken {
+				owners[key] = i
+			}
+		}
+	}
+}
+
+// checkBody checks one body's own fields
+func (c *checker) checkBody(path string, body map[string]interface{}) {
+	bodyType := stringField(body, "bodyType")
+	isPlanet, _ := body["isPlanet"].(bool)
+
+	switch {
+	case bodyType == "":
+		c.warnf(path+".bodyType", "is missing; the body is treated as a planet only if isPlanet is true")
+	case !knownBodyTypes[bodyType]:
+		c.warnf(path+".bodyType", "%q is not a known type (Star, Planet, Dwarf Planet, Moon, Asteroid, Comet)", bodyType)
+	}
+
+	for _, field := range []string{"meanRadius", "equaRadius", "polarRadius", "density", "gravity", "escape", "sideralOrbit", "temperature", "age", "luminosity"} {
+		if value, ok := c.number(body, path, field); ok && value < 0 {
+			c.errorf(path+"."+field, "must not be negative")
+		}
+	}
+	if radius, ok := numberValue(body, "meanRadius"); ok && radius > maxRadiusKm {
+		c.warnf(path+".meanRadius", "%.0f km is larger than any known star; radii are in km", radius)
+	}
+
+	semimajor, hasSemimajor := c.number(body, path, "semimajorAxis")
+	if hasSemimajor && semimajor < 0 {
+		c.errorf(path+".semimajorAxis", "must not be negative")
+	} else if semimajor > maxSemimajorKm {
+		c.warnf(path+".semimajorAxis", "%.3g km is implausibly far; distances are in km", semimajor)
+	}
+	c.checkEccentricity(path, body)
+	c.checkInclination(path, body)
+
+	switch {
+	case bodyType == "Star":
+		if isPlanet {
+			c.errorf(path+".isPlanet", "must be false for a star")
+		}
+		if semimajor != 0 {
+			c.warnf(path+".semimajorAxis", "stars are placed by the renderer; this value is ignored")
+		}
+		if stringField(body, "stellarClass") == "" && stringField(body, "spectralType") == "" {
+			c.warnf(path+".stellarClass", "is missing; the star is drawn with the default symbol")
+		}
+		if luminosity, ok := numberValue(body, "luminosity"); ok && luminosity > maxLuminosity {
+			c.warnf(path+".luminosity", "%.3g is brighter than any known star; luminosity is in solar luminosities", luminosity)
+		}
+		if metallicity, ok := c.number(body, path, "metallicity"); ok && (metallicity < minMetallicity || metallicity > maxMetallicity) {
+			c.warnf(path+".metallicity", "%g is outside the range of known stars; metallicity is [Fe/H] in dex, 0 for the Sun", metallicity)
+		}
+		if temp, ok := numberValue(body, "temperature"); ok && temp > 0 && (temp < minStarTempK || temp > maxStarTempK) {
+			c.warnf(path+".temperature", "%.0f K is outside the range of known stars (%d-%.0f K)", temp, minStarTempK, maxStarTempK)
+		}
+	case isPlanet || bodyType == "Planet":
+		if bodyType == "Planet" && !isPlanet {
+			c.warnf(path+".isPlanet", "should be true for a body of type Planet")
+		}
+		if semimajor <= 0 {
+			c.errorf(path+".semimajorAxis", "must be greater than 0 for a planet")
+		}
+		if period, _ := numberValue(body, "sideralOrbit"); period == 0 {
+			c.warnf(path+".sideralOrbit", "is missing; the planet will not move")
+		}
+	}
+
+	c.checkMass(path, body)
+	c.checkOrbitalElements(path, body)
+	c.checkDisplay(path, body)
+}
+
+func (c *checker) checkEccentricity(path string, fields map[string]interface{}) {
+	if e, ok := c.number(fields, path, "eccentricity"); ok && (e < 0 || e >= 1) {
+		c.errorf(path+".eccentricity", "%g must be at least 0 and below 1 for a closed orbit", e)
+	}
+}
+
+func (c *checker) checkInclination(path string, fields map[string]interface{}) {
+	if i, ok := c.number(fields, path, "inclination"); ok && math.Abs(i) > maxInclinationDg {
+		c.errorf(path+".inclination", "%g is not an angle between -180 and 180 degrees", i)
+	}
+}
+
+// checkMass checks the massValue × 10^massExponent pair
+func (c *checker) checkMass(path string, body map[string]interface{}) {
+	raw, ok := body["mass"]
+	if !ok {
+		return
+	}
+	mass, ok := raw.(map[string]interface{})
+	if !ok {
+		c.errorf(path+".mass", "must be an object with massValue and massExponent")
+		return
+	}
+
+	path += ".mass"
+	if value, ok := c.number(mass, path, "massValue"); ok && value <= 0 {
+		c.errorf(path+".massValue", "must be greater than 0")
+	}
+	if exponent, ok := c.number(mass, path, "massExponent"); ok {
+		if exponent != math.Trunc(exponent) {
+			c.errorf(path+".massExponent", "must be a whole number")
+		} else if exponent < minMassExponent || exponent > maxMassExponent {
+			c.warnf(path+".massExponent", "10^%.0f kg is outside the range of known bodies (10^%d-10^%d)", exponent, minMassExponent, maxMassExponent)
+		}
+	}
+}
+
+// checkDisplay checks the displayColor and symbol overrides. An unknown one is only
+// a warning, as the body is then drawn as usual.
+func (c *checker) checkDisplay(path string, body map[string]interface{}) {
+	if raw, ok := body["displayColor"]; ok {
+		if name, isString := raw.(string); !isString {
+			c.errorf(path+".displayColor", "must be a color name or #rrggbb")
+		} else if _, known := visualization.ParseDisplayColor(name); !known {
+			c.warnf(path+".displayColor", "%q is not a known color name or #rrggbb; the usual color is used", name)
+		}
+	}
+	if raw, ok := body["symbol"]; ok {
+		if symbol, isString := raw.(string); !isString {
+			c.errorf(path+".symbol", "must be a single character")
+		} else if _, valid := visualization.ParseSymbol(symbol); !valid {
+			c.warnf(path+".symbol", "%q is not a single printable character; the usual symbol is used", symbol)
+		}
+	}
+}
+
+// checkOrbitalElements checks the Keplerian elements block, including its epoch
+func (c *checker) checkOrbitalElements(path string, body map[string]interface{}) {
+	raw, ok := body["orbitalElements"]
+	if !ok || raw == nil {
+		return
+	}
+	elements, ok := raw.(map[string]interface{})
+	if !ok {
+		c.errorf(path+".orbitalElements", "must be an object")
+		return
+	}
+
+	path += ".orbitalElements"
+	if a, ok := c.number(elements, path, "semimajorAxis"); !ok || a <= 0 {
+		c.errorf(path+".semimajorAxis", "must be greater than 0")
+	}
+	c.checkEccentricity(path, elements)
+	c.checkInclination(path, elements)
+	for _, field := range []string{"argumentOfPeriapsis", "longitudeOfAscendingNode", "meanAnomaly"} {
+		c.number(elements, path, field)
+	}
+
+	epochPath := path + ".epoch"
+	var epoch time.Time
+	switch value := elements["epoch"].(type) {
+	case nil:
+		c.errorf(epochPath, "is required; meanAnomaly is the position at this moment")
+		return
+	case time.Time:
+		epoch = value
+	case string:
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.errorf(epochPath, "%q is not an RFC 3339 time such as 2000-01-01T12:00:00Z", value)
+			return
+		}
+		epoch = parsed
+	default:
+		c.errorf(epochPath, "must be a time such as 2000-01-01T12:00:00Z")
+		return
+	}
+	if year := epoch.Year(); year < earliestEpochYr || year > latestEpochYr {
+		c.warnf(epochPath, "year %d is unlikely for an epoch", year)
+	}
+}
+
+// checkReferences checks that moons and parent planets point at bodies that exist
+// and agree with each other
+func (c *checker) checkReferences(bodies []map[string]interface{}, names, ids map[string]int) {
+	// resolve finds the body a reference points at, by id first, then by name. The
+	// API names a moon's planet by id in a "planet" field.
+	resolve := func(ref map[string]interface{}) (int, bool) {
+		for _, field := range []string{"id", "planet"} {
+			if index, ok := ids[stringField(ref, field)]; ok {
+				return index, true
+			}
+		}
+		if name := stringField(ref, "englishName"); name != "" {
+			index, ok := names[name]
+			return index, ok
+		}
+		return 0, false
+	}
+
+	for i, body := range bodies {
+		if body == nil {
+			continue
+		}
+		path := fmt.Sprintf("bodies[%d]", i)
+
+		if raw, ok := body["aroundPlanet"]; ok && raw != nil {
+			parent, ok := raw.(map[string]interface{})
+			switch {
+			case !ok:
+				c.errorf(path+".aroundPlanet", "must be an object naming the planet")
+			case refKey(parent) == "":
+				c.errorf(path+".aroundPlanet", "needs an id or englishName")
+			default:
+				if _, found := resolve(parent); !found {
+					c.errorf(path+".aroundPlanet", "orbits %s, which is not in this file", refName(parent))
+				}
+			}
+		}
+
+		raw, ok := body["moons"]
+		if !ok || raw == nil {
+			continue
+		}
+		moons, ok := raw.([]interface{})
+		if !ok {
+			c.errorf(path+".moons", "must be a list")
+			continue
+		}
+		seen := make(map[string]bool)
+		for j, item := range moons {
+			moonPath := fmt.Sprintf("%s.moons[%d]", path, j)
+			moon, ok := item.(map[string]interface{})
+			if !ok {
+				c.errorf(moonPath, "must be an object")
+				continue
+			}
+			name := refName(moon)
+			if refKey(moon) == "" {
+				c.errorf(moonPath, "needs an id or englishName")
+				continue
+			}
+			if seen[name] {
+				c.warnf(moonPath, "%s is listed twice", name)
+			}
+			seen[name] = true
+
+			// A moon described in place is checked like any body, and takes the
+			// place of a body of the same name elsewhere in the file
+			moonIndex, found := resolve(moon)
+			if models.EmbedsBody(moon) {
+				c.checkMoonBody(moonPath, moon)
+				if found {
+					c.warnf(moonPath, "%s is also bodies[%d]; this moon's own fields are shown for it", name, moonIndex)
+				}
+				continue
+			}
+
+			// A moon that is also a body in the file must say it orbits this one
+			if !found || bodies[moonIndex] == nil {
+				continue
+			}
+			parent, _ := bodies[moonIndex]["aroundPlanet"].(map[string]interface{})
+			if parentIndex, ok := resolve(parent); parent == nil || !ok || parentIndex != i {
+				c.errorf(moonPath, "%s is a body in this file, but its aroundPlanet does not name %s", name, refName(body))
+			}
+		}
+	}
+}
+
+// checkMoonBody checks a moon described in place under its planet. Its type
+// defaults to Moon there, so it only needs one to be something else.
+func (c *checker) checkMoonBody(path string, moon map[string]interface{}) {
+	if stringField(moon, "englishName") == "" {
+		c.errorf(path+".englishName", "is required for a moon described in place")
+	}
+	if _, ok := moon["bodyType"]; !ok {
+		withType := make(map[string]interface{}, len(moon)+1)
+		for key, value := range moon {
+			withType[key] = value
+		}
+		withType["bodyType"] = "Moon"
+		moon = withType
+	}
+	c.checkBody(path, moon)
+}
+
+// number reads a numeric field, reporting it if it is there but not a number
+func (c *checker) number(fields map[string]interface{}, path, field string) (float64, bool) {
+	value, ok := numberValue(fields, field)
+	if raw := fields[field]; !ok && raw != nil {
+		c.errorf(joinPath(path, field), "must be a number, not %v", raw)
+	}
+	return value, ok
+}
+
+// numberValue reads a numeric field whichever number type the decoder produced
+func numberValue(fields map[string]interface{}, field string) (float64, bool) {
+	switch value := fields[field].(type) {
+	case float64:
+		return value, true
+	case int64:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	}
+	return 0, false
+}
+
+// stringField reads a string field, or "" if it is missing or not a string
+func stringField(fields map[string]interface{}, field string) string {
+	value, _ := fields[field].(string)
+	return strings.TrimSpace(value)
+}
+
+// refKey returns whatever a reference names its body by, preferring the English
+// name, or "" if it names nothing
+func refKey(ref map[string]interface{}) string {
+	for _, field := range []string{"englishName", "id", "planet"} {
+		if name := stringField(ref, field); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// refName quotes a referenced body's name for messages
+func refName(ref map[string]interface{}) string {
+	return fmt.Sprintf("%q", refKey(ref))
+}
+
+// lineFor finds the line a path starts on, falling back to the nearest enclosing
+// value that has one
+func lineFor(lines map[string]int, path string) int {
+	for path != "" {
+		if line, ok := lines[path]; ok {
+			return line
+		}
+		cut := strings.LastIndexAny(path, ".[")
+		if cut < 0 {
+			break
+		}
+		path = path[:cut]
+	}
+	return 0
+}
+
+// joinPath appends a key to a data path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// normalizeTree turns the lists of tables some decoders produce into plain lists,
+// so the checks see the same shapes whatever the file format
+func normalizeTree(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = normalizeTree(child)
+		}
+		return v
+	case []map[string]interface{}:
+		list := make([]interface{}, len(v))
+		for i, child := range v {
+			list[i] = normalizeTree(child)
+		}
+		return list
+	case []interface{}:
+		for i, child := range v {
+			v[i] = normalizeTree(child)
+		}
+		return v
+	}
+	return value
+}
@@ -0,0 +1,312 @@
+package formats
+
+import (
+	"strings"
+	"testing"
+)
+
+const invalidJSON = `{
+  "systemName": "Broken",
+  "description": "d",
+  "discoveryYear": "2020",
+  "distance": "10 ly",
+  "bodies": [
+    {
+      "id": "star",
+      "englishName": "Star",
+      "bodyType": "Star",
+      "isPlanet": true,
+      "stellarClass": "G2V"
+    },
+    {
+      "id": "b",
+      "englishName": "Star",
+      "bodyType": "Planet",
+      "isPlanet": true,
+      "semimajorAxis": 150000000,
+      "sideralOrbit": 365,
+      "meanRadius": -1,
+      "moons": [{"id": "c"}],
+      "orbitalElements": {"semimajorAxis": 150000000, "epoch": "yesterday"}
+    },
+    {
+      "id": "c",
+      "englishName": "Moonlet",
+      "bodyType": "Moon",
+      "aroundPlanet": {"planet": "elsewhere"}
+    }
+  ]
+}`
+
+const describedMoonJSON = `{
+  "systemName": "Moons",
+  "description": "d",
+  "discoveryYear": "2020",
+  "distance": "10 ly",
+  "bodies": [
+    {"id": "star", "englishName": "Star", "bodyType": "Star", "stellarClass": "G2V"},
+    {
+      "id": "b",
+      "englishName": "b",
+      "bodyType": "Planet",
+      "isPlanet": true,
+      "semimajorAxis": 150000000,
+      "sideralOrbit": 365,
+      "moons": [
+        {"id": "b-i", "englishName": "b I", "meanRadius": -5, "semimajorAxis": 400000},
+        {"id": "b-ii", "semimajorAxis": 600000},
+        {"id": "b-iii", "englishName": "b III", "semimajorAxis": 900000, "sideralOrbit": 30}
+      ]
+    }
+  ]
+}`
+
+func TestValidateDescribedMoons(t *testing.T) {
+	issues := NewJSONFormat().ValidateSystem([]byte(describedMoonJSON))
+
+	if issue, ok := issueAt(issues, "bodies[1].moons[0].meanRadius"); !ok || !strings.Contains(issue.Message, "must not be negative") || issue.Line != 16 {
+		t.Errorf("negative moon radius reported as %+v (found %v)", issue, ok)
+	}
+	if _, ok := issueAt(issues, "bodies[1].moons[1].englishName"); !ok {
+		t.Errorf("moon without a name not reported; got %v", issues)
+	}
+	for _, issue := range issues {
+		if strings.HasPrefix(issue.Path, "bodies[1].moons[2]") {
+			t.Errorf("well-formed moon reported: %s", issue)
+		}
+		if strings.HasSuffix(issue.Path, ".bodyType") {
+			t.Errorf("moon type should default to Moon: %s", issue)
+		}
+	}
+}
+
+// issueAt finds the issue reported for a path
+func issueAt(issues []Issue, path string) (Issue, bool) {
+	for _, issue := range issues {
+		if issue.Path == path {
+			return issue, true
+		}
+	}
+	return Issue{}, false
+}
+
+func TestJSONValidateSystem(t *testing.T) {
+	issues := NewJSONFormat().ValidateSystem([]byte(invalidJSON))
+
+	tests := []struct {
+		path    string
+		line    int
+		message string
+	}{
+		{"bodies[0].isPlanet", 11, "must be false"},
+		{"bodies[1].englishName", 16, "already used by bodies[0]"},
+		{"bodies[1].meanRadius", 21, "must not be negative"},
+		{"bodies[1].moons[0]", 22, "aroundPlanet does not name"},
+		{"bodies[1].orbitalElements.epoch", 23, "RFC 3339"},
+		{"bodies[2].aroundPlanet", 29, "not in this file"},
+	}
+	for _, tt := range tests {
+		issue, ok := issueAt(issues, tt.path)
+		if !ok {
+			t.Errorf("no issue for %s; got %v", tt.path, issues)
+			continue
+		}
+		if issue.Severity != SeverityError || issue.Line != tt.line || !strings.Contains(issue.Message, tt.message) {
+			t.Errorf("%s = %+v, want an error on line %d containing %q", tt.path, issue, tt.line, tt.message)
+		}
+	}
+
+	for i := 1; i < len(issues); i++ {
+		if issues[i].Line < issues[i-1].Line {
+			t.Errorf("issues are not in file order: %v", issues)
+			break
+		}
+	}
+}
+
+func TestJSONValidateSystemSyntaxError(t *testing.T) {
+	issues := NewJSONFormat().ValidateSystem([]byte("{\n  \"systemName\": \"x\",\n  \"bodies\": [,]\n}"))
+	if len(issues) != 1 || issues[0].Line != 3 || issues[0].Severity != SeverityError {
+		t.Errorf("issues = %+v, want one error on line 3", issues)
+	}
+}
+
+func TestTOMLValidateSystem(t *testing.T) {
+	issues := NewTOMLFormat().ValidateSystem([]byte(sampleTOML + `
+[[bodies]]
+id = "c"
+englishName = "Test c"
+isPlanet = true
+semimajorAxis = 300000000.0
+sideralOrbit = 900.0
+eccentricity = 1.5
+
+  [bodies.mass]
+  massValue = -2.0
+  massExponent = 24
+`))
+
+	eccentricity, ok := issueAt(issues, "bodies[2].eccentricity")
+	if !ok || eccentricity.Line != 49 {
+		t.Errorf("eccentricity issue = %+v, want one on line 49", eccentricity)
+	}
+	mass, ok := issueAt(issues, "bodies[2].mass.massValue")
+	if !ok || mass.Line != 52 {
+		t.Errorf("mass issue = %+v, want one on line 52", mass)
+	}
+	for _, issue := range issues {
+		if issue.Severity == SeverityError && !strings.HasPrefix(issue.Path, "bodies[2]") {
+			t.Errorf("unexpected error in the valid part of the file: %v", issue)
+		}
+	}
+}
+
+func TestTOMLValidateSystemParseError(t *testing.T) {
+	issues := NewTOMLFormat().ValidateSystem([]byte("systemName = \"x\"\nbodies = [\n"))
+	if len(issues) != 1 || issues[0].Line != 2 {
+		t.Errorf("issues = %+v, want one error on line 2", issues)
+	}
+}
+
+func TestValidateSystemAcceptsGoodFile(t *testing.T) {
+	for _, issue := range NewTOMLFormat().ValidateSystem([]byte(sampleTOML)) {
+		if issue.Severity == SeverityError {
+			t.Errorf("unexpected error: %v", issue)
+		}
+	}
+}
+
+func TestValidateSystemDisplayOverrides(t *testing.T) {
+	issues := NewJSONFormat().ValidateSystem([]byte(`{
+  "systemName": "Painted",
+  "bodies": [
+    {"id": "a", "englishName": "A", "bodyType": "Planet", "isPlanet": true, "semimajorAxis": 1e8, "sideralOrbit": 10,
+     "displayColor": "crimson", "symbol": "◆"},
+    {"id": "b", "englishName": "B", "bodyType": "Planet", "isPlanet": true, "semimajorAxis": 2e8, "sideralOrbit": 20,
+     "displayColor": "not-a-colour", "symbol": "ab"},
+    {"id": "c", "englishName": "C", "bodyType": "Planet", "isPlanet": true, "semimajorAxis": 3e8, "sideralOrbit": 30,
+     "displayColor": 7}
+  ]
+}`))
+
+	for _, path := range []string{"bodies[0].displayColor", "bodies[0].symbol"} {
+		if issue, ok := issueAt(issues, path); ok {
+			t.Errorf("unexpected issue: %v", issue)
+		}
+	}
+	for _, path := range []string{"bodies[1].displayColor", "bodies[1].symbol"} {
+		if issue, ok := issueAt(issues, path); !ok || issue.Severity != SeverityWarning {
+			t.Errorf("%s = %+v, want a warning", path, issue)
+		}
+	}
+	if issue, ok := issueAt(issues, "bodies[2].displayColor"); !ok || issue.Severity != SeverityError {
+		t.Errorf("bodies[2].displayColor = %+v, want an error", issue)
+	}
+}
+
+func TestValidateSystemAliases(t *testing.T) {
+	issues := NewJSONFormat().ValidateSystem([]byte(`{
+  "systemName": "Named",
+  "bodies": [
+    {"id": "a", "englishName": "Aurora", "bodyType": "Planet", "isPlanet": true, "semimajorAxis": 1e8, "sideralOrbit": 10,
+     "aliases": ["Dawn", "KOI-1 b"]},
+    {"id": "b", "englishName": "Borealis", "bodyType": "Planet", "isPlanet": true, "semimajorAxis": 2e8, "sideralOrbit": 20,
+     "aliases": ["dawn", ""]},
+    {"id": "c", "englishName": "C", "bodyType": "Planet", "isPlanet": true, "semimajorAxis": 3e8, "sideralOrbit": 30,
+     "aliases": "Cee"}
+  ]
+}`))
+
+	for _, path := range []string{"bodies[0].aliases[0]", "bodies[0].aliases[1]"} {
+		if issue, ok := issueAt(issues, path); ok {
+			t.Errorf("unexpected issue: %v", issue)
+		}
+	}
+	if issue, ok := issueAt(issues, "bodies[1].aliases[0]"); !ok || issue.Severity != SeverityWarning || !strings.Contains(issue.Message, "bodies[0]") {
+		t.Errorf("bodies[1].aliases[0] = %+v, want a warning naming bodies[0]", issue)
+	}
+	for _, path := range []string{"bodies[1].aliases[1]", "bodies[2].aliases"} {
+		if issue, ok := issueAt(issues, path); !ok || issue.Severity != SeverityError {
+			t.Errorf("%s = %+v, want an error", path, issue)
+		}
+	}
+}
+
+func TestValidateSystemSkyPosition(t *testing.T) {
+	issues := NewJSONFormat().ValidateSystem([]byte(`{
+  "systemName": "Lost",
+  "distance": "somewhere near Vega",
+  "rightAscension": 400,
+  "bodies": [{"id": "s", "englishName": "S", "bodyType": "Star", "isPlanet": false, "semimajorAxis": 0}]
+}`))
+
+	if issue, ok := issueAt(issues, "distance"); !ok || issue.Severity != SeverityWarning {
+		t.Errorf("distance = %+v, want a warning", issue)
+	}
+	if issue, ok := issueAt(issues, "rightAscension"); !ok || issue.Severity != SeverityError {
+		t.Errorf("rightAscension = %+v, want an error", issue)
+	}
+}
+
+func TestLineForFallsBackToParent(t *testing.T) {
+	lines := map[string]int{"bodies[1]": 7}
+	if got := lineFor(lines, "bodies[1].mass.massValue"); got != 7 {
+		t.Errorf("lineFor() = %d, want 7", got)
+	}
+	if got := lineFor(lines, "systemName"); got != 0 {
+		t.Errorf("lineFor() = %d, want 0", got)
+	}
+}
+
+func TestValidateSystemStellarFields(t *testing.T) {
+	issues := NewJSONFormat().ValidateSystem([]byte(`{
+  "systemName": "Bright",
+  "bodies": [
+    {"id": "a", "englishName": "A", "bodyType": "Star", "spectralType": "G2V", "luminosity": 1.5, "metallicity": -0.2},
+    {"id": "b", "englishName": "B", "bodyType": "Star", "stellarClass": "B", "luminosity": 3.8e26, "metallicity": 12},
+    {"id": "c", "englishName": "C", "bodyType": "Star", "stellarClass": "M", "luminosity": -1, "metallicity": "solar"}
+  ]
+}`))
+
+	for _, path := range []string{"bodies[0].stellarClass", "bodies[0].luminosity", "bodies[0].metallicity"} {
+		if issue, ok := issueAt(issues, path); ok {
+			t.Errorf("unexpected issue: %v", issue)
+		}
+	}
+	for _, path := range []string{"bodies[1].luminosity", "bodies[1].metallicity"} {
+		if issue, ok := issueAt(issues, path); !ok || issue.Severity != SeverityWarning {
+			t.Errorf("%s = %+v, want a warning", path, issue)
+		}
+	}
+	for _, path := range []string{"bodies[2].luminosity", "bodies[2].metallicity"} {
+		if issue, ok := issueAt(issues, path); !ok || issue.Severity != SeverityError {
+			t.Errorf("%s = %+v, want an error", path, issue)
+		}
+	}
+}
+
+func TestValidateSystemBelts(t *testing.T) {
+	issues := NewJSONFormat().ValidateSystem([]byte(`{
+  "systemName": "Dusty",
+  "belts": [
+    {"name": "Inner belt", "innerRadius": 3e8, "outerRadius": 4e8, "kind": "metal", "symbol": "ab"},
+    {"name": "inner belt", "innerRadius": 5e8, "outerRadius": 4e8, "density": 2, "rings": 2.5},
+    {"innerRadius": 0, "outerRadius": 1e9, "symbol": 7}
+  ],
+  "bodies": [{"id": "s", "englishName": "S", "bodyType": "Star", "isPlanet": false, "semimajorAxis": 0}]
+}`))
+
+	for _, path := range []string{"belts[0].kind", "belts[0].symbol", "belts[1].name"} {
+		if issue, ok := issueAt(issues, path); !ok || issue.Severity != SeverityWarning {
+			t.Errorf("%s = %+v, want a warning", path, issue)
+		}
+	}
+	for _, path := range []string{"belts[1].outerRadius", "belts[1].density", "belts[1].rings", "belts[2].name", "belts[2].innerRadius", "belts[2].symbol"} {
+		if issue, ok := issueAt(issues, path); !ok || issue.Severity != SeverityError {
+			t.Errorf("%s = %+v, want an error", path, issue)
+		}
+	}
+	if _, ok := issueAt(issues, "belts[0].outerRadius"); ok {
+		t.Errorf("belts[0] has a good outer edge, got %+v", issues)
+	}
+}
@@ -0,0 +1,89 @@
+package systems
+
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+
+	"github.com/furan917/go-solar-system/internal/systems/formats"
+)
+
+// GalaxyEntry is a system placed on the galaxy map, relative to the Sun
+type GalaxyEntry struct {
+	System        string // name as returned by GetAvailableSystems
+	Name          string
+	Galaxy        string
+	Distance      string  // as written in the system file
+	DiscoveryYear string  // as written in the system file
+	LightYears    float64 // 0 for the Solar System
+
+	// X and Y are the position in light-years, seen from above the Earth's
+	// north pole: +X points towards right ascension 0h and +Y towards 6h
+	X, Y float64
+
+	Located   bool // the distance could be read, so the system can be plotted
+	Estimated bool // the file gives no sky position, so the direction is made up
+}
+
+// GalaxyMap places every available system around the Sun, nearest first. Systems
+// whose metadata cannot be read are left out.
+func (sm *SystemManager) GalaxyMap() []GalaxyEntry {
+	entries := []GalaxyEntry{{
+		System:  "solar-system",
+		Name:    "Solar System",
+		Galaxy:  "Milky Way",
+		Located: true,
+	}}
+
+	for _, system := range sm.GetAvailableSystems()[1:] {
+		metadata, err := sm.LoadSystemMetadata(system)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, PlaceSystem(system, metadata))
+	}
+
+	sort.SliceStable(entries, func(a, b int) bool {
+		if entries[a].Located != entries[b].Located {
+			return entries[a].Located
+		}
+		return entries[a].LightYears < entries[b].LightYears
+	})
+	return entries
+}
+
+// PlaceSystem works out where a system sits from its distance and, if the file
+// gives it, its right ascension. Without that the direction comes from a hash of
+// the name, so it stays put between runs.
+func PlaceSystem(system string, metadata *SystemData) GalaxyEntry {
+	entry := GalaxyEntry{
+		System:        system,
+		Name:          metadata.SystemName,
+		Galaxy:        metadata.Galaxy,
+		Distance:      metadata.Distance,
+		DiscoveryYear: metadata.DiscoveryYear,
+	}
+
+	lightYears, ok := formats.ParseDistance(metadata.Distance)
+	if !ok {
+		return entry
+	}
+	entry.LightYears = lightYears
+	entry.Located = true
+
+	var degrees float64
+	if metadata.RightAscension != nil {
+		degrees = *metadata.RightAscension
+	} else {
+		hash := fnv.New32a()
+		hash.Write([]byte(system))
+		degrees = float64(hash.Sum32() % 360)
+		entry.Estimated = true
+	}
+
+	// The map keeps the true distance; declination would only squash it
+	angle := degrees * math.Pi / 180
+	entry.X = lightYears * math.Cos(angle)
+	entry.Y = lightYears * math.Sin(angle)
+	return entry
+}
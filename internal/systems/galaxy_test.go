@@ -0,0 +1,41 @@
+package systems
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPlaceSystemUsesRightAscension(t *testing.T) {
+	ra := 90.0
+	entry := PlaceSystem("near", &SystemData{SystemName: "Near", Distance: "10 ly", RightAscension: &ra})
+
+	if !entry.Located || entry.Estimated {
+		t.Fatalf("entry = %+v, want located from its right ascension", entry)
+	}
+	if math.Abs(entry.X) > 1e-9 || math.Abs(entry.Y-10) > 1e-9 {
+		t.Errorf("position = (%v, %v), want (0, 10)", entry.X, entry.Y)
+	}
+}
+
+func TestPlaceSystemEstimatesDirection(t *testing.T) {
+	metadata := &SystemData{SystemName: "Guess", Distance: "2 pc"}
+	first := PlaceSystem("guess", metadata)
+	second := PlaceSystem("guess", metadata)
+
+	if !first.Located || !first.Estimated {
+		t.Fatalf("entry = %+v, want located with an estimated direction", first)
+	}
+	if first.X != second.X || first.Y != second.Y {
+		t.Errorf("direction changed between calls: %+v, %+v", first, second)
+	}
+	if got := math.Hypot(first.X, first.Y); math.Abs(got-first.LightYears) > 1e-9 {
+		t.Errorf("distance from the Sun = %v, want %v", got, first.LightYears)
+	}
+}
+
+func TestPlaceSystemUnreadableDistance(t *testing.T) {
+	entry := PlaceSystem("lost", &SystemData{SystemName: "Lost", Distance: "far away"})
+	if entry.Located {
+		t.Errorf("entry = %+v, want it left off the map", entry)
+	}
+}
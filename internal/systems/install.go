@@ -0,0 +1,78 @@
+package systems
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/systems/formats"
+)
+
+// SystemFileHash returns the hex SHA-256 of the file a system is read from, the
+// one in the systems directory or failing that the embedded one, and false when
+// there is no such system
+func (sm *SystemManager) SystemFileHash(systemName string) (string, bool) {
+	_, data, err := sm.readSystemFile(systemName)
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), true
+}
+
+// InstallSystemFile writes a system file into the systems directory under
+// fileName, replacing any file of that name, and offers the system from then on.
+// The file must be in a supported format and pass validation, so a bad download
+// never replaces a good file. It returns the system's name.
+func (sm *SystemManager) InstallSystemFile(fileName string, data []byte) (string, error) {
+	if fileName != filepath.Base(fileName) {
+		return "", fmt.Errorf("system file name %q must not contain a directory", fileName)
+	}
+	systemName := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	if err := validateSystemName(systemName); err != nil {
+		return "", fmt.Errorf("invalid system name %s: %w", systemName, err)
+	}
+	if _, supported := sm.formatRegistry.GetHandlerForExtension(strings.ToLower(filepath.Ext(fileName))); !supported {
+		return "", fmt.Errorf("%s is not a supported system file", fileName)
+	}
+
+	format, err := sm.formatFor(fileName, data)
+	if err != nil {
+		return "", err
+	}
+	if validator, ok := format.(formats.Validator); ok {
+		for _, issue := range validator.ValidateSystem(data) {
+			if issue.Severity == formats.SeverityError {
+				return "", fmt.Errorf("%s: %s", fileName, issue)
+			}
+		}
+	} else if _, err := format.ParseSystemData(data); err != nil {
+		return "", fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	if err := os.MkdirAll(sm.systemsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create systems directory: %w", err)
+	}
+	filePath := filepath.Join(sm.systemsDir, fileName)
+	if err := writeFileAtomic(filePath, data); err != nil {
+		return "", err
+	}
+
+	// A file of the same system in another format would shadow this one
+	if previous, exists := sm.availableSystems[systemName]; exists && previous != filePath {
+		if err := os.Remove(previous); err != nil {
+			return "", fmt.Errorf("installed %s, but could not remove the older %s: %w", filePath, previous, err)
+		}
+	}
+	sm.availableSystems[systemName] = filePath
+
+	delete(sm.loadedSystems, systemName)
+	delete(sm.loadedMoons, systemName)
+	delete(sm.cachedMetadata, systemName)
+	delete(sm.cachedSystemInfo, systemName)
+
+	return systemName, nil
+}
@@ -0,0 +1,44 @@
+package systems
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInstallSystemFile(t *testing.T) {
+	dir := t.TempDir()
+	manager := NewSystemManager(dir)
+
+	if _, ok := manager.SystemFileHash("converted"); ok {
+		t.Fatal("SystemFileHash() found a system that is not installed")
+	}
+	name, err := manager.InstallSystemFile("converted.json", []byte(convertSample))
+	if err != nil || name != "converted" {
+		t.Fatalf("InstallSystemFile() = %q, %v, want converted", name, err)
+	}
+	system, err := manager.LoadSystem("converted")
+	if err != nil || system.SystemName != "Converted" {
+		t.Fatalf("LoadSystem() = %+v, %v, want the installed system", system, err)
+	}
+	before, ok := manager.SystemFileHash("converted")
+	if !ok || len(before) != 64 {
+		t.Fatalf("SystemFileHash() = %q, %v, want a SHA-256", before, ok)
+	}
+
+	for _, bad := range []struct{ file, data string }{
+		{"converted.json", `{"systemName": "Broken", "bodies": [`},
+		{"../escape.json", convertSample},
+		{"notes.txt", convertSample},
+	} {
+		if _, err := manager.InstallSystemFile(bad.file, []byte(bad.data)); err == nil {
+			t.Errorf("InstallSystemFile(%q) accepted a bad file", bad.file)
+		}
+	}
+	if after, _ := manager.SystemFileHash("converted"); after != before {
+		t.Error("a rejected file replaced the installed one")
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "escape.json")); err == nil {
+		t.Error("a file was written outside the systems directory")
+	}
+}
@@ -1,6 +1,7 @@
 package systems
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"os"
@@ -8,18 +9,27 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/models"
 	"github.com/furan917/go-solar-system/internal/systems/formats"
 )
 
 // SystemData represents an external star system (now using interface-based loading)
 type SystemData = formats.SystemData
 
+// SystemMetadata is a system's description, distance and the like, without bodies
+type SystemMetadata = formats.SystemMetadata
+
 // SystemManager handles loading and switching between star systems
 type SystemManager struct {
 	systemsDir       string
 	availableSystems map[string]string
+	embedded         fs.FS             // system files compiled into the binary, if any
+	embeddedSystems  map[string]string // paths in embedded, by system name
 	currentSystem    string
 	loadedSystems    map[string]SystemData
+	loadedMoons      map[string]*moonTable
+	cachedMetadata   map[string]SystemData
 	cachedSystemInfo map[string]string
 	formatRegistry   *formats.FormatRegistry
 }
@@ -29,7 +39,10 @@ func NewSystemManager(systemsDir string) *SystemManager {
 	return &SystemManager{
 		systemsDir:       systemsDir,
 		availableSystems: make(map[string]string),
+		embeddedSystems:  make(map[string]string),
 		loadedSystems:    make(map[string]SystemData),
+		loadedMoons:      make(map[string]*moonTable),
+		cachedMetadata:   make(map[string]SystemData),
 		cachedSystemInfo: make(map[string]string),
 		currentSystem:    "solar-system",
 		formatRegistry:   formats.NewFormatRegistry(),
@@ -84,6 +97,64 @@ func (sm *SystemManager) ScanSystems() error {
 	return err
 }
 
+// embeddedPrefix marks the paths of embedded system files, which are not on disk
+const embeddedPrefix = "embedded:"
+
+// AddEmbeddedSystems offers the system files in fsys, such as ones compiled into
+// the binary, alongside those in the systems directory. A file in the directory
+// with the same name is used instead of the embedded one.
+func (sm *SystemManager) AddEmbeddedSystems(fsys fs.FS) error {
+	sm.embedded = fsys
+	return fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		ext := strings.ToLower(filepath.Ext(path))
+		if _, supported := sm.formatRegistry.GetHandlerForExtension(ext); supported {
+			systemName := strings.TrimSuffix(d.Name(), filepath.Ext(d.Name()))
+			if err := validateSystemName(systemName); err != nil {
+				return fmt.Errorf("invalid system name %s: %w", systemName, err)
+			}
+			sm.embeddedSystems[systemName] = path
+		}
+		return nil
+	})
+}
+
+// IsEmbedded reports whether a system comes from an embedded file rather than one
+// in the systems directory
+func (sm *SystemManager) IsEmbedded(systemName string) bool {
+	_, onDisk := sm.availableSystems[systemName]
+	_, embedded := sm.embeddedSystems[systemName]
+	return embedded && !onDisk
+}
+
+// readSystemFile returns the contents of a system's file with the path it is known
+// by: the file in the systems directory, or failing that the embedded one
+func (sm *SystemManager) readSystemFile(systemName string) (string, []byte, error) {
+	if filePath, exists := sm.availableSystems[systemName]; exists {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read system file %s: %w", filePath, err)
+		}
+		return filePath, data, nil
+	}
+
+	if filePath, exists := sm.embeddedSystems[systemName]; exists {
+		data, err := fs.ReadFile(sm.embedded, filePath)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read embedded system file %s: %w", filePath, err)
+		}
+		return embeddedPrefix + filePath, data, nil
+	}
+
+	return "", nil, fmt.Errorf("system '%s' not found", systemName)
+}
+
 // GetAvailableSystems returns a list of available system names in alphabetical order
 func (sm *SystemManager) GetAvailableSystems() []string {
 	systems := []string{"solar-system"}
@@ -92,6 +163,11 @@ func (sm *SystemManager) GetAvailableSystems() []string {
 	for name := range sm.availableSystems {
 		externalSystems = append(externalSystems, name)
 	}
+	for name := range sm.embeddedSystems {
+		if _, onDisk := sm.availableSystems[name]; !onDisk {
+			externalSystems = append(externalSystems, name)
+		}
+	}
 	sort.Strings(externalSystems)
 
 	systems = append(systems, externalSystems...)
@@ -110,18 +186,51 @@ func (sm *SystemManager) LoadSystem(systemName string) (*SystemData, error) {
 		return &system, nil
 	}
 
+	system, err := sm.ReadSystem(context.Background(), systemName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return sm.KeepSystem(systemName, system), nil
+}
+
+// Belts returns the debris belts drawn round a system's star: the Solar System's
+// own, or the ones its file declares, which may be none
+func (sm *SystemManager) Belts(systemName string) []models.Belt {
+	if systemName == "solar-system" {
+		return models.SolarSystemBelts()
+	}
+	system, err := sm.LoadSystem(systemName)
+	if err != nil {
+		return nil
+	}
+	return system.Belts
+}
+
+// ReadSystem reads a system's file without keeping it, so that a huge one can be
+// read away from the goroutine using the manager and handed to KeepSystem there.
+// Files of constants.StreamSystemFileSize or more in a format that can stream are
+// parsed as they are read: progress, if not nil, hears after each body, and the
+// read stops with ctx's error once ctx is done.
+func (sm *SystemManager) ReadSystem(ctx context.Context, systemName string, progress func(formats.StreamProgress)) (*SystemData, error) {
 	if systemName == "solar-system" {
 		return nil, fmt.Errorf("solar system should be loaded via API")
 	}
 
 	filePath, exists := sm.availableSystems[systemName]
-	if !exists {
-		return nil, fmt.Errorf("system '%s' not found", systemName)
+	if exists && sm.FileSize(systemName) >= constants.StreamSystemFileSize {
+		handler, exists := sm.formatRegistry.GetHandlerForExtension(strings.ToLower(filepath.Ext(filePath)))
+		if streamer, ok := handler.(formats.StreamParser); exists && ok {
+			system, err := streamSystemFile(ctx, filePath, streamer, progress)
+			if err != nil {
+				return nil, err
+			}
+			return withSource(system, filePath), nil
+		}
 	}
 
-	data, err := os.ReadFile(filePath)
+	filePath, data, err := sm.readSystemFile(systemName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read system file %s: %w", filePath, err)
+		return nil, err
 	}
 
 	// Detect format and get appropriate handler
@@ -137,11 +246,96 @@ func (sm *SystemManager) LoadSystem(systemName string) (*SystemData, error) {
 		return nil, fmt.Errorf("failed to parse system file %s: %w", filePath, err)
 	}
 
-	system := *systemData
+	return withSource(systemData, filePath), nil
+}
 
-	sm.loadedSystems[systemName] = system
+// streamSystemFile parses a system file with a format that streams
+func streamSystemFile(ctx context.Context, filePath string, streamer formats.StreamParser, progress func(formats.StreamProgress)) (*SystemData, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read system file %s: %w", filePath, err)
+	}
+	defer file.Close()
 
-	return &system, nil
+	system, err := streamer.ParseSystemDataStream(ctx, file, progress)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to parse system file %s: %w", filePath, err)
+	}
+	return system, nil
+}
+
+// withSource cites filePath as the source of every body in system
+func withSource(system *SystemData, filePath string) *SystemData {
+	for i := range system.Bodies {
+		system.Bodies[i].SetSource(models.SourceSystemFile, filePath)
+	}
+	return system
+}
+
+// KeepSystem keeps a system read by ReadSystem as loaded, gathering its moons, and
+// returns the copy kept
+func (sm *SystemManager) KeepSystem(systemName string, system *SystemData) *SystemData {
+	kept := *system
+	sm.loadedSystems[systemName] = kept
+	sm.loadedMoons[systemName] = hydrateMoons(kept.Bodies, sm.systemPath(systemName))
+	return &kept
+}
+
+// systemPath returns the path a system's file is known by, as readSystemFile does
+func (sm *SystemManager) systemPath(systemName string) string {
+	if filePath, exists := sm.availableSystems[systemName]; exists {
+		return filePath
+	}
+	if filePath, exists := sm.embeddedSystems[systemName]; exists {
+		return embeddedPrefix + filePath
+	}
+	return ""
+}
+
+// IsLarge reports whether a system not loaded yet has a file in the systems
+// directory of constants.StreamSystemFileSize or more, which is worth reading
+// away from the UI
+func (sm *SystemManager) IsLarge(systemName string) bool {
+	_, loaded := sm.loadedSystems[systemName]
+	return !loaded && sm.FileSize(systemName) >= constants.StreamSystemFileSize
+}
+
+// FileSize returns the size of a system's file in the systems directory, or 0
+// for one that is embedded or cannot be read
+func (sm *SystemManager) FileSize(systemName string) int64 {
+	filePath, exists := sm.availableSystems[systemName]
+	if !exists {
+		return 0
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// Moons returns every moon of a system file: those its planets describe in place
+// and its top-level moons. The system is loaded if it is not yet.
+func (sm *SystemManager) Moons(systemName string) ([]models.CelestialBody, error) {
+	if _, err := sm.LoadSystem(systemName); err != nil {
+		return nil, err
+	}
+	return sm.loadedMoons[systemName].moons, nil
+}
+
+// FindMoon looks up a moon of a loaded system file by its id or name
+func (sm *SystemManager) FindMoon(systemName string, ref models.Moon) (models.CelestialBody, bool) {
+	table, ok := sm.loadedMoons[systemName]
+	if !ok {
+		return models.CelestialBody{}, false
+	}
+	if moon, ok := table.find(ref.ID, ref.EnglishName); ok {
+		return moon, true
+	}
+	return table.find("", ref.Name)
 }
 
 // SwitchToSystem switches to a different star system
@@ -171,13 +365,18 @@ func (sm *SystemManager) GetSystemData() (*SystemData, error) {
 
 // GetCurrentSystemDisplayName returns the current system name with galaxy
 func (sm *SystemManager) GetCurrentSystemDisplayName() string {
-	if sm.currentSystem == "solar-system" {
+	return sm.GetSystemDisplayName(sm.currentSystem)
+}
+
+// GetSystemDisplayName returns a system's name with galaxy
+func (sm *SystemManager) GetSystemDisplayName(systemName string) string {
+	if systemName == "solar-system" {
 		return "Solar System, Milky Way"
 	}
 
-	metadata, err := sm.LoadSystemMetadata(sm.currentSystem)
+	metadata, err := sm.LoadSystemMetadata(systemName)
 	if err != nil {
-		return sm.currentSystem
+		return systemName
 	}
 
 	if metadata.Galaxy != "" {
@@ -211,16 +410,21 @@ func (sm *SystemManager) GetSystemInfo(systemName string) (string, error) {
 	return info, nil
 }
 
-// LoadSystemMetadata loads only the metadata (not celestial bodies) for performance
+// LoadSystemMetadata loads only the metadata (not celestial bodies) for performance.
+// The header and status bar ask for it every frame, so it is read from the file once
+// and taken from the loaded system when there is one; catalogue systems run to
+// hundreds of bodies.
 func (sm *SystemManager) LoadSystemMetadata(systemName string) (*SystemData, error) {
-	filePath, exists := sm.availableSystems[systemName]
-	if !exists {
-		return nil, fmt.Errorf("system '%s' not found", systemName)
+	if system, exists := sm.loadedSystems[systemName]; exists {
+		return metadataOf(system), nil
+	}
+	if metadata, exists := sm.cachedMetadata[systemName]; exists {
+		return &metadata, nil
 	}
 
-	data, err := os.ReadFile(filePath)
+	filePath, data, err := sm.readSystemFile(systemName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read system file %s: %w", filePath, err)
+		return nil, err
 	}
 
 	// Detect format and get appropriate handler
@@ -236,14 +440,24 @@ func (sm *SystemManager) LoadSystemMetadata(systemName string) (*SystemData, err
 		return nil, fmt.Errorf("failed to parse system metadata %s: %w", filePath, err)
 	}
 
-	return &SystemData{
-		SystemName:    metadata.SystemName,
-		Description:   metadata.Description,
-		DiscoveryYear: metadata.DiscoveryYear,
-		Distance:      metadata.Distance,
-		Galaxy:        metadata.Galaxy,
-		Bodies:        nil,
-	}, nil
+	system := SystemData{
+		SystemName:     metadata.SystemName,
+		Description:    metadata.Description,
+		DiscoveryYear:  metadata.DiscoveryYear,
+		Distance:       metadata.Distance,
+		Galaxy:         metadata.Galaxy,
+		RightAscension: metadata.RightAscension,
+		Bodies:         nil,
+	}
+	sm.cachedMetadata[systemName] = system
+
+	return &system, nil
+}
+
+// metadataOf returns a copy of a system without its bodies
+func metadataOf(system SystemData) *SystemData {
+	system.Bodies = nil
+	return &system
 }
 
 // ListSystemsWithInfo returns a formatted list of all available systems with descriptions
@@ -304,20 +518,60 @@ func (sm *SystemManager) GetSupportedFormats() []string {
 	return f
 }
 
-// ValidateSystemFile validates a system file using format detection
+// ValidateSystemFile validates a system file using format detection. It fails on
+// the first error CheckSystemFile finds; warnings are let through.
 func (sm *SystemManager) ValidateSystemFile(filePath string) error {
+	issues, err := sm.CheckSystemFile(filePath)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if issue.Severity == formats.SeverityError {
+			return fmt.Errorf("%s: %s", filePath, issue)
+		}
+	}
+	return nil
+}
+
+// CheckSystemFile runs deep validation on a system file: required fields, plausible
+// values, duplicate names, moon references and epochs. Issues are in file order and
+// carry line numbers where the format can provide them. The error is only for a
+// file that cannot be read or whose format is not recognised.
+func (sm *SystemManager) CheckSystemFile(filePath string) ([]formats.Issue, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to read file %s: %w", filePath, err)
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	handler, err := sm.formatFor(filePath, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if validator, ok := handler.(formats.Validator); ok {
+		return validator.ValidateSystem(data), nil
+	}
+
+	// Formats without deep validation still get their own parse checks
+	if err := handler.ValidateFormat(data); err != nil {
+		return []formats.Issue{{Message: err.Error()}}, nil
+	}
+	if _, err := handler.ParseSystemData(data); err != nil {
+		return []formats.Issue{{Message: err.Error()}}, nil
 	}
+	return nil, nil
+}
 
-	// Try extension-based detection first
+// formatFor finds the format of a system file by its extension, falling back to
+// its content
+func (sm *SystemManager) formatFor(filePath string, data []byte) (formats.FileFormat, error) {
 	ext := strings.ToLower(filepath.Ext(filePath))
 	if handler, exists := sm.formatRegistry.GetHandlerForExtension(ext); exists {
-		return handler.ValidateFormat(data)
+		return handler, nil
 	}
-
-	// Fall back to content-based detection
-	_, err = sm.formatRegistry.DetectFormat(data)
-	return err
+	handler, err := sm.formatRegistry.DetectFormat(data)
+	if err != nil {
+		return nil, fmt.Errorf("unrecognised system file %s: %w", filePath, err)
+	}
+	return handler, nil
 }
@@ -0,0 +1,124 @@
+package systems
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestBundledSystemsValidate(t *testing.T) {
+	manager := NewSystemManager(filepath.Join("..", "..", "systems"))
+	if err := manager.ScanSystems(); err != nil {
+		t.Fatalf("ScanSystems() error = %v", err)
+	}
+
+	for _, name := range []string{"main-belt", "kuiper-belt", "centaurs", "kepler-90", "proxima-centauri"} {
+		path, ok := manager.availableSystems[name]
+		if !ok {
+			t.Errorf("bundled catalogue %s not found", name)
+			continue
+		}
+		issues, err := manager.CheckSystemFile(path)
+		if err != nil {
+			t.Fatalf("CheckSystemFile(%s) error = %v", path, err)
+		}
+		for _, issue := range issues {
+			t.Errorf("%s: %s", name, issue)
+		}
+	}
+}
+
+func TestLargeSystemMetadataIsCached(t *testing.T) {
+	bodies := []map[string]interface{}{{"id": "star", "englishName": "Star", "bodyType": "Star"}}
+	for i := 1; i <= 250; i++ {
+		bodies = append(bodies, map[string]interface{}{
+			"id":            fmt.Sprintf("rock-%d", i),
+			"englishName":   fmt.Sprintf("Rock %d", i),
+			"bodyType":      "Asteroid",
+			"semimajorAxis": 3e8 + float64(i)*1e6,
+		})
+	}
+	data, err := json.Marshal(map[string]interface{}{"systemName": "Rubble", "distance": "0 ly", "bodies": bodies})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rubble.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewSystemManager(dir)
+	if err := manager.ScanSystems(); err != nil {
+		t.Fatalf("ScanSystems() error = %v", err)
+	}
+	system, err := manager.LoadSystem("rubble")
+	if err != nil {
+		t.Fatalf("LoadSystem() error = %v", err)
+	}
+	if len(system.Bodies) != 251 {
+		t.Errorf("loaded %d bodies, want 251", len(system.Bodies))
+	}
+
+	// Once loaded, the metadata no longer needs the file
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	metadata, err := manager.LoadSystemMetadata("rubble")
+	if err != nil {
+		t.Fatalf("LoadSystemMetadata() error = %v", err)
+	}
+	if metadata.SystemName != "Rubble" || metadata.Bodies != nil {
+		t.Errorf("metadata = %+v, want the system name without bodies", metadata)
+	}
+}
+
+func TestEmbeddedSystems(t *testing.T) {
+	embedded := fstest.MapFS{
+		"kepler-90.json": {Data: []byte(`{"systemName": "Kepler-90", "distance": "2545 ly", "bodies": [{"id": "kepler-90", "englishName": "Kepler-90", "bodyType": "Star"}]}`)},
+		"tiny.json":      {Data: []byte(`{"systemName": "Embedded Tiny", "distance": "1 ly", "bodies": [{"id": "star", "englishName": "Star", "bodyType": "Star"}]}`)},
+		"notes.txt":      {Data: []byte("not a system")},
+	}
+
+	dir := t.TempDir()
+	onDisk := `{"systemName": "Disk Tiny", "distance": "1 ly", "bodies": [{"id": "star", "englishName": "Star", "bodyType": "Star"}]}`
+	if err := os.WriteFile(filepath.Join(dir, "tiny.json"), []byte(onDisk), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewSystemManager(dir)
+	if err := manager.AddEmbeddedSystems(embedded); err != nil {
+		t.Fatalf("AddEmbeddedSystems() error = %v", err)
+	}
+	if err := manager.ScanSystems(); err != nil {
+		t.Fatalf("ScanSystems() error = %v", err)
+	}
+
+	if got, want := fmt.Sprint(manager.GetAvailableSystems()), "[solar-system kepler-90 tiny]"; got != want {
+		t.Errorf("GetAvailableSystems() = %s, want %s", got, want)
+	}
+
+	system, err := manager.LoadSystem("kepler-90")
+	if err != nil {
+		t.Fatalf("LoadSystem(kepler-90) error = %v", err)
+	}
+	if source := system.Bodies[0].Provenance.Citation; source != "embedded:kepler-90.json" {
+		t.Errorf("Expected the embedded file cited, got %q", source)
+	}
+	if _, err := manager.WriteSystemMetadata("kepler-90", SystemMetadata{SystemName: "Renamed"}); err == nil {
+		t.Error("Expected writing an embedded system to fail")
+	}
+
+	// The file in the systems directory wins over the embedded one
+	if !manager.IsEmbedded("kepler-90") || manager.IsEmbedded("tiny") {
+		t.Error("Expected only kepler-90 to come from the embedded files")
+	}
+	if info, err := manager.GetSystemInfo("tiny"); err != nil || !strings.HasPrefix(info, "Disk Tiny") {
+		t.Errorf("GetSystemInfo(tiny) = %q, %v; want the file on disk", info, err)
+	}
+}
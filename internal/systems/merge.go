@@ -0,0 +1,281 @@
+package systems
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/systems/formats"
+)
+
+// MergePrecedence picks which file wins when two give the same field of a body, or
+// of the system, different values
+type MergePrecedence int
+
+const (
+	// PreferFirst keeps the values of the file given first
+	PreferFirst MergePrecedence = iota
+	// PreferLast keeps the values of the file given last, to lay a newer set of
+	// bodies over an older one
+	PreferLast
+)
+
+// ParseMergePrecedence reads "first" or "last"
+func ParseMergePrecedence(name string) (MergePrecedence, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "first":
+		return PreferFirst, nil
+	case "last":
+		return PreferLast, nil
+	}
+	return PreferFirst, fmt.Errorf("unknown precedence %q (want first or last)", name)
+}
+
+// maxConflictValue is how much of a value a conflict shows
+const maxConflictValue = 40
+
+// MergeSource is a system to merge and the file it was read from
+type MergeSource struct {
+	Path   string
+	System *formats.SystemData
+}
+
+// MergeConflict is a field two files give different values, and which was kept
+type MergeConflict struct {
+	Body  string // the body's name; empty for the system's own fields
+	Field string // the field's key in the file
+
+	Kept, Dropped         string // the values, as they would be written in JSON
+	KeptFrom, DroppedFrom string // the files they came from
+}
+
+// String describes the conflict in one line
+func (c MergeConflict) String() string {
+	field := c.Field
+	if c.Body != "" {
+		field = c.Body + ": " + c.Field
+	}
+	return fmt.Sprintf("%s: kept %s from %s over %s from %s", field, c.Kept, c.KeptFrom, c.Dropped, c.DroppedFrom)
+}
+
+// MergeReport says what a merge did
+type MergeReport struct {
+	Bodies     int // in the merged system
+	Duplicates int // bodies found in more than one place and merged into one
+	Conflicts  []MergeConflict
+}
+
+// mergedBody is a body of the merged system as it stands, field by field, with
+// the file each field came from
+type mergedBody struct {
+	fields  map[string]interface{}
+	origins map[string]string
+	body    models.CelestialBody
+}
+
+// MergeSystems combines sources into one system. Bodies with the same id, or a
+// name in common, are taken to be the same body and merged field by field: a
+// field only one of them gives is kept, and where both give one, prefer picks
+// the file that wins and the difference is reported as a conflict. The system's
+// own fields, and belts of the same name, are merged the same way. Bodies and
+// belts come out in the order they were first found.
+func MergeSystems(sources []MergeSource, prefer MergePrecedence) (*formats.SystemData, MergeReport, error) {
+	var report MergeReport
+	if len(sources) == 0 {
+		return nil, report, fmt.Errorf("no systems to merge")
+	}
+
+	metadata := &mergedBody{fields: map[string]interface{}{}, origins: map[string]string{}}
+	var bodies []*mergedBody
+	var belts []*mergedBody
+	beltIndex := map[string]*mergedBody{}
+	for _, source := range sources {
+		system := source.System
+		fields, err := jsonFields(formats.SystemMetadata{
+			SystemName:     system.SystemName,
+			Description:    system.Description,
+			DiscoveryYear:  system.DiscoveryYear,
+			Distance:       system.Distance,
+			Galaxy:         system.Galaxy,
+			RightAscension: system.RightAscension,
+		})
+		if err != nil {
+			return nil, report, err
+		}
+		report.Conflicts = append(report.Conflicts, metadata.merge("", fields, source.Path, prefer)...)
+
+		for _, body := range system.Bodies {
+			fields, err := jsonFields(body)
+			if err != nil {
+				return nil, report, fmt.Errorf("failed to merge %s from %s: %w", body.EnglishName, source.Path, err)
+			}
+			existing := findMergedBody(bodies, body)
+			if existing == nil {
+				existing = &mergedBody{fields: map[string]interface{}{}, origins: map[string]string{}}
+				bodies = append(bodies, existing)
+			} else {
+				report.Duplicates++
+			}
+			report.Conflicts = append(report.Conflicts, existing.merge(existing.body.EnglishName, fields, source.Path, prefer)...)
+			if err := fromJSONFields(existing.fields, &existing.body); err != nil {
+				return nil, report, fmt.Errorf("failed to merge %s from %s: %w", body.EnglishName, source.Path, err)
+			}
+		}
+
+		for _, belt := range system.Belts {
+			fields, err := jsonFields(belt)
+			if err != nil {
+				return nil, report, fmt.Errorf("failed to merge %s from %s: %w", belt.Name, source.Path, err)
+			}
+			key := strings.ToLower(belt.Name)
+			existing, ok := beltIndex[key]
+			if !ok {
+				existing = &mergedBody{fields: map[string]interface{}{}, origins: map[string]string{}}
+				beltIndex[key] = existing
+				belts = append(belts, existing)
+			}
+			name := belt.Name
+			if kept, ok := existing.fields["name"].(string); ok {
+				name = kept
+			}
+			report.Conflicts = append(report.Conflicts, existing.merge(name, fields, source.Path, prefer)...)
+		}
+	}
+
+	var merged formats.SystemMetadata
+	if err := fromJSONFields(metadata.fields, &merged); err != nil {
+		return nil, report, err
+	}
+	system := &formats.SystemData{
+		SystemName:     merged.SystemName,
+		Description:    merged.Description,
+		DiscoveryYear:  merged.DiscoveryYear,
+		Distance:       merged.Distance,
+		Galaxy:         merged.Galaxy,
+		RightAscension: merged.RightAscension,
+	}
+	for _, body := range bodies {
+		system.Bodies = append(system.Bodies, body.body)
+	}
+	for _, belt := range belts {
+		var merged models.Belt
+		if err := fromJSONFields(belt.fields, &merged); err != nil {
+			return nil, report, err
+		}
+		system.Belts = append(system.Belts, merged)
+	}
+	report.Bodies = len(system.Bodies)
+	return system, report, nil
+}
+
+// findMergedBody returns the body merged so far that body is another copy of:
+// one with the same id, or a name in common
+func findMergedBody(bodies []*mergedBody, body models.CelestialBody) *mergedBody {
+	for _, merged := range bodies {
+		if body.ID != "" && strings.EqualFold(merged.body.ID, body.ID) {
+			return merged
+		}
+		for _, name := range body.Names() {
+			if merged.body.MatchesName(name) {
+				return merged
+			}
+		}
+	}
+	return nil
+}
+
+// merge lays the fields of another copy, read from path, over the merged ones,
+// returning the fields the two disagree on
+func (m *mergedBody) merge(name string, fields map[string]interface{}, path string, prefer MergePrecedence) []MergeConflict {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var conflicts []MergeConflict
+	for _, key := range keys {
+		value := fields[key]
+		if emptyJSONValue(value) {
+			continue
+		}
+		current, ok := m.fields[key]
+		if !ok || emptyJSONValue(current) {
+			m.fields[key], m.origins[key] = value, path
+			continue
+		}
+		if reflect.DeepEqual(current, value) {
+			continue
+		}
+
+		conflict := MergeConflict{Body: name, Field: key,
+			Kept: conflictValue(current), KeptFrom: m.origins[key],
+			Dropped: conflictValue(value), DroppedFrom: path}
+		if prefer == PreferLast {
+			m.fields[key], m.origins[key] = value, path
+			conflict.Kept, conflict.KeptFrom, conflict.Dropped, conflict.DroppedFrom = conflict.Dropped, conflict.DroppedFrom, conflict.Kept, conflict.KeptFrom
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+// jsonFields returns value's fields by their keys in a system file
+func jsonFields(value interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	return fields, json.Unmarshal(data, &fields)
+}
+
+// fromJSONFields fills value from fields keyed as in a system file
+func fromJSONFields(fields map[string]interface{}, value interface{}) error {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
+
+// emptyJSONValue reports whether a decoded value says nothing: a file leaving a
+// field out writes it as zero, empty or null
+func emptyJSONValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		for _, field := range v {
+			if !emptyJSONValue(field) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// conflictValue writes a value for a conflict, cut short if long
+func conflictValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	text := string(data)
+	if len(text) > maxConflictValue {
+		text = text[:maxConflictValue-3] + "..."
+	}
+	return text
+}
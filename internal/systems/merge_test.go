@@ -0,0 +1,127 @@
+package systems
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/systems/formats"
+)
+
+func mergeSources() []MergeSource {
+	return []MergeSource{
+		{Path: "a.json", System: &formats.SystemData{
+			SystemName: "Kepler-11",
+			Distance:   "2000 ly",
+			Bodies: []models.CelestialBody{
+				{ID: "kepler-11", EnglishName: "Kepler-11", BodyType: "Star"},
+				{ID: "b", EnglishName: "Kepler-11 b", IsPlanet: true, SemimajorAxis: 13600000, MeanRadius: 12500},
+			},
+		}},
+		{Path: "b.json", System: &formats.SystemData{
+			SystemName:  "Kepler-11",
+			Description: "Six tightly packed planets",
+			Distance:    "2110 ly",
+			Bodies: []models.CelestialBody{
+				{EnglishName: "Kepler-11 b", IsPlanet: true, SemimajorAxis: 13600000, MeanRadius: 11300, SideralOrbit: 10.3},
+				{ID: "c", EnglishName: "Kepler-11 c", Aliases: []string{"KOI-157.01"}, IsPlanet: true},
+			},
+		}},
+		{Path: "c.json", System: &formats.SystemData{
+			Bodies: []models.CelestialBody{
+				{EnglishName: "KOI-157.01", SemimajorAxis: 15900000},
+			},
+		}},
+	}
+}
+
+func TestMergeSystems(t *testing.T) {
+	system, report, err := MergeSystems(mergeSources(), PreferFirst)
+	if err != nil {
+		t.Fatalf("MergeSystems() error = %v", err)
+	}
+
+	if len(system.Bodies) != 3 || report.Bodies != 3 || report.Duplicates != 2 {
+		t.Fatalf("merged %d bodies (report %+v), want the star, b and c", len(system.Bodies), report)
+	}
+	b := system.Bodies[1]
+	if b.ID != "b" || b.MeanRadius != 12500 || b.SideralOrbit != 10.3 {
+		t.Errorf("b = %+v, want a's radius and id with b's period filled in", b)
+	}
+	c := system.Bodies[2]
+	if c.EnglishName != "Kepler-11 c" || c.SemimajorAxis != 15900000 {
+		t.Errorf("c = %+v, want the body found by its alias given c.json's orbit", c)
+	}
+	if system.Description != "Six tightly packed planets" || system.Distance != "2000 ly" {
+		t.Errorf("metadata = %q, %q, want the description filled in and a's distance", system.Description, system.Distance)
+	}
+
+	if len(report.Conflicts) != 3 {
+		t.Fatalf("conflicts = %v, want the distance, b's radius and c's name", report.Conflicts)
+	}
+	distance, radius, name := report.Conflicts[0], report.Conflicts[1], report.Conflicts[2]
+	if distance.Body != "" || distance.Field != "distance" || distance.Kept != `"2000 ly"` || distance.DroppedFrom != "b.json" {
+		t.Errorf("distance conflict = %+v", distance)
+	}
+	if got := radius.String(); got != "Kepler-11 b: meanRadius: kept 12500 from a.json over 11300 from b.json" {
+		t.Errorf("radius conflict = %q", got)
+	}
+	if name.Field != "englishName" || name.Dropped != `"KOI-157.01"` || name.DroppedFrom != "c.json" {
+		t.Errorf("name conflict = %+v", name)
+	}
+}
+
+func TestMergeSystemsPreferLast(t *testing.T) {
+	system, report, err := MergeSystems(mergeSources(), PreferLast)
+	if err != nil {
+		t.Fatalf("MergeSystems() error = %v", err)
+	}
+	if system.Bodies[1].MeanRadius != 11300 || system.Distance != "2110 ly" {
+		t.Errorf("got radius %v and distance %q, want b.json's", system.Bodies[1].MeanRadius, system.Distance)
+	}
+	for _, conflict := range report.Conflicts {
+		if conflict.KeptFrom < conflict.DroppedFrom {
+			t.Errorf("conflict %v, want the later file's value kept", conflict)
+		}
+	}
+}
+
+func TestParseMergePrecedence(t *testing.T) {
+	for name, want := range map[string]MergePrecedence{"": PreferFirst, "first": PreferFirst, "LAST": PreferLast} {
+		if got, err := ParseMergePrecedence(name); err != nil || got != want {
+			t.Errorf("ParseMergePrecedence(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseMergePrecedence("newest"); err == nil || !strings.Contains(err.Error(), "newest") {
+		t.Errorf("ParseMergePrecedence(newest) error = %v", err)
+	}
+}
+
+func TestMergeSystemsBelts(t *testing.T) {
+	sources := []MergeSource{
+		{Path: "a.json", System: &formats.SystemData{
+			Belts: []models.Belt{{Name: "Inner belt", InnerRadius: 3e8, OuterRadius: 4e8}},
+		}},
+		{Path: "b.json", System: &formats.SystemData{
+			Belts: []models.Belt{
+				{Name: "inner belt", InnerRadius: 3e8, OuterRadius: 4.5e8, Kind: models.BeltRocky},
+				{Name: "Outer belt", InnerRadius: 5e9, OuterRadius: 7e9, Kind: models.BeltIcy},
+			},
+		}},
+	}
+
+	system, report, err := MergeSystems(sources, PreferFirst)
+	if err != nil {
+		t.Fatalf("MergeSystems() error = %v", err)
+	}
+	if len(system.Belts) != 2 || system.Belts[1].Name != "Outer belt" {
+		t.Fatalf("belts = %+v, want the inner belt merged and the outer one added", system.Belts)
+	}
+	inner := system.Belts[0]
+	if inner.Name != "Inner belt" || inner.OuterRadius != 4e8 || inner.Kind != models.BeltRocky {
+		t.Errorf("inner belt = %+v, want a's name and edge with b's kind filled in", inner)
+	}
+	if len(report.Conflicts) != 2 || report.Conflicts[0].Body != "Inner belt" {
+		t.Errorf("conflicts = %v, want the inner belt's name and outer edge", report.Conflicts)
+	}
+}
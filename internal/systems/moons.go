@@ -0,0 +1,136 @@
+package systems
+
+import (
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// moonTable is every moon of a loaded system file, found by id or by name
+type moonTable struct {
+	moons []models.CelestialBody
+	index map[string]int
+}
+
+// add puts a moon in the table unless one of its keys is already there, and
+// returns the moon the table holds for it
+func (t *moonTable) add(moon models.CelestialBody) models.CelestialBody {
+	if existing, ok := t.find(moon.ID, moon.EnglishName); ok {
+		return existing
+	}
+	t.moons = append(t.moons, moon)
+	for _, key := range moonKeys(moon.ID, moon.EnglishName) {
+		t.index[key] = len(t.moons) - 1
+	}
+	return moon
+}
+
+// find returns the moon with the given id or name
+func (t *moonTable) find(id, name string) (models.CelestialBody, bool) {
+	for _, key := range moonKeys(id, name) {
+		if i, ok := t.index[key]; ok {
+			return t.moons[i], true
+		}
+	}
+	return models.CelestialBody{}, false
+}
+
+// moonKeys are the table keys of a moon: its id, and its name ignoring case
+func moonKeys(id, name string) []string {
+	var keys []string
+	if id != "" {
+		keys = append(keys, "id:"+id)
+	}
+	if name != "" {
+		keys = append(keys, "name:"+strings.ToLower(name))
+	}
+	return keys
+}
+
+// hydrateMoons gathers the moons of a system file into a table and fills in each
+// planet's moon list from it, so moon details need nothing from the API. Moons
+// come from entries under a planet that describe the moon in place, and from
+// top-level bodies of type Moon or with an aroundPlanet; a planet gains a moon
+// entry for each top-level body that orbits it but that it does not list.
+func hydrateMoons(bodies []models.CelestialBody, filePath string) *moonTable {
+	table := &moonTable{index: make(map[string]int)}
+
+	for i := range bodies {
+		planet := &bodies[i]
+		for j := range planet.Moons {
+			ref := &planet.Moons[j]
+			if ref.Body == nil {
+				continue
+			}
+			moon := *ref.Body
+			moon.ID = firstNonEmpty(moon.ID, ref.ID)
+			moon.EnglishName = firstNonEmpty(moon.EnglishName, ref.EnglishName, ref.Name, moon.Name)
+			moon.BodyType = firstNonEmpty(moon.BodyType, "Moon")
+			if moon.AroundPlanet == nil {
+				moon.AroundPlanet = &models.Planet{ID: planet.ID, EnglishName: planet.EnglishName}
+			}
+			moon.SetSource(models.SourceSystemFile, filePath)
+
+			moon = table.add(moon)
+			ref.ID = firstNonEmpty(ref.ID, moon.ID)
+			ref.EnglishName = firstNonEmpty(ref.EnglishName, moon.EnglishName)
+			ref.Body = &moon
+		}
+	}
+
+	for _, body := range bodies {
+		if body.AroundPlanet == nil && !strings.EqualFold(body.BodyType, "Moon") {
+			continue
+		}
+		moon := table.add(body)
+
+		parent := parentOf(bodies, body)
+		if parent == nil {
+			continue
+		}
+		listed := false
+		for j := range parent.Moons {
+			ref := &parent.Moons[j]
+			if (ref.ID != "" && ref.ID == moon.ID) || strings.EqualFold(ref.EnglishName, moon.EnglishName) {
+				if ref.Body == nil {
+					ref.Body = &moon
+				}
+				ref.EnglishName = firstNonEmpty(ref.EnglishName, moon.EnglishName)
+				listed = true
+			}
+		}
+		if !listed {
+			parent.Moons = append(parent.Moons, models.Moon{ID: moon.ID, EnglishName: moon.EnglishName, Body: &moon})
+		}
+	}
+
+	return table
+}
+
+// parentOf returns the body moon orbits, by the id or name in its aroundPlanet
+func parentOf(bodies []models.CelestialBody, moon models.CelestialBody) *models.CelestialBody {
+	around := moon.AroundPlanet
+	if around == nil {
+		return nil
+	}
+	for i := range bodies {
+		body := &bodies[i]
+		switch {
+		case body.ID != "" && (body.ID == around.ID || body.ID == around.Planet):
+			return body
+		case around.EnglishName != "" && strings.EqualFold(body.EnglishName, around.EnglishName):
+			return body
+		}
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first of values that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package systems
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+const moonsJSON = `{
+  "systemName": "Moony",
+  "bodies": [
+    {"id": "star", "englishName": "Star", "bodyType": "Star"},
+    {
+      "id": "b",
+      "englishName": "Moony b",
+      "bodyType": "Planet",
+      "isPlanet": true,
+      "semimajorAxis": 150000000,
+      "moons": [
+        {"id": "b-i", "englishName": "Moony b I", "meanRadius": 1200, "semimajorAxis": 400000, "sideralOrbit": 20},
+        {"id": "b-ii"}
+      ]
+    },
+    {"id": "b-ii", "englishName": "Moony b II", "bodyType": "Moon", "aroundPlanet": {"planet": "b"}, "semimajorAxis": 700000},
+    {"id": "b-iii", "englishName": "Moony b III", "bodyType": "Moon", "aroundPlanet": {"englishName": "Moony b"}}
+  ]
+}`
+
+func loadMoonySystem(t *testing.T) *SystemManager {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "moony.json"), []byte(moonsJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manager := NewSystemManager(dir)
+	if err := manager.ScanSystems(); err != nil {
+		t.Fatalf("ScanSystems() error = %v", err)
+	}
+	return manager
+}
+
+func TestLoadSystemHydratesMoons(t *testing.T) {
+	manager := loadMoonySystem(t)
+	system, err := manager.LoadSystem("moony")
+	if err != nil {
+		t.Fatalf("LoadSystem() error = %v", err)
+	}
+
+	planet := system.Bodies[1]
+	if len(planet.Moons) != 3 {
+		t.Fatalf("planet lists %d moons, want the described one, the named one and the one that only names it", len(planet.Moons))
+	}
+	for _, moon := range planet.Moons {
+		if moon.Body == nil || moon.EnglishName == "" {
+			t.Errorf("moon %+v has no body or name", moon)
+		}
+	}
+
+	described := planet.Moons[0].Body
+	if described.BodyType != "Moon" || described.AroundPlanet == nil || described.AroundPlanet.ID != "b" {
+		t.Errorf("described moon = %+v, want type Moon around b", described)
+	}
+	if described.Provenance.Source != models.SourceSystemFile {
+		t.Errorf("described moon source = %v, want the system file", described.Provenance.Source)
+	}
+	if planet.Moons[1].EnglishName != "Moony b II" || planet.Moons[1].Body.SemimajorAxis != 700000 {
+		t.Errorf("named moon = %+v, want it filled in from its body", planet.Moons[1])
+	}
+	if planet.Moons[2].ID != "b-iii" {
+		t.Errorf("moon added for b III = %+v", planet.Moons[2])
+	}
+
+	moons, err := manager.Moons("moony")
+	if err != nil || len(moons) != 3 {
+		t.Errorf("Moons() = %d moons, %v; want 3", len(moons), err)
+	}
+}
+
+func TestFindMoon(t *testing.T) {
+	manager := loadMoonySystem(t)
+	if _, err := manager.LoadSystem("moony"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ref := range []models.Moon{{ID: "b-i"}, {EnglishName: "moony b i"}, {Name: "Moony b III"}} {
+		if _, ok := manager.FindMoon("moony", ref); !ok {
+			t.Errorf("FindMoon(%+v) found nothing", ref)
+		}
+	}
+	if moon, ok := manager.FindMoon("moony", models.Moon{ID: "b-i"}); !ok || moon.MeanRadius != 1200 {
+		t.Errorf("FindMoon(b-i) = %+v", moon)
+	}
+	if _, ok := manager.FindMoon("moony", models.Moon{ID: "nope"}); ok {
+		t.Error("FindMoon found a moon that is not in the file")
+	}
+}
@@ -0,0 +1,177 @@
+package systems
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/systems/formats"
+)
+
+const (
+	// SharePrefix starts every share code, naming the encoding so a later one can
+	// be told apart
+	SharePrefix = "gss1:"
+
+	// MaxShareLength is the longest share code made, about what a chat message
+	// takes; bigger systems are better shared as files
+	MaxShareLength = 4000
+
+	// maxSharedSize caps what a share code may unpack to, so a crafted code cannot
+	// fill the memory
+	maxSharedSize = 1024 * 1024
+)
+
+// EncodeShare packs a system into a share code: its JSON, deflated and written in
+// URL-safe base64 after SharePrefix, so it can be pasted into a chat message or
+// a link. It fails for systems whose code would run past MaxShareLength.
+func EncodeShare(system *formats.SystemData) (string, error) {
+	shared := *system
+	shared.Bodies = make([]models.CelestialBody, len(system.Bodies))
+	copy(shared.Bodies, system.Bodies)
+	for i := range shared.Bodies {
+		shared.Bodies[i].Provenance = models.Provenance{}
+	}
+
+	data, err := marshalJSON(&shared)
+	if err != nil {
+		return "", err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", err
+	}
+	if data, err = marshalJSON(pruneShared(fields)); err != nil {
+		return "", err
+	}
+	var packed bytes.Buffer
+	writer, err := flate.NewWriter(&packed, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	code := SharePrefix + base64.RawURLEncoding.EncodeToString(packed.Bytes())
+	if len(code) > MaxShareLength {
+		return "", fmt.Errorf("share code would be %d characters, over the %d a code can be; share the file instead", len(code), MaxShareLength)
+	}
+	return code, nil
+}
+
+// sharedZeroKeys are the fields where a zero says something, being given rather
+// than left out
+var sharedZeroKeys = map[string]bool{"metallicity": true, "rightAscension": true}
+
+// pruneShared drops the fields of a system's JSON that only repeat what leaving
+// them out means - zero numbers, empty text, false - which keeps codes short and
+// the file unpacked from one free of placeholder values validation rejects, such
+// as a mass of 0
+func pruneShared(fields map[string]interface{}) map[string]interface{} {
+	for key, value := range fields {
+		if object, ok := value.(map[string]interface{}); ok {
+			value = pruneShared(object)
+			fields[key] = value
+		}
+		if items, ok := value.([]interface{}); ok {
+			for _, item := range items {
+				if object, ok := item.(map[string]interface{}); ok {
+					pruneShared(object)
+				}
+			}
+		}
+		if key != "bodies" && key != "systemName" && !sharedZeroKeys[key] && emptyJSONValue(value) {
+			delete(fields, key)
+		}
+	}
+	return fields
+}
+
+// DecodeShare unpacks a share code made by EncodeShare into the system's JSON.
+// Whitespace a chat client wrapped the code with is ignored.
+func DecodeShare(code string) ([]byte, error) {
+	code = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, code)
+	if !strings.HasPrefix(code, SharePrefix) {
+		return nil, fmt.Errorf("not a share code: it should start with %q", SharePrefix)
+	}
+
+	packed, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(code, SharePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("share code is damaged: %w", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(packed)), maxSharedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("share code is damaged: %w", err)
+	}
+	if len(data) > maxSharedSize {
+		return nil, errors.New("share code unpacks to more than a system file can be")
+	}
+	if !json.Valid(data) {
+		return nil, errors.New("share code does not hold a system file")
+	}
+	return data, nil
+}
+
+// InstallShare writes the system a share code holds into the systems directory
+// as a JSON file named after it, as InstallSystemFile does with downloads, and
+// returns the system's name
+func (sm *SystemManager) InstallShare(code string) (string, error) {
+	data, err := DecodeShare(code)
+	if err != nil {
+		return "", err
+	}
+	var system formats.SystemData
+	if err := json.Unmarshal(data, &system); err != nil {
+		return "", fmt.Errorf("share code does not hold a system file: %w", err)
+	}
+
+	name := shareFileName(system.SystemName)
+	if name == "" {
+		return "", errors.New("the shared system has no name to save it under")
+	}
+	return sm.InstallSystemFile(name+".json", indentJSON(data))
+}
+
+// shareFileName turns a system's display name into a system file name: letters
+// and digits kept, lowercased, with runs of anything else made one dash
+func shareFileName(systemName string) string {
+	var name strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(systemName) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && name.Len() > 0 {
+				name.WriteByte('-')
+			}
+			name.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return name.String()
+}
+
+// indentJSON lays out compact JSON for editing by hand
+func indentJSON(data []byte) []byte {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return data
+	}
+	return append(indented.Bytes(), '\n')
+}
@@ -0,0 +1,99 @@
+package systems
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/systems/formats"
+)
+
+func TestShareRoundTrip(t *testing.T) {
+	var system formats.SystemData
+	if err := json.Unmarshal([]byte(convertSample), &system); err != nil {
+		t.Fatal(err)
+	}
+	system.Bodies[0].Provenance = models.Provenance{Citation: "local notes"}
+
+	code, err := EncodeShare(&system)
+	if err != nil {
+		t.Fatalf("EncodeShare() error = %v", err)
+	}
+	if !strings.HasPrefix(code, SharePrefix) || strings.ContainsAny(code, "+/= ") {
+		t.Errorf("code %q is not a URL-safe share code", code)
+	}
+	if system.Bodies[0].Provenance.Citation != "local notes" {
+		t.Error("EncodeShare() changed the system it was given")
+	}
+
+	// Chat clients wrap long lines
+	wrapped := code[:20] + "\n" + code[20:]
+	data, err := DecodeShare(wrapped)
+	if err != nil {
+		t.Fatalf("DecodeShare() error = %v", err)
+	}
+	var decoded formats.SystemData
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.SystemName != "Converted" || len(decoded.Bodies) != 2 || decoded.Bodies[0].Mass.MassExponent != 30 {
+		t.Errorf("decoded %+v, want the shared system", decoded)
+	}
+}
+
+func TestDecodeShareErrors(t *testing.T) {
+	for code, want := range map[string]string{
+		"hello":                "not a share code",
+		SharePrefix + "***":    "damaged",
+		SharePrefix + "AAAA":   "damaged",
+		SharePrefix + "qwsAAA": "does not hold a system file",
+	} {
+		if _, err := DecodeShare(code); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("DecodeShare(%q) error = %v, want %q", code, err, want)
+		}
+	}
+}
+
+func TestEncodeShareRefusesBigSystems(t *testing.T) {
+	system := &formats.SystemData{SystemName: "Crowded"}
+	for i := 0; i < 2000; i++ {
+		system.Bodies = append(system.Bodies, models.CelestialBody{
+			ID:            fmt.Sprintf("rock-%d", i),
+			EnglishName:   fmt.Sprintf("Rock %d", i),
+			SemimajorAxis: float64(i*7919%100003) * 1e4,
+		})
+	}
+	if _, err := EncodeShare(system); err == nil || !strings.Contains(err.Error(), "share the file instead") {
+		t.Errorf("EncodeShare() error = %v, want it refused", err)
+	}
+}
+
+func TestInstallShare(t *testing.T) {
+	var system formats.SystemData
+	if err := json.Unmarshal([]byte(convertSample), &system); err != nil {
+		t.Fatal(err)
+	}
+	system.SystemName = "My Shared System!"
+	code, err := EncodeShare(&system)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewSystemManager(t.TempDir())
+	name, err := manager.InstallShare(code)
+	if err != nil {
+		t.Fatalf("InstallShare() error = %v", err)
+	}
+	if name != "my-shared-system" {
+		t.Errorf("installed as %q, want my-shared-system", name)
+	}
+	loaded, err := manager.LoadSystem(name)
+	if err != nil {
+		t.Fatalf("LoadSystem() error = %v", err)
+	}
+	if loaded.SystemName != "My Shared System!" || len(loaded.Bodies) != 2 {
+		t.Errorf("loaded %+v, want the shared system", loaded)
+	}
+}
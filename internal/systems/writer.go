@@ -0,0 +1,343 @@
+package systems
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/systems/formats"
+)
+
+// SaveOrbitalElements writes a body's orbital elements back into its system file.
+// The matching body's "orbitalElements" object is replaced and the mirrored
+// semimajorAxis/eccentricity/inclination fields are kept in sync; every other key
+// keeps its original order and value so hand-edited files stay readable.
+// It returns the path of the file that was written.
+func (sm *SystemManager) SaveOrbitalElements(systemName string, body models.CelestialBody) (string, error) {
+	if body.OrbitalElements == nil {
+		return "", fmt.Errorf("body %s has no orbital elements", body.EnglishName)
+	}
+
+	if sm.IsEmbedded(systemName) {
+		return "", fmt.Errorf("system '%s' is built in; copy it into %s to edit it", systemName, sm.systemsDir)
+	}
+	filePath, exists := sm.availableSystems[systemName]
+	if !exists {
+		return "", fmt.Errorf("system '%s' not found", systemName)
+	}
+
+	if ext := strings.ToLower(filepath.Ext(filePath)); ext != ".json" {
+		return "", fmt.Errorf("saving is only supported for JSON system files, not %s", ext)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read system file %s: %w", filePath, err)
+	}
+
+	var document orderedObject
+	if err := json.Unmarshal(data, &document); err != nil {
+		return "", fmt.Errorf("failed to parse system file %s: %w", filePath, err)
+	}
+
+	var bodies []orderedObject
+	if err := json.Unmarshal(document.Get("bodies"), &bodies); err != nil {
+		return "", fmt.Errorf("failed to parse bodies in %s: %w", filePath, err)
+	}
+
+	index := findBodyIndex(bodies, body)
+	if index < 0 {
+		return "", fmt.Errorf("body %s not found in %s", body.EnglishName, filePath)
+	}
+
+	elements := *body.OrbitalElements
+	elements.Epoch = elements.Epoch.UTC().Truncate(time.Second)
+
+	updates := map[string]interface{}{
+		"orbitalElements": elements,
+		"semimajorAxis":   elements.SemimajorAxis,
+		"eccentricity":    elements.Eccentricity,
+		"inclination":     elements.Inclination,
+	}
+	for _, key := range []string{"semimajorAxis", "eccentricity", "inclination", "orbitalElements"} {
+		if err := bodies[index].Set(key, updates[key]); err != nil {
+			return "", fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+	}
+
+	if err := document.Set("bodies", bodies); err != nil {
+		return "", fmt.Errorf("failed to encode bodies: %w", err)
+	}
+
+	if err := writeJSONFile(filePath, document); err != nil {
+		return "", err
+	}
+
+	// Force the next load to pick up the edited file
+	delete(sm.loadedSystems, systemName)
+	delete(sm.loadedMoons, systemName)
+
+	return filePath, nil
+}
+
+// WriteSystemMetadata writes a system's metadata (its name, description, discovery
+// year, distance, galaxy and right ascension) back into its file through the
+// file's format, leaving the bodies as they are. It returns the path of the file
+// that was written.
+func (sm *SystemManager) WriteSystemMetadata(systemName string, metadata SystemMetadata) (string, error) {
+	if sm.IsEmbedded(systemName) {
+		return "", fmt.Errorf("system '%s' is built in; copy it into %s to edit it", systemName, sm.systemsDir)
+	}
+	filePath, exists := sm.availableSystems[systemName]
+	if !exists {
+		return "", fmt.Errorf("system '%s' not found", systemName)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read system file %s: %w", filePath, err)
+	}
+	format, err := sm.formatFor(filePath, data)
+	if err != nil {
+		return "", err
+	}
+	writer, ok := format.(formats.MetadataWriter)
+	if !ok {
+		return "", fmt.Errorf("%s files cannot be written", format.GetFormatName())
+	}
+
+	rewritten, err := writer.WriteSystemMetadata(data, &metadata)
+	if err != nil {
+		return "", fmt.Errorf("failed to write metadata to %s: %w", filePath, err)
+	}
+	if err := writeFileAtomic(filePath, rewritten); err != nil {
+		return "", err
+	}
+
+	// Force the header, the system list and the next load to read the file again
+	delete(sm.loadedSystems, systemName)
+	delete(sm.loadedMoons, systemName)
+	delete(sm.cachedMetadata, systemName)
+	delete(sm.cachedSystemInfo, systemName)
+
+	return filePath, nil
+}
+
+// findBodyIndex locates a body by id, falling back to its English name
+func findBodyIndex(bodies []orderedObject, body models.CelestialBody) int {
+	var fallback = -1
+	for i, candidate := range bodies {
+		var id, englishName string
+		_ = json.Unmarshal(candidate.Get("id"), &id)
+		_ = json.Unmarshal(candidate.Get("englishName"), &englishName)
+
+		if body.ID != "" && id == body.ID {
+			return i
+		}
+		if fallback < 0 && englishName == body.EnglishName {
+			fallback = i
+		}
+	}
+	return fallback
+}
+
+// writeJSONFile writes an indented JSON document atomically via a temporary file
+func writeJSONFile(filePath string, value interface{}) error {
+	compact, err := marshalJSON(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode system file: %w", err)
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, compact, "", "  "); err != nil {
+		return fmt.Errorf("failed to format system file: %w", err)
+	}
+	indented.WriteByte('\n')
+
+	return writeFileAtomic(filePath, indented.Bytes())
+}
+
+// writeFileAtomic writes a file via a temporary file in the same directory, so a
+// failed write never leaves it half-written
+func writeFileAtomic(filePath string, data []byte) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(filePath), ".system-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+// ConvertSystemFile rewrites a system file in another format, picked by the
+// output's extension, for example a hand-written JSON file into the binary format
+// for faster loading. The input is parsed and checked as it would be on load.
+func (sm *SystemManager) ConvertSystemFile(inPath, outPath string) error {
+	system, err := sm.ReadSystemFile(inPath)
+	if err != nil {
+		return err
+	}
+	return sm.WriteSystemFile(outPath, system)
+}
+
+// ReadSystemFile parses the system file at path, in whichever format it is in
+func (sm *SystemManager) ReadSystemFile(path string) (*formats.SystemData, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+	source, err := sm.formatFor(path, data)
+	if err != nil {
+		return nil, err
+	}
+	system, err := source.ParseSystemData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse system file %s: %w", path, err)
+	}
+	return system, nil
+}
+
+// WriteSystemFile writes system to path in the format its extension names
+func (sm *SystemManager) WriteSystemFile(path string, system *formats.SystemData) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	target, exists := sm.formatRegistry.GetHandlerForExtension(ext)
+	if !exists {
+		return fmt.Errorf("unsupported output format %q; use one of %s", ext, strings.Join(sm.GetSupportedFormats(), ", "))
+	}
+	encoder, ok := target.(formats.Encoder)
+	if !ok {
+		return fmt.Errorf("%s files cannot be written", target.GetFormatName())
+	}
+
+	// Where a body came from is worked out again when the new file is loaded
+	for i := range system.Bodies {
+		system.Bodies[i].Provenance = models.Provenance{}
+	}
+	encoded, err := encoder.EncodeSystemData(system)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return writeFileAtomic(path, encoded)
+}
+
+// marshalJSON encodes without HTML escaping so text like "&" survives a rewrite untouched
+func marshalJSON(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// orderedObject is a JSON object that remembers its key order so files can be
+// rewritten without shuffling fields around
+type orderedObject struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+// Get returns the raw value for a key, or nil if absent
+func (o *orderedObject) Get(key string) json.RawMessage {
+	return o.values[key]
+}
+
+// Set encodes value under key, appending the key if it is new
+func (o *orderedObject) Set(key string, value interface{}) error {
+	raw, err := marshalJSON(value)
+	if err != nil {
+		return err
+	}
+
+	if o.values == nil {
+		o.values = make(map[string]json.RawMessage)
+	}
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = raw
+	return nil
+}
+
+// UnmarshalJSON decodes an object while recording key order
+func (o *orderedObject) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected JSON object")
+	}
+
+	o.keys = nil
+	o.values = make(map[string]json.RawMessage)
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("expected object key")
+		}
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+
+		if _, exists := o.values[key]; !exists {
+			o.keys = append(o.keys, key)
+		}
+		o.values[key] = value
+	}
+
+	_, err = decoder.Token()
+	return err
+}
+
+// MarshalJSON encodes the object keeping the original key order
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(o.values[key])
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
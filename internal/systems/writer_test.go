@@ -0,0 +1,360 @@
+package systems
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+const convertSample = `{
+  "systemName": "Converted",
+  "distance": "12 ly",
+  "bodies": [
+    {"id": "star", "englishName": "Star", "bodyType": "Star", "mass": {"massValue": 1.9, "massExponent": 30}},
+    {"id": "b", "englishName": "b", "bodyType": "Planet", "isPlanet": true, "semimajorAxis": 150000000, "sideralOrbit": 365}
+  ]
+}`
+
+func TestConvertSystemFileToBinary(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "source.json")
+	if err := os.WriteFile(in, []byte(convertSample), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewSystemManager(dir)
+	if err := manager.ConvertSystemFile(in, filepath.Join(dir, "converted.ssb")); err != nil {
+		t.Fatalf("ConvertSystemFile() error = %v", err)
+	}
+
+	if err := manager.ScanSystems(); err != nil {
+		t.Fatalf("ScanSystems() error = %v", err)
+	}
+	system, err := manager.LoadSystem("converted")
+	if err != nil {
+		t.Fatalf("LoadSystem() error = %v", err)
+	}
+	if system.SystemName != "Converted" || len(system.Bodies) != 2 || system.Bodies[0].Mass.MassExponent != 30 {
+		t.Errorf("loaded %+v, want the converted system", system)
+	}
+}
+
+func TestConvertSystemFileUnknownOutput(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "source.json")
+	if err := os.WriteFile(in, []byte(convertSample), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "source.xml")
+	if err := NewSystemManager(dir).ConvertSystemFile(in, out); err == nil {
+		t.Error("ConvertSystemFile() to .xml succeeded, want an error")
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("output file exists after a failed conversion: %v", err)
+	}
+}
+
+func TestWriteSystemMetadataKeepsBodies(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "converted.json")
+	if err := os.WriteFile(path, []byte(convertSample), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewSystemManager(dir)
+	if err := manager.ScanSystems(); err != nil {
+		t.Fatalf("ScanSystems() error = %v", err)
+	}
+	before, err := manager.LoadSystem("converted")
+	if err != nil {
+		t.Fatalf("LoadSystem() error = %v", err)
+	}
+	// Cache the old metadata, which the save must throw away
+	if _, err := manager.LoadSystemMetadata("converted"); err != nil {
+		t.Fatalf("LoadSystemMetadata() error = %v", err)
+	}
+
+	metadata := SystemMetadata{
+		SystemName:    "Converted",
+		Description:   "Two bodies & a test",
+		DiscoveryYear: "1999",
+		Distance:      "13 ly",
+		Galaxy:        "Milky Way",
+	}
+	if _, err := manager.WriteSystemMetadata("converted", metadata); err != nil {
+		t.Fatalf("WriteSystemMetadata() error = %v", err)
+	}
+
+	after, err := manager.LoadSystemMetadata("converted")
+	if err != nil {
+		t.Fatalf("LoadSystemMetadata() error = %v", err)
+	}
+	if after.Description != metadata.Description || after.Distance != "13 ly" || after.Galaxy != "Milky Way" {
+		t.Errorf("metadata = %+v, want %+v", after, metadata)
+	}
+
+	system, err := manager.LoadSystem("converted")
+	if err != nil {
+		t.Fatalf("LoadSystem() error = %v", err)
+	}
+	if len(system.Bodies) != len(before.Bodies) || system.Bodies[1].SemimajorAxis != before.Bodies[1].SemimajorAxis {
+		t.Errorf("bodies = %+v, want them unchanged", system.Bodies)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(data)
+	if !strings.Contains(text, "& a test") {
+		t.Errorf("description was HTML-escaped:\n%s", text)
+	}
+	if strings.Index(text, `"galaxy"`) > strings.Index(text, `"bodies"`) {
+		t.Errorf("new keys should go before the bodies:\n%s", text)
+	}
+}
+
+func TestWriteSystemMetadataUnknownSystem(t *testing.T) {
+	if _, err := NewSystemManager(t.TempDir()).WriteSystemMetadata("missing", SystemMetadata{SystemName: "Missing"}); err == nil {
+		t.Error("WriteSystemMetadata() for an unknown system succeeded, want an error")
+	}
+}
+
+// elementsSample is hand-written, with keys the app does not know and in no
+// particular order, as a user's own file would be
+const elementsSample = `{
+  "comment": "kept by hand",
+  "systemName": "Edited",
+  "distance": "4 ly",
+  "bodies": [
+    {"englishName": "Star", "id": "star", "bodyType": "Star", "notes": ["hot"]},
+    {"notes": "b's notes", "id": "b", "eccentricity": 0.01, "englishName": "b", "isPlanet": true, "bodyType": "Planet", "sideralOrbit": 365, "semimajorAxis": 150000000, "inclination": 1.5, "colour": "#8af"},
+    {"englishName": "c", "semimajorAxis": 300000000, "id": "c", "bodyType": "Planet", "isPlanet": true, "sideralOrbit": 1000}
+  ],
+  "zz": {"b": 1, "a": 2}
+}`
+
+// writeElementsSample writes elementsSample into a new systems directory and
+// returns a manager that has scanned it, and the file's path
+func writeElementsSample(t *testing.T) (*SystemManager, string) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "edited.json")
+	if err := os.WriteFile(path, []byte(elementsSample), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manager := NewSystemManager(dir)
+	if err := manager.ScanSystems(); err != nil {
+		t.Fatalf("ScanSystems() error = %v", err)
+	}
+	return manager, path
+}
+
+// readOrdered reads a JSON file's top-level object and its bodies, keeping key order
+func readOrdered(t *testing.T, path string) (orderedObject, []orderedObject) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var document orderedObject
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("rewritten file does not parse: %v\n%s", err, data)
+	}
+	var bodies []orderedObject
+	if err := json.Unmarshal(document.Get("bodies"), &bodies); err != nil {
+		t.Fatalf("rewritten bodies do not parse: %v", err)
+	}
+	return document, bodies
+}
+
+// compactJSON strips the layout from a raw value, to compare values written
+// with different indentation
+func compactJSON(t *testing.T, raw json.RawMessage) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestSaveOrbitalElementsRoundTrip(t *testing.T) {
+	manager, path := writeElementsSample(t)
+	beforeDocument, beforeBodies := readOrdered(t, path)
+	if _, err := manager.LoadSystem("edited"); err != nil {
+		t.Fatalf("LoadSystem() error = %v", err)
+	}
+
+	elements := models.OrbitalElement{
+		SemimajorAxis:            160000000,
+		Eccentricity:             0.2,
+		Inclination:              3.25,
+		ArgumentOfPeriapsis:      45,
+		LongitudeOfAscendingNode: 90,
+		MeanAnomaly:              10,
+		Epoch:                    time.Date(2000, 1, 1, 12, 0, 0, 500, time.FixedZone("CET", 3600)),
+	}
+	written, err := manager.SaveOrbitalElements("edited", models.CelestialBody{ID: "b", EnglishName: "b", OrbitalElements: &elements})
+	if err != nil {
+		t.Fatalf("SaveOrbitalElements() error = %v", err)
+	}
+	if written != path {
+		t.Errorf("SaveOrbitalElements() wrote %s, want %s", written, path)
+	}
+
+	document, bodies := readOrdered(t, path)
+	if got, want := strings.Join(document.keys, ","), strings.Join(beforeDocument.keys, ","); got != want {
+		t.Errorf("top-level keys = %s, want %s", got, want)
+	}
+	for _, key := range []string{"comment", "systemName", "distance", "zz"} {
+		if got, want := compactJSON(t, document.Get(key)), compactJSON(t, beforeDocument.Get(key)); got != want {
+			t.Errorf("%s = %s, want %s", key, got, want)
+		}
+	}
+	if len(bodies) != len(beforeBodies) {
+		t.Fatalf("%d bodies after saving, want %d", len(bodies), len(beforeBodies))
+	}
+	for _, i := range []int{0, 2} {
+		got, want := bodies[i], beforeBodies[i]
+		if strings.Join(got.keys, ",") != strings.Join(want.keys, ",") {
+			t.Errorf("body %d keys = %v, want %v", i, got.keys, want.keys)
+		}
+		for _, key := range want.keys {
+			if compactJSON(t, got.Get(key)) != compactJSON(t, want.Get(key)) {
+				t.Errorf("body %d %s = %s, want it untouched", i, key, got.Get(key))
+			}
+		}
+	}
+
+	// The edited body keeps its keys in place, with the elements added at the end
+	edited := bodies[1]
+	if got, want := strings.Join(edited.keys, ","), strings.Join(append(beforeBodies[1].keys, "orbitalElements"), ","); got != want {
+		t.Errorf("edited body keys = %s, want %s", got, want)
+	}
+	for key, want := range map[string]string{
+		"semimajorAxis": "160000000",
+		"eccentricity":  "0.2",
+		"inclination":   "3.25",
+		"notes":         `"b's notes"`,
+		"colour":        `"#8af"`,
+	} {
+		if got := compactJSON(t, edited.Get(key)); got != want {
+			t.Errorf("edited body %s = %s, want %s", key, got, want)
+		}
+	}
+	var saved models.OrbitalElement
+	if err := json.Unmarshal(edited.Get("orbitalElements"), &saved); err != nil {
+		t.Fatalf("orbitalElements do not parse: %v", err)
+	}
+	elements.Epoch = time.Date(2000, 1, 1, 11, 0, 0, 0, time.UTC)
+	if saved != elements {
+		t.Errorf("orbitalElements = %+v, want %+v with the epoch in UTC to the second", saved, elements)
+	}
+
+	// The next load reads the edit rather than the system loaded before it
+	system, err := manager.LoadSystem("edited")
+	if err != nil {
+		t.Fatalf("LoadSystem() error = %v", err)
+	}
+	if body := system.Bodies[1]; body.SemimajorAxis != 160000000 || body.OrbitalElements == nil || body.OrbitalElements.Eccentricity != 0.2 {
+		t.Errorf("reloaded body = %+v, want the saved elements", body)
+	}
+}
+
+func TestSaveOrbitalElementsFindsBodyByName(t *testing.T) {
+	manager, path := writeElementsSample(t)
+	elements := models.OrbitalElement{SemimajorAxis: 310000000, Eccentricity: 0.05}
+	if _, err := manager.SaveOrbitalElements("edited", models.CelestialBody{EnglishName: "c", OrbitalElements: &elements}); err != nil {
+		t.Fatalf("SaveOrbitalElements() error = %v", err)
+	}
+	_, bodies := readOrdered(t, path)
+	if got := compactJSON(t, bodies[2].Get("semimajorAxis")); got != "310000000" {
+		t.Errorf("c's semimajorAxis = %s, want 310000000", got)
+	}
+}
+
+func TestSaveOrbitalElementsErrors(t *testing.T) {
+	elements := &models.OrbitalElement{SemimajorAxis: 1}
+
+	t.Run("embedded", func(t *testing.T) {
+		manager := NewSystemManager(t.TempDir())
+		embedded := fstest.MapFS{"tiny.json": {Data: []byte(`{"systemName": "Tiny", "distance": "1 ly", "bodies": [{"id": "star", "englishName": "Star", "bodyType": "Star"}]}`)}}
+		if err := manager.AddEmbeddedSystems(embedded); err != nil {
+			t.Fatal(err)
+		}
+		if err := manager.ScanSystems(); err != nil {
+			t.Fatal(err)
+		}
+		_, err := manager.SaveOrbitalElements("tiny", models.CelestialBody{ID: "star", EnglishName: "Star", OrbitalElements: elements})
+		if err == nil || !strings.Contains(err.Error(), "built in") {
+			t.Errorf("SaveOrbitalElements() on an embedded system error = %v, want it refused", err)
+		}
+	})
+
+	t.Run("not JSON", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "tiny.ssb")
+		manager := NewSystemManager(dir)
+		if err := manager.ConvertSystemFile(writeSample(t), path); err != nil {
+			t.Fatal(err)
+		}
+		before, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := manager.ScanSystems(); err != nil {
+			t.Fatal(err)
+		}
+		_, err = manager.SaveOrbitalElements("tiny", models.CelestialBody{ID: "b", EnglishName: "b", OrbitalElements: elements})
+		if err == nil || !strings.Contains(err.Error(), "only supported for JSON") {
+			t.Errorf("SaveOrbitalElements() on a binary file error = %v, want it refused", err)
+		}
+		if after, _ := os.ReadFile(path); !bytes.Equal(after, before) {
+			t.Error("the binary file was changed")
+		}
+	})
+
+	t.Run("missing body", func(t *testing.T) {
+		manager, path := writeElementsSample(t)
+		_, err := manager.SaveOrbitalElements("edited", models.CelestialBody{ID: "d", EnglishName: "d", OrbitalElements: elements})
+		if err == nil || !strings.Contains(err.Error(), "body d not found") {
+			t.Errorf("SaveOrbitalElements() for a missing body error = %v", err)
+		}
+		if data, _ := os.ReadFile(path); string(data) != elementsSample {
+			t.Error("the file was changed")
+		}
+	})
+
+	t.Run("no elements", func(t *testing.T) {
+		manager, _ := writeElementsSample(t)
+		if _, err := manager.SaveOrbitalElements("edited", models.CelestialBody{ID: "b", EnglishName: "b"}); err == nil {
+			t.Error("SaveOrbitalElements() without elements succeeded, want an error")
+		}
+	})
+
+	t.Run("unknown system", func(t *testing.T) {
+		manager, _ := writeElementsSample(t)
+		if _, err := manager.SaveOrbitalElements("missing", models.CelestialBody{ID: "b", OrbitalElements: elements}); err == nil {
+			t.Error("SaveOrbitalElements() for an unknown system succeeded, want an error")
+		}
+	})
+}
+
+// writeSample writes convertSample outside any systems directory, returning its path
+func writeSample(t *testing.T) string {
+	t.Helper()
+	source := filepath.Join(t.TempDir(), "source.json")
+	if err := os.WriteFile(source, []byte(convertSample), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return source
+}
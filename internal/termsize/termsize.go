@@ -0,0 +1,60 @@
+// Package termsize measures the terminal's character cells. Fonts differ, so the
+// ratio of a cell's height to its width decides how round a drawn orbit looks.
+package termsize
+
+import (
+	"errors"
+	"math"
+)
+
+// Ratios outside this range are treated as bogus reports
+const (
+	MinAspectRatio = 1.0
+	MaxAspectRatio = 3.5
+)
+
+// ErrUnsupported is returned where the terminal's pixel size cannot be queried
+var ErrUnsupported = errors.New("terminal pixel size is not available on this platform")
+
+// CellAspectRatio returns the height-to-width ratio of one character cell, when
+// the terminal reports its size in pixels
+func CellAspectRatio() (float64, error) {
+	cols, rows, width, height, err := windowPixels()
+	if err != nil {
+		return 0, err
+	}
+	return aspectRatio(cols, rows, width, height)
+}
+
+// aspectRatio works out the cell ratio from a window size in cells and pixels
+func aspectRatio(cols, rows, width, height int) (float64, error) {
+	if cols <= 0 || rows <= 0 || width <= 0 || height <= 0 {
+		return 0, errors.New("terminal did not report its size in pixels")
+	}
+
+	cellWidth := float64(width) / float64(cols)
+	cellHeight := float64(height) / float64(rows)
+	ratio := cellHeight / cellWidth
+	if ratio < MinAspectRatio || ratio > MaxAspectRatio || math.IsNaN(ratio) {
+		return 0, errors.New("terminal reported an implausible cell size")
+	}
+	return ratio, nil
+}
+
+// CellSize returns the size of one character cell in pixels, when the terminal
+// reports its size in pixels
+func CellSize() (width, height int, err error) {
+	cols, rows, pixelWidth, pixelHeight, err := windowPixels()
+	if err != nil {
+		return 0, 0, err
+	}
+	return cellSize(cols, rows, pixelWidth, pixelHeight)
+}
+
+// cellSize works out a cell's size from a window size in cells and pixels
+func cellSize(cols, rows, width, height int) (int, int, error) {
+	if cols <= 0 || rows <= 0 || width < cols || height < rows {
+		return 0, 0, errors.New("terminal did not report its size in pixels")
+	}
+	return width / cols, height / rows, nil
+}
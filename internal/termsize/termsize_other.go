@@ -0,0 +1,9 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package termsize
+
+// windowPixels is not available here; the ratio falls back to the default or a
+// calibrated value
+func windowPixels() (cols, rows, width, height int, err error) {
+	return 0, 0, 0, 0, ErrUnsupported
+}
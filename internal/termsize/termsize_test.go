@@ -0,0 +1,43 @@
+package termsize
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAspectRatio(t *testing.T) {
+	tests := []struct {
+		name                      string
+		cols, rows, width, height int
+		want                      float64
+		wantErr                   bool
+	}{
+		{"typical 8x16 font", 80, 24, 640, 384, 2.0, false},
+		{"wider 10x18 font", 100, 30, 1000, 540, 1.8, false},
+		{"no pixel size reported", 80, 24, 0, 0, 0, true},
+		{"implausibly flat cells", 80, 24, 640, 96, 0, true},
+		{"implausibly tall cells", 80, 24, 80, 384, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := aspectRatio(tt.cols, tt.rows, tt.width, tt.height)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("aspectRatio() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("aspectRatio() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCellSize(t *testing.T) {
+	width, height, err := cellSize(80, 24, 640, 384)
+	if err != nil || width != 8 || height != 16 {
+		t.Errorf("cellSize(80, 24, 640, 384) = %d, %d, %v, want 8, 16", width, height, err)
+	}
+	if _, _, err := cellSize(80, 24, 0, 0); err == nil {
+		t.Error("cellSize() accepted a window without a pixel size")
+	}
+}
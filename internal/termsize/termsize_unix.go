@@ -0,0 +1,24 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package termsize
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// windowPixels asks the controlling terminal for its size in cells and pixels
+func windowPixels() (cols, rows, width, height int, err error) {
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	defer tty.Close()
+
+	size, err := unix.IoctlGetWinsize(int(tty.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	return int(size.Col), int(size.Row), int(size.Xpixel), int(size.Ypixel), nil
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/layout"
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -44,6 +45,12 @@ func NewModal(screen tcell.Screen, config ModalConfig) *Modal {
 		y = screenHeight - config.Height - 2
 	}
 
+	// Small terminals have no room to float a modal beside the map
+	if regions := layout.Compute(screenWidth, screenHeight); regions.FullScreenModals() {
+		area := regions.Modal(config.Height)
+		x, y, config.Width, config.Height = area.X, area.Y, area.Width, area.Height
+	}
+
 	return &Modal{
 		screen:       screen,
 		x:            x,
@@ -111,12 +118,7 @@ func (m *Modal) drawContent() {
 }
 
 func (m *Modal) drawTextAt(x, y int, style tcell.Style, text string) {
-	for i, r := range text {
-		if x+i >= m.x+m.width-2 {
-			break
-		}
-		m.screen.SetContent(x+i, y, r, nil, style)
-	}
+	DrawTextClipped(m.screen, x, y, m.x+m.width-2, style, text)
 }
 
 func (m *Modal) DrawInstructions(instructions string) {
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// CellWriter is the part of a screen text is drawn on
+type CellWriter interface {
+	SetContent(x, y int, mainc rune, combc []rune, style tcell.Style)
+}
+
+// Span is a run of text drawn in one style
+type Span struct {
+	Text  string
+	Style tcell.Style
+}
+
+// TextWidth returns how many cells text takes on screen: two for wide runes such
+// as emoji and CJK, none for combining marks
+func TextWidth(text string) int {
+	return runewidth.StringWidth(text)
+}
+
+// Truncate shortens text to at most maxWidth cells, ending it with "..." when
+// there is room for that
+func Truncate(text string, maxWidth int) string {
+	if TextWidth(text) <= maxWidth {
+		return text
+	}
+	if maxWidth <= 3 {
+		return runewidth.Truncate(text, max(maxWidth, 0), "")
+	}
+	return runewidth.Truncate(text, maxWidth, "...")
+}
+
+// DrawText writes text from x, y and returns the column after it
+func DrawText(screen CellWriter, x, y int, style tcell.Style, text string) int {
+	return DrawSpans(screen, x, y, math.MaxInt, Span{Text: text, Style: style})
+}
+
+// DrawTextClipped is DrawText stopping before column maxX
+func DrawTextClipped(screen CellWriter, x, y, maxX int, style tcell.Style, text string) int {
+	return DrawSpans(screen, x, y, maxX, Span{Text: text, Style: style})
+}
+
+// DrawSpans writes the spans one after another from x, y, stopping before column
+// maxX, and returns the column after the last rune written. A wide rune fills the
+// cell after it with a blank in its style so nothing drawn there before shows
+// through, and is left off rather than cut in half at maxX. Combining marks join
+// the rune before them.
+func DrawSpans(screen CellWriter, x, y, maxX int, spans ...Span) int {
+	lastX, last := -1, rune(0)
+	var combining []rune
+	var lastStyle tcell.Style
+	for _, span := range spans {
+		for _, r := range span.Text {
+			width := runewidth.RuneWidth(r)
+			if width == 0 {
+				if lastX >= 0 {
+					combining = append(combining, r)
+					screen.SetContent(lastX, y, last, combining, lastStyle)
+				}
+				continue
+			}
+			if x+width > maxX {
+				return x
+			}
+			for offset := width - 1; offset > 0; offset-- {
+				screen.SetContent(x+offset, y, ' ', nil, span.Style)
+			}
+			screen.SetContent(x, y, r, nil, span.Style)
+			lastX, last, combining, lastStyle = x, r, nil, span.Style
+			x += width
+		}
+	}
+	return x
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func newTextScreen(t *testing.T) tcell.SimulationScreen {
+	t.Helper()
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("screen.Init() error = %v", err)
+	}
+	t.Cleanup(screen.Fini)
+	screen.SetSize(20, 2)
+	return screen
+}
+
+func TestDrawTextWideRunes(t *testing.T) {
+	screen := newTextScreen(t)
+	for x := 0; x < 20; x++ {
+		screen.SetContent(x, 0, '#', nil, tcell.StyleDefault)
+	}
+
+	end := DrawText(screen, 1, 0, tcell.StyleDefault, "🚀 • Go")
+	if end != 8 || TextWidth("🚀 • Go") != 7 {
+		t.Fatalf("DrawText() ended at %d with width %d, want 8 and 7", end, TextWidth("🚀 • Go"))
+	}
+	want := map[int]rune{1: '🚀', 2: ' ', 3: ' ', 4: '•', 5: ' ', 6: 'G', 7: 'o', 8: '#'}
+	for x, r := range want {
+		if got, _, _, _ := screen.GetContent(x, 0); got != r {
+			t.Errorf("cell %d = %q, want %q", x, got, r)
+		}
+	}
+}
+
+func TestDrawSpansClipsAndCombines(t *testing.T) {
+	screen := newTextScreen(t)
+	bold := tcell.StyleDefault.Bold(true)
+
+	// The wide rune would straddle the limit, so it is left off
+	end := DrawSpans(screen, 0, 0, 4, Span{Text: "ab", Style: bold}, Span{Text: "c🌌d", Style: tcell.StyleDefault})
+	if end != 3 {
+		t.Errorf("DrawSpans() ended at %d, want 3 before the wide rune", end)
+	}
+	if _, _, style, _ := screen.GetContent(1, 0); style != bold {
+		t.Errorf("first span drawn in %v, want bold", style)
+	}
+	if got, _, _, _ := screen.GetContent(3, 0); got == '🌌' {
+		t.Error("wide rune cut in half at the limit")
+	}
+
+	DrawText(screen, 0, 1, tcell.StyleDefault, "éx")
+	if got, combining, _, _ := screen.GetContent(0, 1); got != 'e' || len(combining) != 1 || combining[0] != '́' {
+		t.Errorf("cell 0 = %q %q, want e with its accent", got, combining)
+	}
+	if got, _, _, _ := screen.GetContent(1, 1); got != 'x' {
+		t.Errorf("cell 1 = %q, want x straight after the accented e", got)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{"Jupiter", 10, "Jupiter"},
+		{"Jupiter", 6, "Jup..."},
+		{"🪐 Saturn", 7, "🪐 S..."},
+		{"Jupiter", 2, "Ju"},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.text, tt.width); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
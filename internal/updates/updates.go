@@ -0,0 +1,218 @@
+// Package updates checks a remote index for new and updated curated system files.
+// The index is a manifest listing each file with its SHA-256, signed with an
+// Ed25519 key, so a file is only installed when the manifest it is listed in was
+// signed by the key the user trusts and its contents match the listed hash.
+package updates
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+)
+
+const (
+	// SignatureSuffix is added to the manifest's URL to find its signature: the
+	// base64 Ed25519 signature of the manifest's exact bytes
+	SignatureSuffix = ".sig"
+
+	// maxManifestSize caps a downloaded manifest
+	maxManifestSize = 1024 * 1024
+
+	// maxFileSize caps a downloaded system file
+	maxFileSize = 64 * 1024 * 1024
+)
+
+// ErrBadSignature is returned when a manifest was not signed by the trusted key
+var ErrBadSignature = errors.New("manifest signature does not match the update key")
+
+// Manifest lists the system files an index offers
+type Manifest struct {
+	Systems []Entry `json:"systems"`
+}
+
+// Entry is one system file in a manifest
+type Entry struct {
+	// Name is the system's name, its file name without the extension; manifests
+	// where they differ are rejected
+	Name string `json:"name"`
+
+	// File is the name the file is saved as in the systems directory, e.g. trappist-1.json
+	File string `json:"file"`
+
+	// URL is where the file is downloaded from, absolute or relative to the manifest
+	URL string `json:"url"`
+
+	// SHA256 is the hex SHA-256 of the file's contents
+	SHA256 string `json:"sha256"`
+
+	Size        int64  `json:"size,omitempty"`
+	Updated     string `json:"updated,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Status is how an entry compares with the system installed
+type Status int
+
+const (
+	// StatusCurrent means the installed file is the one listed
+	StatusCurrent Status = iota
+	// StatusNew means no system of that name is installed
+	StatusNew
+	// StatusUpdated means the installed file differs from the one listed
+	StatusUpdated
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusNew:
+		return "new"
+	case StatusUpdated:
+		return "updated"
+	default:
+		return "current"
+	}
+}
+
+// Update is an entry that is not installed as listed
+type Update struct {
+	Entry
+	Status Status
+}
+
+// Pending compares the manifest with what is installed, given the hex SHA-256 of
+// each installed system's file, and returns the entries that are new or differ,
+// by name
+func (m Manifest) Pending(installed func(name string) (string, bool)) []Update {
+	var pending []Update
+	for _, entry := range m.Systems {
+		hash, ok := installed(entry.Name)
+		switch {
+		case !ok:
+			pending = append(pending, Update{Entry: entry, Status: StatusNew})
+		case !strings.EqualFold(hash, entry.SHA256):
+			pending = append(pending, Update{Entry: entry, Status: StatusUpdated})
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Name < pending[j].Name })
+	return pending
+}
+
+// Checker fetches a signed manifest and the files it lists
+type Checker struct {
+	index  *url.URL
+	key    ed25519.PublicKey
+	client *http.Client
+}
+
+// NewChecker creates a checker for the manifest at indexURL, trusting manifests
+// signed by key, a base64 Ed25519 public key
+func NewChecker(indexURL, key string, client *http.Client) (*Checker, error) {
+	index, err := url.Parse(indexURL)
+	if err != nil || (index.Scheme != "https" && index.Scheme != "http") || index.Host == "" {
+		return nil, fmt.Errorf("update index %q is not an http(s) URL", indexURL)
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("update key is not a base64 Ed25519 public key")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: constants.DefaultTimeout}
+	}
+	return &Checker{index: index, key: ed25519.PublicKey(raw), client: client}, nil
+}
+
+// Check downloads the manifest and its signature and returns the manifest once
+// the signature checks out
+func (c *Checker) Check(ctx context.Context) (*Manifest, error) {
+	data, err := c.get(ctx, c.index.String(), maxManifestSize)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := c.get(ctx, c.index.String()+SignatureSuffix, maxManifestSize)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil || !ed25519.Verify(c.key, data, signature) {
+		return nil, ErrBadSignature
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse update manifest: %w", err)
+	}
+	if err := manifest.validate(); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// validate checks every entry is named after the file it installs, since the
+// installed system is named after its file and Pending looks it up by name
+func (m Manifest) validate() error {
+	for _, entry := range m.Systems {
+		if entry.File == "" || entry.File != filepath.Base(entry.File) {
+			return fmt.Errorf("update manifest lists %s with a bad file name %q", entry.Name, entry.File)
+		}
+		if name := strings.TrimSuffix(entry.File, filepath.Ext(entry.File)); entry.Name != name {
+			return fmt.Errorf("update manifest names %s %q; it must be named %q after its file", entry.File, entry.Name, name)
+		}
+	}
+	return nil
+}
+
+// Download fetches an entry's file and returns its contents once they match the
+// hash the signed manifest gave
+func (c *Checker) Download(ctx context.Context, entry Entry) ([]byte, error) {
+	location, err := c.index.Parse(entry.URL)
+	if err != nil {
+		return nil, fmt.Errorf("bad URL for %s: %w", entry.Name, err)
+	}
+	data, err := c.get(ctx, location.String(), maxFileSize)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if !strings.EqualFold(hex.EncodeToString(sum[:]), entry.SHA256) {
+		return nil, fmt.Errorf("%s does not match the SHA-256 in the manifest", entry.File)
+	}
+	return data, nil
+}
+
+// get fetches a URL, refusing bodies larger than limit
+func (c *Checker) get(ctx context.Context, location string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", location, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", location, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", location, err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s is larger than %d bytes", location, limit)
+	}
+	return data, nil
+}
@@ -0,0 +1,134 @@
+package updates
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newIndex(t *testing.T, sign ed25519.PrivateKey, files map[string]string) *httptest.Server {
+	t.Helper()
+	var manifest Manifest
+	for name, contents := range files {
+		sum := sha256.Sum256([]byte(contents))
+		manifest.Systems = append(manifest.Systems, Entry{Name: name, File: name + ".json", URL: "files/" + name + ".json", SHA256: hex.EncodeToString(sum[:])})
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/index.json", func(w http.ResponseWriter, _ *http.Request) { w.Write(data) })
+	mux.HandleFunc("/index.json.sig", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(base64.StdEncoding.EncodeToString(ed25519.Sign(sign, data))))
+	})
+	mux.HandleFunc("/files/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files/kepler-90.json":
+			w.Write([]byte(files["kepler-90"]))
+		case "/files/trappist-1.json":
+			w.Write([]byte("tampered"))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckAndDownload(t *testing.T) {
+	public, private, _ := ed25519.GenerateKey(nil)
+	server := newIndex(t, private, map[string]string{"kepler-90": `{"systemName": "Kepler-90"}`, "trappist-1": `{"systemName": "TRAPPIST-1"}`})
+
+	checker, err := NewChecker(server.URL+"/index.json", base64.StdEncoding.EncodeToString(public), server.Client())
+	if err != nil {
+		t.Fatalf("NewChecker() error = %v", err)
+	}
+	manifest, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	sum := sha256.Sum256([]byte(`{"systemName": "TRAPPIST-1"}`))
+	installed := map[string]string{"trappist-1": hex.EncodeToString(sum[:])}
+	pending := manifest.Pending(func(name string) (string, bool) { hash, ok := installed[name]; return hash, ok })
+	if len(pending) != 1 || pending[0].Name != "kepler-90" || pending[0].Status != StatusNew {
+		t.Fatalf("Pending() = %+v, want only kepler-90 as new", pending)
+	}
+	installed["trappist-1"] = "0000"
+	if pending := manifest.Pending(func(name string) (string, bool) { hash, ok := installed[name]; return hash, ok }); len(pending) != 2 || pending[1].Status != StatusUpdated {
+		t.Errorf("Pending() = %+v, want trappist-1 updated", pending)
+	}
+
+	data, err := checker.Download(context.Background(), pending[0].Entry)
+	if err != nil || string(data) != `{"systemName": "Kepler-90"}` {
+		t.Errorf("Download() = %q, %v, want the listed file", data, err)
+	}
+	for _, entry := range manifest.Systems {
+		if entry.Name == "trappist-1" {
+			if _, err := checker.Download(context.Background(), entry); err == nil {
+				t.Error("Download() accepted a file that does not match its hash")
+			}
+		}
+	}
+}
+
+func TestCheckRejectsOtherKeys(t *testing.T) {
+	public, _, _ := ed25519.GenerateKey(nil)
+	_, other, _ := ed25519.GenerateKey(nil)
+	server := newIndex(t, other, map[string]string{"kepler-90": "{}"})
+
+	checker, err := NewChecker(server.URL+"/index.json", base64.StdEncoding.EncodeToString(public), server.Client())
+	if err != nil {
+		t.Fatalf("NewChecker() error = %v", err)
+	}
+	if _, err := checker.Check(context.Background()); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("Check() error = %v, want ErrBadSignature", err)
+	}
+
+	if _, err := NewChecker("ftp://example.com/index.json", base64.StdEncoding.EncodeToString(public), nil); err == nil {
+		t.Error("NewChecker() accepted a non-http URL")
+	}
+	if _, err := NewChecker(server.URL, "not a key", nil); err == nil {
+		t.Error("NewChecker() accepted a bad key")
+	}
+}
+
+func TestCheckRejectsEntriesNotNamedAfterTheirFile(t *testing.T) {
+	public, private, _ := ed25519.GenerateKey(nil)
+	for _, entry := range []Entry{
+		{Name: "kepler-90", File: "kepler-90b.json"},
+		{Name: "Kepler-90", File: "kepler-90.json"},
+		{Name: "kepler-90", File: "../kepler-90.json"},
+		{Name: "kepler-90"},
+	} {
+		data, err := json.Marshal(Manifest{Systems: []Entry{entry}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		mux := http.NewServeMux()
+		mux.HandleFunc("/index.json", func(w http.ResponseWriter, _ *http.Request) { w.Write(data) })
+		mux.HandleFunc("/index.json.sig", func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(base64.StdEncoding.EncodeToString(ed25519.Sign(private, data))))
+		})
+		server := httptest.NewServer(mux)
+
+		checker, err := NewChecker(server.URL+"/index.json", base64.StdEncoding.EncodeToString(public), server.Client())
+		if err != nil {
+			t.Fatalf("NewChecker() error = %v", err)
+		}
+		if manifest, err := checker.Check(context.Background()); err == nil || errors.Is(err, ErrBadSignature) {
+			t.Errorf("Check() of %+v = %+v, %v; want the entry rejected", entry, manifest, err)
+		}
+		server.Close()
+	}
+}
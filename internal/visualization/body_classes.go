@@ -0,0 +1,154 @@
+package visualization
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// earthMassKg is one Earth mass, the unit the planet classes are split by
+const earthMassKg = 5.97237e24
+
+// BodyClass is the kind of body a symbol is picked for when it has none of its own
+type BodyClass string
+
+const (
+	ClassUnknown     BodyClass = ""
+	ClassStar        BodyClass = "star"
+	ClassGasGiant    BodyClass = "gas_giant"
+	ClassIceGiant    BodyClass = "ice_giant"
+	ClassTerrestrial BodyClass = "terrestrial"
+	ClassDwarf       BodyClass = "dwarf"
+	ClassMoon        BodyClass = "moon"
+	ClassAsteroid    BodyClass = "asteroid"
+	ClassComet       BodyClass = "comet"
+)
+
+// BodyClasses lists every class a symbol can be set for, as written in settings
+var BodyClasses = []BodyClass{ClassStar, ClassGasGiant, ClassIceGiant, ClassTerrestrial, ClassDwarf, ClassMoon, ClassAsteroid, ClassComet}
+
+// ClassRule puts the bodies it matches in a class
+type ClassRule struct {
+	Class BodyClass
+	Match func(body models.CelestialBody) bool
+}
+
+// BodyClassifier sorts bodies into classes by trying its rules in turn; the first
+// that matches wins
+type BodyClassifier struct {
+	rules []ClassRule
+}
+
+// NewBodyClassifier creates a classifier trying rules in the order given
+func NewBodyClassifier(rules ...ClassRule) *BodyClassifier {
+	return &BodyClassifier{rules: rules}
+}
+
+// DefaultBodyClassifier goes by the body type a system file or the API gives, then
+// for planets by mass, or by radius when the mass is not known: 50 Earth masses
+// or 35,000 km make a gas giant, 10 Earth masses or 15,000 km an ice giant, and
+// under a hundredth of an Earth mass or 1,500 km a dwarf
+func DefaultBodyClassifier() *BodyClassifier {
+	return NewBodyClassifier(
+		ClassRule{ClassStar, bodyTypeIs("star")},
+		ClassRule{ClassComet, bodyTypeIs("comet")},
+		ClassRule{ClassAsteroid, bodyTypeIs("asteroid")},
+		ClassRule{ClassDwarf, bodyTypeIs("dwarf planet")},
+		ClassRule{ClassMoon, func(body models.CelestialBody) bool {
+			return body.AroundPlanet != nil || strings.EqualFold(body.BodyType, "moon")
+		}},
+		ClassRule{ClassGasGiant, planetAtLeast(50, 35000)},
+		ClassRule{ClassIceGiant, planetAtLeast(10, 15000)},
+		ClassRule{ClassDwarf, func(body models.CelestialBody) bool {
+			if mass := body.GetMassKg(); mass > 0 {
+				return mass < 0.01*earthMassKg
+			}
+			return body.MeanRadius > 0 && body.MeanRadius < 1500
+		}},
+		ClassRule{ClassTerrestrial, func(body models.CelestialBody) bool {
+			return body.GetMassKg() > 0 || body.MeanRadius > 0
+		}},
+	)
+}
+
+// Register adds a rule tried before all the others, so it can take bodies from
+// the classes the default rules would put them in
+func (c *BodyClassifier) Register(rule ClassRule) {
+	c.rules = append([]ClassRule{rule}, c.rules...)
+}
+
+// Classify returns the class of the first rule matching body, or ClassUnknown
+func (c *BodyClassifier) Classify(body models.CelestialBody) BodyClass {
+	for _, rule := range c.rules {
+		if rule.Match(body) {
+			return rule.Class
+		}
+	}
+	return ClassUnknown
+}
+
+// bodyTypeIs matches bodies of a body type, in any case
+func bodyTypeIs(bodyType string) func(models.CelestialBody) bool {
+	return func(body models.CelestialBody) bool {
+		return strings.EqualFold(body.BodyType, bodyType)
+	}
+}
+
+// planetAtLeast matches bodies of at least earthMasses, or when their mass is not
+// known of at least radiusKm
+func planetAtLeast(earthMasses, radiusKm float64) func(models.CelestialBody) bool {
+	return func(body models.CelestialBody) bool {
+		if mass := body.GetMassKg(); mass > 0 {
+			return mass >= earthMasses*earthMassKg
+		}
+		return body.MeanRadius >= radiusKm
+	}
+}
+
+// WithClassSymbols returns the set with the symbols for classes of body replaced,
+// e.g. {"gas_giant": "◍"}. Unknown classes and symbols that are not one printable
+// character, or not ASCII in the ASCII set, are skipped and returned as errors.
+func (s SymbolSet) WithClassSymbols(overrides map[string]string) (SymbolSet, []error) {
+	if len(overrides) == 0 {
+		return s, nil
+	}
+	classes := make(map[BodyClass]rune, len(s.Classes)+len(overrides))
+	for class, symbol := range s.Classes {
+		classes[class] = symbol
+	}
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		class := BodyClass(strings.ToLower(strings.TrimSpace(name)))
+		if class == ClassUnknown || !knownClass(class) {
+			errs = append(errs, fmt.Errorf("unknown body class %q", name))
+			continue
+		}
+		symbol, ok := ParseSymbol(overrides[name])
+		if !ok || (s.ASCII && symbol >= unicode.MaxASCII) {
+			errs = append(errs, fmt.Errorf("symbol %q for %s cannot be shown", overrides[name], name))
+			continue
+		}
+		classes[class] = symbol
+	}
+	s.Classes = classes
+	return s, errs
+}
+
+func knownClass(class BodyClass) bool {
+	for _, known := range BodyClasses {
+		if class == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package visualization
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestDefaultBodyClassifier(t *testing.T) {
+	withMass := func(b models.CelestialBody, earthMasses float64) models.CelestialBody {
+		b.Mass = models.Mass{MassValue: earthMasses * 5.97237, MassExponent: 24}
+		return b
+	}
+	moon := body("Io", "Moon", 421700, 1821, 1.77)
+	moon.AroundPlanet = &models.Planet{Planet: "jupiter"}
+
+	tests := []struct {
+		body models.CelestialBody
+		want BodyClass
+	}{
+		{body("Kepler-90", "Star", 0, 800000, 0), ClassStar},
+		{body("Halley", "Comet", 2.7e9, 5, 27500), ClassComet},
+		{body("Vesta", "Asteroid", 3.5e8, 262, 1325), ClassAsteroid},
+		{body("Eris", "Dwarf Planet", 1e10, 1163, 203830), ClassDwarf},
+		{moon, ClassMoon},
+		{withMass(body("51 Pegasi b", "Planet", 7.9e6, 0, 4.2), 150), ClassGasGiant},
+		{withMass(body("GJ 436 b", "Planet", 4.2e6, 0, 2.6), 22), ClassIceGiant},
+		{withMass(body("Kepler-22 b", "Planet", 1.3e8, 0, 290), 5), ClassTerrestrial},
+		{withMass(body("Tiny", "Planet", 1e8, 0, 100), 0.002), ClassDwarf},
+		{body("Kepler-90 h", "Planet", 1.5e8, 71000, 332), ClassGasGiant},
+		{body("Kepler-90 g", "Planet", 1.1e8, 20000, 210), ClassIceGiant},
+		{body("TRAPPIST-1 e", "Planet", 4.2e6, 5800, 6.1), ClassTerrestrial},
+		{body("Unknown", "Planet", 1e8, 0, 100), ClassUnknown},
+	}
+	classifier := DefaultBodyClassifier()
+	for _, tt := range tests {
+		if got := classifier.Classify(tt.body); got != tt.want {
+			t.Errorf("Classify(%s) = %q, want %q", tt.body.EnglishName, got, tt.want)
+		}
+	}
+
+	// A registered rule goes before the defaults
+	classifier.Register(ClassRule{ClassComet, func(b models.CelestialBody) bool { return b.EnglishName == "Oumuamua" }})
+	if got := classifier.Classify(body("Oumuamua", "Asteroid", 1e8, 0.1, 0)); got != ClassComet {
+		t.Errorf("Classify(Oumuamua) = %q, want the registered rule's comet", got)
+	}
+}
+
+func TestSymbolSetBody(t *testing.T) {
+	tests := []struct {
+		set   SymbolSet
+		name  string
+		class BodyClass
+		want  rune
+	}{
+		{UnicodeSymbols, "Jupiter", ClassGasGiant, '♃'},
+		{UnicodeSymbols, "Kepler-90 h", ClassGasGiant, '◉'},
+		{UnicodeSymbols, "Halley", ClassComet, '☄'},
+		{UnicodeSymbols, "Nobody", ClassUnknown, UnicodeSymbols.Generic},
+		{ASCIISymbols, "Kepler-90 h", ClassGasGiant, 'k'},
+	}
+	for _, tt := range tests {
+		if got := tt.set.Body(models.CelestialBody{EnglishName: tt.name}, tt.class); got != tt.want {
+			t.Errorf("Body(%s, %q) = %q, want %q", tt.name, tt.class, got, tt.want)
+		}
+	}
+}
+
+func TestWithClassSymbols(t *testing.T) {
+	themed, errs := UnicodeSymbols.WithClassSymbols(map[string]string{
+		"gas_giant": "◍",
+		"Star":      "✷",
+		"nebula":    "~",
+		"comet":     "too long",
+	})
+	if len(errs) != 2 {
+		t.Errorf("WithClassSymbols() errors = %v, want the unknown class and the bad symbol", errs)
+	}
+	if got := themed.Body(models.CelestialBody{EnglishName: "Kepler-90 h"}, ClassGasGiant); got != '◍' {
+		t.Errorf("gas giant = %q, want ◍", got)
+	}
+	if got := themed.Star(""); got != '✷' {
+		t.Errorf("Star(\"\") = %q, want the theme's ✷", got)
+	}
+	if got := themed.Body(models.CelestialBody{}, ClassComet); got != '☄' {
+		t.Errorf("comet = %q, want the default ☄ kept", got)
+	}
+	if UnicodeSymbols.Classes[ClassGasGiant] != '◉' {
+		t.Error("WithClassSymbols() changed the set it was called on")
+	}
+
+	if _, errs := ASCIISymbols.WithClassSymbols(map[string]string{"comet": "☄"}); len(errs) != 1 {
+		t.Errorf("ASCII set took a non-ASCII symbol, errors = %v", errs)
+	}
+}
@@ -0,0 +1,77 @@
+package visualization
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+// bodyStyles holds the symbols of the bodies being drawn by name, from a system
+// file's overrides or else their class, and the colors a system file sets by the
+// symbol the body ends up drawn with
+type bodyStyles struct {
+	symbols map[string]rune
+	colors  map[rune]tcell.Color
+
+	// drawn is the bodies in the order given, for the legend
+	drawn []legendBody
+}
+
+// ParseDisplayColor reads a body's displayColor: a color name such as "crimson"
+// (any case) or a #rrggbb hex value
+func ParseDisplayColor(name string) (tcell.Color, bool) {
+	c := tcell.GetColor(strings.ToLower(strings.TrimSpace(name)))
+	return c, c != tcell.ColorDefault
+}
+
+// ParseSymbol reads a body's symbol, which must be one printable character
+func ParseSymbol(symbol string) (rune, bool) {
+	if utf8.RuneCountInString(symbol) != 1 {
+		return 0, false
+	}
+	r, _ := utf8.DecodeRuneInString(symbol)
+	return r, r != utf8.RuneError && unicode.IsGraphic(r) && !unicode.IsSpace(r)
+}
+
+// SetBodyStyles takes the symbols and colors of the bodies about to be drawn: a
+// system file's symbol, else the set's symbol for the body or its class. A symbol
+// the current set cannot show (anything but ASCII in the ASCII set) is ignored,
+// and a color then goes to the body's usual symbol.
+func (cor *CelestialObjectRenderer) SetBodyStyles(stars, planets []models.CelestialBody) {
+	styles := bodyStyles{symbols: map[string]rune{}, colors: map[rune]tcell.Color{}}
+	add := func(body models.CelestialBody, usual rune, kind string) {
+		symbol := usual
+		styles.symbols[body.EnglishName] = usual
+		if custom, ok := ParseSymbol(body.Symbol); ok && (!cor.symbols.ASCII || custom < unicode.MaxASCII) {
+			styles.symbols[body.EnglishName] = custom
+			symbol = custom
+			kind = ""
+		}
+		if c, ok := ParseDisplayColor(body.DisplayColor); ok {
+			styles.colors[symbol] = c
+		}
+		styles.drawn = append(styles.drawn, legendBody{name: body.EnglishName, symbol: symbol, kind: kind})
+	}
+
+	for _, star := range stars {
+		add(star, cor.symbolForStar(star), cor.starKind(star))
+	}
+	for _, planet := range planets {
+		class := cor.classifier.Classify(planet)
+		symbol, kind := cor.symbols.Body(planet, class), ""
+		if classSymbol, ok := cor.symbols.Classes[class]; ok && classSymbol == symbol {
+			kind = classNames[class]
+		}
+		add(planet, symbol, kind)
+	}
+	cor.styles = styles
+}
+
+// BodyColor returns the color a system file gave the bodies drawn with symbol
+func (cor *CelestialObjectRenderer) BodyColor(symbol rune) (tcell.Color, bool) {
+	c, ok := cor.styles.colors[symbol]
+	return c, ok
+}
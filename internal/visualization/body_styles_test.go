@@ -0,0 +1,69 @@
+package visualization
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestParseDisplayColor(t *testing.T) {
+	tests := []struct {
+		name string
+		want tcell.Color
+		ok   bool
+	}{
+		{"crimson", tcell.ColorCrimson, true},
+		{" Crimson ", tcell.ColorCrimson, true},
+		{"#ff8800", tcell.NewHexColor(0xff8800), true},
+		{"not-a-colour", tcell.ColorDefault, false},
+		{"", tcell.ColorDefault, false},
+	}
+	for _, tt := range tests {
+		if got, ok := ParseDisplayColor(tt.name); got != tt.want || ok != tt.ok {
+			t.Errorf("ParseDisplayColor(%q) = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseSymbol(t *testing.T) {
+	for symbol, want := range map[string]bool{"◆": true, "x": true, "": false, "ab": false, " ": false} {
+		if _, ok := ParseSymbol(symbol); ok != want {
+			t.Errorf("ParseSymbol(%q) ok = %v, want %v", symbol, ok, want)
+		}
+	}
+}
+
+func TestBodyStylesOverrideSymbolsAndColors(t *testing.T) {
+	star := body("Ember", "Star", 0, 500000, 0)
+	star.StellarClass = "M5V"
+	star.DisplayColor = "orangered"
+	painted := body("Painted", "Planet", 1e8, 5000, 100)
+	painted.DisplayColor = "crimson"
+	painted.Symbol = "◆"
+	plain := body("Plain", "Planet", 2e8, 5000, 200)
+
+	r := NewRendererWithDefaults(80, 24)
+	r.RenderSolarSystemDataWithPositions([]models.CelestialBody{star, painted, plain}, 80, 24, 80, 24)
+
+	if got := r.GetPlanetSymbol("Painted"); got != '◆' {
+		t.Errorf("Painted symbol = %c, want ◆", got)
+	}
+	if got, ok := r.GetBodyColor('◆'); !ok || got != tcell.ColorCrimson {
+		t.Errorf("◆ color = %v, %v; want crimson", got, ok)
+	}
+	if got := r.GetPlanetSymbol("Plain"); got != UnicodeSymbols.Classes[ClassTerrestrial] {
+		t.Errorf("Plain symbol = %c, want the terrestrial one", got)
+	}
+	// Without a symbol of its own, the star's color goes to its usual symbol
+	if got, ok := r.GetBodyColor(UnicodeSymbols.Star("M")); !ok || got != tcell.ColorOrangeRed {
+		t.Errorf("star color = %v, %v; want orangered", got, ok)
+	}
+
+	// The ASCII set cannot show ◆, so the body keeps its initial
+	r.SetSymbols(ASCIISymbols)
+	r.RenderSolarSystemDataWithPositions([]models.CelestialBody{star, painted, plain}, 80, 24, 80, 24)
+	if got := r.GetPlanetSymbol("Painted"); got != 'p' {
+		t.Errorf("ASCII Painted symbol = %c, want p", got)
+	}
+}
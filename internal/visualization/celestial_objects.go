@@ -4,6 +4,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/furan917/go-solar-system/internal/constants"
 	"github.com/furan917/go-solar-system/internal/models"
 	"github.com/furan917/go-solar-system/internal/orbital"
 )
@@ -15,35 +16,44 @@ type StarPosition struct {
 
 // CelestialObjectRenderer handles rendering of celestial objects
 type CelestialObjectRenderer struct {
-	circleDrawer      *CircleDrawer
-	startTime         time.Time
-	epochTime         time.Time
-	width             int
-	height            int
-	calculatorFactory *orbital.CalculatorFactory
+	circleDrawer *CircleDrawer
+	startTime    time.Time
+	width        int
+	height       int
+	clock        *orbital.SimulationClock
+	ephemeris    *orbital.Ephemeris
+	symbols      SymbolSet
+	classifier   *BodyClassifier // picks the class symbol of bodies the set has no symbol for
+	styles       bodyStyles
+	source       orbital.Clock
+	miniature    bool // every body at its smallest, for insets
 }
 
 // NewCelestialObjectRenderer creates a new celestial object renderer
 func NewCelestialObjectRenderer(circleDrawer *CircleDrawer, width, height int) *CelestialObjectRenderer {
-	epoch := time.Now()
+	source := orbital.SystemClock{}
+	clock := orbital.NewSimulationClockWithSource(constants.SimulationSpeed, source)
 	return &CelestialObjectRenderer{
-		circleDrawer:      circleDrawer,
-		startTime:         time.Now(),
-		epochTime:         epoch,
-		width:             width,
-		height:            height,
-		calculatorFactory: orbital.NewCalculatorFactory(),
+		circleDrawer: circleDrawer,
+		startTime:    source.Now(),
+		source:       source,
+		width:        width,
+		height:       height,
+		clock:        clock,
+		ephemeris:    orbital.NewEphemeris(clock.Start()),
+		symbols:      UnicodeSymbols,
+		classifier:   DefaultBodyClassifier(),
 	}
 }
 
 // RenderSun renders the sun at the center
-func (cor *CelestialObjectRenderer) RenderSun(grid [][]rune, centerX, centerY int) {
+func (cor *CelestialObjectRenderer) RenderSun(grid *Grid, centerX, centerY int) {
 	sunRadius := cor.scaleSunSize()
-	cor.circleDrawer.DrawFilledCircle(grid, centerX, centerY, sunRadius, '☉')
+	cor.circleDrawer.DrawFilledCircle(grid, centerX, centerY, sunRadius, cor.symbols.Sun)
 }
 
 // RenderStars renders multiple stars for multi-star systems
-func (cor *CelestialObjectRenderer) RenderStars(grid [][]rune, centerX, centerY int, stars []models.CelestialBody) {
+func (cor *CelestialObjectRenderer) RenderStars(grid *Grid, centerX, centerY int, stars []models.CelestialBody) {
 	if len(stars) == 1 {
 		starRadius := cor.scaleStarSize(stars[0].MeanRadius, len(stars))
 		symbol := cor.getStarSymbol(stars[0])
@@ -60,9 +70,7 @@ func (cor *CelestialObjectRenderer) RenderStars(grid [][]rune, centerX, centerY
 
 			px, py := positions[i].X, positions[i].Y
 			if starRadius <= 1 {
-				if cor.circleDrawer.isInBounds(px, py, len(grid[0]), len(grid)) {
-					grid[py][px] = symbol
-				}
+				grid.Set(px, py, symbol)
 			} else {
 				cor.circleDrawer.DrawFilledCircle(grid, px, py, starRadius, symbol)
 			}
@@ -70,49 +78,64 @@ func (cor *CelestialObjectRenderer) RenderStars(grid [][]rune, centerX, centerY
 	}
 }
 
+// RenderCentralBody draws a body other than a star in the middle of a frame, as
+// large as the Sun is drawn so that its satellites' orbits clear it
+func (cor *CelestialObjectRenderer) RenderCentralBody(grid *Grid, centerX, centerY int, body models.CelestialBody) {
+	cor.circleDrawer.DrawFilledCircle(grid, centerX, centerY, cor.scaleSunSize(), cor.GetPlanetSymbol(body.EnglishName))
+}
+
 // RenderPlanet renders a planet at its orbital position
-func (cor *CelestialObjectRenderer) RenderPlanet(grid [][]rune, centerX, centerY int, planet models.CelestialBody, radius float64) {
-	angle := cor.getOrbitalAngle(planet)
-	px, py := cor.circleDrawer.CalculatePosition(centerX, centerY, radius, angle)
+func (cor *CelestialObjectRenderer) RenderPlanet(grid *Grid, centerX, centerY int, planet models.CelestialBody, radius float64) {
+	px, py := cor.GetPlanetPosition(centerX, centerY, planet, radius)
 
 	planetRadius := cor.scalePlanetSize(planet.MeanRadius)
 	symbol := cor.GetPlanetSymbol(planet.EnglishName)
 
 	if planetRadius <= 1 {
-		if cor.circleDrawer.isInBounds(px, py, len(grid[0]), len(grid)) {
-			grid[py][px] = symbol
-		}
+		grid.Set(px, py, symbol)
 	} else {
 		cor.circleDrawer.DrawFilledCircle(grid, px, py, planetRadius, symbol)
 	}
 }
 
 // RenderOrbit renders an orbital path
-func (cor *CelestialObjectRenderer) RenderOrbit(grid [][]rune, centerX, centerY int, radius float64) {
-	cor.circleDrawer.DrawCircle(grid, centerX, centerY, radius, '·')
+func (cor *CelestialObjectRenderer) RenderOrbit(grid *Grid, centerX, centerY int, radius float64) {
+	cor.circleDrawer.DrawCircle(grid, centerX, centerY, radius, cor.symbols.Orbit)
 }
 
-// getOrbitalAngle calculates the current orbital angle for a planet using realistic orbital mechanics
-func (cor *CelestialObjectRenderer) getOrbitalAngle(planet models.CelestialBody) float64 {
-	if planet.SideralOrbit <= 0 {
-		return 0
+// RenderBodyOrbit renders a body's orbital path, drawing a true ellipse when orbital elements are known
+func (cor *CelestialObjectRenderer) RenderBodyOrbit(grid *Grid, centerX, centerY int, planet models.CelestialBody, radius float64) {
+	if planet.OrbitalElements == nil {
+		cor.RenderOrbit(grid, centerX, centerY, radius)
+		return
 	}
 
-	// Calculate mean anomaly based on real orbital mechanics
-	meanAnomaly := cor.calculateMeanAnomaly(planet)
+	elements := planet.OrbitalElements
+	cor.circleDrawer.DrawEllipse(grid, centerX, centerY, radius, elements.Eccentricity, orbital.PeriapsisLongitude(planet), cor.symbols.Orbit)
+}
 
-	// For visualization purposes, we'll use a simplified approach
-	// In a real implementation, you'd solve Kepler's equation for eccentric anomaly
-	// then calculate true anomaly, but for circular orbits this approximation works well
+// GetPlanetPosition returns the screen position of a planet on its (scaled) orbit
+func (cor *CelestialObjectRenderer) GetPlanetPosition(centerX, centerY int, planet models.CelestialBody, radius float64) (int, int) {
+	angle := cor.getOrbitalAngle(planet)
 
-	// Apply eccentricity correction (simplified)
-	if planet.Eccentricity > 0 {
-		// Simple approximation: true anomaly ≈ mean anomaly + 2*e*sin(mean anomaly)
-		trueAnomaly := meanAnomaly + 2*planet.Eccentricity*math.Sin(meanAnomaly)
-		return math.Mod(trueAnomaly, 2*math.Pi)
+	if planet.OrbitalElements != nil {
+		elements := planet.OrbitalElements
+		return cor.circleDrawer.CalculateEllipsePosition(centerX, centerY, radius, elements.Eccentricity, orbital.PeriapsisLongitude(planet), angle)
 	}
 
-	return math.Mod(meanAnomaly, 2*math.Pi)
+	return cor.circleDrawer.CalculatePosition(centerX, centerY, radius, angle)
+}
+
+// GetWorldPosition returns a planet's unscaled position in kilometres for the same
+// moment GetPlanetPosition draws it
+func (cor *CelestialObjectRenderer) GetWorldPosition(planet models.CelestialBody) WorldPoint {
+	x, y := cor.ephemeris.Position(planet, cor.clock.Now())
+	return WorldPoint{X: x, Y: y}
+}
+
+// getOrbitalAngle returns the planet's current true anomaly on the simulated timeline
+func (cor *CelestialObjectRenderer) getOrbitalAngle(planet models.CelestialBody) float64 {
+	return cor.ephemeris.TrueAnomaly(planet, cor.clock.Now())
 }
 
 // scalePlanetSize scales planet size based on actual radius data and terminal size
@@ -121,6 +144,10 @@ func (cor *CelestialObjectRenderer) scalePlanetSize(meanRadius float64) int {
 		return 1
 	}
 
+	if cor.miniature {
+		return 1
+	}
+
 	terminalSizeFactor := cor.getTerminalSizeFactor()
 
 	// Use logarithmic scaling for more realistic size representation
@@ -163,6 +190,9 @@ func (cor *CelestialObjectRenderer) scalePlanetSize(meanRadius float64) int {
 
 // scaleSunSize scales the sun's size based on terminal dimensions
 func (cor *CelestialObjectRenderer) scaleSunSize() int {
+	if cor.miniature {
+		return 1
+	}
 	terminalSizeFactor := cor.getTerminalSizeFactor()
 
 	// Much smaller base sun size to stay within first orbit
@@ -214,39 +244,29 @@ func (cor *CelestialObjectRenderer) getTerminalSizeFactor() float64 {
 	return sizeFactor
 }
 
-// GetPlanetSymbol returns the Unicode symbol for a celestial body
+// GetPlanetSymbol returns the symbol for a celestial body: the one the bodies
+// being drawn were given, else the one its name alone picks
 func (cor *CelestialObjectRenderer) GetPlanetSymbol(name string) rune {
-	// Known solar system symbols for backward compatibility
-	knownSymbols := map[string]rune{
-		"Sun":     '☉',
-		"Mercury": '☿',
-		"Venus":   '♀',
-		"Earth":   '♁',
-		"Mars":    '♂',
-		"Jupiter": '♃',
-		"Saturn":  '♄',
-		"Uranus":  '♅',
-		"Neptune": '♆',
-		"Pluto":   '♇',
-	}
-
-	if symbol, exists := knownSymbols[name]; exists {
+	if symbol, ok := cor.styles.symbols[name]; ok {
 		return symbol
 	}
-
-	return cor.generateGenericSymbol(name)
+	return cor.symbols.Planet(name)
 }
 
-// generateGenericSymbol creates a symbol for unknown celestial bodies
-func (cor *CelestialObjectRenderer) generateGenericSymbol(name string) rune {
-	genericSymbols := []rune{'●', '◉', '◎', '○', '◯', '⬤', '⚫', '⚪', '🪐', '🌍', '🌎', '🌏', '🌑', '🌒', '🌓', '🌔', '🌕', '🌖', '🌗', '🌘'}
+// SetMiniature draws every body at its smallest, whatever the size of the frame
+func (cor *CelestialObjectRenderer) SetMiniature(miniature bool) {
+	cor.miniature = miniature
+}
 
-	hash := 0
-	for _, char := range name {
-		hash = (hash + int(char)) % len(genericSymbols)
-	}
+// BodyClassifier returns the rules bodies are given class symbols by, to register
+// more on
+func (cor *CelestialObjectRenderer) BodyClassifier() *BodyClassifier {
+	return cor.classifier
+}
 
-	return genericSymbols[hash]
+// SetSymbols selects the glyphs bodies and orbits are drawn with
+func (cor *CelestialObjectRenderer) SetSymbols(symbols SymbolSet) {
+	cor.symbols = symbols
 }
 
 // GetOrbitalAngle returns the current orbital angle for a planet (exposed for position calculation)
@@ -254,6 +274,45 @@ func (cor *CelestialObjectRenderer) GetOrbitalAngle(planet models.CelestialBody)
 	return cor.getOrbitalAngle(planet)
 }
 
+// GetCurrentMeanAnomaly returns where a planet is in its orbit at the current
+// simulated time, in radians (exposed for seeding orbital elements)
+func (cor *CelestialObjectRenderer) GetCurrentMeanAnomaly(planet models.CelestialBody) float64 {
+	return cor.ephemeris.MeanAnomaly(planet, cor.clock.Now())
+}
+
+// SetClock replaces the real-time source behind the animation and restarts the
+// simulation clock from it. Golden tests and deterministic mode use it to render
+// fixed moments; call it before handing the clock or ephemeris to anything else.
+func (cor *CelestialObjectRenderer) SetClock(source orbital.Clock) {
+	cor.source = source
+	cor.startTime = source.Now()
+	cor.clock = orbital.NewSimulationClockWithSource(constants.SimulationSpeed, source)
+	cor.ephemeris = orbital.NewEphemeris(cor.clock.Start())
+}
+
+// SetTimeSource is SetClock for a plain function
+func (cor *CelestialObjectRenderer) SetTimeSource(now func() time.Time) {
+	cor.SetClock(orbital.ClockFunc(now))
+}
+
+// shareTimeline takes the clock, ephemeris and start time of other
+func (cor *CelestialObjectRenderer) shareTimeline(other *CelestialObjectRenderer) {
+	cor.clock = other.clock
+	cor.ephemeris = other.ephemeris
+	cor.startTime = other.startTime
+	cor.source = other.source
+}
+
+// GetClock returns the simulation clock driving the animation
+func (cor *CelestialObjectRenderer) GetClock() *orbital.SimulationClock {
+	return cor.clock
+}
+
+// GetEphemeris returns the ephemeris used to place bodies
+func (cor *CelestialObjectRenderer) GetEphemeris() *orbital.Ephemeris {
+	return cor.ephemeris
+}
+
 // GetPlanetSize returns the scaled planet size (exposed for click detection)
 func (cor *CelestialObjectRenderer) GetPlanetSize(meanRadius float64) int {
 	return cor.scalePlanetSize(meanRadius)
@@ -270,28 +329,6 @@ func (cor *CelestialObjectRenderer) GetSunSize() int {
 	return cor.scaleSunSize()
 }
 
-// calculateMeanAnomaly calculates the mean anomaly for a planet based on its orbital period
-func (cor *CelestialObjectRenderer) calculateMeanAnomaly(planet models.CelestialBody) float64 {
-	currentMeanAnomaly := cor.calculateCurrentMeanAnomaly(planet)
-	elapsed := time.Since(cor.startTime).Seconds()
-	orbitalPeriodSeconds := planet.SideralOrbit * 24 * 3600
-	meanMotion := 2 * math.Pi / orbitalPeriodSeconds
-
-	// Scale time for animation purposes (make it much faster for visualization)
-	// Each real day = 0.1 seconds in animation (10x faster than before)
-	animationSpeedFactor := 864000.0
-
-	animatedMeanAnomaly := currentMeanAnomaly + meanMotion*elapsed*animationSpeedFactor
-
-	return animatedMeanAnomaly
-}
-
-// calculateCurrentMeanAnomaly calculates where a planet should be in its orbit today
-func (cor *CelestialObjectRenderer) calculateCurrentMeanAnomaly(planet models.CelestialBody) float64 {
-	calculator := cor.calculatorFactory.CreateCalculator(planet, cor.epochTime)
-	return calculator.CalculateMeanAnomaly(planet, time.Now())
-}
-
 // calculateStarPositions calculates positions for multiple stars around their barycenter
 func (cor *CelestialObjectRenderer) calculateStarPositions(stars []models.CelestialBody, centerX, centerY int) []StarPosition {
 	if len(stars) <= 1 {
@@ -322,14 +359,16 @@ func (cor *CelestialObjectRenderer) calculateBinaryStarPositions(stars []models.
 	r1 := baseSeparation * (mass2 / totalMass)
 	r2 := baseSeparation * (mass1 / totalMass)
 
-	elapsed := time.Since(cor.startTime).Seconds()
+	elapsed := cor.source.Now().Sub(cor.startTime).Seconds()
 	orbitalPeriod := cor.calculateBinaryOrbitalPeriod(stars, baseSeparation)
 	angle := 2 * math.Pi * elapsed / orbitalPeriod
 
+	// Cells are taller than they are wide, so the vertical offset is squashed
+	aspect := cor.circleDrawer.AspectRatio()
 	x1 := centerX + int(r1*math.Cos(angle))
-	y1 := centerY + int(r1*math.Sin(angle)*0.5) // 0.5 for terminal aspect ratio
-	x2 := centerX - int(r2*math.Cos(angle))     // Opposite side
-	y2 := centerY - int(r2*math.Sin(angle)*0.5)
+	y1 := centerY + int(r1*math.Sin(angle)/aspect)
+	x2 := centerX - int(r2*math.Cos(angle)) // Opposite side
+	y2 := centerY - int(r2*math.Sin(angle)/aspect)
 
 	return []StarPosition{
 		{x1, y1},
@@ -350,13 +389,13 @@ func (cor *CelestialObjectRenderer) calculateMultipleStarPositions(stars []model
 	for i := range stars {
 		angle := 2 * math.Pi * float64(i) / float64(len(stars))
 
-		elapsed := time.Since(cor.startTime).Seconds()
+		elapsed := cor.source.Now().Sub(cor.startTime).Seconds()
 		rotationPeriod := cor.calculateMultiStarRotationPeriod(len(stars))
 		rotationAngle := 2 * math.Pi * elapsed / rotationPeriod
 		angle += rotationAngle
 
 		x := centerX + int(ringRadius*math.Cos(angle))
-		y := centerY + int(ringRadius*math.Sin(angle)*0.5) // 0.5 for terminal aspect ratio
+		y := centerY + int(ringRadius*math.Sin(angle)/cor.circleDrawer.AspectRatio())
 
 		positions[i] = StarPosition{x, y}
 	}
@@ -380,15 +419,22 @@ func (cor *CelestialObjectRenderer) getStarMass(star models.CelestialBody) float
 	return 1.989e30
 }
 
-// GetBarycenter calculates the barycenter for multi-star systems
+// GetBarycenter returns the cell at the mass-weighted centre of the stars as they
+// are drawn around centerX, centerY at the current moment
 func (cor *CelestialObjectRenderer) GetBarycenter(stars []models.CelestialBody, centerX, centerY int) (int, int) {
 	if len(stars) <= 1 {
 		return centerX, centerY
 	}
 
-	// For visualization purposes, we keep the barycenter at screen center
-	// Real barycenter calculation would be more complex and might move the center off-screen
-	return centerX, centerY
+	positions := cor.calculateStarPositions(stars, centerX, centerY)
+	var totalMass, sumX, sumY float64
+	for i, star := range stars {
+		mass := cor.getStarMass(star)
+		totalMass += mass
+		sumX += mass * float64(positions[i].X)
+		sumY += mass * float64(positions[i].Y)
+	}
+	return int(math.Round(sumX / totalMass)), int(math.Round(sumY / totalMass))
 }
 
 // calculateBinarySeparation calculates appropriate separation for binary stars
@@ -466,30 +512,20 @@ func (cor *CelestialObjectRenderer) calculateMultiStarRotationPeriod(numStars in
 	return period
 }
 
-// getStarSymbol returns appropriate symbol and color for a star based on its type
+// getStarSymbol returns appropriate symbol for a star based on its type
 func (cor *CelestialObjectRenderer) getStarSymbol(star models.CelestialBody) rune {
-	// Check if we have stellar classification
-	stellarClass := cor.getStellarClass(star)
-
-	// Return symbol based on stellar class
-	switch stellarClass[0] {
-	case 'O', 'B': // Hot blue/blue-white stars
-		return '✦' // Blue star symbol
-	case 'A', 'F': // White/yellow-white stars
-		return '✧' // White star symbol
-	case 'G': // Yellow stars (like Sun)
-		return '☉' // Sun symbol
-	case 'K': // Orange stars
-		return '✩' // Orange star symbol
-	case 'M': // Red dwarf stars
-		return '✪' // Red star symbol
-	default:
-		// Unknown or special cases
-		if star.EnglishName == "Sun" {
-			return '☉'
-		}
-		return '⭐' // Generic star
+	if symbol, ok := cor.styles.symbols[star.EnglishName]; ok {
+		return symbol
+	}
+	return cor.symbolForStar(star)
+}
+
+// symbolForStar is a star's symbol from the symbol set, ignoring any override
+func (cor *CelestialObjectRenderer) symbolForStar(star models.CelestialBody) rune {
+	if star.EnglishName == "Sun" {
+		return cor.symbols.Sun
 	}
+	return cor.symbols.Star(cor.getStellarClass(star))
 }
 
 // getStellarClass extracts stellar classification from star data
@@ -523,9 +559,10 @@ func (cor *CelestialObjectRenderer) getStellarClass(star models.CelestialBody) s
 	}
 }
 
-// getStellarClassField extracts stellar class field from star data
+// getStellarClassField extracts stellar class field from star data, or its
+// spectral type when only that is given
 func (cor *CelestialObjectRenderer) getStellarClassField(star models.CelestialBody) string {
-	return star.StellarClass
+	return star.GetSpectralType()
 }
 
 // getTemperature extracts temperature from star data if available
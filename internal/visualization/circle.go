@@ -1,6 +1,10 @@
 package visualization
 
-import "math"
+import (
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/orbital"
+)
 
 // CircleDrawer handles drawing circular shapes with proper aspect ratio compensation
 type CircleDrawer struct {
@@ -14,42 +18,82 @@ func NewCircleDrawer(aspectRatio float64) *CircleDrawer {
 	}
 }
 
-// DrawCircle draws a circle outline on the grid with improved algorithm
-func (cd *CircleDrawer) DrawCircle(grid [][]rune, centerX, centerY int, radius float64, symbol rune) {
-	circumference := 2 * math.Pi * radius
-	steps := int(circumference * 4)
-	if steps < 720 {
-		steps = 720
-	}
+// AspectRatio returns the height-to-width ratio of a terminal cell in use
+func (cd *CircleDrawer) AspectRatio() float64 {
+	return cd.aspectRatio
+}
+
+// SetAspectRatio changes the cell ratio, e.g. once the terminal has been measured
+func (cd *CircleDrawer) SetAspectRatio(aspectRatio float64) {
+	cd.aspectRatio = aspectRatio
+}
+
+// DrawCircle draws a circle outline on the grid with improved algorithm. On a
+// high-resolution grid the outline is plotted with sub-cell points.
+func (cd *CircleDrawer) DrawCircle(grid *Grid, centerX, centerY int, radius float64, symbol rune) {
+	steps := cd.outlineSteps(grid, radius)
 
 	for i := 0; i < steps; i++ {
 		angle := float64(i) * 2 * math.Pi / float64(steps)
-		x := centerX + int(radius*math.Cos(angle)*cd.aspectRatio)
-		y := centerY + int(radius*math.Sin(angle))
 
-		if cd.isInBounds(x, y, len(grid[0]), len(grid)) && grid[y][x] == ' ' {
-			grid[y][x] = symbol
+		if grid.HighResolution() {
+			x, y := cd.calculatePoint(centerX, centerY, radius, angle)
+			grid.Plot(x, y, symbol)
+			continue
 		}
+
+		x := centerX + int(radius*math.Cos(angle)*cd.aspectRatio)
+		y := centerY + int(radius*math.Sin(angle))
+		grid.SetIfEmpty(x, y, symbol)
 	}
 }
 
-// DrawFilledCircle draws a filled circle on the grid
-func (cd *CircleDrawer) DrawFilledCircle(grid [][]rune, centerX, centerY, radius int, symbol rune) {
+// DrawFilledCircle draws a filled circle on the grid. On a high-resolution grid the
+// disc is filled with sub-cell points around the symbol in its centre cell.
+func (cd *CircleDrawer) DrawFilledCircle(grid *Grid, centerX, centerY, radius int, symbol rune) {
+	if grid.HighResolution() {
+		cd.fillDisc(grid, centerX, centerY, radius, symbol)
+		grid.Set(centerX, centerY, symbol)
+		return
+	}
+
 	for dy := -radius; dy <= radius; dy++ {
 		rowWidth := math.Sqrt(float64(radius*radius - dy*dy))
 		maxDx := int(rowWidth * cd.aspectRatio)
 
 		for dx := -maxDx; dx <= maxDx; dx++ {
-			x := centerX + dx
-			y := centerY + dy
+			grid.Set(centerX+dx, centerY+dy, symbol)
+		}
+	}
+}
 
-			if cd.isInBounds(x, y, len(grid[0]), len(grid)) {
-				grid[y][x] = symbol
-			}
+// DrawEllipse draws an elliptical orbit outline with one focus at the given centre.
+// periapsisAngle rotates the ellipse so its closest approach points in that direction.
+func (cd *CircleDrawer) DrawEllipse(grid *Grid, focusX, focusY int, semiMajor, eccentricity, periapsisAngle float64, symbol rune) {
+	eccentricity = orbital.ClampEccentricity(eccentricity)
+	steps := cd.outlineSteps(grid, semiMajor*(1+eccentricity))
+
+	for i := 0; i < steps; i++ {
+		trueAnomaly := float64(i) * 2 * math.Pi / float64(steps)
+
+		if grid.HighResolution() {
+			radius := ellipseRadius(semiMajor, eccentricity, trueAnomaly)
+			x, y := cd.calculatePoint(focusX, focusY, radius, trueAnomaly+periapsisAngle)
+			grid.Plot(x, y, symbol)
+			continue
 		}
+
+		x, y := cd.CalculateEllipsePosition(focusX, focusY, semiMajor, eccentricity, periapsisAngle, trueAnomaly)
+		grid.SetIfEmpty(x, y, symbol)
 	}
 }
 
+// CalculateEllipsePosition calculates a position on an elliptical orbit at the given true anomaly
+func (cd *CircleDrawer) CalculateEllipsePosition(focusX, focusY int, semiMajor, eccentricity, periapsisAngle, trueAnomaly float64) (int, int) {
+	radius := ellipseRadius(semiMajor, orbital.ClampEccentricity(eccentricity), trueAnomaly)
+	return cd.CalculatePosition(focusX, focusY, radius, trueAnomaly+periapsisAngle)
+}
+
 // CalculatePosition calculates a position on a circle at the given angle
 func (cd *CircleDrawer) CalculatePosition(centerX, centerY int, radius float64, angle float64) (int, int) {
 	x := centerX + int(radius*math.Cos(angle)*cd.aspectRatio)
@@ -57,7 +101,49 @@ func (cd *CircleDrawer) CalculatePosition(centerX, centerY int, radius float64,
 	return x, y
 }
 
-// isInBounds checks if coordinates are within grid bounds
-func (cd *CircleDrawer) isInBounds(x, y, width, height int) bool {
-	return x >= 0 && x < width && y >= 0 && y < height
+// calculatePoint returns a position on a circle in fractional cell coordinates,
+// measured from the middle of the centre cell
+func (cd *CircleDrawer) calculatePoint(centerX, centerY int, radius float64, angle float64) (float64, float64) {
+	x := float64(centerX) + 0.5 + radius*math.Cos(angle)*cd.aspectRatio
+	y := float64(centerY) + 0.5 + radius*math.Sin(angle)
+	return x, y
+}
+
+// fillDisc lights every sub-cell point inside the disc
+func (cd *CircleDrawer) fillDisc(grid *Grid, centerX, centerY, radius int, symbol rune) {
+	subX, subY := grid.SubCells()
+	r := float64(radius) + 0.5
+	cx, cy := float64(centerX)+0.5, float64(centerY)+0.5
+
+	minX := int(math.Floor((cx - r*cd.aspectRatio) * float64(subX)))
+	maxX := int(math.Ceil((cx + r*cd.aspectRatio) * float64(subX)))
+	minY := int(math.Floor((cy - r) * float64(subY)))
+	maxY := int(math.Ceil((cy + r) * float64(subY)))
+
+	for sy := minY; sy <= maxY; sy++ {
+		for sx := minX; sx <= maxX; sx++ {
+			x := (float64(sx) + 0.5) / float64(subX)
+			y := (float64(sy) + 0.5) / float64(subY)
+			dx := (x - cx) / cd.aspectRatio
+			dy := y - cy
+			if dx*dx+dy*dy <= r*r {
+				grid.Plot(x, y, symbol)
+			}
+		}
+	}
+}
+
+// outlineSteps picks enough samples for an outline to leave no gaps at the grid's resolution
+func (cd *CircleDrawer) outlineSteps(grid *Grid, radius float64) int {
+	subX, subY := grid.SubCells()
+	steps := int(2 * math.Pi * radius * 4 * float64(max(subX, subY)))
+	if steps < 720 {
+		steps = 720
+	}
+	return steps
+}
+
+// ellipseRadius returns the distance from the focus at the given true anomaly
+func ellipseRadius(semiMajor, eccentricity, trueAnomaly float64) float64 {
+	return semiMajor * (1 - eccentricity*eccentricity) / (1 + eccentricity*math.Cos(trueAnomaly))
 }
@@ -1,11 +1,53 @@
 package visualization
 
-import "github.com/furan917/go-solar-system/internal/models"
+import (
+	"math"
+	"unicode"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// BeltVisibility is how the debris belts are drawn
+type BeltVisibility int
+
+const (
+	// BeltsShown draws the belts as their system declares them
+	BeltsShown BeltVisibility = iota
+	// BeltsFaint draws them at a third of their density, to keep them out of the
+	// way of the orbits while still showing where they lie
+	BeltsFaint
+	// BeltsHidden leaves them off the map
+	BeltsHidden
+)
+
+// faintBeltDensity is the share of a belt's marks BeltsFaint keeps
+const faintBeltDensity = 1.0 / 3
+
+// fullBeltMarks is how many marks round a ring a belt of density 1 gets, one
+// every three degrees
+const fullBeltMarks = 120
+
+// Next returns the visibility after v, going round shown, faint, hidden
+func (v BeltVisibility) Next() BeltVisibility {
+	return (v + 1) % 3
+}
+
+// String names the visibility
+func (v BeltVisibility) String() string {
+	switch v {
+	case BeltsFaint:
+		return "faint"
+	case BeltsHidden:
+		return "hidden"
+	}
+	return "shown"
+}
 
-// DebrisBeltRenderer handles rendering of asteroid and Kuiper belts
+// DebrisBeltRenderer draws the debris belts a system declares
 type DebrisBeltRenderer struct {
 	circleDrawer *CircleDrawer
 	scaler       *DistanceScaler
+	symbols      SymbolSet
 }
 
 // NewDebrisBeltRenderer creates a new debris belt renderer
@@ -13,84 +55,51 @@ func NewDebrisBeltRenderer(circleDrawer *CircleDrawer, scaler *DistanceScaler) *
 	return &DebrisBeltRenderer{
 		circleDrawer: circleDrawer,
 		scaler:       scaler,
+		symbols:      UnicodeSymbols,
 	}
 }
 
-// RenderAsteroidBelt renders the asteroid belt between Mars and Jupiter
-func (dbr *DebrisBeltRenderer) RenderAsteroidBelt(grid [][]rune, centerX, centerY int, planets []models.CelestialBody) {
-	marsDistance, jupiterDistance := dbr.findPlanetDistances(planets, "Mars", "Jupiter")
-
-	innerRadius := dbr.scaler.ScaleDistance(marsDistance*1.5, planets)
-	outerRadius := dbr.scaler.ScaleDistance(jupiterDistance*0.6, planets)
-
-	dbr.renderDebrisBelt(grid, centerX, centerY, innerRadius, outerRadius, 10, 3, '∗')
-}
-
-// RenderKuiperBelt renders the Kuiper belt beyond Neptune
-func (dbr *DebrisBeltRenderer) RenderKuiperBelt(grid [][]rune, centerX, centerY int, planets []models.CelestialBody) {
-	neptuneDistance := dbr.findPlanetDistance(planets, "Neptune")
-
-	innerRadius := dbr.scaler.ScaleDistance(neptuneDistance*1.2, planets)
-	outerRadius := dbr.scaler.ScaleDistance(neptuneDistance*1.7, planets)
-
-	dbr.renderDebrisBelt(grid, centerX, centerY, innerRadius, outerRadius, 12, 4, '◦')
-}
-
-// findPlanetDistances finds distances for two planets
-func (dbr *DebrisBeltRenderer) findPlanetDistances(planets []models.CelestialBody, planet1, planet2 string) (float64, float64) {
-	var dist1, dist2 float64
-	defaults := map[string]float64{
-		"Mars":    1.5,
-		"Jupiter": 5.2,
-		"Neptune": 30.0,
-	}
-
-	for _, planet := range planets {
-		if planet.EnglishName == planet1 && planet.EnglishName != "Sun" {
-			dist1 = planet.SemimajorAxis
-		}
-		if planet.EnglishName == planet2 && planet.EnglishName != "Sun" {
-			dist2 = planet.SemimajorAxis
-		}
+// RenderBelt draws a belt as rings of marks between its inner and outer radius,
+// scaled like the planets' orbits. density scales how many marks it gets.
+func (dbr *DebrisBeltRenderer) RenderBelt(grid *Grid, centerX, centerY int, belt models.Belt, planets []models.CelestialBody, density float64) {
+	innerRadius := dbr.scaler.ScaleDistance(belt.InnerRadius, planets)
+	outerRadius := dbr.scaler.ScaleDistance(belt.OuterRadius, planets)
+	rings := belt.Rings
+	if rings <= 0 {
+		rings = min(max(int(math.Round((outerRadius-innerRadius)/2)), 2), 6)
 	}
+	marks := max(int(math.Round(fullBeltMarks*belt.MarkDensity()*density)), 8)
 
-	if dist1 == 0 {
-		dist1 = defaults[planet1]
-	}
-	if dist2 == 0 {
-		dist2 = defaults[planet2]
-	}
-
-	return dist1, dist2
+	dbr.renderDebrisBelt(grid, centerX, centerY, innerRadius, outerRadius, marks, rings, dbr.Symbol(belt))
 }
 
-// findPlanetDistance finds distance for a single planet
-func (dbr *DebrisBeltRenderer) findPlanetDistance(planets []models.CelestialBody, planetName string) float64 {
-	for _, planet := range planets {
-		if planet.EnglishName == planetName && planet.EnglishName != "Sun" {
-			return planet.SemimajorAxis
-		}
+// Symbol returns the glyph a belt is drawn with: its file's symbol if the current
+// set can show it, else the set's symbol for its kind
+func (dbr *DebrisBeltRenderer) Symbol(belt models.Belt) rune {
+	if custom, ok := ParseSymbol(belt.Symbol); ok && (!dbr.symbols.ASCII || custom < unicode.MaxASCII) {
+		return custom
 	}
-
-	defaults := map[string]float64{
-		"Neptune": 30.0,
+	if belt.Kind == models.BeltIcy {
+		return dbr.symbols.KuiperBelt
 	}
+	return dbr.symbols.AsteroidBelt
+}
 
-	return defaults[planetName]
+// SetSymbols selects the glyphs the belts are drawn with
+func (dbr *DebrisBeltRenderer) SetSymbols(symbols SymbolSet) {
+	dbr.symbols = symbols
 }
 
-// renderDebrisBelt renders a debris belt with specified parameters
-func (dbr *DebrisBeltRenderer) renderDebrisBelt(grid [][]rune, centerX, centerY int, innerRadius, outerRadius float64, angleStep, rings int, symbol rune) {
-	for angle := 0; angle < 360; angle += angleStep {
-		radians := float64(angle) * 3.14159 / 180
+// renderDebrisBelt draws rings of marks evenly spaced between two radii
+func (dbr *DebrisBeltRenderer) renderDebrisBelt(grid *Grid, centerX, centerY int, innerRadius, outerRadius float64, marks, rings int, symbol rune) {
+	for mark := 0; mark < marks; mark++ {
+		radians := 2 * math.Pi * float64(mark) / float64(marks)
 
 		for i := 0; i < rings; i++ {
 			radius := innerRadius + float64(i)*(outerRadius-innerRadius)/float64(rings)
 			x, y := dbr.circleDrawer.CalculatePosition(centerX, centerY, radius, radians)
 
-			if dbr.circleDrawer.isInBounds(x, y, len(grid[0]), len(grid)) && grid[y][x] == ' ' {
-				grid[y][x] = symbol
-			}
+			grid.SetIfEmpty(x, y, symbol)
 		}
 	}
 }
@@ -0,0 +1,76 @@
+package visualization
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestBeltVisibilityCycles(t *testing.T) {
+	want := []BeltVisibility{BeltsFaint, BeltsHidden, BeltsShown}
+	visibility := BeltsShown
+	for _, next := range want {
+		visibility = visibility.Next()
+		if visibility != next {
+			t.Fatalf("Next() = %v, want %v", visibility, next)
+		}
+	}
+}
+
+// beltMarks counts the marks a belt leaves on a 200x60 map of a star, a near
+// planet and a far one
+func beltMarks(belt models.Belt, density float64) int {
+	planets := []models.CelestialBody{body("Sun", "Star", 0, 695508, 0), body("Near", "Planet", 1e8, 6000, 200), body("Far", "Planet", 8e9, 6000, 100)}
+	scaler := NewDistanceScaler(200, 60)
+	grid := NewGrid(200, 60, RenderModeCells)
+	NewDebrisBeltRenderer(NewCircleDrawer(2), scaler).RenderBelt(grid, 100, 30, belt, planets, density)
+	count := 0
+	for _, row := range grid.Runes() {
+		for _, r := range row {
+			if r == UnicodeSymbols.KuiperBelt {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestRenderBeltDensity(t *testing.T) {
+	belt := models.Belt{Name: "Kuiper belt", InnerRadius: 4.5e9, OuterRadius: 7.5e9, Kind: models.BeltIcy}
+	full, faint := beltMarks(belt, 1), beltMarks(belt, faintBeltDensity)
+	if full == 0 || faint == 0 || faint >= full {
+		t.Errorf("marks = %d shown, %d faint, want fewer but some when faint", full, faint)
+	}
+}
+
+func TestRenderBeltRings(t *testing.T) {
+	belt := models.Belt{Name: "Wide belt", InnerRadius: 1e9, OuterRadius: 7.5e9, Kind: models.BeltIcy, Rings: 1}
+	one := beltMarks(belt, 1)
+	belt.Rings = 4
+	if four := beltMarks(belt, 1); four <= one {
+		t.Errorf("marks = %d in one ring, %d in four, want more rings to leave more marks", one, four)
+	}
+}
+
+func TestBeltSymbol(t *testing.T) {
+	dbr := NewDebrisBeltRenderer(NewCircleDrawer(2), NewDistanceScaler(80, 24))
+	tests := []struct {
+		belt models.Belt
+		want rune
+	}{
+		{models.Belt{Name: "rocky"}, UnicodeSymbols.AsteroidBelt},
+		{models.Belt{Name: "icy", Kind: models.BeltIcy}, UnicodeSymbols.KuiperBelt},
+		{models.Belt{Name: "own", Symbol: "≈"}, '≈'},
+		{models.Belt{Name: "bad", Symbol: "ab"}, UnicodeSymbols.AsteroidBelt},
+	}
+	for _, tt := range tests {
+		if got := dbr.Symbol(tt.belt); got != tt.want {
+			t.Errorf("Symbol(%s) = %q, want %q", tt.belt.Name, got, tt.want)
+		}
+	}
+
+	dbr.SetSymbols(ASCIISymbols)
+	if got := dbr.Symbol(models.Belt{Symbol: "≈"}); got != ASCIISymbols.AsteroidBelt {
+		t.Errorf("ASCII Symbol(≈) = %q, want the ASCII belt", got)
+	}
+}
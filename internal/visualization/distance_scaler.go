@@ -10,6 +10,15 @@ import (
 type DistanceScaler struct {
 	width  int
 	height int
+
+	// fixedMin and fixedMax, when set, replace the range taken from the bodies so
+	// several views can share one scale
+	fixedMin float64
+	fixedMax float64
+
+	// compact packs the orbits into a small frame: the innermost close round a
+	// miniature star and the outermost near the edge
+	compact bool
 }
 
 // NewDistanceScaler creates a new distance scaler
@@ -26,7 +35,10 @@ func (ds *DistanceScaler) ScaleDistance(distance float64, planets []models.Celes
 		return 0
 	}
 
-	minDistance, maxDistance := ds.findDistanceRange(planets)
+	minDistance, maxDistance := ds.fixedMin, ds.fixedMax
+	if maxDistance <= 0 {
+		minDistance, maxDistance = ds.findDistanceRange(planets)
+	}
 
 	if maxDistance <= minDistance || maxDistance-minDistance < minDistance*0.1 {
 		return 7.0
@@ -38,14 +50,41 @@ func (ds *DistanceScaler) ScaleDistance(distance float64, planets []models.Celes
 
 	normalized := (logCurrent - logMin) / (logMax - logMin)
 
-	minRadius := 7.0
-	maxRadius := math.Min(float64(ds.width/2-3), float64(ds.height/2-3)) * 0.95
+	minRadius, margin := 7.0, 3
+	if ds.compact {
+		minRadius, margin = 2.0, 0
+	}
+	maxRadius := math.Min(float64(ds.width/2-margin), float64(ds.height/2-margin)) * 0.95
 
 	return minRadius + normalized*(maxRadius-minRadius)
 }
 
+// SetRange fixes the distances mapped to the innermost and outermost orbit. Zero
+// values go back to each system's own range.
+func (ds *DistanceScaler) SetRange(minDistance, maxDistance float64) {
+	ds.fixedMin = minDistance
+	ds.fixedMax = maxDistance
+}
+
+// SetCompact packs the orbits into a small frame round a miniature star, or
+// spaces them out round a full-size one again
+func (ds *DistanceScaler) SetCompact(compact bool) {
+	ds.compact = compact
+}
+
+// Range returns the fixed distance range, zero when none is set
+func (ds *DistanceScaler) Range() (float64, float64) {
+	return ds.fixedMin, ds.fixedMax
+}
+
 // findDistanceRange finds the minimum and maximum distances among planets (excluding Sun)
 func (ds *DistanceScaler) findDistanceRange(planets []models.CelestialBody) (float64, float64) {
+	return DistanceRange(planets)
+}
+
+// DistanceRange returns the smallest and largest semi-major axes among the bodies
+// in km, ignoring the Sun and anything without an orbit
+func DistanceRange(planets []models.CelestialBody) (float64, float64) {
 	if len(planets) == 0 {
 		return 1.0, 100.0
 	}
@@ -0,0 +1,57 @@
+package visualization
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestDistanceRange(t *testing.T) {
+	bodies := []models.CelestialBody{
+		{EnglishName: "Sun"},
+		{EnglishName: "Inner", SemimajorAxis: 5e7},
+		{EnglishName: "Outer", SemimajorAxis: 4e9},
+		{EnglishName: "Middle", SemimajorAxis: 1.5e8},
+	}
+
+	minDistance, maxDistance := DistanceRange(bodies)
+	if minDistance != 5e7 || maxDistance != 4e9 {
+		t.Errorf("DistanceRange() = %v, %v, want 5e7, 4e9", minDistance, maxDistance)
+	}
+}
+
+func TestSetRangeSharesScaleBetweenSystems(t *testing.T) {
+	compact := []models.CelestialBody{{EnglishName: "b", SemimajorAxis: 1.7e6}, {EnglishName: "h", SemimajorAxis: 9.3e6}}
+	wide := []models.CelestialBody{{EnglishName: "Mercury", SemimajorAxis: 5.8e7}, {EnglishName: "Neptune", SemimajorAxis: 4.5e9}}
+
+	scaler := NewDistanceScaler(120, 40)
+	if got := scaler.ScaleDistance(9.3e6, compact); got < 16 {
+		t.Fatalf("on its own the compact system should fill the view, outermost radius = %v", got)
+	}
+
+	scaler.SetRange(1.7e6, 4.5e9)
+	compactOuter := scaler.ScaleDistance(9.3e6, compact)
+	wideOuter := scaler.ScaleDistance(4.5e9, wide)
+	if compactOuter >= wideOuter*0.6 {
+		t.Errorf("on a common scale the compact system reaches %v, the wide one %v", compactOuter, wideOuter)
+	}
+	if scaler.ScaleDistance(4.5e9, compact) != wideOuter {
+		t.Error("the same distance should scale the same whichever system it belongs to")
+	}
+
+	scaler.SetRange(0, 0)
+	if got := scaler.ScaleDistance(9.3e6, compact); got < 16 {
+		t.Errorf("clearing the range should fit the system again, outermost radius = %v", got)
+	}
+}
+
+func TestCompactScalerKeepsOrbitsInOrderInASmallFrame(t *testing.T) {
+	bodies := []models.CelestialBody{{EnglishName: "Mercury", SemimajorAxis: 5.8e7}, {EnglishName: "Jupiter", SemimajorAxis: 7.8e8}}
+
+	scaler := NewDistanceScaler(24, 10)
+	scaler.SetCompact(true)
+	inner, outer := scaler.ScaleDistance(5.8e7, bodies), scaler.ScaleDistance(7.8e8, bodies)
+	if inner >= outer || outer > 5 {
+		t.Errorf("compact orbits should run outwards within the frame, got inner %v, outer %v", inner, outer)
+	}
+}
@@ -0,0 +1,165 @@
+package visualization
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// Run `go test ./internal/visualization -update` to rewrite the golden files
+// after an intended change to the rendering, then review the diff.
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// goldenTime is the moment every golden frame is rendered at
+var goldenTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var goldenSizes = []struct{ width, height int }{
+	{80, 24},
+	{120, 40},
+	{200, 60},
+}
+
+func body(name, bodyType string, semimajorAxis, meanRadius, period float64) models.CelestialBody {
+	return models.CelestialBody{
+		EnglishName:   name,
+		BodyType:      bodyType,
+		IsPlanet:      bodyType == "Planet",
+		SemimajorAxis: semimajorAxis,
+		MeanRadius:    meanRadius,
+		SideralOrbit:  period,
+	}
+}
+
+func solarSystemFixture() []models.CelestialBody {
+	sun := body("Sun", "Star", 0, 695508, 0)
+	sun.StellarClass = "G2V"
+	return []models.CelestialBody{
+		sun,
+		body("Mercury", "Planet", 57909227, 2439.4, 87.97),
+		body("Venus", "Planet", 108209475, 6051.8, 224.7),
+		body("Earth", "Planet", 149598023, 6371.0, 365.26),
+		body("Mars", "Planet", 227943824, 3389.5, 686.98),
+		body("Jupiter", "Planet", 778340821, 69911, 4332.59),
+		body("Saturn", "Planet", 1426666422, 58232, 10759.22),
+		body("Uranus", "Planet", 2870658186, 25362, 30685.4),
+		body("Neptune", "Planet", 4498396441, 24622, 60189),
+		body("Pluto", "Dwarf Planet", 5906440628, 1188.3, 90560),
+	}
+}
+
+func binaryStarFixture() []models.CelestialBody {
+	primary := body("Alpha Centauri A", "Star", 0, 854000, 0)
+	primary.StellarClass = "G2V"
+	secondary := body("Alpha Centauri B", "Star", 0, 602000, 0)
+	secondary.StellarClass = "K1V"
+	return []models.CelestialBody{
+		primary,
+		secondary,
+		body("Inner World", "Planet", 60000000, 4000, 90),
+		body("Temperate World", "Planet", 180000000, 6500, 450),
+		body("Ice Giant", "Planet", 900000000, 24000, 5200),
+	}
+}
+
+func manyPlanetsFixture() []models.CelestialBody {
+	star := body("Crowded Star", "Star", 0, 500000, 0)
+	star.StellarClass = "K5V"
+	bodies := []models.CelestialBody{star}
+	for i := 1; i <= 20; i++ {
+		axis := 20000000 * float64(i*i)
+		bodies = append(bodies, body(fmt.Sprintf("Planet %d", i), "Planet", axis, 1000*float64(i), 30*float64(i*i)))
+	}
+	return bodies
+}
+
+func TestRenderGolden(t *testing.T) {
+	systems := []struct {
+		name   string
+		bodies []models.CelestialBody
+		belts  []models.Belt
+	}{
+		{"solar-system", solarSystemFixture(), models.SolarSystemBelts()},
+		{"binary-star", binaryStarFixture(), nil},
+		{"twenty-planets", manyPlanetsFixture(), nil},
+	}
+
+	for _, system := range systems {
+		for _, size := range goldenSizes {
+			name := fmt.Sprintf("%s-%dx%d", system.name, size.width, size.height)
+			t.Run(name, func(t *testing.T) {
+				renderer := NewRendererWithDefaults(size.width, size.height)
+				renderer.SetTimeSource(func() time.Time { return goldenTime })
+				renderer.SetBelts(system.belts)
+
+				got := RowsString(renderer.RenderSolarSystemData(system.bodies, size.width, size.height))
+				checkGolden(t, filepath.Join("testdata", "golden", name+".txt"), got)
+			})
+		}
+	}
+}
+
+func jupiterFrameFixture() (models.CelestialBody, []models.CelestialBody) {
+	return body("Jupiter", "Planet", 778340821, 69911, 4332.59), []models.CelestialBody{
+		body("Io", "Moon", 421700, 1821.6, 1.769),
+		body("Europa", "Moon", 671034, 1560.8, 3.551),
+		body("Ganymede", "Moon", 1070412, 2634.1, 7.155),
+		body("Callisto", "Moon", 1882709, 2410.3, 16.69),
+	}
+}
+
+func TestRenderFrameGolden(t *testing.T) {
+	jupiter, moons := jupiterFrameFixture()
+	renderer := NewRendererWithDefaults(120, 40)
+	renderer.SetTimeSource(func() time.Time { return goldenTime })
+
+	grid, positions := renderer.RenderFrameWithPositions(jupiter, moons, 120, 40, 120, 40)
+	checkGolden(t, filepath.Join("testdata", "golden", "jupiter-frame-120x40.txt"), grid.String())
+
+	if got, want := grid.Get(60, 20), renderer.GetPlanetSymbol("Jupiter"); got != want {
+		t.Errorf("centre of the frame = %q, want Jupiter's %q", got, want)
+	}
+	for _, name := range []string{"Jupiter", "Io", "Europa", "Ganymede", "Callisto"} {
+		if _, ok := positions[name]; !ok {
+			t.Errorf("no position for %s", name)
+		}
+	}
+}
+
+func TestRenderIsDeterministic(t *testing.T) {
+	render := func() string {
+		renderer := NewRendererWithDefaults(120, 40)
+		renderer.SetTimeSource(func() time.Time { return goldenTime })
+		return RowsString(renderer.RenderSolarSystemData(binaryStarFixture(), 120, 40))
+	}
+
+	if first, second := render(), render(); first != second {
+		t.Error("two renders of the same moment differ")
+	}
+}
+
+func checkGolden(t *testing.T, path, got string) {
+	t.Helper()
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("render differs from %s (run with -update if the change is intended)\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
@@ -0,0 +1,271 @@
+package visualization
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// RenderMode selects how finely orbit lines and bodies are rasterised
+type RenderMode string
+
+const (
+	// RenderModeCells draws one point per terminal cell
+	RenderModeCells RenderMode = "cells"
+	// RenderModeHalfBlock splits each cell into two points stacked vertically
+	RenderModeHalfBlock RenderMode = "halfblock"
+	// RenderModeBraille splits each cell into a 2×4 block of braille dots
+	RenderModeBraille RenderMode = "braille"
+)
+
+// ParseRenderMode validates a render mode name; an empty name selects cells
+func ParseRenderMode(name string) (RenderMode, error) {
+	switch mode := RenderMode(name); mode {
+	case "":
+		return RenderModeCells, nil
+	case RenderModeCells, RenderModeHalfBlock, RenderModeBraille:
+		return mode, nil
+	default:
+		return RenderModeCells, fmt.Errorf("unknown render mode %q (want cells, halfblock or braille)", name)
+	}
+}
+
+// subCells returns how many points each cell holds horizontally and vertically
+func (m RenderMode) subCells() (int, int) {
+	switch m {
+	case RenderModeHalfBlock:
+		return 1, 2
+	case RenderModeBraille:
+		return 2, 4
+	default:
+		return 1, 1
+	}
+}
+
+// brailleBits maps a dot's column and row within a cell to its bit in U+2800
+var brailleBits = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// Grid is the character grid the orbital view is drawn into. Besides whole-cell
+// symbols it holds a layer of sub-cell points, addressed in fractional cell
+// coordinates, which is composed into half-block or braille glyphs wherever a
+// cell has no symbol of its own.
+type Grid struct {
+	width, height int
+	mode          RenderMode
+	subX, subY    int
+
+	cells []rune
+	dots  []bool
+	ink   []rune // symbol whose colour each cell's points take
+
+	// A layer records drawing calls in ops instead of drawing them; see NewLayer
+	layer bool
+	ops   []gridOp
+}
+
+// NewGrid creates an empty grid of the given size in cells
+func NewGrid(width, height int, mode RenderMode) *Grid {
+	g := &Grid{}
+	g.reset(width, height, mode)
+	return g
+}
+
+// reset empties the grid and gives it a new size and mode, keeping its storage
+// when that is big enough
+func (g *Grid) reset(width, height int, mode RenderMode) {
+	width, height = max(width, 0), max(height, 0)
+	g.width, g.height, g.mode = width, height, mode
+	g.subX, g.subY = mode.subCells()
+
+	g.cells = reuse(g.cells, width*height)
+	for i := range g.cells {
+		g.cells[i] = ' '
+	}
+	g.ink = reuse(g.ink, width*height)
+	if g.HighResolution() {
+		g.dots = reuse(g.dots, width*g.subX*height*g.subY)
+	} else {
+		g.dots = g.dots[:0]
+	}
+}
+
+// reuse returns s cut or grown to n zeroed elements, allocating only when its
+// capacity is too small
+func reuse[T any](s []T, n int) []T {
+	if cap(s) < n {
+		return make([]T, n)
+	}
+	s = s[:n]
+	clear(s)
+	return s
+}
+
+// Width returns the grid width in cells
+func (g *Grid) Width() int {
+	return g.width
+}
+
+// Height returns the grid height in cells
+func (g *Grid) Height() int {
+	return g.height
+}
+
+// Mode returns the grid's render mode
+func (g *Grid) Mode() RenderMode {
+	return g.mode
+}
+
+// HighResolution reports whether the grid has more than one point per cell
+func (g *Grid) HighResolution() bool {
+	return g.subX*g.subY > 1
+}
+
+// SubCells returns how many points each cell holds horizontally and vertically
+func (g *Grid) SubCells() (int, int) {
+	return g.subX, g.subY
+}
+
+// InBounds checks if a cell is within the grid
+func (g *Grid) InBounds(x, y int) bool {
+	return x >= 0 && x < g.width && y >= 0 && y < g.height
+}
+
+// Get returns the symbol in a cell, or a space when out of bounds. A layer's
+// cells are always blank.
+func (g *Grid) Get(x, y int) rune {
+	if g.layer || !g.InBounds(x, y) {
+		return ' '
+	}
+	return g.cells[y*g.width+x]
+}
+
+// Set places a symbol in a cell
+func (g *Grid) Set(x, y int, symbol rune) {
+	if g.layer {
+		g.record(opSet, float64(x), float64(y), symbol)
+		return
+	}
+	if g.InBounds(x, y) {
+		g.cells[y*g.width+x] = symbol
+		g.ink[y*g.width+x] = symbol
+	}
+}
+
+// SetIfEmpty places a symbol only where the cell is still blank
+func (g *Grid) SetIfEmpty(x, y int, symbol rune) {
+	if g.layer {
+		g.record(opSetIfEmpty, float64(x), float64(y), symbol)
+		return
+	}
+	if g.Get(x, y) == ' ' {
+		g.Set(x, y, symbol)
+	}
+}
+
+// Plot lights the sub-cell point at fractional cell coordinates, so (2.5, 3.5)
+// is the middle of cell (2, 3). On a cell grid this falls back to SetIfEmpty.
+func (g *Grid) Plot(x, y float64, ink rune) {
+	cellX, cellY := int(math.Floor(x)), int(math.Floor(y))
+	if !g.InBounds(cellX, cellY) {
+		return
+	}
+	if g.layer {
+		g.record(opPlot, x, y, ink)
+		return
+	}
+
+	if !g.HighResolution() {
+		g.SetIfEmpty(cellX, cellY, ink)
+		return
+	}
+
+	subX := int(math.Floor(x * float64(g.subX)))
+	subY := int(math.Floor(y * float64(g.subY)))
+	g.dots[subY*g.width*g.subX+subX] = true
+	if g.cells[cellY*g.width+cellX] == ' ' {
+		g.ink[cellY*g.width+cellX] = ink
+	}
+}
+
+// At returns the glyph to display for a cell and the symbol whose colour it should
+// be drawn in. Whole-cell symbols win over sub-cell points.
+func (g *Grid) At(x, y int) (rune, rune) {
+	if g.layer || !g.InBounds(x, y) {
+		return ' ', 0
+	}
+
+	i := y*g.width + x
+	if g.cells[i] != ' ' || !g.HighResolution() {
+		return g.cells[i], g.ink[i]
+	}
+
+	glyph := g.composePoints(x, y)
+	if glyph == ' ' {
+		return ' ', 0
+	}
+	return glyph, g.ink[i]
+}
+
+// Runes returns the displayed glyph of every cell, row by row
+func (g *Grid) Runes() [][]rune {
+	rows := make([][]rune, g.height)
+	for y := range rows {
+		rows[y] = make([]rune, g.width)
+		for x := range rows[y] {
+			rows[y][x], _ = g.At(x, y)
+		}
+	}
+	return rows
+}
+
+// String renders the grid as text, one line per row with trailing spaces trimmed
+func (g *Grid) String() string {
+	return RowsString(g.Runes())
+}
+
+// RowsString renders rows of glyphs, such as RenderSolarSystemData returns, as
+// text with trailing spaces trimmed
+func RowsString(rows [][]rune) string {
+	var b strings.Builder
+	for _, row := range rows {
+		b.WriteString(strings.TrimRight(string(row), " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// composePoints turns a cell's lit points into a half-block or braille glyph
+func (g *Grid) composePoints(x, y int) rune {
+	lit := func(dx, dy int) bool {
+		return g.dots[(y*g.subY+dy)*g.width*g.subX+x*g.subX+dx]
+	}
+
+	switch g.mode {
+	case RenderModeHalfBlock:
+		top, bottom := lit(0, 0), lit(0, 1)
+		switch {
+		case top && bottom:
+			return '█'
+		case top:
+			return '▀'
+		case bottom:
+			return '▄'
+		}
+	case RenderModeBraille:
+		var bits rune
+		for dx := 0; dx < g.subX; dx++ {
+			for dy := 0; dy < g.subY; dy++ {
+				if lit(dx, dy) {
+					bits |= brailleBits[dx][dy]
+				}
+			}
+		}
+		if bits != 0 {
+			return 0x2800 + bits
+		}
+	}
+	return ' '
+}
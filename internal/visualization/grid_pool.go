@@ -0,0 +1,37 @@
+package visualization
+
+// gridPool saves the renderer allocating a grid, and a layer for every orbit and
+// body, each frame. Grids are double-buffered: the grid handed out for a frame is
+// left alone while the next one is drawn, so a caller can still be reading it.
+type gridPool struct {
+	grids       [2]*Grid
+	next        int
+	spareLayers []*Grid
+}
+
+// grid returns an empty grid of the given size and mode, reusing the one handed
+// out two frames ago
+func (p *gridPool) grid(width, height int, mode RenderMode) *Grid {
+	g := p.grids[p.next]
+	if g == nil {
+		g = NewGrid(width, height, mode)
+		p.grids[p.next] = g
+	} else {
+		g.reset(width, height, mode)
+	}
+	p.next = 1 - p.next
+	return g
+}
+
+// layers returns n empty layers of grid, reusing the ones handed out before. They
+// are only good until the next call.
+func (p *gridPool) layers(grid *Grid, n int) []*Grid {
+	for len(p.spareLayers) < n {
+		p.spareLayers = append(p.spareLayers, &Grid{})
+	}
+	layers := p.spareLayers[:n]
+	for _, layer := range layers {
+		layer.resetLayer(grid)
+	}
+	return layers
+}
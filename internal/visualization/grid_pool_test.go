@@ -0,0 +1,102 @@
+package visualization
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGridPoolDoubleBuffers(t *testing.T) {
+	var pool gridPool
+	first := pool.grid(4, 2, RenderModeCells)
+	first.Set(0, 0, '♁')
+
+	second := pool.grid(4, 2, RenderModeCells)
+	if second == first {
+		t.Fatal("consecutive frames got the same grid")
+	}
+	if first.Get(0, 0) != '♁' {
+		t.Error("drawing the next frame cleared the one before it")
+	}
+
+	third := pool.grid(4, 2, RenderModeCells)
+	if third != first {
+		t.Error("the grid from two frames ago was not reused")
+	}
+	if third.Get(0, 0) != ' ' {
+		t.Error("a reused grid should come back empty")
+	}
+}
+
+func TestReusedGridDrawsLikeNewOne(t *testing.T) {
+	var pool gridPool
+	sizes := []struct {
+		width, height int
+		mode          RenderMode
+	}{
+		{30, 12, RenderModeBraille},
+		{22, 11, RenderModeCells},
+		{22, 11, RenderModeHalfBlock},
+		{40, 20, RenderModeBraille},
+		{22, 11, RenderModeBraille},
+	}
+
+	for _, size := range sizes {
+		fresh := NewGrid(size.width, size.height, size.mode)
+		reused := pool.grid(size.width, size.height, size.mode)
+		for _, draw := range drawOverlapping() {
+			draw(fresh)
+			draw(reused)
+		}
+		if fresh.String() != reused.String() {
+			t.Errorf("%dx%d %s: reused grid drew\n%s\nwant\n%s", size.width, size.height, size.mode, reused, fresh)
+		}
+	}
+}
+
+func TestGridPoolStopsAllocating(t *testing.T) {
+	var pool gridPool
+	grid := pool.grid(200, 60, RenderModeBraille)
+	pool.grid(200, 60, RenderModeBraille)
+	pool.layers(grid, 40)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		grid := pool.grid(200, 60, RenderModeBraille)
+		pool.layers(grid, 40)
+	})
+	if allocs != 0 {
+		t.Errorf("a warmed-up pool allocated %v times a frame, want none", allocs)
+	}
+}
+
+// BenchmarkGridPerFrame compares allocating a grid for every frame with taking
+// one from the pool
+func BenchmarkGridPerFrame(b *testing.B) {
+	b.Run("new", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			NewGrid(200, 60, RenderModeBraille)
+		}
+	})
+	b.Run("pool", func(b *testing.B) {
+		b.ReportAllocs()
+		var pool gridPool
+		for i := 0; i < b.N; i++ {
+			pool.grid(200, 60, RenderModeBraille)
+		}
+	})
+}
+
+// BenchmarkRenderFrame renders the Solar System at a large terminal size, as the
+// app does ten times a second
+func BenchmarkRenderFrame(b *testing.B) {
+	renderer := NewRendererWithDefaults(200, 60)
+	renderer.SetRenderMode(RenderModeBraille)
+	renderer.SetTimeSource(func() time.Time { return goldenTime })
+	bodies := solarSystemFixture()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		renderer.RenderSolarSystemDataWithPositions(bodies, 200, 60, 200, 60)
+	}
+}
@@ -0,0 +1,65 @@
+package visualization
+
+import "testing"
+
+func TestGridComposesBraille(t *testing.T) {
+	grid := NewGrid(2, 1, RenderModeBraille)
+
+	// Top-left and bottom-right dots of the first cell
+	grid.Plot(0.1, 0.1, '·')
+	grid.Plot(0.9, 0.9, '·')
+
+	if got, ink := grid.At(0, 0); got != '⢁' || ink != '·' {
+		t.Errorf("At(0, 0) = %q ink %q, want '⢁' ink '·'", got, ink)
+	}
+	if got, _ := grid.At(1, 0); got != ' ' {
+		t.Errorf("At(1, 0) = %q, want blank", got)
+	}
+}
+
+func TestGridComposesHalfBlocks(t *testing.T) {
+	grid := NewGrid(3, 1, RenderModeHalfBlock)
+	grid.Plot(0.5, 0.2, '·')
+	grid.Plot(1.5, 0.7, '·')
+	grid.Plot(2.5, 0.2, '·')
+	grid.Plot(2.5, 0.7, '·')
+
+	want := []rune{'▀', '▄', '█'}
+	for x, glyph := range grid.Runes()[0] {
+		if glyph != want[x] {
+			t.Errorf("cell %d = %q, want %q", x, glyph, want[x])
+		}
+	}
+}
+
+func TestGridSymbolsHidePoints(t *testing.T) {
+	grid := NewGrid(1, 1, RenderModeBraille)
+	grid.Plot(0.5, 0.5, '·')
+	grid.Set(0, 0, '♁')
+
+	if got, ink := grid.At(0, 0); got != '♁' || ink != '♁' {
+		t.Errorf("At(0, 0) = %q ink %q, want the symbol", got, ink)
+	}
+}
+
+func TestGridCellModePlotsSymbols(t *testing.T) {
+	grid := NewGrid(2, 2, RenderModeCells)
+	grid.Plot(1.7, 0.2, '·')
+	grid.Plot(1.2, 0.9, '∗') // already occupied
+
+	if got := grid.Get(1, 0); got != '·' {
+		t.Errorf("Get(1, 0) = %q, want '·'", got)
+	}
+}
+
+func TestParseRenderMode(t *testing.T) {
+	if mode, err := ParseRenderMode(""); err != nil || mode != RenderModeCells {
+		t.Errorf("ParseRenderMode(\"\") = %v, %v", mode, err)
+	}
+	if mode, err := ParseRenderMode("braille"); err != nil || mode != RenderModeBraille {
+		t.Errorf("ParseRenderMode(braille) = %v, %v", mode, err)
+	}
+	if _, err := ParseRenderMode("sixel"); err == nil {
+		t.Error("ParseRenderMode(sixel) should fail")
+	}
+}
@@ -0,0 +1,85 @@
+package visualization
+
+import (
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// habitableSpacing is roughly how many cells apart the dots of each ring of the
+// habitable zone are, so the band reads as a shading behind the orbits
+const habitableSpacing = 3.0
+
+// HabitableZoneMarks is the habitable zone the last render drew: its edges in AU,
+// and the radii in rows its band was drawn between
+type HabitableZoneMarks struct {
+	Luminosity       float64 // of all the stars together, in solar luminosities
+	InnerAU, OuterAU float64
+	Inner, Outer     float64
+}
+
+// SetHabitableZoneShown draws the band round the stars where a planet could keep
+// liquid water, or leaves it off
+func (r *Renderer) SetHabitableZoneShown(shown bool) {
+	r.showHabitable = shown
+}
+
+// HabitableZoneShown reports whether the habitable zone is drawn
+func (r *Renderer) HabitableZoneShown() bool {
+	return r.showHabitable
+}
+
+// HabitableZoneMarks returns the habitable zone the last render drew, and false
+// if it drew none
+func (r *Renderer) HabitableZoneMarks() (HabitableZoneMarks, bool) {
+	return r.habitableMarks, r.habitableDrawn
+}
+
+// starsLuminosity adds up the stars' luminosity in solar luminosities, given or
+// worked out from their temperature and radius; the Sun's when there are no stars
+func starsLuminosity(stars []models.CelestialBody) float64 {
+	if len(stars) == 0 {
+		return 1
+	}
+	var total float64
+	for _, star := range stars {
+		luminosity, _ := star.GetLuminosity()
+		total += luminosity
+	}
+	return total
+}
+
+// habitableZoneDraw returns the drawing of the habitable zone of stars shining
+// with luminosity as rings of dots from its inner edge to its outer, no more than
+// a row apart, scaled like the orbits, filling only cells nothing else was drawn
+// in. It returns false when the luminosity is not known, or the zone would be
+// hidden under the star.
+func (r *Renderer) habitableZoneDraw(luminosity float64, planets []models.CelestialBody, centerX, centerY int) (func(*Grid), HabitableZoneMarks, bool) {
+	if luminosity <= 0 {
+		return nil, HabitableZoneMarks{}, false
+	}
+
+	marks := HabitableZoneMarks{Luminosity: luminosity}
+	marks.InnerAU, marks.OuterAU = models.HabitableZone(luminosity)
+	marks.Inner = math.Max(r.distanceScaler.ScaleDistance(marks.InnerAU*constants.AstronomicalUnit, planets), float64(r.celestialRenderer.GetSunSize()+1))
+	marks.Outer = r.distanceScaler.ScaleDistance(marks.OuterAU*constants.AstronomicalUnit, planets)
+	if marks.Outer < marks.Inner {
+		return nil, HabitableZoneMarks{}, false
+	}
+
+	ink := r.symbols.Habitable
+	return func(layer *Grid) {
+		rings := int(marks.Outer-marks.Inner) + 1
+		for ring := 0; ring <= rings; ring++ {
+			radius := marks.Inner + (marks.Outer-marks.Inner)*float64(ring)/float64(rings)
+			dots := max(12, int(2*math.Pi*radius/habitableSpacing))
+			stagger := float64(ring%2) / 2
+			for i := 0; i < dots; i++ {
+				angle := 2 * math.Pi * (float64(i) + stagger) / float64(dots)
+				x, y := r.circleDrawer.calculatePoint(centerX, centerY, radius, angle)
+				layer.SetIfEmpty(int(x), int(y), ink)
+			}
+		}
+	}, marks, true
+}
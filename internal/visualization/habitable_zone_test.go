@@ -0,0 +1,59 @@
+package visualization
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderHabitableZone(t *testing.T) {
+	planets := solarSystemFixture()
+	renderer := NewRendererWithDefaults(120, 40)
+	renderer.SetTimeSource(func() time.Time { return goldenTime })
+
+	grid, _ := renderer.RenderSolarSystemDataWithPositions(planets, 120, 40, 120, 40)
+	if _, ok := renderer.HabitableZoneMarks(); ok || strings.ContainsRune(grid.String(), renderer.GetSymbols().Habitable) {
+		t.Fatal("habitable zone drawn while hidden")
+	}
+
+	renderer.SetHabitableZoneShown(true)
+	grid, positions := renderer.RenderSolarSystemDataWithPositions(planets, 120, 40, 120, 40)
+	marks, ok := renderer.HabitableZoneMarks()
+	if !ok || marks.Luminosity != 1 || !strings.ContainsRune(grid.String(), renderer.GetSymbols().Habitable) {
+		t.Fatalf("Expected the Sun's habitable zone drawn, got %+v (%v)", marks, ok)
+	}
+
+	// The band lies between Venus's orbit and Mars's, round the Earth's
+	radius := func(name string) float64 {
+		p := positions[name]
+		return math.Hypot(float64(p.X-60)/renderer.GetAspectRatio(), float64(p.Y-20))
+	}
+	if marks.Inner < radius("Venus")-1 || marks.Outer > radius("Mars")+1 || marks.Inner > radius("Earth")+1 || marks.Outer < radius("Earth")-1 {
+		t.Errorf("Habitable zone drawn %.1f-%.1f rows out; Venus %.1f, Earth %.1f, Mars %.1f",
+			marks.Inner, marks.Outer, radius("Venus"), radius("Earth"), radius("Mars"))
+	}
+}
+
+func TestHabitableZoneFollowsStarLuminosity(t *testing.T) {
+	bodies := binaryStarFixture()
+	bodies[0].Luminosity = 1.5
+	bodies[1].Temperature, bodies[1].Luminosity = 5260, 0
+	renderer := NewRendererWithDefaults(120, 40)
+	renderer.SetTimeSource(func() time.Time { return goldenTime })
+	renderer.SetHabitableZoneShown(true)
+
+	renderer.RenderSolarSystemDataWithPositions(bodies, 120, 40, 120, 40)
+	marks, ok := renderer.HabitableZoneMarks()
+	secondary, _ := bodies[1].GetLuminosity()
+	if !ok || math.Abs(marks.Luminosity-(1.5+secondary)) > 1e-9 {
+		t.Errorf("Luminosity %g (%v), want both stars' %g", marks.Luminosity, ok, 1.5+secondary)
+	}
+
+	// A star nothing is known of has no zone to draw
+	bodies[0].Luminosity, bodies[1].Temperature = 0, 0
+	renderer.RenderSolarSystemDataWithPositions(bodies, 120, 40, 120, 40)
+	if _, ok := renderer.HabitableZoneMarks(); ok {
+		t.Error("Habitable zone drawn for stars of unknown luminosity")
+	}
+}
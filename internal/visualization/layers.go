@@ -0,0 +1,95 @@
+package visualization
+
+import (
+	"runtime"
+	"sync"
+)
+
+// gridOpKind is a drawing call recorded by a layer
+type gridOpKind uint8
+
+const (
+	opSet gridOpKind = iota
+	opSetIfEmpty
+	opPlot
+)
+
+// gridOp is one recorded drawing call; x and y are cells for Set and SetIfEmpty
+// and fractional cell coordinates for Plot
+type gridOp struct {
+	kind   gridOpKind
+	x, y   float64
+	symbol rune
+}
+
+// NewLayer creates an empty layer the size and mode of the grid. A layer records
+// what is drawn into it rather than drawing it, so layers can be drawn at the
+// same time and composited afterwards with exactly the result of drawing them
+// one after another. Reading a layer sees blank cells.
+func (g *Grid) NewLayer() *Grid {
+	layer := &Grid{}
+	layer.resetLayer(g)
+	return layer
+}
+
+// resetLayer empties a layer and matches it to grid, keeping the storage of the
+// calls it recorded before
+func (g *Grid) resetLayer(grid *Grid) {
+	g.width, g.height, g.mode = grid.width, grid.height, grid.mode
+	g.subX, g.subY = grid.subX, grid.subY
+	g.layer = true
+	g.ops = g.ops[:0]
+}
+
+func (g *Grid) record(kind gridOpKind, x, y float64, symbol rune) {
+	g.ops = append(g.ops, gridOp{kind: kind, x: x, y: y, symbol: symbol})
+}
+
+// Composite replays layers onto the grid in order, so later layers are drawn
+// over earlier ones just as if they had been drawn into the grid directly
+func (g *Grid) Composite(layers ...*Grid) {
+	for _, layer := range layers {
+		for _, op := range layer.ops {
+			switch op.kind {
+			case opSet:
+				g.Set(int(op.x), int(op.y), op.symbol)
+			case opSetIfEmpty:
+				g.SetIfEmpty(int(op.x), int(op.y), op.symbol)
+			case opPlot:
+				g.Plot(op.x, op.y, op.symbol)
+			}
+		}
+	}
+}
+
+// drawLayers runs each draw function into a layer of its own, spread over the
+// available CPUs, then composites the layers onto the grid in the order given.
+// layers holds an empty layer of the grid for each draw.
+func drawLayers(grid *Grid, layers []*Grid, draws []func(*Grid)) {
+
+	workers := min(runtime.GOMAXPROCS(0), len(draws))
+	if workers <= 1 {
+		for i, draw := range draws {
+			draw(layers[i])
+		}
+	} else {
+		next := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range next {
+					draws[i](layers[i])
+				}
+			}()
+		}
+		for i := range draws {
+			next <- i
+		}
+		close(next)
+		wg.Wait()
+	}
+
+	grid.Composite(layers...)
+}
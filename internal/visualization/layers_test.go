@@ -0,0 +1,55 @@
+package visualization
+
+import "testing"
+
+// drawOverlapping draws an orbit, a belt and a planet that all cross the same cells
+func drawOverlapping() []func(*Grid) {
+	drawer := NewCircleDrawer(2.0)
+	return []func(*Grid){
+		func(g *Grid) { drawer.DrawCircle(g, 10, 5, 4, '·') },
+		func(g *Grid) {
+			for x := 0; x < 20; x++ {
+				g.SetIfEmpty(x, 5, '∘')
+			}
+		},
+		func(g *Grid) { drawer.DrawFilledCircle(g, 18, 5, 2, '♁') },
+		func(g *Grid) { g.Plot(-1, 2, '·') }, // off the grid
+	}
+}
+
+func TestLayersMatchDrawingInOrder(t *testing.T) {
+	for _, mode := range []RenderMode{RenderModeCells, RenderModeHalfBlock, RenderModeBraille} {
+		t.Run(string(mode), func(t *testing.T) {
+			direct := NewGrid(22, 11, mode)
+			for _, draw := range drawOverlapping() {
+				draw(direct)
+			}
+
+			layered := NewGrid(22, 11, mode)
+			draws := drawOverlapping()
+			drawLayers(layered, new(gridPool).layers(layered, len(draws)), draws)
+
+			for y := 0; y < direct.Height(); y++ {
+				for x := 0; x < direct.Width(); x++ {
+					wantGlyph, wantInk := direct.At(x, y)
+					gotGlyph, gotInk := layered.At(x, y)
+					if gotGlyph != wantGlyph || gotInk != wantInk {
+						t.Fatalf("cell (%d, %d) = %q ink %q, want %q ink %q", x, y, gotGlyph, gotInk, wantGlyph, wantInk)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLayerReadsBlank(t *testing.T) {
+	layer := NewGrid(2, 2, RenderModeBraille).NewLayer()
+	layer.Set(0, 0, '♁')
+
+	if got := layer.Get(0, 0); got != ' ' {
+		t.Errorf("Get(0, 0) = %q, want blank until composited", got)
+	}
+	if got, _ := layer.At(0, 0); got != ' ' {
+		t.Errorf("At(0, 0) = %q, want blank until composited", got)
+	}
+}
@@ -0,0 +1,111 @@
+package visualization
+
+import (
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// LegendEntry explains one symbol on the map
+type LegendEntry struct {
+	Symbol rune
+	Label  string
+}
+
+// legendBody is a body as the legend describes it: kind is what its symbol stands
+// for, or empty when the symbol is the body's own
+type legendBody struct {
+	name   string
+	symbol rune
+	kind   string
+}
+
+// classNames describes each class of body in the legend
+var classNames = map[BodyClass]string{
+	ClassUnknown:     "body",
+	ClassStar:        "star",
+	ClassGasGiant:    "gas giant",
+	ClassIceGiant:    "ice giant",
+	ClassTerrestrial: "terrestrial planet",
+	ClassDwarf:       "dwarf planet",
+	ClassMoon:        "moon",
+	ClassAsteroid:    "asteroid",
+	ClassComet:       "comet",
+}
+
+// starKind describes what a star's symbol stands for: its stellar class, if the
+// set gives that class a symbol of its own
+func (cor *CelestialObjectRenderer) starKind(star models.CelestialBody) string {
+	if star.EnglishName == "Sun" {
+		return ""
+	}
+	if class := cor.getStellarClass(star); class != "" {
+		if _, ok := cor.symbols.Stars[class[0]]; ok {
+			return class[:1] + "-class star"
+		}
+	}
+	return "star"
+}
+
+// Legend explains the symbols in inks, the ones the map was last drawn with, in
+// the order the map draws them: stars and bodies, grouped by symbol with what the
+// symbol stands for, then a swollen star, the habitable zone, orbits, belts and
+// the lines over them. Symbols are described the first time they come up only.
+func (r *Renderer) Legend(inks map[rune]bool) []LegendEntry {
+	var entries []LegendEntry
+	seen := map[rune]int{}
+	for _, body := range r.celestialRenderer.styles.drawn {
+		if !inks[body.symbol] {
+			continue
+		}
+		if i, ok := seen[body.symbol]; ok {
+			entries[i].Label += ", " + body.name
+			continue
+		}
+		label := body.name
+		if body.kind != "" {
+			label = body.kind + ": " + body.name
+		}
+		seen[body.symbol] = len(entries)
+		entries = append(entries, LegendEntry{Symbol: body.symbol, Label: label})
+	}
+
+	features := []LegendEntry{
+		{r.symbols.Envelope, "the star, swollen as it ages"},
+		{r.symbols.Habitable, "habitable zone, where water could be liquid"},
+		{r.symbols.Orbit, "orbit"},
+	}
+	features = append(features, r.beltLegend()...)
+	features = append(features,
+		LegendEntry{r.symbols.Resonance, "link between orbits in resonance"},
+		LegendEntry{r.symbols.Transfer, "transfer orbit"},
+		LegendEntry{r.symbols.Hill, "Hill sphere, where the body holds on to moons"},
+		LegendEntry{r.symbols.Roche, "Roche limit, inside which tides tear a moon apart"},
+	)
+	for _, feature := range features {
+		if _, ok := seen[feature.Symbol]; ok || !inks[feature.Symbol] {
+			continue
+		}
+		seen[feature.Symbol] = len(entries)
+		entries = append(entries, feature)
+	}
+	return entries
+}
+
+// beltLegend names the belts on the map by the glyph they're drawn with, listing
+// belts drawn alike together as bodies are
+func (r *Renderer) beltLegend() []LegendEntry {
+	if r.beltVisibility == BeltsHidden {
+		return nil
+	}
+	var entries []LegendEntry
+	seen := map[rune]int{}
+	for _, belt := range r.belts {
+		symbol := r.debrisBeltRenderer.Symbol(belt)
+		if i, ok := seen[symbol]; ok {
+			entries[i].Label += ", " + belt.Name
+			continue
+		}
+		seen[symbol] = len(entries)
+		entries = append(entries, LegendEntry{Symbol: symbol, Label: belt.Name})
+	}
+	return entries
+}
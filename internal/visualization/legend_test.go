@@ -0,0 +1,78 @@
+package visualization
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestLegendGroupsBodiesBySymbol(t *testing.T) {
+	star := body("Ember", "Star", 0, 500000, 0)
+	star.StellarClass = "M5V"
+	giants := []models.CelestialBody{body("Ember b", "Planet", 1e8, 70000, 100), body("Ember c", "Planet", 2e8, 60000, 300)}
+	rocky := body("Ember d", "Planet", 3e8, 6000, 500)
+	painted := body("Ember e", "Planet", 4e8, 6000, 700)
+	painted.Symbol = "✚"
+
+	r := NewRendererWithDefaults(80, 24)
+	r.RenderSolarSystemDataWithPositions(append([]models.CelestialBody{star, rocky, painted}, giants...), 80, 24, 80, 24)
+
+	s := UnicodeSymbols
+	inks := map[rune]bool{s.Star("M"): true, s.Classes[ClassGasGiant]: true, s.Classes[ClassTerrestrial]: true, '✚': true, s.Orbit: true}
+	got := r.Legend(inks)
+	want := []LegendEntry{
+		{s.Star("M"), "M-class star: Ember"},
+		{s.Classes[ClassTerrestrial], "terrestrial planet: Ember d"},
+		{'✚', "Ember e"},
+		{s.Classes[ClassGasGiant], "gas giant: Ember b, Ember c"},
+		{s.Orbit, "orbit"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Legend() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Legend()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Only what was drawn is explained
+	if got := r.Legend(map[rune]bool{s.Orbit: true}); len(got) != 1 {
+		t.Errorf("Legend(orbit only) = %+v, want just the orbit", got)
+	}
+}
+
+func TestLegendNamesBelts(t *testing.T) {
+	star := body("Ember", "Star", 0, 500000, 0)
+	star.StellarClass = "M5V"
+	planets := []models.CelestialBody{star, body("Ember b", "Planet", 1e9, 6000, 100)}
+
+	r := NewRendererWithDefaults(80, 24)
+	r.SetBelts([]models.Belt{
+		{Name: "Inner belt", InnerRadius: 2e8, OuterRadius: 3e8},
+		{Name: "Middle belt", InnerRadius: 4e8, OuterRadius: 5e8},
+		{Name: "Outer belt", InnerRadius: 2e9, OuterRadius: 3e9, Kind: models.BeltIcy},
+	})
+	r.RenderSolarSystemDataWithPositions(planets, 80, 24, 80, 24)
+
+	s := UnicodeSymbols
+	inks := map[rune]bool{s.AsteroidBelt: true, s.KuiperBelt: true}
+	got := r.Legend(inks)
+	want := []LegendEntry{
+		{s.AsteroidBelt, "Inner belt, Middle belt"},
+		{s.KuiperBelt, "Outer belt"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Legend() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Legend()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	r.SetBeltVisibility(BeltsHidden)
+	if got := r.Legend(inks); len(got) != 0 {
+		t.Errorf("Legend() with the belts hidden = %+v, want nothing", got)
+	}
+}
@@ -0,0 +1,79 @@
+package visualization
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+//go:embed data/moons.json
+var moonFactsJSON []byte
+
+// MoonFacts is the static baseline for a well-known moon. The API often returns
+// little more than a name for moons, so this fills in the rest.
+type MoonFacts struct {
+	Name          string   `json:"name"`
+	Planet        string   `json:"planet"`
+	MeanRadius    float64  `json:"meanRadius"`    // km
+	SideralOrbit  float64  `json:"sideralOrbit"`  // days
+	SemimajorAxis float64  `json:"semimajorAxis"` // km
+	DiscoveredBy  string   `json:"discoveredBy"`
+	DiscoveryDate string   `json:"discoveryDate"`
+	Facts         []string `json:"facts"`
+}
+
+// loadMoonFacts parses the embedded knowledge base, keyed by lowercase name
+func loadMoonFacts() map[string]MoonFacts {
+	var entries []MoonFacts
+	if err := json.Unmarshal(moonFactsJSON, &entries); err != nil {
+		panic("visualization: invalid embedded moon knowledge base: " + err.Error())
+	}
+
+	knowledge := make(map[string]MoonFacts, len(entries))
+	for _, entry := range entries {
+		knowledge[strings.ToLower(entry.Name)] = entry
+	}
+	return knowledge
+}
+
+// GetMoonFacts returns the baseline for a moon by English name
+func (mh *MoonHandler) GetMoonFacts(name string) (MoonFacts, bool) {
+	facts, ok := mh.knowledge[strings.ToLower(name)]
+	return facts, ok
+}
+
+// EnrichMoon fills the gaps in a moon's API data from the knowledge base. Values
+// the API did return always win.
+func (mh *MoonHandler) EnrichMoon(moon models.CelestialBody) models.CelestialBody {
+	facts, ok := mh.GetMoonFacts(moon.EnglishName)
+	if !ok {
+		return moon
+	}
+
+	if moon.MeanRadius == 0 && facts.MeanRadius != 0 {
+		moon.MeanRadius = facts.MeanRadius
+		moon.SetFieldSource("meanRadius", models.SourceKnowledgeBase)
+	}
+	if moon.SideralOrbit == 0 && facts.SideralOrbit != 0 {
+		moon.SideralOrbit = facts.SideralOrbit
+		moon.SetFieldSource("sideralOrbit", models.SourceKnowledgeBase)
+	}
+	if moon.SemimajorAxis == 0 && facts.SemimajorAxis != 0 {
+		moon.SemimajorAxis = facts.SemimajorAxis
+		moon.SetFieldSource("semimajorAxis", models.SourceKnowledgeBase)
+	}
+	if moon.DiscoveredBy == "" && facts.DiscoveredBy != "" {
+		moon.DiscoveredBy = facts.DiscoveredBy
+		moon.SetFieldSource("discoveredBy", models.SourceKnowledgeBase)
+	}
+	if moon.DiscoveryDate == "" && facts.DiscoveryDate != "" {
+		moon.DiscoveryDate = facts.DiscoveryDate
+		moon.SetFieldSource("discoveryDate", models.SourceKnowledgeBase)
+	}
+	if moon.AroundPlanet == nil && facts.Planet != "" {
+		moon.AroundPlanet = &models.Planet{EnglishName: facts.Planet}
+	}
+	return moon
+}
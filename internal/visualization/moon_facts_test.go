@@ -0,0 +1,66 @@
+package visualization
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestMoonKnowledgeBase(t *testing.T) {
+	knowledge := loadMoonFacts()
+	if len(knowledge) < 50 {
+		t.Errorf("knowledge base has %d moons, want at least 50", len(knowledge))
+	}
+
+	for key, facts := range knowledge {
+		if facts.Planet == "" || facts.MeanRadius <= 0 || facts.SideralOrbit <= 0 || facts.SemimajorAxis <= 0 {
+			t.Errorf("%s: incomplete baseline %+v", key, facts)
+		}
+		if len(facts.Facts) == 0 {
+			t.Errorf("%s: no facts", key)
+		}
+	}
+}
+
+func TestEnrichMoonFillsGaps(t *testing.T) {
+	mh := NewMoonHandler()
+
+	moon := mh.EnrichMoon(models.CelestialBody{EnglishName: "Titan"})
+	if moon.MeanRadius != 2574.7 || moon.SideralOrbit == 0 || moon.DiscoveredBy != "Christiaan Huygens" {
+		t.Errorf("EnrichMoon(Titan) = %+v, want the baseline filled in", moon)
+	}
+	if moon.AroundPlanet == nil || moon.AroundPlanet.EnglishName != "Saturn" {
+		t.Errorf("EnrichMoon(Titan).AroundPlanet = %v, want Saturn", moon.AroundPlanet)
+	}
+}
+
+func TestEnrichMoonKeepsAPIData(t *testing.T) {
+	mh := NewMoonHandler()
+
+	moon := mh.EnrichMoon(models.CelestialBody{
+		EnglishName:  "europa",
+		MeanRadius:   1560,
+		DiscoveredBy: "G. Galilei",
+		AroundPlanet: &models.Planet{EnglishName: "Jupiter"},
+	})
+	if moon.MeanRadius != 1560 || moon.DiscoveredBy != "G. Galilei" {
+		t.Errorf("EnrichMoon() overwrote API data: %+v", moon)
+	}
+	if moon.SemimajorAxis != 671034 {
+		t.Errorf("SemimajorAxis = %v, want the baseline 671034", moon.SemimajorAxis)
+	}
+	if got := moon.SourceOf("meanRadius"); got != models.SourceUnknown {
+		t.Errorf("SourceOf(meanRadius) = %q, want the moon's own source", got)
+	}
+	if got := moon.SourceOf("semimajorAxis"); got != models.SourceKnowledgeBase {
+		t.Errorf("SourceOf(semimajorAxis) = %q, want the knowledge base", got)
+	}
+}
+
+func TestEnrichMoonUnknown(t *testing.T) {
+	mh := NewMoonHandler()
+	moon := models.CelestialBody{EnglishName: "S/2004 S 12"}
+	if got := mh.EnrichMoon(moon); got.MeanRadius != 0 || got.AroundPlanet != nil {
+		t.Errorf("EnrichMoon() changed an unknown moon: %+v", got)
+	}
+}
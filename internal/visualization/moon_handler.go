@@ -3,6 +3,7 @@ package visualization
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/furan917/go-solar-system/internal/models"
 )
@@ -10,9 +11,15 @@ import (
 // MoonHandler handles moon name resolution and display
 type MoonHandler struct {
 	famousMoons map[string][]string
+	knowledge   map[string]MoonFacts
+
+	// resolved holds names fetched for moons the API listed without one, by moon id
+	resolvedMu sync.RWMutex
+	resolved   map[string]string
 }
 
-// NewMoonHandler creates a new moon handler with well-known moon names
+// NewMoonHandler creates a new moon handler with well-known moon names and the
+// embedded moon knowledge base
 func NewMoonHandler() *MoonHandler {
 	return &MoonHandler{
 		famousMoons: map[string][]string{
@@ -23,6 +30,8 @@ func NewMoonHandler() *MoonHandler {
 			"Uranus":  {"Titania", "Oberon", "Umbriel", "Ariel"},
 			"Neptune": {"Triton", "Nereid"},
 		},
+		knowledge: loadMoonFacts(),
+		resolved:  make(map[string]string),
 	}
 }
 
@@ -60,6 +69,10 @@ func (mh *MoonHandler) GetMoonNameFromAPI(moon models.Moon) string {
 		return moon.EnglishName
 	}
 
+	if name, ok := mh.ResolvedName(moon); ok {
+		return name
+	}
+
 	if moon.Name != "" {
 		return moon.Name
 	}
@@ -75,6 +88,37 @@ func (mh *MoonHandler) GetMoonNameFromAPI(moon models.Moon) string {
 	return ""
 }
 
+// MoonID returns the API id of a moon, taken from its Rel URL when the id is missing
+func (mh *MoonHandler) MoonID(moon models.Moon) string {
+	if moon.ID != "" {
+		return moon.ID
+	}
+	if moon.Rel == "" {
+		return ""
+	}
+	parts := strings.Split(strings.TrimRight(moon.Rel, "/"), "/")
+	return parts[len(parts)-1]
+}
+
+// ResolvedName returns a name fetched in the background for a moon listed without one
+func (mh *MoonHandler) ResolvedName(moon models.Moon) (string, bool) {
+	id := mh.MoonID(moon)
+	if id == "" {
+		return "", false
+	}
+	mh.resolvedMu.RLock()
+	defer mh.resolvedMu.RUnlock()
+	name, ok := mh.resolved[id]
+	return name, ok
+}
+
+// SetResolvedName records the name fetched for a moon id
+func (mh *MoonHandler) SetResolvedName(id, name string) {
+	mh.resolvedMu.Lock()
+	defer mh.resolvedMu.Unlock()
+	mh.resolved[id] = name
+}
+
 // extractMoonNameFromURL extracts moon name from API URL
 func (mh *MoonHandler) extractMoonNameFromURL(url string) string {
 	if url == "" {
@@ -0,0 +1,45 @@
+package visualization
+
+import (
+	"testing"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestMoonID(t *testing.T) {
+	mh := NewMoonHandler()
+	tests := []struct {
+		moon models.Moon
+		want string
+	}{
+		{models.Moon{ID: "io", Rel: "https://api.le-systeme-solaire.net/rest/bodies/europe"}, "io"},
+		{models.Moon{Rel: "https://api.le-systeme-solaire.net/rest/bodies/europe"}, "europe"},
+		{models.Moon{Rel: "https://api.le-systeme-solaire.net/rest/bodies/callisto/"}, "callisto"},
+		{models.Moon{}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := mh.MoonID(tt.moon); got != tt.want {
+			t.Errorf("MoonID(%+v) = %q, want %q", tt.moon, got, tt.want)
+		}
+	}
+}
+
+func TestResolvedNamesReplaceURLIds(t *testing.T) {
+	mh := NewMoonHandler()
+	moon := models.Moon{Rel: "https://api.le-systeme-solaire.net/rest/bodies/europe"}
+
+	if got := mh.GetMoonNameFromAPI(moon); got != "Europe" {
+		t.Errorf("GetMoonNameFromAPI() before resolving = %q, want %q", got, "Europe")
+	}
+
+	mh.SetResolvedName("europe", "Europa")
+	if got := mh.GetMoonNameFromAPI(moon); got != "Europa" {
+		t.Errorf("GetMoonNameFromAPI() after resolving = %q, want %q", got, "Europa")
+	}
+
+	named := models.Moon{EnglishName: "Io", Rel: moon.Rel}
+	if got := mh.GetMoonNameFromAPI(named); got != "Io" {
+		t.Errorf("GetMoonNameFromAPI() = %q, want the API's English name", got)
+	}
+}
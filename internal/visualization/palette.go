@@ -0,0 +1,207 @@
+package visualization
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Palette is the colors the map draws bodies, orbits and belts in. Every
+// distinction it makes is also made by the glyphs, so the map still reads with
+// no color at all; the color-blind palettes keep neighbouring planets and the two
+// belts apart for viewers who confuse red with green, or blue with yellow.
+type Palette struct {
+	Name string
+
+	Sun          tcell.Color
+	Orbit        tcell.Color
+	AsteroidBelt tcell.Color
+	KuiperBelt   tcell.Color
+	Resonance    tcell.Color
+	Transfer     tcell.Color
+	Habitable    tcell.Color
+	Envelope     tcell.Color
+	Hill         tcell.Color
+	Roche        tcell.Color
+
+	// Planets maps known bodies to their color; Other covers the rest
+	Planets map[string]tcell.Color
+	Other   tcell.Color
+}
+
+// DefaultPalette is the full-color look
+var DefaultPalette = Palette{
+	Name:         "default",
+	Sun:          tcell.ColorYellow,
+	Orbit:        tcell.ColorDarkGray,
+	AsteroidBelt: tcell.ColorDarkGray,
+	KuiperBelt:   tcell.ColorDarkGray,
+	Resonance:    tcell.ColorFuchsia,
+	Transfer:     tcell.ColorLime,
+	Habitable:    tcell.ColorDarkGreen,
+	Envelope:     tcell.ColorDarkRed,
+	Hill:         tcell.ColorTeal,
+	Roche:        tcell.ColorMaroon,
+	Planets: map[string]tcell.Color{
+		"Mercury": tcell.ColorGray,
+		"Venus":   tcell.ColorOrange,
+		"Earth":   tcell.ColorBlue,
+		"Mars":    tcell.ColorRed,
+		"Jupiter": tcell.ColorBrown,
+		"Saturn":  tcell.ColorYellow,
+		"Uranus":  tcell.ColorAqua,
+		"Neptune": tcell.ColorBlue,
+		"Pluto":   tcell.ColorGray,
+	},
+	Other: tcell.ColorWhite,
+}
+
+// DeuteranopiaPalette avoids red against green, for the most common color
+// blindness, using the Okabe-Ito colors
+var DeuteranopiaPalette = Palette{
+	Name:         "deuteranopia",
+	Sun:          tcell.NewHexColor(0xF0E442),
+	Orbit:        tcell.NewHexColor(0x555555),
+	AsteroidBelt: tcell.NewHexColor(0xA07000),
+	KuiperBelt:   tcell.NewHexColor(0x3A7CA5),
+	Resonance:    tcell.NewHexColor(0x009E73),
+	Transfer:     tcell.NewHexColor(0xF5F5F5),
+	Habitable:    tcell.NewHexColor(0x44AA99),
+	Envelope:     tcell.NewHexColor(0xCC6677),
+	Hill:         tcell.NewHexColor(0x56B4E9),
+	Roche:        tcell.NewHexColor(0xD55E00),
+	Planets: map[string]tcell.Color{
+		"Mercury": tcell.NewHexColor(0x999999),
+		"Venus":   tcell.NewHexColor(0xE69F00),
+		"Earth":   tcell.NewHexColor(0x0072B2),
+		"Mars":    tcell.NewHexColor(0xD55E00),
+		"Jupiter": tcell.NewHexColor(0xCC79A7),
+		"Saturn":  tcell.NewHexColor(0xF0E442),
+		"Uranus":  tcell.NewHexColor(0x56B4E9),
+		"Neptune": tcell.NewHexColor(0x0072B2),
+		"Pluto":   tcell.NewHexColor(0x999999),
+	},
+	Other: tcell.ColorWhite,
+}
+
+// ProtanopiaPalette also avoids red against green, and keeps off deep reds,
+// which look nearly black without red cones
+var ProtanopiaPalette = Palette{
+	Name:         "protanopia",
+	Sun:          tcell.NewHexColor(0xF0E442),
+	Orbit:        tcell.NewHexColor(0x555555),
+	AsteroidBelt: tcell.NewHexColor(0xA07000),
+	KuiperBelt:   tcell.NewHexColor(0x3A7CA5),
+	Resonance:    tcell.NewHexColor(0x009E73),
+	Transfer:     tcell.NewHexColor(0xF5F5F5),
+	Habitable:    tcell.NewHexColor(0x44AA99),
+	Envelope:     tcell.NewHexColor(0xCC6677),
+	Hill:         tcell.NewHexColor(0x88CCEE),
+	Roche:        tcell.NewHexColor(0xE69F00),
+	Planets: map[string]tcell.Color{
+		"Mercury": tcell.NewHexColor(0x999999),
+		"Venus":   tcell.NewHexColor(0xCC79A7),
+		"Earth":   tcell.NewHexColor(0x56B4E9),
+		"Mars":    tcell.NewHexColor(0xE69F00),
+		"Jupiter": tcell.NewHexColor(0xDDCC77),
+		"Saturn":  tcell.NewHexColor(0xF0E442),
+		"Uranus":  tcell.NewHexColor(0x88CCEE),
+		"Neptune": tcell.NewHexColor(0x0072B2),
+		"Pluto":   tcell.NewHexColor(0x999999),
+	},
+	Other: tcell.ColorWhite,
+}
+
+// TritanopiaPalette avoids blue against yellow and green, telling bodies apart
+// by reds, pinks and teals instead
+var TritanopiaPalette = Palette{
+	Name:         "tritanopia",
+	Sun:          tcell.NewHexColor(0xFF7F7F),
+	Orbit:        tcell.NewHexColor(0x555555),
+	AsteroidBelt: tcell.NewHexColor(0x8C3B47),
+	KuiperBelt:   tcell.NewHexColor(0x2F6F6F),
+	Resonance:    tcell.NewHexColor(0xE0E0E0),
+	Transfer:     tcell.NewHexColor(0xFFB000),
+	Habitable:    tcell.NewHexColor(0x6B4C9A),
+	Envelope:     tcell.NewHexColor(0xFF7F7F),
+	Hill:         tcell.NewHexColor(0x7FDBDB),
+	Roche:        tcell.NewHexColor(0xD7263D),
+	Planets: map[string]tcell.Color{
+		"Mercury": tcell.NewHexColor(0x999999),
+		"Venus":   tcell.NewHexColor(0xF4A6C6),
+		"Earth":   tcell.NewHexColor(0x00B3B3),
+		"Mars":    tcell.NewHexColor(0xD7263D),
+		"Jupiter": tcell.NewHexColor(0xB07AA1),
+		"Saturn":  tcell.ColorWhite,
+		"Uranus":  tcell.NewHexColor(0x7FDBDB),
+		"Neptune": tcell.NewHexColor(0x007A7A),
+		"Pluto":   tcell.NewHexColor(0x999999),
+	},
+	Other: tcell.ColorWhite,
+}
+
+// palettes are the palettes the config can name
+var palettes = map[string]Palette{
+	DefaultPalette.Name:      DefaultPalette,
+	DeuteranopiaPalette.Name: DeuteranopiaPalette,
+	ProtanopiaPalette.Name:   ProtanopiaPalette,
+	TritanopiaPalette.Name:   TritanopiaPalette,
+}
+
+// PaletteNames returns the names ParsePalette accepts, sorted
+func PaletteNames() []string {
+	names := make([]string, 0, len(palettes))
+	for name := range palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParsePalette looks a palette up by name, in any case; an empty name selects the
+// default
+func ParsePalette(name string) (Palette, error) {
+	if name == "" {
+		return DefaultPalette, nil
+	}
+	if palette, ok := palettes[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return palette, nil
+	}
+	return DefaultPalette, fmt.Errorf("unknown palette %q (want %s)", name, strings.Join(PaletteNames(), ", "))
+}
+
+// InkColor returns the color for a cell inked with symbol, a glyph from symbols
+func (p Palette) InkColor(symbols SymbolSet, symbol rune) tcell.Color {
+	switch symbol {
+	case symbols.Sun:
+		return p.Sun
+	case symbols.Orbit:
+		return p.Orbit
+	case symbols.AsteroidBelt:
+		return p.AsteroidBelt
+	case symbols.KuiperBelt:
+		return p.KuiperBelt
+	case symbols.Resonance:
+		return p.Resonance
+	case symbols.Transfer:
+		return p.Transfer
+	case symbols.Habitable:
+		return p.Habitable
+	case symbols.Envelope:
+		return p.Envelope
+	case symbols.Hill:
+		return p.Hill
+	case symbols.Roche:
+		return p.Roche
+	}
+	for name, planetSymbol := range symbols.Planets {
+		if planetSymbol == symbol {
+			if c, ok := p.Planets[name]; ok {
+				return c
+			}
+		}
+	}
+	return p.Other
+}
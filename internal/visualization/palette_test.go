@@ -0,0 +1,65 @@
+package visualization
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestParsePalette(t *testing.T) {
+	for _, name := range []string{"", "default", "Deuteranopia", "protanopia", " tritanopia "} {
+		if _, err := ParsePalette(name); err != nil {
+			t.Errorf("ParsePalette(%q) error = %v", name, err)
+		}
+	}
+
+	palette, err := ParsePalette("sepia")
+	if err == nil {
+		t.Error("ParsePalette(\"sepia\") error = nil, want an error")
+	}
+	if palette.Name != DefaultPalette.Name {
+		t.Errorf("ParsePalette(\"sepia\") = %s, want the default to fall back on", palette.Name)
+	}
+}
+
+func TestPaletteInkColor(t *testing.T) {
+	for _, symbols := range []SymbolSet{UnicodeSymbols, ASCIISymbols} {
+		p := DeuteranopiaPalette
+		if got := p.InkColor(symbols, symbols.Planets["Mars"]); got != p.Planets["Mars"] {
+			t.Errorf("Mars ink = %v, want %v", got, p.Planets["Mars"])
+		}
+		if got := p.InkColor(symbols, symbols.Sun); got != p.Sun {
+			t.Errorf("Sun ink = %v, want %v", got, p.Sun)
+		}
+		if got := p.InkColor(symbols, symbols.KuiperBelt); got != p.KuiperBelt {
+			t.Errorf("Kuiper belt ink = %v, want %v", got, p.KuiperBelt)
+		}
+		if got := p.InkColor(symbols, symbols.Resonance); got != p.Resonance {
+			t.Errorf("resonance ink = %v, want %v", got, p.Resonance)
+		}
+		if got := p.InkColor(symbols, symbols.Transfer); got != p.Transfer {
+			t.Errorf("transfer ink = %v, want %v", got, p.Transfer)
+		}
+		if got := p.InkColor(symbols, symbols.Envelope); got != p.Envelope {
+			t.Errorf("envelope ink = %v, want %v", got, p.Envelope)
+		}
+		if got := p.InkColor(symbols, 'Ω'); got != p.Other {
+			t.Errorf("unknown ink = %v, want %v", got, p.Other)
+		}
+	}
+}
+
+// The color-blind palettes must not fall back to the default's red and green for
+// the planets, or the belts to one shared color
+func TestColorBlindPalettesSeparateBelts(t *testing.T) {
+	for _, p := range []Palette{DeuteranopiaPalette, ProtanopiaPalette, TritanopiaPalette} {
+		if p.AsteroidBelt == p.KuiperBelt {
+			t.Errorf("%s draws both belts in %v", p.Name, p.AsteroidBelt)
+		}
+		for name, c := range p.Planets {
+			if c == tcell.ColorRed || c == tcell.ColorGreen {
+				t.Errorf("%s draws %s in %v", p.Name, name, c)
+			}
+		}
+	}
+}
@@ -2,10 +2,12 @@ package visualization
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/furan917/go-solar-system/internal/constants"
 	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -13,9 +15,23 @@ import (
 type PlanetPosition struct {
 	X, Y   int
 	Radius int
+	World  WorldPoint
 	Planet models.CelestialBody
 }
 
+// WorldPoint is a position in the orbital plane in kilometres, relative to the system barycenter
+type WorldPoint struct {
+	X, Y float64
+}
+
+// Camera describes how the orbital view maps onto the terminal grid
+type Camera struct {
+	OriginX, OriginY int // grid cell of the system barycenter
+	Width, Height    int
+	AspectRatio      float64
+	Scale            string
+}
+
 // RendererDependencies encapsulates all dependencies for the Renderer
 type RendererDependencies struct {
 	CircleDrawer       *CircleDrawer
@@ -35,6 +51,29 @@ type Renderer struct {
 	debrisBeltRenderer *DebrisBeltRenderer
 	distanceScaler     *DistanceScaler
 	moonHandler        *MoonHandler
+	renderMode         RenderMode
+	symbols            SymbolSet
+	palette            Palette
+	belts              []models.Belt
+	beltVisibility     BeltVisibility
+	miniature          bool
+	showResonances     bool
+	resonanceLinks     []ResonanceLink
+	transfer           *TransferPath
+	transferMarks      TransferMarks
+	transferDrawn      bool
+	showWobble         bool
+	wobbleMarks        WobbleMarks
+	wobbleDrawn        bool
+	showHabitable      bool
+	habitableMarks     HabitableZoneMarks
+	habitableDrawn     bool
+	spheresBody        string
+	sphereMarks        SphereMarks
+	sphereDrawn        bool
+	evolved            *EvolvedStar
+	stripLabels        []StripLabel
+	grids              gridPool
 }
 
 // NewRenderer creates a renderer with dependency injection
@@ -49,6 +88,9 @@ func NewRenderer(width, height int, deps RendererDependencies) *Renderer {
 		debrisBeltRenderer: deps.DebrisBeltRenderer,
 		distanceScaler:     deps.DistanceScaler,
 		moonHandler:        deps.MoonHandler,
+		renderMode:         RenderModeCells,
+		symbols:            UnicodeSymbols,
+		palette:            DefaultPalette,
 	}
 }
 
@@ -72,66 +114,93 @@ func NewRendererWithDefaults(width, height int) *Renderer {
 }
 
 func (r *Renderer) RenderSolarSystemData(planets []models.CelestialBody, width, height int) [][]rune {
-	centerX := width / 2
-	centerY := height / 2
-
 	r.celestialRenderer.UpdateDimensions(r.width, r.height)
+	grid, _ := r.renderBodies(planets, nil, width, height)
+	return grid.Runes()
+}
 
-	grid := r.createGrid(width, height)
-
-	stars, actualPlanets := r.separateStarsAndPlanets(planets)
-
-	if len(stars) > 0 {
-		r.celestialRenderer.RenderStars(grid, centerX, centerY, stars)
-	} else {
-		r.celestialRenderer.RenderSun(grid, centerX, centerY)
-	}
-
-	r.debrisBeltRenderer.RenderAsteroidBelt(grid, centerX, centerY, actualPlanets)
-	r.debrisBeltRenderer.RenderKuiperBelt(grid, centerX, centerY, actualPlanets)
-
-	for _, planet := range actualPlanets {
-		if planet.SemimajorAxis <= 0 {
-			continue
-		}
-
-		radius := r.distanceScaler.ScaleDistance(planet.SemimajorAxis, actualPlanets)
-
-		r.celestialRenderer.RenderOrbit(grid, centerX, centerY, radius)
-
-		r.celestialRenderer.RenderPlanet(grid, centerX, centerY, planet, radius)
-	}
+// RenderSolarSystemDataWithPositions renders and returns planet positions for mouse
+// interaction. The grid is reused by the render after next, so read it before then.
+func (r *Renderer) RenderSolarSystemDataWithPositions(planets []models.CelestialBody, width, height, screenWidth, screenHeight int) (*Grid, map[string]PlanetPosition) {
+	r.celestialRenderer.UpdateDimensions(screenWidth, screenHeight)
+	return r.renderBodies(planets, nil, width, height)
+}
 
-	return grid
+// RenderFrameWithPositions renders a frame centred on one body, such as a planet
+// with its moons around it, and returns where each body landed. Distances are
+// scaled to the satellites' orbits, and no debris belts are drawn. The grid is
+// reused like RenderSolarSystemDataWithPositions's.
+func (r *Renderer) RenderFrameWithPositions(center models.CelestialBody, satellites []models.CelestialBody, width, height, screenWidth, screenHeight int) (*Grid, map[string]PlanetPosition) {
+	r.celestialRenderer.UpdateDimensions(screenWidth, screenHeight)
+	return r.renderBodies(satellites, &center, width, height)
 }
 
-// RenderSolarSystemDataWithPositions renders and returns planet positions for mouse interaction
-func (r *Renderer) RenderSolarSystemDataWithPositions(planets []models.CelestialBody, width, height, screenWidth, screenHeight int) ([][]rune, map[string]PlanetPosition) {
+// renderBodies draws the bodies into a new grid and works out where each one
+// landed. The middle of the frame is the system's stars, pulled off it by their
+// planets when the wobble is shown, or center when it is given. That, the debris
+// belts, every orbit and planet, any resonance links, any transfer and the
+// habitable zone are drawn as separate layers in parallel, then composited in
+// that order; the habitable zone goes last as it only fills cells left blank.
+func (r *Renderer) renderBodies(planets []models.CelestialBody, center *models.CelestialBody, width, height int) (*Grid, map[string]PlanetPosition) {
 	centerX := width / 2
 	centerY := height / 2
 	planetPositions := make(map[string]PlanetPosition)
 
-	r.celestialRenderer.UpdateDimensions(screenWidth, screenHeight)
-
 	grid := r.createGrid(width, height)
 
+	var draws []func(*Grid)
 	stars, actualPlanets := r.separateStarsAndPlanets(planets)
-
-	if len(stars) > 0 {
-		r.celestialRenderer.RenderStars(grid, centerX, centerY, stars)
+	starX, starY := centerX, centerY
+	r.wobbleMarks, r.wobbleDrawn = WobbleMarks{}, false
+	if center != nil {
+		stars, actualPlanets = nil, planets
+		r.celestialRenderer.SetBodyStyles(nil, append([]models.CelestialBody{*center}, planets...))
+		draws = append(draws, func(layer *Grid) {
+			r.celestialRenderer.RenderCentralBody(layer, centerX, centerY, *center)
+		})
+		planetPositions[center.EnglishName] = PlanetPosition{
+			X:      centerX,
+			Y:      centerY,
+			Radius: r.celestialRenderer.GetSunSize(),
+			World:  WorldPoint{},
+			Planet: *center,
+		}
 	} else {
-		r.celestialRenderer.RenderSun(grid, centerX, centerY)
+		r.celestialRenderer.SetBodyStyles(stars, actualPlanets)
+		if r.showWobble {
+			r.wobbleMarks, r.wobbleDrawn = r.starWobble(stars, actualPlanets, centerX, centerY), true
+			starX, starY = r.wobbleMarks.StarX, r.wobbleMarks.StarY
+		}
+		draws = append(draws,
+			func(layer *Grid) {
+				if len(stars) > 0 {
+					r.celestialRenderer.RenderStars(layer, starX, starY, stars)
+				} else {
+					r.celestialRenderer.RenderSun(layer, starX, starY)
+				}
+			},
+			func(layer *Grid) {
+				density := 1.0
+				switch r.beltVisibility {
+				case BeltsHidden:
+					return
+				case BeltsFaint:
+					density = faintBeltDensity
+				}
+				for _, belt := range r.belts {
+					r.debrisBeltRenderer.RenderBelt(layer, centerX, centerY, belt, actualPlanets, density)
+				}
+			},
+		)
 	}
 
-	r.debrisBeltRenderer.RenderAsteroidBelt(grid, centerX, centerY, actualPlanets)
-	r.debrisBeltRenderer.RenderKuiperBelt(grid, centerX, centerY, actualPlanets)
-
 	for _, star := range stars {
 		starRadius := r.celestialRenderer.GetSunSize() // Use sun size for now
 		planetPositions[star.EnglishName] = PlanetPosition{
-			X:      centerX, // Simplified - stars are at barycenter for interaction
-			Y:      centerY,
+			X:      starX, // Simplified - stars are at barycenter for interaction
+			Y:      starY,
 			Radius: starRadius,
+			World:  WorldPoint{},
 			Planet: star,
 		}
 	}
@@ -143,42 +212,230 @@ func (r *Renderer) RenderSolarSystemDataWithPositions(planets []models.Celestial
 
 		radius := r.distanceScaler.ScaleDistance(planet.SemimajorAxis, actualPlanets)
 
-		r.celestialRenderer.RenderOrbit(grid, centerX, centerY, radius)
-
-		angle := r.celestialRenderer.GetOrbitalAngle(planet)
-		px, py := r.circleDrawer.CalculatePosition(centerX, centerY, radius, angle)
+		px, py := r.celestialRenderer.GetPlanetPosition(centerX, centerY, planet, radius)
 		planetRadius := r.celestialRenderer.GetPlanetSize(planet.MeanRadius)
 
 		planetPositions[planet.EnglishName] = PlanetPosition{
 			X:      px,
 			Y:      py,
 			Radius: planetRadius,
+			World:  r.celestialRenderer.GetWorldPosition(planet),
 			Planet: planet,
 		}
 
-		r.celestialRenderer.RenderPlanet(grid, centerX, centerY, planet, radius)
+		draws = append(draws,
+			func(layer *Grid) {
+				r.celestialRenderer.RenderBodyOrbit(layer, centerX, centerY, planet, radius)
+			},
+			func(layer *Grid) {
+				r.celestialRenderer.RenderPlanet(layer, centerX, centerY, planet, radius)
+			},
+		)
 	}
 
+	r.resonanceLinks = nil
+	if r.showResonances {
+		var links []func(*Grid)
+		links, r.resonanceLinks = r.resonanceDraws(actualPlanets, planetPositions)
+		draws = append(draws, links...)
+	}
+
+	r.transferMarks, r.transferDrawn = TransferMarks{}, false
+	if r.transfer != nil && center == nil {
+		var path func(*Grid)
+		if path, r.transferMarks, r.transferDrawn = r.transferDraw(actualPlanets, centerX, centerY); r.transferDrawn {
+			draws = append(draws, path)
+		}
+	}
+
+	if r.evolved != nil && center == nil {
+		if envelope, ok := r.envelopeDraw(actualPlanets, starX, starY); ok {
+			draws = append(draws, envelope)
+		}
+	}
+
+	r.habitableMarks, r.habitableDrawn = HabitableZoneMarks{}, false
+	if (r.showHabitable || r.evolved != nil) && center == nil {
+		luminosity := starsLuminosity(stars)
+		if r.evolved != nil {
+			luminosity = r.evolved.Luminosity
+		}
+		var zone func(*Grid)
+		if zone, r.habitableMarks, r.habitableDrawn = r.habitableZoneDraw(luminosity, actualPlanets, centerX, centerY); r.habitableDrawn {
+			draws = append(draws, zone)
+		}
+	}
+
+	r.sphereMarks, r.sphereDrawn = SphereMarks{}, false
+	if r.spheresBody != "" {
+		if spheres, ok := r.spheresFor(planets, center, planetPositions); ok {
+			draws = append(draws, spheres)
+		}
+	}
+
+	drawLayers(grid, r.grids.layers(grid, len(draws)), draws)
 	return grid, planetPositions
 }
 
-// createGrid creates a new grid filled with spaces
-func (r *Renderer) createGrid(width, height int) [][]rune {
-	grid := make([][]rune, height)
-	for i := range grid {
-		grid[i] = make([]rune, width)
-		for j := range grid[i] {
-			grid[i][j] = ' '
-		}
+// GetCamera returns the camera used for a view of the given grid size
+func (r *Renderer) GetCamera(width, height int) Camera {
+	return Camera{
+		OriginX:     width / 2,
+		OriginY:     height / 2,
+		Width:       width,
+		Height:      height,
+		AspectRatio: r.circleDrawer.AspectRatio(),
+		Scale:       "logarithmic",
 	}
-	return grid
 }
 
-// GetPlanetSymbol returns the Unicode symbol for a celestial body (delegated to celestial renderer)
+// createGrid returns an empty grid in the current render mode from the pool
+func (r *Renderer) createGrid(width, height int) *Grid {
+	return r.grids.grid(width, height, r.renderMode)
+}
+
+// SetRenderMode selects how orbits and bodies are rasterised
+func (r *Renderer) SetRenderMode(mode RenderMode) {
+	r.renderMode = mode
+}
+
+// GetRenderMode returns the current render mode
+func (r *Renderer) GetRenderMode() RenderMode {
+	return r.renderMode
+}
+
+// SetSymbols selects the glyphs used for bodies, orbits and belts
+func (r *Renderer) SetSymbols(symbols SymbolSet) {
+	r.symbols = symbols
+	r.celestialRenderer.SetSymbols(symbols)
+	r.debrisBeltRenderer.SetSymbols(symbols)
+}
+
+// SetPalette selects the colors bodies, orbits and belts are drawn in
+func (r *Renderer) SetPalette(palette Palette) {
+	r.palette = palette
+}
+
+// GetPalette returns the palette in use
+func (r *Renderer) GetPalette() Palette {
+	return r.palette
+}
+
+// SetMiniature draws the stars and planets at their smallest, for a frame too
+// small for their usual sizes such as an overview inset
+func (r *Renderer) SetMiniature(miniature bool) {
+	r.miniature = miniature
+	r.celestialRenderer.SetMiniature(miniature)
+	r.distanceScaler.SetCompact(miniature)
+}
+
+// SetBelts selects the debris belts drawn round the stars: the ones the system's
+// file declares, or the Solar System's
+func (r *Renderer) SetBelts(belts []models.Belt) {
+	r.belts = belts
+}
+
+// Belts returns the debris belts drawn round the stars
+func (r *Renderer) Belts() []models.Belt {
+	return r.belts
+}
+
+// SetBeltVisibility draws the belts, draws them faintly or leaves them off the map
+func (r *Renderer) SetBeltVisibility(visibility BeltVisibility) {
+	r.beltVisibility = visibility
+}
+
+// BeltVisibility returns how the belts are drawn
+func (r *Renderer) BeltVisibility() BeltVisibility {
+	return r.beltVisibility
+}
+
+// SetResonancesShown draws links between orbits in resonance, or stops drawing them
+func (r *Renderer) SetResonancesShown(shown bool) {
+	r.showResonances = shown
+}
+
+// ResonancesShown reports whether resonance links are drawn
+func (r *Renderer) ResonancesShown() bool {
+	return r.showResonances
+}
+
+// ResonanceLinks returns the resonances the last render drew, to be labelled
+func (r *Renderer) ResonanceLinks() []ResonanceLink {
+	return r.resonanceLinks
+}
+
+// InkColor returns the palette's color for cells inked with symbol
+func (r *Renderer) InkColor(symbol rune) tcell.Color {
+	return r.palette.InkColor(r.symbols, symbol)
+}
+
+// SetAspectRatio sets the height-to-width ratio of a terminal cell used to keep
+// orbits round
+func (r *Renderer) SetAspectRatio(aspectRatio float64) {
+	r.circleDrawer.SetAspectRatio(aspectRatio)
+}
+
+// GetAspectRatio returns the cell ratio in use
+func (r *Renderer) GetAspectRatio() float64 {
+	return r.circleDrawer.AspectRatio()
+}
+
+// GetSymbols returns the glyphs in use
+func (r *Renderer) GetSymbols() SymbolSet {
+	return r.symbols
+}
+
+// GetPlanetSymbol returns the symbol for a celestial body (delegated to celestial renderer)
 func (r *Renderer) GetPlanetSymbol(name string) rune {
 	return r.celestialRenderer.GetPlanetSymbol(name)
 }
 
+// BodyClassifier returns the rules bodies are given class symbols by
+func (r *Renderer) BodyClassifier() *BodyClassifier {
+	return r.celestialRenderer.BodyClassifier()
+}
+
+// GetCurrentMeanAnomaly returns a planet's present-day mean anomaly in radians
+func (r *Renderer) GetCurrentMeanAnomaly(planet models.CelestialBody) float64 {
+	return r.celestialRenderer.GetCurrentMeanAnomaly(planet)
+}
+
+// SetTimeSource makes rendering read real time from now instead of the system
+// clock (delegated to celestial renderer)
+func (r *Renderer) SetTimeSource(now func() time.Time) {
+	r.celestialRenderer.SetTimeSource(now)
+}
+
+// SetClock makes rendering read real time from clock, such as a StepClock that
+// only moves once a frame (delegated to celestial renderer)
+func (r *Renderer) SetClock(clock orbital.Clock) {
+	r.celestialRenderer.SetClock(clock)
+}
+
+// SetDistanceRange puts every orbit on one common scale, from minDistance at the
+// innermost ring to maxDistance at the outermost (km). Zero values go back to
+// fitting each system on its own.
+func (r *Renderer) SetDistanceRange(minDistance, maxDistance float64) {
+	r.distanceScaler.SetRange(minDistance, maxDistance)
+}
+
+// ShareTimeline makes this renderer animate from other's clock, so two views of
+// different systems always show the same simulated moment
+func (r *Renderer) ShareTimeline(other *Renderer) {
+	r.celestialRenderer.shareTimeline(other.celestialRenderer)
+}
+
+// GetClock returns the simulation clock driving the animation
+func (r *Renderer) GetClock() *orbital.SimulationClock {
+	return r.celestialRenderer.GetClock()
+}
+
+// GetEphemeris returns the ephemeris used to place bodies
+func (r *Renderer) GetEphemeris() *orbital.Ephemeris {
+	return r.celestialRenderer.GetEphemeris()
+}
+
 // GetMoonHandler returns the moon handler for external use
 func (r *Renderer) GetMoonHandler() *MoonHandler {
 	return r.moonHandler
@@ -202,8 +459,12 @@ func (r *Renderer) UpdateDimensions(width, height int) {
 	r.centerY = height / 2
 
 	r.celestialRenderer.UpdateDimensions(width, height)
+	minDistance, maxDistance := r.distanceScaler.Range()
 	r.distanceScaler = NewDistanceScaler(width, height)
+	r.distanceScaler.SetRange(minDistance, maxDistance)
+	r.distanceScaler.SetCompact(r.miniature)
 	r.debrisBeltRenderer = NewDebrisBeltRenderer(r.circleDrawer, r.distanceScaler)
+	r.debrisBeltRenderer.SetSymbols(r.symbols)
 }
 
 // separateStarsAndPlanets separates celestial bodies into stars and planets
@@ -222,6 +483,12 @@ func (r *Renderer) separateStarsAndPlanets(bodies []models.CelestialBody) ([]mod
 	return stars, planets
 }
 
+// GetBodyColor returns the color a system file gave the bodies drawn with symbol,
+// as of the last render
+func (r *Renderer) GetBodyColor(symbol rune) (tcell.Color, bool) {
+	return r.celestialRenderer.BodyColor(symbol)
+}
+
 func (r *Renderer) GetColorForSymbol(symbol rune) tcell.Color {
 	return r.symbolToTcellColor(symbol)
 }
@@ -229,15 +496,25 @@ func (r *Renderer) GetColorForSymbol(symbol rune) tcell.Color {
 func (r *Renderer) getColorForSymbol(symbol rune) *color.Color {
 	knownColorMap := map[rune]*color.Color{
 		'☿': color.New(color.FgHiBlack, color.Bold),   // Mercury
+		'm': color.New(color.FgHiBlack, color.Bold),   // Mercury (ASCII)
 		'♀': color.New(color.FgYellow, color.Bold),    // Venus
+		'V': color.New(color.FgYellow, color.Bold),    // Venus (ASCII)
 		'♁': color.New(color.FgBlue, color.Bold),      // Earth
+		'E': color.New(color.FgBlue, color.Bold),      // Earth (ASCII)
 		'♂': color.New(color.FgRed, color.Bold),       // Mars
+		'M': color.New(color.FgRed, color.Bold),       // Mars (ASCII)
 		'♃': color.New(color.FgHiYellow, color.Bold),  // Jupiter
+		'J': color.New(color.FgHiYellow, color.Bold),  // Jupiter (ASCII)
 		'♄': color.New(color.FgHiMagenta, color.Bold), // Saturn
+		'S': color.New(color.FgHiMagenta, color.Bold), // Saturn (ASCII)
 		'♅': color.New(color.FgCyan, color.Bold),      // Uranus
+		'U': color.New(color.FgCyan, color.Bold),      // Uranus (ASCII)
 		'♆': color.New(color.FgBlue, color.Bold),      // Neptune
+		'N': color.New(color.FgBlue, color.Bold),      // Neptune (ASCII)
 		'♇': color.New(color.FgHiBlack, color.Bold),   // Pluto
+		'P': color.New(color.FgHiBlack, color.Bold),   // Pluto (ASCII)
 		'☉': color.New(color.FgYellow, color.Bold),    // Sun
+		'*': color.New(color.FgYellow, color.Bold),    // Sun (ASCII)
 	}
 
 	if planetColor, exists := knownColorMap[symbol]; exists {
@@ -287,15 +564,25 @@ func (r *Renderer) getPlanetColors() map[string]*color.Color {
 func (r *Renderer) symbolToTcellColor(symbol rune) tcell.Color {
 	colorMap := map[rune]tcell.Color{
 		'☿': tcell.ColorGray,   // Mercury
+		'm': tcell.ColorGray,   // Mercury (ASCII)
 		'♀': tcell.ColorYellow, // Venus
+		'V': tcell.ColorYellow, // Venus (ASCII)
 		'♁': tcell.ColorBlue,   // Earth
+		'E': tcell.ColorBlue,   // Earth (ASCII)
 		'♂': tcell.ColorRed,    // Mars
+		'M': tcell.ColorRed,    // Mars (ASCII)
 		'♃': tcell.ColorOrange, // Jupiter
+		'J': tcell.ColorOrange, // Jupiter (ASCII)
 		'♄': tcell.ColorPurple, // Saturn
+		'S': tcell.ColorPurple, // Saturn (ASCII)
 		'♅': tcell.ColorTeal,   // Uranus
+		'U': tcell.ColorTeal,   // Uranus (ASCII)
 		'♆': tcell.ColorNavy,   // Neptune
+		'N': tcell.ColorNavy,   // Neptune (ASCII)
 		'♇': tcell.ColorGray,   // Pluto
+		'P': tcell.ColorGray,   // Pluto (ASCII)
 		'☉': tcell.ColorYellow, // Sun
+		'*': tcell.ColorYellow, // Sun (ASCII)
 		'✦': tcell.ColorBlue,   // Blue star
 		'✧': tcell.ColorWhite,  // White star
 		'✩': tcell.ColorOrange, // Orange star
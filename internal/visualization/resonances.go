@@ -0,0 +1,52 @@
+package visualization
+
+import (
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+)
+
+// ResonanceLink is a resonance drawn on the map, with the cell its ratio is
+// labelled at: halfway along the line between the two bodies
+type ResonanceLink struct {
+	X, Y      int
+	Resonance orbital.Resonance
+}
+
+// resonanceDraws returns a layer for each resonance between the drawn bodies,
+// and where each is labelled
+func (r *Renderer) resonanceDraws(bodies []models.CelestialBody, positions map[string]PlanetPosition) ([]func(*Grid), []ResonanceLink) {
+	var draws []func(*Grid)
+	var links []ResonanceLink
+	for _, resonance := range orbital.FindResonances(bodies) {
+		inner, innerDrawn := positions[resonance.Inner.EnglishName]
+		outer, outerDrawn := positions[resonance.Outer.EnglishName]
+		if !innerDrawn || !outerDrawn {
+			continue
+		}
+		draws = append(draws, func(layer *Grid) {
+			drawDottedLine(layer, inner.X, inner.Y, outer.X, outer.Y, r.symbols.Resonance)
+		})
+		links = append(links, ResonanceLink{X: (inner.X + outer.X) / 2, Y: (inner.Y + outer.Y) / 2, Resonance: resonance})
+	}
+	return draws, links
+}
+
+// drawDottedLine plots a dashed line between the middles of two cells, leaving
+// alone cells already drawn in so it passes behind bodies
+func drawDottedLine(grid *Grid, x1, y1, x2, y2 int, ink rune) {
+	subX, subY := grid.SubCells()
+	dx, dy := float64(x2-x1), float64(y2-y1)
+	steps := int(math.Ceil(math.Max(math.Abs(dx)*float64(subX), math.Abs(dy)*float64(subY))))
+	if steps == 0 {
+		return
+	}
+	for i := 0; i <= steps; i++ {
+		if (i/subX)%2 != 0 {
+			continue
+		}
+		t := float64(i) / float64(steps)
+		grid.Plot(float64(x1)+0.5+dx*t, float64(y1)+0.5+dy*t, ink)
+	}
+}
@@ -0,0 +1,42 @@
+package visualization
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Io, Europa and Ganymede are in the 1:2:4 Laplace resonance
+func TestRenderResonanceLinks(t *testing.T) {
+	jupiter, moons := jupiterFrameFixture()
+	renderer := NewRendererWithDefaults(120, 40)
+	renderer.SetTimeSource(func() time.Time { return goldenTime })
+
+	grid, _ := renderer.RenderFrameWithPositions(jupiter, moons, 120, 40, 120, 40)
+	if links := renderer.ResonanceLinks(); len(links) != 0 {
+		t.Errorf("ResonanceLinks() = %+v with resonances hidden, want none", links)
+	}
+	if strings.ContainsRune(grid.String(), renderer.GetSymbols().Resonance) {
+		t.Error("resonance links drawn while hidden")
+	}
+
+	renderer.SetResonancesShown(true)
+	grid, positions := renderer.RenderFrameWithPositions(jupiter, moons, 120, 40, 120, 40)
+	links := renderer.ResonanceLinks()
+	if len(links) != 2 {
+		t.Fatalf("ResonanceLinks() = %+v, want Io-Europa and Europa-Ganymede", links)
+	}
+	for _, link := range links {
+		if link.Resonance.Label() != "1:2" {
+			t.Errorf("%s-%s labelled %s, want 1:2", link.Resonance.Inner.EnglishName, link.Resonance.Outer.EnglishName, link.Resonance.Label())
+		}
+	}
+
+	io, europa := positions["Io"], positions["Europa"]
+	if links[0].X != (io.X+europa.X)/2 || links[0].Y != (io.Y+europa.Y)/2 {
+		t.Errorf("Io-Europa label at %d,%d, want halfway between them", links[0].X, links[0].Y)
+	}
+	if !strings.ContainsRune(grid.String(), renderer.GetSymbols().Resonance) {
+		t.Error("no resonance link drawn")
+	}
+}
@@ -0,0 +1,115 @@
+package visualization
+
+import (
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+)
+
+// sphereSpacing is roughly how many cells apart the dots of the Hill sphere and
+// Roche limit are, so they read as faint circles behind the orbits
+const sphereSpacing = 2.5
+
+// SphereMarks is the Hill sphere and Roche limit the last render drew round a
+// body: their radii in km, 0 when unknown, and in rows as drawn, 0 when they
+// would be hidden under the body's symbol
+type SphereMarks struct {
+	Body            string
+	HillKm, RocheKm float64
+	Hill, Roche     float64
+}
+
+// SetSpheresBody draws the Hill sphere and Roche limit round the named body, or
+// round none for ""
+func (r *Renderer) SetSpheresBody(name string) {
+	r.spheresBody = name
+}
+
+// SpheresBody returns the body whose Hill sphere and Roche limit are drawn, or ""
+func (r *Renderer) SpheresBody() string {
+	return r.spheresBody
+}
+
+// SphereMarks returns the spheres the last render drew, and false if it was not
+// asked for any or the body was not on the map
+func (r *Renderer) SphereMarks() (SphereMarks, bool) {
+	return r.sphereMarks, r.sphereDrawn
+}
+
+// spheresFor draws the spheres of the body asked for where it was placed on the
+// map: round the centre of a frame centred on it, scaled like its moons' orbits
+// and in line from its edge inside the innermost, or round its place on its orbit, scaled like the orbits at its distance
+func (r *Renderer) spheresFor(planets []models.CelestialBody, center *models.CelestialBody, positions map[string]PlanetPosition) (func(*Grid), bool) {
+	pos, ok := positions[r.spheresBody]
+	if !ok {
+		return nil, false
+	}
+	body := pos.Planet
+	cells := func(km float64) float64 {
+		return r.distanceScaler.ScaleDistance(body.SemimajorAxis+km, planets) - r.distanceScaler.ScaleDistance(body.SemimajorAxis, planets)
+	}
+	if center != nil {
+		if center.EnglishName != body.EnglishName {
+			return nil, false
+		}
+		// The log scale runs out inside the innermost orbit, so closer in the
+		// distance runs in line from the body's drawn edge out to that orbit
+		inner := math.Inf(1)
+		for _, planet := range planets {
+			if planet.SemimajorAxis > body.MeanRadius {
+				inner = math.Min(inner, planet.SemimajorAxis)
+			}
+		}
+		edge := float64(pos.Radius)
+		cells = func(km float64) float64 {
+			if km < inner {
+				return edge + (km-body.MeanRadius)/(inner-body.MeanRadius)*(r.distanceScaler.ScaleDistance(inner, planets)-edge)
+			}
+			return r.distanceScaler.ScaleDistance(km, planets)
+		}
+	} else if body.SemimajorAxis <= 0 {
+		return nil, false
+	}
+
+	var draw func(*Grid)
+	draw, r.sphereMarks = r.spheresDraw(body, pos.X, pos.Y, cells, float64(pos.Radius))
+	r.sphereDrawn = true
+	return draw, true
+}
+
+// spheresDraw returns the drawing of body's Hill sphere and Roche limit as
+// dotted circles round x, y. cells turns a distance from the body in km into
+// rows on the map, scaled like the orbits round it; circles less than a cell
+// wider than bodyRadius are left off, as the body's symbol would cover them.
+func (r *Renderer) spheresDraw(body models.CelestialBody, x, y int, cells func(km float64) float64, bodyRadius float64) (func(*Grid), SphereMarks) {
+	marks := SphereMarks{Body: body.EnglishName}
+	if hill, ok := orbital.BodyHillRadius(body); ok {
+		marks.HillKm = hill
+		if radius := cells(hill); radius >= bodyRadius+1 {
+			marks.Hill = radius
+		}
+	}
+	if roche, ok := orbital.BodyRocheLimit(body); ok {
+		marks.RocheKm = roche
+		if radius := cells(roche); radius >= bodyRadius+1 {
+			marks.Roche = radius
+		}
+	}
+
+	return func(layer *Grid) {
+		for _, ring := range []struct {
+			radius float64
+			ink    rune
+		}{{marks.Hill, r.symbols.Hill}, {marks.Roche, r.symbols.Roche}} {
+			if ring.radius <= 0 {
+				continue
+			}
+			dots := max(8, int(2*math.Pi*ring.radius/sphereSpacing))
+			for i := 0; i < dots; i++ {
+				px, py := r.circleDrawer.calculatePoint(x, y, ring.radius, 2*math.Pi*float64(i)/float64(dots))
+				layer.SetIfEmpty(int(px), int(py), ring.ink)
+			}
+		}
+	}, marks
+}
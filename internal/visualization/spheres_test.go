@@ -0,0 +1,53 @@
+package visualization
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestRenderSpheres(t *testing.T) {
+	jupiter := body("Jupiter", "Planet", 778340821, 69911, 4332.59)
+	jupiter.Mass = models.Mass{MassValue: 1.8982, MassExponent: 27}
+	moons := []models.CelestialBody{
+		body("Io", "Moon", 421700, 1821.6, 1.769),
+		body("Europa", "Moon", 671034, 1560.8, 3.551),
+		body("Ganymede", "Moon", 1070412, 2634.1, 7.155),
+		body("Callisto", "Moon", 1882709, 2410.3, 16.689),
+	}
+	renderer := NewRendererWithDefaults(120, 40)
+	renderer.SetTimeSource(func() time.Time { return goldenTime })
+
+	grid, _ := renderer.RenderFrameWithPositions(jupiter, moons, 120, 40, 120, 40)
+	if _, ok := renderer.SphereMarks(); ok || strings.ContainsRune(grid.String(), renderer.GetSymbols().Roche) {
+		t.Fatal("spheres drawn while hidden")
+	}
+
+	renderer.SetSpheresBody("Jupiter")
+	grid, positions := renderer.RenderFrameWithPositions(jupiter, moons, 120, 40, 120, 40)
+	marks, ok := renderer.SphereMarks()
+	if !ok || marks.Body != "Jupiter" || !strings.ContainsRune(grid.String(), renderer.GetSymbols().Roche) {
+		t.Fatalf("Expected Jupiter's spheres drawn, got %+v (%v)", marks, ok)
+	}
+
+	// The Roche limit lies inside Io's orbit and the Hill sphere beyond Callisto's
+	radius := func(name string) float64 {
+		p := positions[name]
+		return math.Hypot(float64(p.X-60)/renderer.GetAspectRatio(), float64(p.Y-20))
+	}
+	if marks.Roche <= 0 || marks.Roche > radius("Io") {
+		t.Errorf("Roche limit drawn %.1f rows out, Io's orbit %.1f", marks.Roche, radius("Io"))
+	}
+	if marks.Hill < radius("Callisto") {
+		t.Errorf("Hill sphere drawn %.1f rows out, Callisto's orbit %.1f", marks.Hill, radius("Callisto"))
+	}
+
+	// Another body's frame leaves them off
+	renderer.RenderFrameWithPositions(moons[0], nil, 120, 40, 120, 40)
+	if _, ok := renderer.SphereMarks(); ok {
+		t.Error("Expected no spheres round Io while Jupiter's are asked for")
+	}
+}
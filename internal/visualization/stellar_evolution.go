@@ -0,0 +1,46 @@
+package visualization
+
+import (
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// EvolvedStar is the star as a fast-forward through its life has it now. While
+// one is set the map draws the habitable zone from its luminosity, shown or not,
+// and shades the space inside its surface once it outgrows the star drawn.
+type EvolvedStar struct {
+	Luminosity float64 // solar luminosities
+	RadiusKm   float64
+}
+
+// SetEvolvedStar draws the star as it has evolved, or as it is when star is nil
+func (r *Renderer) SetEvolvedStar(star *EvolvedStar) {
+	r.evolved = star
+}
+
+// EvolvedStar returns the evolved star being drawn, or nil
+func (r *Renderer) EvolvedStar() *EvolvedStar {
+	return r.evolved
+}
+
+// envelopeDraw returns the drawing of the evolved star's surface scaled like the
+// orbits, centred on the star at x, y, shading the cells inside it nothing else
+// was drawn in. It returns false while the star fits inside the one drawn.
+func (r *Renderer) envelopeDraw(planets []models.CelestialBody, x, y int) (func(*Grid), bool) {
+	radius := r.distanceScaler.ScaleDistance(r.evolved.RadiusKm, planets)
+	if radius <= float64(r.celestialRenderer.GetSunSize()) {
+		return nil, false
+	}
+
+	ink := r.symbols.Envelope
+	aspect := r.circleDrawer.AspectRatio()
+	return func(layer *Grid) {
+		for dy := -int(radius); dy <= int(radius); dy++ {
+			reach := int(math.Sqrt(radius*radius-float64(dy*dy)) * aspect)
+			for dx := -reach; dx <= reach; dx++ {
+				layer.SetIfEmpty(x+dx, y+dy, ink)
+			}
+		}
+	}, true
+}
@@ -0,0 +1,44 @@
+package visualization
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderEvolvedStar(t *testing.T) {
+	planets := solarSystemFixture()
+	renderer := NewRendererWithDefaults(120, 40)
+	renderer.SetTimeSource(func() time.Time { return goldenTime })
+	envelope := renderer.GetSymbols().Envelope
+
+	// A Sun-like star fits inside the star drawn
+	renderer.SetEvolvedStar(&EvolvedStar{Luminosity: 1, RadiusKm: 695700})
+	grid, _ := renderer.RenderSolarSystemDataWithPositions(planets, 120, 40, 120, 40)
+	if strings.ContainsRune(grid.String(), envelope) {
+		t.Error("envelope drawn for a star no bigger than the one drawn")
+	}
+	if marks, ok := renderer.HabitableZoneMarks(); !ok || marks.Luminosity != 1 {
+		t.Errorf("Expected the evolved star's habitable zone drawn while the zone is hidden, got %+v (%v)", marks, ok)
+	}
+
+	// As a giant it swallows the inner orbits, but the bodies are still drawn
+	renderer.SetEvolvedStar(&EvolvedStar{Luminosity: 2500, RadiusKm: 1.2e8})
+	grid, positions := renderer.RenderSolarSystemDataWithPositions(planets, 120, 40, 120, 40)
+	if !strings.ContainsRune(grid.String(), envelope) {
+		t.Fatal("giant's envelope not drawn")
+	}
+	if marks, _ := renderer.HabitableZoneMarks(); marks.Luminosity != 2500 {
+		t.Errorf("habitable zone drawn for %g solar luminosities, want the giant's 2500", marks.Luminosity)
+	}
+	venus := positions["Venus"]
+	if grid.Get(venus.X, venus.Y) == envelope {
+		t.Error("the envelope covered Venus")
+	}
+
+	renderer.SetEvolvedStar(nil)
+	grid, _ = renderer.RenderSolarSystemDataWithPositions(planets, 120, 40, 120, 40)
+	if _, ok := renderer.HabitableZoneMarks(); ok || strings.ContainsRune(grid.String(), envelope) {
+		t.Error("the evolved star still drawn after it was cleared")
+	}
+}
@@ -0,0 +1,216 @@
+package visualization
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/furan917/go-solar-system/internal/constants"
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// maxStripGlyph is the largest radius, in rows, a body is drawn with on the strip
+const maxStripGlyph = 4
+
+// StripLabel is text to write over a strip view: a body's name beside its glyph,
+// or a distance along the bottom row
+type StripLabel struct {
+	X, Y int
+	Text string
+}
+
+// RenderStripWithPositions lays the bodies out on one horizontal axis instead of
+// around their orbits: stars at the left edge, then each body at its distance on
+// a logarithmic scale, drawn as large as its radius relative to the biggest one.
+// It returns where each body landed, and StripLabels says what to write where.
+// The grid is reused like RenderSolarSystemDataWithPositions's.
+func (r *Renderer) RenderStripWithPositions(planets []models.CelestialBody, width, height, screenWidth, screenHeight int) (*Grid, map[string]PlanetPosition) {
+	r.celestialRenderer.UpdateDimensions(screenWidth, screenHeight)
+	grid := r.createGrid(width, height)
+	positions := make(map[string]PlanetPosition)
+	r.stripLabels = nil
+	r.resonanceLinks = nil
+
+	stars, bodies := r.separateStarsAndPlanets(planets)
+	r.celestialRenderer.SetBodyStyles(stars, bodies)
+
+	var placed []models.CelestialBody
+	largest := 0.0
+	for _, body := range bodies {
+		if body.SemimajorAxis > 0 {
+			placed = append(placed, body)
+			largest = math.Max(largest, body.MeanRadius)
+		}
+	}
+
+	// The bottom row holds the distance scale and each glyph needs a row above
+	// and below it for names
+	axisY := (height - 1) / 2
+	glyph := min(maxStripGlyph, max((height-5)/3, 0))
+	aspect := r.circleDrawer.AspectRatio()
+
+	starRadius := min(r.celestialRenderer.GetSunSize(), glyph+1)
+	starWidth := int(float64(starRadius) * aspect)
+	left := 2*starWidth + 3
+	right := width - 1 - int(float64(glyph)*aspect)
+
+	for x := 0; x < width; x++ {
+		grid.Set(x, axisY, r.symbols.Orbit)
+	}
+	r.drawStripStars(grid, stars, starWidth, axisY, starRadius, positions)
+
+	if len(placed) == 0 || right <= left {
+		return grid, positions
+	}
+
+	nearest, farthest := math.Inf(1), 0.0
+	for _, body := range placed {
+		nearest = math.Min(nearest, body.SemimajorAxis)
+		farthest = math.Max(farthest, body.SemimajorAxis)
+	}
+	axisX := func(distance float64) int {
+		if farthest <= nearest {
+			return (left + right) / 2
+		}
+		fraction := math.Log(distance/nearest) / math.Log(farthest/nearest)
+		return left + int(math.Round(fraction*float64(right-left)))
+	}
+
+	labels := newStripLabeller(2*starWidth+2, width, height)
+	for i, body := range placed {
+		x := axisX(body.SemimajorAxis)
+		size := 0
+		if largest > 0 {
+			size = int(math.Round(float64(glyph) * body.MeanRadius / largest))
+		}
+
+		symbol := r.celestialRenderer.GetPlanetSymbol(body.EnglishName)
+		if size == 0 {
+			grid.Set(x, axisY, symbol)
+		} else {
+			r.circleDrawer.DrawFilledCircle(grid, x, axisY, size, symbol)
+		}
+
+		positions[body.EnglishName] = PlanetPosition{
+			X:      x,
+			Y:      axisY,
+			Radius: max(size, 1),
+			World:  r.celestialRenderer.GetWorldPosition(body),
+			Planet: body,
+		}
+
+		// Names alternate below and above the axis so neighbours rarely collide
+		row := axisY + size + 1
+		if i%2 == 1 || row >= height-1 {
+			row = axisY - size - 1
+		}
+		labels.add(x, row, body.EnglishName)
+	}
+
+	for _, tick := range distanceTicks(nearest, farthest) {
+		labels.add(axisX(tick.km), height-1, tick.text)
+	}
+	r.stripLabels = labels.placed
+	return grid, positions
+}
+
+// StripLabels returns the names and distances the last strip render left to be written
+func (r *Renderer) StripLabels() []StripLabel {
+	return r.stripLabels
+}
+
+// GetStripCamera returns the camera used for a strip view of the given grid size:
+// the stars at its left edge, on the axis
+func (r *Renderer) GetStripCamera(width, height int) Camera {
+	return Camera{
+		OriginX:     0,
+		OriginY:     (height - 1) / 2,
+		Width:       width,
+		Height:      height,
+		AspectRatio: r.circleDrawer.AspectRatio(),
+		Scale:       "strip",
+	}
+}
+
+// drawStripStars draws the system's stars at the left end of the axis: one as a
+// disc, several as a column of symbols
+func (r *Renderer) drawStripStars(grid *Grid, stars []models.CelestialBody, x, axisY, radius int, positions map[string]PlanetPosition) {
+	switch len(stars) {
+	case 0:
+		r.circleDrawer.DrawFilledCircle(grid, x, axisY, radius, r.symbols.Sun)
+	case 1:
+		r.circleDrawer.DrawFilledCircle(grid, x, axisY, radius, r.celestialRenderer.getStarSymbol(stars[0]))
+	}
+
+	for i, star := range stars {
+		y := axisY
+		if len(stars) > 1 {
+			y = axisY + (2*i - (len(stars) - 1))
+			grid.Set(x, y, r.celestialRenderer.getStarSymbol(star))
+		}
+		positions[star.EnglishName] = PlanetPosition{X: x, Y: y, Radius: radius, Planet: star}
+	}
+}
+
+// stripLabeller places labels centred on a column, clear of the stars, nudging
+// each right of the one before it on the same row and dropping those that no
+// longer fit
+type stripLabeller struct {
+	from          int
+	width, height int
+	rowEnds       map[int]int
+	placed        []StripLabel
+}
+
+// newStripLabeller returns a labeller for a grid of the given size whose labels
+// start at column from or later
+func newStripLabeller(from, width, height int) *stripLabeller {
+	return &stripLabeller{from: from, width: width, height: height, rowEnds: map[int]int{}}
+}
+
+// add places text centred on column x of row y, if it still fits
+func (l *stripLabeller) add(x, y int, text string) {
+	if y < 0 || y >= l.height {
+		return
+	}
+	col := max(x-len(text)/2, l.from)
+	if end, ok := l.rowEnds[y]; ok {
+		col = max(col, end+1)
+	}
+	if col+len(text) > l.width {
+		return
+	}
+	l.rowEnds[y] = col + len(text)
+	l.placed = append(l.placed, StripLabel{X: col, Y: y, Text: text})
+}
+
+// distanceTick is a mark on the strip's distance scale
+type distanceTick struct {
+	km   float64
+	text string
+}
+
+// distanceTicks returns a mark at each power of ten AU between nearest and
+// farthest (km), or one at each end when the range holds none
+func distanceTicks(nearest, farthest float64) []distanceTick {
+	au := constants.AstronomicalUnit
+	var ticks []distanceTick
+	for exponent := math.Ceil(math.Log10(nearest / au)); exponent <= math.Log10(farthest/au); exponent++ {
+		distance := math.Pow(10, exponent)
+		ticks = append(ticks, distanceTick{km: distance * au, text: strconv.FormatFloat(distance, 'f', -1, 64) + " AU"})
+	}
+	if len(ticks) > 0 {
+		return ticks
+	}
+
+	for _, km := range []float64{nearest, farthest} {
+		text := strconv.FormatFloat(km/au, 'g', 2, 64)
+		if km/au >= 10 {
+			text = strconv.FormatFloat(km/au, 'f', 0, 64)
+		}
+		ticks = append(ticks, distanceTick{km: km, text: text + " AU"})
+	}
+	if farthest <= nearest {
+		ticks = ticks[:1]
+	}
+	return ticks
+}
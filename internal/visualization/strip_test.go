@@ -0,0 +1,66 @@
+package visualization
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderStripPlacesBodiesByDistance(t *testing.T) {
+	renderer := NewRendererWithDefaults(160, 14)
+	renderer.SetTimeSource(func() time.Time { return goldenTime })
+
+	_, positions := renderer.RenderStripWithPositions(solarSystemFixture(), 160, 14, 160, 14)
+
+	order := []string{"Mercury", "Venus", "Earth", "Mars", "Jupiter", "Saturn", "Uranus", "Neptune", "Pluto"}
+	for i, name := range order {
+		position, ok := positions[name]
+		if !ok {
+			t.Fatalf("%s not placed", name)
+		}
+		if position.Y != positions["Mercury"].Y {
+			t.Errorf("%s at row %d, off the axis", name, position.Y)
+		}
+		if i > 0 && position.X <= positions[order[i-1]].X {
+			t.Errorf("%s at column %d, not beyond %s at %d", name, position.X, order[i-1], positions[order[i-1]].X)
+		}
+	}
+	if sun := positions["Sun"]; sun.X >= positions["Mercury"].X {
+		t.Errorf("Sun at column %d, want it left of Mercury", sun.X)
+	}
+
+	// On a log scale the gap from Jupiter to Saturn is about the one from Venus to Mars
+	inner := positions["Mars"].X - positions["Venus"].X
+	outer := positions["Saturn"].X - positions["Jupiter"].X
+	if inner < outer-2 || inner > outer+6 {
+		t.Errorf("Venus-Mars spans %d columns and Jupiter-Saturn %d, want them about equal", inner, outer)
+	}
+
+	if jupiter, earth := positions["Jupiter"].Radius, positions["Earth"].Radius; jupiter <= earth {
+		t.Errorf("Jupiter drawn with radius %d, Earth %d: want Jupiter larger", jupiter, earth)
+	}
+
+	var labels []string
+	for _, label := range renderer.StripLabels() {
+		labels = append(labels, label.Text)
+	}
+	joined := strings.Join(labels, ",")
+	for _, want := range []string{"Earth", "Jupiter", "1 AU", "10 AU"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("labels %q missing %q", joined, want)
+		}
+	}
+}
+
+func TestRenderStripWithOneBody(t *testing.T) {
+	renderer := NewRendererWithDefaults(80, 10)
+	bodies := solarSystemFixture()[:2]
+
+	_, positions := renderer.RenderStripWithPositions(bodies, 80, 10, 80, 10)
+	if _, ok := positions["Mercury"]; !ok {
+		t.Fatal("a lone planet was not placed")
+	}
+	if labels := renderer.StripLabels(); len(labels) != 2 {
+		t.Errorf("labels = %+v, want the planet's name and its distance", labels)
+	}
+}
@@ -0,0 +1,213 @@
+package visualization
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// SymbolSet is the glyphs used to draw bodies, orbits and debris belts
+type SymbolSet struct {
+	ASCII bool
+
+	Sun          rune
+	Orbit        rune
+	AsteroidBelt rune
+	KuiperBelt   rune
+	Resonance    rune // the links between orbits in resonance
+	Transfer     rune // the path of a transfer between orbits
+	Habitable    rune // the band round the stars where water could be liquid
+	Envelope     rune // the inside of a star swollen past its drawn size
+	Hill         rune // the Hill sphere round the selected body
+	Roche        rune // the Roche limit round the selected body
+
+	// Planets maps known bodies to their symbol
+	Planets map[string]rune
+
+	// Stars maps a stellar class letter to its symbol; UnknownStar covers the rest
+	Stars       map[byte]rune
+	UnknownStar rune
+
+	// Classes maps a class of body to its symbol, for bodies not in Planets
+	Classes map[BodyClass]rune
+
+	// Generic is for bodies nothing else gives a symbol. The ASCII set uses the
+	// body's initial instead.
+	Generic rune
+}
+
+// UnicodeSymbols uses the astronomical symbols
+var UnicodeSymbols = SymbolSet{
+	Sun:          '☉',
+	Orbit:        '·',
+	AsteroidBelt: '∗',
+	KuiperBelt:   '◦',
+	Resonance:    '•',
+	Transfer:     '×',
+	Habitable:    '≈',
+	Envelope:     '░',
+	Hill:         '∴',
+	Roche:        '∵',
+	Planets: map[string]rune{
+		"Sun":     '☉',
+		"Mercury": '☿',
+		"Venus":   '♀',
+		"Earth":   '♁',
+		"Mars":    '♂',
+		"Jupiter": '♃',
+		"Saturn":  '♄',
+		"Uranus":  '♅',
+		"Neptune": '♆',
+		"Pluto":   '♇',
+	},
+	Stars: map[byte]rune{
+		'O': '✦', 'B': '✦', // Hot blue/blue-white stars
+		'A': '✧', 'F': '✧', // White/yellow-white stars
+		'G': '☉', // Yellow stars (like Sun)
+		'K': '✩', // Orange stars
+		'M': '✪', // Red dwarf stars
+	},
+	UnknownStar: '⭐',
+	Classes: map[BodyClass]rune{
+		ClassGasGiant:    '◉',
+		ClassIceGiant:    '◎',
+		ClassTerrestrial: '●',
+		ClassDwarf:       '∘',
+		ClassMoon:        '○',
+		ClassAsteroid:    '◆',
+		ClassComet:       '☄',
+	},
+	Generic: '◌',
+}
+
+// ASCIISymbols is for terminals that cannot show the astronomical symbols: stars
+// are '*', known planets their capital initial (Mercury 'm' so Mars keeps 'M')
+// and other bodies their lowercase initial
+var ASCIISymbols = SymbolSet{
+	ASCII:        true,
+	Sun:          '*',
+	Orbit:        '.',
+	AsteroidBelt: ':',
+	KuiperBelt:   ',',
+	Resonance:    '~',
+	Transfer:     '+',
+	Habitable:    '=',
+	Envelope:     '%',
+	Hill:         '^',
+	Roche:        '!',
+	Planets: map[string]rune{
+		"Sun":     '*',
+		"Mercury": 'm',
+		"Venus":   'V',
+		"Earth":   'E',
+		"Mars":    'M',
+		"Jupiter": 'J',
+		"Saturn":  'S',
+		"Uranus":  'U',
+		"Neptune": 'N',
+		"Pluto":   'P',
+	},
+	Stars:       map[byte]rune{},
+	UnknownStar: '*',
+	Classes:     map[BodyClass]rune{},
+	Generic:     'o',
+}
+
+// Planet returns the symbol for a body by name, for when nothing more is known of it
+func (s SymbolSet) Planet(name string) rune {
+	return s.Body(models.CelestialBody{EnglishName: name}, ClassUnknown)
+}
+
+// Body returns the symbol for a body of a class: its own if it is a known body,
+// else its class's, else its initial in the ASCII set or the generic symbol
+func (s SymbolSet) Body(body models.CelestialBody, class BodyClass) rune {
+	if symbol, exists := s.Planets[body.EnglishName]; exists {
+		return symbol
+	}
+	if symbol, exists := s.Classes[class]; exists {
+		return symbol
+	}
+
+	if s.ASCII {
+		for _, char := range body.EnglishName {
+			if char < unicode.MaxASCII && unicode.IsLetter(char) {
+				return unicode.ToLower(char)
+			}
+		}
+	}
+	return s.Generic
+}
+
+// Star returns the symbol for a star of the given stellar class, or for stars a
+// theme gave a class symbol when the stellar class has none
+func (s SymbolSet) Star(stellarClass string) rune {
+	if stellarClass != "" {
+		if symbol, exists := s.Stars[stellarClass[0]]; exists {
+			return symbol
+		}
+	}
+	if symbol, exists := s.Classes[ClassStar]; exists {
+		return symbol
+	}
+	return s.UnknownStar
+}
+
+// probeSymbols are checked against the terminal; if any cannot be shown the
+// ASCII set is used
+var probeSymbols = []rune{'☉', '♃', '·', '◦'}
+
+// TerminalEnv describes the terminal for symbol detection
+type TerminalEnv struct {
+	GOOS   string
+	Getenv func(string) string
+
+	// CanDisplay reports whether the terminal can show a rune; nil skips the check
+	CanDisplay func(rune) bool
+}
+
+// DetectSymbolSet picks the ASCII set when the locale is not UTF-8, the terminal
+// is dumb, it is a legacy Windows console, or terminfo says it cannot draw the
+// astronomical symbols
+func DetectSymbolSet(env TerminalEnv) SymbolSet {
+	if !unicodeTerminal(env) {
+		return ASCIISymbols
+	}
+	return UnicodeSymbols
+}
+
+func unicodeTerminal(env TerminalEnv) bool {
+	getenv := env.Getenv
+	if getenv == nil {
+		getenv = func(string) string { return "" }
+	}
+
+	if env.GOOS == "windows" {
+		// Windows Terminal sets WT_SESSION; the legacy console host cannot draw these
+		return getenv("WT_SESSION") != ""
+	}
+
+	if getenv("TERM") == "dumb" {
+		return false
+	}
+
+	// The first locale variable that is set wins, as in setlocale(3)
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := getenv(name); locale != "" {
+			locale = strings.ToLower(locale)
+			if !strings.Contains(locale, "utf-8") && !strings.Contains(locale, "utf8") {
+				return false
+			}
+			break
+		}
+	}
+
+	if env.CanDisplay != nil {
+		for _, symbol := range probeSymbols {
+			if !env.CanDisplay(symbol) {
+				return false
+			}
+		}
+	}
+	return true
+}
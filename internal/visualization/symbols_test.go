@@ -0,0 +1,81 @@
+package visualization
+
+import "testing"
+
+func envFrom(vars map[string]string) func(string) string {
+	return func(name string) string { return vars[name] }
+}
+
+func TestDetectSymbolSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       TerminalEnv
+		wantASCII bool
+	}{
+		{"utf-8 locale", TerminalEnv{GOOS: "linux", Getenv: envFrom(map[string]string{"LANG": "en_GB.UTF-8"})}, false},
+		{"utf8 spelling", TerminalEnv{GOOS: "linux", Getenv: envFrom(map[string]string{"LC_ALL": "C.utf8"})}, false},
+		{"C locale", TerminalEnv{GOOS: "linux", Getenv: envFrom(map[string]string{"LANG": "C"})}, true},
+		{"LC_ALL wins over LANG", TerminalEnv{GOOS: "linux", Getenv: envFrom(map[string]string{"LC_ALL": "POSIX", "LANG": "en_US.UTF-8"})}, true},
+		{"dumb terminal", TerminalEnv{GOOS: "linux", Getenv: envFrom(map[string]string{"TERM": "dumb", "LANG": "en_US.UTF-8"})}, true},
+		{"no locale set", TerminalEnv{GOOS: "darwin", Getenv: envFrom(nil)}, false},
+		{"legacy windows console", TerminalEnv{GOOS: "windows", Getenv: envFrom(nil)}, true},
+		{"windows terminal", TerminalEnv{GOOS: "windows", Getenv: envFrom(map[string]string{"WT_SESSION": "1"})}, false},
+		{"terminfo cannot display", TerminalEnv{
+			GOOS:       "linux",
+			Getenv:     envFrom(map[string]string{"LANG": "en_US.UTF-8"}),
+			CanDisplay: func(r rune) bool { return r < 0x80 || r == '·' },
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectSymbolSet(tt.env); got.ASCII != tt.wantASCII {
+				t.Errorf("DetectSymbolSet() ASCII = %v, want %v", got.ASCII, tt.wantASCII)
+			}
+		})
+	}
+}
+
+func TestASCIISymbolsArePlainASCII(t *testing.T) {
+	s := ASCIISymbols
+	for _, name := range []string{"Sun", "Mercury", "Mars", "Ceres", "51 Pegasi b", "Ἥλιος"} {
+		if symbol := s.Planet(name); symbol >= 0x80 {
+			t.Errorf("Planet(%q) = %q, want ASCII", name, symbol)
+		}
+	}
+	for _, symbol := range []rune{s.Sun, s.Orbit, s.AsteroidBelt, s.KuiperBelt, s.Resonance, s.Transfer, s.Habitable, s.Envelope, s.Star("G2V"), s.Star("")} {
+		if symbol >= 0x80 {
+			t.Errorf("symbol %q is not ASCII", symbol)
+		}
+	}
+}
+
+func TestSymbolSetPlanet(t *testing.T) {
+	tests := []struct {
+		set  SymbolSet
+		name string
+		want rune
+	}{
+		{UnicodeSymbols, "Jupiter", '♃'},
+		{ASCIISymbols, "Jupiter", 'J'},
+		{ASCIISymbols, "Mercury", 'm'},
+		{ASCIISymbols, "Ceres", 'c'},
+		{ASCIISymbols, "51 Pegasi b", 'p'},
+		{ASCIISymbols, "", 'o'},
+	}
+
+	for _, tt := range tests {
+		if got := tt.set.Planet(tt.name); got != tt.want {
+			t.Errorf("Planet(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSymbolSetStar(t *testing.T) {
+	if got := UnicodeSymbols.Star("M4V"); got != '✪' {
+		t.Errorf("Star(M4V) = %q, want '✪'", got)
+	}
+	if got := UnicodeSymbols.Star("Q"); got != UnicodeSymbols.UnknownStar {
+		t.Errorf("Star(Q) = %q, want the unknown star symbol", got)
+	}
+}
@@ -0,0 +1,110 @@
+package visualization
+
+import (
+	"math"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+)
+
+const (
+	// transferSamples is how many points along the transfer are plotted
+	transferSamples = 360
+
+	// transferDash is how many of those points make up each dash, and each gap
+	transferDash = 6
+)
+
+// TransferPath is a Hohmann transfer to draw on the map: a craft leaving Origin's
+// orbit at Departure and reaching Destination's half a turn later, TravelTime on
+type TransferPath struct {
+	Origin      models.CelestialBody
+	Destination models.CelestialBody
+	Departure   time.Time
+	TravelTime  time.Duration
+}
+
+// Arrival is when the craft reaches the destination's orbit
+func (p TransferPath) Arrival() time.Time {
+	return p.Departure.Add(p.TravelTime)
+}
+
+// TransferMarks are the cells the last render drew a transfer between, and where
+// the craft was if it was on its way
+type TransferMarks struct {
+	DepartureX, DepartureY int
+	ArrivalX, ArrivalY     int
+	CraftX, CraftY         int
+	InFlight               bool
+}
+
+// SetTransfer draws a transfer on the whole-system map from the next render on;
+// nil stops drawing it
+func (r *Renderer) SetTransfer(path *TransferPath) {
+	r.transfer = path
+}
+
+// Transfer returns the transfer being drawn, or nil
+func (r *Renderer) Transfer() *TransferPath {
+	return r.transfer
+}
+
+// TransferMarks returns where the last render drew the transfer's ends and craft,
+// and false if it drew no transfer
+func (r *Renderer) TransferMarks() (TransferMarks, bool) {
+	return r.transferMarks, r.transferDrawn
+}
+
+// transferDraw returns a layer drawing the transfer as a dashed path from where
+// the origin is at departure, half a turn round to the destination's orbit. The
+// dashes march along it as simulated time passes, so the path runs with the clock.
+func (r *Renderer) transferDraw(planets []models.CelestialBody, centerX, centerY int) (func(*Grid), TransferMarks, bool) {
+	path := r.transfer
+	origin, okOrigin := findPlanet(planets, path.Origin.EnglishName)
+	destination, okDestination := findPlanet(planets, path.Destination.EnglishName)
+	if !okOrigin || !okDestination || origin.SemimajorAxis <= 0 || destination.SemimajorAxis <= 0 || path.TravelTime <= 0 {
+		return nil, TransferMarks{}, false
+	}
+
+	// The map places a body at its longitude, so the craft leaves from there
+	start := r.GetEphemeris().Longitude(origin, path.Departure)
+	point := func(fraction float64) (float64, float64) {
+		distance, swept := orbital.TransferPoint(origin.SemimajorAxis, destination.SemimajorAxis, fraction)
+		return r.circleDrawer.calculatePoint(centerX, centerY, r.distanceScaler.ScaleDistance(distance, planets), start+swept)
+	}
+
+	progress := float64(r.GetClock().Now().Sub(path.Departure)) / float64(path.TravelTime)
+	shift := int(math.Floor(progress * transferSamples))
+
+	var marks TransferMarks
+	x, y := point(0)
+	marks.DepartureX, marks.DepartureY = int(x), int(y)
+	x, y = point(1)
+	marks.ArrivalX, marks.ArrivalY = int(x), int(y)
+	if progress >= 0 && progress <= 1 {
+		x, y = point(progress)
+		marks.CraftX, marks.CraftY, marks.InFlight = int(x), int(y), true
+	}
+
+	ink := r.symbols.Transfer
+	return func(layer *Grid) {
+		for i := 0; i <= transferSamples; i++ {
+			if ((i-shift)%(2*transferDash)+2*transferDash)%(2*transferDash) >= transferDash {
+				continue
+			}
+			x, y := point(float64(i) / transferSamples)
+			layer.Plot(x, y, ink)
+		}
+	}, marks, true
+}
+
+// findPlanet returns the body with the given name
+func findPlanet(planets []models.CelestialBody, name string) (models.CelestialBody, bool) {
+	for _, planet := range planets {
+		if planet.EnglishName == name {
+			return planet, true
+		}
+	}
+	return models.CelestialBody{}, false
+}
@@ -0,0 +1,51 @@
+package visualization
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderTransfer(t *testing.T) {
+	planets := solarSystemFixture()
+	earth, mars := planets[3], planets[4]
+	now := goldenTime
+	renderer := NewRendererWithDefaults(120, 40)
+	renderer.SetTimeSource(func() time.Time { return now })
+
+	renderer.SetTransfer(&TransferPath{Origin: earth, Destination: mars, Departure: goldenTime, TravelTime: 259 * 24 * time.Hour})
+	grid, _ := renderer.RenderSolarSystemDataWithPositions(planets, 120, 40, 120, 40)
+	if !strings.ContainsRune(grid.String(), renderer.GetSymbols().Transfer) {
+		t.Fatal("no transfer drawn")
+	}
+	marks, ok := renderer.TransferMarks()
+	if !ok || !marks.InFlight {
+		t.Fatalf("TransferMarks() = %+v, %v, want a craft leaving", marks, ok)
+	}
+	if marks.CraftX != marks.DepartureX || marks.CraftY != marks.DepartureY {
+		t.Errorf("craft at %d,%d at departure, want it at the departure mark %d,%d", marks.CraftX, marks.CraftY, marks.DepartureX, marks.DepartureY)
+	}
+
+	// Half a turn round the Sun, so the arrival is on the far side of it
+	if (marks.DepartureX-60)*(marks.ArrivalX-60) > 0 && (marks.DepartureY-20)*(marks.ArrivalY-20) > 0 {
+		t.Errorf("departure %d,%d and arrival %d,%d on the same side of the Sun", marks.DepartureX, marks.DepartureY, marks.ArrivalX, marks.ArrivalY)
+	}
+
+	now = goldenTime.Add(300 * 24 * time.Hour)
+	renderer.RenderSolarSystemDataWithPositions(planets, 120, 40, 120, 40)
+	if marks, _ := renderer.TransferMarks(); marks.InFlight {
+		t.Error("craft still in flight after arriving")
+	}
+
+	// A frame centred on a planet has no room for a transfer between orbits
+	renderer.RenderFrameWithPositions(earth, nil, 120, 40, 120, 40)
+	if _, ok := renderer.TransferMarks(); ok {
+		t.Error("transfer drawn on a frame centred on a planet")
+	}
+
+	renderer.SetTransfer(nil)
+	grid, _ = renderer.RenderSolarSystemDataWithPositions(planets, 120, 40, 120, 40)
+	if strings.ContainsRune(grid.String(), renderer.GetSymbols().Transfer) {
+		t.Error("transfer drawn after it was cleared")
+	}
+}
@@ -0,0 +1,58 @@
+package visualization
+
+import (
+	"math"
+
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/orbital"
+)
+
+// wobbleReach is how many rows from the barycenter the star is drawn when its
+// planets pull it as far as they can, so the wobble can be seen at all
+const wobbleReach = 3.0
+
+// WobbleMarks are where the last render drew the stars and their barycenter with
+// the wobble exaggerated, and the wobble itself
+type WobbleMarks struct {
+	Wobble                   orbital.Wobble
+	BarycenterX, BarycenterY int
+	StarX, StarY             int
+}
+
+// SetWobbleShown draws the stars pulled off the barycenter by their planets, far
+// further than they really are, or back at the middle of the map
+func (r *Renderer) SetWobbleShown(shown bool) {
+	r.showWobble = shown
+}
+
+// WobbleShown reports whether the stars' wobble is drawn
+func (r *Renderer) WobbleShown() bool {
+	return r.showWobble
+}
+
+// WobbleMarks returns where the last render drew the stars and their barycenter,
+// and false if it drew no wobble
+func (r *Renderer) WobbleMarks() (WobbleMarks, bool) {
+	return r.wobbleMarks, r.wobbleDrawn
+}
+
+// starWobble works out the stars' wobble now, and where to draw them so that the
+// widest wobble their planets could give is wobbleReach rows
+func (r *Renderer) starWobble(stars, planets []models.CelestialBody, centerX, centerY int) WobbleMarks {
+	mass := orbital.SolarMass
+	if len(stars) > 0 {
+		mass = 0
+		for _, star := range stars {
+			mass += r.celestialRenderer.getStarMass(star)
+		}
+	}
+
+	wobble := r.GetEphemeris().StarWobble(mass, planets, r.GetClock().Now())
+	marks := WobbleMarks{Wobble: wobble, StarX: centerX, StarY: centerY}
+	marks.BarycenterX, marks.BarycenterY = r.celestialRenderer.GetBarycenter(stars, centerX, centerY)
+	if reach := orbital.MaxWobble(mass, planets); reach > 0 {
+		x, y := r.circleDrawer.calculatePoint(centerX, centerY, wobble.Offset()*wobbleReach/reach, math.Atan2(wobble.Y, wobble.X))
+		marks.StarX, marks.StarY = int(x), int(y)
+	}
+	return marks
+}
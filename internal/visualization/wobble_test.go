@@ -0,0 +1,63 @@
+package visualization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func TestRenderStarWobble(t *testing.T) {
+	planets := solarSystemFixture()
+	planets[5].Mass = models.Mass{MassValue: 1.89819, MassExponent: 27} // Jupiter
+	renderer := NewRendererWithDefaults(120, 40)
+	renderer.SetTimeSource(func() time.Time { return goldenTime })
+
+	_, positions := renderer.RenderSolarSystemDataWithPositions(planets, 120, 40, 120, 40)
+	if _, ok := renderer.WobbleMarks(); ok || positions["Sun"].X != 60 || positions["Sun"].Y != 20 {
+		t.Fatalf("Sun at %d,%d with the wobble hidden, want the middle", positions["Sun"].X, positions["Sun"].Y)
+	}
+
+	renderer.SetWobbleShown(true)
+	_, positions = renderer.RenderSolarSystemDataWithPositions(planets, 120, 40, 120, 40)
+	marks, ok := renderer.WobbleMarks()
+	if !ok {
+		t.Fatal("no wobble drawn")
+	}
+	if marks.BarycenterX != 60 || marks.BarycenterY != 20 {
+		t.Errorf("barycenter at %d,%d, want the middle", marks.BarycenterX, marks.BarycenterY)
+	}
+	if marks.StarX == 60 && marks.StarY == 20 {
+		t.Error("Sun drawn on the barycenter, want it pulled off by Jupiter")
+	}
+	if sun := positions["Sun"]; sun.X != marks.StarX || sun.Y != marks.StarY {
+		t.Errorf("Sun clickable at %d,%d, want where it is drawn %d,%d", sun.X, sun.Y, marks.StarX, marks.StarY)
+	}
+
+	// The Sun is pulled towards the far side of the barycenter from Jupiter
+	jupiter := positions["Jupiter"]
+	if (jupiter.X-60)*(marks.StarX-60)+(jupiter.Y-20)*(marks.StarY-20) >= 0 {
+		t.Errorf("Sun at %d,%d is not opposite Jupiter at %d,%d", marks.StarX, marks.StarY, jupiter.X, jupiter.Y)
+	}
+	if speed := marks.Wobble.Speed(); speed < 12 || speed > 13 {
+		t.Errorf("wobble speed %.2f m/s, want Jupiter's 12.5", speed)
+	}
+}
+
+func TestGetBarycenterWeighsStarsByMass(t *testing.T) {
+	renderer := NewRendererWithDefaults(120, 40)
+	renderer.SetTimeSource(func() time.Time { return goldenTime })
+	stars := binaryStarFixture()[:2]
+	cor := renderer.celestialRenderer
+
+	positions := cor.calculateStarPositions(stars, 60, 20)
+	x, y := cor.GetBarycenter(stars, 60, 20)
+	heavy, light := positions[0], positions[1]
+	distance := func(p StarPosition) int { return (p.X-x)*(p.X-x) + (p.Y-y)*(p.Y-y) }
+	if distance(heavy) > distance(light) {
+		t.Errorf("barycenter %d,%d nearer %+v than the heavier star at %+v", x, y, light, heavy)
+	}
+	if x, y := cor.GetBarycenter(stars[:1], 60, 20); x != 60 || y != 20 {
+		t.Errorf("GetBarycenter() of one star = %d,%d, want the middle", x, y)
+	}
+}
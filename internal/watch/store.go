@@ -0,0 +1,107 @@
+package watch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// Snapshot is a body as last fetched
+type Snapshot struct {
+	Body    models.CelestialBody `json:"body"`
+	Fetched time.Time            `json:"fetched"`
+}
+
+// Store keeps the last fetch of every watched body, so changes are noticed across
+// restarts as well as within a session. It is safe for concurrent use.
+type Store struct {
+	path string // empty keeps snapshots in memory only
+
+	mu        sync.Mutex
+	snapshots map[string]Snapshot
+}
+
+// NewStore returns an empty store saved to path
+func NewStore(path string) *Store {
+	return &Store{path: path, snapshots: make(map[string]Snapshot)}
+}
+
+// Load reads the snapshots saved at path. A missing file is an empty store.
+func Load(path string) (*Store, error) {
+	store := NewStore(path)
+	if path == "" {
+		return store, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return store, fmt.Errorf("failed to read watch snapshots %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &store.snapshots); err != nil {
+		return NewStore(path), fmt.Errorf("failed to parse watch snapshots %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// Update records a fresh fetch of body and returns how it differs from the last
+// one. The first fetch of a body has nothing to compare with and returns nil.
+func (s *Store) Update(body models.CelestialBody, fetched time.Time) []Change {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, seen := s.snapshots[body.ID]
+	s.snapshots[body.ID] = Snapshot{Body: body, Fetched: fetched}
+	if !seen {
+		return nil
+	}
+	return Diff(previous.Body, body)
+}
+
+// Forget drops the snapshot of a body that is no longer watched
+func (s *Store) Forget(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.snapshots, id)
+}
+
+// Save writes the snapshots to the store's file
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	data, err := json.MarshalIndent(s.snapshots, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode watch snapshots: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create watch snapshot directory: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write watch snapshots %s: %w", s.path, err)
+	}
+	return nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,93 @@
+// Package watch spots changes in the API's data for bodies the user watches. The
+// upstream dataset is curated by hand, so moons get added and values corrected.
+package watch
+
+import (
+	"strconv"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+// Change is one field that differs between two fetches of a body. Old is empty
+// for an added moon and New for a removed one.
+type Change struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// field reads one compared value from a body
+type field struct {
+	name  string
+	value func(models.CelestialBody) string
+}
+
+func number(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// fields are the values compared between fetches, in display order
+var fields = []field{
+	{"Name", func(b models.CelestialBody) string { return b.EnglishName }},
+	{"Body type", func(b models.CelestialBody) string { return b.BodyType }},
+	{"Mean radius (km)", func(b models.CelestialBody) string { return number(b.MeanRadius) }},
+	{"Equatorial radius (km)", func(b models.CelestialBody) string { return number(b.EquaRadius) }},
+	{"Polar radius (km)", func(b models.CelestialBody) string { return number(b.PolarRadius) }},
+	{"Mass (kg)", func(b models.CelestialBody) string {
+		return number(b.Mass.MassValue) + "e" + strconv.Itoa(b.Mass.MassExponent)
+	}},
+	{"Density (g/cm³)", func(b models.CelestialBody) string { return number(b.Density) }},
+	{"Gravity (m/s²)", func(b models.CelestialBody) string { return number(b.Gravity) }},
+	{"Escape velocity (m/s)", func(b models.CelestialBody) string { return number(b.Escape) }},
+	{"Semi-major axis (km)", func(b models.CelestialBody) string { return number(b.SemimajorAxis) }},
+	{"Perihelion (km)", func(b models.CelestialBody) string { return number(b.Perihelion) }},
+	{"Aphelion (km)", func(b models.CelestialBody) string { return number(b.Aphelion) }},
+	{"Eccentricity", func(b models.CelestialBody) string { return number(b.Eccentricity) }},
+	{"Inclination (°)", func(b models.CelestialBody) string { return number(b.Inclination) }},
+	{"Orbital period (days)", func(b models.CelestialBody) string { return number(b.SideralOrbit) }},
+	{"Rotation period (hours)", func(b models.CelestialBody) string { return number(b.SideralRotation) }},
+	{"Discovered by", func(b models.CelestialBody) string { return b.DiscoveredBy }},
+	{"Discovery date", func(b models.CelestialBody) string { return b.DiscoveryDate }},
+}
+
+// Diff lists what changed from old to new. Moons are matched by name, so a moon
+// the API renamed shows as one removed and one added.
+func Diff(old, new models.CelestialBody) []Change {
+	var changes []Change
+	for _, f := range fields {
+		if before, after := f.value(old), f.value(new); before != after {
+			changes = append(changes, Change{Field: f.name, Old: before, New: after})
+		}
+	}
+
+	oldMoons := moonNames(old.Moons)
+	newMoons := moonNames(new.Moons)
+	for _, name := range sortedKeys(newMoons) {
+		if !oldMoons[name] {
+			changes = append(changes, Change{Field: "Moon added", New: name})
+		}
+	}
+	for _, name := range sortedKeys(oldMoons) {
+		if !newMoons[name] {
+			changes = append(changes, Change{Field: "Moon removed", Old: name})
+		}
+	}
+	return changes
+}
+
+// moonNames returns the set of moon names, falling back to the API link for moons
+// listed without one
+func moonNames(moons []models.Moon) map[string]bool {
+	names := make(map[string]bool, len(moons))
+	for _, moon := range moons {
+		switch {
+		case moon.EnglishName != "":
+			names[moon.EnglishName] = true
+		case moon.Name != "":
+			names[moon.Name] = true
+		case moon.Rel != "":
+			names[moon.Rel] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,75 @@
+package watch
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/furan917/go-solar-system/internal/models"
+)
+
+func mars() models.CelestialBody {
+	return models.CelestialBody{
+		ID:          "mars",
+		EnglishName: "Mars",
+		MeanRadius:  3389.5,
+		Mass:        models.Mass{MassValue: 6.41712, MassExponent: 23},
+		Moons:       []models.Moon{{Name: "Phobos"}, {Name: "Deimos"}},
+	}
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	if changes := Diff(mars(), mars()); len(changes) != 0 {
+		t.Errorf("Diff() of identical bodies = %v, want none", changes)
+	}
+}
+
+func TestDiffFieldsAndMoons(t *testing.T) {
+	updated := mars()
+	updated.MeanRadius = 3389.9
+	updated.Mass.MassExponent = 24
+	updated.Moons = []models.Moon{{Name: "Phobos"}, {Name: "S/2025 M 1"}}
+
+	want := []Change{
+		{Field: "Mean radius (km)", Old: "3389.5", New: "3389.9"},
+		{Field: "Mass (kg)", Old: "6.41712e23", New: "6.41712e24"},
+		{Field: "Moon added", New: "S/2025 M 1"},
+		{Field: "Moon removed", Old: "Deimos"},
+	}
+	if got := Diff(mars(), updated); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+}
+
+func TestStoreUpdateAndPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watch.json")
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if changes := store.Update(mars(), now); changes != nil {
+		t.Errorf("first Update() = %v, want nil", changes)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	updated := mars()
+	updated.Moons = append(updated.Moons, models.Moon{Name: "Deimos II"})
+	changes := reloaded.Update(updated, now.Add(time.Hour))
+	if len(changes) != 1 || changes[0].New != "Deimos II" {
+		t.Errorf("Update() after reload = %v, want the added moon", changes)
+	}
+
+	reloaded.Forget("mars")
+	if changes := reloaded.Update(mars(), now); changes != nil {
+		t.Errorf("Update() after Forget() = %v, want nil", changes)
+	}
+}
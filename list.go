@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/api"
+	"github.com/furan917/go-solar-system/internal/bodystore"
+	"github.com/furan917/go-solar-system/internal/filter"
+	"github.com/furan917/go-solar-system/internal/models"
+	"github.com/furan917/go-solar-system/internal/systems"
+)
+
+// runList prints the bodies of a system that pass a filter expression, the same
+// language as the filter bar, as in `go-solar-system list 'mass>1e24 && moons>=2'`
+// or `go-solar-system list -system trappist-1 'radius<6000'`. The Solar System comes
+// from the API, or the body store when the API cannot answer. It returns the
+// process exit code: 1 if the bodies could not be loaded, 2 for bad usage or a
+// filter that does not parse.
+func runList(args []string, storePath string, out io.Writer) int {
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	flags.SetOutput(out)
+	system := flags.String("system", "solar-system", "system to list: solar-system, or the name of a system file")
+	if err := flags.Parse(args); err != nil {
+		fmt.Fprintln(out, "usage: go-solar-system list [-system name] [filter]")
+		return 2
+	}
+
+	var bodyFilter *filter.Filter
+	if expression := strings.Join(flags.Args(), " "); strings.TrimSpace(expression) != "" {
+		var err error
+		if bodyFilter, err = filter.Parse(expression); err != nil {
+			fmt.Fprintf(out, "bad filter: %v\n", err)
+			return 2
+		}
+	}
+
+	bodies, err := listBodies(*system, storePath)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	if bodyFilter != nil {
+		bodies = bodyFilter.Apply(bodies)
+	}
+	if len(bodies) == 0 {
+		fmt.Fprintln(out, "No bodies match")
+		return 0
+	}
+
+	fmt.Fprintf(out, "%-28s %-14s %-12s %-12s %-14s %s\n", "NAME", "TYPE", "MASS (kg)", "RADIUS (km)", "AXIS (km)", "MOONS")
+	for _, body := range bodies {
+		fmt.Fprintf(out, "%-28s %-14s %-12s %-12s %-14s %d\n", body.EnglishName, body.BodyType,
+			listNumber("%.3e", body.GetMassKg()), listNumber("%.0f", body.MeanRadius), listNumber("%.0f", body.SemimajorAxis), len(body.Moons))
+	}
+	return 0
+}
+
+// listNumber formats a value for the list, or a dash when it is not recorded
+func listNumber(format string, value float64) string {
+	if value == 0 {
+		return "-"
+	}
+	return fmt.Sprintf(format, value)
+}
+
+// listBodies loads every body of a system for the list command
+func listBodies(system, storePath string) ([]models.CelestialBody, error) {
+	if system == "solar-system" {
+		options := []api.Option{api.WithDiskCache(api.DefaultDiskCacheDir())}
+		if _, err := os.Stat(storePath); !errors.Is(err, fs.ErrNotExist) {
+			store, err := bodystore.Open(storePath)
+			if err != nil {
+				return nil, err
+			}
+			defer store.Close()
+			options = append(options, api.WithStore(store))
+		}
+		return api.NewClient(options...).GetAllBodies()
+	}
+
+	manager := systems.NewSystemManager("systems")
+	if err := manager.AddEmbeddedSystems(builtinSystems()); err != nil {
+		return nil, err
+	}
+	if err := manager.ScanSystems(); err != nil {
+		return nil, err
+	}
+	data, err := manager.LoadSystem(system)
+	if err != nil {
+		return nil, fmt.Errorf("no system %q (try one of %s): %w", system, strings.Join(manager.GetAvailableSystems(), ", "), err)
+	}
+	return data.Bodies, nil
+}
@@ -1,20 +1,91 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/furan917/go-solar-system/internal/app"
+	"github.com/furan917/go-solar-system/internal/bodystore"
+	"github.com/furan917/go-solar-system/internal/config"
+	"github.com/furan917/go-solar-system/internal/logging"
 )
 
 func main() {
-	solarSystem, err := app.NewSolarSystem()
+	debug := flag.Bool("debug", false, "enable debug logging and show the debug overlay (toggle with F9)")
+	logFile := flag.String("log-file", logging.DefaultPath(), "file to write logs to; rotated when it grows past 1MB")
+	configFile := flag.String("config", config.DefaultPath(), "settings file")
+	ascii := flag.Bool("ascii", false, "draw bodies with plain ASCII letters for terminals that cannot show the astronomical symbols")
+	syncListen := flag.String("sync-listen", "", "broadcast this session for live sync on an address, e.g. localhost:7817")
+	syncOrigins := flag.String("sync-origin", "", "comma-separated browser origins, e.g. http://localhost:3000, whose pages may follow --sync-listen besides its own host")
+	syncFollow := flag.String("sync-follow", "", "mirror the session broadcast at a URL, e.g. ws://localhost:7817/sync")
+	palette := flag.String("palette", "", "colors to draw the map in: default, deuteranopia, protanopia or tritanopia (overrides the config)")
+	deterministic := flag.Bool("deterministic", false, "start the animation at J2000 and move it a fixed step per frame, for reproducible screenshots and recordings")
+	offline := flag.Bool("offline", false, "never contact the API; show only the bodies kept in the body store")
+	control := flag.String("control", "", "read automation commands (select Mars, screenshot out.png...) from a file or FIFO, or - for stdin")
+	gamepadPath := flag.String("gamepad", "", "navigate with a game controller: a joystick device such as /dev/input/js0, or a FIFO a bridge writes inputs (up, down, confirm...) to")
+	fps := flag.Int("fps", 0, "frames a second to draw the map at, up to 60 (overrides the config; 10 unless set)")
+	idle := flag.Int("idle", 0, "after this many seconds without input, draw one frame a second until the next key or click, to save power (overrides the config)")
+	recordInput := flag.Bool("record-input", false, "record key and mouse events with the terminal's size, TERM and locale, for the bugreport command to bundle")
+	flag.Parse()
+
+	switch flag.Arg(0) {
+	case "validate":
+		os.Exit(runValidate(flag.Args()[1:], os.Stdout))
+	case "convert":
+		os.Exit(runConvert(flag.Args()[1:], os.Stdout))
+	case "merge":
+		os.Exit(runMerge(flag.Args()[1:], os.Stdout))
+	case "share":
+		os.Exit(runShare(flag.Args()[1:], os.Stdout))
+	case "load-share":
+		os.Exit(runLoadShare(flag.Args()[1:], "systems", os.Stdin, os.Stdout))
+	case "stats":
+		os.Exit(runStats(flag.Args()[1:], *configFile, os.Stdout))
+	case "search":
+		os.Exit(runSearch(flag.Args()[1:], bodystore.DefaultPath(), os.Stdout))
+	case "history":
+		os.Exit(runHistory(flag.Args()[1:], bodystore.DefaultPath(), os.Stdout))
+	case "list":
+		os.Exit(runList(flag.Args()[1:], bodystore.DefaultPath(), os.Stdout))
+	case "scale":
+		os.Exit(runScale(flag.Args()[1:], bodystore.DefaultPath(), os.Stdout))
+	case "bugreport":
+		os.Exit(runBugReport(flag.Args()[1:], *logFile, *configFile, os.Stdout))
+	}
+
+	logger, err := logging.Open(*logFile, *debug)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer logger.Close()
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		logger.Printf("Using default settings: %v", err)
+	}
+
+	solarSystem, err := app.NewSolarSystem(app.Options{Logger: logger, Debug: *debug, Config: cfg, ConfigPath: *configFile, ASCII: *ascii, Palette: *palette, FPS: *fps, IdleSeconds: *idle, Deterministic: *deterministic, SyncListen: *syncListen, SyncOrigins: splitList(*syncOrigins), SyncFollow: *syncFollow, Control: *control, Gamepad: *gamepadPath, Offline: *offline, BuiltinSystems: builtinSystems(), RecordInput: *recordInput})
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	fmt.Println("🌌 Welcome to the Interactive Solar System!")
 	if err := solarSystem.Run(); err != nil {
+		logger.Printf("Exited with error: %v", err)
 		log.Fatal(err)
 	}
 }
+
+// splitList reads a comma-separated flag value, skipping blank entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/furan917/go-solar-system/internal/systems"
+)
+
+// runMerge combines system files into one, as in
+// `go-solar-system merge -o kepler-11.json kepler-11.json community.toml`, listing
+// the fields the files disagree on. It returns the process exit code: 1 if a file
+// could not be read or written, or -strict found conflicts, 2 for bad usage.
+func runMerge(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("merge", flag.ContinueOnError)
+	flags.SetOutput(out)
+	output := flags.String("o", "", "file to write, in the format its extension names")
+	prefer := flags.String("prefer", "first", "whose value to keep when files disagree: first or last")
+	strict := flags.Bool("strict", false, "write nothing if the files disagree on any field")
+	if err := flags.Parse(args); err != nil || *output == "" || flags.NArg() < 2 {
+		fmt.Fprintln(out, "usage: go-solar-system merge -o <output> [-prefer first|last] [-strict] <file> <file>...")
+		return 2
+	}
+	precedence, err := systems.ParseMergePrecedence(*prefer)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 2
+	}
+
+	manager := systems.NewSystemManager("")
+	var sources []systems.MergeSource
+	for _, path := range flags.Args() {
+		system, err := manager.ReadSystemFile(path)
+		if err != nil {
+			fmt.Fprintln(out, err)
+			return 1
+		}
+		sources = append(sources, systems.MergeSource{Path: path, System: system})
+	}
+
+	merged, report, err := systems.MergeSystems(sources, precedence)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	for _, conflict := range report.Conflicts {
+		fmt.Fprintf(out, "conflict: %s\n", conflict)
+	}
+	if *strict && len(report.Conflicts) > 0 {
+		fmt.Fprintf(out, "%s not written: %s\n", *output, count(len(report.Conflicts), "conflict"))
+		return 1
+	}
+
+	if err := manager.WriteSystemFile(*output, merged); err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	fmt.Fprintf(out, "%s: %d bodies from %s, %s merged, %s\n", *output, report.Bodies,
+		count(len(sources), "file"), count(report.Duplicates, "duplicate"), count(len(report.Conflicts), "conflict"))
+	return 0
+}
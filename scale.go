@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/scalemodel"
+)
+
+// runScale prints a system shrunk so its star is a given size, every body's
+// diameter and distance from the star at that scale, as in
+// `go-solar-system scale football` or `go-solar-system scale -system trappist-1 1m`.
+// It returns the process exit code: 1 if the bodies could not be loaded or the
+// system has no star to scale from, 2 for bad usage.
+func runScale(args []string, storePath string, out io.Writer) int {
+	flags := flag.NewFlagSet("scale", flag.ContinueOnError)
+	flags.SetOutput(out)
+	system := flags.String("system", "solar-system", "system to model: solar-system, or the name of a system file")
+	if err := flags.Parse(args); err != nil {
+		fmt.Fprintln(out, "usage: go-solar-system scale [-system name] [size, e.g. football or 22cm]")
+		return 2
+	}
+
+	size := "football"
+	if len(flags.Args()) > 0 {
+		size = strings.Join(flags.Args(), " ")
+	}
+	diameter, err := scalemodel.ParseSize(size)
+	if err != nil {
+		fmt.Fprintf(out, "bad size: %v\n", err)
+		return 2
+	}
+
+	bodies, err := listBodies(*system, storePath)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	model, err := scalemodel.New(bodies, diameter)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+
+	fmt.Fprintf(out, "%s %s across\n\n", model.Star, scalemodel.FormatLength(diameter))
+	fmt.Fprintf(out, "%-28s %-12s %s\n", "NAME", "DIAMETER", "DISTANCE")
+	for _, body := range model.Bodies {
+		distance := "-"
+		if body.Distance > 0 {
+			distance = scalemodel.FormatLength(body.Distance)
+		}
+		fmt.Fprintf(out, "%-28s %-12s %s\n", body.Name, scalemodel.FormatLength(body.Diameter), distance)
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/furan917/go-solar-system/internal/systems"
+	"github.com/furan917/go-solar-system/internal/systems/formats"
+)
+
+// runShare prints a system file as a share code to paste into a chat, as in
+// `go-solar-system share systems/my-system.toml`. It returns the process exit
+// code: 1 if the file could not be read or is too big for a code, 2 for bad usage.
+func runShare(args []string, out io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprintln(out, "usage: go-solar-system share <file>")
+		return 2
+	}
+
+	system, err := systems.NewSystemManager("").ReadSystemFile(args[0])
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	code, err := systems.EncodeShare(system)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	fmt.Fprintln(out, code)
+	return 0
+}
+
+// runLoadShare unpacks a share code into the systems directory, or into the file
+// -o names, as in `go-solar-system load-share gss1:...`. With no code given it is
+// read from in. It returns the process exit code: 1 if the code is damaged or the
+// system could not be written, 2 for bad usage.
+func runLoadShare(args []string, systemsDir string, in io.Reader, out io.Writer) int {
+	flags := flag.NewFlagSet("load-share", flag.ContinueOnError)
+	flags.SetOutput(out)
+	output := flags.String("o", "", "file to write instead, in the format its extension names")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 1 {
+		fmt.Fprintln(out, "usage: go-solar-system load-share [-o file] [code]")
+		return 2
+	}
+
+	code := flags.Arg(0)
+	if code == "" {
+		data, err := io.ReadAll(in)
+		if err != nil {
+			fmt.Fprintln(out, err)
+			return 1
+		}
+		code = string(data)
+	}
+
+	manager := systems.NewSystemManager(systemsDir)
+	if *output != "" {
+		data, err := systems.DecodeShare(code)
+		if err != nil {
+			fmt.Fprintln(out, err)
+			return 1
+		}
+		var system formats.SystemData
+		if err := json.Unmarshal(data, &system); err != nil {
+			fmt.Fprintf(out, "share code does not hold a system file: %v\n", err)
+			return 1
+		}
+		if err := manager.WriteSystemFile(*output, &system); err != nil {
+			fmt.Fprintln(out, err)
+			return 1
+		}
+		fmt.Fprintf(out, "%s: written from the share code\n", *output)
+		return 0
+	}
+
+	if err := manager.ScanSystems(); err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	name, err := manager.InstallShare(code)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	fmt.Fprintf(out, "Added %s to %s - pick it with S in the app\n", name, systemsDir)
+	return 0
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/furan917/go-solar-system/internal/analytics"
+)
+
+// runStats summarises the analytics recorded next to the config file, or in the
+// file given, as in `go-solar-system stats -top 5`. It returns the process exit
+// code: 1 if the file could not be read, 2 for bad usage.
+func runStats(args []string, configPath string, out io.Writer) int {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	flags.SetOutput(out)
+	top := flags.Int("top", 10, "systems and bodies to list (0 for all)")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 1 {
+		fmt.Fprintln(out, "usage: go-solar-system stats [-top n] [file]")
+		return 2
+	}
+
+	path := analytics.PathFor(configPath)
+	if flags.NArg() == 1 {
+		path = flags.Arg(0)
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Fprintf(out, "No analytics recorded yet - set \"analytics\": true in %s to start\n", configPath)
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	defer file.Close()
+
+	summary, err := analytics.Summarize(file)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	summary.Write(out, *top)
+	return 0
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/furan917/go-solar-system/internal/bodystore"
+	"github.com/furan917/go-solar-system/internal/watch"
+)
+
+// openStore opens the body store at path for the search and history commands.
+// When it cannot, it says why and returns the exit code: 0 if there is simply no
+// store yet, 1 if it could not be opened.
+func openStore(path string, out io.Writer) (*bodystore.Store, int) {
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		fmt.Fprintln(out, "No bodies kept yet - set \"store\": true in the config to start")
+		return nil, 0
+	}
+	store, err := bodystore.Open(path)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return nil, 1
+	}
+	return store, 0
+}
+
+// runSearch lists the bodies in the body store with a name or id containing the
+// text given, as in `go-solar-system search -limit 5 centauri`. It returns the
+// process exit code: 1 if the store could not be read, 2 for bad usage.
+func runSearch(args []string, storePath string, out io.Writer) int {
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	flags.SetOutput(out)
+	limit := flags.Int("limit", 20, "bodies to list")
+	if err := flags.Parse(args); err != nil || flags.NArg() == 0 || *limit <= 0 {
+		fmt.Fprintln(out, "usage: go-solar-system search [-limit n] <text>")
+		return 2
+	}
+
+	store, code := openStore(storePath, out)
+	if store == nil {
+		return code
+	}
+	defer store.Close()
+
+	query := strings.Join(flags.Args(), " ")
+	matches, err := store.Search(query, *limit)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	if len(matches) == 0 {
+		fmt.Fprintf(out, "No bodies kept match %q\n", query)
+		return 0
+	}
+	for _, match := range matches {
+		fmt.Fprintf(out, "%-16s %-28s %-14s %-16s %s\n", match.System, match.Body.EnglishName, match.Body.BodyType, match.Body.ID, match.Fetched.Local().Format("2006-01-02"))
+	}
+	return 0
+}
+
+// runHistory prints every version of a body kept in the body store and what
+// changed between them, as in `go-solar-system history mars` or, for a body of
+// another system, `go-solar-system history proxima-centauri-b alpha-centauri`. It returns
+// the process exit code: 1 if the store could not be read, 2 for bad usage.
+func runHistory(args []string, storePath string, out io.Writer) int {
+	if len(args) == 0 || len(args) > 2 {
+		fmt.Fprintln(out, "usage: go-solar-system history <id> [system]")
+		return 2
+	}
+	id, system := args[0], "solar-system"
+	if len(args) == 2 {
+		system = args[1]
+	}
+
+	store, code := openStore(storePath, out)
+	if store == nil {
+		return code
+	}
+	defer store.Close()
+
+	snapshots, err := store.History(system, id)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	if len(snapshots) == 0 {
+		fmt.Fprintf(out, "No versions of %s kept for %s - search for its id with `go-solar-system search`\n", id, system)
+		return 0
+	}
+
+	for i, snapshot := range snapshots {
+		fmt.Fprintf(out, "%s  %s\n", snapshot.Fetched.Local().Format("2006-01-02 15:04"), snapshot.Body.EnglishName)
+		if i == 0 {
+			fmt.Fprintln(out, "  first kept")
+			continue
+		}
+		changes := watch.Diff(snapshots[i-1].Body, snapshot.Body)
+		if len(changes) == 0 {
+			fmt.Fprintln(out, "  fields not shown here changed")
+		}
+		for _, change := range changes {
+			value := change.Old + " → " + change.New
+			switch {
+			case change.Old == "":
+				value = change.New
+			case change.New == "":
+				value = change.Old
+			}
+			fmt.Fprintf(out, "  %-20s %s\n", change.Field, value)
+		}
+	}
+	return 0
+}
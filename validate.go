@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/furan917/go-solar-system/internal/systems"
+	"github.com/furan917/go-solar-system/internal/systems/formats"
+)
+
+// runValidate checks each system file given to `go-solar-system validate` and
+// prints its issues with line numbers. It returns the process exit code: 1 if any
+// file has errors, 2 if none was given.
+func runValidate(paths []string, out io.Writer) int {
+	if len(paths) == 0 {
+		fmt.Fprintln(out, "usage: go-solar-system validate <file>...")
+		return 2
+	}
+
+	manager := systems.NewSystemManager("")
+	failed := false
+	for _, path := range paths {
+		issues, err := manager.CheckSystemFile(path)
+		if err != nil {
+			fmt.Fprintln(out, err)
+			failed = true
+			continue
+		}
+
+		errorCount, warningCount := 0, 0
+		for _, issue := range issues {
+			if issue.Severity == formats.SeverityError {
+				errorCount++
+			} else {
+				warningCount++
+			}
+			fmt.Fprintf(out, "%s: %s\n", path, issue)
+		}
+
+		switch {
+		case errorCount > 0:
+			failed = true
+			fmt.Fprintf(out, "%s: %s, %s\n", path, count(errorCount, "error"), count(warningCount, "warning"))
+		case warningCount > 0:
+			fmt.Fprintf(out, "%s: valid, %s\n", path, count(warningCount, "warning"))
+		default:
+			fmt.Fprintf(out, "%s: valid\n", path)
+		}
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// count writes n with the noun, pluralised when needed
+func count(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}